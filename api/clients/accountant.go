@@ -9,6 +9,7 @@ import (
 	"time"
 
 	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/meterer"
 )
@@ -18,6 +19,8 @@ var requiredQuorums = []uint8{0, 1}
 
 type Accountant interface {
 	AccountBlob(ctx context.Context, numSymbols uint64, quorums []uint8) (*commonpb.PaymentHeader, []byte, error)
+	// SetPaymentState initializes the accountant with the payment state of the account reported by the disperser.
+	SetPaymentState(paymentState *disperser_rpc.GetPaymentStateReply) error
 }
 
 var _ Accountant = &accountant{}
@@ -38,6 +41,9 @@ type accountant struct {
 
 	paymentSigner core.PaymentSigner
 	numBins       uint32
+
+	// store persists the local accounting, nil if the accountant is not persistent
+	store AccountantStore
 }
 
 type BinRecord struct {
@@ -46,9 +52,9 @@ type BinRecord struct {
 }
 
 func NewAccountant(reservation *core.ActiveReservation, onDemand *core.OnDemandPayment, reservationWindow uint32, pricePerSymbol uint32, minNumSymbols uint32, paymentSigner core.PaymentSigner, numBins uint32) *accountant {
-	//TODO: client storage; currently every instance starts fresh but on-chain or a small store makes more sense
-	// Also client is currently responsible for supplying network params, we need to add RPC in order to be automatic
-	// There's a subsequent PR that handles populating the accountant with on-chain state from the disperser
+	// Every instance created by NewAccountant starts fresh, see NewPersistentAccountant for an accountant which
+	// survives restarts. The network params supplied here are overridden by SetPaymentState, which populates the
+	// accountant with the on-chain state reported by the disperser.
 	binRecords := make([]BinRecord, numBins)
 	for i := range binRecords {
 		binRecords[i] = BinRecord{Index: uint32(i), Usage: 0}
@@ -68,6 +74,112 @@ func NewAccountant(reservation *core.ActiveReservation, onDemand *core.OnDemandP
 	return &a
 }
 
+// NewPersistentAccountant creates an accountant whose local accounting is saved to the store after every accounted
+// blob, and restored from it on creation. This lets the usage of the account survive restarts of the client.
+func NewPersistentAccountant(reservation *core.ActiveReservation, onDemand *core.OnDemandPayment, reservationWindow uint32, pricePerSymbol uint32, minNumSymbols uint32, paymentSigner core.PaymentSigner, numBins uint32, store AccountantStore) (*accountant, error) {
+	a := NewAccountant(reservation, onDemand, reservationWindow, pricePerSymbol, minNumSymbols, paymentSigner, numBins)
+	a.store = store
+
+	state, err := store.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load accountant state: %w", err)
+	}
+	if state != nil {
+		a.mergeState(state)
+	}
+	return a, nil
+}
+
+// SetPaymentState sets the on-chain payment parameters, reservation and deposit of the account to the ones reported
+// by the disperser, and merges the usage recorded by the disperser into the local accounting. This recovers the usage
+// of a client which lost its local state, or which shares its account with other clients. For each bin and for the
+// cumulative payment, the larger of the local and the disperser value is kept, since underestimating the usage gets
+// payments rejected by the disperser.
+func (a *accountant) SetPaymentState(paymentState *disperser_rpc.GetPaymentStateReply) error {
+	if paymentState == nil {
+		return fmt.Errorf("payment state cannot be nil")
+	}
+	params := paymentState.GetPaymentGlobalParams()
+	if params == nil {
+		return fmt.Errorf("payment global params cannot be nil")
+	}
+	if params.GetReservationWindow() == 0 {
+		return fmt.Errorf("reservation window must be positive")
+	}
+
+	// An account without a reservation gets an empty one, which has no bandwidth for any quorum.
+	reservation := &core.ActiveReservation{}
+	if paymentState.GetReservation() != nil {
+		pbReservation := paymentState.GetReservation()
+		reservation.SymbolsPerSec = pbReservation.GetSymbolsPerSecond()
+		reservation.StartTimestamp = pbReservation.GetStartTimestamp()
+		reservation.EndTimestamp = pbReservation.GetEndTimestamp()
+		reservation.QuorumNumbers = make([]uint8, len(pbReservation.GetQuorumNumbers()))
+		for i, quorum := range pbReservation.GetQuorumNumbers() {
+			reservation.QuorumNumbers[i] = uint8(quorum)
+		}
+		reservation.QuorumSplit = make([]byte, len(pbReservation.GetQuorumSplits()))
+		for i, split := range pbReservation.GetQuorumSplits() {
+			reservation.QuorumSplit[i] = byte(split)
+		}
+	}
+
+	binRecords := make([]BinRecord, len(paymentState.GetBinRecord()))
+	for i, record := range paymentState.GetBinRecord() {
+		binRecords[i] = BinRecord{
+			Index: record.GetIndex(),
+			Usage: record.GetUsage(),
+		}
+	}
+
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+	a.reservation = reservation
+	a.onDemand = &core.OnDemandPayment{
+		CumulativePayment: new(big.Int).SetBytes(paymentState.GetOnchainCumulativePayment()),
+	}
+	a.reservationWindow = params.GetReservationWindow()
+	a.pricePerSymbol = params.GetPricePerSymbol()
+	a.minNumSymbols = params.GetMinNumSymbols()
+	a.mergeState(&AccountantState{
+		BinRecords:        binRecords,
+		CumulativePayment: new(big.Int).SetBytes(paymentState.GetCumulativePayment()),
+	})
+	return a.persist()
+}
+
+// mergeState merges the state into the local accounting, keeping the larger usage of each bin and the larger
+// cumulative payment. The caller must hold the usage lock, unless the accountant isn't shared yet.
+func (a *accountant) mergeState(state *AccountantState) {
+	for _, record := range state.BinRecords {
+		relativeIndex := record.Index % a.numBins
+		current := a.binRecords[relativeIndex]
+		if current.Index < record.Index || (current.Index == record.Index && current.Usage < record.Usage) {
+			a.binRecords[relativeIndex] = record
+		}
+	}
+	if state.CumulativePayment != nil && state.CumulativePayment.Cmp(a.cumulativePayment) > 0 {
+		a.cumulativePayment = new(big.Int).Set(state.CumulativePayment)
+	}
+}
+
+// persist saves the local accounting to the store of the accountant, if any. The caller must hold the usage lock.
+func (a *accountant) persist() error {
+	if a.store == nil {
+		return nil
+	}
+	binRecords := make([]BinRecord, len(a.binRecords))
+	copy(binRecords, a.binRecords)
+	err := a.store.Save(&AccountantState{
+		BinRecords:        binRecords,
+		CumulativePayment: new(big.Int).Set(a.cumulativePayment),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to persist accountant state: %w", err)
+	}
+	return nil
+}
+
 // BlobPaymentInfo calculates and records payment information. The accountant
 // will attempt to use the active reservation first and check for quorum settings,
 // then on-demand if the reservation is not available. The returned values are
@@ -88,6 +200,9 @@ func (a *accountant) BlobPaymentInfo(ctx context.Context, numSymbols uint64, quo
 		if err := QuorumCheck(quorumNumbers, a.reservation.QuorumNumbers); err != nil {
 			return 0, big.NewInt(0), err
 		}
+		if err := a.persist(); err != nil {
+			return 0, big.NewInt(0), err
+		}
 		return currentBinIndex, big.NewInt(0), nil
 	}
 
@@ -98,6 +213,9 @@ func (a *accountant) BlobPaymentInfo(ctx context.Context, numSymbols uint64, quo
 		if err := QuorumCheck(quorumNumbers, a.reservation.QuorumNumbers); err != nil {
 			return 0, big.NewInt(0), err
 		}
+		if err := a.persist(); err != nil {
+			return 0, big.NewInt(0), err
+		}
 		return currentBinIndex, big.NewInt(0), nil
 	}
 
@@ -110,6 +228,9 @@ func (a *accountant) BlobPaymentInfo(ctx context.Context, numSymbols uint64, quo
 		if err := QuorumCheck(quorumNumbers, requiredQuorums); err != nil {
 			return 0, big.NewInt(0), err
 		}
+		if err := a.persist(); err != nil {
+			return 0, big.NewInt(0), err
+		}
 		return 0, a.cumulativePayment, nil
 	}
 	return 0, big.NewInt(0), fmt.Errorf("neither reservation nor on-demand payment is available")
@@ -0,0 +1,137 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+)
+
+// AccountantState is the local accounting of an accountant, which must survive restarts of the client: forgetting the
+// usage of the reservation bins over-spends the reservation, and forgetting the cumulative payment makes the
+// disperser reject on-demand payments for not being increasing.
+type AccountantState struct {
+	BinRecords        []BinRecord `json:"bin_records"`
+	CumulativePayment *big.Int    `json:"cumulative_payment"`
+}
+
+// AccountantStore persists the state of an accountant.
+type AccountantStore interface {
+	// Load returns the last saved state, or nil if no state has been saved yet.
+	Load() (*AccountantState, error)
+	// Save replaces the saved state.
+	Save(state *AccountantState) error
+}
+
+type fileAccountantStore struct {
+	path string
+}
+
+var _ AccountantStore = &fileAccountantStore{}
+
+// NewFileAccountantStore creates an AccountantStore which keeps the state in a JSON file at the given path.
+func NewFileAccountantStore(path string) (AccountantStore, error) {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create directory of accountant state file: %w", err)
+	}
+	return &fileAccountantStore{path: path}, nil
+}
+
+func (s *fileAccountantStore) Load() (*AccountantState, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read accountant state file: %w", err)
+	}
+	return unmarshalAccountantState(data)
+}
+
+// Save writes the state to a temporary file which is then renamed over the state file, so that a crash never leaves
+// a partially written state behind.
+func (s *fileAccountantStore) Save(state *AccountantState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal accountant state: %w", err)
+	}
+
+	tmpPath := s.path + ".tmp"
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create accountant state file: %w", err)
+	}
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	closeErr := file.Close()
+	if err != nil {
+		return fmt.Errorf("failed to write accountant state file: %w", err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("failed to close accountant state file: %w", closeErr)
+	}
+
+	err = os.Rename(tmpPath, s.path)
+	if err != nil {
+		return fmt.Errorf("failed to replace accountant state file: %w", err)
+	}
+	return nil
+}
+
+type kvAccountantStore struct {
+	store kvstore.Store[[]byte]
+	key   []byte
+}
+
+var _ AccountantStore = &kvAccountantStore{}
+
+// NewKVAccountantStore creates an AccountantStore which keeps the state of the account in a key-value store. Several
+// accounts can share the same store.
+func NewKVAccountantStore(store kvstore.Store[[]byte], accountID string) AccountantStore {
+	return &kvAccountantStore{
+		store: store,
+		key:   []byte("accountant-state-" + accountID),
+	}
+}
+
+func (s *kvAccountantStore) Load() (*AccountantState, error) {
+	data, err := s.store.Get(s.key)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accountant state: %w", err)
+	}
+	return unmarshalAccountantState(data)
+}
+
+func (s *kvAccountantStore) Save(state *AccountantState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal accountant state: %w", err)
+	}
+	err = s.store.Put(s.key, data)
+	if err != nil {
+		return fmt.Errorf("failed to put accountant state: %w", err)
+	}
+	return nil
+}
+
+func unmarshalAccountantState(data []byte) (*AccountantState, error) {
+	state := &AccountantState{}
+	err := json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal accountant state: %w", err)
+	}
+	if state.CumulativePayment == nil {
+		state.CumulativePayment = big.NewInt(0)
+	}
+	return state, nil
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/hex"
 	"math/big"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/auth"
 	"github.com/Layr-Labs/eigenda/core/meterer"
@@ -450,6 +453,231 @@ func TestQuorumCheck(t *testing.T) {
 	}
 }
 
+func TestPersistentAccountant(t *testing.T) {
+	reservation := &core.ActiveReservation{
+		SymbolsPerSec:  200,
+		StartTimestamp: 100,
+		EndTimestamp:   200,
+		QuorumSplit:    []byte{50, 50},
+		QuorumNumbers:  []uint8{0, 1},
+	}
+	onDemand := &core.OnDemandPayment{
+		CumulativePayment: big.NewInt(5000),
+	}
+	reservationWindow := uint32(5)
+	pricePerSymbol := uint32(1)
+	minNumSymbols := uint32(100)
+
+	privateKey1, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	paymentSigner, err := auth.NewPaymentSigner(hex.EncodeToString(privateKey1.D.Bytes()))
+	assert.NoError(t, err)
+
+	stores := map[string]func() AccountantStore{
+		"file": func() AccountantStore {
+			store, err := NewFileAccountantStore(filepath.Join(t.TempDir(), "state", "accountant.json"))
+			assert.NoError(t, err)
+			return store
+		},
+		"kvstore": func() AccountantStore {
+			return NewKVAccountantStore(mapstore.NewStore(), paymentSigner.GetAccountID())
+		},
+	}
+
+	for name, newStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			store := newStore()
+			ctx := context.Background()
+			quorums := []uint8{0, 1}
+
+			accountant, err := NewPersistentAccountant(reservation, onDemand, reservationWindow, pricePerSymbol, minNumSymbols, paymentSigner, numBins, store)
+			assert.NoError(t, err)
+
+			// Use the reservation, then pay on demand
+			_, _, err = accountant.AccountBlob(ctx, 500, quorums)
+			assert.NoError(t, err)
+			header, _, err := accountant.AccountBlob(ctx, 1500, quorums)
+			assert.NoError(t, err)
+			assert.Equal(t, big.NewInt(1500), core.ConvertPaymentHeader(header).CumulativePayment)
+
+			// A restarted accountant resumes from the persisted usage
+			restarted, err := NewPersistentAccountant(reservation, onDemand, reservationWindow, pricePerSymbol, minNumSymbols, paymentSigner, numBins, store)
+			assert.NoError(t, err)
+			assert.Equal(t, accountant.binRecords, restarted.binRecords)
+			assert.Equal(t, big.NewInt(1500), restarted.cumulativePayment)
+
+			header, _, err = restarted.AccountBlob(ctx, 1500, quorums)
+			assert.NoError(t, err)
+			assert.Equal(t, big.NewInt(3000), core.ConvertPaymentHeader(header).CumulativePayment)
+		})
+	}
+}
+
+func TestSetPaymentStateMergesUsage(t *testing.T) {
+	reservation := &core.ActiveReservation{
+		SymbolsPerSec:  200,
+		StartTimestamp: 100,
+		EndTimestamp:   200,
+		QuorumSplit:    []byte{50, 50},
+		QuorumNumbers:  []uint8{0, 1},
+	}
+	onDemand := &core.OnDemandPayment{
+		CumulativePayment: big.NewInt(5000),
+	}
+	reservationWindow := uint32(5)
+	pricePerSymbol := uint32(1)
+	minNumSymbols := uint32(100)
+
+	privateKey1, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	paymentSigner, err := auth.NewPaymentSigner(hex.EncodeToString(privateKey1.D.Bytes()))
+	assert.NoError(t, err)
+	store := NewKVAccountantStore(mapstore.NewStore(), paymentSigner.GetAccountID())
+	accountant, err := NewPersistentAccountant(reservation, onDemand, reservationWindow, pricePerSymbol, minNumSymbols, paymentSigner, numBins, store)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	quorums := []uint8{0, 1}
+	paymentGlobalParams := &disperser_rpc.PaymentGlobalParams{
+		MinNumSymbols:     minNumSymbols,
+		PricePerSymbol:    pricePerSymbol,
+		ReservationWindow: reservationWindow,
+	}
+	now := uint64(time.Now().Unix())
+	currentBinIndex := meterer.GetBinIndex(now, reservationWindow)
+
+	_, _, err = accountant.AccountBlob(ctx, 200, quorums)
+	assert.NoError(t, err)
+
+	// The disperser recorded more usage of the current bin than the client, and a larger cumulative payment
+	err = accountant.SetPaymentState(&disperser_rpc.GetPaymentStateReply{
+		PaymentGlobalParams: paymentGlobalParams,
+		BinRecord: []*disperser_rpc.BinRecord{
+			{Index: currentBinIndex, Usage: 900},
+			{Index: currentBinIndex - 1, Usage: 700},
+		},
+		CumulativePayment: big.NewInt(2000).Bytes(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(900), accountant.GetRelativeBinRecord(currentBinIndex).Usage)
+	assert.Equal(t, big.NewInt(2000), accountant.cumulativePayment)
+
+	// A stale disperser view doesn't roll back the local accounting
+	err = accountant.SetPaymentState(&disperser_rpc.GetPaymentStateReply{
+		PaymentGlobalParams: paymentGlobalParams,
+		BinRecord:           []*disperser_rpc.BinRecord{{Index: currentBinIndex, Usage: 100}},
+		CumulativePayment:   big.NewInt(1000).Bytes(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(900), accountant.GetRelativeBinRecord(currentBinIndex).Usage)
+	assert.Equal(t, big.NewInt(2000), accountant.cumulativePayment)
+
+	// The merged state is persisted
+	state, err := store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(2000), state.CumulativePayment)
+}
+
+func TestSetPaymentState(t *testing.T) {
+	privateKey1, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	paymentSigner, err := auth.NewPaymentSigner(hex.EncodeToString(privateKey1.D.Bytes()))
+	assert.NoError(t, err)
+	// The accountant is created without any knowledge of the account, and populated from the disperser
+	accountant := NewAccountant(&core.ActiveReservation{}, &core.OnDemandPayment{CumulativePayment: big.NewInt(0)}, 1, 0, 0, paymentSigner, numBins)
+
+	ctx := context.Background()
+	quorums := []uint8{0, 1}
+	reservationWindow := uint32(5)
+	currentBinIndex := meterer.GetBinIndex(uint64(time.Now().Unix()), reservationWindow)
+
+	err = accountant.SetPaymentState(&disperser_rpc.GetPaymentStateReply{
+		PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+			GlobalSymbolsPerSecond: 4096,
+			MinNumSymbols:          100,
+			PricePerSymbol:         1,
+			ReservationWindow:      reservationWindow,
+			OnDemandQuorumNumbers:  []uint32{0, 1},
+		},
+		BinRecord: []*disperser_rpc.BinRecord{
+			{Index: currentBinIndex, Usage: 900},
+		},
+		Reservation: &disperser_rpc.Reservation{
+			SymbolsPerSecond: 200,
+			StartTimestamp:   100,
+			EndTimestamp:     200,
+			QuorumNumbers:    []uint32{0, 1},
+			QuorumSplits:     []uint32{50, 50},
+		},
+		CumulativePayment:        big.NewInt(1000).Bytes(),
+		OnchainCumulativePayment: big.NewInt(5000).Bytes(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &core.ActiveReservation{
+		SymbolsPerSec:  200,
+		StartTimestamp: 100,
+		EndTimestamp:   200,
+		QuorumNumbers:  []uint8{0, 1},
+		QuorumSplit:    []byte{50, 50},
+	}, accountant.reservation)
+	assert.Equal(t, big.NewInt(5000), accountant.onDemand.CumulativePayment)
+	assert.Equal(t, reservationWindow, accountant.reservationWindow)
+	assert.Equal(t, uint32(1), accountant.pricePerSymbol)
+	assert.Equal(t, uint32(100), accountant.minNumSymbols)
+	assert.Equal(t, uint64(900), accountant.GetRelativeBinRecord(currentBinIndex).Usage)
+	assert.Equal(t, big.NewInt(1000), accountant.cumulativePayment)
+
+	// The reservation has 1000 symbols per bin and 900 were used by the account, so the next blob overflows
+	header, _, err := accountant.AccountBlob(ctx, 200, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, currentBinIndex, header.BinIndex)
+	assert.Equal(t, uint64(100), accountant.GetRelativeBinRecord(currentBinIndex+2).Usage)
+
+	// Further blobs are paid on-demand, on top of the payments made by the account before
+	header, _, err = accountant.AccountBlob(ctx, 200, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1200).Bytes(), header.CumulativePayment)
+
+	// Malformed payment states are rejected
+	err = accountant.SetPaymentState(nil)
+	assert.Error(t, err)
+	err = accountant.SetPaymentState(&disperser_rpc.GetPaymentStateReply{})
+	assert.Error(t, err)
+	err = accountant.SetPaymentState(&disperser_rpc.GetPaymentStateReply{
+		PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{},
+	})
+	assert.Error(t, err)
+}
+
+func TestSetPaymentStateWithoutReservation(t *testing.T) {
+	privateKey1, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	paymentSigner, err := auth.NewPaymentSigner(hex.EncodeToString(privateKey1.D.Bytes()))
+	assert.NoError(t, err)
+	accountant := NewAccountant(&core.ActiveReservation{}, &core.OnDemandPayment{CumulativePayment: big.NewInt(0)}, 1, 0, 0, paymentSigner, numBins)
+
+	err = accountant.SetPaymentState(&disperser_rpc.GetPaymentStateReply{
+		PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+			MinNumSymbols:     100,
+			PricePerSymbol:    2,
+			ReservationWindow: 5,
+		},
+		OnchainCumulativePayment: big.NewInt(500).Bytes(),
+	})
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	header, _, err := accountant.AccountBlob(ctx, 50, []uint8{0})
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(200).Bytes(), header.CumulativePayment)
+
+	// The deposit only covers one more blob
+	_, _, err = accountant.AccountBlob(ctx, 50, []uint8{0})
+	assert.NoError(t, err)
+	_, _, err = accountant.AccountBlob(ctx, 50, []uint8{0})
+	assert.ErrorContains(t, err, "neither reservation nor on-demand payment is available")
+}
+
 func mapRecordUsage(records []BinRecord) []uint64 {
 	return []uint64{records[0].Usage, records[1].Usage, records[2].Usage}
 }
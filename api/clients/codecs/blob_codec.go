@@ -26,6 +26,20 @@ func BlobEncodingVersionToCodec(version BlobEncodingVersion) (BlobCodec, error)
 	}
 }
 
+// CreateCodec creates the codec used to encode payloads into blobs and to decode them back. Unless point verification
+// mode is disabled, the payload is IFFTed after being encoded, so that points can be opened on the KZG commitment.
+func CreateCodec(payloadEncodingVersion BlobEncodingVersion, disablePointVerificationMode bool) (BlobCodec, error) {
+	lowLevelCodec, err := BlobEncodingVersionToCodec(payloadEncodingVersion)
+	if err != nil {
+		return nil, fmt.Errorf("create low level codec: %w", err)
+	}
+
+	if disablePointVerificationMode {
+		return NewNoIFFTCodec(lowLevelCodec), nil
+	}
+	return NewIFFTCodec(lowLevelCodec), nil
+}
+
 func GenericDecodeBlob(data []byte) ([]byte, error) {
 	if len(data) <= 32 {
 		return nil, fmt.Errorf("data is not of length greater than 32 bytes: %d", len(data))
@@ -0,0 +1,294 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"google.golang.org/grpc"
+)
+
+type DisperserClientV2Config struct {
+	Hostname          string
+	Port              string
+	UseSecureGrpcFlag bool
+}
+
+type DisperserClientV2 interface {
+	Close() error
+	// DisperseBlob disperses a blob to the given quorums. The payment for the blob is computed by the Accountant and
+	// the blob header is signed with the signer of the client. Returns the status of the blob and its blob key.
+	DisperseBlob(ctx context.Context, data []byte, blobVersion corev2.BlobVersion, quorums []core.QuorumID) (*dispv2.BlobStatus, corev2.BlobKey, error)
+	GetBlobStatus(ctx context.Context, blobKey corev2.BlobKey) (*disperser_rpc.BlobStatusReply, error)
+	GetBlobCommitment(ctx context.Context, data []byte) (*disperser_rpc.BlobCommitmentReply, error)
+	// GetPaymentState returns the payment state of the account of the signer of the client.
+	GetPaymentState(ctx context.Context) (*disperser_rpc.GetPaymentStateReply, error)
+	// PopulateAccountant initializes the Accountant of the client with the payment state of the account reported by
+	// the disperser. It should be called before the first dispersal.
+	PopulateAccountant(ctx context.Context) error
+}
+
+type disperserClientV2 struct {
+	config     *DisperserClientV2Config
+	signer     corev2.BlobRequestSigner
+	initOnce   sync.Once
+	initErr    error // the error of the initialization in initOnce, returned to every caller
+	conn       *grpc.ClientConn
+	client     disperser_rpc.DisperserClient
+	prover     encoding.Prover
+	accountant Accountant
+}
+
+var _ DisperserClientV2 = &disperserClientV2{}
+
+// NewDisperserClientV2 creates a client for the v2 disperser API. Like the v1 DisperserClient, it maintains a single
+// underlying grpc connection which is established lazily on the first method call.
+//
+// If prover is nil, the commitments of dispersed blobs are computed by the disperser via GetBlobCommitment, which
+// spares the caller from loading the SRS.
+//
+// DisperserClientV2 is safe to be used concurrently by multiple goroutines.
+func NewDisperserClientV2(config *DisperserClientV2Config, signer corev2.BlobRequestSigner, prover encoding.Prover, accountant Accountant) (*disperserClientV2, error) {
+	if config == nil {
+		return nil, api.NewErrorInvalidArg("config must be provided")
+	}
+	if config.Hostname == "" {
+		return nil, api.NewErrorInvalidArg("hostname must be provided")
+	}
+	if config.Port == "" {
+		return nil, api.NewErrorInvalidArg("port must be provided")
+	}
+	if signer == nil {
+		return nil, api.NewErrorInvalidArg("signer must be provided")
+	}
+	if accountant == nil {
+		return nil, api.NewErrorInvalidArg("accountant must be provided")
+	}
+
+	return &disperserClientV2{
+		config:     config,
+		signer:     signer,
+		prover:     prover,
+		accountant: accountant,
+		// conn and client are initialized lazily
+	}, nil
+}
+
+// Close closes the grpc connection to the disperser server.
+// It is thread safe and can be called multiple times.
+func (c *disperserClientV2) Close() error {
+	if c.conn != nil {
+		err := c.conn.Close()
+		c.conn = nil
+		c.client = nil
+		return err
+	}
+	return nil
+}
+
+func (c *disperserClientV2) DisperseBlob(
+	ctx context.Context,
+	data []byte,
+	blobVersion corev2.BlobVersion,
+	quorums []core.QuorumID,
+) (*dispv2.BlobStatus, corev2.BlobKey, error) {
+	err := c.initOnceGrpcConnection()
+	if err != nil {
+		return nil, [32]byte{}, api.NewErrorFailover(err)
+	}
+
+	if len(quorums) == 0 {
+		return nil, [32]byte{}, api.NewErrorInvalidArg("quorum numbers must be provided")
+	}
+	for _, q := range quorums {
+		if q > corev2.MaxQuorumID {
+			return nil, [32]byte{}, api.NewErrorInvalidArg(fmt.Sprintf("quorum number %d must be less than or equal to %d", q, corev2.MaxQuorumID))
+		}
+	}
+
+	// check every 32 bytes of data are within the valid range for a bn254 field element
+	_, err = rs.ToFrArray(data)
+	if err != nil {
+		return nil, [32]byte{}, api.NewErrorInvalidArg(
+			fmt.Sprintf("encountered an error to convert a 32-bytes into a valid field element, "+
+				"please use the correct format where every 32bytes(big-endian) is less than "+
+				"21888242871839275222246405745257275088548364400416034343698204186575808495617, %v", err))
+	}
+
+	// The v2 disperser authenticates the blob header against the account ID in the payment metadata, so the account
+	// ID of the blob request signer takes precedence over the one of the payment signer.
+	accountID, err := c.signer.GetAccountID()
+	if err != nil {
+		return nil, [32]byte{}, api.NewErrorInvalidArg(fmt.Sprintf("please configure signer key if you want to disperse blobs: %v", err))
+	}
+
+	symbolLength := encoding.GetBlobLength(uint(len(data)))
+	paymentHeader, _, err := c.accountant.AccountBlob(ctx, uint64(symbolLength), quorums)
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("error accounting blob: %w", err)
+	}
+	paymentMetadata := core.ConvertPaymentHeader(paymentHeader)
+	paymentMetadata.AccountID = accountID
+
+	blobCommitments, err := c.getBlobCommitments(ctx, data)
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     blobVersion,
+		BlobCommitments: blobCommitments,
+		QuorumNumbers:   quorums,
+		PaymentMetadata: *paymentMetadata,
+	}
+	sig, err := c.signer.SignBlobRequest(blobHeader)
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("error signing blob request: %w", err)
+	}
+	blobHeader.Signature = sig
+
+	blobHeaderProto, err := blobHeader.ToProtobuf()
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("error converting blob header to protobuf: %w", err)
+	}
+
+	reply, err := c.client.DisperseBlob(ctx, &disperser_rpc.DisperseBlobRequest{
+		Data:       data,
+		BlobHeader: blobHeaderProto,
+	})
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("error while calling DisperseBlob: %w", err)
+	}
+
+	blobStatus, err := dispv2.BlobStatusFromProtobuf(reply.GetResult())
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	blobKey, err := corev2.BytesToBlobKey(reply.GetBlobKey())
+	if err != nil {
+		return nil, [32]byte{}, fmt.Errorf("disperser returned an invalid blob key: %w", err)
+	}
+
+	return &blobStatus, blobKey, nil
+}
+
+// GetBlobStatus returns the status of a blob with the given blob key.
+func (c *disperserClientV2) GetBlobStatus(ctx context.Context, blobKey corev2.BlobKey) (*disperser_rpc.BlobStatusReply, error) {
+	err := c.initOnceGrpcConnection()
+	if err != nil {
+		return nil, api.NewErrorInternal(err.Error())
+	}
+
+	request := &disperser_rpc.BlobStatusRequest{
+		BlobKey: blobKey[:],
+	}
+	return c.client.GetBlobStatus(ctx, request)
+}
+
+// GetBlobCommitment is a utility method that calculates commitment for a blob payload.
+func (c *disperserClientV2) GetBlobCommitment(ctx context.Context, data []byte) (*disperser_rpc.BlobCommitmentReply, error) {
+	err := c.initOnceGrpcConnection()
+	if err != nil {
+		return nil, api.NewErrorInternal(err.Error())
+	}
+
+	request := &disperser_rpc.BlobCommitmentRequest{
+		Data: data,
+	}
+	return c.client.GetBlobCommitment(ctx, request)
+}
+
+// GetPaymentState returns the payment state of the account of the signer of the client. The request is signed so
+// that the disperser only reveals the state of an account to its owner.
+func (c *disperserClientV2) GetPaymentState(ctx context.Context) (*disperser_rpc.GetPaymentStateReply, error) {
+	err := c.initOnceGrpcConnection()
+	if err != nil {
+		return nil, api.NewErrorInternal(err.Error())
+	}
+
+	accountID, err := c.signer.GetAccountID()
+	if err != nil {
+		return nil, fmt.Errorf("error getting account ID: %w", err)
+	}
+
+	timestamp := uint64(time.Now().UnixNano())
+	signature, err := c.signer.SignPaymentStateRequest(timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("error signing payment state request: %w", err)
+	}
+
+	request := &disperser_rpc.GetPaymentStateRequest{
+		AccountId: accountID,
+		Signature: signature,
+		Timestamp: timestamp,
+	}
+	return c.client.GetPaymentState(ctx, request)
+}
+
+// PopulateAccountant initializes the Accountant of the client with the payment state of the account reported by the
+// disperser.
+func (c *disperserClientV2) PopulateAccountant(ctx context.Context) error {
+	paymentState, err := c.GetPaymentState(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting payment state for initializing accountant: %w", err)
+	}
+
+	err = c.accountant.SetPaymentState(paymentState)
+	if err != nil {
+		return fmt.Errorf("error setting payment state for accountant: %w", err)
+	}
+
+	return nil
+}
+
+// getBlobCommitments computes the commitments of the blob locally if the client has a prover, and asks the
+// disperser to compute them otherwise.
+func (c *disperserClientV2) getBlobCommitments(ctx context.Context, data []byte) (encoding.BlobCommitments, error) {
+	if c.prover != nil {
+		commitments, err := c.prover.GetCommitments(data)
+		if err != nil {
+			return encoding.BlobCommitments{}, fmt.Errorf("error getting blob commitments: %w", err)
+		}
+		return commitments, nil
+	}
+
+	reply, err := c.GetBlobCommitment(ctx, data)
+	if err != nil {
+		return encoding.BlobCommitments{}, fmt.Errorf("error getting blob commitments from disperser: %w", err)
+	}
+
+	commitments, err := encoding.BlobCommitmentsFromProtobuf(reply.GetBlobCommitment())
+	if err != nil {
+		return encoding.BlobCommitments{}, fmt.Errorf("disperser returned invalid blob commitments: %w", err)
+	}
+	return *commitments, nil
+}
+
+// initOnceGrpcConnection initializes the grpc connection and client if they are not already initialized.
+// If initialization fails, it caches the error and will return it on every subsequent call.
+func (c *disperserClientV2) initOnceGrpcConnection() error {
+	c.initOnce.Do(func() {
+		addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
+		dialOptions := getGrpcDialOptions(c.config.UseSecureGrpcFlag)
+		conn, err := grpc.Dial(addr, dialOptions...)
+		if err != nil {
+			c.initErr = err
+			return
+		}
+		c.conn = conn
+		c.client = disperser_rpc.NewDisperserClient(conn)
+	})
+	if c.initErr != nil {
+		return fmt.Errorf("initializing grpc connection: %w", c.initErr)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package clients_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	authv2 "github.com/Layr-Labs/eigenda/core/auth/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisperserClientV2FailedDial(t *testing.T) {
+	// A unix socket target with an authority is rejected when the connection is created.
+	config := &clients.DisperserClientV2Config{
+		Hostname: "unix://nohost",
+		Port:     "1",
+	}
+	signer := authv2.NewLocalBlobRequestSigner("0x000000000000000000000000000000000000000000000000000000000000abcd")
+	accountant := clients.NewAccountant(nil, nil, 0, 0, 0, nil, 0)
+	disperserClient, err := clients.NewDisperserClientV2(config, signer, nil, accountant)
+	require.NoError(t, err)
+
+	// Every call returns the error of the failed dial, not only the first one.
+	for i := 0; i < 2; i++ {
+		_, err = disperserClient.GetBlobStatus(context.Background(), corev2.BlobKey{})
+		require.ErrorContains(t, err, "initializing grpc connection")
+	}
+	_, err = disperserClient.GetBlobCommitment(context.Background(), []byte("test"))
+	require.ErrorContains(t, err, "initializing grpc connection")
+}
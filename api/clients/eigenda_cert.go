@@ -0,0 +1,54 @@
+package clients
+
+import (
+	"errors"
+	"fmt"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+)
+
+// EigenDACert contains everything needed to verify that a blob has been certified by EigenDA, and to retrieve it
+// from the relays or the operators of the network.
+type EigenDACert struct {
+	BlobKey         corev2.BlobKey
+	BlobCertificate *corev2.BlobCertificate
+	BatchHeader     *corev2.BatchHeader
+	BlobIndex       uint32
+	InclusionProof  []byte
+	Attestation     *disperser_rpc.Attestation
+}
+
+// NewEigenDACert builds an EigenDACert from the status reply of a certified blob.
+func NewEigenDACert(reply *disperser_rpc.BlobStatusReply) (*EigenDACert, error) {
+	if reply.GetStatus() != disperser_rpc.BlobStatus_CERTIFIED {
+		return nil, fmt.Errorf("blob is not certified, status: %s", reply.GetStatus())
+	}
+	signedBatch := reply.GetSignedBatch()
+	verificationInfo := reply.GetBlobVerificationInfo()
+	if signedBatch == nil || verificationInfo == nil {
+		return nil, errors.New("reply of certified blob is missing the signed batch or the blob verification info")
+	}
+
+	blobCertificate, err := corev2.BlobCertificateFromProtobuf(verificationInfo.GetBlobCertificate())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse blob certificate: %w", err)
+	}
+	blobKey, err := blobCertificate.BlobHeader.BlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute blob key: %w", err)
+	}
+	batchHeader, err := corev2.BatchHeaderFromProtobuf(signedBatch.GetHeader())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse batch header: %w", err)
+	}
+
+	return &EigenDACert{
+		BlobKey:         blobKey,
+		BlobCertificate: blobCertificate,
+		BatchHeader:     batchHeader,
+		BlobIndex:       verificationInfo.GetBlobIndex(),
+		InclusionProof:  verificationInfo.GetInclusionProof(),
+		Attestation:     signedBatch.GetNonSignerStakesAndSignature(),
+	}, nil
+}
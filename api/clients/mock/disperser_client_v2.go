@@ -0,0 +1,68 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDisperserClientV2 struct {
+	mock.Mock
+}
+
+var _ clients.DisperserClientV2 = (*MockDisperserClientV2)(nil)
+
+func NewMockDisperserClientV2() *MockDisperserClientV2 {
+	return &MockDisperserClientV2{}
+}
+
+func (c *MockDisperserClientV2) DisperseBlob(ctx context.Context, data []byte, blobVersion corev2.BlobVersion, quorums []core.QuorumID) (*dispv2.BlobStatus, corev2.BlobKey, error) {
+	args := c.Called(data, blobVersion, quorums)
+	var status *dispv2.BlobStatus
+	if args.Get(0) != nil {
+		status = (args.Get(0)).(*dispv2.BlobStatus)
+	}
+	return status, args.Get(1).(corev2.BlobKey), args.Error(2)
+}
+
+func (c *MockDisperserClientV2) GetBlobStatus(ctx context.Context, blobKey corev2.BlobKey) (*disperser_rpc.BlobStatusReply, error) {
+	args := c.Called(blobKey)
+	var reply *disperser_rpc.BlobStatusReply
+	if args.Get(0) != nil {
+		reply = (args.Get(0)).(*disperser_rpc.BlobStatusReply)
+	}
+	return reply, args.Error(1)
+}
+
+func (c *MockDisperserClientV2) GetBlobCommitment(ctx context.Context, data []byte) (*disperser_rpc.BlobCommitmentReply, error) {
+	args := c.Called(data)
+	var reply *disperser_rpc.BlobCommitmentReply
+	if args.Get(0) != nil {
+		reply = (args.Get(0)).(*disperser_rpc.BlobCommitmentReply)
+	}
+	return reply, args.Error(1)
+}
+
+func (c *MockDisperserClientV2) GetPaymentState(ctx context.Context) (*disperser_rpc.GetPaymentStateReply, error) {
+	args := c.Called()
+	var reply *disperser_rpc.GetPaymentStateReply
+	if args.Get(0) != nil {
+		reply = (args.Get(0)).(*disperser_rpc.GetPaymentStateReply)
+	}
+	return reply, args.Error(1)
+}
+
+func (c *MockDisperserClientV2) PopulateAccountant(ctx context.Context) error {
+	args := c.Called()
+	return args.Error(0)
+}
+
+func (c *MockDisperserClientV2) Close() error {
+	args := c.Called()
+	return args.Error(0)
+}
@@ -5,6 +5,8 @@ import (
 
 	"github.com/Layr-Labs/eigenda/api/clients"
 	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/stretchr/testify/mock"
 	"github.com/wealdtech/go-merkletree/v2"
 )
@@ -69,3 +71,23 @@ func (c *MockNodeClient) GetChunks(
 		Chunks:     chunks,
 	}
 }
+
+func (c *MockNodeClient) GetChunksV2(
+	ctx context.Context,
+	opID core.OperatorID,
+	opInfo *core.IndexedOperatorInfo,
+	blobKey corev2.BlobKey,
+	quorumID core.QuorumID,
+	chunksChan chan clients.RetrievedChunks,
+) {
+	args := c.Called(opID, opInfo, blobKey, quorumID)
+	var chunks []*encoding.Frame
+	if args.Get(0) != nil {
+		chunks = (args.Get(0)).([]*encoding.Frame)
+	}
+	chunksChan <- clients.RetrievedChunks{
+		OperatorID: opID,
+		Err:        args.Error(1),
+		Chunks:     chunks,
+	}
+}
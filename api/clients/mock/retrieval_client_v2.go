@@ -0,0 +1,29 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRetrievalClientV2 struct {
+	mock.Mock
+}
+
+var _ clients.RetrievalClientV2 = (*MockRetrievalClientV2)(nil)
+
+func NewRetrievalClientV2() *MockRetrievalClientV2 {
+	return &MockRetrievalClientV2{}
+}
+
+func (c *MockRetrievalClientV2) GetBlob(ctx context.Context, blobHeader *corev2.BlobHeader, referenceBlockNumber uint64, quorumID core.QuorumID) ([]byte, error) {
+	args := c.Called(blobHeader, referenceBlockNumber, quorumID)
+	var blob []byte
+	if args.Get(0) != nil {
+		blob = args.Get(0).([]byte)
+	}
+	return blob, args.Error(1)
+}
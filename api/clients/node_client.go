@@ -6,7 +6,9 @@ import (
 	"time"
 
 	grpcnode "github.com/Layr-Labs/eigenda/api/grpc/node"
+	grpcnodev2 "github.com/Layr-Labs/eigenda/api/grpc/node/v2"
 	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/wealdtech/go-merkletree/v2"
 	"google.golang.org/grpc"
@@ -22,6 +24,8 @@ type RetrievedChunks struct {
 type NodeClient interface {
 	GetBlobHeader(ctx context.Context, socket string, batchHeaderHash [32]byte, blobIndex uint32) (*core.BlobHeader, *merkletree.Proof, error)
 	GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan RetrievedChunks)
+	// GetChunksV2 retrieves the chunks of a v2 blob held by the operator for the given quorum.
+	GetChunksV2(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, blobKey corev2.BlobKey, quorumID core.QuorumID, chunksChan chan RetrievedChunks)
 }
 
 type client struct {
@@ -155,3 +159,65 @@ func (c client) GetChunks(
 		Chunks:     chunks,
 	}
 }
+
+func (c client) GetChunksV2(
+	ctx context.Context,
+	opID core.OperatorID,
+	opInfo *core.IndexedOperatorInfo,
+	blobKey corev2.BlobKey,
+	quorumID core.QuorumID,
+	chunksChan chan RetrievedChunks,
+) {
+	conn, err := grpc.Dial(
+		core.OperatorSocket(opInfo.Socket).GetRetrievalSocket(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		chunksChan <- RetrievedChunks{
+			OperatorID: opID,
+			Err:        err,
+			Chunks:     nil,
+		}
+		return
+	}
+	defer conn.Close()
+
+	n := grpcnodev2.NewRetrievalClient(conn)
+	nodeCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	request := &grpcnodev2.GetChunksRequest{
+		BlobKey:  blobKey[:],
+		QuorumId: uint32(quorumID),
+	}
+
+	reply, err := n.GetChunks(nodeCtx, request)
+	if err != nil {
+		chunksChan <- RetrievedChunks{
+			OperatorID: opID,
+			Err:        err,
+			Chunks:     nil,
+		}
+		return
+	}
+
+	// v2 nodes always serve chunks in the gnark format
+	chunks := make([]*encoding.Frame, len(reply.GetChunks()))
+	for i, data := range reply.GetChunks() {
+		chunk, err := new(encoding.Frame).DeserializeGnark(data)
+		if err != nil {
+			chunksChan <- RetrievedChunks{
+				OperatorID: opID,
+				Err:        err,
+				Chunks:     nil,
+			}
+			return
+		}
+		chunks[i] = chunk
+	}
+	chunksChan <- RetrievedChunks{
+		OperatorID: opID,
+		Err:        nil,
+		Chunks:     chunks,
+	}
+}
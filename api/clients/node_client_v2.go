@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"sync"
 
-	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
 	nodegrpc "github.com/Layr-Labs/eigenda/api/grpc/node/v2"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
@@ -51,24 +50,14 @@ func (c *nodeClientV2) StoreChunks(ctx context.Context, batch *corev2.Batch) (*c
 		return nil, err
 	}
 
-	blobCerts := make([]*commonpb.BlobCertificate, len(batch.BlobCertificates))
-	for i, cert := range batch.BlobCertificates {
-		var err error
-		blobCerts[i], err = cert.ToProtobuf()
-		if err != nil {
-			return nil, fmt.Errorf("failed to convert blob certificate to protobuf: %v", err)
-		}
+	batchProto, err := batch.ToProtobuf()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert batch to protobuf: %v", err)
 	}
 
 	// Call the gRPC method to store chunks
 	response, err := c.dispersalClient.StoreChunks(ctx, &nodegrpc.StoreChunksRequest{
-		Batch: &commonpb.Batch{
-			Header: &commonpb.BatchHeader{
-				BatchRoot:            batch.BatchHeader.BatchRoot[:],
-				ReferenceBlockNumber: batch.BatchHeader.ReferenceBlockNumber,
-			},
-			BlobCertificates: blobCerts,
-		},
+		Batch: batchProto,
 	})
 	if err != nil {
 		return nil, err
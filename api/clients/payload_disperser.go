@@ -0,0 +1,187 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+type PayloadDisperserConfig struct {
+	// BlobVersion is the version of the blobs dispersed by the PayloadDisperser
+	BlobVersion corev2.BlobVersion
+
+	// Quorums are the quorums the blobs are dispersed to
+	Quorums []core.QuorumID
+
+	// PayloadEncodingVersion is the version of the codec used to encode payloads into blobs
+	PayloadEncodingVersion codecs.BlobEncodingVersion
+
+	// DisablePointVerificationMode disables the IFFT of the encoded payload. When point verification mode is
+	// disabled, points cannot be opened on the commitment of the blob, which makes fraud proofs impossible.
+	DisablePointVerificationMode bool
+
+	// DisperseBlobTimeout is the timeout of the DisperseBlob call to the disperser
+	DisperseBlobTimeout time.Duration
+
+	// BlobCertifiedTimeout is the total amount of time the PayloadDisperser waits for a dispersed blob to be certified
+	BlobCertifiedTimeout time.Duration
+
+	// BlobStatusPollInterval is the amount of time to wait between two status queries of a dispersed blob
+	BlobStatusPollInterval time.Duration
+}
+
+func (c *PayloadDisperserConfig) CheckAndSetDefaults() error {
+	if len(c.Quorums) == 0 {
+		return errors.New("PayloadDisperserConfig.Quorums not set")
+	}
+	if c.DisperseBlobTimeout == 0 {
+		c.DisperseBlobTimeout = 30 * time.Second
+	}
+	if c.BlobCertifiedTimeout == 0 {
+		c.BlobCertifiedTimeout = 2 * time.Minute
+	}
+	if c.BlobStatusPollInterval == 0 {
+		c.BlobStatusPollInterval = time.Second
+	}
+	return nil
+}
+
+// PayloadDisperser disperses payloads to EigenDA v2 and waits for them to be certified.
+type PayloadDisperser interface {
+	// SendPayload encodes the payload into a blob, disperses it and polls its status until it is certified.
+	// Returns the EigenDACert of the certified blob.
+	SendPayload(ctx context.Context, payload []byte) (*EigenDACert, error)
+	Close() error
+}
+
+type payloadDisperser struct {
+	logger          logging.Logger
+	config          PayloadDisperserConfig
+	disperserClient DisperserClientV2
+	codec           codecs.BlobCodec
+}
+
+var _ PayloadDisperser = &payloadDisperser{}
+
+// NewPayloadDisperser creates a PayloadDisperser which turns the asynchronous dispersal API of the v2 disperser
+// (DisperseBlob + polling GetBlobStatus) into a synchronous one.
+func NewPayloadDisperser(logger logging.Logger, config PayloadDisperserConfig, disperserClient DisperserClientV2) (*payloadDisperser, error) {
+	err := config.CheckAndSetDefaults()
+	if err != nil {
+		return nil, err
+	}
+	if disperserClient == nil {
+		return nil, errors.New("disperser client must be provided")
+	}
+
+	codec, err := codecs.CreateCodec(config.PayloadEncodingVersion, config.DisablePointVerificationMode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &payloadDisperser{
+		logger:          logger.With("component", "PayloadDisperser"),
+		config:          config,
+		disperserClient: disperserClient,
+		codec:           codec,
+	}, nil
+}
+
+func (d *payloadDisperser) SendPayload(ctx context.Context, payload []byte) (*EigenDACert, error) {
+	if len(payload) == 0 {
+		return nil, api.NewErrorInvalidArg("payload must not be empty")
+	}
+
+	blob, err := d.codec.EncodeBlob(payload)
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to encode payload: %v", err))
+	}
+	blob = padToPowerOfTwoSymbols(blob)
+
+	disperseCtx, cancel := context.WithTimeout(ctx, d.config.DisperseBlobTimeout)
+	defer cancel()
+	blobStatus, blobKey, err := d.disperserClient.DisperseBlob(disperseCtx, blob, d.config.BlobVersion, d.config.Quorums)
+	if err != nil {
+		return nil, fmt.Errorf("error dispersing blob: %w", err)
+	}
+	d.logger.Info("Blob accepted by EigenDA disperser, now polling for status updates", "blobKey", blobKey.Hex(), "status", blobStatus.String())
+
+	return d.waitForCertification(ctx, blobKey)
+}
+
+// waitForCertification polls the status of the blob until it is certified, fails, or the BlobCertifiedTimeout
+// elapses.
+func (d *payloadDisperser) waitForCertification(ctx context.Context, blobKey corev2.BlobKey) (*EigenDACert, error) {
+	ctx, cancel := context.WithTimeout(ctx, d.config.BlobCertifiedTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(d.config.BlobStatusPollInterval)
+	defer ticker.Stop()
+
+	latestStatus := disperser_rpc.BlobStatus_UNKNOWN
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, api.NewErrorFailover(fmt.Errorf("timed out waiting for blob %s to be certified, latest status %s: %w",
+				blobKey.Hex(), latestStatus, ctx.Err()))
+		case <-ticker.C:
+			reply, err := d.disperserClient.GetBlobStatus(ctx, blobKey)
+			if err != nil {
+				d.logger.Warn("Unable to retrieve blob status, will retry", "blobKey", blobKey.Hex(), "err", err)
+				continue
+			}
+
+			if reply.GetStatus() != latestStatus {
+				d.logger.Debug("Blob status changed", "blobKey", blobKey.Hex(), "status", reply.GetStatus())
+				latestStatus = reply.GetStatus()
+			}
+
+			switch reply.GetStatus() {
+			case disperser_rpc.BlobStatus_QUEUED, disperser_rpc.BlobStatus_ENCODED:
+				continue
+			case disperser_rpc.BlobStatus_CERTIFIED:
+				cert, err := NewEigenDACert(reply)
+				if err != nil {
+					return nil, api.NewErrorInternal(fmt.Sprintf("disperser returned an invalid certificate for blob %s: %v", blobKey.Hex(), err))
+				}
+				if cert.BlobKey != blobKey {
+					return nil, api.NewErrorInternal(fmt.Sprintf("disperser returned the certificate of blob %s for blob %s", cert.BlobKey.Hex(), blobKey.Hex()))
+				}
+				return cert, nil
+			case disperser_rpc.BlobStatus_FAILED:
+				return nil, api.NewErrorInternal(fmt.Sprintf("blob dispersal (blobKey=%s) reached failed status. please resubmit the blob.", blobKey.Hex()))
+			case disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES:
+				// Some quorum failed to sign the blob, indicating that the whole network is having issues.
+				return nil, api.NewErrorFailover(fmt.Errorf("blob dispersal (blobKey=%s) failed with insufficient signatures. eigenda nodes are probably down", blobKey.Hex()))
+			default:
+				return nil, api.NewErrorInternal(fmt.Sprintf("blob dispersal (blobKey=%s) reached unknown status %s", blobKey.Hex(), reply.GetStatus()))
+			}
+		}
+	}
+}
+
+func (d *payloadDisperser) Close() error {
+	return d.disperserClient.Close()
+}
+
+// padToPowerOfTwoSymbols pads the blob with zeros so that its length in symbols is a power of 2, which is required
+// of v2 blobs. Trailing zeros don't alter the payload, since the codecs record the length of the payload.
+func padToPowerOfTwoSymbols(blob []byte) []byte {
+	numSymbols := encoding.NextPowerOf2(uint64(encoding.GetBlobLength(uint(len(blob)))))
+	paddedLength := numSymbols * encoding.BYTES_PER_SYMBOL
+	if uint64(len(blob)) == paddedLength {
+		return blob
+	}
+	padded := make([]byte, paddedLength)
+	copy(padded, blob)
+	return padded
+}
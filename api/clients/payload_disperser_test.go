@@ -0,0 +1,162 @@
+package clients_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/mock"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// makeTestCertificate encodes the payload into a blob and builds the blob certificate of the blob.
+func makeTestCertificate(t *testing.T, payload []byte, quorums []core.QuorumID) (*corev2.BlobCertificate, []byte) {
+	p, _, err := makeTestComponents()
+	require.NoError(t, err)
+
+	codec, err := codecs.CreateCodec(codecs.DefaultBlobEncoding, false)
+	require.NoError(t, err)
+	blob, err := codec.EncodeBlob(payload)
+	require.NoError(t, err)
+
+	commitments, err := p.GetCommitments(blob)
+	require.NoError(t, err)
+
+	return &corev2.BlobCertificate{
+		BlobHeader: &corev2.BlobHeader{
+			BlobVersion:     0,
+			BlobCommitments: commitments,
+			QuorumNumbers:   quorums,
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID:         "0x1234",
+				BinIndex:          0,
+				CumulativePayment: big.NewInt(100),
+			},
+			Signature: []byte{1, 2, 3},
+		},
+		RelayKeys: []corev2.RelayKey{0, 1},
+	}, blob
+}
+
+func makeCertifiedReply(t *testing.T, cert *corev2.BlobCertificate) *disperser_rpc.BlobStatusReply {
+	certProto, err := cert.ToProtobuf()
+	require.NoError(t, err)
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{1},
+		ReferenceBlockNumber: 100,
+	}
+	return &disperser_rpc.BlobStatusReply{
+		Status: disperser_rpc.BlobStatus_CERTIFIED,
+		SignedBatch: &disperser_rpc.SignedBatch{
+			Header:                      batchHeader.ToProtobuf(),
+			NonSignerStakesAndSignature: &disperser_rpc.Attestation{QuorumNumbers: []uint32{0, 1}},
+		},
+		BlobVerificationInfo: &disperser_rpc.BlobVerificationInfo{
+			BlobCertificate: certProto,
+			BlobIndex:       3,
+			InclusionProof:  []byte{4, 5, 6},
+		},
+	}
+}
+
+func newTestPayloadDisperser(t *testing.T, disperserClient clients.DisperserClientV2) clients.PayloadDisperser {
+	disperser, err := clients.NewPayloadDisperser(logging.NewNoopLogger(), clients.PayloadDisperserConfig{
+		Quorums:                []core.QuorumID{0, 1},
+		BlobCertifiedTimeout:   time.Second,
+		BlobStatusPollInterval: 10 * time.Millisecond,
+	}, disperserClient)
+	require.NoError(t, err)
+	return disperser
+}
+
+func TestSendPayload(t *testing.T) {
+	quorums := []core.QuorumID{0, 1}
+	cert, blob := makeTestCertificate(t, gettysburgAddressBytes, quorums)
+	blobKey, err := cert.BlobHeader.BlobKey()
+	require.NoError(t, err)
+
+	disperserClient := clientsmock.NewMockDisperserClientV2()
+	queued := dispv2.Queued
+	disperserClient.On("DisperseBlob", blob, corev2.BlobVersion(0), quorums).Return(&queued, blobKey, nil).Once()
+	disperserClient.On("GetBlobStatus", blobKey).Return(&disperser_rpc.BlobStatusReply{Status: disperser_rpc.BlobStatus_QUEUED}, nil).Once()
+	disperserClient.On("GetBlobStatus", blobKey).Return(nil, errors.New("transient error")).Once()
+	disperserClient.On("GetBlobStatus", blobKey).Return(&disperser_rpc.BlobStatusReply{Status: disperser_rpc.BlobStatus_ENCODED}, nil).Once()
+	disperserClient.On("GetBlobStatus", blobKey).Return(makeCertifiedReply(t, cert), nil).Once()
+
+	disperser := newTestPayloadDisperser(t, disperserClient)
+	eigenDACert, err := disperser.SendPayload(context.Background(), gettysburgAddressBytes)
+	require.NoError(t, err)
+
+	assert.Equal(t, blobKey, eigenDACert.BlobKey)
+	assert.Equal(t, cert.RelayKeys, eigenDACert.BlobCertificate.RelayKeys)
+	assert.Equal(t, uint64(100), eigenDACert.BatchHeader.ReferenceBlockNumber)
+	assert.Equal(t, uint32(3), eigenDACert.BlobIndex)
+	assert.Equal(t, []byte{4, 5, 6}, eigenDACert.InclusionProof)
+	assert.Equal(t, []uint32{0, 1}, eigenDACert.Attestation.GetQuorumNumbers())
+	disperserClient.AssertExpectations(t)
+}
+
+func TestSendPayloadFailures(t *testing.T) {
+	quorums := []core.QuorumID{0, 1}
+	cert, blob := makeTestCertificate(t, gettysburgAddressBytes, quorums)
+	blobKey, err := cert.BlobHeader.BlobKey()
+	require.NoError(t, err)
+	queued := dispv2.Queued
+
+	t.Run("empty payload", func(t *testing.T) {
+		disperser := newTestPayloadDisperser(t, clientsmock.NewMockDisperserClientV2())
+		_, err := disperser.SendPayload(context.Background(), []byte{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("dispersal rejected", func(t *testing.T) {
+		disperserClient := clientsmock.NewMockDisperserClientV2()
+		disperserClient.On("DisperseBlob", blob, corev2.BlobVersion(0), quorums).Return(nil, corev2.BlobKey{}, errors.New("rejected"))
+		disperser := newTestPayloadDisperser(t, disperserClient)
+		_, err := disperser.SendPayload(context.Background(), gettysburgAddressBytes)
+		assert.ErrorContains(t, err, "rejected")
+	})
+
+	t.Run("failed status", func(t *testing.T) {
+		disperserClient := clientsmock.NewMockDisperserClientV2()
+		disperserClient.On("DisperseBlob", blob, corev2.BlobVersion(0), quorums).Return(&queued, blobKey, nil)
+		disperserClient.On("GetBlobStatus", blobKey).Return(&disperser_rpc.BlobStatusReply{Status: disperser_rpc.BlobStatus_FAILED}, nil)
+		disperser := newTestPayloadDisperser(t, disperserClient)
+		_, err := disperser.SendPayload(context.Background(), gettysburgAddressBytes)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+
+	t.Run("insufficient signatures", func(t *testing.T) {
+		disperserClient := clientsmock.NewMockDisperserClientV2()
+		disperserClient.On("DisperseBlob", blob, corev2.BlobVersion(0), quorums).Return(&queued, blobKey, nil)
+		disperserClient.On("GetBlobStatus", blobKey).Return(&disperser_rpc.BlobStatusReply{Status: disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES}, nil)
+		disperser := newTestPayloadDisperser(t, disperserClient)
+		_, err := disperser.SendPayload(context.Background(), gettysburgAddressBytes)
+		var errFailover *api.ErrorFailover
+		assert.ErrorAs(t, err, &errFailover)
+	})
+
+	t.Run("certification timeout", func(t *testing.T) {
+		disperserClient := clientsmock.NewMockDisperserClientV2()
+		disperserClient.On("DisperseBlob", blob, corev2.BlobVersion(0), quorums).Return(&queued, blobKey, nil)
+		disperserClient.On("GetBlobStatus", mock.Anything).Return(&disperser_rpc.BlobStatusReply{Status: disperser_rpc.BlobStatus_ENCODED}, nil)
+		disperser := newTestPayloadDisperser(t, disperserClient)
+		_, err := disperser.SendPayload(context.Background(), gettysburgAddressBytes)
+		var errFailover *api.ErrorFailover
+		assert.ErrorAs(t, err, &errFailover)
+	})
+}
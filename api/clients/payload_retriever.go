@@ -0,0 +1,223 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+type PayloadRetrieverConfig struct {
+	// PayloadEncodingVersion is the version of the codec used to decode blobs into payloads
+	PayloadEncodingVersion codecs.BlobEncodingVersion
+
+	// DisablePointVerificationMode must match the setting of the PayloadDisperser which dispersed the payloads
+	DisablePointVerificationMode bool
+
+	// RelayTimeout is the timeout of a single GetBlob call to a relay
+	RelayTimeout time.Duration
+
+	// OperatorRetrievalTimeout is the timeout of the retrieval of a blob from the operators of a single quorum
+	OperatorRetrievalTimeout time.Duration
+}
+
+func (c *PayloadRetrieverConfig) CheckAndSetDefaults() error {
+	if c.RelayTimeout == 0 {
+		c.RelayTimeout = 5 * time.Second
+	}
+	if c.OperatorRetrievalTimeout == 0 {
+		c.OperatorRetrievalTimeout = 30 * time.Second
+	}
+	return nil
+}
+
+// PayloadRetriever retrieves the payloads dispersed by a PayloadDisperser.
+type PayloadRetriever interface {
+	// GetPayload retrieves the blob of the certificate and decodes it into the original payload. The blob is fetched
+	// from the relays of the certificate first, and reconstructed from the chunks of the operators if no relay
+	// serves it.
+	GetPayload(ctx context.Context, cert *EigenDACert) ([]byte, error)
+	Close() error
+}
+
+type payloadRetriever struct {
+	logger          logging.Logger
+	config          PayloadRetrieverConfig
+	relayClient     RelayClient
+	retrievalClient RetrievalClientV2
+	prover          encoding.Prover
+	codec           codecs.BlobCodec
+}
+
+var _ PayloadRetriever = &payloadRetriever{}
+
+// NewPayloadRetriever creates a PayloadRetriever.
+//
+// retrievalClient is optional. If it is nil, the payloads are only retrieved from the relays.
+//
+// prover is required if relayClient is provided: relays are not trusted, so the blobs they serve are checked against
+// the commitment of the certificate. Blobs reconstructed from the chunks of the operators are verified by the
+// retrieval client.
+func NewPayloadRetriever(
+	logger logging.Logger,
+	config PayloadRetrieverConfig,
+	relayClient RelayClient,
+	retrievalClient RetrievalClientV2,
+	prover encoding.Prover,
+) (*payloadRetriever, error) {
+	err := config.CheckAndSetDefaults()
+	if err != nil {
+		return nil, err
+	}
+	if relayClient == nil && retrievalClient == nil {
+		return nil, errors.New("at least one of relay client and retrieval client must be provided")
+	}
+	if relayClient != nil && prover == nil {
+		return nil, errors.New("a prover is required to verify the blobs served by the relays")
+	}
+
+	codec, err := codecs.CreateCodec(config.PayloadEncodingVersion, config.DisablePointVerificationMode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &payloadRetriever{
+		logger:          logger.With("component", "PayloadRetriever"),
+		config:          config,
+		relayClient:     relayClient,
+		retrievalClient: retrievalClient,
+		prover:          prover,
+		codec:           codec,
+	}, nil
+}
+
+func (r *payloadRetriever) GetPayload(ctx context.Context, cert *EigenDACert) ([]byte, error) {
+	if cert == nil || cert.BlobCertificate == nil || cert.BlobCertificate.BlobHeader == nil {
+		return nil, errors.New("certificate is missing the blob header")
+	}
+	blobHeader := cert.BlobCertificate.BlobHeader
+	blobKey, err := blobHeader.BlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute blob key: %w", err)
+	}
+	if blobKey != cert.BlobKey {
+		return nil, fmt.Errorf("blob key %s of certificate does not match its blob header %s", cert.BlobKey.Hex(), blobKey.Hex())
+	}
+
+	blob, err := r.getBlobFromRelays(ctx, blobKey, blobHeader, cert.BlobCertificate.RelayKeys)
+	if err != nil {
+		r.logger.Warn("failed to retrieve blob from relays, falling back to operators", "blobKey", blobKey.Hex(), "err", err)
+		if cert.BatchHeader == nil {
+			return nil, fmt.Errorf("certificate is missing the batch header, cannot retrieve blob from operators: %w", err)
+		}
+		blob, err = r.getBlobFromOperators(ctx, blobHeader, cert.BatchHeader.ReferenceBlockNumber)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	payload, err := r.codec.DecodeBlob(blob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode blob %s: %w", blobKey.Hex(), err)
+	}
+	return payload, nil
+}
+
+// getBlobFromRelays tries the relays of the certificate in random order, and returns the first blob that matches the
+// blob header.
+func (r *payloadRetriever) getBlobFromRelays(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	blobHeader *corev2.BlobHeader,
+	relayKeys []corev2.RelayKey,
+) ([]byte, error) {
+	if r.relayClient == nil {
+		return nil, errors.New("no relay client configured")
+	}
+	if len(relayKeys) == 0 {
+		return nil, errors.New("certificate has no relay keys")
+	}
+
+	var lastErr error
+	for _, i := range rand.Perm(len(relayKeys)) {
+		relayKey := relayKeys[i]
+		relayCtx, cancel := context.WithTimeout(ctx, r.config.RelayTimeout)
+		blob, err := r.relayClient.GetBlob(relayCtx, relayKey, blobKey)
+		cancel()
+		if err != nil {
+			r.logger.Warn("failed to get blob from relay", "blobKey", blobKey.Hex(), "relayKey", relayKey, "err", err)
+			lastErr = err
+			continue
+		}
+
+		err = r.verifyBlob(blob, blobHeader.BlobCommitments)
+		if err != nil {
+			r.logger.Warn("relay returned an invalid blob", "blobKey", blobKey.Hex(), "relayKey", relayKey, "err", err)
+			lastErr = err
+			continue
+		}
+		return blob, nil
+	}
+	return nil, fmt.Errorf("no relay returned a valid blob: %w", lastErr)
+}
+
+// getBlobFromOperators reconstructs the blob from the chunks of the operators, trying the quorums of the blob in turn.
+func (r *payloadRetriever) getBlobFromOperators(
+	ctx context.Context,
+	blobHeader *corev2.BlobHeader,
+	referenceBlockNumber uint64,
+) ([]byte, error) {
+	if r.retrievalClient == nil {
+		return nil, errors.New("no retrieval client configured")
+	}
+
+	var lastErr error
+	for _, quorumID := range blobHeader.QuorumNumbers {
+		retrievalCtx, cancel := context.WithTimeout(ctx, r.config.OperatorRetrievalTimeout)
+		blob, err := r.retrievalClient.GetBlob(retrievalCtx, blobHeader, referenceBlockNumber, quorumID)
+		cancel()
+		if err != nil {
+			r.logger.Warn("failed to retrieve blob from operators", "quorum", quorumID, "err", err)
+			lastErr = err
+			continue
+		}
+		return blob, nil
+	}
+	return nil, fmt.Errorf("failed to retrieve blob from the operators of quorums %v: %w", blobHeader.QuorumNumbers, lastErr)
+}
+
+// verifyBlob checks that the length of the blob matches its commitments and that the blob is the one committed to.
+func (r *payloadRetriever) verifyBlob(blob []byte, commitments encoding.BlobCommitments) error {
+	if len(blob) == 0 {
+		return errors.New("blob is empty")
+	}
+	if uint(len(blob)) > commitments.Length*encoding.BYTES_PER_SYMBOL {
+		return fmt.Errorf("blob length %d bytes exceeds the committed length of %d symbols", len(blob), commitments.Length)
+	}
+	if r.prover == nil {
+		return errors.New("no prover configured, cannot verify blob")
+	}
+
+	computed, err := r.prover.GetCommitments(blob)
+	if err != nil {
+		return fmt.Errorf("failed to compute blob commitments: %w", err)
+	}
+	if commitments.Commitment == nil || !(*bn254.G1Affine)(computed.Commitment).Equal((*bn254.G1Affine)(commitments.Commitment)) {
+		return errors.New("blob does not match the commitment of the certificate")
+	}
+	return nil
+}
+
+func (r *payloadRetriever) Close() error {
+	if r.relayClient != nil {
+		return r.relayClient.Close()
+	}
+	return nil
+}
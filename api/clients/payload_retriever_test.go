@@ -0,0 +1,110 @@
+package clients_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func makeTestEigenDACert(t *testing.T, payload []byte) (*clients.EigenDACert, []byte) {
+	cert, blob := makeTestCertificate(t, payload, []core.QuorumID{0, 1})
+	blobKey, err := cert.BlobHeader.BlobKey()
+	require.NoError(t, err)
+	return &clients.EigenDACert{
+		BlobKey:         blobKey,
+		BlobCertificate: cert,
+		BatchHeader: &corev2.BatchHeader{
+			BatchRoot:            [32]byte{1},
+			ReferenceBlockNumber: 100,
+		},
+	}, blob
+}
+
+func TestGetPayloadFromRelay(t *testing.T) {
+	p, _, err := makeTestComponents()
+	require.NoError(t, err)
+	eigenDACert, blob := makeTestEigenDACert(t, gettysburgAddressBytes)
+
+	relayClient := clientsmock.NewRelayClient()
+	retrievalClient := clientsmock.NewRetrievalClientV2()
+	// The first relay tried fails, the other serves the blob
+	relayClient.On("GetBlob", eigenDACert.BlobKey).Return([]byte{}, errors.New("relay unavailable")).Once()
+	relayClient.On("GetBlob", eigenDACert.BlobKey).Return(blob, nil).Once()
+
+	retriever, err := clients.NewPayloadRetriever(logging.NewNoopLogger(), clients.PayloadRetrieverConfig{}, relayClient, retrievalClient, p)
+	require.NoError(t, err)
+
+	payload, err := retriever.GetPayload(context.Background(), eigenDACert)
+	require.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, payload)
+	relayClient.AssertExpectations(t)
+	retrievalClient.AssertNotCalled(t, "GetBlob", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetPayloadFallbackToOperators(t *testing.T) {
+	p, _, err := makeTestComponents()
+	require.NoError(t, err)
+	eigenDACert, blob := makeTestEigenDACert(t, gettysburgAddressBytes)
+	blobHeader := eigenDACert.BlobCertificate.BlobHeader
+
+	// The relays serve a blob which doesn't match the commitment of the certificate
+	tamperedBlob := make([]byte, len(blob))
+	copy(tamperedBlob, blob)
+	tamperedBlob[33] ^= 1
+	relayClient := clientsmock.NewRelayClient()
+	relayClient.On("GetBlob", eigenDACert.BlobKey).Return(tamperedBlob, nil)
+
+	// The operators of the first quorum fail, the ones of the second quorum serve the blob
+	retrievalClient := clientsmock.NewRetrievalClientV2()
+	retrievalClient.On("GetBlob", blobHeader, uint64(100), core.QuorumID(0)).Return(nil, errors.New("not enough chunks"))
+	retrievalClient.On("GetBlob", blobHeader, uint64(100), core.QuorumID(1)).Return(blob, nil)
+
+	retriever, err := clients.NewPayloadRetriever(logging.NewNoopLogger(), clients.PayloadRetrieverConfig{}, relayClient, retrievalClient, p)
+	require.NoError(t, err)
+
+	payload, err := retriever.GetPayload(context.Background(), eigenDACert)
+	require.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, payload)
+	relayClient.AssertNumberOfCalls(t, "GetBlob", 2)
+	retrievalClient.AssertExpectations(t)
+}
+
+func TestGetPayloadFailures(t *testing.T) {
+	p, _, err := makeTestComponents()
+	require.NoError(t, err)
+	eigenDACert, _ := makeTestEigenDACert(t, gettysburgAddressBytes)
+
+	t.Run("relays without prover", func(t *testing.T) {
+		_, err := clients.NewPayloadRetriever(logging.NewNoopLogger(), clients.PayloadRetrieverConfig{}, clientsmock.NewRelayClient(), nil, nil)
+		assert.ErrorContains(t, err, "prover is required")
+	})
+
+	t.Run("mismatched blob key", func(t *testing.T) {
+		retriever, err := clients.NewPayloadRetriever(logging.NewNoopLogger(), clients.PayloadRetrieverConfig{}, clientsmock.NewRelayClient(), nil, p)
+		require.NoError(t, err)
+		cert := *eigenDACert
+		cert.BlobKey = corev2.BlobKey{1}
+		_, err = retriever.GetPayload(context.Background(), &cert)
+		assert.ErrorContains(t, err, "does not match")
+	})
+
+	t.Run("no source serves the blob", func(t *testing.T) {
+		relayClient := clientsmock.NewRelayClient()
+		relayClient.On("GetBlob", eigenDACert.BlobKey).Return([]byte{}, errors.New("relay unavailable"))
+		retrievalClient := clientsmock.NewRetrievalClientV2()
+		retrievalClient.On("GetBlob", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("not enough chunks"))
+		retriever, err := clients.NewPayloadRetriever(logging.NewNoopLogger(), clients.PayloadRetrieverConfig{}, relayClient, retrievalClient, p)
+		require.NoError(t, err)
+		_, err = retriever.GetPayload(context.Background(), eigenDACert)
+		assert.ErrorContains(t, err, "not enough chunks")
+	})
+}
@@ -2,19 +2,31 @@ package clients
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	relaygrpc "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/relay/auth"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/hashicorp/go-multierror"
 	"google.golang.org/grpc"
 )
 
+// MessageSigner signs the given hash with the BLS key of the operator.
+type MessageSigner func(ctx context.Context, data [32]byte) (*core.Signature, error)
+
 type RelayClientConfig struct {
 	Sockets           map[corev2.RelayKey]string
 	UseSecureGrpcFlag bool
+	// OperatorID is the ID of the operator on whose behalf chunks are requested. Relays only serve chunks to
+	// requests signed by a registered operator, so GetChunksByRange and GetChunksByIndex fail if it is not set.
+	OperatorID *core.OperatorID
+	// MessageSigner signs GetChunks requests with the BLS key of the operator.
+	MessageSigner MessageSigner
 }
 
 type ChunkRequestByRange struct {
@@ -57,7 +69,7 @@ var _ RelayClient = (*relayClient)(nil)
 // NewRelayClient creates a new RelayClient that connects to the relays specified in the config.
 // It keeps a connection to each relay and reuses it for subsequent requests, and the connection is lazily instantiated.
 func NewRelayClient(config *RelayClientConfig, logger logging.Logger) (*relayClient, error) {
-	if config == nil || len(config.Sockets) > 0 {
+	if config == nil || len(config.Sockets) == 0 {
 		return nil, fmt.Errorf("invalid config: %v", config)
 	}
 
@@ -123,10 +135,13 @@ func (c *relayClient) GetChunksByRange(ctx context.Context, relayKey corev2.Rela
 			},
 		}
 	}
-	res, err := client.GetChunks(ctx, &relaygrpc.GetChunksRequest{
+	request := &relaygrpc.GetChunksRequest{
 		ChunkRequests: grpcRequests,
-	})
-
+	}
+	if err := c.signGetChunksRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	res, err := client.GetChunks(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -158,10 +173,13 @@ func (c *relayClient) GetChunksByIndex(ctx context.Context, relayKey corev2.Rela
 			},
 		}
 	}
-	res, err := client.GetChunks(ctx, &relaygrpc.GetChunksRequest{
+	request := &relaygrpc.GetChunksRequest{
 		ChunkRequests: grpcRequests,
-	})
-
+	}
+	if err := c.signGetChunksRequest(ctx, request); err != nil {
+		return nil, err
+	}
+	res, err := client.GetChunks(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -169,9 +187,31 @@ func (c *relayClient) GetChunksByIndex(ctx context.Context, relayKey corev2.Rela
 	return res.GetData(), nil
 }
 
+// signGetChunksRequest sets the requester ID of the request to the operator ID and the timestamp to the current time,
+// and signs the request.
+func (c *relayClient) signGetChunksRequest(ctx context.Context, request *relaygrpc.GetChunksRequest) error {
+	if c.config.OperatorID == nil || c.config.MessageSigner == nil {
+		return errors.New("operator ID and message signer are required to request chunks")
+	}
+
+	request.RequesterId = c.config.OperatorID[:]
+	request.Timestamp = uint64(time.Now().UnixNano())
+	hash := auth.HashGetChunksRequest(request)
+	signature, err := c.config.MessageSigner(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+	request.RequesterSignature = signature.Serialize()
+	return nil
+}
+
 func (c *relayClient) initOnceGrpcConnection(key corev2.RelayKey) error {
+	once, ok := c.initOnce[key]
+	if !ok {
+		return fmt.Errorf("unknown relay key: %v", key)
+	}
 	var initErr error
-	c.initOnce[key].Do(func() {
+	once.Do(func() {
 		socket, ok := c.config.Sockets[key]
 		if !ok {
 			initErr = fmt.Errorf("unknown relay key: %v", key)
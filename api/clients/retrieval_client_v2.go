@@ -0,0 +1,162 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gammazero/workerpool"
+)
+
+// RetrievalClientV2 is an object that can retrieve v2 blobs directly from the operators of the network,
+// without going through the relays.
+type RetrievalClientV2 interface {
+	// GetBlob downloads the chunks of a blob in the given quorum from the operators, verifies them against the
+	// commitments in the blob header and recombines them into the original blob.
+	GetBlob(ctx context.Context, blobHeader *corev2.BlobHeader, referenceBlockNumber uint64, quorumID core.QuorumID) ([]byte, error)
+}
+
+type retrievalClientV2 struct {
+	logger            logging.Logger
+	indexedChainState core.IndexedChainState
+	nodeClient        NodeClient
+	verifier          encoding.Verifier
+	numConnections    int
+}
+
+var _ RetrievalClientV2 = &retrievalClientV2{}
+
+// NewRetrievalClientV2 creates a new retrieval client for v2 blobs.
+func NewRetrievalClientV2(
+	logger logging.Logger,
+	chainState core.IndexedChainState,
+	nodeClient NodeClient,
+	verifier encoding.Verifier,
+	numConnections int,
+) RetrievalClientV2 {
+	return &retrievalClientV2{
+		logger:            logger.With("component", "RetrievalClientV2"),
+		indexedChainState: chainState,
+		nodeClient:        nodeClient,
+		verifier:          verifier,
+		numConnections:    numConnections,
+	}
+}
+
+func (r *retrievalClientV2) GetBlob(
+	ctx context.Context,
+	blobHeader *corev2.BlobHeader,
+	referenceBlockNumber uint64,
+	quorumID core.QuorumID,
+) ([]byte, error) {
+	if blobHeader == nil {
+		return nil, errors.New("blob header is nil")
+	}
+	if !slices.Contains(blobHeader.QuorumNumbers, quorumID) {
+		return nil, fmt.Errorf("quorum %d is not one of the quorums of the blob %v", quorumID, blobHeader.QuorumNumbers)
+	}
+
+	blobKey, err := blobHeader.BlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob key: %w", err)
+	}
+
+	// Validate the blob length
+	err = r.verifier.VerifyBlobLength(blobHeader.BlobCommitments)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate the commitments are equivalent
+	err = r.verifier.VerifyCommitEquivalenceBatch([]encoding.BlobCommitments{blobHeader.BlobCommitments})
+	if err != nil {
+		return nil, err
+	}
+
+	indexedOperatorState, err := r.indexedChainState.GetIndexedOperatorState(ctx, uint(referenceBlockNumber), []core.QuorumID{quorumID})
+	if err != nil {
+		return nil, err
+	}
+	operators, ok := indexedOperatorState.Operators[quorumID]
+	if !ok {
+		return nil, fmt.Errorf("no quorum with ID: %d", quorumID)
+	}
+
+	assignments, err := corev2.GetAssignments(indexedOperatorState.OperatorState, blobHeader.BlobVersion, quorumID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignments: %w", err)
+	}
+
+	encodingParams, err := blobHeader.GetEncodingParams()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encoding params: %w", err)
+	}
+
+	// The blob can be reconstructed from any set of chunks covering its length.
+	requiredChunks := int(encoding.GetNumSys(uint64(blobHeader.BlobCommitments.Length), encodingParams.ChunkLength))
+
+	// Fetch chunks from all operators. The requests that are still in flight once enough chunks have been
+	// gathered are cancelled.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunksChan := make(chan RetrievedChunks, len(operators))
+	pool := workerpool.New(r.numConnections)
+	defer pool.Stop()
+	for opID := range operators {
+		opID := opID
+		opInfo := indexedOperatorState.IndexedOperators[opID]
+		pool.Submit(func() {
+			r.nodeClient.GetChunksV2(ctx, opID, opInfo, blobKey, quorumID, chunksChan)
+		})
+	}
+
+	var chunks []*encoding.Frame
+	var indices []encoding.ChunkNumber
+	for i := 0; i < len(operators) && len(chunks) < requiredChunks; i++ {
+		reply := <-chunksChan
+		if reply.Err != nil {
+			r.logger.Warn("failed to get chunks from operator", "operator", reply.OperatorID.Hex(), "err", reply.Err)
+			continue
+		}
+		assignment, ok := assignments[reply.OperatorID]
+		if !ok {
+			r.logger.Warn("received chunks from operator without assignment", "operator", reply.OperatorID.Hex())
+			continue
+		}
+		if len(reply.Chunks) != int(assignment.NumChunks) {
+			r.logger.Warn("number of chunks does not match assignment", "operator", reply.OperatorID.Hex(),
+				"chunks", len(reply.Chunks), "assigned", assignment.NumChunks)
+			continue
+		}
+
+		assignmentIndices := make([]encoding.ChunkNumber, assignment.NumChunks)
+		for j, index := range assignment.GetIndices() {
+			assignmentIndices[j] = encoding.ChunkNumber(index)
+		}
+		err = r.verifier.VerifyFrames(reply.Chunks, assignmentIndices, blobHeader.BlobCommitments, encodingParams)
+		if err != nil {
+			r.logger.Warn("failed to verify chunks from operator", "operator", reply.OperatorID.Hex(), "err", err)
+			continue
+		}
+
+		chunks = append(chunks, reply.Chunks...)
+		indices = append(indices, assignmentIndices...)
+	}
+
+	if len(chunks) < requiredChunks {
+		return nil, fmt.Errorf("retrieved %d valid chunks of blob %s from quorum %d, %d are required",
+			len(chunks), blobKey.Hex(), quorumID, requiredChunks)
+	}
+
+	return r.verifier.Decode(
+		chunks,
+		indices,
+		encodingParams,
+		uint64(blobHeader.BlobCommitments.Length)*encoding.BYTES_PER_SYMBOL)
+}
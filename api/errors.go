@@ -28,6 +28,16 @@ func NewErrorInvalidArg(msg string) error {
 	return newErrorGRPC(codes.InvalidArgument, msg)
 }
 
+// HTTP Mapping: 400 Bad Request
+func NewErrorFailedPrecondition(msg string) error {
+	return newErrorGRPC(codes.FailedPrecondition, msg)
+}
+
+// HTTP Mapping: 401 Unauthorized
+func NewErrorUnauthenticated(msg string) error {
+	return newErrorGRPC(codes.Unauthenticated, msg)
+}
+
 // HTTP Mapping: 404 Not Found
 func NewErrorNotFound(msg string) error {
 	return newErrorGRPC(codes.NotFound, msg)
@@ -53,6 +63,11 @@ func NewErrorUnimplemented() error {
 	return newErrorGRPC(codes.Unimplemented, "not implemented")
 }
 
+// HTTP Mapping: 503 Service Unavailable
+func NewErrorUnavailable(msg string) error {
+	return newErrorGRPC(codes.Unavailable, msg)
+}
+
 // HTTP Mapping: 504 Gateway Timeout
 func NewErrorDeadlineExceeded(msg string) error {
 	return newErrorGRPC(codes.DeadlineExceeded, "msg")
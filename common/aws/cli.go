@@ -17,6 +17,8 @@ var (
 	FragmentParallelismConstantFlagName = "aws.fragment-parallelism-constant"
 	FragmentReadTimeoutFlagName         = "aws.fragment-read-timeout"
 	FragmentWriteTimeoutFlagName        = "aws.fragment-write-timeout"
+	LocalS3PathFlagName                 = "aws.local-s3-path"
+	LocalDynamoDBPathFlagName           = "aws.local-dynamodb-path"
 )
 
 type ClientConfig struct {
@@ -43,6 +45,15 @@ type ClientConfig struct {
 	// FragmentWriteTimeout is used to bound the maximum time to wait for a single fragmented write.
 	// Default is 30 seconds.
 	FragmentWriteTimeout time.Duration
+
+	// LocalS3Path is a directory in which S3 objects are stored instead of S3. This is meant for running
+	// devnets and integration tests on a single machine. If empty, S3 is used.
+	LocalS3Path string
+	// LocalDynamoDBPath is a directory in which DynamoDB tables are stored in an embedded database instead of
+	// DynamoDB. The database can only be opened by one process at a time, so this is meant for tests and single
+	// process deployments. Processes which share tables must use DynamoDB, or DynamoDB Local through EndpointURL.
+	// If empty, DynamoDB is used.
+	LocalDynamoDBPath string
 }
 
 func ClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
@@ -109,6 +120,20 @@ func ClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
 			Value:    30 * time.Second,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "FRAGMENT_WRITE_TIMEOUT"),
 		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, LocalS3PathFlagName),
+			Usage:    "Store S3 objects in this local directory instead of S3, for devnets and tests. Each bucket is a subdirectory which must exist",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_LOCAL_S3_PATH"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, LocalDynamoDBPathFlagName),
+			Usage:    "Store DynamoDB tables in an embedded database in this local directory instead of DynamoDB, for tests and single process deployments. The tables are created on startup. The directory can only be used by one process at a time, so processes which share tables (e.g. the apiserver and the batcher) must use DynamoDB, or DynamoDB Local through the endpoint URL",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_LOCAL_DYNAMODB_PATH"),
+		},
 	}
 }
 
@@ -122,6 +147,8 @@ func ReadClientConfig(ctx *cli.Context, flagPrefix string) ClientConfig {
 		FragmentParallelismConstant: ctx.GlobalInt(common.PrefixFlag(flagPrefix, FragmentParallelismConstantFlagName)),
 		FragmentReadTimeout:         ctx.GlobalDuration(common.PrefixFlag(flagPrefix, FragmentReadTimeoutFlagName)),
 		FragmentWriteTimeout:        ctx.GlobalDuration(common.PrefixFlag(flagPrefix, FragmentWriteTimeoutFlagName)),
+		LocalS3Path:                 ctx.GlobalString(common.PrefixFlag(flagPrefix, LocalS3PathFlagName)),
+		LocalDynamoDBPath:           ctx.GlobalString(common.PrefixFlag(flagPrefix, LocalDynamoDBPathFlagName)),
 	}
 }
 
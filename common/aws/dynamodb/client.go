@@ -35,6 +35,8 @@ const (
 var (
 	once               sync.Once
 	clientRef          *client
+	localOnce          sync.Once
+	localClientRef     *LocalClient
 	ErrConditionFailed = errors.New("condition failed")
 )
 
@@ -47,6 +49,14 @@ type QueryResult struct {
 	LastEvaluatedKey Key
 }
 
+// TransactWrite is a write of a transaction: either a put of Item or, if DeleteKey is set, a delete of the item with
+// that key. If Condition is set, the transaction only succeeds if it holds for the item being replaced or deleted.
+type TransactWrite struct {
+	Item      Item
+	DeleteKey Key
+	Condition *expression.ConditionBuilder
+}
+
 type Client interface {
 	DeleteTable(ctx context.Context, tableName string) error
 	PutItem(ctx context.Context, tableName string, item Item) error
@@ -64,6 +74,7 @@ type Client interface {
 	QueryIndexWithPagination(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues, limit int32, exclusiveStartKey map[string]types.AttributeValue) (QueryResult, error)
 	DeleteItem(ctx context.Context, tableName string, key Key) error
 	DeleteItems(ctx context.Context, tableName string, keys []Key) ([]Key, error)
+	TransactWriteItems(ctx context.Context, tableName string, writes []TransactWrite) error
 	TableExists(ctx context.Context, name string) error
 }
 
@@ -74,7 +85,23 @@ type client struct {
 
 var _ Client = (*client)(nil)
 
-func NewClient(cfg commonaws.ClientConfig, logger logging.Logger) (*client, error) {
+// NewClient returns the DynamoDB client, or a LocalClient if cfg.LocalDynamoDBPath is set. Both are shared by all
+// the callers in a process.
+func NewClient(cfg commonaws.ClientConfig, logger logging.Logger) (Client, error) {
+	if cfg.LocalDynamoDBPath != "" {
+		var err error
+		localOnce.Do(func() {
+			localClientRef, err = NewLocalClient(cfg.LocalDynamoDBPath, logger)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if localClientRef == nil {
+			return nil, errors.New("local dynamodb client failed to initialize")
+		}
+		return localClientRef, nil
+	}
+
 	var err error
 	once.Do(func() {
 		createClient := func(service, region string, options ...interface{}) (aws.Endpoint, error) {
@@ -109,7 +136,20 @@ func NewClient(cfg commonaws.ClientConfig, logger logging.Logger) (*client, erro
 		dynamoClient := dynamodb.NewFromConfig(awsConfig)
 		clientRef = &client{dynamoClient: dynamoClient, logger: logger.With("component", "DynamodbClient")}
 	})
-	return clientRef, err
+	if err != nil {
+		return nil, err
+	}
+	return clientRef, nil
+}
+
+// EnsureTable creates the table described by the input if the client is a LocalClient. Tables in DynamoDB are
+// provisioned separately, so this is a no-op for other clients.
+func EnsureTable(ctx context.Context, c Client, input *dynamodb.CreateTableInput) error {
+	localClient, ok := c.(*LocalClient)
+	if !ok {
+		return nil
+	}
+	return localClient.CreateTable(ctx, input)
 }
 
 func (c *client) DeleteTable(ctx context.Context, tableName string) error {
@@ -287,56 +327,82 @@ func (c *client) GetItems(ctx context.Context, tableName string, keys []Key) ([]
 
 // QueryIndex returns all items in the index that match the given key
 func (c *client) QueryIndex(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues) ([]Item, error) {
-	response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
+	return queryPages(ctx, &dynamodb.QueryInput{
 		TableName:                 aws.String(tableName),
 		IndexName:                 aws.String(indexName),
 		KeyConditionExpression:    aws.String(keyCondition),
 		ExpressionAttributeValues: expAttributeValues,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return response.Items, nil
+	}, c.query)
 }
 
 // Query returns all items in the primary index that match the given expression
 func (c *client) Query(ctx context.Context, tableName string, keyCondition string, expAttributeValues ExpressionValues) ([]Item, error) {
-	response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
+	return queryPages(ctx, &dynamodb.QueryInput{
 		TableName:                 aws.String(tableName),
 		KeyConditionExpression:    aws.String(keyCondition),
 		ExpressionAttributeValues: expAttributeValues,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return response.Items, nil
+	}, c.query)
 }
 
-// QueryWithInput is a wrapper for the Query function that allows for a custom query input
+// QueryWithInput is a wrapper for the Query function that allows for a custom query input. All the matching items
+// are returned, or the first input.Limit of them if a limit is set.
 func (c *client) QueryWithInput(ctx context.Context, input *dynamodb.QueryInput) ([]Item, error) {
-	response, err := c.dynamoClient.Query(ctx, input)
-	if err != nil {
-		return nil, err
-	}
-	return response.Items, nil
+	return queryPages(ctx, input, c.query)
 }
 
 // QueryIndexCount returns the count of the items in the index that match the given key
 func (c *client) QueryIndexCount(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues) (int32, error) {
-	response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
+	return countPages(ctx, &dynamodb.QueryInput{
 		TableName:                 aws.String(tableName),
 		IndexName:                 aws.String(indexName),
 		KeyConditionExpression:    aws.String(keyCondition),
 		ExpressionAttributeValues: expAttributeValues,
 		Select:                    types.SelectCount,
-	})
-	if err != nil {
-		return 0, err
+	}, c.query)
+}
+
+func (c *client) query(ctx context.Context, input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	return c.dynamoClient.Query(ctx, input)
+}
+
+// queryPages runs a query page by page, following LastEvaluatedKey, until all the matching items are returned or,
+// if the input has a limit, until that many items are returned. A single page may hold fewer items than requested,
+// since DynamoDB stops reading after 1MB of data, and the limit of a page applies before its filter expression.
+func queryPages(ctx context.Context, input *dynamodb.QueryInput, query func(context.Context, *dynamodb.QueryInput) (*dynamodb.QueryOutput, error)) ([]Item, error) {
+	pageInput := *input
+	limit := aws.ToInt32(input.Limit)
+	items := make([]Item, 0)
+	for {
+		if limit > 0 {
+			pageInput.Limit = aws.Int32(limit - int32(len(items)))
+		}
+		output, err := query(ctx, &pageInput)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, output.Items...)
+		if len(output.LastEvaluatedKey) == 0 || (limit > 0 && int32(len(items)) >= limit) {
+			return items, nil
+		}
+		pageInput.ExclusiveStartKey = output.LastEvaluatedKey
 	}
+}
 
-	return response.Count, nil
+// countPages runs a count query page by page, and returns the total count of the matching items.
+func countPages(ctx context.Context, input *dynamodb.QueryInput, query func(context.Context, *dynamodb.QueryInput) (*dynamodb.QueryOutput, error)) (int32, error) {
+	pageInput := *input
+	var count int32
+	for {
+		output, err := query(ctx, &pageInput)
+		if err != nil {
+			return 0, err
+		}
+		count += output.Count
+		if len(output.LastEvaluatedKey) == 0 {
+			return count, nil
+		}
+		pageInput.ExclusiveStartKey = output.LastEvaluatedKey
+	}
 }
 
 // QueryIndexWithPagination returns all items in the index that match the given key
@@ -399,6 +465,56 @@ func (c *client) DeleteItems(ctx context.Context, tableName string, keys []Key)
 	return c.writeItems(ctx, tableName, keys, delete)
 }
 
+// TransactWriteItems applies the writes atomically: either all of them succeed, or none does. ErrConditionFailed is
+// returned if the condition of a write does not hold. An item may only be written once per transaction.
+func (c *client) TransactWriteItems(ctx context.Context, tableName string, writes []TransactWrite) error {
+	items := make([]types.TransactWriteItem, len(writes))
+	for i, w := range writes {
+		var condition *string
+		var names map[string]string
+		var values map[string]types.AttributeValue
+		if w.Condition != nil {
+			expr, err := expression.NewBuilder().WithCondition(*w.Condition).Build()
+			if err != nil {
+				return err
+			}
+			condition, names, values = expr.Condition(), expr.Names(), expr.Values()
+		}
+
+		if w.DeleteKey != nil {
+			items[i].Delete = &types.Delete{
+				TableName:                 aws.String(tableName),
+				Key:                       w.DeleteKey,
+				ConditionExpression:       condition,
+				ExpressionAttributeNames:  names,
+				ExpressionAttributeValues: values,
+			}
+		} else {
+			items[i].Put = &types.Put{
+				TableName:                 aws.String(tableName),
+				Item:                      w.Item,
+				ConditionExpression:       condition,
+				ExpressionAttributeNames:  names,
+				ExpressionAttributeValues: values,
+			}
+		}
+	}
+
+	_, err := c.dynamoClient.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{TransactItems: items})
+	var tce *types.TransactionCanceledException
+	if errors.As(err, &tce) {
+		for _, reason := range tce.CancellationReasons {
+			if aws.ToString(reason.Code) == "ConditionalCheckFailed" {
+				return ErrConditionFailed
+			}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write items to table %s: %w", tableName, err)
+	}
+	return nil
+}
+
 // writeItems writes items in batches of 25 items (which is a limit DynamoDB imposes)
 // update and delete operations are supported.
 // For update operation, requestItems is []Item.
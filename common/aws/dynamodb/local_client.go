@@ -0,0 +1,1118 @@
+package dynamodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+const (
+	// schemaPrefix prefixes the keys of table schemas in the store.
+	schemaPrefix byte = 's'
+	// itemPrefix prefixes the keys of items in the store.
+	itemPrefix byte = 'i'
+	// indexPrefix prefixes the keys of global secondary index entries in the store.
+	indexPrefix byte = 'x'
+)
+
+// keyAttribute is an attribute of the key schema of a table or of an index.
+type keyAttribute struct {
+	Name string
+	Type types.ScalarAttributeType
+}
+
+// keySchema is the key schema of a table or of a global secondary index.
+type keySchema struct {
+	HashKey keyAttribute
+	// RangeKey is nil if the key only has a hash key.
+	RangeKey *keyAttribute
+}
+
+// attributes returns the names of the key attributes.
+func (s keySchema) attributes() []string {
+	if s.RangeKey == nil {
+		return []string{s.HashKey.Name}
+	}
+	return []string{s.HashKey.Name, s.RangeKey.Name}
+}
+
+// tableSchema is the schema of a table of the local client, persisted with the items of the table.
+type tableSchema struct {
+	Name    string
+	Key     keySchema
+	Indexes map[string]keySchema
+}
+
+// LocalClient is a Client which stores tables in an embedded key-value store instead of DynamoDB.
+//
+// The store can only be opened by one process at a time, so the LocalClient is meant for tests and for components
+// which run in a single process. Components which share tables across processes, such as the apiserver and the
+// batcher of a disperser, must use DynamoDB, which can be emulated on a single machine by pointing the endpoint URL
+// at DynamoDB Local.
+//
+// Items are stored under their primary key, encoded such that the order of the keys in the store is the order in
+// which DynamoDB sorts the items of a partition. Global secondary indexes are maintained as separate entries which
+// are written atomically with the items they point to. Queries on an index are therefore strongly consistent, which
+// is stronger than the eventual consistency of DynamoDB.
+//
+// Tables must be created with CreateTable before they are used. Writes are serialized, which makes conditional
+// writes and increments atomic.
+type LocalClient struct {
+	store  kvstore.Store[[]byte]
+	logger logging.Logger
+
+	// schemaLock protects schemas.
+	schemaLock sync.RWMutex
+	schemas    map[string]*tableSchema
+
+	// writeLock serializes writes, so that the checks of conditional writes and the maintenance of indexes are
+	// not interleaved with other writes.
+	writeLock sync.Mutex
+}
+
+var _ Client = (*LocalClient)(nil)
+
+// ErrLocalStoreInUse is returned when the store of a LocalClient is already opened, by this or another process.
+var ErrLocalStoreInUse = errors.New("local dynamodb store is in use by another client")
+
+// NewLocalClient creates a LocalClient which stores its tables in a LevelDB database at the given path. Tables
+// created by a previous instance with the same path are available to the new instance. The database can only be
+// opened by one process at a time.
+func NewLocalClient(path string, logger logging.Logger) (*LocalClient, error) {
+	if path == "" {
+		return nil, errors.New("path must be provided")
+	}
+	store, err := leveldb.NewStore(logger, path)
+	if errors.Is(err, storage.ErrLocked) || errors.Is(err, syscall.EWOULDBLOCK) {
+		return nil, fmt.Errorf("%w: %s", ErrLocalStoreInUse, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open local dynamodb store at %s: %w", path, err)
+	}
+
+	c := &LocalClient{
+		store:   store,
+		logger:  logger.With("component", "LocalDynamodbClient"),
+		schemas: make(map[string]*tableSchema),
+	}
+	if err := c.loadSchemas(); err != nil {
+		_ = store.Shutdown()
+		return nil, err
+	}
+	return c, nil
+}
+
+// loadSchemas reads the schemas of the existing tables from the store.
+func (c *LocalClient) loadSchemas() error {
+	it, err := c.store.NewIterator([]byte{schemaPrefix})
+	if err != nil {
+		return err
+	}
+	defer it.Release()
+
+	for it.Next() {
+		schema := &tableSchema{}
+		if err := json.Unmarshal(it.Value(), schema); err != nil {
+			return fmt.Errorf("failed to decode table schema: %w", err)
+		}
+		c.schemas[schema.Name] = schema
+	}
+	return it.Error()
+}
+
+// Shutdown closes the underlying store. The client cannot be used afterwards.
+func (c *LocalClient) Shutdown() error {
+	return c.store.Shutdown()
+}
+
+// CreateTable creates a table with the key schema and the global secondary indexes of the input. Other settings,
+// such as the provisioned throughput, are ignored. Creating a table which already exists with the same schema is a
+// no-op, so that a service can create its tables every time it starts.
+func (c *LocalClient) CreateTable(ctx context.Context, input *dynamodb.CreateTableInput) error {
+	schema, err := parseTableSchema(input)
+	if err != nil {
+		return err
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if existing, ok := c.getSchemaIfExists(schema.Name); ok {
+		if reflect.DeepEqual(existing, schema) {
+			return nil
+		}
+		return &types.ResourceInUseException{
+			Message: aws.String(fmt.Sprintf("table %s already exists with a different schema", schema.Name)),
+		}
+	}
+
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	if err := c.store.Put(schemaKey(schema.Name), data); err != nil {
+		return fmt.Errorf("failed to create table %s: %w", schema.Name, err)
+	}
+
+	c.schemaLock.Lock()
+	c.schemas[schema.Name] = schema
+	c.schemaLock.Unlock()
+	return nil
+}
+
+// parseTableSchema extracts the schema of a table from the input of CreateTable.
+func parseTableSchema(input *dynamodb.CreateTableInput) (*tableSchema, error) {
+	if input == nil || aws.ToString(input.TableName) == "" {
+		return nil, errors.New("table name must be provided")
+	}
+	name := aws.ToString(input.TableName)
+	if len(input.LocalSecondaryIndexes) > 0 {
+		return nil, fmt.Errorf("table %s: local secondary indexes are not supported by the local client", name)
+	}
+
+	attributeTypes := make(map[string]types.ScalarAttributeType, len(input.AttributeDefinitions))
+	for _, definition := range input.AttributeDefinitions {
+		attributeTypes[aws.ToString(definition.AttributeName)] = definition.AttributeType
+	}
+
+	key, err := parseKeySchema(input.KeySchema, attributeTypes)
+	if err != nil {
+		return nil, fmt.Errorf("table %s: %w", name, err)
+	}
+	schema := &tableSchema{
+		Name:    name,
+		Key:     key,
+		Indexes: make(map[string]keySchema, len(input.GlobalSecondaryIndexes)),
+	}
+	for _, index := range input.GlobalSecondaryIndexes {
+		indexName := aws.ToString(index.IndexName)
+		if indexName == "" {
+			return nil, fmt.Errorf("table %s: index name must be provided", name)
+		}
+		if _, ok := schema.Indexes[indexName]; ok {
+			return nil, fmt.Errorf("table %s: duplicate index %s", name, indexName)
+		}
+		if index.Projection != nil && index.Projection.ProjectionType != "" &&
+			index.Projection.ProjectionType != types.ProjectionTypeAll {
+			return nil, fmt.Errorf("table %s: index %s: only the ALL projection is supported by the local client",
+				name, indexName)
+		}
+		indexKey, err := parseKeySchema(index.KeySchema, attributeTypes)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: index %s: %w", name, indexName, err)
+		}
+		schema.Indexes[indexName] = indexKey
+	}
+	return schema, nil
+}
+
+func parseKeySchema(
+	elements []types.KeySchemaElement,
+	attributeTypes map[string]types.ScalarAttributeType,
+) (keySchema, error) {
+	if len(elements) == 0 || len(elements) > 2 {
+		return keySchema{}, fmt.Errorf("key schema must have one or two elements, got %d", len(elements))
+	}
+
+	attribute := func(element types.KeySchemaElement) (keyAttribute, error) {
+		name := aws.ToString(element.AttributeName)
+		attributeType, ok := attributeTypes[name]
+		if !ok {
+			return keyAttribute{}, fmt.Errorf("key attribute %s is not defined", name)
+		}
+		switch attributeType {
+		case types.ScalarAttributeTypeS, types.ScalarAttributeTypeN, types.ScalarAttributeTypeB:
+		default:
+			return keyAttribute{}, fmt.Errorf("key attribute %s has invalid type %q", name, attributeType)
+		}
+		return keyAttribute{Name: name, Type: attributeType}, nil
+	}
+
+	if elements[0].KeyType != types.KeyTypeHash {
+		return keySchema{}, errors.New("the first element of the key schema must be the hash key")
+	}
+	hashKey, err := attribute(elements[0])
+	if err != nil {
+		return keySchema{}, err
+	}
+	key := keySchema{HashKey: hashKey}
+	if len(elements) == 2 {
+		if elements[1].KeyType != types.KeyTypeRange {
+			return keySchema{}, errors.New("the second element of the key schema must be the range key")
+		}
+		rangeKey, err := attribute(elements[1])
+		if err != nil {
+			return keySchema{}, err
+		}
+		key.RangeKey = &rangeKey
+	}
+	return key, nil
+}
+
+func (c *LocalClient) DeleteTable(ctx context.Context, tableName string) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	if _, err := c.getSchema(tableName); err != nil {
+		return fmt.Errorf("failed to delete table %s: %w", tableName, err)
+	}
+
+	batch := c.store.NewBatch()
+	for _, prefix := range [][]byte{tablePrefix(itemPrefix, tableName), tablePrefix(indexPrefix, tableName)} {
+		it, err := c.store.NewIterator(prefix)
+		if err != nil {
+			return err
+		}
+		for it.Next() {
+			batch.Delete(append([]byte{}, it.Key()...))
+		}
+		err = it.Error()
+		it.Release()
+		if err != nil {
+			return fmt.Errorf("failed to delete table %s: %w", tableName, err)
+		}
+	}
+	batch.Delete(schemaKey(tableName))
+	if err := batch.Apply(); err != nil {
+		return fmt.Errorf("failed to delete table %s: %w", tableName, err)
+	}
+
+	// The builtin delete is shadowed by the batch operation of this package
+	c.schemaLock.Lock()
+	schemas := make(map[string]*tableSchema, len(c.schemas))
+	for name, schema := range c.schemas {
+		if name != tableName {
+			schemas[name] = schema
+		}
+	}
+	c.schemas = schemas
+	c.schemaLock.Unlock()
+	return nil
+}
+
+func (c *LocalClient) PutItem(ctx context.Context, tableName string, item Item) error {
+	err := c.putItem(tableName, item, nil)
+	if err != nil {
+		return fmt.Errorf("failed to put item in table %s: %w", tableName, err)
+	}
+	return nil
+}
+
+func (c *LocalClient) PutItemWithCondition(
+	ctx context.Context,
+	tableName string,
+	item Item,
+	condition string,
+	expressionAttributeNames map[string]string,
+	expressionAttributeValues map[string]types.AttributeValue,
+) error {
+	cond, err := parseCondition(condition, expressionAttributeNames, expressionAttributeValues)
+	if err != nil {
+		return err
+	}
+	err = c.putItem(tableName, item, cond)
+	if errors.Is(err, ErrConditionFailed) {
+		return ErrConditionFailed
+	}
+	if err != nil {
+		return fmt.Errorf("failed to put item in table %s: %w", tableName, err)
+	}
+	return nil
+}
+
+// PutItems puts the items one by one. Each put is atomic, but the batch as a whole is not.
+// Items are never left unprocessed, so the returned slice is always empty.
+func (c *LocalClient) PutItems(ctx context.Context, tableName string, items []Item) ([]Item, error) {
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := c.PutItem(ctx, tableName, item); err != nil {
+			return nil, err
+		}
+	}
+	return make([]Item, 0), nil
+}
+
+func (c *LocalClient) UpdateItem(ctx context.Context, tableName string, key Key, item Item) (Item, error) {
+	return c.updateItem(tableName, key, nil, func(existing Item) (Item, error) {
+		return setAttributes(existing, key, item), nil
+	})
+}
+
+func (c *LocalClient) UpdateItemWithCondition(
+	ctx context.Context,
+	tableName string,
+	key Key,
+	item Item,
+	condition expression.ConditionBuilder,
+) (Item, error) {
+	expr, err := expression.NewBuilder().WithCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+	cond, err := parseCondition(aws.ToString(expr.Condition()), expr.Names(), expr.Values())
+	if err != nil {
+		return nil, err
+	}
+	return c.updateItem(tableName, key, cond, func(existing Item) (Item, error) {
+		return setAttributes(existing, key, item), nil
+	})
+}
+
+// setAttributes sets the attributes of the item in the existing item, except for the key attributes.
+// Returns the attributes which were set.
+func setAttributes(existing Item, key Key, item Item) Item {
+	updated := make(Item)
+	for name, value := range item {
+		// Ignore primary key updates
+		if _, ok := key[name]; ok {
+			continue
+		}
+		existing[name] = value
+		updated[name] = value
+	}
+	return updated
+}
+
+// IncrementBy increments the attribute by the value for item that matches with the key. Like the ADD action of
+// DynamoDB, a missing attribute or item is treated as zero.
+func (c *LocalClient) IncrementBy(ctx context.Context, tableName string, key Key, attr string, value uint64) (Item, error) {
+	return c.updateItem(tableName, key, nil, func(existing Item) (Item, error) {
+		if _, ok := key[attr]; ok {
+			return nil, fmt.Errorf("cannot increment key attribute %s", attr)
+		}
+		current := "0"
+		if v, ok := existing[attr]; ok {
+			n, ok := v.(*types.AttributeValueMemberN)
+			if !ok {
+				return nil, fmt.Errorf("cannot increment attribute %s of type %T", attr, v)
+			}
+			current = n.Value
+		}
+		sum, err := addNumbers(current, strconv.FormatUint(value, 10))
+		if err != nil {
+			return nil, err
+		}
+		existing[attr] = &types.AttributeValueMemberN{Value: sum}
+		return Item{attr: &types.AttributeValueMemberN{Value: sum}}, nil
+	})
+}
+
+func (c *LocalClient) GetItem(ctx context.Context, tableName string, key Key) (Item, error) {
+	schema, err := c.getSchema(tableName)
+	if err != nil {
+		return nil, err
+	}
+	itemKey, err := schema.itemKey(key, true)
+	if err != nil {
+		return nil, err
+	}
+	return c.readItem(itemKey)
+}
+
+// GetItems returns the items for the given keys. Keys of missing items are skipped.
+func (c *LocalClient) GetItems(ctx context.Context, tableName string, keys []Key) ([]Item, error) {
+	items := make([]Item, 0, len(keys))
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		item, err := c.GetItem(ctx, tableName, key)
+		if err != nil {
+			return nil, err
+		}
+		if item != nil {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// QueryIndex returns all items in the index that match the given key
+func (c *LocalClient) QueryIndex(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues) ([]Item, error) {
+	return queryPages(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(tableName),
+		IndexName:                 aws.String(indexName),
+		KeyConditionExpression:    aws.String(keyCondition),
+		ExpressionAttributeValues: expAttributeValues,
+	}, c.query)
+}
+
+// Query returns all items in the primary index that match the given expression
+func (c *LocalClient) Query(ctx context.Context, tableName string, keyCondition string, expAttributeValues ExpressionValues) ([]Item, error) {
+	return queryPages(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(tableName),
+		KeyConditionExpression:    aws.String(keyCondition),
+		ExpressionAttributeValues: expAttributeValues,
+	}, c.query)
+}
+
+// QueryWithInput is a wrapper for the Query function that allows for a custom query input. All the matching items
+// are returned, or the first input.Limit of them if a limit is set.
+func (c *LocalClient) QueryWithInput(ctx context.Context, input *dynamodb.QueryInput) ([]Item, error) {
+	return queryPages(ctx, input, c.query)
+}
+
+// QueryIndexCount returns the count of the items in the index that match the given key
+func (c *LocalClient) QueryIndexCount(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues) (int32, error) {
+	return countPages(ctx, &dynamodb.QueryInput{
+		TableName:                 aws.String(tableName),
+		IndexName:                 aws.String(indexName),
+		KeyConditionExpression:    aws.String(keyCondition),
+		ExpressionAttributeValues: expAttributeValues,
+		Select:                    types.SelectCount,
+	}, c.query)
+}
+
+// QueryIndexWithPagination returns all items in the index that match the given key
+// Results are limited to the given limit and the pagination token is returned
+// When limit is 0, all items are returned
+func (c *LocalClient) QueryIndexWithPagination(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues, limit int32, exclusiveStartKey map[string]types.AttributeValue) (QueryResult, error) {
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(tableName),
+		IndexName:                 aws.String(indexName),
+		KeyConditionExpression:    aws.String(keyCondition),
+		ExpressionAttributeValues: expAttributeValues,
+		ExclusiveStartKey:         exclusiveStartKey,
+	}
+	if limit > 0 {
+		input.Limit = &limit
+	}
+
+	output, err := c.query(ctx, input)
+	if err != nil {
+		return QueryResult{}, err
+	}
+	if len(output.Items) == 0 {
+		return QueryResult{Items: nil, LastEvaluatedKey: nil}, nil
+	}
+	return QueryResult{
+		Items:            output.Items,
+		LastEvaluatedKey: output.LastEvaluatedKey,
+	}, nil
+}
+
+func (c *LocalClient) DeleteItem(ctx context.Context, tableName string, key Key) error {
+	schema, err := c.getSchema(tableName)
+	if err != nil {
+		return err
+	}
+	itemKey, err := schema.itemKey(key, true)
+	if err != nil {
+		return err
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	existing, err := c.readItem(itemKey)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+
+	batch := c.store.NewBatch()
+	if err := schema.deleteIndexEntries(batch, existing); err != nil {
+		return err
+	}
+	batch.Delete(itemKey)
+	return batch.Apply()
+}
+
+// DeleteItems deletes the items one by one. Keys are never left unprocessed, so the returned slice is always empty.
+func (c *LocalClient) DeleteItems(ctx context.Context, tableName string, keys []Key) ([]Key, error) {
+	for _, key := range keys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := c.DeleteItem(ctx, tableName, key); err != nil {
+			return nil, err
+		}
+	}
+	return make([]Key, 0), nil
+}
+
+// TransactWriteItems applies the writes atomically: either all of them succeed, or none does. ErrConditionFailed is
+// returned if the condition of a write does not hold. An item may only be written once per transaction.
+func (c *LocalClient) TransactWriteItems(ctx context.Context, tableName string, writes []TransactWrite) error {
+	schema, err := c.getSchema(tableName)
+	if err != nil {
+		return err
+	}
+
+	type write struct {
+		itemKey  []byte
+		cond     condition
+		existing Item
+	}
+	parsed := make([]write, len(writes))
+	seen := make(map[string]struct{}, len(writes))
+	for i, w := range writes {
+		if w.DeleteKey != nil {
+			parsed[i].itemKey, err = schema.itemKey(w.DeleteKey, true)
+		} else {
+			parsed[i].itemKey, err = schema.itemKey(w.Item, false)
+		}
+		if err != nil {
+			return err
+		}
+		if _, ok := seen[string(parsed[i].itemKey)]; ok {
+			return errors.New("transactions cannot write the same item more than once")
+		}
+		seen[string(parsed[i].itemKey)] = struct{}{}
+
+		if w.Condition != nil {
+			expr, err := expression.NewBuilder().WithCondition(*w.Condition).Build()
+			if err != nil {
+				return err
+			}
+			parsed[i].cond, err = parseCondition(aws.ToString(expr.Condition()), expr.Names(), expr.Values())
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	for i := range parsed {
+		parsed[i].existing, err = c.readItem(parsed[i].itemKey)
+		if err != nil {
+			return err
+		}
+		if parsed[i].cond != nil && !parsed[i].cond.evaluate(parsed[i].existing) {
+			return ErrConditionFailed
+		}
+	}
+
+	batch := c.store.NewBatch()
+	for i, w := range writes {
+		if parsed[i].existing != nil {
+			if err := schema.deleteIndexEntries(batch, parsed[i].existing); err != nil {
+				return err
+			}
+		}
+		if w.DeleteKey != nil {
+			batch.Delete(parsed[i].itemKey)
+			continue
+		}
+		if err := schema.putIndexEntries(batch, w.Item); err != nil {
+			return err
+		}
+		data, err := encodeItem(w.Item)
+		if err != nil {
+			return err
+		}
+		batch.Put(parsed[i].itemKey, data)
+	}
+	return batch.Apply()
+}
+
+// TableExists checks if a table exists
+func (c *LocalClient) TableExists(ctx context.Context, name string) error {
+	if name == "" {
+		return errors.New("table name is empty")
+	}
+	_, err := c.getSchema(name)
+	return err
+}
+
+// getSchemaIfExists returns the schema of a table and whether the table exists.
+func (c *LocalClient) getSchemaIfExists(tableName string) (*tableSchema, bool) {
+	c.schemaLock.RLock()
+	defer c.schemaLock.RUnlock()
+	schema, ok := c.schemas[tableName]
+	return schema, ok
+}
+
+// getSchema returns the schema of a table, or a ResourceNotFoundException like DynamoDB if it does not exist.
+func (c *LocalClient) getSchema(tableName string) (*tableSchema, error) {
+	schema, ok := c.getSchemaIfExists(tableName)
+	if !ok {
+		return nil, &types.ResourceNotFoundException{
+			Message: aws.String(fmt.Sprintf("table %s not found", tableName)),
+		}
+	}
+	return schema, nil
+}
+
+// readItem returns the item stored under the key, or nil if there is none.
+func (c *LocalClient) readItem(itemKey []byte) (Item, error) {
+	data, err := c.store.Get(itemKey)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeItem(data)
+}
+
+// putItem replaces the item with the same key, if the condition is satisfied by the existing item.
+func (c *LocalClient) putItem(tableName string, item Item, cond condition) error {
+	schema, err := c.getSchema(tableName)
+	if err != nil {
+		return err
+	}
+	itemKey, err := schema.itemKey(item, false)
+	if err != nil {
+		return err
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	existing, err := c.readItem(itemKey)
+	if err != nil {
+		return err
+	}
+	if cond != nil && !cond.evaluate(existing) {
+		return ErrConditionFailed
+	}
+	return c.writeItem(schema, itemKey, existing, item)
+}
+
+// updateItem applies the update to the item with the given key, if the condition is satisfied by the existing item.
+// Like DynamoDB, the item is created if it does not exist. The update modifies the item in place and returns the
+// attributes it updated.
+func (c *LocalClient) updateItem(
+	tableName string,
+	key Key,
+	cond condition,
+	update func(existing Item) (Item, error),
+) (Item, error) {
+	schema, err := c.getSchema(tableName)
+	if err != nil {
+		return nil, err
+	}
+	itemKey, err := schema.itemKey(key, true)
+	if err != nil {
+		return nil, err
+	}
+
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+
+	existing, err := c.readItem(itemKey)
+	if err != nil {
+		return nil, err
+	}
+	if cond != nil && !cond.evaluate(existing) {
+		return nil, ErrConditionFailed
+	}
+
+	item := make(Item)
+	for name, value := range existing {
+		item[name] = value
+	}
+	for name, value := range key {
+		item[name] = value
+	}
+	updated, err := update(item)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.writeItem(schema, itemKey, existing, item); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// writeItem atomically replaces the existing item, which may be nil, and its index entries. The caller must hold
+// the write lock.
+func (c *LocalClient) writeItem(schema *tableSchema, itemKey []byte, existing Item, item Item) error {
+	data, err := encodeItem(item)
+	if err != nil {
+		return err
+	}
+
+	batch := c.store.NewBatch()
+	if existing != nil {
+		if err := schema.deleteIndexEntries(batch, existing); err != nil {
+			return err
+		}
+	}
+	if err := schema.putIndexEntries(batch, item); err != nil {
+		return err
+	}
+	batch.Put(itemKey, data)
+	return batch.Apply()
+}
+
+// query implements the subset of the DynamoDB Query API used by the clients of this package: key conditions,
+// filter expressions, the sort direction, limits, pagination and counting.
+func (c *LocalClient) query(ctx context.Context, input *dynamodb.QueryInput) (*dynamodb.QueryOutput, error) {
+	if input == nil {
+		return nil, errors.New("query input must be provided")
+	}
+	if input.ProjectionExpression != nil || len(input.AttributesToGet) > 0 {
+		return nil, errors.New("projections are not supported by the local client")
+	}
+	if input.Select != "" && input.Select != types.SelectAllAttributes && input.Select != types.SelectCount {
+		return nil, fmt.Errorf("select %s is not supported by the local client", input.Select)
+	}
+
+	tableName := aws.ToString(input.TableName)
+	indexName := aws.ToString(input.IndexName)
+	schema, err := c.getSchema(tableName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := schema.keySchemaOf(indexName)
+	if err != nil {
+		return nil, err
+	}
+
+	keyCondition, err := parseCondition(
+		aws.ToString(input.KeyConditionExpression),
+		input.ExpressionAttributeNames,
+		input.ExpressionAttributeValues)
+	if err != nil {
+		return nil, err
+	}
+	hashValue, err := partitionOf(keyCondition, key)
+	if err != nil {
+		return nil, err
+	}
+	encodedHash, err := encodeKeyValue(hashValue, key.HashKey.Type)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value of hash key %s: %w", key.HashKey.Name, err)
+	}
+
+	var filter condition
+	if input.FilterExpression != nil {
+		filter, err = parseCondition(
+			aws.ToString(input.FilterExpression),
+			input.ExpressionAttributeNames,
+			input.ExpressionAttributeValues)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var startKey []byte
+	if input.ExclusiveStartKey != nil {
+		startKey, err = schema.entryKey(indexName, input.ExclusiveStartKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exclusive start key: %w", err)
+		}
+	}
+
+	limit := aws.ToInt32(input.Limit)
+	if limit < 0 {
+		return nil, fmt.Errorf("invalid limit %d", limit)
+	}
+	forward := aws.ToBool(input.ScanIndexForward) || input.ScanIndexForward == nil
+	countOnly := input.Select == types.SelectCount
+
+	entries, err := c.newEntryIterator(schema.partitionPrefix(indexName, encodedHash), startKey, forward)
+	if err != nil {
+		return nil, err
+	}
+	defer entries.Release()
+
+	output := &dynamodb.QueryOutput{
+		Items: make([]Item, 0),
+	}
+	var evaluated int32
+	var lastItem Item
+	for entries.Next() {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var item Item
+		if indexName == "" {
+			item, err = decodeItem(entries.Value())
+		} else {
+			// The value of an index entry is the key of the item it points to
+			item, err = c.readItem(entries.Value())
+		}
+		if err != nil {
+			return nil, err
+		}
+		if item == nil || !keyCondition.evaluate(item) {
+			// The item was updated or deleted after the iterator was created
+			continue
+		}
+
+		if limit > 0 && evaluated == limit {
+			// There are more matching items, so the caller needs a key to continue from
+			output.LastEvaluatedKey, err = schema.lastEvaluatedKey(indexName, lastItem)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+		evaluated++
+		lastItem = item
+
+		if filter != nil && !filter.evaluate(item) {
+			continue
+		}
+		output.Count++
+		if !countOnly {
+			output.Items = append(output.Items, item)
+		}
+	}
+	if err := entries.Error(); err != nil {
+		return nil, err
+	}
+	output.ScannedCount = evaluated
+	return output, nil
+}
+
+// partitionOf validates a key condition and returns the value of the hash key it selects. The key condition must be
+// an equality on the hash key, optionally combined with AND with a condition on the range key.
+func partitionOf(keyCondition condition, key keySchema) (types.AttributeValue, error) {
+	conjuncts := make([]condition, 0, 2)
+	var flatten func(c condition)
+	flatten = func(c condition) {
+		if a, ok := c.(*and); ok {
+			flatten(a.left)
+			flatten(a.right)
+			return
+		}
+		conjuncts = append(conjuncts, c)
+	}
+	flatten(keyCondition)
+
+	var hashValue types.AttributeValue
+	rangeConditions := 0
+	for _, c := range conjuncts {
+		if cmp, ok := c.(*comparison); ok && cmp.operator == "=" && cmp.left.name == key.HashKey.Name &&
+			cmp.right.name == "" && hashValue == nil {
+			hashValue = cmp.right.value
+			continue
+		}
+
+		var attribute operand
+		var args []operand
+		switch v := c.(type) {
+		case *comparison:
+			if v.operator == "<>" {
+				return nil, fmt.Errorf("unsupported operator %s in key condition", v.operator)
+			}
+			attribute, args = v.left, []operand{v.right}
+		case *between:
+			attribute, args = v.value, []operand{v.lower, v.upper}
+		case *function:
+			if v.name != "begins_with" {
+				return nil, fmt.Errorf("unsupported function %s in key condition", v.name)
+			}
+			attribute, args = v.args[0], v.args[1:]
+		default:
+			return nil, errors.New("key conditions only support AND of comparisons, BETWEEN and begins_with")
+		}
+		if key.RangeKey == nil || attribute.name != key.RangeKey.Name {
+			return nil, fmt.Errorf("key condition on %s, which is not the range key", attribute.name)
+		}
+		for _, arg := range args {
+			if arg.name != "" {
+				return nil, errors.New("key conditions must compare the range key to values")
+			}
+		}
+		rangeConditions++
+	}
+
+	if hashValue == nil {
+		return nil, fmt.Errorf("key condition must select the hash key %s with an equality", key.HashKey.Name)
+	}
+	if rangeConditions > 1 {
+		return nil, errors.New("key condition can have at most one condition on the range key")
+	}
+	return hashValue, nil
+}
+
+// newEntryIterator returns an iterator over the entries with the given prefix in ascending or descending order of
+// their keys, starting after a key which is excluded.
+func (c *LocalClient) newEntryIterator(prefix []byte, startKey []byte, forward bool) (iterator.Iterator, error) {
+	start := prefix
+	end := kvstore.PrefixEnd(prefix)
+	if startKey != nil {
+		if forward {
+			// The smallest key larger than the start key
+			start = append(append([]byte{}, startKey...), 0x00)
+		} else {
+			end = startKey
+		}
+	}
+	return c.store.NewRangeIterator(start, end, &kvstore.IteratorOptions{Reverse: !forward})
+}
+
+// schemaKey returns the key of the schema of a table.
+func schemaKey(tableName string) []byte {
+	return tablePrefix(schemaPrefix, tableName)
+}
+
+// tablePrefix returns the prefix of the keys of a kind of entries of a table.
+func tablePrefix(prefix byte, tableName string) []byte {
+	return append([]byte{prefix}, encodeBytes([]byte(tableName))...)
+}
+
+// keySchemaOf returns the key schema of the table if the index name is empty, and the key schema of the index
+// otherwise.
+func (s *tableSchema) keySchemaOf(indexName string) (keySchema, error) {
+	if indexName == "" {
+		return s.Key, nil
+	}
+	index, ok := s.Indexes[indexName]
+	if !ok {
+		return keySchema{}, fmt.Errorf("index %s not found in table %s", indexName, s.Name)
+	}
+	return index, nil
+}
+
+// encodeKey encodes the key attributes of the item for the given key schema. If exact is true, the item must only
+// contain the key attributes. Returns nil if the item lacks a key attribute and lacking attributes are allowed, which
+// is the case of items which are not indexed by a sparse index.
+func encodeKey(key keySchema, item map[string]types.AttributeValue, exact bool, allowMissing bool) ([]byte, error) {
+	if exact && len(item) != len(key.attributes()) {
+		return nil, fmt.Errorf("key must contain exactly the attributes %v", key.attributes())
+	}
+
+	encoded := make([]byte, 0)
+	for _, attribute := range []*keyAttribute{&key.HashKey, key.RangeKey} {
+		if attribute == nil {
+			continue
+		}
+		value, ok := item[attribute.Name]
+		if !ok {
+			if allowMissing {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("missing key attribute %s", attribute.Name)
+		}
+		encodedValue, err := encodeKeyValue(value, attribute.Type)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of key attribute %s: %w", attribute.Name, err)
+		}
+		if attribute.Type != types.ScalarAttributeTypeN && len(encodedValue) == 2 {
+			return nil, fmt.Errorf("key attribute %s must not be empty", attribute.Name)
+		}
+		encoded = append(encoded, encodedValue...)
+	}
+	return encoded, nil
+}
+
+// itemKey returns the key under which the item with the given primary key attributes is stored.
+func (s *tableSchema) itemKey(key map[string]types.AttributeValue, exact bool) ([]byte, error) {
+	encoded, err := encodeKey(s.Key, key, exact, false)
+	if err != nil {
+		return nil, err
+	}
+	return append(tablePrefix(itemPrefix, s.Name), encoded...), nil
+}
+
+// indexPrefix returns the prefix of the entries of an index.
+func (s *tableSchema) indexPrefix(indexName string) []byte {
+	return append(tablePrefix(indexPrefix, s.Name), encodeBytes([]byte(indexName))...)
+}
+
+// partitionPrefix returns the prefix of the entries of the table or index with the given encoded hash key.
+func (s *tableSchema) partitionPrefix(indexName string, encodedHash []byte) []byte {
+	if indexName == "" {
+		return append(tablePrefix(itemPrefix, s.Name), encodedHash...)
+	}
+	return append(s.indexPrefix(indexName), encodedHash...)
+}
+
+// indexEntryKey returns the key of the entry of the item in the index, or nil if the item is not indexed. The entry
+// key ends with the primary key of the item, which keeps the entries of items with the same index key apart.
+func (s *tableSchema) indexEntryKey(indexName string, item map[string]types.AttributeValue) ([]byte, error) {
+	encodedIndexKey, err := encodeKey(s.Indexes[indexName], item, false, true)
+	if err != nil || encodedIndexKey == nil {
+		return nil, err
+	}
+	encodedPrimaryKey, err := encodeKey(s.Key, item, false, false)
+	if err != nil {
+		return nil, err
+	}
+	entryKey := append(s.indexPrefix(indexName), encodedIndexKey...)
+	return append(entryKey, encodedPrimaryKey...), nil
+}
+
+// entryKey returns the key of the entry of the item in the table or in the index. It is used to resume a query
+// after the item.
+func (s *tableSchema) entryKey(indexName string, item map[string]types.AttributeValue) ([]byte, error) {
+	if indexName == "" {
+		return s.itemKey(item, false)
+	}
+	entryKey, err := s.indexEntryKey(indexName, item)
+	if err != nil {
+		return nil, err
+	}
+	if entryKey == nil {
+		return nil, fmt.Errorf("missing key attribute of index %s", indexName)
+	}
+	return entryKey, nil
+}
+
+// lastEvaluatedKey returns the key of the item from which a query on the table or the index can be resumed. Like
+// DynamoDB, it contains the primary key of the item, and the key of the index for a query on an index.
+func (s *tableSchema) lastEvaluatedKey(indexName string, item Item) (Key, error) {
+	names := s.Key.attributes()
+	if indexName != "" {
+		names = append(names, s.Indexes[indexName].attributes()...)
+	}
+	key := make(Key, len(names))
+	for _, name := range names {
+		value, ok := item[name]
+		if !ok {
+			return nil, fmt.Errorf("missing key attribute %s", name)
+		}
+		key[name] = value
+	}
+	return key, nil
+}
+
+// putIndexEntries adds the entries of the item in the indexes of the table to the batch.
+func (s *tableSchema) putIndexEntries(batch kvstore.Batch[[]byte], item Item) error {
+	itemKey, err := s.itemKey(item, false)
+	if err != nil {
+		return err
+	}
+	for indexName := range s.Indexes {
+		entryKey, err := s.indexEntryKey(indexName, item)
+		if err != nil {
+			return fmt.Errorf("index %s: %w", indexName, err)
+		}
+		if entryKey != nil {
+			batch.Put(entryKey, itemKey)
+		}
+	}
+	return nil
+}
+
+// deleteIndexEntries adds the deletion of the entries of the item in the indexes of the table to the batch.
+func (s *tableSchema) deleteIndexEntries(batch kvstore.Batch[[]byte], item Item) error {
+	for indexName := range s.Indexes {
+		entryKey, err := s.indexEntryKey(indexName, item)
+		if err != nil {
+			return fmt.Errorf("index %s: %w", indexName, err)
+		}
+		if entryKey != nil {
+			batch.Delete(entryKey)
+		}
+	}
+	return nil
+}
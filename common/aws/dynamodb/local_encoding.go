@@ -0,0 +1,369 @@
+package dynamodb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// storedValue is the JSON representation of an attribute value persisted by the local client. It mirrors the
+// DynamoDB JSON format, e.g. {"S": "foo"} or {"N": "42"}.
+type storedValue struct {
+	S    *string                 `json:"S,omitempty"`
+	N    *string                 `json:"N,omitempty"`
+	B    *[]byte                 `json:"B,omitempty"`
+	BOOL *bool                   `json:"BOOL,omitempty"`
+	NULL *bool                   `json:"NULL,omitempty"`
+	L    *[]storedValue          `json:"L,omitempty"`
+	M    *map[string]storedValue `json:"M,omitempty"`
+	SS   *[]string               `json:"SS,omitempty"`
+	NS   *[]string               `json:"NS,omitempty"`
+	BS   *[][]byte               `json:"BS,omitempty"`
+}
+
+func toStoredValue(value types.AttributeValue) (storedValue, error) {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return storedValue{S: &v.Value}, nil
+	case *types.AttributeValueMemberN:
+		if _, err := parseNumber(v.Value); err != nil {
+			return storedValue{}, err
+		}
+		return storedValue{N: &v.Value}, nil
+	case *types.AttributeValueMemberB:
+		data := append([]byte{}, v.Value...)
+		return storedValue{B: &data}, nil
+	case *types.AttributeValueMemberBOOL:
+		return storedValue{BOOL: &v.Value}, nil
+	case *types.AttributeValueMemberNULL:
+		return storedValue{NULL: &v.Value}, nil
+	case *types.AttributeValueMemberL:
+		list := make([]storedValue, len(v.Value))
+		for i, element := range v.Value {
+			stored, err := toStoredValue(element)
+			if err != nil {
+				return storedValue{}, err
+			}
+			list[i] = stored
+		}
+		return storedValue{L: &list}, nil
+	case *types.AttributeValueMemberM:
+		m := make(map[string]storedValue, len(v.Value))
+		for name, element := range v.Value {
+			stored, err := toStoredValue(element)
+			if err != nil {
+				return storedValue{}, err
+			}
+			m[name] = stored
+		}
+		return storedValue{M: &m}, nil
+	case *types.AttributeValueMemberSS:
+		set := append([]string{}, v.Value...)
+		return storedValue{SS: &set}, nil
+	case *types.AttributeValueMemberNS:
+		for _, n := range v.Value {
+			if _, err := parseNumber(n); err != nil {
+				return storedValue{}, err
+			}
+		}
+		set := append([]string{}, v.Value...)
+		return storedValue{NS: &set}, nil
+	case *types.AttributeValueMemberBS:
+		set := make([][]byte, len(v.Value))
+		for i, b := range v.Value {
+			set[i] = append([]byte{}, b...)
+		}
+		return storedValue{BS: &set}, nil
+	default:
+		return storedValue{}, fmt.Errorf("unsupported attribute value type %T", value)
+	}
+}
+
+func (v storedValue) toAttributeValue() (types.AttributeValue, error) {
+	switch {
+	case v.S != nil:
+		return &types.AttributeValueMemberS{Value: *v.S}, nil
+	case v.N != nil:
+		return &types.AttributeValueMemberN{Value: *v.N}, nil
+	case v.B != nil:
+		return &types.AttributeValueMemberB{Value: *v.B}, nil
+	case v.BOOL != nil:
+		return &types.AttributeValueMemberBOOL{Value: *v.BOOL}, nil
+	case v.NULL != nil:
+		return &types.AttributeValueMemberNULL{Value: *v.NULL}, nil
+	case v.L != nil:
+		list := make([]types.AttributeValue, len(*v.L))
+		for i, element := range *v.L {
+			value, err := element.toAttributeValue()
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return &types.AttributeValueMemberL{Value: list}, nil
+	case v.M != nil:
+		m := make(map[string]types.AttributeValue, len(*v.M))
+		for name, element := range *v.M {
+			value, err := element.toAttributeValue()
+			if err != nil {
+				return nil, err
+			}
+			m[name] = value
+		}
+		return &types.AttributeValueMemberM{Value: m}, nil
+	case v.SS != nil:
+		return &types.AttributeValueMemberSS{Value: *v.SS}, nil
+	case v.NS != nil:
+		return &types.AttributeValueMemberNS{Value: *v.NS}, nil
+	case v.BS != nil:
+		return &types.AttributeValueMemberBS{Value: *v.BS}, nil
+	default:
+		return nil, errors.New("stored attribute value has no type")
+	}
+}
+
+// encodeItem serializes an item to the format persisted by the local client.
+func encodeItem(item Item) ([]byte, error) {
+	stored := make(map[string]storedValue, len(item))
+	for name, value := range item {
+		v, err := toStoredValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of attribute %s: %w", name, err)
+		}
+		stored[name] = v
+	}
+	return json.Marshal(stored)
+}
+
+// decodeItem is the inverse of encodeItem. The returned item does not share memory with the data.
+func decodeItem(data []byte) (Item, error) {
+	var stored map[string]storedValue
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to decode item: %w", err)
+	}
+	item := make(Item, len(stored))
+	for name, v := range stored {
+		value, err := v.toAttributeValue()
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of attribute %s: %w", name, err)
+		}
+		item[name] = value
+	}
+	return item, nil
+}
+
+// number is a DynamoDB number in normalized form. Its value is 0.d1d2d3... * 10^exponent, negated if negative.
+// The digits have no leading or trailing zeros, and are empty for zero.
+type number struct {
+	negative bool
+	digits   string
+	exponent int
+}
+
+// maxNumberDigits is the maximum precision of a DynamoDB number.
+const maxNumberDigits = 38
+
+// parseNumber parses the string representation of a DynamoDB number, e.g. "42", "-1.5" or "1e10".
+func parseNumber(s string) (number, error) {
+	invalid := fmt.Errorf("invalid number: %q", s)
+
+	mantissa, exponentPart, hasExponent := strings.Cut(strings.ToLower(s), "e")
+	exponent := 0
+	if hasExponent {
+		e, err := strconv.Atoi(exponentPart)
+		if err != nil {
+			return number{}, invalid
+		}
+		exponent = e
+	}
+
+	n := number{}
+	if strings.HasPrefix(mantissa, "-") {
+		n.negative = true
+		mantissa = mantissa[1:]
+	} else if strings.HasPrefix(mantissa, "+") {
+		mantissa = mantissa[1:]
+	}
+
+	integerPart, fractionalPart, _ := strings.Cut(mantissa, ".")
+	if integerPart == "" && fractionalPart == "" {
+		return number{}, invalid
+	}
+	for _, c := range integerPart + fractionalPart {
+		if c < '0' || c > '9' {
+			return number{}, invalid
+		}
+	}
+
+	digits := integerPart + fractionalPart
+	exponent += len(integerPart)
+	trimmed := strings.TrimLeft(digits, "0")
+	exponent -= len(digits) - len(trimmed)
+	n.digits = strings.TrimRight(trimmed, "0")
+	if n.digits == "" {
+		return number{}, nil
+	}
+	if len(n.digits) > maxNumberDigits {
+		return number{}, fmt.Errorf("number %q exceeds the maximum precision of %d digits", s, maxNumberDigits)
+	}
+	if exponent < -130 || exponent > 126 {
+		return number{}, fmt.Errorf("number %q is out of range", s)
+	}
+	n.exponent = exponent
+	return n, nil
+}
+
+// encodeNumber returns a byte string whose lexicographic order is the numeric order of the numbers. No encoding is
+// a prefix of another, so the encoding of a number can be followed by other key components.
+func encodeNumber(n number) []byte {
+	if n.digits == "" {
+		return []byte{0x02}
+	}
+
+	magnitude := make([]byte, 0, len(n.digits)+3)
+	biasedExponent := uint16(n.exponent + 0x8000)
+	magnitude = append(magnitude, byte(biasedExponent>>8), byte(biasedExponent))
+	for _, c := range n.digits {
+		// Digits are shifted by one so that the terminator is smaller than any digit
+		magnitude = append(magnitude, byte(c-'0'+1))
+	}
+	magnitude = append(magnitude, 0x00)
+
+	if !n.negative {
+		return append([]byte{0x03}, magnitude...)
+	}
+	// A larger magnitude is a smaller negative number, so the bytes of the magnitude are inverted
+	for i := range magnitude {
+		magnitude[i] = ^magnitude[i]
+	}
+	return append([]byte{0x01}, magnitude...)
+}
+
+// addNumbers returns the sum of two DynamoDB numbers in their string representation.
+func addNumbers(a string, b string) (string, error) {
+	if _, err := parseNumber(a); err != nil {
+		return "", err
+	}
+	if _, err := parseNumber(b); err != nil {
+		return "", err
+	}
+	x, ok := new(big.Rat).SetString(a)
+	if !ok {
+		return "", fmt.Errorf("invalid number: %q", a)
+	}
+	y, ok := new(big.Rat).SetString(b)
+	if !ok {
+		return "", fmt.Errorf("invalid number: %q", b)
+	}
+	sum := new(big.Rat).Add(x, y)
+
+	var result string
+	if sum.IsInt() {
+		result = sum.Num().String()
+	} else {
+		// The sum of two decimals has finitely many fractional digits, bounded by the range of the exponent.
+		result = strings.TrimRight(sum.FloatString(maxNumberDigits+130), "0")
+	}
+	if _, err := parseNumber(result); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// encodeBytes returns an encoding of the data which preserves the lexicographic order and is not a prefix of the
+// encoding of any other data. Zero bytes are escaped as 0x00 0xFF, and the encoding is terminated by 0x00 0x01.
+func encodeBytes(data []byte) []byte {
+	encoded := make([]byte, 0, len(data)+2)
+	for _, b := range data {
+		if b == 0x00 {
+			encoded = append(encoded, 0x00, 0xFF)
+		} else {
+			encoded = append(encoded, b)
+		}
+	}
+	return append(encoded, 0x00, 0x01)
+}
+
+// encodeKeyValue encodes a key attribute of the given type, such that the lexicographic order of the encodings is the
+// order in which DynamoDB sorts the values.
+func encodeKeyValue(value types.AttributeValue, attributeType types.ScalarAttributeType) ([]byte, error) {
+	switch attributeType {
+	case types.ScalarAttributeTypeS:
+		if v, ok := value.(*types.AttributeValueMemberS); ok {
+			return encodeBytes([]byte(v.Value)), nil
+		}
+	case types.ScalarAttributeTypeN:
+		if v, ok := value.(*types.AttributeValueMemberN); ok {
+			n, err := parseNumber(v.Value)
+			if err != nil {
+				return nil, err
+			}
+			return encodeNumber(n), nil
+		}
+	case types.ScalarAttributeTypeB:
+		if v, ok := value.(*types.AttributeValueMemberB); ok {
+			return encodeBytes(v.Value), nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key attribute type %s", attributeType)
+	}
+	return nil, fmt.Errorf("expected a value of type %s, got %T", attributeType, value)
+}
+
+// compareValues compares two scalar values of the same type. The second return value is false if the values are not
+// comparable, i.e. if they are not both strings, numbers or binaries.
+func compareValues(a types.AttributeValue, b types.AttributeValue) (int, bool) {
+	switch x := a.(type) {
+	case *types.AttributeValueMemberS:
+		if y, ok := b.(*types.AttributeValueMemberS); ok {
+			return strings.Compare(x.Value, y.Value), true
+		}
+	case *types.AttributeValueMemberN:
+		if y, ok := b.(*types.AttributeValueMemberN); ok {
+			nx, err := parseNumber(x.Value)
+			if err != nil {
+				return 0, false
+			}
+			ny, err := parseNumber(y.Value)
+			if err != nil {
+				return 0, false
+			}
+			return bytes.Compare(encodeNumber(nx), encodeNumber(ny)), true
+		}
+	case *types.AttributeValueMemberB:
+		if y, ok := b.(*types.AttributeValueMemberB); ok {
+			return bytes.Compare(x.Value, y.Value), true
+		}
+	}
+	return 0, false
+}
+
+// valuesEqual returns true if two attribute values are equal.
+func valuesEqual(a types.AttributeValue, b types.AttributeValue) bool {
+	if c, ok := compareValues(a, b); ok {
+		return c == 0
+	}
+	x, err := toStoredValue(a)
+	if err != nil {
+		return false
+	}
+	y, err := toStoredValue(b)
+	if err != nil {
+		return false
+	}
+	xBytes, err := json.Marshal(x)
+	if err != nil {
+		return false
+	}
+	yBytes, err := json.Marshal(y)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(xBytes, yBytes)
+}
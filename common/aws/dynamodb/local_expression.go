@@ -0,0 +1,506 @@
+package dynamodb
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// This file implements the subset of the DynamoDB expression language used by the local client: comparisons,
+// BETWEEN, IN, AND, OR, NOT, parentheses and the functions attribute_exists, attribute_not_exists, begins_with and
+// contains. Attribute paths are limited to top level attributes.
+
+// condition is a parsed condition or key condition expression.
+type condition interface {
+	// evaluate returns true if the item satisfies the condition. A nil item has no attributes.
+	evaluate(item Item) bool
+}
+
+// operand is either an attribute of the item or a constant value.
+type operand struct {
+	// name is the name of the attribute, empty for a constant value
+	name  string
+	value types.AttributeValue
+}
+
+// resolve returns the value of the operand for the item, or nil if the attribute does not exist.
+func (o operand) resolve(item Item) types.AttributeValue {
+	if o.name == "" {
+		return o.value
+	}
+	return item[o.name]
+}
+
+type comparison struct {
+	operator string
+	left     operand
+	right    operand
+}
+
+func (c *comparison) evaluate(item Item) bool {
+	left := c.left.resolve(item)
+	right := c.right.resolve(item)
+	if left == nil || right == nil {
+		// Comparisons with missing attributes are false, except for inequality
+		return c.operator == "<>"
+	}
+
+	switch c.operator {
+	case "=":
+		return valuesEqual(left, right)
+	case "<>":
+		return !valuesEqual(left, right)
+	}
+
+	cmp, ok := compareValues(left, right)
+	if !ok {
+		return false
+	}
+	switch c.operator {
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	}
+	return false
+}
+
+type between struct {
+	value operand
+	lower operand
+	upper operand
+}
+
+func (b *between) evaluate(item Item) bool {
+	value := b.value.resolve(item)
+	lower := b.lower.resolve(item)
+	upper := b.upper.resolve(item)
+	if value == nil || lower == nil || upper == nil {
+		return false
+	}
+	lowerCmp, ok := compareValues(value, lower)
+	if !ok {
+		return false
+	}
+	upperCmp, ok := compareValues(value, upper)
+	if !ok {
+		return false
+	}
+	return lowerCmp >= 0 && upperCmp <= 0
+}
+
+type in struct {
+	value      operand
+	candidates []operand
+}
+
+func (i *in) evaluate(item Item) bool {
+	value := i.value.resolve(item)
+	if value == nil {
+		return false
+	}
+	for _, candidate := range i.candidates {
+		if c := candidate.resolve(item); c != nil && valuesEqual(value, c) {
+			return true
+		}
+	}
+	return false
+}
+
+type function struct {
+	name string
+	args []operand
+}
+
+func (f *function) evaluate(item Item) bool {
+	switch f.name {
+	case "attribute_exists":
+		return f.args[0].resolve(item) != nil
+	case "attribute_not_exists":
+		return f.args[0].resolve(item) == nil
+	case "begins_with":
+		value := f.args[0].resolve(item)
+		prefix := f.args[1].resolve(item)
+		switch v := value.(type) {
+		case *types.AttributeValueMemberS:
+			if p, ok := prefix.(*types.AttributeValueMemberS); ok {
+				return strings.HasPrefix(v.Value, p.Value)
+			}
+		case *types.AttributeValueMemberB:
+			if p, ok := prefix.(*types.AttributeValueMemberB); ok {
+				return strings.HasPrefix(string(v.Value), string(p.Value))
+			}
+		}
+		return false
+	case "contains":
+		value := f.args[0].resolve(item)
+		element := f.args[1].resolve(item)
+		if element == nil {
+			return false
+		}
+		switch v := value.(type) {
+		case *types.AttributeValueMemberS:
+			if e, ok := element.(*types.AttributeValueMemberS); ok {
+				return strings.Contains(v.Value, e.Value)
+			}
+		case *types.AttributeValueMemberB:
+			if e, ok := element.(*types.AttributeValueMemberB); ok {
+				return strings.Contains(string(v.Value), string(e.Value))
+			}
+		case *types.AttributeValueMemberL:
+			for _, member := range v.Value {
+				if valuesEqual(member, element) {
+					return true
+				}
+			}
+		case *types.AttributeValueMemberSS:
+			if e, ok := element.(*types.AttributeValueMemberS); ok {
+				for _, member := range v.Value {
+					if member == e.Value {
+						return true
+					}
+				}
+			}
+		case *types.AttributeValueMemberNS:
+			for _, member := range v.Value {
+				if valuesEqual(&types.AttributeValueMemberN{Value: member}, element) {
+					return true
+				}
+			}
+		case *types.AttributeValueMemberBS:
+			if e, ok := element.(*types.AttributeValueMemberB); ok {
+				for _, member := range v.Value {
+					if string(member) == string(e.Value) {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+	return false
+}
+
+type and struct {
+	left  condition
+	right condition
+}
+
+func (a *and) evaluate(item Item) bool {
+	return a.left.evaluate(item) && a.right.evaluate(item)
+}
+
+type or struct {
+	left  condition
+	right condition
+}
+
+func (o *or) evaluate(item Item) bool {
+	return o.left.evaluate(item) || o.right.evaluate(item)
+}
+
+type not struct {
+	operand condition
+}
+
+func (n *not) evaluate(item Item) bool {
+	return !n.operand.evaluate(item)
+}
+
+// functionArity is the number of arguments of each supported function.
+var functionArity = map[string]int{
+	"attribute_exists":     1,
+	"attribute_not_exists": 1,
+	"begins_with":          2,
+	"contains":             2,
+}
+
+// parseCondition parses a condition expression. Placeholders for names (#name) and values (:value) are resolved
+// using the given maps.
+func parseCondition(
+	expression string,
+	names map[string]string,
+	values map[string]types.AttributeValue,
+) (condition, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{
+		expression: expression,
+		tokens:     tokens,
+		names:      names,
+		values:     values,
+	}
+	c, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.position < len(p.tokens) {
+		return nil, p.errorf("unexpected token %q", p.tokens[p.position])
+	}
+	return c, nil
+}
+
+// tokenize splits an expression into names, placeholders, operators, parentheses and commas.
+func tokenize(expression string) ([]string, error) {
+	tokens := make([]string, 0)
+	runes := []rune(expression)
+	isNameRune := func(r rune) bool {
+		return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')' || r == ',' || r == '=':
+			tokens = append(tokens, string(r))
+			i++
+		case r == '<' || r == '>':
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '<' && runes[i+1] == '>')) {
+				tokens = append(tokens, string(runes[i:i+2]))
+				i += 2
+			} else {
+				tokens = append(tokens, string(r))
+				i++
+			}
+		case r == '#' || r == ':' || isNameRune(r):
+			start := i
+			i++
+			for i < len(runes) && isNameRune(runes[i]) {
+				i++
+			}
+			token := string(runes[start:i])
+			if token == "#" || token == ":" {
+				return nil, fmt.Errorf("invalid expression %q: empty placeholder", expression)
+			}
+			tokens = append(tokens, token)
+		default:
+			return nil, fmt.Errorf("invalid expression %q: unsupported character %q", expression, r)
+		}
+	}
+	return tokens, nil
+}
+
+type parser struct {
+	expression string
+	tokens     []string
+	position   int
+	names      map[string]string
+	values     map[string]types.AttributeValue
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid expression %q: %s", p.expression, fmt.Sprintf(format, args...))
+}
+
+// peek returns the next token, or an empty string at the end of the expression.
+func (p *parser) peek() string {
+	if p.position < len(p.tokens) {
+		return p.tokens[p.position]
+	}
+	return ""
+}
+
+// keyword returns true and consumes the next token if it is the given keyword. Keywords are case-insensitive.
+func (p *parser) keyword(keyword string) bool {
+	if strings.EqualFold(p.peek(), keyword) {
+		p.position++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(token string) error {
+	if p.peek() != token {
+		return p.errorf("expected %q, got %q", token, p.peek())
+	}
+	p.position++
+	return nil
+}
+
+func (p *parser) parseOr() (condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &or{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (condition, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &and{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (condition, error) {
+	if p.keyword("NOT") {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &not{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (condition, error) {
+	if p.peek() == "(" {
+		p.position++
+		c, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return c, nil
+	}
+
+	if arity, ok := functionArity[strings.ToLower(p.peek())]; ok && p.position+1 < len(p.tokens) && p.tokens[p.position+1] == "(" {
+		name := strings.ToLower(p.peek())
+		p.position += 2
+		args := make([]operand, 0, arity)
+		for len(args) < arity {
+			if len(args) > 0 {
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+			arg, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		if (name == "attribute_exists" || name == "attribute_not_exists") && args[0].name == "" {
+			return nil, p.errorf("the argument of %s must be an attribute", name)
+		}
+		return &function{name: name, args: args}, nil
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.keyword("BETWEEN"):
+		lower, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword("AND") {
+			return nil, p.errorf("expected AND in BETWEEN")
+		}
+		upper, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return &between{value: left, lower: lower, upper: upper}, nil
+	case p.keyword("IN"):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		candidates := make([]operand, 0)
+		for {
+			candidate, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, candidate)
+			if p.peek() != "," {
+				break
+			}
+			p.position++
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return &in{value: left, candidates: candidates}, nil
+	}
+
+	operator := p.peek()
+	switch operator {
+	case "=", "<>", "<", "<=", ">", ">=":
+		p.position++
+	default:
+		return nil, p.errorf("expected a comparison, got %q", operator)
+	}
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return &comparison{operator: operator, left: left, right: right}, nil
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	token := p.peek()
+	if token == "" {
+		return operand{}, p.errorf("unexpected end of expression")
+	}
+
+	switch {
+	case strings.HasPrefix(token, ":"):
+		value, ok := p.values[token]
+		if !ok {
+			return operand{}, p.errorf("value %s is not defined", token)
+		}
+		p.position++
+		return operand{value: value}, nil
+	case strings.HasPrefix(token, "#"):
+		name, ok := p.names[token]
+		if !ok {
+			return operand{}, p.errorf("name %s is not defined", token)
+		}
+		p.position++
+		return operand{name: name}, nil
+	case token == "(" || token == ")" || token == ",":
+		return operand{}, p.errorf("unexpected token %q", token)
+	default:
+		if isReservedWord(token) {
+			return operand{}, p.errorf("unexpected keyword %q", token)
+		}
+		r := []rune(token)[0]
+		if !unicode.IsLetter(r) && r != '_' {
+			return operand{}, p.errorf("invalid attribute name %q", token)
+		}
+		p.position++
+		return operand{name: token}, nil
+	}
+}
+
+// isReservedWord returns true for the keywords of the expression language, which cannot be used as attribute names.
+func isReservedWord(token string) bool {
+	switch strings.ToUpper(token) {
+	case "AND", "OR", "NOT", "BETWEEN", "IN":
+		return true
+	}
+	return false
+}
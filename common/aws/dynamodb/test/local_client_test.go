@@ -0,0 +1,570 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+	"testing"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	blobTableName   = "blob-metadata"
+	statusIndexName = "StatusIndex"
+	expiryIndexName = "Status-Expiry-Index"
+)
+
+func newTestLocalClient(t *testing.T, path string) *commondynamodb.LocalClient {
+	client, err := commondynamodb.NewLocalClient(path, logging.NewNoopLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Shutdown()
+	})
+	err = client.CreateTable(context.Background(), blobstore.GenerateTableSchema(blobTableName, 10, 10))
+	require.NoError(t, err)
+	return client
+}
+
+func blobItem(blobHash string, status int, expiry int64, requestedAt int64) commondynamodb.Item {
+	return commondynamodb.Item{
+		"BlobHash":     &types.AttributeValueMemberS{Value: blobHash},
+		"MetadataHash": &types.AttributeValueMemberS{Value: "metadata"},
+		"BlobStatus":   &types.AttributeValueMemberN{Value: strconv.Itoa(status)},
+		"Expiry":       &types.AttributeValueMemberN{Value: strconv.FormatInt(expiry, 10)},
+		"RequestedAt":  &types.AttributeValueMemberN{Value: strconv.FormatInt(requestedAt, 10)},
+		"BlobSize":     &types.AttributeValueMemberN{Value: "1024"},
+	}
+}
+
+func blobKey(blobHash string) commondynamodb.Key {
+	return commondynamodb.Key{
+		"BlobHash":     &types.AttributeValueMemberS{Value: blobHash},
+		"MetadataHash": &types.AttributeValueMemberS{Value: "metadata"},
+	}
+}
+
+func TestLocalClientItems(t *testing.T) {
+	ctx := context.Background()
+	path := t.TempDir()
+	client, err := commondynamodb.NewLocalClient(path, logging.NewNoopLogger())
+	require.NoError(t, err)
+	err = client.CreateTable(ctx, blobstore.GenerateTableSchema(blobTableName, 10, 10))
+	require.NoError(t, err)
+
+	item := blobItem("blob1", 0, 100, 1)
+	item["Data"] = &types.AttributeValueMemberB{Value: []byte{0, 1, 2}}
+	item["Flags"] = &types.AttributeValueMemberL{Value: []types.AttributeValue{
+		&types.AttributeValueMemberBOOL{Value: true},
+		&types.AttributeValueMemberM{Value: map[string]types.AttributeValue{
+			"nested": &types.AttributeValueMemberSS{Value: []string{"a", "b"}},
+		}},
+	}}
+	err = client.PutItem(ctx, blobTableName, item)
+	assert.NoError(t, err)
+
+	fetched, err := client.GetItem(ctx, blobTableName, blobKey("blob1"))
+	assert.NoError(t, err)
+	assert.Equal(t, item, fetched)
+
+	// Missing items are returned as nil
+	fetched, err = client.GetItem(ctx, blobTableName, blobKey("missing"))
+	assert.NoError(t, err)
+	assert.Nil(t, fetched)
+
+	// Keys must contain exactly the key attributes, with the types of the schema
+	_, err = client.GetItem(ctx, blobTableName, commondynamodb.Key{
+		"BlobHash": &types.AttributeValueMemberS{Value: "blob1"},
+	})
+	assert.Error(t, err)
+	err = client.PutItem(ctx, blobTableName, commondynamodb.Item{
+		"BlobHash":     &types.AttributeValueMemberN{Value: "1"},
+		"MetadataHash": &types.AttributeValueMemberS{Value: "metadata"},
+	})
+	assert.Error(t, err)
+	// Index keys must have the types of the schema as well
+	invalid := blobItem("blob2", 0, 100, 1)
+	invalid["BlobStatus"] = &types.AttributeValueMemberS{Value: "0"}
+	err = client.PutItem(ctx, blobTableName, invalid)
+	assert.Error(t, err)
+
+	// Tables must be created before they are used
+	err = client.PutItem(ctx, "missing-table", item)
+	var notFound *types.ResourceNotFoundException
+	assert.ErrorAs(t, err, &notFound)
+	assert.Error(t, client.TableExists(ctx, "missing-table"))
+	assert.NoError(t, client.TableExists(ctx, blobTableName))
+
+	// Creating a table again is a no-op, but not with a different schema
+	err = client.CreateTable(ctx, blobstore.GenerateTableSchema(blobTableName, 20, 20))
+	assert.NoError(t, err)
+	schema := blobstore.GenerateTableSchema(blobTableName, 10, 10)
+	schema.GlobalSecondaryIndexes = schema.GlobalSecondaryIndexes[:1]
+	err = client.CreateTable(ctx, schema)
+	var inUse *types.ResourceInUseException
+	assert.ErrorAs(t, err, &inUse)
+
+	items, err := client.GetItems(ctx, blobTableName, []commondynamodb.Key{blobKey("blob1"), blobKey("missing")})
+	assert.NoError(t, err)
+	assert.Equal(t, []commondynamodb.Item{item}, items)
+
+	// Tables and items survive a restart of the client
+	require.NoError(t, client.Shutdown())
+	client = newTestLocalClient(t, path)
+	fetched, err = client.GetItem(ctx, blobTableName, blobKey("blob1"))
+	assert.NoError(t, err)
+	assert.Equal(t, item, fetched)
+
+	err = client.DeleteItem(ctx, blobTableName, blobKey("blob1"))
+	assert.NoError(t, err)
+	fetched, err = client.GetItem(ctx, blobTableName, blobKey("blob1"))
+	assert.NoError(t, err)
+	assert.Nil(t, fetched)
+	count, err := client.QueryIndexCount(ctx, blobTableName, statusIndexName, "BlobStatus = :status", commondynamodb.ExpressionValues{
+		":status": &types.AttributeValueMemberN{Value: "0"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), count)
+
+	// Deleting a table deletes its items
+	err = client.PutItem(ctx, blobTableName, item)
+	assert.NoError(t, err)
+	err = client.DeleteTable(ctx, blobTableName)
+	assert.NoError(t, err)
+	assert.Error(t, client.TableExists(ctx, blobTableName))
+	err = client.CreateTable(ctx, blobstore.GenerateTableSchema(blobTableName, 10, 10))
+	assert.NoError(t, err)
+	fetched, err = client.GetItem(ctx, blobTableName, blobKey("blob1"))
+	assert.NoError(t, err)
+	assert.Nil(t, fetched)
+}
+
+func TestLocalClientSingleProcess(t *testing.T) {
+	path := t.TempDir()
+	newTestLocalClient(t, path)
+
+	// the store can't be shared with another client, in this or another process
+	_, err := commondynamodb.NewLocalClient(path, logging.NewNoopLogger())
+	assert.ErrorIs(t, err, commondynamodb.ErrLocalStoreInUse)
+}
+
+func TestLocalClientConditionalWrites(t *testing.T) {
+	ctx := context.Background()
+	client := newTestLocalClient(t, t.TempDir())
+
+	condition := "attribute_not_exists(BlobHash) AND attribute_not_exists(MetadataHash)"
+	err := client.PutItemWithCondition(ctx, blobTableName, blobItem("blob1", 0, 100, 1), condition, nil, nil)
+	assert.NoError(t, err)
+	err = client.PutItemWithCondition(ctx, blobTableName, blobItem("blob1", 1, 100, 1), condition, nil, nil)
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+
+	// Placeholders for names and values are resolved
+	err = client.PutItemWithCondition(ctx, blobTableName, blobItem("blob1", 1, 100, 1),
+		"#status = :status OR NOT (#size >= :size)",
+		map[string]string{"#status": "BlobStatus", "#size": "BlobSize"},
+		map[string]types.AttributeValue{
+			":status": &types.AttributeValueMemberN{Value: "0"},
+			":size":   &types.AttributeValueMemberN{Value: "1e3"},
+		})
+	assert.NoError(t, err)
+	// Undefined placeholders are rejected
+	err = client.PutItemWithCondition(ctx, blobTableName, blobItem("blob1", 1, 100, 1), "#status = :status", nil, nil)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, commondynamodb.ErrConditionFailed)
+
+	// The condition of an update is evaluated against the existing item
+	statusIn := func(statuses ...int) expression.ConditionBuilder {
+		values := make([]expression.OperandBuilder, len(statuses))
+		for i, status := range statuses {
+			values[i] = expression.Value(status)
+		}
+		return expression.Name("BlobStatus").In(values[0], values[1:]...)
+	}
+	updated, err := client.UpdateItemWithCondition(ctx, blobTableName, blobKey("blob1"), commondynamodb.Item{
+		"BlobStatus": &types.AttributeValueMemberN{Value: "2"},
+	}, statusIn(0, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, commondynamodb.Item{"BlobStatus": &types.AttributeValueMemberN{Value: "2"}}, updated)
+	_, err = client.UpdateItemWithCondition(ctx, blobTableName, blobKey("blob1"), commondynamodb.Item{
+		"BlobStatus": &types.AttributeValueMemberN{Value: "3"},
+	}, statusIn(0, 1))
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+	// A missing item does not satisfy the condition
+	_, err = client.UpdateItemWithCondition(ctx, blobTableName, blobKey("missing"), commondynamodb.Item{
+		"BlobStatus": &types.AttributeValueMemberN{Value: "3"},
+	}, statusIn(0, 1))
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+
+	// An update without a condition creates the item if needed, and ignores key attributes
+	updated, err = client.UpdateItem(ctx, blobTableName, blobKey("blob2"), commondynamodb.Item{
+		"BlobHash":   &types.AttributeValueMemberS{Value: "other"},
+		"BlobStatus": &types.AttributeValueMemberN{Value: "1"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, commondynamodb.Item{"BlobStatus": &types.AttributeValueMemberN{Value: "1"}}, updated)
+	fetched, err := client.GetItem(ctx, blobTableName, blobKey("blob2"))
+	assert.NoError(t, err)
+	assert.Equal(t, "blob2", fetched["BlobHash"].(*types.AttributeValueMemberS).Value)
+	assert.Equal(t, "1", fetched["BlobStatus"].(*types.AttributeValueMemberN).Value)
+}
+
+func TestLocalClientTransactWriteItems(t *testing.T) {
+	ctx := context.Background()
+	client := newTestLocalClient(t, t.TempDir())
+
+	err := client.PutItem(ctx, blobTableName, blobItem("blob1", 0, 100, 1))
+	require.NoError(t, err)
+	notExists := expression.AttributeNotExists(expression.Name("BlobHash"))
+	statusIs := func(status int) *expression.ConditionBuilder {
+		cond := expression.Name("BlobStatus").Equal(expression.Value(status))
+		return &cond
+	}
+
+	// Nothing is written if any condition fails
+	err = client.TransactWriteItems(ctx, blobTableName, []commondynamodb.TransactWrite{
+		{Item: blobItem("blob2", 0, 100, 1), Condition: &notExists},
+		{DeleteKey: blobKey("blob1"), Condition: statusIs(1)},
+	})
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+	item, err := client.GetItem(ctx, blobTableName, blobKey("blob2"))
+	require.NoError(t, err)
+	assert.Nil(t, item)
+
+	// Everything is written if all conditions hold
+	err = client.TransactWriteItems(ctx, blobTableName, []commondynamodb.TransactWrite{
+		{Item: blobItem("blob2", 0, 100, 1), Condition: &notExists},
+		{DeleteKey: blobKey("blob1"), Condition: statusIs(0)},
+	})
+	require.NoError(t, err)
+	item, err = client.GetItem(ctx, blobTableName, blobKey("blob2"))
+	require.NoError(t, err)
+	assert.NotNil(t, item)
+	item, err = client.GetItem(ctx, blobTableName, blobKey("blob1"))
+	require.NoError(t, err)
+	assert.Nil(t, item)
+	// The index entries are maintained
+	items, err := client.QueryIndex(ctx, blobTableName, statusIndexName, "BlobStatus = :status", commondynamodb.ExpressionValues{
+		":status": &types.AttributeValueMemberN{Value: "0"},
+	})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "blob2", items[0]["BlobHash"].(*types.AttributeValueMemberS).Value)
+
+	// An item can only be written once per transaction
+	err = client.TransactWriteItems(ctx, blobTableName, []commondynamodb.TransactWrite{
+		{Item: blobItem("blob3", 0, 100, 1)},
+		{DeleteKey: blobKey("blob3")},
+	})
+	assert.Error(t, err)
+}
+
+func TestLocalClientIncrementBy(t *testing.T) {
+	ctx := context.Background()
+	client := newTestLocalClient(t, t.TempDir())
+	key := blobKey("blob1")
+
+	// A missing item and attribute count as zero
+	updated, err := client.IncrementBy(ctx, blobTableName, key, "Usage", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, commondynamodb.Item{"Usage": &types.AttributeValueMemberN{Value: "100"}}, updated)
+
+	// Concurrent increments are atomic
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				_, err := client.IncrementBy(ctx, blobTableName, key, "Usage", 1)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Large values keep their precision
+	updated, err = client.IncrementBy(ctx, blobTableName, key, "Usage", 1<<63)
+	assert.NoError(t, err)
+	expected := new(big.Int).Add(big.NewInt(200), new(big.Int).Lsh(big.NewInt(1), 63))
+	assert.Equal(t, expected.String(), updated["Usage"].(*types.AttributeValueMemberN).Value)
+
+	// Only numbers can be incremented
+	_, err = client.IncrementBy(ctx, blobTableName, key, "BlobHash", 1)
+	assert.Error(t, err)
+	_, err = client.UpdateItem(ctx, blobTableName, key, commondynamodb.Item{
+		"Name": &types.AttributeValueMemberS{Value: "name"},
+	})
+	assert.NoError(t, err)
+	_, err = client.IncrementBy(ctx, blobTableName, key, "Name", 1)
+	assert.Error(t, err)
+}
+
+func TestLocalClientQuery(t *testing.T) {
+	ctx := context.Background()
+	client := newTestLocalClient(t, t.TempDir())
+	err := client.CreateTable(ctx, meterer.GenerateOnDemandTableSchema("on-demand"))
+	require.NoError(t, err)
+
+	// Numbers are ordered by value, not by their representation
+	payments := []string{"-1000", "-2.5", "-2", "0", "0.001", "3", "10", "10.5", "1e3", "123456789012345678901234567890"}
+	for _, account := range []string{"account1", "account2"} {
+		for i := len(payments) - 1; i >= 0; i-- {
+			err := client.PutItem(ctx, "on-demand", commondynamodb.Item{
+				"AccountID":          &types.AttributeValueMemberS{Value: account},
+				"CumulativePayments": &types.AttributeValueMemberN{Value: payments[i]},
+			})
+			require.NoError(t, err)
+		}
+	}
+	paymentsOf := func(items []commondynamodb.Item) []string {
+		result := make([]string, len(items))
+		for i, item := range items {
+			result[i] = item["CumulativePayments"].(*types.AttributeValueMemberN).Value
+		}
+		return result
+	}
+	query := func(condition string, values commondynamodb.ExpressionValues, forward bool, limit int32) []string {
+		values[":account"] = &types.AttributeValueMemberS{Value: "account1"}
+		input := &dynamodb.QueryInput{
+			TableName:                 aws.String("on-demand"),
+			KeyConditionExpression:    aws.String("AccountID = :account" + condition),
+			ExpressionAttributeValues: values,
+			ScanIndexForward:          aws.Bool(forward),
+		}
+		if limit > 0 {
+			input.Limit = aws.Int32(limit)
+		}
+		items, err := client.QueryWithInput(ctx, input)
+		require.NoError(t, err)
+		return paymentsOf(items)
+	}
+
+	assert.Equal(t, payments, query("", commondynamodb.ExpressionValues{}, true, 0))
+	assert.Equal(t, []string{"123456789012345678901234567890", "1e3", "10.5"},
+		query("", commondynamodb.ExpressionValues{}, false, 3))
+	assert.Equal(t, []string{"-2", "0", "0.001", "3"}, query(" AND CumulativePayments BETWEEN :start AND :end",
+		commondynamodb.ExpressionValues{
+			":start": &types.AttributeValueMemberN{Value: "-2"},
+			":end":   &types.AttributeValueMemberN{Value: "3.0"},
+		}, true, 0))
+	assert.Equal(t, []string{"0.001", "0"}, query(" AND CumulativePayments < :payment",
+		commondynamodb.ExpressionValues{
+			":payment": &types.AttributeValueMemberN{Value: "3"},
+		}, false, 2))
+	assert.Equal(t, []string{"1e3"}, query(" AND CumulativePayments = :payment",
+		commondynamodb.ExpressionValues{
+			":payment": &types.AttributeValueMemberN{Value: "1000"},
+		}, true, 0))
+
+	// A limited query returns as many items as the limit even when a filter drops some of the evaluated items
+	items, err := client.QueryWithInput(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String("on-demand"),
+		KeyConditionExpression: aws.String("AccountID = :account"),
+		FilterExpression:       aws.String("CumulativePayments > :payment"),
+		ExpressionAttributeValues: commondynamodb.ExpressionValues{
+			":account": &types.AttributeValueMemberS{Value: "account1"},
+			":payment": &types.AttributeValueMemberN{Value: "0"},
+		},
+		Limit: aws.Int32(3),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0.001", "3", "10"}, paymentsOf(items))
+
+	// Key conditions must select a partition
+	_, err = client.Query(ctx, "on-demand", "CumulativePayments > :payment", commondynamodb.ExpressionValues{
+		":payment": &types.AttributeValueMemberN{Value: "0"},
+	})
+	assert.Error(t, err)
+	_, err = client.Query(ctx, "on-demand", "AccountID = :account OR CumulativePayments > :payment", commondynamodb.ExpressionValues{
+		":account": &types.AttributeValueMemberS{Value: "account1"},
+		":payment": &types.AttributeValueMemberN{Value: "0"},
+	})
+	assert.Error(t, err)
+
+	// Prefix conditions on string range keys
+	for _, sk := range []string{"BatchHeader#1", "BlobCertificate", "BatchHeader#2", "Attestation"} {
+		err := client.PutItem(ctx, blobTableName, commondynamodb.Item{
+			"BlobHash":     &types.AttributeValueMemberS{Value: "blob1"},
+			"MetadataHash": &types.AttributeValueMemberS{Value: sk},
+		})
+		require.NoError(t, err)
+	}
+	items, err = client.Query(ctx, blobTableName, "BlobHash = :pk AND begins_with(MetadataHash, :prefix)", commondynamodb.ExpressionValues{
+		":pk":     &types.AttributeValueMemberS{Value: "blob1"},
+		":prefix": &types.AttributeValueMemberS{Value: "BatchHeader#"},
+	})
+	assert.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "BatchHeader#1", items[0]["MetadataHash"].(*types.AttributeValueMemberS).Value)
+	assert.Equal(t, "BatchHeader#2", items[1]["MetadataHash"].(*types.AttributeValueMemberS).Value)
+}
+
+func TestLocalClientIndexes(t *testing.T) {
+	ctx := context.Background()
+	client := newTestLocalClient(t, t.TempDir())
+
+	numBlobs := 25
+	for i := 0; i < numBlobs; i++ {
+		err := client.PutItem(ctx, blobTableName, blobItem(fmt.Sprintf("blob%d", i), 0, int64(100+i%5), int64(numBlobs-i)))
+		require.NoError(t, err)
+	}
+	// Items without the key attributes of an index are not indexed
+	err := client.PutItem(ctx, blobTableName, blobKey("unindexed"))
+	require.NoError(t, err)
+
+	statusValues := func(status int) commondynamodb.ExpressionValues {
+		return commondynamodb.ExpressionValues{
+			":status": &types.AttributeValueMemberN{Value: strconv.Itoa(status)},
+		}
+	}
+	items, err := client.QueryIndex(ctx, blobTableName, statusIndexName, "BlobStatus = :status", statusValues(0))
+	assert.NoError(t, err)
+	require.Len(t, items, numBlobs)
+	// Items are sorted by the range key of the index
+	for i, item := range items {
+		assert.Equal(t, strconv.Itoa(i+1), item["RequestedAt"].(*types.AttributeValueMemberN).Value)
+	}
+
+	// Index entries follow the updates of the items
+	for i := 0; i < 10; i++ {
+		_, err := client.UpdateItem(ctx, blobTableName, blobKey(fmt.Sprintf("blob%d", i)), commondynamodb.Item{
+			"BlobStatus": &types.AttributeValueMemberN{Value: "1"},
+		})
+		require.NoError(t, err)
+	}
+	count, err := client.QueryIndexCount(ctx, blobTableName, statusIndexName, "BlobStatus = :status", statusValues(0))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(numBlobs-10), count)
+	count, err = client.QueryIndexCount(ctx, blobTableName, statusIndexName, "BlobStatus = :status", statusValues(1))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(10), count)
+
+	_, err = client.QueryIndex(ctx, blobTableName, "MissingIndex", "BlobStatus = :status", statusValues(0))
+	assert.Error(t, err)
+
+	// Paginate over the blobs which have not expired
+	values := statusValues(0)
+	values[":expiry"] = &types.AttributeValueMemberN{Value: "101"}
+	expected := make(map[string]bool)
+	for i := 10; i < numBlobs; i++ {
+		if 100+i%5 > 101 {
+			expected[fmt.Sprintf("blob%d", i)] = true
+		}
+	}
+	fetched := make(map[string]bool)
+	var exclusiveStartKey commondynamodb.Key
+	pages := 0
+	for {
+		result, err := client.QueryIndexWithPagination(ctx, blobTableName, expiryIndexName,
+			"BlobStatus = :status AND Expiry > :expiry", values, 4, exclusiveStartKey)
+		require.NoError(t, err)
+		if result.Items == nil {
+			assert.Nil(t, result.LastEvaluatedKey)
+			break
+		}
+		pages++
+		assert.LessOrEqual(t, len(result.Items), 4)
+		for _, item := range result.Items {
+			blobHash := item["BlobHash"].(*types.AttributeValueMemberS).Value
+			assert.False(t, fetched[blobHash], blobHash)
+			fetched[blobHash] = true
+		}
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		// The key contains the primary key and the key of the index
+		assert.Len(t, result.LastEvaluatedKey, 4)
+		exclusiveStartKey = result.LastEvaluatedKey
+	}
+	assert.Equal(t, expected, fetched)
+	assert.Equal(t, (len(expected)+3)/4, pages)
+
+	// Without a limit, all the items are returned at once
+	result, err := client.QueryIndexWithPagination(ctx, blobTableName, expiryIndexName,
+		"BlobStatus = :status AND Expiry > :expiry", values, 0, nil)
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, len(expected))
+	assert.Nil(t, result.LastEvaluatedKey)
+
+	// Deleted items are removed from the indexes
+	failed, err := client.DeleteItems(ctx, blobTableName, []commondynamodb.Key{blobKey("blob0"), blobKey("blob1")})
+	assert.NoError(t, err)
+	assert.Empty(t, failed)
+	count, err = client.QueryIndexCount(ctx, blobTableName, statusIndexName, "BlobStatus = :status", statusValues(1))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(8), count)
+}
+
+func TestLocalClientOffchainStore(t *testing.T) {
+	ctx := context.Background()
+	config := commonaws.ClientConfig{
+		LocalDynamoDBPath: t.TempDir(),
+	}
+	// The tables are created by the store
+	store, err := meterer.NewOffchainStore(config, "reservations", "on-demand", "global", logging.NewNoopLogger())
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		usage, err := store.UpdateReservationBin(ctx, "account", uint64(i), 100)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(100), usage)
+	}
+	usage, err := store.UpdateReservationBin(ctx, "account", 1, 50)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(150), usage)
+
+	bins, err := store.GetReservationBins(ctx, "account", 1, 2)
+	require.NoError(t, err)
+	require.Len(t, bins, 2)
+	assert.Equal(t, uint32(1), bins[0].BinIndex)
+	assert.Equal(t, uint32(150), bins[0].BinUsage)
+	assert.Equal(t, uint32(2), bins[1].BinIndex)
+
+	usage, err = store.UpdateGlobalBin(ctx, 7, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), usage)
+
+	largest, err := store.GetLargestCumulativePayment(ctx, "account")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(0), largest)
+	for _, payment := range []int64{9, 100, 25} {
+		err = store.AddOnDemandPayment(ctx, core.PaymentMetadata{
+			AccountID:         "account",
+			CumulativePayment: big.NewInt(payment),
+		}, 1)
+		require.NoError(t, err)
+	}
+	largest, err = store.GetLargestCumulativePayment(ctx, "account")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100), largest)
+
+	prevPayment, nextPayment, _, err := store.GetRelevantOnDemandRecords(ctx, "account", big.NewInt(25))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(9), prevPayment)
+	assert.Equal(t, uint64(100), nextPayment)
+
+	err = store.RemoveOnDemandPayment(ctx, "account", big.NewInt(100))
+	require.NoError(t, err)
+	largest, err = store.GetLargestCumulativePayment(ctx, "account")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(25), largest)
+
+	// The store shares the client of the process
+	client, err := commondynamodb.NewClient(config, logging.NewNoopLogger())
+	require.NoError(t, err)
+	assert.NoError(t, client.TableExists(ctx, "global"))
+	assert.Error(t, client.TableExists(ctx, "missing"))
+}
@@ -55,7 +55,10 @@ func (c *MockDynamoDBClient) IncrementBy(ctx context.Context, tableName string,
 }
 
 func (c *MockDynamoDBClient) GetItem(ctx context.Context, tableName string, key dynamodb.Key) (dynamodb.Item, error) {
-	args := c.Called()
+	args := c.Called(ctx, tableName, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(dynamodb.Item), args.Error(1)
 }
 
@@ -99,6 +102,11 @@ func (c *MockDynamoDBClient) DeleteItems(ctx context.Context, tableName string,
 	return args.Get(0).([]dynamodb.Key), args.Error(1)
 }
 
+func (c *MockDynamoDBClient) TransactWriteItems(ctx context.Context, tableName string, writes []dynamodb.TransactWrite) error {
+	args := c.Called()
+	return args.Error(0)
+}
+
 func (c *MockDynamoDBClient) TableExists(ctx context.Context, name string) error {
 	args := c.Called()
 	return args.Error(0)
@@ -71,3 +71,8 @@ func (s *S3Client) FragmentedDownloadObject(
 	}
 	return data, nil
 }
+
+func (s *S3Client) FragmentedDeleteObject(ctx context.Context, bucket string, key string) error {
+	delete(s.bucket, key)
+	return nil
+}
@@ -37,7 +37,13 @@ type client struct {
 
 var _ Client = (*client)(nil)
 
+// NewClient creates a Client for S3. If the config has a LocalS3Path, a Client storing objects in that directory is
+// returned instead, see NewLocalClient.
 func NewClient(ctx context.Context, cfg commonaws.ClientConfig, logger logging.Logger) (Client, error) {
+	if cfg.LocalS3Path != "" {
+		return NewLocalClient(cfg.LocalS3Path, logger)
+	}
+
 	var err error
 	once.Do(func() {
 		customResolver := aws.EndpointResolverWithOptionsFunc(
@@ -286,6 +292,10 @@ func (s *client) FragmentedDownloadObject(
 
 }
 
+func (s *client) FragmentedDeleteObject(ctx context.Context, bucket string, key string) error {
+	return deleteFragments(ctx, s, bucket, key)
+}
+
 // readResult is the result of a read task.
 type readResult struct {
 	fragment *Fragment
@@ -1,6 +1,7 @@
 package s3
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -38,6 +39,44 @@ func getFragmentKey(fileKey string, fragmentCount int, index int) (string, error
 	return fmt.Sprintf("%s-%d%s", fileKey, index, postfix), nil
 }
 
+// isFragmentKey returns true if the key is the key of a fragment of the file with the given key.
+func isFragmentKey(fileKey string, key string) bool {
+	index, found := strings.CutPrefix(key, fileKey+"-")
+	if !found {
+		return false
+	}
+	index = strings.TrimSuffix(index, "f")
+	if index == "" {
+		return false
+	}
+	for _, c := range index {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// deleteFragments deletes all fragments of a file. The fragments are found by listing the keys that extend the key of
+// the file rather than from the size of the file, so that the fragments of a partially uploaded file are deleted too.
+func deleteFragments(ctx context.Context, client Client, bucket string, fileKey string) error {
+	objects, err := client.ListObjects(ctx, bucket, fileKey+"-")
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		if !isFragmentKey(fileKey, object.Key) {
+			// The key of another file which extends the key of this file
+			continue
+		}
+		err = client.DeleteObject(ctx, bucket, object.Key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Fragment is a subset of a file.
 type Fragment struct {
 	FragmentKey string
@@ -289,3 +289,18 @@ func TestMissingFinalFragment(t *testing.T) {
 	_, err = recombineFragments(fragments)
 	assert.Error(t, err)
 }
+
+func TestIsFragmentKey(t *testing.T) {
+	fragmentKeys, err := getFragmentKeys("abc123", 3)
+	assert.NoError(t, err)
+	for _, key := range fragmentKeys {
+		assert.True(t, isFragmentKey("abc123", key))
+	}
+
+	assert.False(t, isFragmentKey("abc123", "abc123"))
+	assert.False(t, isFragmentKey("abc123", "abc123-"))
+	assert.False(t, isFragmentKey("abc123", "abc123-f"))
+	assert.False(t, isFragmentKey("abc123", "abc123-1x"))
+	assert.False(t, isFragmentKey("abc123", "abc123-1-0f"))
+	assert.False(t, isFragmentKey("abc123", "abc1234-0f"))
+}
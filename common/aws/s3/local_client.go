@@ -0,0 +1,379 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// objectSuffix is appended to the name of the file holding an object. This keeps the files of the objects apart
+	// from the directories of the keys they prefix, e.g. the objects "a" and "a/b" are stored in "a.obj" and "a/b.obj".
+	// Escaped key segments contain no ".", so no directory name ends with the suffix.
+	objectSuffix = ".obj"
+
+	// tempFilePattern is the pattern of the temporary files objects are written to before being renamed into place.
+	tempFilePattern = ".upload-*.tmp"
+)
+
+// ErrBucketNotFound is returned by the local client when an operation targets a bucket that was not created.
+var ErrBucketNotFound = errors.New("bucket not found")
+
+// localClient is a Client which stores objects on the local filesystem. Each bucket is a directory under the root
+// directory, and each object is a file in its bucket directory. The segments of a key separated by "/" map to nested
+// directories, so that listing a prefix only walks the directories which can contain matching keys.
+//
+// Objects are written to a temporary file which is then renamed into place, so readers never observe a partially
+// written object, and a crash leaves either the old or the new version of an object.
+type localClient struct {
+	rootDir string
+	logger  logging.Logger
+
+	// dirLock prevents an upload from racing with the removal of the empty directories left behind by a deletion.
+	// Uploads hold the read lock, the removal of directories holds the write lock.
+	dirLock sync.RWMutex
+}
+
+var _ Client = (*localClient)(nil)
+
+// NewLocalClient creates a Client which stores objects in the given directory. The directory is created if it does
+// not exist. Buckets created by a previous instance with the same directory are available to the new instance.
+func NewLocalClient(rootDir string, logger logging.Logger) (Client, error) {
+	if rootDir == "" {
+		return nil, errors.New("root directory must be provided")
+	}
+	if err := os.MkdirAll(rootDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create root directory %s: %w", rootDir, err)
+	}
+
+	return &localClient{
+		rootDir: rootDir,
+		logger:  logger.With("component", "LocalS3Client"),
+	}, nil
+}
+
+func (c *localClient) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
+	path, err := c.objectPath(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrObjectNotFound
+		}
+		return nil, fmt.Errorf("failed to read object %s/%s: %w", bucket, key, err)
+	}
+	return data, nil
+}
+
+func (c *localClient) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+	path, err := c.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+
+	c.dirLock.RLock()
+	defer c.dirLock.RUnlock()
+	return writeFileAtomically(path, data)
+}
+
+func (c *localClient) DeleteObject(ctx context.Context, bucket string, key string) error {
+	path, err := c.objectPath(bucket, key)
+	if err != nil {
+		return err
+	}
+
+	// Like S3, deleting an object that does not exist is not an error.
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object %s/%s: %w", bucket, key, err)
+	}
+
+	c.removeEmptyDirs(filepath.Dir(path), c.bucketPath(bucket))
+	return nil
+}
+
+func (c *localClient) ListObjects(ctx context.Context, bucket string, prefix string) ([]Object, error) {
+	bucketPath := c.bucketPath(bucket)
+	if err := c.checkBucket(bucket); err != nil {
+		return nil, err
+	}
+
+	// Only the directory of the last complete segment of the prefix can contain matching keys.
+	searchPath := bucketPath
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		dirPath, err := escapeKey(prefix[:i])
+		if err != nil {
+			// No key can be stored under an invalid directory
+			return []Object{}, nil
+		}
+		searchPath = filepath.Join(bucketPath, dirPath)
+	}
+
+	objects := make([]Object, 0)
+	err := filepath.WalkDir(searchPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// The directory was removed after an object was deleted, or it never existed.
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), objectSuffix) {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(bucketPath, path)
+		if err != nil {
+			return err
+		}
+		key, err := unescapeKey(strings.TrimSuffix(filepath.ToSlash(relPath), objectSuffix))
+		if err != nil {
+			c.logger.Warn("skipping file with invalid name in bucket", "bucket", bucket, "path", path, "err", err)
+			return nil
+		}
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// The object was deleted while listing
+				return nil
+			}
+			return err
+		}
+		objects = append(objects, Object{
+			Key:  key,
+			Size: info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects in bucket %s: %w", bucket, err)
+	}
+
+	// S3 lists keys in lexicographic order
+	sort.Slice(objects, func(i, j int) bool {
+		return objects[i].Key < objects[j].Key
+	})
+	return objects, nil
+}
+
+func (c *localClient) CreateBucket(ctx context.Context, bucket string) error {
+	if err := validateBucketName(bucket); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.bucketPath(bucket), 0755); err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", bucket, err)
+	}
+	return nil
+}
+
+func (c *localClient) FragmentedUploadObject(
+	ctx context.Context,
+	bucket string,
+	key string,
+	data []byte,
+	fragmentSize int) error {
+
+	if fragmentSize <= 0 {
+		return errors.New("fragmentSize must be greater than 0")
+	}
+
+	fragments, err := breakIntoFragments(key, data, fragmentSize)
+	if err != nil {
+		return err
+	}
+
+	for _, fragment := range fragments {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		err = c.UploadObject(ctx, bucket, fragment.FragmentKey, fragment.Data)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *localClient) FragmentedDownloadObject(
+	ctx context.Context,
+	bucket string,
+	key string,
+	fileSize int,
+	fragmentSize int) ([]byte, error) {
+
+	if fragmentSize <= 0 {
+		return nil, errors.New("fragmentSize must be greater than 0")
+	}
+
+	fragmentKeys, err := getFragmentKeys(key, getFragmentCount(fileSize, fragmentSize))
+	if err != nil {
+		return nil, err
+	}
+
+	fragments := make([]*Fragment, len(fragmentKeys))
+	for i, fragmentKey := range fragmentKeys {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		data, err := c.DownloadObject(ctx, bucket, fragmentKey)
+		if err != nil {
+			return nil, err
+		}
+		fragments[i] = &Fragment{
+			FragmentKey: fragmentKey,
+			Data:        data,
+			Index:       i,
+		}
+	}
+
+	return recombineFragments(fragments)
+}
+
+func (c *localClient) FragmentedDeleteObject(ctx context.Context, bucket string, key string) error {
+	return deleteFragments(ctx, c, bucket, key)
+}
+
+// bucketPath returns the path of the directory of a bucket.
+func (c *localClient) bucketPath(bucket string) string {
+	return filepath.Join(c.rootDir, bucket)
+}
+
+// checkBucket returns ErrBucketNotFound if the bucket has not been created.
+func (c *localClient) checkBucket(bucket string) error {
+	if err := validateBucketName(bucket); err != nil {
+		return err
+	}
+	info, err := os.Stat(c.bucketPath(bucket))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrBucketNotFound, bucket)
+		}
+		return fmt.Errorf("failed to stat bucket %s: %w", bucket, err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("bucket path %s is not a directory", c.bucketPath(bucket))
+	}
+	return nil
+}
+
+// objectPath returns the path of the file of an object, after checking that its bucket exists.
+func (c *localClient) objectPath(bucket string, key string) (string, error) {
+	if err := c.checkBucket(bucket); err != nil {
+		return "", err
+	}
+	escapedKey, err := escapeKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(c.bucketPath(bucket), escapedKey+objectSuffix), nil
+}
+
+// removeEmptyDirs removes the directory and its parents up to, but excluding, the bucket directory as long as they
+// are empty.
+func (c *localClient) removeEmptyDirs(dir string, bucketPath string) {
+	c.dirLock.Lock()
+	defer c.dirLock.Unlock()
+
+	for dir != bucketPath && strings.HasPrefix(dir, bucketPath) {
+		// os.Remove fails on a directory which is not empty, which ends the cleanup
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+// validateBucketName checks that a bucket name maps to a single directory under the root directory.
+func validateBucketName(bucket string) error {
+	if bucket == "" || bucket == "." || bucket == ".." || strings.ContainsAny(bucket, `/\`) {
+		return fmt.Errorf("invalid bucket name: %q", bucket)
+	}
+	return nil
+}
+
+// escapeKey maps a key to a relative file path. Each segment of the key is escaped so that it is a valid file name
+// without ".", which keeps the segments that name directories apart from the names of object files (e.g. the key
+// "a.obj/b" is stored in "a%2Eobj/b.obj", not in the directory of the file of the key "a"). Keys with empty segments,
+// or with "." or ".." segments, are rejected.
+func escapeKey(key string) (string, error) {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", fmt.Errorf("invalid key: %q", key)
+		}
+		// "%" is escaped by url.PathEscape, so escaping "." as well keeps the mapping reversible
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), ".", "%2E")
+	}
+	return filepath.Join(segments...), nil
+}
+
+// unescapeKey is the inverse of escapeKey, for a relative path using "/" as a separator.
+func unescapeKey(path string) (string, error) {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return "", err
+		}
+		segments[i] = unescaped
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// writeFileAtomically writes the data to a temporary file in the directory of the path, syncs it and renames it to
+// the path. The parent directories of the path are created if needed.
+func writeFileAtomically(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	tmpFile, err := os.CreateTemp(dir, tempFilePattern)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file in %s: %w", dir, err)
+	}
+	tmpPath := tmpFile.Name()
+	defer func() {
+		// A no-op once the file has been renamed
+		_ = os.Remove(tmpPath)
+	}()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		_ = tmpFile.Close()
+		return fmt.Errorf("failed to write temporary file %s: %w", tmpPath, err)
+	}
+	if err := tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		return fmt.Errorf("failed to sync temporary file %s: %w", tmpPath, err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close temporary file %s: %w", tmpPath, err)
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return fmt.Errorf("failed to set permissions of temporary file %s: %w", tmpPath, err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to rename temporary file %s to %s: %w", tmpPath, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,293 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBucket = "test-bucket"
+
+func newTestLocalClient(t *testing.T) (Client, string) {
+	rootDir := t.TempDir()
+	client, err := NewLocalClient(rootDir, logging.NewNoopLogger())
+	require.NoError(t, err)
+	err = client.CreateBucket(context.Background(), testBucket)
+	require.NoError(t, err)
+	return client, rootDir
+}
+
+func TestLocalClientUploadDownloadDelete(t *testing.T) {
+	tu.InitializeRandom()
+	client, _ := newTestLocalClient(t)
+	ctx := context.Background()
+
+	data := tu.RandomBytes(1024)
+	err := client.UploadObject(ctx, testBucket, "abc/blob/abcdef", data)
+	assert.NoError(t, err)
+
+	downloaded, err := client.DownloadObject(ctx, testBucket, "abc/blob/abcdef")
+	assert.NoError(t, err)
+	assert.Equal(t, data, downloaded)
+
+	// Overwrite the object
+	data = tu.RandomBytes(512)
+	err = client.UploadObject(ctx, testBucket, "abc/blob/abcdef", data)
+	assert.NoError(t, err)
+	downloaded, err = client.DownloadObject(ctx, testBucket, "abc/blob/abcdef")
+	assert.NoError(t, err)
+	assert.Equal(t, data, downloaded)
+
+	// Empty objects are allowed
+	err = client.UploadObject(ctx, testBucket, "empty", []byte{})
+	assert.NoError(t, err)
+	downloaded, err = client.DownloadObject(ctx, testBucket, "empty")
+	assert.NoError(t, err)
+	assert.Empty(t, downloaded)
+
+	err = client.DeleteObject(ctx, testBucket, "abc/blob/abcdef")
+	assert.NoError(t, err)
+	_, err = client.DownloadObject(ctx, testBucket, "abc/blob/abcdef")
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+
+	// Deleting a missing object is not an error
+	err = client.DeleteObject(ctx, testBucket, "abc/blob/abcdef")
+	assert.NoError(t, err)
+}
+
+func TestLocalClientBuckets(t *testing.T) {
+	client, rootDir := newTestLocalClient(t)
+	ctx := context.Background()
+
+	err := client.UploadObject(ctx, "missing-bucket", "key", []byte{1})
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+	_, err = client.DownloadObject(ctx, "missing-bucket", "key")
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+	_, err = client.ListObjects(ctx, "missing-bucket", "")
+	assert.ErrorIs(t, err, ErrBucketNotFound)
+
+	// Creating a bucket is idempotent
+	err = client.CreateBucket(ctx, "other-bucket")
+	assert.NoError(t, err)
+	err = client.CreateBucket(ctx, "other-bucket")
+	assert.NoError(t, err)
+
+	// Buckets are isolated from each other
+	err = client.UploadObject(ctx, testBucket, "key", []byte{1})
+	assert.NoError(t, err)
+	_, err = client.DownloadObject(ctx, "other-bucket", "key")
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+
+	// Invalid bucket names cannot escape the root directory
+	for _, bucket := range []string{"", ".", "..", "a/b", `a\b`} {
+		err = client.CreateBucket(ctx, bucket)
+		assert.Error(t, err, bucket)
+	}
+
+	// Objects survive a restart of the client
+	restarted, err := NewLocalClient(rootDir, logging.NewNoopLogger())
+	assert.NoError(t, err)
+	downloaded, err := restarted.DownloadObject(ctx, testBucket, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{1}, downloaded)
+}
+
+func TestLocalClientKeys(t *testing.T) {
+	client, rootDir := newTestLocalClient(t)
+	ctx := context.Background()
+
+	// A key can be both an object and a prefix of other objects, including keys which end with the suffix of object
+	// files
+	keys := []string{"a", "a/b", "a/b/c", "a.obj", "a.obj/b", "a%2Eobj", "x.obj/y", "x", "with space", "percent%41",
+		"colon:key", "../../etc/passwd-like"}
+	for i, key := range keys[:len(keys)-1] {
+		err := client.UploadObject(ctx, testBucket, key, []byte{byte(i)})
+		assert.NoError(t, err, key)
+	}
+	for i, key := range keys[:len(keys)-1] {
+		downloaded, err := client.DownloadObject(ctx, testBucket, key)
+		assert.NoError(t, err, key)
+		assert.Equal(t, []byte{byte(i)}, downloaded, key)
+	}
+
+	// Keys which could escape the bucket directory or which have empty segments are rejected
+	for _, key := range []string{"", "/a", "a/", "a//b", ".", "..", "../../etc/passwd-like", "a/./b"} {
+		err := client.UploadObject(ctx, testBucket, key, []byte{1})
+		assert.Error(t, err, key)
+	}
+	entries, err := os.ReadDir(rootDir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	objects, err := client.ListObjects(ctx, testBucket, "")
+	assert.NoError(t, err)
+	listedKeys := make([]string, len(objects))
+	for i, object := range objects {
+		listedKeys[i] = object.Key
+	}
+	assert.Equal(t, []string{"a", "a%2Eobj", "a.obj", "a.obj/b", "a/b", "a/b/c", "colon:key", "percent%41", "with space",
+		"x", "x.obj/y"}, listedKeys)
+
+	objects, err = client.ListObjects(ctx, testBucket, "a.obj/")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 1)
+	assert.Equal(t, "a.obj/b", objects[0].Key)
+}
+
+func TestLocalClientListObjects(t *testing.T) {
+	tu.InitializeRandom()
+	client, rootDir := newTestLocalClient(t)
+	ctx := context.Background()
+
+	expected := make(map[string]int64)
+	for i := 0; i < 100; i++ {
+		key := ScopedKey(blobNamespace, fmt.Sprintf("%x", tu.RandomBytes(32)), prefixLength)
+		size := rand.Intn(100)
+		err := client.UploadObject(ctx, testBucket, key, tu.RandomBytes(size))
+		assert.NoError(t, err)
+		expected[key] = int64(size)
+	}
+
+	objects, err := client.ListObjects(ctx, testBucket, "")
+	assert.NoError(t, err)
+	assert.Len(t, objects, len(expected))
+	for i, object := range objects {
+		assert.Equal(t, expected[object.Key], object.Size)
+		if i > 0 {
+			assert.Less(t, objects[i-1].Key, object.Key)
+		}
+	}
+
+	// The prefix does not have to end at a segment boundary
+	for _, prefix := range []string{"1", "1a/", "2b/bl", "3c/blob/3c", "not-a-prefix", "zz/"} {
+		objects, err = client.ListObjects(ctx, testBucket, prefix)
+		assert.NoError(t, err)
+		count := 0
+		for key := range expected {
+			if strings.HasPrefix(key, prefix) {
+				count++
+			}
+		}
+		assert.Len(t, objects, count, prefix)
+		for _, object := range objects {
+			assert.True(t, strings.HasPrefix(object.Key, prefix))
+		}
+	}
+
+	// Deleting all objects leaves no directories behind
+	for key := range expected {
+		err = client.DeleteObject(ctx, testBucket, key)
+		assert.NoError(t, err)
+	}
+	objects, err = client.ListObjects(ctx, testBucket, "")
+	assert.NoError(t, err)
+	assert.Empty(t, objects)
+	entries, err := os.ReadDir(filepath.Join(rootDir, testBucket))
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLocalClientNoTemporaryFiles(t *testing.T) {
+	client, rootDir := newTestLocalClient(t)
+	ctx := context.Background()
+
+	for i := 0; i < 10; i++ {
+		err := client.UploadObject(ctx, testBucket, "dir/key", []byte{byte(i)})
+		assert.NoError(t, err)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(rootDir, testBucket, "dir"))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "key"+objectSuffix, entries[0].Name())
+}
+
+func TestLocalClientFragmentedObjects(t *testing.T) {
+	tu.InitializeRandom()
+	client, _ := newTestLocalClient(t)
+	ctx := context.Background()
+
+	fragmentSize := rand.Intn(100) + 100
+	for _, fileSize := range []int{1, fragmentSize, fragmentSize*3 + 7} {
+		key := fmt.Sprintf("file-%d", fileSize)
+		data := tu.RandomBytes(fileSize)
+		err := client.FragmentedUploadObject(ctx, testBucket, key, data, fragmentSize)
+		assert.NoError(t, err)
+
+		// The fragments are listed as separate objects
+		objects, err := client.ListObjects(ctx, testBucket, key+"-")
+		assert.NoError(t, err)
+		assert.Len(t, objects, getFragmentCount(fileSize, fragmentSize))
+
+		downloaded, err := client.FragmentedDownloadObject(ctx, testBucket, key, fileSize, fragmentSize)
+		assert.NoError(t, err)
+		assert.Equal(t, data, downloaded)
+	}
+
+	// A missing fragment fails the download
+	data := tu.RandomBytes(fragmentSize * 2)
+	err := client.FragmentedUploadObject(ctx, testBucket, "partial", data, fragmentSize)
+	assert.NoError(t, err)
+	err = client.DeleteObject(ctx, testBucket, "partial-1f")
+	assert.NoError(t, err)
+	_, err = client.FragmentedDownloadObject(ctx, testBucket, "partial", len(data), fragmentSize)
+	assert.ErrorIs(t, err, ErrObjectNotFound)
+
+	_, err = client.FragmentedDownloadObject(ctx, testBucket, "partial", len(data), 0)
+	assert.Error(t, err)
+
+	// Deleting a file deletes its fragments, even if some are missing, but not the files whose keys extend its key
+	err = client.UploadObject(ctx, testBucket, "partial-extended", data)
+	assert.NoError(t, err)
+	err = client.FragmentedDeleteObject(ctx, testBucket, "partial")
+	assert.NoError(t, err)
+	objects, err := client.ListObjects(ctx, testBucket, "partial")
+	assert.NoError(t, err)
+	require.Len(t, objects, 1)
+	assert.Equal(t, "partial-extended", objects[0].Key)
+	err = client.FragmentedDeleteObject(ctx, testBucket, "partial")
+	assert.NoError(t, err)
+}
+
+func TestLocalClientConcurrentAccess(t *testing.T) {
+	tu.InitializeRandom()
+	client, _ := newTestLocalClient(t)
+	ctx := context.Background()
+
+	// Writers and deleters of keys sharing directories must not interfere with each other
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				key := fmt.Sprintf("shared/dir/%d-%d", i, j)
+				data := tu.RandomBytes(32)
+				err := client.UploadObject(ctx, testBucket, key, data)
+				assert.NoError(t, err)
+				downloaded, err := client.DownloadObject(ctx, testBucket, key)
+				assert.NoError(t, err)
+				assert.Equal(t, data, downloaded)
+				if j%2 == 0 {
+					err = client.DeleteObject(ctx, testBucket, key)
+					assert.NoError(t, err)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	objects, err := client.ListObjects(ctx, testBucket, "shared/")
+	assert.NoError(t, err)
+	assert.Len(t, objects, 8*25)
+}
@@ -26,8 +26,7 @@ type Client interface {
 	// smaller parts and uploaded in parallel. The file will be reassembled on download.
 	//
 	// Note: if a file is uploaded with this method, only the FragmentedDownloadObject method should be used to
-	// download the file. It is not advised to use DeleteObject on files uploaded with this method (if such
-	// functionality is required, a new method to do so should be added to this interface).
+	// download the file, and only the FragmentedDeleteObject method should be used to delete it.
 	//
 	// Note: if this operation fails partway through, some file fragments may have made it to S3 and others may not.
 	// In order to prevent long term accumulation of fragments, it is suggested to use this method in conjunction with
@@ -49,4 +48,9 @@ type Client interface {
 		key string,
 		fileSize int,
 		fragmentSize int) ([]byte, error)
+
+	// FragmentedDeleteObject deletes a file uploaded with the FragmentedUploadObject method. The fragments of the file
+	// are found by listing, so the fragments of a partially uploaded file are deleted too. Deleting a file that does not
+	// exist is not an error.
+	FragmentedDeleteObject(ctx context.Context, bucket string, key string) error
 }
@@ -0,0 +1,81 @@
+// Package fswatch watches files that are reloaded while a service is running, such as allowlists and webhook
+// subscriptions.
+package fswatch
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long WatchFile waits for file system events to settle before reloading. Editors and
+// deployment tooling often produce several events for a single logical change.
+const watchDebounce = 250 * time.Millisecond
+
+// WatchFile calls reload whenever the file at the given path may have changed, and additionally every interval in
+// case a file system event is missed (if interval is positive). It blocks until the context is cancelled. If the file
+// system can't be watched, WatchFile falls back to reloading every interval.
+//
+// The directory containing the file is watched rather than the file itself, since editors and tools such as
+// kubernetes replace files by renaming or swapping symlinks, which a watch on the file itself would not survive.
+// reload is therefore also called for changes to other files in the directory, and should be cheap when the file
+// hasn't changed.
+func WatchFile(ctx context.Context, logger logging.Logger, path string, interval time.Duration, reload func()) {
+	if path == "" {
+		return
+	}
+
+	var tick <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var events <-chan fsnotify.Event
+	var watchErrors <-chan error
+	watcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		err = watcher.Add(filepath.Dir(path))
+		if err != nil {
+			_ = watcher.Close()
+		}
+	}
+	if err != nil {
+		logger.Warn("failed to watch file, falling back to periodic reloads", "path", path, "err", err)
+	} else {
+		defer watcher.Close()
+		events = watcher.Events
+		watchErrors = watcher.Errors
+	}
+
+	debounce := time.NewTimer(watchDebounce)
+	if !debounce.Stop() {
+		<-debounce.C
+	}
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+			reload()
+		case <-events:
+			if !debounce.Stop() {
+				select {
+				case <-debounce.C:
+				default:
+				}
+			}
+			debounce.Reset(watchDebounce)
+		case <-debounce.C:
+			reload()
+		case err := <-watchErrors:
+			logger.Warn("error watching file", "path", path, "err", err)
+		}
+	}
+}
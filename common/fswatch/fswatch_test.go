@@ -0,0 +1,57 @@
+package fswatch_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/fswatch"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "subscriptions.json")
+	require.NoError(t, os.WriteFile(path, []byte("{}"), 0644))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reloads := atomic.Int32{}
+	done := make(chan struct{})
+	go func() {
+		// the periodic fallback is disabled so that every reload comes from a file system event
+		fswatch.WatchFile(ctx, logging.NewNoopLogger(), path, 0, func() {
+			reloads.Add(1)
+		})
+		close(done)
+	}()
+
+	// give the watcher time to start
+	time.Sleep(100 * time.Millisecond)
+
+	// editors often write a temporary file and rename it over the original
+	tmpPath := filepath.Join(dir, "subscriptions.json.tmp")
+	require.NoError(t, os.WriteFile(tmpPath, []byte("[]"), 0644))
+	require.NoError(t, os.Rename(tmpPath, path))
+
+	assert.Eventually(t, func() bool {
+		return reloads.Load() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// bursts of events are coalesced
+	time.Sleep(500 * time.Millisecond)
+	assert.Equal(t, int32(1), reloads.Load())
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("WatchFile did not return after the context was cancelled")
+	}
+}
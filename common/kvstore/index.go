@@ -0,0 +1,40 @@
+package kvstore
+
+import (
+	"errors"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+)
+
+// ErrIndexNotFound is returned when an index is not found.
+var ErrIndexNotFound = errors.New("index not found")
+
+// Index is a secondary index on a table in a TableStore. Each entry in the indexed table has at most one index key,
+// derived from its value. The TableStore keeps the index consistent with the indexed table, so that every write to
+// the table is atomically reflected in the index.
+//
+// The iterators returned by an Index walk over the entries of the indexed table in order of their index keys
+// (entries that share an index key are ordered by their keys). For each entry, Key() returns the key of the entry
+// in the indexed table (without internal metadata, i.e. the same as Key.Bytes()), and Value() returns its value.
+// Unlike the iterators returned by a Store, index iterators are not guaranteed to walk over a consistent snapshot:
+// an entry removed after the iterator is created may be skipped, and the value returned for an entry is the value
+// at the time that the iterator reaches it.
+type Index interface {
+	// Name returns the name of the index.
+	Name() string
+
+	// KeyBuilder returns the key builder of the indexed table.
+	KeyBuilder() KeyBuilder
+
+	// Lookup returns an iterator over the entries with the given index key.
+	// The iterator must be closed by calling Release() when done.
+	Lookup(indexKey []byte) (iterator.Iterator, error)
+
+	// NewIterator returns an iterator over the entries with an index key that begins with the given prefix.
+	// The iterator must be closed by calling Release() when done.
+	NewIterator(prefix []byte) (iterator.Iterator, error)
+
+	// NewRangeIterator returns an iterator over the entries with an index key in the range [start, end).
+	// A nil start or end leaves that side of the range unbounded. The iterator must be closed by calling
+	// Release() when done.
+	NewRangeIterator(start []byte, end []byte, options *IteratorOptions) (iterator.Iterator, error)
+}
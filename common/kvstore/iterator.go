@@ -0,0 +1,225 @@
+package kvstore
+
+import (
+	"bytes"
+
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// IteratorOptions configures the behavior of an iterator returned by NewRangeIterator.
+type IteratorOptions struct {
+	// Reverse causes the iterator to walk over keys in descending order. When set, Next moves to the
+	// next smaller key, Prev moves to the next larger key, First moves to the largest key in the range,
+	// Last moves to the smallest key in the range, and Seek moves to the largest key less than or equal
+	// to the requested key.
+	Reverse bool
+
+	// Limit is the maximum number of entries that can be visited by walking the iterator with Next.
+	// Calling First or Seek restarts the count. A value of 0 means that there is no limit.
+	Limit uint32
+}
+
+// WrapIterator applies the given options to an iterator that walks over keys in ascending order.
+// If options is nil, the base iterator is returned unmodified. Intended for use by Store implementations.
+func WrapIterator(base iterator.Iterator, options *IteratorOptions) iterator.Iterator {
+	if options == nil {
+		return base
+	}
+
+	it := base
+	if options.Reverse {
+		it = &reverseIterator{base: it}
+	}
+	if options.Limit > 0 {
+		it = &limitIterator{
+			base:  it,
+			limit: options.Limit,
+		}
+	}
+	return it
+}
+
+// PrefixEnd returns the smallest key that is larger than every key with the given prefix. This is the exclusive
+// upper bound to pass to NewRangeIterator in order to iterate over all keys with a prefix. Returns nil if no such
+// key exists (i.e. if the prefix is empty or consists only of 0xFF bytes), which NewRangeIterator interprets as
+// an unbounded range.
+func PrefixEnd(prefix []byte) []byte {
+	return util.BytesPrefix(prefix).Limit
+}
+
+var _ iterator.Iterator = &reverseIterator{}
+
+// reverseIterator walks over the entries of an ascending iterator in descending order.
+type reverseIterator struct {
+	base    iterator.Iterator
+	started bool
+}
+
+func (r *reverseIterator) First() bool {
+	r.started = true
+	return r.base.Last()
+}
+
+func (r *reverseIterator) Last() bool {
+	r.started = true
+	return r.base.First()
+}
+
+func (r *reverseIterator) Seek(key []byte) bool {
+	r.started = true
+	if !r.base.Seek(key) {
+		// All keys are smaller than the requested key.
+		return r.base.Last()
+	}
+	if bytes.Equal(r.base.Key(), key) {
+		return true
+	}
+	// The base iterator is positioned at the smallest key larger than the requested key.
+	return r.base.Prev()
+}
+
+func (r *reverseIterator) Next() bool {
+	if !r.started {
+		r.started = true
+		return r.base.Last()
+	}
+	return r.base.Prev()
+}
+
+func (r *reverseIterator) Prev() bool {
+	if !r.started {
+		r.started = true
+		return r.base.First()
+	}
+	return r.base.Next()
+}
+
+func (r *reverseIterator) Release() {
+	r.base.Release()
+}
+
+func (r *reverseIterator) SetReleaser(releaser util.Releaser) {
+	r.base.SetReleaser(releaser)
+}
+
+func (r *reverseIterator) Valid() bool {
+	return r.base.Valid()
+}
+
+func (r *reverseIterator) Error() error {
+	return r.base.Error()
+}
+
+func (r *reverseIterator) Key() []byte {
+	return r.base.Key()
+}
+
+func (r *reverseIterator) Value() []byte {
+	return r.base.Value()
+}
+
+var _ iterator.Iterator = &limitIterator{}
+
+// limitIterator stops an iterator after a fixed number of entries have been visited with Next.
+type limitIterator struct {
+	base iterator.Iterator
+	// limit is the maximum number of entries that may be visited.
+	limit uint32
+	// count is the number of entries visited so far, including the current entry.
+	count uint32
+	// exhausted is true if Next was called after the limit was reached.
+	exhausted bool
+}
+
+func (l *limitIterator) First() bool {
+	l.exhausted = false
+	if !l.base.First() {
+		l.count = 0
+		return false
+	}
+	l.count = 1
+	return true
+}
+
+func (l *limitIterator) Last() bool {
+	l.exhausted = false
+	if !l.base.Last() {
+		l.count = 0
+		return false
+	}
+	l.count = l.limit
+	return true
+}
+
+func (l *limitIterator) Seek(key []byte) bool {
+	l.exhausted = false
+	if !l.base.Seek(key) {
+		l.count = 0
+		return false
+	}
+	l.count = 1
+	return true
+}
+
+func (l *limitIterator) Next() bool {
+	if l.exhausted {
+		return false
+	}
+	if l.count >= l.limit {
+		l.exhausted = true
+		return false
+	}
+	if !l.base.Next() {
+		return false
+	}
+	l.count++
+	return true
+}
+
+func (l *limitIterator) Prev() bool {
+	if l.exhausted {
+		// The base iterator was never advanced past the last visited entry.
+		l.exhausted = false
+		return l.base.Valid()
+	}
+	if l.count <= 1 {
+		l.count = 0
+		return l.base.Prev()
+	}
+	if !l.base.Prev() {
+		return false
+	}
+	l.count--
+	return true
+}
+
+func (l *limitIterator) Release() {
+	l.base.Release()
+}
+
+func (l *limitIterator) SetReleaser(releaser util.Releaser) {
+	l.base.SetReleaser(releaser)
+}
+
+func (l *limitIterator) Valid() bool {
+	return !l.exhausted && l.base.Valid()
+}
+
+func (l *limitIterator) Error() error {
+	return l.base.Error()
+}
+
+func (l *limitIterator) Key() []byte {
+	if l.exhausted {
+		return nil
+	}
+	return l.base.Key()
+}
+
+func (l *limitIterator) Value() []byte {
+	if l.exhausted {
+		return nil
+	}
+	return l.base.Value()
+}
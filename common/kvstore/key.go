@@ -24,4 +24,14 @@ type KeyBuilder interface {
 	TableName() string
 	// Key creates a key from a byte slice.
 	Key(key []byte) Key
+	// Uint64Key creates a key from a uint64. The value is encoded in big-endian order, so the lexicographic order
+	// of the keys matches the numeric order of the values. Useful for scanning ranges of timestamps or block numbers.
+	Uint64Key(value uint64) Key
+	// PrefixRange returns a pair of keys that can be passed to NewRangeIterator in order to iterate over all keys
+	// in this table that begin with the given prefix. The end key is an exclusive bound, and is not guaranteed
+	// to be a key in this table.
+	PrefixRange(prefix []byte) (start Key, end Key)
+	// TableRange returns a pair of keys that can be passed to NewRangeIterator in order to iterate over
+	// all keys in this table. Equivalent to PrefixRange(nil).
+	TableRange() (start Key, end Key)
 }
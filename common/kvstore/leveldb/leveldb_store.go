@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"io/fs"
 	"os"
+	"path/filepath"
 
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
@@ -13,6 +15,7 @@ import (
 )
 
 var _ kvstore.Store[[]byte] = &levelDBStore{}
+var _ kvstore.StatsReporter = &levelDBStore{}
 
 // levelDBStore implements kvstore.Store interfaces with levelDB as the backend engine.
 type levelDBStore struct {
@@ -64,6 +67,16 @@ func (store *levelDBStore) NewIterator(prefix []byte) (iterator.Iterator, error)
 	return store.db.NewIterator(util.BytesPrefix(prefix), nil), nil
 }
 
+// NewRangeIterator creates a new iterator over the keys in the range [start, end).
+func (store *levelDBStore) NewRangeIterator(
+	start []byte,
+	end []byte,
+	options *kvstore.IteratorOptions) (iterator.Iterator, error) {
+
+	it := store.db.NewIterator(&util.Range{Start: start, Limit: end}, nil)
+	return kvstore.WrapIterator(it, options), nil
+}
+
 // Delete deletes data from the store.
 func (store *levelDBStore) Delete(key []byte) error {
 	return store.db.Delete(key, nil)
@@ -153,3 +166,78 @@ func (store *levelDBStore) Destroy() error {
 	}
 	return nil
 }
+
+// Compaction parameters used to estimate the compaction debt of a LevelDB store. These match the defaults used by
+// goleveldb when opening a store.
+const (
+	level0CompactionTrigger = 4
+	level1TargetSize        = 10 * 1024 * 1024
+	levelSizeMultiplier     = 10
+)
+
+// Stats returns statistics about the resource usage of the store. LevelDB does not track compaction debt, so it is
+// estimated from the amount by which each level exceeds its target size.
+func (store *levelDBStore) Stats() (*kvstore.Stats, error) {
+	dbStats := &leveldb.DBStats{}
+	err := store.db.Stats(dbStats)
+	if err != nil {
+		return nil, fmt.Errorf("error getting LevelDB stats: %w", err)
+	}
+
+	size, err := directorySize(store.path)
+	if err != nil {
+		return nil, err
+	}
+
+	compactionDebt := uint64(0)
+	targetSize := int64(level1TargetSize)
+	for level, levelSize := range dbStats.LevelSizes {
+		if level == 0 {
+			if dbStats.LevelTablesCounts[0] >= level0CompactionTrigger {
+				compactionDebt += uint64(levelSize)
+			}
+			continue
+		}
+		if levelSize > targetSize {
+			compactionDebt += uint64(levelSize - targetSize)
+		}
+		targetSize *= levelSizeMultiplier
+	}
+
+	return &kvstore.Stats{
+		Size:               size,
+		CompactionDebt:     compactionDebt,
+		WriteStalls:        uint64(dbStats.WriteDelayCount),
+		WriteStallDuration: dbStats.WriteDelayDuration,
+	}, nil
+}
+
+// directorySize returns the total size of the files in a directory.
+func directorySize(path string) (uint64, error) {
+	size := uint64(0)
+	err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Files are deleted by compaction while we walk the directory.
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("error computing size of %s: %w", path, err)
+	}
+	return size, nil
+}
@@ -10,6 +10,7 @@ import (
 )
 
 var _ kvstore.Store[[]byte] = &mapStore{}
+var _ kvstore.StatsReporter = &mapStore{}
 
 // mapStore is a simple in-memory implementation of KVStore. Designed more as a correctness test than a
 // production implementation -- there are things that may not be performant with this implementation.
@@ -132,6 +133,9 @@ func (m *batch) Size() uint32 {
 	return uint32(len(m.keys))
 }
 
+// mapIterator walks over a sorted copy of the keys in a mapStore. A currentIndex of -1 means that the iterator
+// is positioned before the first key, and a currentIndex of len(keys) means that the iterator is positioned
+// after the last key.
 type mapIterator struct {
 	keys         []string
 	values       map[string][]byte
@@ -139,46 +143,32 @@ type mapIterator struct {
 }
 
 func (it *mapIterator) First() bool {
-	if len(it.keys) == 0 {
-		return false
-	}
 	it.currentIndex = 0
-	return true
+	return it.Valid()
 }
 
 func (it *mapIterator) Last() bool {
-	if len(it.keys) == 0 {
-		return false
-	}
 	it.currentIndex = len(it.keys) - 1
-	return true
+	return it.Valid()
 }
 
 func (it *mapIterator) Seek(key []byte) bool {
-	// Not efficient. But then again, nothing is efficient in this iterator implementation.
-	for i, k := range it.keys {
-		if k == string(key) {
-			it.currentIndex = i
-			return true
-		}
-	}
-	return false
+	it.currentIndex = sort.SearchStrings(it.keys, string(key))
+	return it.Valid()
 }
 
 func (it *mapIterator) Next() bool {
-	if it.currentIndex == len(it.keys)-1 {
-		return false
+	if it.currentIndex < len(it.keys) {
+		it.currentIndex++
 	}
-	it.currentIndex++
-	return true
+	return it.Valid()
 }
 
 func (it *mapIterator) Prev() bool {
-	if it.currentIndex == 0 {
-		return false
+	if it.currentIndex >= 0 {
+		it.currentIndex--
 	}
-	it.currentIndex--
-	return true
+	return it.Valid()
 }
 
 func (it *mapIterator) Release() {
@@ -190,7 +180,7 @@ func (it *mapIterator) SetReleaser(releaser util.Releaser) {
 }
 
 func (it *mapIterator) Valid() bool {
-	return true
+	return it.currentIndex >= 0 && it.currentIndex < len(it.keys)
 }
 
 func (it *mapIterator) Error() error {
@@ -198,10 +188,16 @@ func (it *mapIterator) Error() error {
 }
 
 func (it *mapIterator) Key() []byte {
+	if !it.Valid() {
+		return nil
+	}
 	return []byte(it.keys[it.currentIndex])
 }
 
 func (it *mapIterator) Value() []byte {
+	if !it.Valid() {
+		return nil
+	}
 	return it.values[it.keys[it.currentIndex]]
 }
 
@@ -209,6 +205,22 @@ func (it *mapIterator) Value() []byte {
 // WARNING: this implementation does a full copy to return the iterator. This is not efficient.
 // Not for production use.
 func (store *mapStore) NewIterator(prefix []byte) (iterator.Iterator, error) {
+	return store.newIterator(util.BytesPrefix(prefix), nil), nil
+}
+
+// NewRangeIterator creates a new iterator over the keys in the range [start, end).
+// WARNING: this implementation does a full copy to return the iterator. This is not efficient.
+// Not for production use.
+func (store *mapStore) NewRangeIterator(
+	start []byte,
+	end []byte,
+	options *kvstore.IteratorOptions) (iterator.Iterator, error) {
+
+	return store.newIterator(&util.Range{Start: start, Limit: end}, options), nil
+}
+
+// newIterator copies all keys in the given range and returns an iterator over them.
+func (store *mapStore) newIterator(keyRange *util.Range, options *kvstore.IteratorOptions) iterator.Iterator {
 	store.lock.RLock()
 	defer store.lock.RUnlock()
 
@@ -216,22 +228,10 @@ func (store *mapStore) NewIterator(prefix []byte) (iterator.Iterator, error) {
 	keys := make([]string, 0, len(store.data))
 
 	for k, v := range store.data {
-		keyBytes := []byte(k)
-
-		if len(keyBytes) < len(prefix) {
-			// Key is shorter than the prefix, so it can't have the prefix
+		if keyRange.Start != nil && k < string(keyRange.Start) {
 			continue
 		}
-
-		// check if the key has the prefix
-		matchesPrefix := true
-		for i, b := range prefix {
-			if keyBytes[i] != b {
-				matchesPrefix = false
-				break
-			}
-		}
-		if !matchesPrefix {
+		if keyRange.Limit != nil && k >= string(keyRange.Limit) {
 			continue
 		}
 
@@ -240,15 +240,13 @@ func (store *mapStore) NewIterator(prefix []byte) (iterator.Iterator, error) {
 	}
 
 	// Iterator must walk over keys in lexicographical order
-	sort.Slice(keys, func(i, j int) bool {
-		return keys[i] < keys[j]
-	})
+	sort.Strings(keys)
 
-	return &mapIterator{
+	return kvstore.WrapIterator(&mapIterator{
 		keys:         keys,
 		values:       mapCopy,
 		currentIndex: -1,
-	}, nil
+	}, options)
 }
 
 // Get retrieves data from the mapStore. Returns nil if the data is not found.
@@ -281,3 +279,18 @@ func (store *mapStore) Shutdown() error {
 func (store *mapStore) Destroy() error {
 	return store.Shutdown()
 }
+
+// Stats returns statistics about the resource usage of the mapStore. The size of the store is the total size of
+// the keys and values it holds.
+func (store *mapStore) Stats() (*kvstore.Stats, error) {
+	store.lock.RLock()
+	defer store.lock.RUnlock()
+
+	size := uint64(0)
+	for key, value := range store.data {
+		size += uint64(len(key) + len(value))
+	}
+	return &kvstore.Stats{
+		Size: size,
+	}, nil
+}
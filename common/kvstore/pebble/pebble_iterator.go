@@ -0,0 +1,117 @@
+package pebble
+
+import (
+	"github.com/cockroachdb/pebble"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var _ iterator.Iterator = &pebbleIterator{}
+
+// pebbleIterator adapts a pebble iterator to the goleveldb iterator interface used by kvstore. Like a goleveldb
+// iterator, a fresh pebbleIterator is positioned before the first key, so that calling Next moves to the first key
+// and calling Prev moves to the last key.
+type pebbleIterator struct {
+	it *pebble.Iterator
+	// The store that created this iterator.
+	store *pebbleStore
+	// True once the iterator has been positioned.
+	started bool
+	// The error returned when closing the pebble iterator, if any.
+	err error
+	// True once the iterator has been released.
+	released bool
+	releaser util.Releaser
+}
+
+func (it *pebbleIterator) First() bool {
+	if it.released {
+		return false
+	}
+	it.started = true
+	return it.it.First()
+}
+
+func (it *pebbleIterator) Last() bool {
+	if it.released {
+		return false
+	}
+	it.started = true
+	return it.it.Last()
+}
+
+func (it *pebbleIterator) Seek(key []byte) bool {
+	if it.released {
+		return false
+	}
+	it.started = true
+	return it.it.SeekGE(key)
+}
+
+func (it *pebbleIterator) Next() bool {
+	if it.released {
+		return false
+	}
+	if !it.started {
+		return it.First()
+	}
+	return it.it.Next()
+}
+
+func (it *pebbleIterator) Prev() bool {
+	if it.released {
+		return false
+	}
+	if !it.started {
+		return it.Last()
+	}
+	return it.it.Prev()
+}
+
+func (it *pebbleIterator) Key() []byte {
+	if !it.Valid() {
+		return nil
+	}
+	return it.it.Key()
+}
+
+func (it *pebbleIterator) Value() []byte {
+	if !it.Valid() {
+		return nil
+	}
+	return it.it.Value()
+}
+
+func (it *pebbleIterator) Valid() bool {
+	return !it.released && it.started && it.it.Valid()
+}
+
+func (it *pebbleIterator) Error() error {
+	if it.released {
+		return it.err
+	}
+	return it.it.Error()
+}
+
+func (it *pebbleIterator) Release() {
+	if it.released {
+		return
+	}
+	it.released = true
+	it.err = it.it.Close()
+	it.store.releaseIterator(it)
+	if it.releaser != nil {
+		it.releaser.Release()
+		it.releaser = nil
+	}
+}
+
+func (it *pebbleIterator) SetReleaser(releaser util.Releaser) {
+	if it.released {
+		panic(util.ErrReleased)
+	}
+	if it.releaser != nil && releaser != nil {
+		panic(util.ErrHasReleaser)
+	}
+	it.releaser = releaser
+}
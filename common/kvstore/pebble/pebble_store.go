@@ -0,0 +1,317 @@
+package pebble
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var _ kvstore.Store[[]byte] = &pebbleStore{}
+var _ kvstore.StatsReporter = &pebbleStore{}
+
+// pebbleStore implements kvstore.Store interfaces with Pebble as the backend engine.
+type pebbleStore struct {
+	db   *pebble.DB
+	path string
+
+	logger logging.Logger
+
+	// Write stalls are reported by pebble as events, and are tallied here.
+	stallLock          sync.Mutex
+	writeStalls        uint64
+	writeStallDuration time.Duration
+	stallStart         time.Time
+
+	// Iterators that have not yet been released. Pebble refuses to close while iterators are open, so any iterators
+	// still open when the store is shut down are released first.
+	iteratorLock sync.Mutex
+	iterators    map[*pebbleIterator]struct{}
+
+	shutdown bool
+}
+
+// NewStore returns a new pebbleStore built using Pebble.
+func NewStore(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
+	store := &pebbleStore{
+		path:      path,
+		logger:    logger,
+		iterators: make(map[*pebbleIterator]struct{}),
+	}
+
+	options := &pebble.Options{
+		EventListener: &pebble.EventListener{
+			WriteStallBegin: store.writeStallBegin,
+			WriteStallEnd:   store.writeStallEnd,
+		},
+	}
+
+	db, err := pebble.Open(path, options)
+	if err != nil {
+		return nil, err
+	}
+	store.db = db
+
+	return store, nil
+}
+
+// writeStallBegin is called by pebble when writes begin to be stalled.
+func (store *pebbleStore) writeStallBegin(info pebble.WriteStallBeginInfo) {
+	store.stallLock.Lock()
+	defer store.stallLock.Unlock()
+
+	store.writeStalls++
+	store.stallStart = time.Now()
+	store.logger.Debug("pebble write stall", "reason", info.Reason)
+}
+
+// writeStallEnd is called by pebble when writes are no longer stalled.
+func (store *pebbleStore) writeStallEnd() {
+	store.stallLock.Lock()
+	defer store.stallLock.Unlock()
+
+	if !store.stallStart.IsZero() {
+		store.writeStallDuration += time.Since(store.stallStart)
+		store.stallStart = time.Time{}
+	}
+}
+
+// Put stores a data in the store.
+func (store *pebbleStore) Put(key []byte, value []byte) error {
+	if value == nil {
+		value = []byte{}
+	}
+	return store.db.Set(key, value, pebble.NoSync)
+}
+
+// Get retrieves data from the store. Returns kvstore.ErrNotFound if the data is not found.
+func (store *pebbleStore) Get(key []byte) ([]byte, error) {
+	data, closer, err := store.db.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, kvstore.ErrNotFound
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+
+	// The returned slice is only valid until the closer is closed.
+	dataCopy := make([]byte, len(data))
+	copy(dataCopy, data)
+	return dataCopy, nil
+}
+
+// NewIterator creates a new iterator. Only keys prefixed with the given prefix will be iterated.
+func (store *pebbleStore) NewIterator(prefix []byte) (iterator.Iterator, error) {
+	keyRange := util.BytesPrefix(prefix)
+	return store.newIterator(keyRange.Start, keyRange.Limit)
+}
+
+// NewRangeIterator creates a new iterator over the keys in the range [start, end).
+func (store *pebbleStore) NewRangeIterator(
+	start []byte,
+	end []byte,
+	options *kvstore.IteratorOptions) (iterator.Iterator, error) {
+
+	it, err := store.newIterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return kvstore.WrapIterator(it, options), nil
+}
+
+// newIterator creates a new iterator over the keys in the range [start, end). A nil bound is unbounded.
+func (store *pebbleStore) newIterator(start []byte, end []byte) (iterator.Iterator, error) {
+	it, err := store.db.NewIter(&pebble.IterOptions{
+		LowerBound: start,
+		UpperBound: end,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating pebble iterator: %w", err)
+	}
+
+	store.iteratorLock.Lock()
+	defer store.iteratorLock.Unlock()
+
+	pebbleIt := &pebbleIterator{
+		it:    it,
+		store: store,
+	}
+	store.iterators[pebbleIt] = struct{}{}
+	return pebbleIt, nil
+}
+
+// releaseIterator stops tracking an iterator once it has been released.
+func (store *pebbleStore) releaseIterator(it *pebbleIterator) {
+	store.iteratorLock.Lock()
+	defer store.iteratorLock.Unlock()
+
+	delete(store.iterators, it)
+}
+
+// Delete deletes data from the store.
+func (store *pebbleStore) Delete(key []byte) error {
+	return store.db.Delete(key, pebble.NoSync)
+}
+
+// DeleteBatch deletes multiple key-value pairs from the store.
+func (store *pebbleStore) DeleteBatch(keys [][]byte) error {
+	batch := store.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+	for _, key := range keys {
+		err := batch.Delete(key, nil)
+		if err != nil {
+			return err
+		}
+	}
+	return batch.Commit(pebble.NoSync)
+}
+
+// WriteBatch adds multiple key-value pairs to the store.
+func (store *pebbleStore) WriteBatch(keys [][]byte, values [][]byte) error {
+	batch := store.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+	for i, key := range keys {
+		err := batch.Set(key, values[i], nil)
+		if err != nil {
+			return err
+		}
+	}
+	return batch.Commit(pebble.NoSync)
+}
+
+// NewBatch creates a new batch for the store.
+func (store *pebbleStore) NewBatch() kvstore.Batch[[]byte] {
+	return &pebbleBatch{
+		store: store,
+		batch: store.db.NewBatch(),
+	}
+}
+
+type pebbleBatch struct {
+	store *pebbleStore
+	// The operations in the batch. A pebble batch can only be committed once, but a kvstore batch may be applied
+	// several times, so this batch is never committed. Instead, a copy of it is committed each time Apply is called.
+	batch *pebble.Batch
+	// The first error encountered while adding an operation to the batch, returned by Apply.
+	err error
+}
+
+func (m *pebbleBatch) Put(key []byte, value []byte) {
+	if value == nil {
+		value = []byte{}
+	}
+	err := m.batch.Set(key, value, nil)
+	if err != nil && m.err == nil {
+		m.err = err
+	}
+}
+
+func (m *pebbleBatch) Delete(key []byte) {
+	err := m.batch.Delete(key, nil)
+	if err != nil && m.err == nil {
+		m.err = err
+	}
+}
+
+func (m *pebbleBatch) Apply() error {
+	if m.err != nil {
+		return m.err
+	}
+
+	batch := m.store.db.NewBatch()
+	defer func() {
+		_ = batch.Close()
+	}()
+	err := batch.Apply(m.batch, nil)
+	if err != nil {
+		return err
+	}
+	return batch.Commit(pebble.NoSync)
+}
+
+// Size returns the number of operations in the batch.
+func (m *pebbleBatch) Size() uint32 {
+	return m.batch.Count()
+}
+
+// Stats returns statistics about the resource usage of the store.
+func (store *pebbleStore) Stats() (*kvstore.Stats, error) {
+	metrics := store.db.Metrics()
+
+	store.stallLock.Lock()
+	writeStalls := store.writeStalls
+	writeStallDuration := store.writeStallDuration
+	if !store.stallStart.IsZero() {
+		writeStallDuration += time.Since(store.stallStart)
+	}
+	store.stallLock.Unlock()
+
+	return &kvstore.Stats{
+		Size:               metrics.DiskSpaceUsage(),
+		CompactionDebt:     metrics.Compact.EstimatedDebt,
+		WriteStalls:        writeStalls,
+		WriteStallDuration: writeStallDuration,
+	}, nil
+}
+
+// Shutdown shuts down the store.
+//
+// Warning: it is not thread safe to call this method concurrently with other methods on this class,
+// or while there exist unclosed iterators.
+func (store *pebbleStore) Shutdown() error {
+	store.iteratorLock.Lock()
+	iterators := make([]*pebbleIterator, 0, len(store.iterators))
+	for it := range store.iterators {
+		iterators = append(iterators, it)
+	}
+	store.iteratorLock.Unlock()
+
+	if len(iterators) > 0 {
+		store.logger.Warn("releasing iterators that were not released before shutdown", "count", len(iterators))
+		for _, it := range iterators {
+			it.Release()
+		}
+	}
+
+	err := store.db.Close()
+	if err != nil {
+		return err
+	}
+
+	store.shutdown = true
+	return nil
+}
+
+// Destroy destroys the store.
+//
+// Warning: it is not thread safe to call this method concurrently with other methods on this class,
+// or while there exist unclosed iterators.
+func (store *pebbleStore) Destroy() error {
+	if !store.shutdown {
+		err := store.Shutdown()
+		if err != nil {
+			return err
+		}
+	}
+
+	store.logger.Info(fmt.Sprintf("destroying Pebble store at path: %s", store.path))
+	err := os.RemoveAll(store.path)
+	if err != nil {
+		return err
+	}
+	return nil
+}
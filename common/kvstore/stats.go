@@ -0,0 +1,24 @@
+package kvstore
+
+import "time"
+
+// Stats describes the resource usage of a store. Statistics that a store implementation does not track are zero.
+type Stats struct {
+	// The approximate number of bytes used by the store. For persistent stores this is the size of the files on disk,
+	// for in-memory stores this is the size of the keys and values held in memory.
+	Size uint64
+	// An estimate of the number of bytes that must be compacted before the store is back in a healthy shape.
+	// A growing compaction debt means that compaction is not keeping up with writes.
+	CompactionDebt uint64
+	// The number of times writes have been stalled or delayed to allow compaction to catch up, since the store
+	// was opened.
+	WriteStalls uint64
+	// The total time writes have spent stalled or delayed, since the store was opened.
+	WriteStallDuration time.Duration
+}
+
+// StatsReporter is implemented by stores that are able to report statistics about their resource usage.
+type StatsReporter interface {
+	// Stats returns statistics about the resource usage of the store.
+	Stats() (*Stats, error)
+}
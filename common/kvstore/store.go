@@ -34,6 +34,14 @@ type Store[K any] interface {
 	// of the database, so it will not see any writes that occur after the iterator is created.
 	NewIterator(prefix K) (iterator.Iterator, error)
 
+	// NewRangeIterator returns an iterator over the keys in the range [start, end). A nil start means that the
+	// range begins at the smallest key, and a nil end means that the range extends through the largest key.
+	// The iterator must be closed by calling Release() when done. Keys are returned in lexicographically sorted
+	// order unless options request reverse iteration, and the number of keys returned may be bounded by
+	// options.Limit. A nil options is equivalent to the default options. Like NewIterator, the iterator walks
+	// over a consistent snapshot of the database.
+	NewRangeIterator(start K, end K, options *IteratorOptions) (iterator.Iterator, error)
+
 	// Shutdown shuts down the store, flushing any remaining data to disk.
 	//
 	// Warning: it is not thread safe to call this method concurrently with other methods on this class,
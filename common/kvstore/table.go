@@ -3,6 +3,7 @@ package kvstore
 import (
 	"errors"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"io"
 	"time"
 )
 
@@ -54,4 +55,12 @@ type TableStore interface {
 	// NewTableIterator returns an iterator that can be used to iterate over all keys in a table.
 	// Equivalent to NewIterator(keyBuilder.Key([]byte{})).
 	NewTableIterator(keyBuilder KeyBuilder) (iterator.Iterator, error)
+
+	// GetIndex gets a secondary index on a table. Returns ErrIndexNotFound if the index does not exist.
+	GetIndex(tableName string, indexName string) (Index, error)
+
+	// Snapshot writes a point-in-time snapshot of all tables to the given writer, including the expiration times
+	// of keys. The snapshot can be taken while the store is in use. Data written after this method is called is not
+	// guaranteed to be included in the snapshot.
+	Snapshot(writer io.Writer) error
 }
@@ -1,6 +1,10 @@
 package tablestore
 
-import "time"
+import (
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"time"
+)
 
 // StoreType describes the underlying store implementation.
 type StoreType int
@@ -10,15 +14,43 @@ const (
 	LevelDB StoreType = iota
 	// MapStore is an in-memory store. This store does not preserve data across restarts.
 	MapStore
+	// Pebble is a Pebble-backed store. Pebble handles large write batches more smoothly than LevelDB.
+	Pebble
 )
 
+// String returns the name of the store type.
+func (t StoreType) String() string {
+	switch t {
+	case LevelDB:
+		return "leveldb"
+	case MapStore:
+		return "mapstore"
+	case Pebble:
+		return "pebble"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(t))
+	}
+}
+
+// ParseStoreType parses the name of a persistent store type, as returned by StoreType.String.
+func ParseStoreType(name string) (StoreType, error) {
+	switch name {
+	case LevelDB.String():
+		return LevelDB, nil
+	case Pebble.String():
+		return Pebble, nil
+	default:
+		return 0, fmt.Errorf("unknown store type: %s", name)
+	}
+}
+
 // Config is the configuration for a TableStore.
 type Config struct {
 	// The type of the base store. Default is LevelDB.
 	Type StoreType
 	// The path to the file system directory where the store will write its data. Default is nil.
 	// Some store implementations may ignore this field (e.g. MapStore). Other store implementations may require
-	// this field to be set (e.g. LevelDB).
+	// this field to be set (e.g. LevelDB, Pebble).
 	Path *string
 	// If true, the store will perform garbage collection on a background goroutine. Default is true.
 	GarbageCollectionEnabled bool
@@ -30,6 +62,29 @@ type Config struct {
 	// The list of tables to create on startup. Any pre-existing table not in this list will be deleted. If
 	// this list is nil, the previous schema will be carried forward with no modifications. Default is nil.
 	Schema []string
+	// The secondary indices to maintain. Each index is backed by an internal table that is created when the index
+	// is first configured (and populated from the existing contents of the indexed table), and that is deleted
+	// when the index is no longer configured. Default is nil.
+	Indices []*IndexConfig
+	// The registry to register the store's metrics with. If nil, no metrics are collected. Default is nil.
+	MetricsRegistry *prometheus.Registry
+	// The name of the store, used to label its metrics. Stores that share a registry must have different names.
+	// Default is "".
+	MetricsName string
+}
+
+// IndexFunction computes the index key of a value stored in an indexed table. If the returned index key is nil then
+// the value is omitted from the index. An error returned by an IndexFunction causes the write to fail.
+type IndexFunction func(value []byte) ([]byte, error)
+
+// IndexConfig describes a secondary index on a table.
+type IndexConfig struct {
+	// The name of the table to index. The table must be in the schema.
+	TableName string
+	// The name of the index. Must be unique among the indices of a table.
+	Name string
+	// Computes the index key of each value in the table.
+	IndexFunction IndexFunction
 }
 
 // DefaultConfig returns a Config with default values.
@@ -52,6 +107,14 @@ func DefaultLevelDBConfig(path string) *Config {
 	return config
 }
 
+// DefaultPebbleConfig returns a Config with default values for a Pebble store.
+func DefaultPebbleConfig(path string) *Config {
+	config := DefaultConfig()
+	config.Type = Pebble
+	config.Path = &path
+	return config
+}
+
 // DefaultMapStoreConfig returns a Config with default values for a MapStore.
 func DefaultMapStoreConfig() *Config {
 	config := DefaultConfig()
@@ -0,0 +1,455 @@
+package tablestore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"strings"
+	"sync"
+)
+
+// The names of the internal tables that back secondary indices begin with this prefix.
+// Tables in the schema are not permitted to begin with this prefix.
+const indexTablePrefix = "__index__:"
+
+// When an index is being built, a marker is stored in the metadata table under this prefix followed by the name
+// of the index table. This is required to detect and complete an index build that was interrupted by a crash.
+const metadataIndexBuildPrefix = "index_build:"
+
+// The maximum number of operations in a batch when building an index.
+const indexBuildBatchSize = 1024
+
+// Within the key of an index entry, the index key is separated from the key of the indexed entry by this terminator.
+// Since 0x00 bytes within the index key are escaped as 0x00 0xFF, the terminator can't appear within the
+// escaped index key, and it sorts before any continuation of the index key. This preserves the order of index keys.
+var indexKeyTerminator = []byte{0x00, 0x01}
+
+// indexTableName returns the name of the table that backs an index.
+func indexTableName(tableName string, indexName string) string {
+	return indexTablePrefix + tableName + ":" + indexName
+}
+
+// isIndexTable returns true if the table with the given name backs an index.
+func isIndexTable(tableName string) bool {
+	return strings.HasPrefix(tableName, indexTablePrefix)
+}
+
+// escapeIndexKey escapes every 0x00 byte in an index key as 0x00 0xFF. This escaping preserves both the order and
+// the prefixes of index keys.
+func escapeIndexKey(indexKey []byte) []byte {
+	escaped := make([]byte, 0, len(indexKey)+len(indexKeyTerminator))
+	for _, b := range indexKey {
+		if b == 0x00 {
+			escaped = append(escaped, 0x00, 0xFF)
+		} else {
+			escaped = append(escaped, b)
+		}
+	}
+	return escaped
+}
+
+// parseIndexEntryKey extracts the key of the indexed entry (without the table prefix) from the key of an index entry
+// (without the index table prefix).
+func parseIndexEntryKey(entryKey []byte) ([]byte, error) {
+	for i := 0; i+1 < len(entryKey); i++ {
+		if entryKey[i] != 0x00 {
+			continue
+		}
+		if entryKey[i+1] == indexKeyTerminator[1] {
+			return entryKey[i+2:], nil
+		}
+		// Skip the escaped byte
+		i++
+	}
+	return nil, fmt.Errorf("malformed index entry key %x", entryKey)
+}
+
+var _ kvstore.Index = &index{}
+
+// index is a secondary index on a table.
+type index struct {
+	// the name of the index
+	name string
+	// the base store
+	base kvstore.Store[[]byte]
+	// the key builder of the indexed table
+	tableKeyBuilder *keyBuilder
+	// the key builder of the table that holds the index entries
+	indexKeyBuilder *keyBuilder
+	// computes the index key of a value in the indexed table
+	indexFunction IndexFunction
+}
+
+// Name returns the name of the index.
+func (i *index) Name() string {
+	return i.name
+}
+
+// KeyBuilder returns the key builder of the indexed table.
+func (i *index) KeyBuilder() kvstore.KeyBuilder {
+	return i.tableKeyBuilder
+}
+
+// entryKey returns the raw key of the index entry for an entry in the indexed table.
+func (i *index) entryKey(indexKey []byte, rawKey []byte) []byte {
+	data := escapeIndexKey(indexKey)
+	data = append(data, indexKeyTerminator...)
+	data = append(data, rawKey[prefixLength:]...)
+	return i.indexKeyBuilder.Key(data).Raw()
+}
+
+// Lookup returns an iterator over the entries with the given index key.
+func (i *index) Lookup(indexKey []byte) (iterator.Iterator, error) {
+	start := append(i.indexKeyBuilder.Key(escapeIndexKey(indexKey)).Raw(), indexKeyTerminator...)
+	return i.newIterator(&util.Range{Start: start, Limit: kvstore.PrefixEnd(start)}, nil)
+}
+
+// NewIterator returns an iterator over the entries with an index key that begins with the given prefix.
+func (i *index) NewIterator(prefix []byte) (iterator.Iterator, error) {
+	return i.newIterator(util.BytesPrefix(i.indexKeyBuilder.Key(escapeIndexKey(prefix)).Raw()), nil)
+}
+
+// NewRangeIterator returns an iterator over the entries with an index key in the range [start, end).
+func (i *index) NewRangeIterator(
+	start []byte,
+	end []byte,
+	options *kvstore.IteratorOptions) (iterator.Iterator, error) {
+
+	startKey, endKey := i.indexKeyBuilder.TableRange()
+	if start != nil {
+		startKey = i.indexKeyBuilder.Key(escapeIndexKey(start))
+	}
+	if end != nil {
+		endKey = i.indexKeyBuilder.Key(escapeIndexKey(end))
+	}
+	return i.newIterator(&util.Range{Start: startKey.Raw(), Limit: endKey.Raw()}, options)
+}
+
+// newIterator returns an iterator over the index entries with raw keys in the given range.
+func (i *index) newIterator(keyRange *util.Range, options *kvstore.IteratorOptions) (iterator.Iterator, error) {
+	it, err := i.base.NewRangeIterator(keyRange.Start, keyRange.Limit, options)
+	if err != nil {
+		return nil, err
+	}
+	return &indexIterator{
+		index:   i,
+		base:    it,
+		reverse: options != nil && options.Reverse,
+	}, nil
+}
+
+// indexSet holds the secondary indices of a table store.
+type indexSet struct {
+	// Serializes writes to indexed tables, so that the index entries to remove are computed from up-to-date values.
+	lock sync.Mutex
+	// A map from table prefixes to the indices on that table.
+	byPrefix map[string][]*index
+	// A map from table names to index names to indices.
+	byName map[string]map[string]*index
+}
+
+// newIndexSet creates the set of indices described by the given configuration.
+func newIndexSet(
+	base kvstore.Store[[]byte],
+	keyBuilderMap map[string]kvstore.KeyBuilder,
+	indexConfigs []*IndexConfig) (*indexSet, error) {
+
+	indices := &indexSet{
+		byPrefix: make(map[string][]*index),
+		byName:   make(map[string]map[string]*index),
+	}
+
+	for _, indexConfig := range indexConfigs {
+		tableKeyBuilder, ok := keyBuilderMap[indexConfig.TableName]
+		if !ok {
+			return nil, fmt.Errorf("table %s not found", indexConfig.TableName)
+		}
+		indexKeyBuilder, ok := keyBuilderMap[indexTableName(indexConfig.TableName, indexConfig.Name)]
+		if !ok {
+			return nil, fmt.Errorf("table for index %s on table %s not found",
+				indexConfig.Name, indexConfig.TableName)
+		}
+
+		idx := &index{
+			name:            indexConfig.Name,
+			base:            base,
+			tableKeyBuilder: tableKeyBuilder.(*keyBuilder),
+			indexKeyBuilder: indexKeyBuilder.(*keyBuilder),
+			indexFunction:   indexConfig.IndexFunction,
+		}
+
+		prefix := string(idx.tableKeyBuilder.prefix)
+		indices.byPrefix[prefix] = append(indices.byPrefix[prefix], idx)
+		if indices.byName[indexConfig.TableName] == nil {
+			indices.byName[indexConfig.TableName] = make(map[string]*index)
+		}
+		indices.byName[indexConfig.TableName][indexConfig.Name] = idx
+	}
+
+	return indices, nil
+}
+
+// get returns the index with the given name on the given table, or nil if there is no such index.
+func (s *indexSet) get(tableName string, indexName string) *index {
+	return s.byName[tableName][indexName]
+}
+
+// indicesFor returns the indices on the table that contains the key with the given raw value.
+func (s *indexSet) indicesFor(rawKey []byte) []*index {
+	if len(s.byPrefix) == 0 || len(rawKey) < prefixLength {
+		return nil
+	}
+	return s.byPrefix[string(rawKey[:prefixLength])]
+}
+
+// indexedWrite is a write to an indexed table.
+type indexedWrite struct {
+	rawKey  []byte
+	value   []byte
+	deleted bool
+}
+
+// updateIndices adds operations to a batch that bring the indices up to date with the given writes. The caller must
+// hold the index lock until the batch is applied, and each key may appear in at most one write.
+func (s *indexSet) updateIndices(
+	base kvstore.Store[[]byte],
+	batch kvstore.Batch[[]byte],
+	writes []*indexedWrite) error {
+
+	for _, write := range writes {
+		oldValue, err := base.Get(write.rawKey)
+		oldValueExists := true
+		if errors.Is(err, kvstore.ErrNotFound) {
+			oldValueExists = false
+		} else if err != nil {
+			return fmt.Errorf("error reading previous value: %w", err)
+		}
+
+		for _, idx := range s.indicesFor(write.rawKey) {
+			if oldValueExists {
+				oldIndexKey, err := idx.indexFunction(oldValue)
+				if err != nil {
+					return fmt.Errorf("error computing previous key for index %s: %w", idx.name, err)
+				}
+				if oldIndexKey != nil {
+					batch.Delete(idx.entryKey(oldIndexKey, write.rawKey))
+				}
+			}
+			if !write.deleted {
+				newIndexKey, err := idx.indexFunction(write.value)
+				if err != nil {
+					return fmt.Errorf("error computing key for index %s: %w", idx.name, err)
+				}
+				if newIndexKey != nil {
+					batch.Put(idx.entryKey(newIndexKey, write.rawKey), []byte{})
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// buildIndex populates an index from the contents of the indexed table, discarding any existing index entries.
+// A marker is kept in the metadata table while the build is in progress, so that an interrupted build is
+// restarted the next time the store is started.
+func buildIndex(
+	base kvstore.Store[[]byte],
+	metadataKeyBuilder kvstore.KeyBuilder,
+	idx *index) error {
+
+	markerKey := metadataKeyBuilder.Key([]byte(metadataIndexBuildPrefix + idx.indexKeyBuilder.tableName))
+	err := base.Put(markerKey.Raw(), []byte{})
+	if err != nil {
+		return fmt.Errorf("error setting index build marker: %w", err)
+	}
+
+	// Discard entries left behind by an interrupted build.
+	clearEntry := func(rawKey []byte, value []byte, batch kvstore.Batch[[]byte]) error {
+		batch.Delete(rawKey)
+		return nil
+	}
+	err = forEachInTable(base, idx.indexKeyBuilder, clearEntry)
+	if err != nil {
+		return fmt.Errorf("error clearing index %s: %w", idx.name, err)
+	}
+
+	indexEntry := func(rawKey []byte, value []byte, batch kvstore.Batch[[]byte]) error {
+		indexKey, err := idx.indexFunction(value)
+		if err != nil {
+			return fmt.Errorf("error computing index key: %w", err)
+		}
+		if indexKey != nil {
+			batch.Put(idx.entryKey(indexKey, rawKey), []byte{})
+		}
+		return nil
+	}
+	err = forEachInTable(base, idx.tableKeyBuilder, indexEntry)
+	if err != nil {
+		return fmt.Errorf("error populating index %s: %w", idx.name, err)
+	}
+
+	return base.Delete(markerKey.Raw())
+}
+
+// forEachInTable calls a function for each entry in a table, passing a batch in which to write. The batch is
+// periodically applied.
+func forEachInTable(
+	base kvstore.Store[[]byte],
+	builder *keyBuilder,
+	f func(rawKey []byte, value []byte, batch kvstore.Batch[[]byte]) error) error {
+
+	it, err := base.NewIterator(builder.prefix)
+	if err != nil {
+		return err
+	}
+	defer it.Release()
+
+	batch := base.NewBatch()
+	for it.Next() {
+		err = f(bytes.Clone(it.Key()), it.Value(), batch)
+		if err != nil {
+			return err
+		}
+		if batch.Size() >= indexBuildBatchSize {
+			err = batch.Apply()
+			if err != nil {
+				return err
+			}
+			batch = base.NewBatch()
+		}
+	}
+	if err = it.Error(); err != nil {
+		return err
+	}
+
+	if batch.Size() > 0 {
+		return batch.Apply()
+	}
+	return nil
+}
+
+// loadIndexBuildMarkers returns the names of the index tables with a build in progress.
+func loadIndexBuildMarkers(
+	base kvstore.Store[[]byte],
+	metadataKeyBuilder kvstore.KeyBuilder) (map[string]bool, error) {
+
+	prefix := metadataKeyBuilder.Key([]byte(metadataIndexBuildPrefix)).Raw()
+	it, err := base.NewIterator(prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	markers := make(map[string]bool)
+	for it.Next() {
+		markers[string(it.Key()[len(prefix):])] = true
+	}
+	return markers, it.Error()
+}
+
+var _ iterator.Iterator = &indexIterator{}
+
+// indexIterator walks over the index entries of an index, and resolves each to an entry in the indexed table.
+type indexIterator struct {
+	index *index
+	// iterates over the index entries
+	base iterator.Iterator
+	// true if the base iterator walks over index entries in descending order
+	reverse bool
+
+	// the key (without table prefix) and value of the current entry in the indexed table
+	key   []byte
+	value []byte
+	err   error
+}
+
+// resolve looks up the entry in the indexed table that the current index entry refers to. Entries that have been
+// removed since the iterator was created are skipped by moving forward (or backward if forward is false).
+func (it *indexIterator) resolve(found bool, forward bool) bool {
+	it.key = nil
+	it.value = nil
+
+	for found {
+		key, err := parseIndexEntryKey(it.base.Key()[prefixLength:])
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		value, err := it.index.base.Get(it.index.tableKeyBuilder.Key(key).Raw())
+		if err == nil {
+			it.key = key
+			it.value = value
+			return true
+		}
+		if !errors.Is(err, kvstore.ErrNotFound) {
+			it.err = err
+			return false
+		}
+
+		if forward {
+			found = it.base.Next()
+		} else {
+			found = it.base.Prev()
+		}
+	}
+	return false
+}
+
+func (it *indexIterator) First() bool {
+	return it.resolve(it.base.First(), true)
+}
+
+func (it *indexIterator) Last() bool {
+	return it.resolve(it.base.Last(), false)
+}
+
+// Seek moves to the first entry with an index key that is greater than or equal to the given index key, or for
+// a reverse iterator, to the last entry with an index key that is less than or equal to the given index key.
+func (it *indexIterator) Seek(indexKey []byte) bool {
+	target := it.index.indexKeyBuilder.Key(escapeIndexKey(indexKey)).Raw()
+	if it.reverse {
+		// Sorts after all entries with this index key, but before any entry with a larger index key.
+		target = append(target, 0x00, indexKeyTerminator[1]+1)
+	}
+	return it.resolve(it.base.Seek(target), true)
+}
+
+func (it *indexIterator) Next() bool {
+	return it.resolve(it.base.Next(), true)
+}
+
+func (it *indexIterator) Prev() bool {
+	return it.resolve(it.base.Prev(), false)
+}
+
+func (it *indexIterator) Release() {
+	it.base.Release()
+}
+
+func (it *indexIterator) SetReleaser(releaser util.Releaser) {
+	it.base.SetReleaser(releaser)
+}
+
+func (it *indexIterator) Valid() bool {
+	return it.err == nil && it.key != nil
+}
+
+func (it *indexIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.base.Error()
+}
+
+func (it *indexIterator) Key() []byte {
+	return it.key
+}
+
+func (it *indexIterator) Value() []byte {
+	return it.value
+}
@@ -0,0 +1,403 @@
+package tablestore
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+// Values in the indexed test tables are an 8 byte "owner" followed by arbitrary data. Values with an owner of zero
+// are omitted from the owner index, and values shorter than 8 bytes can't be indexed.
+func ownerIndexFunction(value []byte) ([]byte, error) {
+	if len(value) < 8 {
+		return nil, errors.New("value too short")
+	}
+	if binary.BigEndian.Uint64(value) == 0 {
+		return nil, nil
+	}
+	return value[:8], nil
+}
+
+func ownerValue(owner uint64, data []byte) []byte {
+	value := make([]byte, 8+len(data))
+	binary.BigEndian.PutUint64(value, owner)
+	copy(value[8:], data)
+	return value
+}
+
+func ownerIndexConfig() *IndexConfig {
+	return &IndexConfig{
+		TableName:     "blobs",
+		Name:          "owner",
+		IndexFunction: ownerIndexFunction,
+	}
+}
+
+// collectIndexKeys walks over an index iterator and returns the keys it visits.
+func collectIndexKeys(t *testing.T, it iterator.Iterator) []string {
+	keys := make([]string, 0)
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+	}
+	assert.NoError(t, it.Error())
+	it.Release()
+	return keys
+}
+
+// verifyIndex checks that an index contains exactly the expected entries, in the expected order.
+func verifyIndex(t *testing.T, idx kvstore.Index, expected map[string][]byte) {
+	type entry struct {
+		owner uint64
+		key   string
+	}
+	entries := make([]entry, 0)
+	for key, value := range expected {
+		owner := binary.BigEndian.Uint64(value)
+		if owner != 0 {
+			entries = append(entries, entry{owner: owner, key: key})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].owner != entries[j].owner {
+			return entries[i].owner < entries[j].owner
+		}
+		return entries[i].key < entries[j].key
+	})
+
+	it, err := idx.NewIterator(nil)
+	assert.NoError(t, err)
+	defer it.Release()
+
+	for _, e := range entries {
+		assert.True(t, it.Next())
+		assert.Equal(t, e.key, string(it.Key()))
+		assert.Equal(t, expected[e.key], it.Value())
+	}
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Error())
+}
+
+func TestIndexLookups(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	config := DefaultMapStoreConfig()
+	config.Schema = []string{"blobs", "other"}
+	config.Indices = []*IndexConfig{ownerIndexConfig()}
+	store, err := Start(logger, config)
+	assert.NoError(t, err)
+
+	kb, err := store.GetKeyBuilder("blobs")
+	assert.NoError(t, err)
+
+	// Index tables are internal
+	assert.ElementsMatch(t, []string{"blobs", "other"}, store.GetTables())
+	assert.Equal(t, 2, len(store.GetKeyBuilders()))
+
+	idx, err := store.GetIndex("blobs", "owner")
+	assert.NoError(t, err)
+	assert.Equal(t, "owner", idx.Name())
+	assert.Equal(t, "blobs", idx.KeyBuilder().TableName())
+
+	_, err = store.GetIndex("blobs", "missing")
+	assert.ErrorIs(t, err, kvstore.ErrIndexNotFound)
+	_, err = store.GetIndex("other", "owner")
+	assert.ErrorIs(t, err, kvstore.ErrIndexNotFound)
+
+	err = store.Put(kb.Key([]byte("a")), ownerValue(1, []byte("a")))
+	assert.NoError(t, err)
+	err = store.Put(kb.Key([]byte("b")), ownerValue(2, []byte("b")))
+	assert.NoError(t, err)
+	err = store.Put(kb.Key([]byte("c")), ownerValue(1, []byte("c")))
+	assert.NoError(t, err)
+	err = store.Put(kb.Key([]byte("d")), ownerValue(0, []byte("d")))
+	assert.NoError(t, err)
+	// An index key that contains 0x00 bytes, and a key that is a prefix of another key
+	err = store.Put(kb.Key([]byte("e")), ownerValue(256, []byte("e")))
+	assert.NoError(t, err)
+	err = store.Put(kb.Key([]byte("ee")), ownerValue(256, []byte("ee")))
+	assert.NoError(t, err)
+
+	it, err := idx.Lookup(ownerValue(1, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "c"}, collectIndexKeys(t, it))
+
+	it, err = idx.Lookup(ownerValue(256, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"e", "ee"}, collectIndexKeys(t, it))
+
+	// Values with a nil index key are not indexed
+	it, err = idx.Lookup(ownerValue(0, nil))
+	assert.NoError(t, err)
+	assert.Empty(t, collectIndexKeys(t, it))
+
+	// Prefix of the index key
+	it, err = idx.NewIterator(make([]byte, 7))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "c", "b"}, collectIndexKeys(t, it))
+
+	// Range of index keys
+	it, err = idx.NewRangeIterator(ownerValue(2, nil), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "e", "ee"}, collectIndexKeys(t, it))
+
+	it, err = idx.NewRangeIterator(nil, ownerValue(256, nil), &kvstore.IteratorOptions{Reverse: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "c", "a"}, collectIndexKeys(t, it))
+
+	it, err = idx.NewRangeIterator(nil, nil, &kvstore.IteratorOptions{Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "c"}, collectIndexKeys(t, it))
+
+	// Seeking by index key
+	it, err = idx.NewRangeIterator(nil, nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, it.Seek(ownerValue(2, nil)))
+	assert.Equal(t, "b", string(it.Key()))
+	assert.Equal(t, ownerValue(2, []byte("b")), it.Value())
+	it.Release()
+
+	it, err = idx.NewRangeIterator(nil, nil, &kvstore.IteratorOptions{Reverse: true})
+	assert.NoError(t, err)
+	assert.True(t, it.Seek(ownerValue(1, nil)))
+	assert.Equal(t, "c", string(it.Key()))
+	assert.True(t, it.Next())
+	assert.Equal(t, "a", string(it.Key()))
+	assert.False(t, it.Next())
+	it.Release()
+
+	// Changing the index key of a value moves it within the index
+	err = store.Put(kb.Key([]byte("a")), ownerValue(2, []byte("a")))
+	assert.NoError(t, err)
+	it, err = idx.Lookup(ownerValue(1, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c"}, collectIndexKeys(t, it))
+	it, err = idx.Lookup(ownerValue(2, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, collectIndexKeys(t, it))
+
+	// Deleting a value removes it from the index
+	err = store.Delete(kb.Key([]byte("b")))
+	assert.NoError(t, err)
+	it, err = idx.Lookup(ownerValue(2, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, collectIndexKeys(t, it))
+
+	// Values that can't be indexed are rejected
+	err = store.Put(kb.Key([]byte("f")), []byte("short"))
+	assert.Error(t, err)
+	_, err = store.Get(kb.Key([]byte("f")))
+	assert.ErrorIs(t, err, kvstore.ErrNotFound)
+
+	err = store.Destroy()
+	assert.NoError(t, err)
+}
+
+func TestIndexRandomBatches(t *testing.T) {
+	tu.InitializeRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	config := DefaultMapStoreConfig()
+	config.Schema = []string{"blobs"}
+	config.Indices = []*IndexConfig{ownerIndexConfig()}
+	config.GarbageCollectionEnabled = false
+	store, err := Start(logger, config)
+	assert.NoError(t, err)
+
+	kb, err := store.GetKeyBuilder("blobs")
+	assert.NoError(t, err)
+	idx, err := store.GetIndex("blobs", "owner")
+	assert.NoError(t, err)
+
+	expected := make(map[string][]byte)
+	keys := make([]string, 0)
+	for i := 0; i < 50; i++ {
+		keys = append(keys, string(tu.RandomBytes(8)))
+	}
+
+	for i := 0; i < 100; i++ {
+		batch := store.NewBatch()
+		pending := make(map[string][]byte)
+		deleted := make(map[string]bool)
+
+		// Batches may write to the same key more than once
+		for j := 0; j < 20; j++ {
+			key := keys[rand.Intn(len(keys))]
+			if rand.Float64() < 0.25 {
+				batch.Delete(kb.Key([]byte(key)))
+				delete(pending, key)
+				deleted[key] = true
+			} else {
+				value := ownerValue(uint64(rand.Intn(5)), tu.RandomBytes(8))
+				batch.Put(kb.Key([]byte(key)), value)
+				pending[key] = value
+				delete(deleted, key)
+			}
+		}
+
+		// Occasionally include a value that can't be indexed, which causes the entire batch to fail
+		if rand.Float64() < 0.1 {
+			batch.Put(kb.Key([]byte(keys[0])), []byte("short"))
+			err = batch.Apply()
+			assert.Error(t, err)
+		} else {
+			err = batch.Apply()
+			assert.NoError(t, err)
+			for key := range deleted {
+				delete(expected, key)
+			}
+			for key, value := range pending {
+				expected[key] = value
+			}
+		}
+
+		verifyIndex(t, idx, expected)
+	}
+
+	err = store.Destroy()
+	assert.NoError(t, err)
+}
+
+func TestIndexExpiration(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	config := DefaultMapStoreConfig()
+	config.Schema = []string{"blobs"}
+	config.Indices = []*IndexConfig{ownerIndexConfig()}
+	config.GarbageCollectionEnabled = false
+	store, err := Start(logger, config)
+	assert.NoError(t, err)
+
+	kb, err := store.GetKeyBuilder("blobs")
+	assert.NoError(t, err)
+	idx, err := store.GetIndex("blobs", "owner")
+	assert.NoError(t, err)
+
+	now := time.Now()
+	err = store.PutWithExpiration(kb.Key([]byte("a")), ownerValue(1, nil), now.Add(time.Minute))
+	assert.NoError(t, err)
+	err = store.PutWithExpiration(kb.Key([]byte("b")), ownerValue(1, nil), now.Add(time.Hour))
+	assert.NoError(t, err)
+
+	err = (store.(*tableStore)).expireKeys(now.Add(2*time.Minute), 1024)
+	assert.NoError(t, err)
+
+	verifyIndex(t, idx, map[string][]byte{"b": ownerValue(1, nil)})
+
+	err = store.Destroy()
+	assert.NoError(t, err)
+}
+
+func TestIndexBuild(t *testing.T) {
+	tu.InitializeRandom()
+	deleteDBDirectory(t)
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	// Write data before the index exists.
+	config := DefaultLevelDBConfig(dbPath)
+	config.Schema = []string{"blobs"}
+	store, err := Start(logger, config)
+	assert.NoError(t, err)
+
+	kb, err := store.GetKeyBuilder("blobs")
+	assert.NoError(t, err)
+
+	expected := make(map[string][]byte)
+	for i := 0; i < 100; i++ {
+		key := tu.RandomBytes(8)
+		value := ownerValue(uint64(rand.Intn(5)), tu.RandomBytes(8))
+		err = store.Put(kb.Key(key), value)
+		assert.NoError(t, err)
+		expected[string(key)] = value
+	}
+	err = store.Shutdown()
+	assert.NoError(t, err)
+
+	// Adding the index populates it from the existing data. The schema is carried forward.
+	config = DefaultLevelDBConfig(dbPath)
+	config.Indices = []*IndexConfig{ownerIndexConfig()}
+	store, err = Start(logger, config)
+	assert.NoError(t, err)
+	idx, err := store.GetIndex("blobs", "owner")
+	assert.NoError(t, err)
+	verifyIndex(t, idx, expected)
+
+	// Simulate a crash during an index build by leaving behind a build marker and a stale index entry.
+	indexTable := idx.(*index).indexKeyBuilder
+	err = store.Shutdown()
+	assert.NoError(t, err)
+
+	base, err := leveldb.NewStore(logger, dbPath)
+	assert.NoError(t, err)
+	metadataKeyBuilder := newKeyBuilder("metadata", metadataTableID)
+	err = base.Put(metadataKeyBuilder.Key([]byte(metadataIndexBuildPrefix+indexTable.tableName)).Raw(), []byte{})
+	assert.NoError(t, err)
+	err = base.Put(indexTable.Key(append(ownerValue(7, nil), 0x00, 0x01, 's')).Raw(), []byte{})
+	assert.NoError(t, err)
+	err = base.Put(kb.Key([]byte("s")).Raw(), ownerValue(8, nil))
+	assert.NoError(t, err)
+	expected["s"] = ownerValue(8, nil)
+	err = base.Shutdown()
+	assert.NoError(t, err)
+
+	store, err = Start(logger, config)
+	assert.NoError(t, err)
+	idx, err = store.GetIndex("blobs", "owner")
+	assert.NoError(t, err)
+	verifyIndex(t, idx, expected)
+
+	markers, err := loadIndexBuildMarkers(store.(*tableStore).base, metadataKeyBuilder)
+	assert.NoError(t, err)
+	assert.Empty(t, markers)
+	err = store.Shutdown()
+	assert.NoError(t, err)
+
+	// Removing the index drops its table.
+	config = DefaultLevelDBConfig(dbPath)
+	store, err = Start(logger, config)
+	assert.NoError(t, err)
+	_, err = store.GetIndex("blobs", "owner")
+	assert.ErrorIs(t, err, kvstore.ErrIndexNotFound)
+	assert.Equal(t, []string{"blobs"}, store.GetTables())
+	assert.Equal(t, 1, len(store.(*tableStore).keyBuilderMap))
+	err = store.Shutdown()
+	assert.NoError(t, err)
+
+	// An index on a table that is not in the schema is rejected.
+	config = DefaultLevelDBConfig(dbPath)
+	config.Indices = []*IndexConfig{
+		{
+			TableName:     "missing",
+			Name:          "owner",
+			IndexFunction: ownerIndexFunction,
+		},
+	}
+	_, err = Start(logger, config)
+	assert.Error(t, err)
+
+	// Table names may not use the prefix reserved for index tables.
+	config = DefaultLevelDBConfig(dbPath)
+	config.Schema = []string{"blobs", indexTableName("blobs", "owner")}
+	_, err = Start(logger, config)
+	assert.Error(t, err)
+
+	base, err = leveldb.NewStore(logger, dbPath)
+	assert.NoError(t, err)
+	err = base.Destroy()
+	assert.NoError(t, err)
+	verifyDBIsDeleted(t)
+}
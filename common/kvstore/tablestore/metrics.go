@@ -0,0 +1,167 @@
+package tablestore
+
+import (
+	"fmt"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"time"
+)
+
+const metricsNamespace = "eigenda_kvstore"
+
+// metrics exposes prometheus metrics for a TableStore. All metrics are labeled with the name of the store and the
+// type of its base store, so that several stores (possibly with different backends) can be compared. A nil *metrics
+// is valid and records nothing.
+type metrics struct {
+	registry *prometheus.Registry
+
+	// Collects size and compaction metrics from the base store each time metrics are scraped.
+	stats *statsCollector
+
+	gcKeysDeleted prometheus.Counter
+	gcLatency     prometheus.Summary
+}
+
+// newMetrics creates and registers the metrics for a store. Returns nil if the config has no metrics registry.
+func newMetrics(logger logging.Logger, base kvstore.Store[[]byte], config *Config) (*metrics, error) {
+	if config.MetricsRegistry == nil {
+		return nil, nil
+	}
+
+	labels := prometheus.Labels{
+		"store":   config.MetricsName,
+		"backend": config.Type.String(),
+	}
+
+	m := &metrics{
+		registry: config.MetricsRegistry,
+		gcKeysDeleted: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Namespace:   metricsNamespace,
+				Name:        "gc_keys_deleted_total",
+				Help:        "the number of expired keys deleted by garbage collection",
+				ConstLabels: labels,
+			},
+		),
+		gcLatency: prometheus.NewSummary(
+			prometheus.SummaryOpts{
+				Namespace:   metricsNamespace,
+				Name:        "gc_latency_ms",
+				Help:        "the time taken by each garbage collection pass",
+				Objectives:  map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+				ConstLabels: labels,
+			},
+		),
+	}
+
+	collectors := []prometheus.Collector{m.gcKeysDeleted, m.gcLatency}
+
+	if reporter, ok := base.(kvstore.StatsReporter); ok {
+		m.stats = newStatsCollector(logger, reporter, labels)
+		collectors = append(collectors, m.stats)
+	}
+
+	for i, collector := range collectors {
+		err := m.registry.Register(collector)
+		if err != nil {
+			for _, registered := range collectors[:i] {
+				m.registry.Unregister(registered)
+			}
+			return nil, fmt.Errorf("error registering metrics, is another store with the name \"%s\" "+
+				"registered with the same registry? %w", config.MetricsName, err)
+		}
+	}
+
+	return m, nil
+}
+
+// reportGarbageCollection records the result of a garbage collection pass.
+func (m *metrics) reportGarbageCollection(keysDeleted int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.gcKeysDeleted.Add(float64(keysDeleted))
+	m.gcLatency.Observe(float64(duration.Milliseconds()))
+}
+
+// unregister removes the metrics from the registry, so that a store with the same name can be started again.
+func (m *metrics) unregister() {
+	if m == nil {
+		return
+	}
+	m.registry.Unregister(m.gcKeysDeleted)
+	m.registry.Unregister(m.gcLatency)
+	if m.stats != nil {
+		m.registry.Unregister(m.stats)
+	}
+}
+
+var _ prometheus.Collector = &statsCollector{}
+
+// statsCollector reads the statistics of a base store each time metrics are collected.
+type statsCollector struct {
+	logger   logging.Logger
+	reporter kvstore.StatsReporter
+
+	size               *prometheus.Desc
+	compactionDebt     *prometheus.Desc
+	writeStalls        *prometheus.Desc
+	writeStallDuration *prometheus.Desc
+}
+
+// newStatsCollector creates a collector for the statistics of a base store.
+func newStatsCollector(
+	logger logging.Logger,
+	reporter kvstore.StatsReporter,
+	labels prometheus.Labels) *statsCollector {
+
+	return &statsCollector{
+		logger:   logger,
+		reporter: reporter,
+		size: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "size_bytes"),
+			"the approximate number of bytes used by the store, on disk for persistent stores",
+			nil,
+			labels),
+		compactionDebt: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "compaction_debt_bytes"),
+			"an estimate of the number of bytes that must be compacted to bring the store back into shape",
+			nil,
+			labels),
+		writeStalls: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "write_stalls_total"),
+			"the number of times writes were stalled or delayed to let compaction catch up",
+			nil,
+			labels),
+		writeStallDuration: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "write_stall_seconds_total"),
+			"the total time writes spent stalled or delayed to let compaction catch up",
+			nil,
+			labels),
+	}
+}
+
+// Describe sends the descriptors of the metrics collected by this collector.
+func (c *statsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.size
+	ch <- c.compactionDebt
+	ch <- c.writeStalls
+	ch <- c.writeStallDuration
+}
+
+// Collect reads the statistics of the store and sends them as metrics.
+func (c *statsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats, err := c.reporter.Stats()
+	if err != nil {
+		c.logger.Warn("failed to get store stats", "err", err)
+		ch <- prometheus.NewInvalidMetric(c.size, err)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(stats.Size))
+	ch <- prometheus.MustNewConstMetric(c.compactionDebt, prometheus.GaugeValue, float64(stats.CompactionDebt))
+	ch <- prometheus.MustNewConstMetric(c.writeStalls, prometheus.CounterValue, float64(stats.WriteStalls))
+	ch <- prometheus.MustNewConstMetric(
+		c.writeStallDuration, prometheus.CounterValue, stats.WriteStallDuration.Seconds())
+}
@@ -0,0 +1,131 @@
+package tablestore
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+	"time"
+)
+
+// findMetric returns the metric with the given name and store label, or nil if there is no such metric.
+func findMetric(t *testing.T, registry *prometheus.Registry, name string, storeName string) *dto.Metric {
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "store" && label.GetValue() == storeName {
+					return metric
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func TestMetrics(t *testing.T) {
+	tu.InitializeRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	configs := []*Config{
+		DefaultMapStoreConfig(),
+		DefaultLevelDBConfig(dbPath),
+		DefaultPebbleConfig(dbPath),
+	}
+
+	for _, config := range configs {
+		deleteDBDirectory(t)
+
+		registry := prometheus.NewRegistry()
+		config.Schema = []string{"test"}
+		config.GarbageCollectionEnabled = false
+		config.MetricsRegistry = registry
+		config.MetricsName = "chunks"
+
+		store, err := Start(logger, config)
+		assert.NoError(t, err)
+
+		kb, err := store.GetKeyBuilder("test")
+		assert.NoError(t, err)
+
+		now := tu.RandomTime()
+		for i := 0; i < 100; i++ {
+			expiryTime := now.Add(time.Hour)
+			if i%2 == 0 {
+				expiryTime = now.Add(-time.Hour)
+			}
+			err = store.PutWithExpiration(kb.Key(tu.RandomBytes(32)), tu.RandomBytes(128), expiryTime)
+			assert.NoError(t, err)
+		}
+
+		err = store.(*tableStore).expireKeys(now, 7)
+		assert.NoError(t, err)
+
+		storeMetrics := store.(*tableStore).metrics
+		assert.Equal(t, 50.0, testutil.ToFloat64(storeMetrics.gcKeysDeleted))
+		assert.Equal(t, uint64(1), findMetric(t, registry, "eigenda_kvstore_gc_latency_ms", "chunks").
+			GetSummary().GetSampleCount())
+
+		size := findMetric(t, registry, "eigenda_kvstore_size_bytes", "chunks")
+		assert.NotNil(t, size, "store type %s", config.Type)
+		assert.Greater(t, size.GetGauge().GetValue(), 0.0)
+		for _, label := range size.GetLabel() {
+			if label.GetName() == "backend" {
+				assert.Equal(t, config.Type.String(), label.GetValue())
+			}
+		}
+		assert.NotNil(t, findMetric(t, registry, "eigenda_kvstore_compaction_debt_bytes", "chunks"))
+		assert.NotNil(t, findMetric(t, registry, "eigenda_kvstore_write_stalls_total", "chunks"))
+
+		// Metrics are unregistered on shutdown, so the store can be started again with the same registry.
+		err = store.Shutdown()
+		assert.NoError(t, err)
+		assert.Nil(t, findMetric(t, registry, "eigenda_kvstore_size_bytes", "chunks"))
+
+		store, err = Start(logger, config)
+		assert.NoError(t, err)
+		err = store.Destroy()
+		assert.NoError(t, err)
+	}
+
+	err = os.RemoveAll(dbPath)
+	assert.NoError(t, err)
+}
+
+func TestMetricsNames(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	registry := prometheus.NewRegistry()
+
+	config := DefaultMapStoreConfig()
+	config.MetricsRegistry = registry
+	config.MetricsName = "a"
+	storeA, err := Start(logger, config)
+	assert.NoError(t, err)
+
+	// Stores that share a registry must have different names.
+	_, err = Start(logger, config)
+	assert.Error(t, err)
+
+	config.MetricsName = "b"
+	storeB, err := Start(logger, config)
+	assert.NoError(t, err)
+	assert.NotNil(t, findMetric(t, registry, "eigenda_kvstore_size_bytes", "a"))
+	assert.NotNil(t, findMetric(t, registry, "eigenda_kvstore_size_bytes", "b"))
+
+	err = storeA.Destroy()
+	assert.NoError(t, err)
+	err = storeB.Destroy()
+	assert.NoError(t, err)
+}
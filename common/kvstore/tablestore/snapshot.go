@@ -0,0 +1,538 @@
+package tablestore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"hash"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// A snapshot is a stream with the following layout. Integers are varint encoded unless noted otherwise, and byte
+// slices are prefixed with their length.
+//
+//	magic (8 bytes) | format version | schema version | table count | table names...
+//	records...
+//	end record | entry count | expiration count | CRC32 of all preceding bytes (4 bytes, big endian)
+//
+// Entry records hold the index of a table in the list of table names, a key (without the table prefix), and
+// a value. Expiration records hold the index of a table, a key, and an expiration time in unix nanoseconds.
+// Snapshots are logical: table IDs, the namespace table, and the metadata table are rebuilt on restore, and index
+// tables are rebuilt from the indexed tables.
+
+// snapshotMagic is the first 8 bytes of every snapshot.
+var snapshotMagic = []byte("EDATSNAP")
+
+// The current version of the snapshot format.
+const snapshotFormatVersion uint64 = 1
+
+// The maximum length of any byte slice in a snapshot. Guards against huge allocations when reading corrupt data.
+const maxSnapshotFieldLength = 1 << 30
+
+// The maximum number of operations in a batch when restoring a snapshot.
+const restoreBatchSize = 1024
+
+// Snapshot record types.
+const (
+	snapshotEndRecord        byte = 0
+	snapshotEntryRecord      byte = 1
+	snapshotExpirationRecord byte = 2
+)
+
+// While a snapshot is being restored, this key is present in the metadata table. A store containing this key
+// holds a partial restore, and can't be started.
+const metadataRestoreKey = "restore"
+
+// ErrIncompleteRestore is returned when starting a store that contains a restore that did not complete.
+var ErrIncompleteRestore = errors.New("store contains an incomplete restore, delete it and restore again")
+
+// Snapshot writes a point-in-time snapshot of all tables to the given writer. The snapshot can be taken while the
+// store is in use, and does not include writes that happen after this method is called.
+func (t *tableStore) Snapshot(writer io.Writer) error {
+	return writeSnapshot(t.base, writer)
+}
+
+// SnapshotStore writes a snapshot of the store described by the config without starting it, i.e. without modifying
+// the schema, completing interrupted table deletions, or building indices. The store must not be open in another
+// process. The Schema and Indices in the config are ignored.
+func SnapshotStore(logger logging.Logger, config *Config, writer io.Writer) error {
+	if config == nil {
+		return errors.New("config is required")
+	}
+	if (config.Type == LevelDB || config.Type == Pebble) && config.Path != nil {
+		// Opening a persistent store at a path that does not exist would create a new, empty store.
+		_, err := os.Stat(*config.Path)
+		if err != nil {
+			return fmt.Errorf("error opening store: %w", err)
+		}
+	}
+
+	base, err := buildBaseStore(config.Type, logger, config.Path)
+	if err != nil {
+		return fmt.Errorf("error building base store: %w", err)
+	}
+
+	err = writeSnapshot(base, writer)
+	shutdownErr := base.Shutdown()
+	if err != nil {
+		return err
+	}
+	return shutdownErr
+}
+
+// writeSnapshot writes a snapshot of the tables in a base store. A single iterator is used to read all data, so that
+// the snapshot reflects a single point in time.
+func writeSnapshot(base kvstore.Store[[]byte], writer io.Writer) error {
+	it, err := base.NewIterator(nil)
+	if err != nil {
+		return fmt.Errorf("error creating iterator: %w", err)
+	}
+	defer it.Release()
+
+	metadataKeyBuilder := newKeyBuilder("metadata", metadataTableID)
+	namespaceKeyBuilder := newKeyBuilder("namespace", namespaceTableID).(*keyBuilder)
+
+	schemaKey := metadataKeyBuilder.Key([]byte(metadataSchemaVersionKey)).Raw()
+	if !it.Seek(schemaKey) || !bytes.Equal(it.Key(), schemaKey) {
+		return errors.New("store has no schema version")
+	}
+	schemaVersion := binary.BigEndian.Uint64(it.Value())
+	if schemaVersion != currentSchemaVersion {
+		return fmt.Errorf("incompatible schema version: code is at version %d, data on disk is at version %d",
+			currentSchemaVersion, schemaVersion)
+	}
+
+	restoreKey := metadataKeyBuilder.Key([]byte(metadataRestoreKey)).Raw()
+	if it.Seek(restoreKey) && bytes.Equal(it.Key(), restoreKey) {
+		return ErrIncompleteRestore
+	}
+
+	// A table that is partially deleted is omitted from the snapshot.
+	deletedTableID := int64(-1)
+	deletionKey := metadataKeyBuilder.Key([]byte(metadataDeletionKey)).Raw()
+	if it.Seek(deletionKey) && bytes.Equal(it.Key(), deletionKey) {
+		deletedTableID = int64(binary.BigEndian.Uint32(it.Value()))
+	}
+
+	tableIDs := make(map[uint32]string)
+	for ok := it.Seek(namespaceKeyBuilder.prefix); ok; ok = it.Next() {
+		if !bytes.HasPrefix(it.Key(), namespaceKeyBuilder.prefix) {
+			break
+		}
+		tableID := binary.BigEndian.Uint32(it.Key()[prefixLength:])
+		tableName := string(it.Value())
+		if int64(tableID) == deletedTableID || isIndexTable(tableName) {
+			continue
+		}
+		tableIDs[tableID] = tableName
+	}
+
+	tableNames := make([]string, 0, len(tableIDs))
+	for _, tableName := range tableIDs {
+		tableNames = append(tableNames, tableName)
+	}
+	sort.Strings(tableNames)
+	tableIndices := make(map[uint32]uint64)
+	for tableID, tableName := range tableIDs {
+		tableIndices[tableID] = uint64(sort.SearchStrings(tableNames, tableName))
+	}
+
+	w := newSnapshotWriter(writer)
+	w.write(snapshotMagic)
+	w.writeUvarint(snapshotFormatVersion)
+	w.writeUvarint(schemaVersion)
+	w.writeUvarint(uint64(len(tableNames)))
+	for _, tableName := range tableNames {
+		w.writeBytes([]byte(tableName))
+	}
+
+	var entryCount uint64
+	var expirationCount uint64
+	for ok := it.First(); ok; ok = it.Next() {
+		if w.err != nil {
+			return fmt.Errorf("error writing snapshot: %w", w.err)
+		}
+
+		key := it.Key()
+		tableID := binary.BigEndian.Uint32(key)
+
+		if tableID == expirationTableID {
+			expiryTime, baseKey := parsePrependedTimestamp(key[prefixLength:])
+			tableIndex, found := tableIndices[binary.BigEndian.Uint32(baseKey)]
+			if !found {
+				continue
+			}
+			w.write([]byte{snapshotExpirationRecord})
+			w.writeUvarint(tableIndex)
+			w.writeBytes(baseKey[prefixLength:])
+			w.writeVarint(expiryTime.UnixNano())
+			expirationCount++
+			continue
+		}
+
+		tableIndex, found := tableIndices[tableID]
+		if !found {
+			// Internal tables
+			continue
+		}
+		w.write([]byte{snapshotEntryRecord})
+		w.writeUvarint(tableIndex)
+		w.writeBytes(key[prefixLength:])
+		w.writeBytes(it.Value())
+		entryCount++
+	}
+	if err = it.Error(); err != nil {
+		return fmt.Errorf("error iterating over store: %w", err)
+	}
+
+	w.write([]byte{snapshotEndRecord})
+	w.writeUvarint(entryCount)
+	w.writeUvarint(expirationCount)
+	return w.finish()
+}
+
+// Restore creates a new store from a snapshot. The store described by the config must not contain any data.
+// The tables of the restored store are the tables in the snapshot (the Schema in the config is ignored), and any
+// indices in the config are rebuilt. If the snapshot is corrupt or restoring fails partway through, the store is
+// left in a state that can't be started (see ErrIncompleteRestore) and should be deleted.
+func Restore(logger logging.Logger, config *Config, reader io.Reader) (kvstore.TableStore, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+
+	r := newSnapshotReader(reader)
+	tableNames, err := r.readHeader()
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot header: %w", err)
+	}
+
+	base, err := buildBaseStore(config.Type, logger, config.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error building base store: %w", err)
+	}
+
+	it, err := base.NewIterator(nil)
+	if err != nil {
+		_ = base.Shutdown()
+		return nil, fmt.Errorf("error creating iterator: %w", err)
+	}
+	empty := !it.Next()
+	it.Release()
+	if !empty {
+		_ = base.Shutdown()
+		return nil, errors.New("cannot restore into a store that contains data")
+	}
+
+	// The marker is removed once the restore completes.
+	restoreKey := newKeyBuilder("metadata", metadataTableID).Key([]byte(metadataRestoreKey)).Raw()
+	err = base.Put(restoreKey, []byte{})
+	if err != nil {
+		_ = base.Shutdown()
+		return nil, fmt.Errorf("error setting restore marker: %w", err)
+	}
+
+	restoreConfig := *config
+	restoreConfig.Schema = tableNames
+	store, err := start(logger, base, &restoreConfig)
+	if err != nil {
+		_ = base.Shutdown()
+		return nil, err
+	}
+
+	err = restore(store.(*tableStore), r, tableNames)
+	if err != nil {
+		_ = store.Shutdown()
+		return nil, fmt.Errorf("error restoring snapshot: %w", err)
+	}
+
+	err = base.Delete(restoreKey)
+	if err != nil {
+		_ = store.Shutdown()
+		return nil, fmt.Errorf("error deleting restore marker: %w", err)
+	}
+
+	return store, nil
+}
+
+// restore writes the records of a snapshot into a newly created store.
+func restore(store *tableStore, r *snapshotReader, tableNames []string) error {
+	var err error
+	keyBuilders := make([]kvstore.KeyBuilder, len(tableNames))
+	for i, tableName := range tableNames {
+		keyBuilders[i], err = store.GetKeyBuilder(tableName)
+		if err != nil {
+			return fmt.Errorf("error getting key builder for table %s: %w", tableName, err)
+		}
+	}
+
+	var entryCount uint64
+	var expirationCount uint64
+	batch := newTableStoreBatch(store.base, store.expirationKeyBuilder, store.indices)
+	for {
+		recordType, err := r.readByte()
+		if err != nil {
+			return err
+		}
+		if recordType == snapshotEndRecord {
+			break
+		}
+
+		tableIndex, err := r.readUvarint()
+		if err != nil {
+			return err
+		}
+		if tableIndex >= uint64(len(keyBuilders)) {
+			return fmt.Errorf("invalid table index %d", tableIndex)
+		}
+		key, err := r.readBytes()
+		if err != nil {
+			return err
+		}
+
+		switch recordType {
+		case snapshotEntryRecord:
+			value, err := r.readBytes()
+			if err != nil {
+				return err
+			}
+			batch.Put(keyBuilders[tableIndex].Key(key), value)
+			entryCount++
+		case snapshotExpirationRecord:
+			expiryUnixNano, err := r.readVarint()
+			if err != nil {
+				return err
+			}
+			batch.putExpiration(keyBuilders[tableIndex].Key(key), time.Unix(0, expiryUnixNano))
+			expirationCount++
+		default:
+			return fmt.Errorf("unknown record type %d", recordType)
+		}
+
+		if batch.Size() >= restoreBatchSize {
+			err = batch.Apply()
+			if err != nil {
+				return err
+			}
+			batch = newTableStoreBatch(store.base, store.expirationKeyBuilder, store.indices)
+		}
+	}
+	if batch.Size() > 0 {
+		err = batch.Apply()
+		if err != nil {
+			return err
+		}
+	}
+
+	expectedEntryCount, err := r.readUvarint()
+	if err != nil {
+		return err
+	}
+	expectedExpirationCount, err := r.readUvarint()
+	if err != nil {
+		return err
+	}
+	if entryCount != expectedEntryCount || expirationCount != expectedExpirationCount {
+		return fmt.Errorf("snapshot has %d entries and %d expirations, expected %d entries and %d expirations",
+			entryCount, expirationCount, expectedEntryCount, expectedExpirationCount)
+	}
+	return r.verifyChecksum()
+}
+
+// snapshotWriter writes the fields of a snapshot while computing its checksum. The first error encountered is
+// retained, and subsequent writes are ignored.
+type snapshotWriter struct {
+	writer *bufio.Writer
+	crc    hash.Hash32
+	buf    []byte
+	err    error
+}
+
+func newSnapshotWriter(writer io.Writer) *snapshotWriter {
+	return &snapshotWriter{
+		writer: bufio.NewWriter(writer),
+		crc:    crc32.NewIEEE(),
+		buf:    make([]byte, binary.MaxVarintLen64),
+	}
+}
+
+func (w *snapshotWriter) write(data []byte) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = w.writer.Write(data)
+	w.crc.Write(data)
+}
+
+func (w *snapshotWriter) writeUvarint(value uint64) {
+	n := binary.PutUvarint(w.buf, value)
+	w.write(w.buf[:n])
+}
+
+func (w *snapshotWriter) writeVarint(value int64) {
+	n := binary.PutVarint(w.buf, value)
+	w.write(w.buf[:n])
+}
+
+func (w *snapshotWriter) writeBytes(data []byte) {
+	w.writeUvarint(uint64(len(data)))
+	w.write(data)
+}
+
+// finish writes the checksum and flushes the snapshot.
+func (w *snapshotWriter) finish() error {
+	if w.err != nil {
+		return fmt.Errorf("error writing snapshot: %w", w.err)
+	}
+	checksum := make([]byte, 4)
+	binary.BigEndian.PutUint32(checksum, w.crc.Sum32())
+	_, err := w.writer.Write(checksum)
+	if err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+	err = w.writer.Flush()
+	if err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+	return nil
+}
+
+// snapshotReader reads the fields of a snapshot while computing its checksum.
+type snapshotReader struct {
+	reader *bufio.Reader
+	crc    hash.Hash32
+}
+
+func newSnapshotReader(reader io.Reader) *snapshotReader {
+	return &snapshotReader{
+		reader: bufio.NewReader(reader),
+		crc:    crc32.NewIEEE(),
+	}
+}
+
+// ReadByte implements io.ByteReader, so that varints can be read directly.
+func (r *snapshotReader) ReadByte() (byte, error) {
+	b, err := r.reader.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	r.crc.Write([]byte{b})
+	return b, nil
+}
+
+func (r *snapshotReader) readByte() (byte, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return 0, fmt.Errorf("error reading snapshot: %w", unexpectedEOF(err))
+	}
+	return b, nil
+}
+
+func (r *snapshotReader) readUvarint() (uint64, error) {
+	value, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("error reading snapshot: %w", unexpectedEOF(err))
+	}
+	return value, nil
+}
+
+func (r *snapshotReader) readVarint() (int64, error) {
+	value, err := binary.ReadVarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("error reading snapshot: %w", unexpectedEOF(err))
+	}
+	return value, nil
+}
+
+func (r *snapshotReader) read(length uint64) ([]byte, error) {
+	if length > maxSnapshotFieldLength {
+		return nil, fmt.Errorf("snapshot field of length %d exceeds maximum length", length)
+	}
+	data := make([]byte, length)
+	_, err := io.ReadFull(r.reader, data)
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot: %w", unexpectedEOF(err))
+	}
+	r.crc.Write(data)
+	return data, nil
+}
+
+func (r *snapshotReader) readBytes() ([]byte, error) {
+	length, err := r.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	return r.read(length)
+}
+
+// readHeader reads the header of a snapshot and returns the names of its tables.
+func (r *snapshotReader) readHeader() ([]string, error) {
+	magic, err := r.read(uint64(len(snapshotMagic)))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(magic, snapshotMagic) {
+		return nil, errors.New("not a table store snapshot")
+	}
+
+	formatVersion, err := r.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if formatVersion != snapshotFormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot format version %d", formatVersion)
+	}
+
+	schemaVersion, err := r.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	if schemaVersion != currentSchemaVersion {
+		return nil, fmt.Errorf("incompatible schema version: code is at version %d, snapshot is at version %d",
+			currentSchemaVersion, schemaVersion)
+	}
+
+	tableCount, err := r.readUvarint()
+	if err != nil {
+		return nil, err
+	}
+	tableNames := make([]string, 0)
+	for i := uint64(0); i < tableCount; i++ {
+		tableName, err := r.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		tableNames = append(tableNames, string(tableName))
+	}
+
+	return tableNames, nil
+}
+
+// verifyChecksum reads the checksum at the end of a snapshot and compares it to the checksum of the data read.
+func (r *snapshotReader) verifyChecksum() error {
+	expected := r.crc.Sum32()
+	checksum := make([]byte, 4)
+	_, err := io.ReadFull(r.reader, checksum)
+	if err != nil {
+		return fmt.Errorf("error reading snapshot checksum: %w", unexpectedEOF(err))
+	}
+	if binary.BigEndian.Uint32(checksum) != expected {
+		return errors.New("snapshot checksum mismatch")
+	}
+	return nil
+}
+
+// unexpectedEOF converts io.EOF to io.ErrUnexpectedEOF, since a snapshot never ends between fields.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,206 @@
+package tablestore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"io"
+)
+
+// DefaultS3SnapshotPartSize is the default size of the objects that a snapshot is split into when uploaded to S3.
+const DefaultS3SnapshotPartSize = 64 * 1024 * 1024
+
+// s3SnapshotManifest describes a snapshot stored in S3. A snapshot is stored as a sequence of part objects followed
+// by a manifest object. The manifest is uploaded last, so a snapshot without a manifest is incomplete.
+type s3SnapshotManifest struct {
+	// The number of parts in the snapshot.
+	Parts int `json:"parts"`
+	// The total size of the snapshot in bytes.
+	Size int64 `json:"size"`
+}
+
+// s3SnapshotPartKey returns the key of a part of a snapshot.
+func s3SnapshotPartKey(key string, part int) string {
+	return fmt.Sprintf("%s/part-%08d", key, part)
+}
+
+// s3SnapshotManifestKey returns the key of the manifest of a snapshot.
+func s3SnapshotManifestKey(key string) string {
+	return key + "/manifest"
+}
+
+var _ io.WriteCloser = &s3SnapshotWriter{}
+
+// s3SnapshotWriter uploads a snapshot to S3 one part at a time, so that only one part is held in memory.
+type s3SnapshotWriter struct {
+	ctx      context.Context
+	client   s3.Client
+	bucket   string
+	key      string
+	partSize int
+
+	buffer []byte
+	parts  int
+	size   int64
+	err    error
+	closed bool
+}
+
+// NewS3SnapshotWriter returns a writer that uploads a snapshot (see TableStore.Snapshot) to S3 under the given key.
+// The snapshot can only be read once Close returns without error. If partSize is not positive,
+// DefaultS3SnapshotPartSize is used.
+func NewS3SnapshotWriter(
+	ctx context.Context,
+	client s3.Client,
+	bucket string,
+	key string,
+	partSize int) io.WriteCloser {
+
+	if partSize <= 0 {
+		partSize = DefaultS3SnapshotPartSize
+	}
+
+	return &s3SnapshotWriter{
+		ctx:      ctx,
+		client:   client,
+		bucket:   bucket,
+		key:      key,
+		partSize: partSize,
+		buffer:   make([]byte, 0, partSize),
+	}
+}
+
+// Write buffers data, uploading a part each time the buffer fills up.
+func (w *s3SnapshotWriter) Write(data []byte) (int, error) {
+	if w.closed {
+		return 0, errors.New("writer is closed")
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	written := 0
+	for len(data) > 0 {
+		n := min(len(data), w.partSize-len(w.buffer))
+		w.buffer = append(w.buffer, data[:n]...)
+		data = data[n:]
+		written += n
+
+		if len(w.buffer) == w.partSize {
+			err := w.uploadPart()
+			if err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+// uploadPart uploads the buffered data as the next part.
+func (w *s3SnapshotWriter) uploadPart() error {
+	err := w.client.UploadObject(w.ctx, w.bucket, s3SnapshotPartKey(w.key, w.parts), w.buffer)
+	if err != nil {
+		w.err = fmt.Errorf("error uploading snapshot part %d: %w", w.parts, err)
+		return w.err
+	}
+	w.parts++
+	w.size += int64(len(w.buffer))
+	w.buffer = w.buffer[:0]
+	return nil
+}
+
+// Close uploads any remaining data followed by the manifest.
+func (w *s3SnapshotWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	if w.err != nil {
+		return w.err
+	}
+
+	if len(w.buffer) > 0 {
+		err := w.uploadPart()
+		if err != nil {
+			return err
+		}
+	}
+
+	manifest, err := json.Marshal(&s3SnapshotManifest{
+		Parts: w.parts,
+		Size:  w.size,
+	})
+	if err != nil {
+		return fmt.Errorf("error encoding snapshot manifest: %w", err)
+	}
+	err = w.client.UploadObject(w.ctx, w.bucket, s3SnapshotManifestKey(w.key), manifest)
+	if err != nil {
+		return fmt.Errorf("error uploading snapshot manifest: %w", err)
+	}
+	return nil
+}
+
+var _ io.Reader = &s3SnapshotReader{}
+
+// s3SnapshotReader downloads a snapshot from S3 one part at a time.
+type s3SnapshotReader struct {
+	ctx      context.Context
+	client   s3.Client
+	bucket   string
+	key      string
+	manifest *s3SnapshotManifest
+
+	nextPart int
+	current  *bytes.Reader
+	read     int64
+}
+
+// NewS3SnapshotReader returns a reader for a snapshot uploaded with NewS3SnapshotWriter.
+// Returns an error if the snapshot does not exist or is incomplete.
+func NewS3SnapshotReader(ctx context.Context, client s3.Client, bucket string, key string) (io.Reader, error) {
+	data, err := client.DownloadObject(ctx, bucket, s3SnapshotManifestKey(key))
+	if err != nil {
+		return nil, fmt.Errorf("error downloading snapshot manifest: %w", err)
+	}
+
+	manifest := &s3SnapshotManifest{}
+	err = json.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding snapshot manifest: %w", err)
+	}
+
+	return &s3SnapshotReader{
+		ctx:      ctx,
+		client:   client,
+		bucket:   bucket,
+		key:      key,
+		manifest: manifest,
+		current:  bytes.NewReader(nil),
+	}, nil
+}
+
+// Read reads from the current part, downloading the next part when the current part is exhausted.
+func (r *s3SnapshotReader) Read(data []byte) (int, error) {
+	for r.current.Len() == 0 {
+		if r.nextPart == r.manifest.Parts {
+			if r.read != r.manifest.Size {
+				return 0, fmt.Errorf("snapshot has %d bytes, expected %d", r.read, r.manifest.Size)
+			}
+			return 0, io.EOF
+		}
+
+		part, err := r.client.DownloadObject(r.ctx, r.bucket, s3SnapshotPartKey(r.key, r.nextPart))
+		if err != nil {
+			return 0, fmt.Errorf("error downloading snapshot part %d: %w", r.nextPart, err)
+		}
+		r.nextPart++
+		r.current = bytes.NewReader(part)
+	}
+
+	n, err := r.current.Read(data)
+	r.read += int64(n)
+	return n, err
+}
@@ -0,0 +1,317 @@
+package tablestore
+
+import (
+	"bytes"
+	"context"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+)
+
+// snapshotTestData describes the contents of a store used to test snapshots.
+type snapshotTestData struct {
+	// table name -> key -> value
+	values map[string]map[string][]byte
+	// table name -> key -> expiration time
+	expirations map[string]map[string]time.Time
+}
+
+// populateSnapshotTestStore writes random data to the tables of a store.
+func populateSnapshotTestStore(t *testing.T, store kvstore.TableStore, tables []string) *snapshotTestData {
+	data := &snapshotTestData{
+		values:      make(map[string]map[string][]byte),
+		expirations: make(map[string]map[string]time.Time),
+	}
+
+	for _, table := range tables {
+		kb, err := store.GetKeyBuilder(table)
+		assert.NoError(t, err)
+
+		data.values[table] = make(map[string][]byte)
+		data.expirations[table] = make(map[string]time.Time)
+
+		for i := 0; i < 100; i++ {
+			key := tu.RandomBytes(rand.Intn(16) + 1)
+			value := ownerValue(uint64(rand.Intn(4)), tu.RandomBytes(rand.Intn(32)))
+			data.values[table][string(key)] = value
+
+			if rand.Float64() < 0.25 {
+				expiryTime := tu.RandomTime()
+				data.expirations[table][string(key)] = expiryTime
+				err = store.PutWithExpiration(kb.Key(key), value, expiryTime)
+			} else {
+				err = store.Put(kb.Key(key), value)
+			}
+			assert.NoError(t, err)
+		}
+	}
+
+	return data
+}
+
+// verifySnapshotTestStore checks that a store contains exactly the expected data.
+func verifySnapshotTestStore(t *testing.T, store kvstore.TableStore, data *snapshotTestData) {
+	tables := make([]string, 0)
+	for table := range data.values {
+		tables = append(tables, table)
+	}
+	assert.ElementsMatch(t, tables, store.GetTables())
+
+	for table, values := range data.values {
+		kb, err := store.GetKeyBuilder(table)
+		assert.NoError(t, err)
+
+		it, err := store.NewTableIterator(kb)
+		assert.NoError(t, err)
+		count := 0
+		for it.Next() {
+			count++
+			assert.Equal(t, values[string(it.Key())], it.Value())
+		}
+		it.Release()
+		assert.Equal(t, len(values), count)
+	}
+
+	// Expiration times are preserved
+	expirationCount := 0
+	tStore := store.(*tableStore)
+	it, err := store.NewTableIterator(tStore.expirationKeyBuilder)
+	assert.NoError(t, err)
+	for it.Next() {
+		expiryTime, rawKey := parsePrependedTimestamp(it.Key())
+		found := false
+		for table, expirations := range data.expirations {
+			kb, err := store.GetKeyBuilder(table)
+			assert.NoError(t, err)
+			if bytes.Equal(rawKey[:prefixLength], kb.Key(nil).Raw()) {
+				expected, ok := expirations[string(rawKey[prefixLength:])]
+				assert.True(t, ok)
+				assert.True(t, expected.Equal(expiryTime))
+				found = true
+			}
+		}
+		assert.True(t, found)
+		expirationCount++
+	}
+	it.Release()
+
+	expectedExpirationCount := 0
+	for _, expirations := range data.expirations {
+		expectedExpirationCount += len(expirations)
+	}
+	assert.Equal(t, expectedExpirationCount, expirationCount)
+}
+
+func TestSnapshotAndRestore(t *testing.T) {
+	tu.InitializeRandom()
+	deleteDBDirectory(t)
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	tables := []string{"blobs", "chunks", "empty"}
+
+	config := DefaultMapStoreConfig()
+	config.Schema = tables
+	config.Indices = []*IndexConfig{ownerIndexConfig()}
+	config.GarbageCollectionEnabled = false
+	store, err := Start(logger, config)
+	assert.NoError(t, err)
+
+	data := populateSnapshotTestStore(t, store, []string{"blobs", "chunks"})
+	data.values["empty"] = make(map[string][]byte)
+
+	snapshot := &bytes.Buffer{}
+	err = store.Snapshot(snapshot)
+	assert.NoError(t, err)
+	err = store.Shutdown()
+	assert.NoError(t, err)
+
+	// Restore into a LevelDB store, with the index rebuilt.
+	restoreConfig := DefaultLevelDBConfig(dbPath)
+	restoreConfig.Indices = []*IndexConfig{ownerIndexConfig()}
+	restoreConfig.GarbageCollectionEnabled = false
+	restored, err := Restore(logger, restoreConfig, bytes.NewReader(snapshot.Bytes()))
+	assert.NoError(t, err)
+	verifySnapshotTestStore(t, restored, data)
+
+	idx, err := restored.GetIndex("blobs", "owner")
+	assert.NoError(t, err)
+	verifyIndex(t, idx, data.values["blobs"])
+
+	// The restored store can be restarted.
+	err = restored.Shutdown()
+	assert.NoError(t, err)
+	restored, err = Start(logger, restoreConfig)
+	assert.NoError(t, err)
+	verifySnapshotTestStore(t, restored, data)
+
+	// A store that contains data can't be restored into.
+	err = restored.Shutdown()
+	assert.NoError(t, err)
+	_, err = Restore(logger, restoreConfig, bytes.NewReader(snapshot.Bytes()))
+	assert.Error(t, err)
+
+	// A snapshot of a stopped store is identical to a snapshot of the running store.
+	stoppedSnapshot := &bytes.Buffer{}
+	err = SnapshotStore(logger, restoreConfig, stoppedSnapshot)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot.Bytes(), stoppedSnapshot.Bytes())
+
+	missingPath := "missing-store"
+	err = SnapshotStore(logger, DefaultLevelDBConfig(missingPath), &bytes.Buffer{})
+	assert.Error(t, err)
+	_, err = os.Stat(missingPath)
+	assert.True(t, os.IsNotExist(err))
+
+	err = os.RemoveAll(dbPath)
+	assert.NoError(t, err)
+}
+
+// callbackWriter calls a function before its first write.
+type callbackWriter struct {
+	writer   io.Writer
+	callback func()
+}
+
+func (w *callbackWriter) Write(data []byte) (int, error) {
+	if w.callback != nil {
+		w.callback()
+		w.callback = nil
+	}
+	return w.writer.Write(data)
+}
+
+func TestSnapshotIsPointInTime(t *testing.T) {
+	tu.InitializeRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	config := DefaultMapStoreConfig()
+	config.Schema = []string{"blobs"}
+	store, err := Start(logger, config)
+	assert.NoError(t, err)
+
+	data := populateSnapshotTestStore(t, store, []string{"blobs"})
+	kb, err := store.GetKeyBuilder("blobs")
+	assert.NoError(t, err)
+
+	// Modify the store while the snapshot is being written.
+	snapshot := &bytes.Buffer{}
+	writer := &callbackWriter{
+		writer: snapshot,
+		callback: func() {
+			for key := range data.values["blobs"] {
+				err := store.Delete(kb.Key([]byte(key)))
+				assert.NoError(t, err)
+			}
+			err := store.Put(kb.Key([]byte("new")), ownerValue(1, nil))
+			assert.NoError(t, err)
+		},
+	}
+	err = store.Snapshot(writer)
+	assert.NoError(t, err)
+
+	restored, err := Restore(logger, DefaultMapStoreConfig(), snapshot)
+	assert.NoError(t, err)
+	verifySnapshotTestStore(t, restored, data)
+
+	err = store.Destroy()
+	assert.NoError(t, err)
+	err = restored.Destroy()
+	assert.NoError(t, err)
+}
+
+func TestRestoreCorruptSnapshot(t *testing.T) {
+	tu.InitializeRandom()
+	deleteDBDirectory(t)
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	config := DefaultMapStoreConfig()
+	config.Schema = []string{"blobs"}
+	store, err := Start(logger, config)
+	assert.NoError(t, err)
+	populateSnapshotTestStore(t, store, []string{"blobs"})
+
+	snapshot := &bytes.Buffer{}
+	err = store.Snapshot(snapshot)
+	assert.NoError(t, err)
+	err = store.Destroy()
+	assert.NoError(t, err)
+
+	// Corrupt a byte of a value near the end of the snapshot, so that the header and record structure are intact.
+	corrupted := bytes.Clone(snapshot.Bytes())
+	corrupted[len(corrupted)-10] ^= 0xFF
+
+	_, err = Restore(logger, DefaultLevelDBConfig(dbPath), bytes.NewReader(corrupted))
+	assert.Error(t, err)
+
+	// The partially restored store can't be started.
+	_, err = Start(logger, DefaultLevelDBConfig(dbPath))
+	assert.ErrorIs(t, err, ErrIncompleteRestore)
+	deleteDBDirectory(t)
+
+	// A truncated snapshot is also rejected.
+	_, err = Restore(logger, DefaultLevelDBConfig(dbPath), bytes.NewReader(snapshot.Bytes()[:snapshot.Len()/2]))
+	assert.Error(t, err)
+	deleteDBDirectory(t)
+
+	// Data that is not a snapshot is rejected before anything is written.
+	_, err = Restore(logger, DefaultLevelDBConfig(dbPath), bytes.NewReader(tu.RandomBytes(100)))
+	assert.Error(t, err)
+	_, err = os.Stat(dbPath)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSnapshotS3(t *testing.T) {
+	tu.InitializeRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	client, err := s3.NewLocalClient(t.TempDir(), logger)
+	assert.NoError(t, err)
+	ctx := context.Background()
+	err = client.CreateBucket(ctx, "backups")
+	assert.NoError(t, err)
+
+	config := DefaultMapStoreConfig()
+	config.Schema = []string{"blobs", "chunks"}
+	store, err := Start(logger, config)
+	assert.NoError(t, err)
+	data := populateSnapshotTestStore(t, store, []string{"blobs", "chunks"})
+
+	// An incomplete snapshot can't be read.
+	writer := NewS3SnapshotWriter(ctx, client, "backups", "node/snapshot", 100)
+	err = store.Snapshot(writer)
+	assert.NoError(t, err)
+	_, err = NewS3SnapshotReader(ctx, client, "backups", "node/snapshot")
+	assert.Error(t, err)
+
+	err = writer.Close()
+	assert.NoError(t, err)
+	objects, err := client.ListObjects(ctx, "backups", "node/snapshot/part-")
+	assert.NoError(t, err)
+	assert.Greater(t, len(objects), 1)
+
+	reader, err := NewS3SnapshotReader(ctx, client, "backups", "node/snapshot")
+	assert.NoError(t, err)
+	restored, err := Restore(logger, DefaultMapStoreConfig(), reader)
+	assert.NoError(t, err)
+	verifySnapshotTestStore(t, restored, data)
+
+	err = store.Destroy()
+	assert.NoError(t, err)
+	err = restored.Destroy()
+	assert.NoError(t, err)
+}
@@ -2,6 +2,7 @@ package tablestore
 
 import (
 	"context"
+	"fmt"
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/syndtr/goleveldb/leveldb/iterator"
@@ -34,6 +35,12 @@ type tableStore struct {
 	// A key builder for the expiration table. Keys in this table are made up of a timestamp prepended to a key.
 	// The value is an empty byte slice. Iterating over this table will return keys in order of expiration time.
 	expirationKeyBuilder kvstore.KeyBuilder
+
+	// The secondary indices of the store.
+	indices *indexSet
+
+	// The metrics of the store. Nil if metrics are not enabled.
+	metrics *metrics
 }
 
 // wrapper wraps the given Store to create a TableStore.
@@ -46,9 +53,11 @@ func newTableStore(
 	base kvstore.Store[[]byte],
 	tableIDMap map[uint32]string,
 	expirationKeyBuilder kvstore.KeyBuilder,
+	indexConfigs []*IndexConfig,
+	metrics *metrics,
 	gcEnabled bool,
 	gcPeriod time.Duration,
-	gcBatchSize uint32) kvstore.TableStore {
+	gcBatchSize uint32) (*tableStore, error) {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	waitGroup := &sync.WaitGroup{}
@@ -61,23 +70,31 @@ func newTableStore(
 		base:                 base,
 		keyBuilderMap:        make(map[string]kvstore.KeyBuilder),
 		expirationKeyBuilder: expirationKeyBuilder,
+		metrics:              metrics,
 	}
 
 	for prefix, name := range tableIDMap {
 		store.keyBuilderMap[name] = newKeyBuilder(name, prefix)
 	}
 
+	indices, err := newIndexSet(base, store.keyBuilderMap, indexConfigs)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("error loading indices: %w", err)
+	}
+	store.indices = indices
+
 	if gcEnabled {
 		store.expireKeysInBackground(gcPeriod, gcBatchSize)
 	}
 
-	return store
+	return store, nil
 }
 
 // GetKeyBuilder gets the key builder for a particular table. Returns ErrTableNotFound if the table does not exist.
 func (t *tableStore) GetKeyBuilder(name string) (kvstore.KeyBuilder, error) {
 	table, ok := t.keyBuilderMap[name]
-	if !ok {
+	if !ok || isIndexTable(name) {
 		return nil, kvstore.ErrTableNotFound
 	}
 
@@ -87,7 +104,10 @@ func (t *tableStore) GetKeyBuilder(name string) (kvstore.KeyBuilder, error) {
 // GetKeyBuilders returns a list of all tables in the store in no particular order.
 func (t *tableStore) GetKeyBuilders() []kvstore.KeyBuilder {
 	tables := make([]kvstore.KeyBuilder, 0, len(t.keyBuilderMap))
-	for _, kb := range t.keyBuilderMap {
+	for name, kb := range t.keyBuilderMap {
+		if isIndexTable(name) {
+			continue
+		}
 		tables = append(tables, kb)
 	}
 
@@ -97,8 +117,11 @@ func (t *tableStore) GetKeyBuilders() []kvstore.KeyBuilder {
 // GetTables returns a list of all tables in the store in no particular order.
 func (t *tableStore) GetTables() []string {
 	tables := make([]string, 0, len(t.keyBuilderMap))
-	for _, kb := range t.keyBuilderMap {
-		tables = append(tables, kb.TableName())
+	for name := range t.keyBuilderMap {
+		if isIndexTable(name) {
+			continue
+		}
+		tables = append(tables, name)
 	}
 
 	return tables
@@ -106,16 +129,30 @@ func (t *tableStore) GetTables() []string {
 
 // NewBatch creates a new batch for writing to the store.
 func (t *tableStore) NewBatch() kvstore.Batch[kvstore.Key] {
-	return newTableStoreBatch(t.base, t.expirationKeyBuilder)
+	return newTableStoreBatch(t.base, t.expirationKeyBuilder, t.indices)
 }
 
 // NewTTLBatch creates a new batch for writing to the store with TTLs.
 func (t *tableStore) NewTTLBatch() kvstore.TTLBatch[kvstore.Key] {
-	return newTableStoreBatch(t.base, t.expirationKeyBuilder)
+	return newTableStoreBatch(t.base, t.expirationKeyBuilder, t.indices)
+}
+
+// GetIndex gets a secondary index on a table. Returns ErrIndexNotFound if the index does not exist.
+func (t *tableStore) GetIndex(tableName string, indexName string) (kvstore.Index, error) {
+	idx := t.indices.get(tableName, indexName)
+	if idx == nil {
+		return nil, kvstore.ErrIndexNotFound
+	}
+	return idx, nil
 }
 
 // Put adds a key-value pair to the store.
 func (t *tableStore) Put(k kvstore.Key, value []byte) error {
+	if len(t.indices.indicesFor(k.Raw())) > 0 {
+		batch := t.NewBatch()
+		batch.Put(k, value)
+		return batch.Apply()
+	}
 	return t.base.Put(k.Raw(), value)
 }
 
@@ -126,6 +163,11 @@ func (t *tableStore) Get(k kvstore.Key) ([]byte, error) {
 
 // Delete removes a key from the store.
 func (t *tableStore) Delete(k kvstore.Key) error {
+	if len(t.indices.indicesFor(k.Raw())) > 0 {
+		batch := t.NewBatch()
+		batch.Delete(k)
+		return batch.Apply()
+	}
 	return t.base.Delete(k.Raw())
 }
 
@@ -148,6 +190,31 @@ func (t *tableStore) NewIterator(prefix kvstore.Key) (iterator.Iterator, error)
 	return newTableStoreIterator(t.base, prefix)
 }
 
+// NewRangeIterator returns an iterator over the keys in [start, end). Both keys must belong to the same table.
+// If start is nil then iteration begins at the first key in the end key's table, and if end is nil then iteration
+// continues through the last key in the start key's table.
+func (t *tableStore) NewRangeIterator(
+	start kvstore.Key,
+	end kvstore.Key,
+	options *kvstore.IteratorOptions) (iterator.Iterator, error) {
+
+	if start == nil && end == nil {
+		return nil, fmt.Errorf("at least one of start and end must be non-nil")
+	}
+	if start == nil {
+		start, _ = end.Builder().TableRange()
+	}
+	if end == nil {
+		_, end = start.Builder().TableRange()
+	}
+	if start.Builder().TableName() != end.Builder().TableName() {
+		return nil, fmt.Errorf("start key is in table %s but end key is in table %s",
+			start.Builder().TableName(), end.Builder().TableName())
+	}
+
+	return newTableStoreRangeIterator(t.base, start, end, options)
+}
+
 // NewTableIterator returns an iterator that can be used to iterate over all keys in a table.
 func (t *tableStore) NewTableIterator(builder kvstore.KeyBuilder) (iterator.Iterator, error) {
 	return newTableStoreIterator(t.base, builder.Key([]byte{}))
@@ -177,38 +244,48 @@ func (t *tableStore) expireKeysInBackground(gcPeriod time.Duration, gcBatchSize
 
 // Delete all keys with a TTL that has expired.
 func (t *tableStore) expireKeys(now time.Time, gcBatchSize uint32) error {
+	start := time.Now()
+	keysDeleted := 0
+
 	it, err := t.NewTableIterator(t.expirationKeyBuilder)
 	if err != nil {
 		return err
 	}
 	defer it.Release()
 
-	batch := t.base.NewBatch()
+	batch := newTableStoreBatch(t.base, t.expirationKeyBuilder, t.indices)
 
 	for it.Next() {
-		expiryKey := it.Key()
-		expiryTimestamp, baseKey := parsePrependedTimestamp(expiryKey)
+		expiryKey := t.expirationKeyBuilder.Key(it.Key()).Raw()
+		expiryTimestamp, baseKey := parsePrependedTimestamp(it.Key())
 
 		if expiryTimestamp.After(now) {
 			// No more values to expire
 			break
 		}
 
-		batch.Delete(baseKey)
-		batch.Delete(expiryKey)
+		// Deleting through a table store batch also removes the key from any indices on its table.
+		batch.deleteRaw(baseKey)
+		batch.deleteRaw(expiryKey)
+		keysDeleted++
 
 		if batch.Size() >= gcBatchSize {
 			err = batch.Apply()
 			if err != nil {
 				return err
 			}
-			batch = t.base.NewBatch()
+			batch = newTableStoreBatch(t.base, t.expirationKeyBuilder, t.indices)
 		}
 	}
 
 	if batch.Size() > 0 {
-		return batch.Apply()
+		err = batch.Apply()
+		if err != nil {
+			return err
+		}
 	}
+
+	t.metrics.reportGarbageCollection(keysDeleted, time.Since(start))
 	return nil
 }
 
@@ -216,6 +293,7 @@ func (t *tableStore) expireKeys(now time.Time, gcBatchSize uint32) error {
 func (t *tableStore) Shutdown() error {
 	t.cancel()
 	t.waitGroup.Wait()
+	t.metrics.unregister()
 	return t.base.Shutdown()
 }
 
@@ -223,5 +301,6 @@ func (t *tableStore) Shutdown() error {
 func (t *tableStore) Destroy() error {
 	t.cancel()
 	t.waitGroup.Wait()
+	t.metrics.unregister()
 	return t.base.Destroy()
 }
@@ -9,18 +9,30 @@ var _ kvstore.TTLBatch[kvstore.Key] = &tableStoreBatch{}
 
 // tableStoreBatch is a batch for writing to a table store.
 type tableStoreBatch struct {
+	base                 kvstore.Store[[]byte]
 	baseBatch            kvstore.Batch[[]byte]
 	expirationKeyBuilder kvstore.KeyBuilder
+
+	// the secondary indices of the table store
+	indices *indexSet
+	// the final write to each key in an indexed table, in the order that the keys were first written
+	indexedWrites []*indexedWrite
+	// a map from raw keys to their position in indexedWrites
+	indexedWritePositions map[string]int
 }
 
 // newTableStoreBatch creates a new batch for writing to a table store.
 func newTableStoreBatch(
 	base kvstore.Store[[]byte],
-	expirationKeyBuilder kvstore.KeyBuilder) kvstore.TTLBatch[kvstore.Key] {
+	expirationKeyBuilder kvstore.KeyBuilder,
+	indices *indexSet) *tableStoreBatch {
 
 	return &tableStoreBatch{
-		baseBatch:            base.NewBatch(),
-		expirationKeyBuilder: expirationKeyBuilder,
+		base:                  base,
+		baseBatch:             base.NewBatch(),
+		expirationKeyBuilder:  expirationKeyBuilder,
+		indices:               indices,
+		indexedWritePositions: make(map[string]int),
 	}
 }
 
@@ -32,24 +44,70 @@ func (t *tableStoreBatch) PutWithTTL(k kvstore.Key, value []byte, ttl time.Durat
 
 // PutWithExpiration adds a key-value pair to the batch that expires at a specified time.
 func (t *tableStoreBatch) PutWithExpiration(k kvstore.Key, value []byte, expiryTime time.Time) {
-	expirationKey := t.expirationKeyBuilder.Key(prependTimestamp(expiryTime, k.Raw()))
+	t.Put(k, value)
+	t.putExpiration(k, expiryTime)
+}
 
-	t.baseBatch.Put(k.Raw(), value)
+// putExpiration schedules a key to expire at the given time.
+func (t *tableStoreBatch) putExpiration(k kvstore.Key, expiryTime time.Time) {
+	expirationKey := t.expirationKeyBuilder.Key(prependTimestamp(expiryTime, k.Raw()))
 	t.baseBatch.Put(expirationKey.Raw(), []byte{})
 }
 
 // Put adds a key-value pair to the batch.
 func (t *tableStoreBatch) Put(k kvstore.Key, value []byte) {
+	if value == nil {
+		value = []byte{}
+	}
 	t.baseBatch.Put(k.Raw(), value)
+	t.recordIndexedWrite(k.Raw(), value, false)
 }
 
 // Delete removes a key-value pair from the batch.
 func (t *tableStoreBatch) Delete(k kvstore.Key) {
-	t.baseBatch.Delete(k.Raw())
+	t.deleteRaw(k.Raw())
+}
+
+// deleteRaw removes the key with the given raw value.
+func (t *tableStoreBatch) deleteRaw(rawKey []byte) {
+	t.baseBatch.Delete(rawKey)
+	t.recordIndexedWrite(rawKey, nil, true)
+}
+
+// recordIndexedWrite records a write if the key is in an indexed table, so that the indices can be updated when the
+// batch is applied.
+func (t *tableStoreBatch) recordIndexedWrite(rawKey []byte, value []byte, deleted bool) {
+	if t.indices == nil || len(t.indices.indicesFor(rawKey)) == 0 {
+		return
+	}
+
+	write := &indexedWrite{
+		rawKey:  rawKey,
+		value:   value,
+		deleted: deleted,
+	}
+	if position, ok := t.indexedWritePositions[string(rawKey)]; ok {
+		t.indexedWrites[position] = write
+		return
+	}
+	t.indexedWritePositions[string(rawKey)] = len(t.indexedWrites)
+	t.indexedWrites = append(t.indexedWrites, write)
 }
 
-// Apply applies the batch to the store.
+// Apply applies the batch to the store. If the batch writes to indexed tables, the changes to the indices are
+// applied atomically with the rest of the batch.
 func (t *tableStoreBatch) Apply() error {
+	if len(t.indexedWrites) == 0 {
+		return t.baseBatch.Apply()
+	}
+
+	t.indices.lock.Lock()
+	defer t.indices.lock.Unlock()
+
+	err := t.indices.updateIndices(t.base, t.baseBatch, t.indexedWrites)
+	if err != nil {
+		return err
+	}
 	return t.baseBatch.Apply()
 }
 
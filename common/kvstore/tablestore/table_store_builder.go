@@ -7,6 +7,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
 	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/pebble"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"math"
 	"sort"
@@ -54,7 +55,23 @@ func Start(logger logging.Logger, config *Config) (kvstore.TableStore, error) {
 		return nil, fmt.Errorf("error building base store: %w", err)
 	}
 
-	return start(logger, base, config)
+	restoreKey := newKeyBuilder("metadata", metadataTableID).Key([]byte(metadataRestoreKey))
+	_, err = base.Get(restoreKey.Raw())
+	if err == nil {
+		_ = base.Shutdown()
+		return nil, ErrIncompleteRestore
+	} else if !errors.Is(err, kvstore.ErrNotFound) {
+		_ = base.Shutdown()
+		return nil, fmt.Errorf("error reading restore marker: %w", err)
+	}
+
+	store, err := start(logger, base, config)
+	if err != nil {
+		// Release the base store (e.g. the LevelDB file lock) so that the store can be started again.
+		_ = base.Shutdown()
+		return nil, err
+	}
+	return store, nil
 }
 
 // Future work: if we ever decide to permit third parties to provide custom store implementations not in this module,
@@ -68,11 +85,16 @@ func start(
 	base kvstore.Store[[]byte],
 	config *Config) (kvstore.TableStore, error) {
 
+	err := validateConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
 	metadataKeyBuilder := newKeyBuilder("metadata", metadataTableID)
 	namespaceKeyBuilder := newKeyBuilder("namespace", namespaceTableID)
 	expirationKeyBuilder := newKeyBuilder("expiration", expirationTableID)
 
-	err := validateSchema(base, metadataKeyBuilder)
+	err = validateSchema(base, metadataKeyBuilder)
 	if err != nil {
 		return nil, fmt.Errorf("error validating schema: %w", err)
 	}
@@ -87,30 +109,170 @@ func start(
 		return nil, fmt.Errorf("error loading namespace table: %w", err)
 	}
 
-	if config.Schema != nil {
-		err = addAndRemoveTables(
-			base,
-			metadataKeyBuilder,
-			namespaceKeyBuilder,
-			tableIDMap,
-			config.Schema)
-		if err != nil {
-			return nil, fmt.Errorf("error adding and removing tables: %w", err)
+	// Index tables are created and dropped along with the indices in the config. They are managed even if the
+	// schema is carried forward, so that an index is never left behind without being maintained.
+	schema, err := computeSchemaWithIndices(tableIDMap, config)
+	if err != nil {
+		return nil, fmt.Errorf("error computing schema: %w", err)
+	}
+
+	indexTablesToBuild, err := loadIndexBuildMarkers(base, metadataKeyBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("error loading index build markers: %w", err)
+	}
+	existingTables := make(map[string]bool)
+	for _, tableName := range tableIDMap {
+		existingTables[tableName] = true
+	}
+	for _, indexConfig := range config.Indices {
+		tableName := indexTableName(indexConfig.TableName, indexConfig.Name)
+		if !existingTables[tableName] {
+			// Mark the index as being built before creating its table, so that a crash before the build completes
+			// is detected the next time the store is started.
+			markerKey := metadataKeyBuilder.Key([]byte(metadataIndexBuildPrefix + tableName))
+			err = base.Put(markerKey.Raw(), []byte{})
+			if err != nil {
+				return nil, fmt.Errorf("error setting index build marker: %w", err)
+			}
+			indexTablesToBuild[tableName] = true
 		}
 	}
 
-	store := newTableStore(
+	err = addAndRemoveTables(
+		base,
+		metadataKeyBuilder,
+		namespaceKeyBuilder,
+		tableIDMap,
+		schema)
+	if err != nil {
+		return nil, fmt.Errorf("error adding and removing tables: %w", err)
+	}
+
+	storeMetrics, err := newMetrics(logger, base, config)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := newTableStore(
 		logger,
 		base,
 		tableIDMap,
 		expirationKeyBuilder,
+		config.Indices,
+		storeMetrics,
 		config.GarbageCollectionEnabled,
 		config.GarbageCollectionInterval,
 		config.GarbageCollectionBatchSize)
+	if err != nil {
+		storeMetrics.unregister()
+		return nil, err
+	}
+
+	err = buildIndices(logger, base, metadataKeyBuilder, store.indices, indexTablesToBuild)
+	if err != nil {
+		_ = store.Shutdown()
+		return nil, fmt.Errorf("error building indices: %w", err)
+	}
 
 	return store, nil
 }
 
+// validateConfig checks that the tables and indices in a config are well-formed.
+func validateConfig(config *Config) error {
+	for _, tableName := range config.Schema {
+		if isIndexTable(tableName) {
+			return fmt.Errorf("table name %s uses reserved prefix %s", tableName, indexTablePrefix)
+		}
+	}
+
+	indexTables := make(map[string]bool)
+	for _, indexConfig := range config.Indices {
+		if indexConfig == nil {
+			return errors.New("index config is nil")
+		}
+		if indexConfig.Name == "" {
+			return fmt.Errorf("index on table %s has no name", indexConfig.TableName)
+		}
+		if indexConfig.IndexFunction == nil {
+			return fmt.Errorf("index %s on table %s has no index function", indexConfig.Name, indexConfig.TableName)
+		}
+		tableName := indexTableName(indexConfig.TableName, indexConfig.Name)
+		if indexTables[tableName] {
+			return fmt.Errorf("duplicate index %s on table %s", indexConfig.Name, indexConfig.TableName)
+		}
+		indexTables[tableName] = true
+	}
+
+	return nil
+}
+
+// computeSchemaWithIndices returns the list of tables that the store should contain, including the tables that
+// back indices. If the config has no schema, the existing tables are carried forward.
+func computeSchemaWithIndices(tableIDMap map[uint32]string, config *Config) ([]string, error) {
+	schema := make([]string, 0, len(tableIDMap)+len(config.Indices))
+	if config.Schema != nil {
+		schema = append(schema, config.Schema...)
+	} else {
+		for _, tableName := range tableIDMap {
+			if !isIndexTable(tableName) {
+				schema = append(schema, tableName)
+			}
+		}
+	}
+
+	tables := make(map[string]bool)
+	for _, tableName := range schema {
+		tables[tableName] = true
+	}
+	for _, indexConfig := range config.Indices {
+		if !tables[indexConfig.TableName] {
+			return nil, fmt.Errorf("index %s is on table %s, which is not in the schema",
+				indexConfig.Name, indexConfig.TableName)
+		}
+		schema = append(schema, indexTableName(indexConfig.TableName, indexConfig.Name))
+	}
+
+	return schema, nil
+}
+
+// buildIndices populates the given index tables from the contents of their indexed tables. Build markers for
+// index tables that no longer exist are discarded.
+func buildIndices(
+	logger logging.Logger,
+	base kvstore.Store[[]byte],
+	metadataKeyBuilder kvstore.KeyBuilder,
+	indices *indexSet,
+	indexTablesToBuild map[string]bool) error {
+
+	indices.lock.Lock()
+	defer indices.lock.Unlock()
+
+	for _, tableIndices := range indices.byName {
+		for _, idx := range tableIndices {
+			if !indexTablesToBuild[idx.indexKeyBuilder.tableName] {
+				continue
+			}
+			delete(indexTablesToBuild, idx.indexKeyBuilder.tableName)
+
+			logger.Infof("building index %s on table %s", idx.name, idx.tableKeyBuilder.tableName)
+			err := buildIndex(base, metadataKeyBuilder, idx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for tableName := range indexTablesToBuild {
+		markerKey := metadataKeyBuilder.Key([]byte(metadataIndexBuildPrefix + tableName))
+		err := base.Delete(markerKey.Raw())
+		if err != nil {
+			return fmt.Errorf("error deleting index build marker: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // buildBaseStore creates a new base store of the given type.
 func buildBaseStore(
 	storeType StoreType,
@@ -125,6 +287,11 @@ func buildBaseStore(
 		return leveldb.NewStore(logger, *path)
 	case MapStore:
 		return mapstore.NewStore(), nil
+	case Pebble:
+		if path == nil {
+			return nil, errors.New("path is required for Pebble store")
+		}
+		return pebble.NewStore(logger, *path)
 	default:
 		return nil, fmt.Errorf("unknown store type: %d", storeType)
 	}
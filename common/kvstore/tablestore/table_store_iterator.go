@@ -27,6 +27,25 @@ func newTableStoreIterator(base kvstore.Store[[]byte], k kvstore.Key) (*tableSto
 	}, nil
 }
 
+// newTableStoreRangeIterator creates a new table store iterator that iterates over the keys in [start, end).
+// The raw end key may be nil, in which case the range is unbounded.
+func newTableStoreRangeIterator(
+	base kvstore.Store[[]byte],
+	start kvstore.Key,
+	end kvstore.Key,
+	options *kvstore.IteratorOptions) (*tableStoreIterator, error) {
+
+	baseIterator, err := base.NewRangeIterator(start.Raw(), end.Raw(), options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tableStoreIterator{
+		baseIterator: baseIterator,
+		keyBuilder:   start.Builder(),
+	}, nil
+}
+
 func (t *tableStoreIterator) First() bool {
 	return t.baseIterator.First()
 }
@@ -65,6 +84,9 @@ func (t *tableStoreIterator) Error() error {
 
 func (t *tableStoreIterator) Key() []byte {
 	baseKey := t.baseIterator.Key()
+	if baseKey == nil {
+		return nil
+	}
 	return baseKey[prefixLength:]
 }
 
@@ -1,6 +1,7 @@
 package tablestore
 
 import (
+	"bytes"
 	"encoding/binary"
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 )
@@ -29,6 +30,34 @@ func (k *key) Bytes() []byte {
 	return k.data[prefixLength:]
 }
 
+var _ kvstore.Key = (*boundKey)(nil)
+
+// boundKey is an exclusive upper bound for a range of keys. Unlike a regular key, the raw value of a bound key
+// may not contain the table prefix (i.e. when the bound is the first key of the next table).
+type boundKey struct {
+	keyBuilder *keyBuilder
+	data       []byte
+}
+
+// Builder returns the KeyBuilder that was used to create the key.
+func (k *boundKey) Builder() kvstore.KeyBuilder {
+	return k.keyBuilder
+}
+
+// Raw returns the raw byte slice that represents the key. Returns nil if the bound is unbounded,
+// which is only possible for the table with the largest possible prefix.
+func (k *boundKey) Raw() []byte {
+	return k.data
+}
+
+// Bytes returns the key without the table prefix. Returns nil if the bound lies outside the table.
+func (k *boundKey) Bytes() []byte {
+	if len(k.data) < prefixLength || !bytes.Equal(k.data[:prefixLength], k.keyBuilder.prefix) {
+		return nil
+	}
+	return k.data[prefixLength:]
+}
+
 var _ kvstore.KeyBuilder = (*keyBuilder)(nil)
 
 type keyBuilder struct {
@@ -61,3 +90,25 @@ func (k *keyBuilder) Key(data []byte) kvstore.Key {
 		data:       result,
 	}
 }
+
+// Uint64Key creates a key from a uint64, encoded in big-endian order.
+func (k *keyBuilder) Uint64Key(value uint64) kvstore.Key {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, value)
+	return k.Key(data)
+}
+
+// PrefixRange returns a pair of keys that bound all keys in the table that begin with the given prefix.
+func (k *keyBuilder) PrefixRange(prefix []byte) (kvstore.Key, kvstore.Key) {
+	start := k.Key(prefix)
+	end := &boundKey{
+		keyBuilder: k,
+		data:       kvstore.PrefixEnd(start.Raw()),
+	}
+	return start, end
+}
+
+// TableRange returns a pair of keys that bound all keys in the table.
+func (k *keyBuilder) TableRange() (kvstore.Key, kvstore.Key) {
+	return k.PrefixRange(nil)
+}
@@ -824,6 +824,113 @@ func TestIteration(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestRangeIteration(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	config := DefaultMapStoreConfig()
+	config.Schema = []string{"table1", "table2", "table3"}
+	store, err := Start(logger, config)
+	assert.NoError(t, err)
+
+	kb1, err := store.GetKeyBuilder("table1")
+	assert.NoError(t, err)
+	kb2, err := store.GetKeyBuilder("table2")
+	assert.NoError(t, err)
+	kb3, err := store.GetKeyBuilder("table3")
+	assert.NoError(t, err)
+
+	// Fill all tables so that any leak across table boundaries is detected.
+	for i := uint64(0); i < 100; i++ {
+		value := make([]byte, 8)
+		binary.BigEndian.PutUint64(value, i)
+
+		err = store.Put(kb1.Uint64Key(i), value)
+		assert.NoError(t, err)
+		err = store.Put(kb2.Uint64Key(i), value)
+		assert.NoError(t, err)
+		err = store.Put(kb3.Uint64Key(i), value)
+		assert.NoError(t, err)
+	}
+	err = store.Put(kb2.Key([]byte{0xff, 0xff}), []byte{})
+	assert.NoError(t, err)
+
+	collect := func(it iterator.Iterator) []uint64 {
+		values := make([]uint64, 0)
+		for it.Next() {
+			values = append(values, binary.BigEndian.Uint64(it.Value()))
+		}
+		it.Release()
+		return values
+	}
+
+	expected := func(start uint64, end uint64, reverse bool) []uint64 {
+		values := make([]uint64, 0)
+		for i := start; i < end; i++ {
+			values = append(values, i)
+		}
+		if reverse {
+			sort.Slice(values, func(i, j int) bool {
+				return values[i] > values[j]
+			})
+		}
+		return values
+	}
+
+	// Range within a table
+	it, err := store.NewRangeIterator(kb2.Uint64Key(10), kb2.Uint64Key(20), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expected(10, 20, false), collect(it))
+
+	// Keys are returned without the table prefix
+	it, err = store.NewRangeIterator(kb2.Uint64Key(10), kb2.Uint64Key(11), nil)
+	assert.NoError(t, err)
+	assert.True(t, it.Next())
+	assert.Equal(t, kb2.Uint64Key(10).Bytes(), it.Key())
+	it.Release()
+
+	// A nil end extends through the end of the start key's table, but not into the next table
+	it, err = store.NewRangeIterator(kb1.Uint64Key(90), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expected(90, 100, false), collect(it))
+
+	// A nil start begins at the start of the end key's table, but not in the previous table
+	it, err = store.NewRangeIterator(nil, kb3.Uint64Key(5), &kvstore.IteratorOptions{Reverse: true})
+	assert.NoError(t, err)
+	assert.Equal(t, expected(0, 5, true), collect(it))
+
+	// Table range
+	start, end := kb1.TableRange()
+	it, err = store.NewRangeIterator(start, end, &kvstore.IteratorOptions{Reverse: true, Limit: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, expected(90, 100, true), collect(it))
+
+	// Prefix range. All keys in [0, 100) share a prefix of seven zero bytes.
+	start, end = kb2.PrefixRange(make([]byte, 7))
+	it, err = store.NewRangeIterator(start, end, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expected(0, 100, false), collect(it))
+
+	// Prefix range with a prefix that consists only of 0xFF bytes extends to the end of the table
+	start, end = kb2.PrefixRange([]byte{0xff})
+	assert.Nil(t, end.Bytes())
+	it, err = store.NewRangeIterator(start, end, nil)
+	assert.NoError(t, err)
+	assert.True(t, it.Next())
+	assert.Equal(t, []byte{0xff, 0xff}, it.Key())
+	assert.False(t, it.Next())
+	it.Release()
+
+	// Bounds must be in the same table
+	_, err = store.NewRangeIterator(kb1.Uint64Key(0), kb2.Uint64Key(0), nil)
+	assert.Error(t, err)
+	_, err = store.NewRangeIterator(nil, nil, nil)
+	assert.Error(t, err)
+
+	err = store.Destroy()
+	assert.NoError(t, err)
+}
+
 func TestRestart(t *testing.T) {
 	deleteDBDirectory(t)
 
@@ -1089,6 +1196,14 @@ func (e *explodingStore) NewIterator(prefix []byte) (iterator.Iterator, error) {
 	return e.base.NewIterator(prefix)
 }
 
+func (e *explodingStore) NewRangeIterator(
+	start []byte,
+	end []byte,
+	options *kvstore.IteratorOptions) (iterator.Iterator, error) {
+
+	return e.base.NewRangeIterator(start, end, options)
+}
+
 func (e *explodingStore) Shutdown() error {
 	return e.base.Shutdown()
 }
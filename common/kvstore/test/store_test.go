@@ -1,14 +1,17 @@
 package test
 
 import (
+	"encoding/binary"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
 	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/pebble"
 	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/stretchr/testify/assert"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"math/rand"
 	"os"
 	"testing"
@@ -22,6 +25,9 @@ var storeBuilders = []func(logger logging.Logger, path string) (kvstore.Store[[]
 	func(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
 		return leveldb.NewStore(logger, path)
 	},
+	func(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
+		return pebble.NewStore(logger, path)
+	},
 	func(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
 		config := tablestore.DefaultMapStoreConfig()
 		config.Schema = []string{"test"}
@@ -40,6 +46,15 @@ var storeBuilders = []func(logger logging.Logger, path string) (kvstore.Store[[]
 		}
 		return NewTableAsAStore(tableStore)
 	},
+	func(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
+		config := tablestore.DefaultPebbleConfig(path)
+		config.Schema = []string{"test"}
+		tableStore, err := tablestore.Start(logger, config)
+		if err != nil {
+			return nil, err
+		}
+		return NewTableAsAStore(tableStore)
+	},
 }
 
 var dbPath = "test-store"
@@ -56,7 +71,6 @@ func verifyDBIsDeleted(t *testing.T) {
 
 func randomOperationsTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	expectedData := make(map[string][]byte)
 
@@ -132,6 +146,9 @@ func TestRandomOperations(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		// The directory is cleared before the store is opened, since not every backend tolerates its files
+		// being deleted while it is open.
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		randomOperationsTest(t, store)
@@ -140,7 +157,6 @@ func TestRandomOperations(t *testing.T) {
 
 func writeBatchTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	var err error
 
@@ -199,6 +215,9 @@ func TestWriteBatch(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		// The directory is cleared before the store is opened, since not every backend tolerates its files
+		// being deleted while it is open.
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		writeBatchTest(t, store)
@@ -207,7 +226,6 @@ func TestWriteBatch(t *testing.T) {
 
 func deleteBatchTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	expectedData := make(map[string][]byte)
 
@@ -265,6 +283,9 @@ func TestDeleteBatch(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		// The directory is cleared before the store is opened, since not every backend tolerates its files
+		// being deleted while it is open.
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		deleteBatchTest(t, store)
@@ -273,7 +294,6 @@ func TestDeleteBatch(t *testing.T) {
 
 func iterationTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	expectedData := make(map[string][]byte)
 
@@ -317,6 +337,9 @@ func TestIteration(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		// The directory is cleared before the store is opened, since not every backend tolerates its files
+		// being deleted while it is open.
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		iterationTest(t, store)
@@ -325,7 +348,6 @@ func TestIteration(t *testing.T) {
 
 func iterationWithPrefixTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	prefixA := tu.RandomBytes(8)
 	prefixB := tu.RandomBytes(8)
@@ -403,6 +425,9 @@ func TestIterationWithPrefix(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		// The directory is cleared before the store is opened, since not every backend tolerates its files
+		// being deleted while it is open.
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		iterationWithPrefixTest(t, store)
@@ -411,7 +436,6 @@ func TestIterationWithPrefix(t *testing.T) {
 
 func putNilTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	key := tu.RandomBytes(32)
 
@@ -432,8 +456,131 @@ func TestPutNil(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		// The directory is cleared before the store is opened, since not every backend tolerates its files
+		// being deleted while it is open.
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		putNilTest(t, store)
 	}
 }
+
+// collectKeys walks over an iterator with Next and returns the keys it visits, interpreted as big-endian uint64s.
+func collectKeys(t *testing.T, it iterator.Iterator) []uint64 {
+	keys := make([]uint64, 0)
+	for it.Next() {
+		keys = append(keys, binary.BigEndian.Uint64(it.Key()))
+	}
+	assert.NoError(t, it.Error())
+	it.Release()
+	return keys
+}
+
+// expectedKeys returns the even numbers in [start, end), in ascending or descending order.
+func expectedKeys(start uint64, end uint64, reverse bool) []uint64 {
+	keys := make([]uint64, 0)
+	for k := start; k < end; k++ {
+		if k%2 == 0 {
+			keys = append(keys, k)
+		}
+	}
+	if reverse {
+		for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+	}
+	return keys
+}
+
+func rangeIterationTest(t *testing.T, store kvstore.Store[[]byte]) {
+
+	uint64Key := func(value uint64) []byte {
+		k := make([]byte, 8)
+		binary.BigEndian.PutUint64(k, value)
+		return k
+	}
+
+	// Insert the even numbers in [0, 200).
+	for i := uint64(0); i < 200; i += 2 {
+		err := store.Put(uint64Key(i), uint64Key(i))
+		assert.NoError(t, err)
+	}
+
+	// Bounded range
+	it, err := store.NewRangeIterator(uint64Key(20), uint64Key(60), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedKeys(20, 60, false), collectKeys(t, it))
+
+	// Bounded range in reverse
+	it, err = store.NewRangeIterator(uint64Key(21), uint64Key(61), &kvstore.IteratorOptions{Reverse: true})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedKeys(21, 61, true), collectKeys(t, it))
+
+	// Unbounded start
+	it, err = store.NewRangeIterator(nil, uint64Key(10), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedKeys(0, 10, false), collectKeys(t, it))
+
+	// Unbounded end
+	it, err = store.NewRangeIterator(uint64Key(190), nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedKeys(190, 200, false), collectKeys(t, it))
+
+	// Unbounded in both directions, in reverse
+	it, err = store.NewRangeIterator(nil, nil, &kvstore.IteratorOptions{Reverse: true})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedKeys(0, 200, true), collectKeys(t, it))
+
+	// Limit
+	it, err = store.NewRangeIterator(uint64Key(50), nil, &kvstore.IteratorOptions{Limit: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedKeys(50, 60, false), collectKeys(t, it))
+
+	// Limit in reverse
+	it, err = store.NewRangeIterator(nil, uint64Key(50), &kvstore.IteratorOptions{Reverse: true, Limit: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedKeys(44, 50, true), collectKeys(t, it))
+
+	// Limit larger than the range
+	it, err = store.NewRangeIterator(uint64Key(50), uint64Key(56), &kvstore.IteratorOptions{Limit: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, expectedKeys(50, 56, false), collectKeys(t, it))
+
+	// Empty range
+	it, err = store.NewRangeIterator(uint64Key(51), uint64Key(52), nil)
+	assert.NoError(t, err)
+	assert.Empty(t, collectKeys(t, it))
+
+	// Seeking in reverse moves to the largest key less than or equal to the requested key.
+	it, err = store.NewRangeIterator(uint64Key(20), uint64Key(60), &kvstore.IteratorOptions{Reverse: true})
+	assert.NoError(t, err)
+	assert.True(t, it.Seek(uint64Key(31)))
+	assert.Equal(t, uint64Key(30), it.Key())
+	assert.True(t, it.Seek(uint64Key(32)))
+	assert.Equal(t, uint64Key(32), it.Key())
+	assert.True(t, it.Seek(uint64Key(100)))
+	assert.Equal(t, uint64Key(58), it.Key())
+	assert.True(t, it.Next())
+	assert.Equal(t, uint64Key(56), it.Key())
+	assert.True(t, it.Prev())
+	assert.Equal(t, uint64Key(58), it.Key())
+	it.Release()
+
+	err = store.Destroy()
+	assert.NoError(t, err)
+	verifyDBIsDeleted(t)
+}
+
+func TestRangeIteration(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(t, err)
+
+	for _, builder := range storeBuilders {
+		// The directory is cleared before the store is opened, since not every backend tolerates its files
+		// being deleted while it is open.
+		deleteDBDirectory(t)
+		store, err := builder(logger, dbPath)
+		assert.NoError(t, err)
+		rangeIterationTest(t, store)
+	}
+}
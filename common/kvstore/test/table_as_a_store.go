@@ -49,6 +49,21 @@ func (t *tableAsAStore) NewIterator(prefix []byte) (iterator.Iterator, error) {
 	return t.tableStore.NewIterator(t.kb.Key(prefix))
 }
 
+func (t *tableAsAStore) NewRangeIterator(
+	start []byte,
+	end []byte,
+	options *kvstore.IteratorOptions) (iterator.Iterator, error) {
+
+	startKey, endKey := t.kb.TableRange()
+	if start != nil {
+		startKey = t.kb.Key(start)
+	}
+	if end != nil {
+		endKey = t.kb.Key(end)
+	}
+	return t.tableStore.NewRangeIterator(startKey, endKey, options)
+}
+
 func (t *tableAsAStore) Shutdown() error {
 	return t.tableStore.Shutdown()
 }
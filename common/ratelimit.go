@@ -67,6 +67,60 @@ type RateBucketParams struct {
 	LastRequestTime time.Time
 }
 
+// BucketDeduction is the amount to deduct from each bucket of a requester for a single request.
+type BucketDeduction struct {
+	RequesterID RequesterID
+	// Deductions[i] is the amount of time deducted from the i'th bucket.
+	Deductions []time.Duration
+}
+
+// BucketCheckResult is the outcome of checking the buckets of a requester for a single request.
+type BucketCheckResult struct {
+	// Allowed is true if every bucket of the requester remains non-empty after the deduction.
+	Allowed bool
+	// BucketLevels are the levels of the buckets after the deduction.
+	BucketLevels []time.Duration
+}
+
+// AtomicBucketStore is a bucket store that checks and updates the buckets of several requests in a single atomic
+// operation. Rate limiters that share such a store (e.g. replicas of a service behind a load balancer) enforce a
+// single shared limit without racing each other.
+type AtomicBucketStore interface {
+	KVStore[RateBucketParams]
+
+	// CheckAndDeduct refills the buckets of each requester by the time elapsed since its last request (up to the
+	// bucket sizes), deducts the given amounts, and checks whether the requests are allowed. Results are returned in
+	// the order of the deductions. If countFailed is false, processing stops at the first request that is not
+	// allowed, so fewer results than deductions may be returned. The buckets are only updated if every request is
+	// allowed or countFailed is true. A requester with no stored buckets starts with full buckets.
+	CheckAndDeduct(
+		ctx context.Context,
+		now time.Time,
+		bucketSizes []time.Duration,
+		deductions []BucketDeduction,
+		countFailed bool) ([]BucketCheckResult, error)
+}
+
+// BucketLevels is a snapshot of the buckets of a single requester.
+type BucketLevels struct {
+	// Found is false if the rate limiter has no record of the requester, in which case its buckets are full.
+	Found bool
+	// LastRequestTime is the time of the last request that was counted against the buckets.
+	LastRequestTime time.Time
+	// Levels are the levels of the buckets at the time of the snapshot, i.e. including the time that has elapsed
+	// since the last request.
+	Levels []time.Duration
+	// Sizes are the sizes of the buckets, in the same order as Levels.
+	Sizes []time.Duration
+}
+
+// BucketInspector is implemented by rate limiters whose buckets can be inspected without counting a request against
+// them.
+type BucketInspector interface {
+	// GetBucketLevels returns the current levels of the buckets of a requester.
+	GetBucketLevels(ctx context.Context, requesterID RequesterID) (*BucketLevels, error)
+}
+
 // GetClientAddress returns the client address from the context. If the header is not empty, it will
 // take the ip address located at the `numProxies` position from the end of the header. If the ip address cannot be
 // found in the header, it will use the connection ip if `allowDirectConnectionFallback` is true. Otherwise, it will return
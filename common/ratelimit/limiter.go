@@ -46,6 +46,10 @@ func NewRateLimiter(reg prometheus.Registerer, rateParams common.GlobalRateParam
 // is not allowed.
 func (d *rateLimiter) AllowRequest(ctx context.Context, params []common.RequestParams) (bool, *common.RequestParams, error) {
 
+	if atomicStore, ok := d.bucketStore.(common.AtomicBucketStore); ok {
+		return d.allowRequestAtomically(ctx, atomicStore, params)
+	}
+
 	updatedBucketParams := make([]*common.RateBucketParams, len(params))
 
 	allowed := true
@@ -76,6 +80,72 @@ func (d *rateLimiter) AllowRequest(ctx context.Context, params []common.RequestP
 
 }
 
+// allowRequestAtomically checks and updates the buckets of all requests in a single operation on the bucket store,
+// so that rate limiters sharing the store can't race each other between reading and updating buckets.
+func (d *rateLimiter) allowRequestAtomically(ctx context.Context, store common.AtomicBucketStore, params []common.RequestParams) (bool, *common.RequestParams, error) {
+
+	deductions := make([]common.BucketDeduction, len(params))
+	for i, param := range params {
+		deductions[i] = common.BucketDeduction{
+			RequesterID: param.RequesterID,
+			Deductions:  d.getDeductions(param),
+		}
+	}
+
+	results, err := store.CheckAndDeduct(ctx, time.Now().UTC(), d.globalRateParams.BucketSizes, deductions, d.globalRateParams.CountFailed)
+	if err != nil {
+		return false, nil, err
+	}
+
+	allowed := true
+	var limitedParam *common.RequestParams
+	for i, result := range results {
+		d.logger.Debug("Bucket levels updated", "key", params[i].RequesterID, "name", params[i].RequesterName, "levels", result.BucketLevels, "allowed", result.Allowed)
+		d.reportBucketLevels(params[i], result.BucketLevels)
+		if !result.Allowed && allowed {
+			allowed = false
+			limitedParam = &params[i]
+		}
+	}
+
+	return allowed, limitedParam, nil
+}
+
+// GetBucketLevels returns the current levels of the buckets of a requester. As when checking requests, a requester
+// whose buckets can't be read from the bucket store is treated as having full buckets.
+func (d *rateLimiter) GetBucketLevels(ctx context.Context, requesterID common.RequesterID) (*common.BucketLevels, error) {
+	sizes := make([]time.Duration, len(d.globalRateParams.BucketSizes))
+	copy(sizes, d.globalRateParams.BucketSizes)
+
+	bucketParams, err := d.bucketStore.GetItem(ctx, requesterID)
+	if err != nil || bucketParams == nil {
+		levels := make([]time.Duration, len(sizes))
+		copy(levels, sizes)
+		return &common.BucketLevels{
+			Found:  false,
+			Levels: levels,
+			Sizes:  sizes,
+		}, nil
+	}
+
+	interval := time.Since(bucketParams.LastRequestTime)
+	levels := make([]time.Duration, len(sizes))
+	for i, size := range sizes {
+		if i < len(bucketParams.BucketLevels) {
+			levels[i] = getBucketLevel(bucketParams.BucketLevels[i], size, interval, 0)
+		} else {
+			levels[i] = size
+		}
+	}
+
+	return &common.BucketLevels{
+		Found:           true,
+		LastRequestTime: bucketParams.LastRequestTime,
+		Levels:          levels,
+		Sizes:           sizes,
+	}, nil
+}
+
 func (d *rateLimiter) updateBucketParams(ctx context.Context, params []common.RequestParams, updatedBucketParams []*common.RateBucketParams) error {
 	for i, param := range params {
 		err := d.bucketStore.UpdateItem(ctx, param.RequesterID, updatedBucketParams[i])
@@ -109,29 +179,17 @@ func (d *rateLimiter) checkAllowed(ctx context.Context, params common.RequestPar
 	lastRequestTime := time.Now().UTC()
 
 	// Calculate updated bucket levels
+	deductions := d.getDeductions(params)
 	allowed := true
 	for i, size := range d.globalRateParams.BucketSizes {
 
-		// Determine bucket deduction
-		deduction := time.Microsecond * time.Duration(1e6*float32(params.BlobSize)/float32(params.Rate)/d.globalRateParams.Multipliers[i])
-
 		// Update the bucket level
-		bucketLevels[i] = getBucketLevel(bucketParams.BucketLevels[i], size, interval, deduction)
+		bucketLevels[i] = getBucketLevel(bucketParams.BucketLevels[i], size, interval, deductions[i])
 		allowed = allowed && bucketLevels[i] > 0
 
-		d.logger.Debug("Bucket level updated", "key", params.RequesterID, "name", params.RequesterName, "prevLevel", bucketParams.BucketLevels[i], "level", bucketLevels[i], "size", size, "interval", interval, "deduction", deduction, "allowed", allowed)
-
-		// Update metrics only if the requester name is provided. We're making
-		// an assumption that the requester name is only provided for authenticated
-		// requests so it should limit the cardinality of the requester_id label.
-		if params.RequesterName != "" {
-			d.bucketLevels.With(prometheus.Labels{
-				"requester_id":   params.RequesterID,
-				"requester_name": params.RequesterName,
-				"bucket_index":   strconv.Itoa(i),
-			}).Set(float64(bucketLevels[i]))
-		}
+		d.logger.Debug("Bucket level updated", "key", params.RequesterID, "name", params.RequesterName, "prevLevel", bucketParams.BucketLevels[i], "level", bucketLevels[i], "size", size, "interval", interval, "deduction", deductions[i], "allowed", allowed)
 	}
+	d.reportBucketLevels(params, bucketLevels)
 
 	bucketParams = &common.RateBucketParams{
 		LastRequestTime: lastRequestTime,
@@ -142,6 +200,32 @@ func (d *rateLimiter) checkAllowed(ctx context.Context, params common.RequestPar
 
 }
 
+// getDeductions returns the amount of time to deduct from each bucket for a request.
+func (d *rateLimiter) getDeductions(params common.RequestParams) []time.Duration {
+	deductions := make([]time.Duration, len(d.globalRateParams.BucketSizes))
+	for i := range deductions {
+		deductions[i] = time.Microsecond * time.Duration(1e6*float32(params.BlobSize)/float32(params.Rate)/d.globalRateParams.Multipliers[i])
+	}
+	return deductions
+}
+
+// reportBucketLevels updates the bucket level metrics of a requester.
+func (d *rateLimiter) reportBucketLevels(params common.RequestParams, bucketLevels []time.Duration) {
+	// Update metrics only if the requester name is provided. We're making
+	// an assumption that the requester name is only provided for authenticated
+	// requests so it should limit the cardinality of the requester_id label.
+	if params.RequesterName == "" {
+		return
+	}
+	for i, level := range bucketLevels {
+		d.bucketLevels.With(prometheus.Labels{
+			"requester_id":   params.RequesterID,
+			"requester_name": params.RequesterName,
+			"bucket_index":   strconv.Itoa(i),
+		}).Set(float64(level))
+	}
+}
+
 func getBucketLevel(bucketLevel, bucketSize, interval, deduction time.Duration) time.Duration {
 
 	newLevel := bucketLevel + interval - deduction
@@ -59,3 +59,44 @@ func TestRatelimit(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, false, allow)
 }
+
+func TestGetBucketLevels(t *testing.T) {
+
+	ratelimiter, err := makeTestRatelimiter()
+	assert.NoError(t, err)
+
+	inspector, ok := ratelimiter.(common.BucketInspector)
+	assert.True(t, ok)
+
+	ctx := context.Background()
+
+	levels, err := inspector.GetBucketLevels(ctx, "testRetriever")
+	assert.NoError(t, err)
+	assert.False(t, levels.Found)
+	assert.Equal(t, []time.Duration{time.Second, time.Minute}, levels.Levels)
+	assert.Equal(t, []time.Duration{time.Second, time.Minute}, levels.Sizes)
+
+	params := []common.RequestParams{
+		{
+			RequesterID: "testRetriever",
+			BlobSize:    50,
+			Rate:        100,
+		},
+	}
+	allow, _, err := ratelimiter.AllowRequest(ctx, params)
+	assert.NoError(t, err)
+	assert.True(t, allow)
+
+	levels, err = inspector.GetBucketLevels(ctx, "testRetriever")
+	assert.NoError(t, err)
+	assert.True(t, levels.Found)
+	assert.False(t, levels.LastRequestTime.IsZero())
+	// half a second was deducted from each bucket, and a little time has passed since
+	assert.InDelta(t, float64(500*time.Millisecond), float64(levels.Levels[0]), float64(100*time.Millisecond))
+	assert.InDelta(t, float64(time.Minute-500*time.Millisecond), float64(levels.Levels[1]), float64(100*time.Millisecond))
+
+	// inspecting the buckets doesn't count as a request
+	again, err := inspector.GetBucketLevels(ctx, "testRetriever")
+	assert.NoError(t, err)
+	assert.Equal(t, levels.LastRequestTime, again.LastRequestTime)
+}
@@ -0,0 +1,172 @@
+package ratelimit_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/redis"
+	"github.com/Layr-Labs/eigenda/common/store"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRedisBucketStore(t *testing.T, ttl time.Duration) (*miniredis.Miniredis, common.AtomicBucketStore) {
+	server := miniredis.RunT(t)
+
+	config := redis.DefaultClientConfig()
+	config.Address = server.Addr()
+	client, err := redis.NewClient(*config)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		_ = client.Close()
+	})
+
+	return server, store.NewRedisBucketStore(client, "ratelimit:", ttl)
+}
+
+func TestRedisBucketStore(t *testing.T) {
+	server, bucketStore := newRedisBucketStore(t, time.Minute)
+	ctx := context.Background()
+
+	_, err := bucketStore.GetItem(ctx, "requester")
+	assert.Error(t, err)
+
+	params := &common.RateBucketParams{
+		BucketLevels:    []time.Duration{time.Second, 3 * time.Millisecond},
+		LastRequestTime: time.UnixMicro(time.Now().UnixMicro()).UTC(),
+	}
+	err = bucketStore.UpdateItem(ctx, "requester", params)
+	assert.NoError(t, err)
+
+	stored, err := bucketStore.GetItem(ctx, "requester")
+	assert.NoError(t, err)
+	assert.Equal(t, params, stored)
+
+	// Buckets expire once the ttl elapses.
+	server.FastForward(time.Minute)
+	_, err = bucketStore.GetItem(ctx, "requester")
+	assert.Error(t, err)
+}
+
+func TestRedisCheckAndDeduct(t *testing.T) {
+	_, bucketStore := newRedisBucketStore(t, time.Minute)
+	ctx := context.Background()
+
+	sizes := []time.Duration{time.Second, 10 * time.Second}
+	now := time.Now()
+
+	// A new requester starts with full buckets.
+	results, err := bucketStore.CheckAndDeduct(ctx, now, sizes, []common.BucketDeduction{
+		{RequesterID: "a", Deductions: []time.Duration{600 * time.Millisecond, time.Second}},
+	}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []common.BucketCheckResult{
+		{Allowed: true, BucketLevels: []time.Duration{400 * time.Millisecond, 9 * time.Second}},
+	}, results)
+
+	// Buckets refill with time, up to their size.
+	now = now.Add(100 * time.Millisecond)
+	results, err = bucketStore.CheckAndDeduct(ctx, now, sizes, []common.BucketDeduction{
+		{RequesterID: "a", Deductions: []time.Duration{200 * time.Millisecond, 2 * time.Second}},
+	}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []common.BucketCheckResult{
+		{Allowed: true, BucketLevels: []time.Duration{300 * time.Millisecond, 7100 * time.Millisecond}},
+	}, results)
+
+	// If a request is not allowed, processing stops and no buckets are updated.
+	results, err = bucketStore.CheckAndDeduct(ctx, now, sizes, []common.BucketDeduction{
+		{RequesterID: "b", Deductions: []time.Duration{time.Millisecond, time.Millisecond}},
+		{RequesterID: "a", Deductions: []time.Duration{time.Second, time.Second}},
+		{RequesterID: "c", Deductions: []time.Duration{time.Millisecond, time.Millisecond}},
+	}, false)
+	assert.NoError(t, err)
+	assert.Len(t, results, 2)
+	assert.True(t, results[0].Allowed)
+	assert.False(t, results[1].Allowed)
+	_, err = bucketStore.GetItem(ctx, "b")
+	assert.Error(t, err)
+	stored, err := bucketStore.GetItem(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{300 * time.Millisecond, 7100 * time.Millisecond}, stored.BucketLevels)
+
+	// If failed requests are counted, all requests are processed and all buckets are updated.
+	results, err = bucketStore.CheckAndDeduct(ctx, now, sizes, []common.BucketDeduction{
+		{RequesterID: "b", Deductions: []time.Duration{time.Millisecond, time.Millisecond}},
+		{RequesterID: "a", Deductions: []time.Duration{time.Second, time.Second}},
+		{RequesterID: "c", Deductions: []time.Duration{time.Millisecond, time.Millisecond}},
+	}, true)
+	assert.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.False(t, results[1].Allowed)
+	stored, err = bucketStore.GetItem(ctx, "a")
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{0, 6100 * time.Millisecond}, stored.BucketLevels)
+	_, err = bucketStore.GetItem(ctx, "c")
+	assert.NoError(t, err)
+
+	// The number of deductions must match the number of buckets.
+	_, err = bucketStore.CheckAndDeduct(ctx, now, sizes, []common.BucketDeduction{
+		{RequesterID: "a", Deductions: []time.Duration{time.Second}},
+	}, true)
+	assert.Error(t, err)
+}
+
+func TestRedisRatelimitSharedByReplicas(t *testing.T) {
+	_, bucketStore := newRedisBucketStore(t, time.Minute)
+	ctx := context.Background()
+
+	globalParams := common.GlobalRateParams{
+		BucketSizes: []time.Duration{time.Minute},
+		Multipliers: []float32{1},
+	}
+
+	// Several replicas share a single limit.
+	replicas := make([]common.RateLimiter, 4)
+	for i := range replicas {
+		replicas[i] = ratelimit.NewRateLimiter(prometheus.NewRegistry(), globalParams, bucketStore, logging.NewNoopLogger())
+	}
+
+	// Each request uses a second of the bucket, so a minute's worth of requests is allowed.
+	params := []common.RequestParams{
+		{
+			RequesterID:   "rollup",
+			RequesterName: "rollup",
+			BlobSize:      100,
+			Rate:          100,
+		},
+	}
+
+	allowedCount := 0
+	lock := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for i, replica := range replicas {
+		wg.Add(1)
+		go func(i int, replica common.RateLimiter) {
+			defer wg.Done()
+			for j := 0; j < 30; j++ {
+				allowed, limited, err := replica.AllowRequest(ctx, params)
+				assert.NoError(t, err)
+				lock.Lock()
+				if allowed {
+					allowedCount++
+				} else {
+					assert.Equal(t, "rollup", limited.RequesterID, fmt.Sprintf("replica %d", i))
+				}
+				lock.Unlock()
+			}
+		}(i, replica)
+	}
+	wg.Wait()
+
+	// The bucket refills slightly while the requests are processed, which may allow one more request.
+	assert.GreaterOrEqual(t, allowedCount, 59)
+	assert.LessOrEqual(t, allowedCount, 60)
+}
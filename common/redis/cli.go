@@ -0,0 +1,110 @@
+package redis
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+var (
+	AddressFlagName   = "redis.address"
+	PasswordFlagName  = "redis.password"
+	DBFlagName        = "redis.db"
+	PoolSizeFlagName  = "redis.pool-size"
+	TimeoutFlagName   = "redis.timeout"
+	TLSFlagName       = "redis.tls"
+	TLSCACertFlagName = "redis.tls-ca-cert"
+)
+
+type ClientConfig struct {
+	// Address (host:port) of the server. If empty, Redis is not used.
+	Address string
+	// Password used to authenticate with the server. If empty, no authentication is performed.
+	Password string
+	// DB is the index of the database to select after connecting. Default is 0.
+	DB int
+	// PoolSize is the maximum number of connections to the server. Default is 16.
+	PoolSize int
+	// Timeout bounds the time taken to connect to the server, and to send a single command and read its reply
+	// when the context of the command has no earlier deadline. Default is 1 second.
+	Timeout time.Duration
+	// TLSEnabled makes the client connect to the server over TLS.
+	TLSEnabled bool
+	// TLSCACertPath is the path of a PEM file with the CA certificates used to verify the server. If empty, the
+	// system roots are used.
+	TLSCACertPath string
+}
+
+func ClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, AddressFlagName),
+			Usage:    "Address (host:port) of a server that speaks the Redis protocol. If empty, Redis is not used",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "REDIS_ADDRESS"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, PasswordFlagName),
+			Usage:    "Password of the Redis server",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "REDIS_PASSWORD"),
+		},
+		cli.IntFlag{
+			Name:     common.PrefixFlag(flagPrefix, DBFlagName),
+			Usage:    "Index of the Redis database to use",
+			Required: false,
+			Value:    0,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "REDIS_DB"),
+		},
+		cli.IntFlag{
+			Name:     common.PrefixFlag(flagPrefix, PoolSizeFlagName),
+			Usage:    "Maximum number of connections to the Redis server",
+			Required: false,
+			Value:    16,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "REDIS_POOL_SIZE"),
+		},
+		cli.DurationFlag{
+			Name:     common.PrefixFlag(flagPrefix, TimeoutFlagName),
+			Usage:    "Timeout for connecting to the Redis server and for each command",
+			Required: false,
+			Value:    time.Second,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "REDIS_TIMEOUT"),
+		},
+		cli.BoolFlag{
+			Name:     common.PrefixFlag(flagPrefix, TLSFlagName),
+			Usage:    "Connect to the Redis server over TLS",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "REDIS_TLS"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, TLSCACertFlagName),
+			Usage:    "Path of a PEM file with the CA certificates used to verify the Redis server. If empty, the system roots are used",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "REDIS_TLS_CA_CERT"),
+		},
+	}
+}
+
+func ReadClientConfig(ctx *cli.Context, flagPrefix string) ClientConfig {
+	return ClientConfig{
+		Address:       ctx.GlobalString(common.PrefixFlag(flagPrefix, AddressFlagName)),
+		Password:      ctx.GlobalString(common.PrefixFlag(flagPrefix, PasswordFlagName)),
+		DB:            ctx.GlobalInt(common.PrefixFlag(flagPrefix, DBFlagName)),
+		PoolSize:      ctx.GlobalInt(common.PrefixFlag(flagPrefix, PoolSizeFlagName)),
+		Timeout:       ctx.GlobalDuration(common.PrefixFlag(flagPrefix, TimeoutFlagName)),
+		TLSEnabled:    ctx.GlobalBool(common.PrefixFlag(flagPrefix, TLSFlagName)),
+		TLSCACertPath: ctx.GlobalString(common.PrefixFlag(flagPrefix, TLSCACertFlagName)),
+	}
+}
+
+// DefaultClientConfig returns a new ClientConfig with default values.
+func DefaultClientConfig() *ClientConfig {
+	return &ClientConfig{
+		PoolSize: 16,
+		Timeout:  time.Second,
+	}
+}
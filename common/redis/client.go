@@ -0,0 +1,75 @@
+package redis
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// NewClient creates a client of a server that speaks the Redis protocol. Connections are made lazily, so the server
+// does not need to be reachable when the client is created. The client is safe for concurrent use.
+func NewClient(cfg ClientConfig) (*goredis.Client, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("redis address is required")
+	}
+
+	defaults := DefaultClientConfig()
+	if cfg.PoolSize <= 0 {
+		cfg.PoolSize = defaults.PoolSize
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaults.Timeout
+	}
+
+	options := &goredis.Options{
+		Addr:         cfg.Address,
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		PoolSize:     cfg.PoolSize,
+		DialTimeout:  cfg.Timeout,
+		ReadTimeout:  cfg.Timeout,
+		WriteTimeout: cfg.Timeout,
+		// Commands give up at the deadline of their context if it is earlier than the timeout.
+		ContextTimeoutEnabled: true,
+	}
+	if cfg.TLSEnabled {
+		tlsConfig, err := newTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		options.TLSConfig = tlsConfig
+	}
+
+	return goredis.NewClient(options), nil
+}
+
+// newTLSConfig returns the TLS configuration used to connect to the server. The certificate of the server is
+// verified against the configured CA certificates, or the system roots if none are configured.
+func newTLSConfig(cfg ClientConfig) (*tls.Config, error) {
+	host, _, err := net.SplitHostPort(cfg.Address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis address %s: %w", cfg.Address, err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: host,
+	}
+	if cfg.TLSCACertPath != "" {
+		pem, err := os.ReadFile(cfg.TLSCACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read redis CA certificates: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.TLSCACertPath)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
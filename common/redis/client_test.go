@@ -0,0 +1,122 @@
+package redis_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/redis"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig(address string) redis.ClientConfig {
+	config := redis.DefaultClientConfig()
+	config.Address = address
+	config.PoolSize = 4
+	return *config
+}
+
+func TestAuthAndSelect(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireAuth("secret")
+	ctx := context.Background()
+
+	config := newTestConfig(server.Addr())
+	config.Password = "wrong"
+	client, err := redis.NewClient(config)
+	require.NoError(t, err)
+	assert.Error(t, client.Ping(ctx).Err())
+	_ = client.Close()
+
+	config.Password = "secret"
+	config.DB = 1
+	client, err = redis.NewClient(config)
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	err = client.Set(ctx, "key", "value", time.Second).Err()
+	assert.NoError(t, err)
+	value, err := server.DB(1).Get("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+	assert.False(t, server.DB(0).Exists("key"))
+}
+
+// writeTestCertificate writes a self-signed certificate for 127.0.0.1 to a PEM file, and returns it along with the
+// path of the file.
+func writeTestCertificate(t *testing.T) (tls.Certificate, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "redis"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	require.NoError(t, err)
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, path
+}
+
+func TestTLS(t *testing.T) {
+	certificate, caPath := writeTestCertificate(t)
+	server, err := miniredis.RunTLS(&tls.Config{Certificates: []tls.Certificate{certificate}})
+	require.NoError(t, err)
+	defer server.Close()
+	ctx := context.Background()
+
+	config := newTestConfig(server.Addr())
+	config.TLSEnabled = true
+	config.TLSCACertPath = caPath
+	client, err := redis.NewClient(config)
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+	assert.NoError(t, client.Ping(ctx).Err())
+
+	// The certificate of the server isn't trusted by default.
+	config.TLSCACertPath = ""
+	untrusted, err := redis.NewClient(config)
+	require.NoError(t, err)
+	defer func() { _ = untrusted.Close() }()
+	assert.Error(t, untrusted.Ping(ctx).Err())
+
+	// Plaintext connections are rejected.
+	config.TLSEnabled = false
+	plaintext, err := redis.NewClient(config)
+	require.NoError(t, err)
+	defer func() { _ = plaintext.Close() }()
+	assert.Error(t, plaintext.Ping(ctx).Err())
+}
+
+func TestInvalidConfig(t *testing.T) {
+	_, err := redis.NewClient(redis.ClientConfig{})
+	assert.Error(t, err)
+
+	config := newTestConfig("127.0.0.1:6379")
+	config.TLSEnabled = true
+	config.TLSCACertPath = filepath.Join(t.TempDir(), "missing.pem")
+	_, err = redis.NewClient(config)
+	assert.Error(t, err)
+}
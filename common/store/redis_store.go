@@ -0,0 +1,220 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/redis/go-redis/v9"
+)
+
+// CheckAndDeductScript atomically refills, deducts and checks the rate limit buckets of several requesters.
+// Bucket state is stored as a string of comma separated integers: the time of the last request followed by the
+// level of each bucket, all in microseconds (which, unlike nanoseconds, are exactly representable as Lua numbers).
+//
+// KEYS: the key of each request's requester.
+// ARGV: the current time, 1 if failed requests count towards the limit (0 otherwise), the ttl of the keys in
+// milliseconds (0 for no ttl), the number of buckets n, the n bucket sizes, then n deductions for each key.
+//
+// Returns, for each request that was processed, an array of 1 if the request is allowed (0 otherwise) followed by
+// the new bucket levels.
+const CheckAndDeductScript = `
+local now = tonumber(ARGV[1])
+local countFailed = ARGV[2] == '1'
+local ttl = tonumber(ARGV[3])
+local n = tonumber(ARGV[4])
+local sizes = {}
+for i = 1, n do
+	sizes[i] = tonumber(ARGV[4 + i])
+end
+
+local results = {}
+local values = {}
+local allAllowed = true
+for k = 1, #KEYS do
+	local levels = {}
+	local interval = 0
+	local stored = redis.call('GET', KEYS[k])
+	local fields = {}
+	if stored then
+		for field in string.gmatch(stored, '[^,]+') do
+			fields[#fields + 1] = tonumber(field)
+		end
+		interval = math.max(0, now - fields[1])
+	end
+
+	local allowed = 1
+	local result = {0}
+	local value = string.format('%d', now)
+	for i = 1, n do
+		local level = fields[i + 1] or sizes[i]
+		local deduction = tonumber(ARGV[4 + n + (k - 1) * n + i])
+		level = math.min(math.max(level + interval - deduction, 0), sizes[i])
+		if level <= 0 then
+			allowed = 0
+		end
+		result[i + 1] = level
+		value = value .. ',' .. string.format('%d', level)
+	end
+	result[1] = allowed
+	results[k] = result
+	values[k] = value
+
+	if allowed == 0 then
+		allAllowed = false
+		if not countFailed then
+			break
+		end
+	end
+end
+
+if allAllowed or countFailed then
+	for k = 1, #values do
+		if ttl > 0 then
+			redis.call('SET', KEYS[k], values[k], 'PX', ttl)
+		else
+			redis.call('SET', KEYS[k], values[k])
+		end
+	end
+end
+return results
+`
+
+var checkAndDeductScript = redis.NewScript(CheckAndDeductScript)
+
+var _ common.AtomicBucketStore = &redisBucketStore{}
+
+// redisBucketStore stores rate limit buckets in a server that speaks the Redis protocol, so that several rate
+// limiters can share them. All keys touched by a single CheckAndDeduct must be on the same server, so this store
+// does not support Redis Cluster.
+type redisBucketStore struct {
+	client    redis.Cmdable
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// NewRedisBucketStore creates a bucket store backed by Redis. Keys are the requester ID prefixed with keyPrefix.
+// Keys expire after ttl (if positive), which should be at least the largest bucket size, since a requester that has
+// been idle for that long has full buckets.
+func NewRedisBucketStore(client redis.Cmdable, keyPrefix string, ttl time.Duration) common.AtomicBucketStore {
+	return &redisBucketStore{
+		client:    client,
+		keyPrefix: keyPrefix,
+		ttl:       ttl,
+	}
+}
+
+func (s *redisBucketStore) GetItem(ctx context.Context, requesterID string) (*common.RateBucketParams, error) {
+	value, err := s.client.Get(ctx, s.keyPrefix+requesterID).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, errors.New("item not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return decodeBucketParams(value)
+}
+
+func (s *redisBucketStore) UpdateItem(ctx context.Context, requesterID string, params *common.RateBucketParams) error {
+	// A ttl of 0 sets no expiry
+	return s.client.Set(ctx, s.keyPrefix+requesterID, encodeBucketParams(params), max(s.ttl, 0)).Err()
+}
+
+func (s *redisBucketStore) CheckAndDeduct(
+	ctx context.Context,
+	now time.Time,
+	bucketSizes []time.Duration,
+	deductions []common.BucketDeduction,
+	countFailed bool) ([]common.BucketCheckResult, error) {
+
+	keys := make([]string, len(deductions))
+	args := make([]interface{}, 0, 4+len(bucketSizes)*(1+len(deductions)))
+	args = append(args,
+		strconv.FormatInt(now.UnixMicro(), 10),
+		"0",
+		strconv.FormatInt(s.ttl.Milliseconds(), 10),
+		strconv.Itoa(len(bucketSizes)))
+	if countFailed {
+		args[1] = "1"
+	}
+	for _, size := range bucketSizes {
+		args = append(args, strconv.FormatInt(size.Microseconds(), 10))
+	}
+	for i, deduction := range deductions {
+		if len(deduction.Deductions) != len(bucketSizes) {
+			return nil, fmt.Errorf("request %d has %d deductions, expected %d",
+				i, len(deduction.Deductions), len(bucketSizes))
+		}
+		keys[i] = s.keyPrefix + deduction.RequesterID
+		for _, amount := range deduction.Deductions {
+			args = append(args, strconv.FormatInt(amount.Microseconds(), 10))
+		}
+	}
+
+	reply, err := checkAndDeductScript.Run(ctx, s.client, keys, args...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	replies, ok := reply.([]interface{})
+	if !ok || len(replies) > len(deductions) {
+		return nil, fmt.Errorf("unexpected reply %v", reply)
+	}
+	results := make([]common.BucketCheckResult, len(replies))
+	for i, resultReply := range replies {
+		fields, ok := resultReply.([]interface{})
+		if !ok || len(fields) != 1+len(bucketSizes) {
+			return nil, fmt.Errorf("unexpected result %v", resultReply)
+		}
+		levels := make([]time.Duration, len(bucketSizes))
+		for j, field := range fields {
+			value, ok := field.(int64)
+			if !ok {
+				return nil, fmt.Errorf("unexpected result %v", resultReply)
+			}
+			if j == 0 {
+				results[i].Allowed = value == 1
+			} else {
+				levels[j-1] = time.Duration(value) * time.Microsecond
+			}
+		}
+		results[i].BucketLevels = levels
+	}
+	return results, nil
+}
+
+// encodeBucketParams encodes bucket params in the format used by CheckAndDeductScript.
+func encodeBucketParams(params *common.RateBucketParams) string {
+	fields := make([]string, 0, 1+len(params.BucketLevels))
+	fields = append(fields, strconv.FormatInt(params.LastRequestTime.UnixMicro(), 10))
+	for _, level := range params.BucketLevels {
+		fields = append(fields, strconv.FormatInt(level.Microseconds(), 10))
+	}
+	return strings.Join(fields, ",")
+}
+
+// decodeBucketParams decodes bucket params in the format used by CheckAndDeductScript.
+func decodeBucketParams(value string) (*common.RateBucketParams, error) {
+	fields := strings.Split(value, ",")
+	micros := make([]int64, len(fields))
+	for i, field := range fields {
+		var err error
+		micros[i], err = strconv.ParseInt(field, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bucket params %q: %w", value, err)
+		}
+	}
+
+	levels := make([]time.Duration, len(micros)-1)
+	for i := range levels {
+		levels[i] = time.Duration(micros[i+1]) * time.Microsecond
+	}
+	return &common.RateBucketParams{
+		BucketLevels:    levels,
+		LastRequestTime: time.UnixMicro(micros[0]).UTC(),
+	}, nil
+}
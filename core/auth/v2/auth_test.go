@@ -3,6 +3,7 @@ package v2_test
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
@@ -57,6 +58,52 @@ func TestAuthenticationFail(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestAuthenticatePaymentStateRequest(t *testing.T) {
+	signer := auth.NewLocalBlobRequestSigner(privateKeyHex)
+	authenticator := auth.NewAuthenticator()
+
+	accountId, err := signer.GetAccountID()
+	assert.NoError(t, err)
+
+	timestamp := uint64(time.Now().UnixNano())
+	signature, err := signer.SignPaymentStateRequest(timestamp)
+	assert.NoError(t, err)
+
+	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId, timestamp)
+	assert.NoError(t, err)
+
+	// The signature doesn't cover a different timestamp
+	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId, timestamp+1)
+	assert.Error(t, err)
+}
+
+func TestAuthenticatePaymentStateRequestFail(t *testing.T) {
+	signer := auth.NewLocalBlobRequestSigner(privateKeyHex)
+	authenticator := auth.NewAuthenticator()
+
+	accountId, err := signer.GetAccountID()
+	assert.NoError(t, err)
+	timestamp := uint64(time.Now().UnixNano())
+
+	// Signature from a different account
+	wrongPrivateKeyHex := "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcded"
+	wrongSigner := auth.NewLocalBlobRequestSigner(wrongPrivateKeyHex)
+	signature, err := wrongSigner.SignPaymentStateRequest(timestamp)
+	assert.NoError(t, err)
+	err = authenticator.AuthenticatePaymentStateRequest(signature, accountId, timestamp)
+	assert.Error(t, err)
+
+	// Malformed signature
+	err = authenticator.AuthenticatePaymentStateRequest([]byte{1, 2, 3}, accountId, timestamp)
+	assert.EqualError(t, err, "signature length is unexpected: 3")
+
+	// Malformed account ID
+	signature, err = signer.SignPaymentStateRequest(timestamp)
+	assert.NoError(t, err)
+	err = authenticator.AuthenticatePaymentStateRequest(signature, "not-hex", timestamp)
+	assert.Error(t, err)
+}
+
 func TestNoopSignerFail(t *testing.T) {
 	signer := auth.NewLocalNoopSigner()
 	accountId, err := signer.GetAccountID()
@@ -66,6 +113,9 @@ func TestNoopSignerFail(t *testing.T) {
 
 	_, err = signer.SignBlobRequest(header)
 	assert.EqualError(t, err, "noop signer cannot sign blob request")
+
+	_, err = signer.SignPaymentStateRequest(uint64(time.Now().UnixNano()))
+	assert.EqualError(t, err, "noop signer cannot sign payment state request")
 }
 
 func testHeader(t *testing.T, accountID string) *corev2.BlobHeader {
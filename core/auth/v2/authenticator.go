@@ -2,6 +2,7 @@ package v2
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 
@@ -32,19 +33,46 @@ func (*authenticator) AuthenticateBlobRequest(header *core.BlobHeader) error {
 		return fmt.Errorf("failed to get blob key: %v", err)
 	}
 
-	publicKeyBytes, err := hexutil.Decode(header.PaymentMetadata.AccountID)
+	return verifySignature(blobKey[:], sig, header.PaymentMetadata.AccountID)
+}
+
+func (*authenticator) AuthenticatePaymentStateRequest(sig []byte, accountId string, timestamp uint64) error {
+	// Ensure the signature is 65 bytes (Recovery ID is the last byte)
+	if len(sig) != 65 {
+		return fmt.Errorf("signature length is unexpected: %d", len(sig))
+	}
+
+	accountIdBytes, err := hexutil.Decode(accountId)
+	if err != nil {
+		return fmt.Errorf("failed to decode account ID (%v): %v", accountId, err)
+	}
+
+	return verifySignature(hashPaymentStateRequest(accountIdBytes, timestamp), sig, accountId)
+}
+
+// hashPaymentStateRequest returns the hash signed by the account in a payment state request: the keccak256 hash of
+// the account ID followed by the big-endian encoding of the timestamp of the request.
+func hashPaymentStateRequest(accountIdBytes []byte, timestamp uint64) []byte {
+	timestampBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(timestampBytes, timestamp)
+	return crypto.Keccak256(accountIdBytes, timestampBytes)
+}
+
+// verifySignature checks that the signature over the hash was produced by the key of the given account.
+func verifySignature(hash []byte, sig []byte, accountId string) error {
+	publicKeyBytes, err := hexutil.Decode(accountId)
 	if err != nil {
-		return fmt.Errorf("failed to decode public key (%v): %v", header.PaymentMetadata.AccountID, err)
+		return fmt.Errorf("failed to decode public key (%v): %v", accountId, err)
 	}
 
 	// Decode public key
 	pubKey, err := crypto.UnmarshalPubkey(publicKeyBytes)
 	if err != nil {
-		return fmt.Errorf("failed to decode public key (%v): %v", header.PaymentMetadata.AccountID, err)
+		return fmt.Errorf("failed to decode public key (%v): %v", accountId, err)
 	}
 
 	// Verify the signature
-	sigPublicKeyECDSA, err := crypto.SigToPub(blobKey[:], sig)
+	sigPublicKeyECDSA, err := crypto.SigToPub(hash, sig)
 	if err != nil {
 		return fmt.Errorf("failed to recover public key from signature: %v", err)
 	}
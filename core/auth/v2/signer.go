@@ -44,6 +44,20 @@ func (s *LocalBlobRequestSigner) SignBlobRequest(header *core.BlobHeader) ([]byt
 	return sig, nil
 }
 
+// SignPaymentStateRequest signs the keccak256 hash of the account ID, i.e. of the public key of the signer, and of
+// the timestamp of the request. The timestamp lets the disperser reject signatures that are replayed later.
+func (s *LocalBlobRequestSigner) SignPaymentStateRequest(timestamp uint64) ([]byte, error) {
+	accountIdBytes := crypto.FromECDSAPub(&s.PrivateKey.PublicKey)
+	hash := hashPaymentStateRequest(accountIdBytes, timestamp)
+
+	sig, err := crypto.Sign(hash, s.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign hash: %v", err)
+	}
+
+	return sig, nil
+}
+
 func (s *LocalBlobRequestSigner) GetAccountID() (string, error) {
 
 	publicKeyBytes := crypto.FromECDSAPub(&s.PrivateKey.PublicKey)
@@ -63,6 +77,10 @@ func (s *LocalNoopSigner) SignBlobRequest(header *core.BlobHeader) ([]byte, erro
 	return nil, fmt.Errorf("noop signer cannot sign blob request")
 }
 
+func (s *LocalNoopSigner) SignPaymentStateRequest(timestamp uint64) ([]byte, error) {
+	return nil, fmt.Errorf("noop signer cannot sign payment state request")
+}
+
 func (s *LocalNoopSigner) GetAccountID() (string, error) {
 	return "", fmt.Errorf("noop signer cannot get accountID")
 }
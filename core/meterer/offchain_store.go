@@ -39,6 +39,17 @@ func NewOffchainStore(
 		return OffchainStore{}, err
 	}
 
+	// The tables only need to be created when they are stored locally
+	for _, schema := range []*dynamodb.CreateTableInput{
+		GenerateReservationTableSchema(reservationTableName),
+		GenerateOnDemandTableSchema(onDemandTableName),
+		GenerateGlobalReservationTableSchema(globalBinTableName),
+	} {
+		if err := commondynamodb.EnsureTable(context.Background(), dynamoClient, schema); err != nil {
+			return OffchainStore{}, err
+		}
+	}
+
 	err = dynamoClient.TableExists(context.Background(), reservationTableName)
 	if err != nil {
 		return OffchainStore{}, err
@@ -237,3 +248,81 @@ func (s *OffchainStore) GetRelevantOnDemandRecords(ctx context.Context, accountI
 
 	return prevPayment, nextPayment, nextDataLength, nil
 }
+
+// GetReservationBins returns the reservation bins of an account with indices in [startIndex, endIndex], ordered by bin index.
+// Bins that have not been used are not included.
+func (s *OffchainStore) GetReservationBins(ctx context.Context, accountID string, startIndex uint32, endIndex uint32) ([]ReservationBin, error) {
+	queryInput := &dynamodb.QueryInput{
+		TableName:              aws.String(s.reservationTableName),
+		KeyConditionExpression: aws.String("AccountID = :account AND BinIndex BETWEEN :start AND :end"),
+		ExpressionAttributeValues: commondynamodb.ExpressionValues{
+			":account": &types.AttributeValueMemberS{Value: accountID},
+			":start":   &types.AttributeValueMemberN{Value: strconv.FormatUint(uint64(startIndex), 10)},
+			":end":     &types.AttributeValueMemberN{Value: strconv.FormatUint(uint64(endIndex), 10)},
+		},
+		ScanIndexForward: aws.Bool(true),
+	}
+	items, err := s.dynamoClient.QueryWithInput(ctx, queryInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reservation bins for account: %w", err)
+	}
+
+	bins := make([]ReservationBin, 0, len(items))
+	for _, item := range items {
+		binIndexAttr, ok := item["BinIndex"].(*types.AttributeValueMemberN)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type for BinIndex: %T", item["BinIndex"])
+		}
+		binIndex, err := strconv.ParseUint(binIndexAttr.Value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse BinIndex: %w", err)
+		}
+		binUsageAttr, ok := item["BinUsage"].(*types.AttributeValueMemberN)
+		if !ok {
+			return nil, fmt.Errorf("unexpected type for BinUsage: %T", item["BinUsage"])
+		}
+		binUsage, err := strconv.ParseUint(binUsageAttr.Value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse BinUsage: %w", err)
+		}
+		bins = append(bins, ReservationBin{
+			AccountID: accountID,
+			BinIndex:  uint32(binIndex),
+			BinUsage:  uint32(binUsage),
+		})
+	}
+
+	return bins, nil
+}
+
+// GetLargestCumulativePayment returns the largest cumulative payment recorded for an account, or zero if the account
+// has not made any on-demand payment.
+func (s *OffchainStore) GetLargestCumulativePayment(ctx context.Context, accountID string) (*big.Int, error) {
+	queryInput := &dynamodb.QueryInput{
+		TableName:              aws.String(s.onDemandTableName),
+		KeyConditionExpression: aws.String("AccountID = :account"),
+		ExpressionAttributeValues: commondynamodb.ExpressionValues{
+			":account": &types.AttributeValueMemberS{Value: accountID},
+		},
+		ScanIndexForward: aws.Bool(false),
+		Limit:            aws.Int32(1),
+	}
+	result, err := s.dynamoClient.QueryWithInput(ctx, queryInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payments for account: %w", err)
+	}
+	if len(result) == 0 {
+		return big.NewInt(0), nil
+	}
+
+	paymentAttr, ok := result[0]["CumulativePayments"].(*types.AttributeValueMemberN)
+	if !ok {
+		return nil, fmt.Errorf("unexpected type for CumulativePayments: %T", result[0]["CumulativePayments"])
+	}
+	payment, ok := new(big.Int).SetString(paymentAttr.Value, 10)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse CumulativePayments: %s", paymentAttr.Value)
+	}
+
+	return payment, nil
+}
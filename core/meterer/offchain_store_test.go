@@ -3,12 +3,15 @@ package meterer_test
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"strconv"
 	"testing"
 	"time"
 
 	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/meterer"
+	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/stretchr/testify/assert"
 )
@@ -246,3 +249,57 @@ func TestOnDemandUsageBasicOperations(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "3000", item["DataLength"].(*types.AttributeValueMemberN).Value)
 }
+
+func TestPaymentStateQueries(t *testing.T) {
+	reservationTableName := "reservations_test_state"
+	onDemandTableName := "ondemand_test_state"
+	globalTableName := "global_test_state"
+	err := meterer.CreateReservationTable(clientConfig, reservationTableName)
+	assert.NoError(t, err)
+	err = meterer.CreateOnDemandTable(clientConfig, onDemandTableName)
+	assert.NoError(t, err)
+	err = meterer.CreateGlobalReservationTable(clientConfig, globalTableName)
+	assert.NoError(t, err)
+
+	store, err := meterer.NewOffchainStore(clientConfig, reservationTableName, onDemandTableName, globalTableName, logging.NewNoopLogger())
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	// No usage recorded yet
+	bins, err := store.GetReservationBins(ctx, "account1", 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, bins, 0)
+	payment, err := store.GetLargestCumulativePayment(ctx, "account1")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(0), payment)
+
+	for _, binIndex := range []uint64{1, 3, 4, 7} {
+		_, err = store.UpdateReservationBin(ctx, "account1", binIndex, binIndex*100)
+		assert.NoError(t, err)
+	}
+	_, err = store.UpdateReservationBin(ctx, "account2", 3, 1000)
+	assert.NoError(t, err)
+
+	bins, err = store.GetReservationBins(ctx, "account1", 2, 4)
+	assert.NoError(t, err)
+	assert.Len(t, bins, 2)
+	assert.Equal(t, uint32(3), bins[0].BinIndex)
+	assert.Equal(t, uint32(300), bins[0].BinUsage)
+	assert.Equal(t, uint32(4), bins[1].BinIndex)
+	assert.Equal(t, uint32(400), bins[1].BinUsage)
+
+	for _, cumulativePayment := range []int64{100, 2500, 300} {
+		err = store.AddOnDemandPayment(ctx, core.PaymentMetadata{
+			AccountID:         "account1",
+			CumulativePayment: big.NewInt(cumulativePayment),
+		}, 10)
+		assert.NoError(t, err)
+	}
+	payment, err = store.GetLargestCumulativePayment(ctx, "account1")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(2500), payment)
+	payment, err = store.GetLargestCumulativePayment(ctx, "account2")
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(0), payment)
+}
@@ -12,7 +12,12 @@ import (
 
 func CreateReservationTable(clientConfig commonaws.ClientConfig, tableName string) error {
 	ctx := context.Background()
-	_, err := test_utils.CreateTable(ctx, clientConfig, tableName, &dynamodb.CreateTableInput{
+	_, err := test_utils.CreateTable(ctx, clientConfig, tableName, GenerateReservationTableSchema(tableName))
+	return err
+}
+
+func GenerateReservationTableSchema(tableName string) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
 		AttributeDefinitions: []types.AttributeDefinition{
 			{
 				AttributeName: aws.String("AccountID"),
@@ -38,13 +43,17 @@ func CreateReservationTable(clientConfig commonaws.ClientConfig, tableName strin
 			ReadCapacityUnits:  aws.Int64(10),
 			WriteCapacityUnits: aws.Int64(10),
 		},
-	})
-	return err
+	}
 }
 
 func CreateGlobalReservationTable(clientConfig commonaws.ClientConfig, tableName string) error {
 	ctx := context.Background()
-	_, err := test_utils.CreateTable(ctx, clientConfig, tableName, &dynamodb.CreateTableInput{
+	_, err := test_utils.CreateTable(ctx, clientConfig, tableName, GenerateGlobalReservationTableSchema(tableName))
+	return err
+}
+
+func GenerateGlobalReservationTableSchema(tableName string) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
 		AttributeDefinitions: []types.AttributeDefinition{
 			{
 				AttributeName: aws.String("BinIndex"),
@@ -80,13 +89,17 @@ func CreateGlobalReservationTable(clientConfig commonaws.ClientConfig, tableName
 			ReadCapacityUnits:  aws.Int64(10),
 			WriteCapacityUnits: aws.Int64(10),
 		},
-	})
-	return err
+	}
 }
 
 func CreateOnDemandTable(clientConfig commonaws.ClientConfig, tableName string) error {
 	ctx := context.Background()
-	_, err := test_utils.CreateTable(ctx, clientConfig, tableName, &dynamodb.CreateTableInput{
+	_, err := test_utils.CreateTable(ctx, clientConfig, tableName, GenerateOnDemandTableSchema(tableName))
+	return err
+}
+
+func GenerateOnDemandTableSchema(tableName string) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
 		AttributeDefinitions: []types.AttributeDefinition{
 			{
 				AttributeName: aws.String("AccountID"),
@@ -112,6 +125,5 @@ func CreateOnDemandTable(clientConfig commonaws.ClientConfig, tableName string)
 			ReadCapacityUnits:  aws.Int64(10),
 			WriteCapacityUnits: aws.Int64(10),
 		},
-	})
-	return err
+	}
 }
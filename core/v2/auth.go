@@ -2,9 +2,11 @@ package v2
 
 type BlobRequestAuthenticator interface {
 	AuthenticateBlobRequest(header *BlobHeader) error
+	AuthenticatePaymentStateRequest(sig []byte, accountId string, timestamp uint64) error
 }
 
 type BlobRequestSigner interface {
 	SignBlobRequest(header *BlobHeader) ([]byte, error)
+	SignPaymentStateRequest(timestamp uint64) ([]byte, error)
 	GetAccountID() (string, error)
 }
@@ -5,6 +5,8 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/wealdtech/go-merkletree/v2"
+	"github.com/wealdtech/go-merkletree/v2/keccak256"
 	"golang.org/x/crypto/sha3"
 )
 
@@ -263,3 +265,23 @@ func (h BatchHeader) Hash() ([32]byte, error) {
 
 	return headerHash, nil
 }
+
+// BuildMerkleTree builds the merkle tree over the hashes of the given blob certificates.
+// The root of the tree is the BatchRoot of the batch containing the certificates.
+func BuildMerkleTree(certs []*BlobCertificate) (*merkletree.MerkleTree, error) {
+	leafs := make([][]byte, len(certs))
+	for i, cert := range certs {
+		leaf, err := cert.Hash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob header hash: %w", err)
+		}
+		leafs[i] = leaf[:]
+	}
+
+	tree, err := merkletree.NewTree(merkletree.WithData(leafs), merkletree.WithHashType(keccak256.New()))
+	if err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
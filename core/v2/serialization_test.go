@@ -1,11 +1,14 @@
 package v2_test
 
 import (
+	"context"
 	"encoding/hex"
 	"math/big"
 	"testing"
 
+	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/mock"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
 	"github.com/stretchr/testify/assert"
@@ -97,3 +100,61 @@ func TestBlobCertHash(t *testing.T) {
 	// 0xc4512b8702f69cb837fff50a93d3d28aada535b1f151b64db45859c3f5bb096a verified in solidity
 	assert.Equal(t, "c4512b8702f69cb837fff50a93d3d28aada535b1f151b64db45859c3f5bb096a", hex.EncodeToString(hash[:]))
 }
+
+func TestBatchProtobufRoundTrip(t *testing.T) {
+	data := codec.ConvertByPaddingEmptyByte(GETTYSBURG_ADDRESS_BYTES)
+	commitments, err := p.GetCommitments(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certs := []*v2.BlobCertificate{
+		{
+			BlobHeader: &v2.BlobHeader{
+				BlobVersion:     0,
+				BlobCommitments: commitments,
+				QuorumNumbers:   []core.QuorumID{0, 1},
+				PaymentMetadata: core.PaymentMetadata{
+					AccountID:         "0x123",
+					BinIndex:          5,
+					CumulativePayment: big.NewInt(100),
+				},
+				Signature: []byte{1, 2, 3},
+			},
+			RelayKeys: []v2.RelayKey{4, 5, 6},
+		},
+	}
+	tree, err := v2.BuildMerkleTree(certs)
+	assert.NoError(t, err)
+	var batchRoot [32]byte
+	copy(batchRoot[:], tree.Root())
+	batch := &v2.Batch{
+		BatchHeader: &v2.BatchHeader{
+			BatchRoot:            batchRoot,
+			ReferenceBlockNumber: 1,
+		},
+		BlobCertificates: certs,
+	}
+
+	batchProto, err := batch.ToProtobuf()
+	assert.NoError(t, err)
+	newBatch, err := v2.BatchFromProtobuf(batchProto)
+	assert.NoError(t, err)
+	assert.Equal(t, batch.BatchHeader, newBatch.BatchHeader)
+	assert.Len(t, newBatch.BlobCertificates, 1)
+	assert.Equal(t, certs[0].RelayKeys, newBatch.BlobCertificates[0].RelayKeys)
+
+	expectedHash, err := certs[0].Hash()
+	assert.NoError(t, err)
+	hash, err := newBatch.BlobCertificates[0].Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHash, hash)
+
+	val := v2.NewShardValidator(v, dat, mock.MakeOperatorId(0))
+	assert.NoError(t, val.ValidateBatchHeader(context.Background(), newBatch.BatchHeader, newBatch.BlobCertificates))
+	newBatch.BatchHeader.BatchRoot = [32]byte{1}
+	assert.Error(t, val.ValidateBatchHeader(context.Background(), newBatch.BatchHeader, newBatch.BlobCertificates))
+
+	_, err = v2.BatchHeaderFromProtobuf(&commonpb.BatchHeader{BatchRoot: []byte{1, 2, 3}, ReferenceBlockNumber: 1})
+	assert.Error(t, err)
+}
@@ -201,16 +201,104 @@ func (c *BlobCertificate) ToProtobuf() (*commonpb.BlobCertificate, error) {
 	}, nil
 }
 
+func BlobCertificateFromProtobuf(proto *commonpb.BlobCertificate) (*BlobCertificate, error) {
+	if proto.GetBlobHeader() == nil {
+		return nil, errors.New("missing blob header in blob certificate")
+	}
+
+	blobHeader, err := NewBlobHeader(proto.GetBlobHeader())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create blob header: %v", err)
+	}
+
+	relayKeys := make([]RelayKey, len(proto.GetRelays()))
+	for i, r := range proto.GetRelays() {
+		relayKeys[i] = RelayKey(r)
+	}
+
+	return &BlobCertificate{
+		BlobHeader: blobHeader,
+		RelayKeys:  relayKeys,
+	}, nil
+}
+
 type BatchHeader struct {
 	BatchRoot            [32]byte
 	ReferenceBlockNumber uint64
 }
 
+func (h *BatchHeader) ToProtobuf() *commonpb.BatchHeader {
+	return &commonpb.BatchHeader{
+		BatchRoot:            h.BatchRoot[:],
+		ReferenceBlockNumber: h.ReferenceBlockNumber,
+	}
+}
+
+func BatchHeaderFromProtobuf(proto *commonpb.BatchHeader) (*BatchHeader, error) {
+	if proto == nil {
+		return nil, errors.New("missing batch header")
+	}
+	if len(proto.GetBatchRoot()) != 32 {
+		return nil, fmt.Errorf("invalid batch root length: expected 32 bytes, got %d", len(proto.GetBatchRoot()))
+	}
+
+	var batchRoot [32]byte
+	copy(batchRoot[:], proto.GetBatchRoot())
+	return &BatchHeader{
+		BatchRoot:            batchRoot,
+		ReferenceBlockNumber: proto.GetReferenceBlockNumber(),
+	}, nil
+}
+
 type Batch struct {
 	BatchHeader      *BatchHeader
 	BlobCertificates []*BlobCertificate
 }
 
+func (b *Batch) ToProtobuf() (*commonpb.Batch, error) {
+	if b.BatchHeader == nil {
+		return nil, errors.New("batch header is nil")
+	}
+
+	blobCerts := make([]*commonpb.BlobCertificate, len(b.BlobCertificates))
+	for i, cert := range b.BlobCertificates {
+		var err error
+		blobCerts[i], err = cert.ToProtobuf()
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert blob certificate to protobuf: %v", err)
+		}
+	}
+
+	return &commonpb.Batch{
+		Header:           b.BatchHeader.ToProtobuf(),
+		BlobCertificates: blobCerts,
+	}, nil
+}
+
+func BatchFromProtobuf(proto *commonpb.Batch) (*Batch, error) {
+	if proto == nil {
+		return nil, errors.New("missing batch")
+	}
+
+	batchHeader, err := BatchHeaderFromProtobuf(proto.GetHeader())
+	if err != nil {
+		return nil, err
+	}
+
+	blobCerts := make([]*BlobCertificate, len(proto.GetBlobCertificates()))
+	for i, cert := range proto.GetBlobCertificates() {
+		blobCerts[i], err = BlobCertificateFromProtobuf(cert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert blob certificate %d: %v", i, err)
+		}
+	}
+
+	return &Batch{
+		BatchHeader:      batchHeader,
+		BlobCertificates: blobCerts,
+	}, nil
+}
+
 type Attestation struct {
 	*BatchHeader
 
@@ -226,6 +314,8 @@ type Attestation struct {
 	Sigma *core.Signature
 	// QuorumNumbers contains the quorums relevant for the attestation
 	QuorumNumbers []core.QuorumID
+	// QuorumResults contains the percentage of the stake of each quorum that signed the batch
+	QuorumResults map[core.QuorumID]uint8
 }
 
 type BlobVerificationInfo struct {
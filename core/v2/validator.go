@@ -1,6 +1,7 @@
 package v2
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -20,22 +21,29 @@ type BlobShard struct {
 	Chunks map[core.QuorumID][]*encoding.Frame
 }
 
+type ShardValidator interface {
+	ValidateBatchHeader(ctx context.Context, header *BatchHeader, blobCerts []*BlobCertificate) error
+	ValidateBlobs(ctx context.Context, blobs []*BlobShard, pool common.WorkerPool, referenceBlockNumber uint64) error
+}
+
 // shardValidator implements the validation logic that a DA node should apply to its received data
-type ShardValidator struct {
+type shardValidator struct {
 	verifier   encoding.Verifier
 	chainState core.ChainState
 	operatorID core.OperatorID
 }
 
-func NewShardValidator(v encoding.Verifier, cst core.ChainState, operatorID core.OperatorID) *ShardValidator {
-	return &ShardValidator{
+var _ ShardValidator = (*shardValidator)(nil)
+
+func NewShardValidator(v encoding.Verifier, cst core.ChainState, operatorID core.OperatorID) ShardValidator {
+	return &shardValidator{
 		verifier:   v,
 		chainState: cst,
 		operatorID: operatorID,
 	}
 }
 
-func (v *ShardValidator) validateBlobQuorum(quorum core.QuorumID, blob *BlobShard, operatorState *core.OperatorState) ([]*encoding.Frame, *Assignment, error) {
+func (v *shardValidator) validateBlobQuorum(quorum core.QuorumID, blob *BlobShard, operatorState *core.OperatorState) ([]*encoding.Frame, *Assignment, error) {
 
 	// Check if the operator is a member of the quorum
 	if _, ok := operatorState.Operators[quorum]; !ok {
@@ -73,14 +81,37 @@ func (v *ShardValidator) validateBlobQuorum(quorum core.QuorumID, blob *BlobShar
 	return chunks, &assignment, nil
 }
 
-func (v *ShardValidator) ValidateBlobs(ctx context.Context, blobs []*BlobShard, pool common.WorkerPool, referenceBlockNumber uint64) error {
+// ValidateBatchHeader checks that the batch root in the header commits to the given blob certificates.
+func (v *shardValidator) ValidateBatchHeader(ctx context.Context, header *BatchHeader, blobCerts []*BlobCertificate) error {
+	if header == nil {
+		return errors.New("batch header is nil")
+	}
+	if len(blobCerts) == 0 {
+		return errors.New("no blob certificates in the batch")
+	}
+
+	tree, err := BuildMerkleTree(blobCerts)
+	if err != nil {
+		return fmt.Errorf("failed to build merkle tree: %w", err)
+	}
+
+	if !bytes.Equal(tree.Root(), header.BatchRoot[:]) {
+		return errors.New("invalid batch header: batch root does not match the blob certificates")
+	}
+
+	return nil
+}
+
+func (v *shardValidator) ValidateBlobs(ctx context.Context, blobs []*BlobShard, pool common.WorkerPool, referenceBlockNumber uint64) error {
 	var err error
 	subBatchMap := make(map[encoding.EncodingParams]*encoding.SubBatch)
 	blobCommitmentList := make([]encoding.BlobCommitments, len(blobs))
 
 	for k, blob := range blobs {
-		if len(blob.Chunks) != len(blob.BlobHeader.QuorumNumbers) {
-			return fmt.Errorf("number of bundles (%d) does not match number of quorums (%d)", len(blob.Chunks), len(blob.BlobHeader.QuorumNumbers))
+		// The operator only receives bundles for the quorums it has chunks assigned in, so there can be fewer
+		// bundles than quorums. Missing bundles for assigned quorums are caught by validateBlobQuorum.
+		if len(blob.Chunks) > len(blob.BlobHeader.QuorumNumbers) {
+			return fmt.Errorf("number of bundles (%d) exceeds number of quorums (%d)", len(blob.Chunks), len(blob.BlobHeader.QuorumNumbers))
 		}
 
 		state, err := v.chainState.GetOperatorState(ctx, uint(referenceBlockNumber), blob.BlobHeader.QuorumNumbers)
@@ -94,20 +125,17 @@ func (v *ShardValidator) ValidateBlobs(ctx context.Context, blobs []*BlobShard,
 		// for each quorum
 		for _, quorum := range blob.BlobHeader.QuorumNumbers {
 			chunks, assignment, err := v.validateBlobQuorum(quorum, blob, state)
-			if err != nil {
-				return err
-			}
-			// TODO: Define params for the blob
-			params, err := blob.BlobHeader.GetEncodingParams()
-			if err != nil {
-				return err
-			}
-
 			if errors.Is(err, ErrBlobQuorumSkip) {
 				continue
 			} else if err != nil {
 				return err
 			} else {
+				// TODO: Define params for the blob
+				params, err := blob.BlobHeader.GetEncodingParams()
+				if err != nil {
+					return err
+				}
+
 				// Check the received chunks against the commitment
 				blobIndex := 0
 				subBatch, ok := subBatchMap[params]
@@ -177,7 +205,7 @@ func (v *ShardValidator) ValidateBlobs(ctx context.Context, blobs []*BlobShard,
 	return nil
 }
 
-func (v *ShardValidator) universalVerifyWorker(params encoding.EncodingParams, subBatch *encoding.SubBatch, out chan error) {
+func (v *shardValidator) universalVerifyWorker(params encoding.EncodingParams, subBatch *encoding.SubBatch, out chan error) {
 
 	err := v.verifier.UniversalVerifySubBatch(params, subBatch.Samples, subBatch.NumBlobs)
 	if err != nil {
@@ -188,7 +216,7 @@ func (v *ShardValidator) universalVerifyWorker(params encoding.EncodingParams, s
 	out <- nil
 }
 
-func (v *ShardValidator) VerifyBlobLengthWorker(blobCommitments encoding.BlobCommitments, out chan error) {
+func (v *shardValidator) VerifyBlobLengthWorker(blobCommitments encoding.BlobCommitments, out chan error) {
 	err := v.verifier.VerifyBlobLength(blobCommitments)
 	if err != nil {
 		out <- err
@@ -0,0 +1,517 @@
+package apiserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/admin"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/common/allowlist"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// minAdminTokenLength is the minimum length of the admin token, to rule out trivially guessable tokens.
+const minAdminTokenLength = 16
+
+// defaultPauseReason is returned to clients whose requests are rejected when no reason is given for a pause.
+const defaultPauseReason = "paused by the operator"
+
+// DefaultAdminBindAddress is the address the admin service listens on unless configured otherwise. It only accepts
+// connections from the host the server runs on.
+const DefaultAdminBindAddress = "127.0.0.1"
+
+// adminReplicaHeader is the metadata key through which admin requests name the replica they are meant for.
+const adminReplicaHeader = "x-admin-replica"
+
+// AdminConfig configures the admin service of a dispersal server.
+type AdminConfig struct {
+	// GrpcPort is the port the admin service listens on. The admin service is disabled if it is empty.
+	GrpcPort string
+	// BindAddress is the address the admin service listens on. Defaults to DefaultAdminBindAddress. Listening on an
+	// address other than a loopback address requires TLS.
+	BindAddress string
+	// TokenFile is the path to a file containing the bearer token that every admin request must present.
+	TokenFile string
+	// TLSCertFile and TLSKeyFile are the paths to the certificate and private key the admin service serves TLS with.
+	// TLS is disabled if they are empty.
+	TLSCertFile string
+	TLSKeyFile  string
+	// ReplicaID identifies this server among the replicas of the disperser API server. Every admin request must name
+	// it, since the state changed through the admin service is local to the replica. Defaults to the hostname.
+	ReplicaID string
+}
+
+// adminTarget is a dispersal server that can be operated through an AdminServer.
+type adminTarget interface {
+	// getControls returns the runtime state of the server.
+	getControls() *dispersalControls
+	// getRateLimiter returns the rate limiter of the server, or nil if rate limiting is disabled.
+	getRateLimiter() common.RateLimiter
+	// appliesAccountRates returns true if the server rate limits dispersals using the allowlist.
+	appliesAccountRates() bool
+	// rateLimitKeys returns the keys of the rate limit buckets of an account for a quorum. If account is empty, only
+	// the keys of the system wide buckets are returned.
+	rateLimitKeys(account string, quorumID core.QuorumID) []string
+	// refreshQuorums reloads the quorum configuration from the chain, returning the number of quorums and the
+	// required quorums.
+	refreshQuorums(ctx context.Context) (uint8, []core.QuorumID, error)
+}
+
+// AdminServer serves the admin API of a dispersal server, which lets operators inspect and change its state at
+// runtime. Changes are held in the memory of the replica that serves the request, and are lost when it restarts: they
+// are not shared with the other replicas of the API server. To make the replica an operator acts on explicit, every
+// request must name the replica ID of the server in an "x-admin-replica" metadata entry, and requests meant for
+// another replica are rejected. Operators must repeat a change on every replica it should apply to.
+type AdminServer struct {
+	pb.UnimplementedAdminServer
+
+	config AdminConfig
+	token  []byte
+	target adminTarget
+	logger logging.Logger
+}
+
+// NewAdminServer creates the admin server of the v1 dispersal server.
+func (s *DispersalServer) NewAdminServer(config AdminConfig) (*AdminServer, error) {
+	return newAdminServer(config, s, s.logger)
+}
+
+// NewAdminServer creates the admin server of the v2 dispersal server.
+func (s *DispersalServerV2) NewAdminServer(config AdminConfig) (*AdminServer, error) {
+	return newAdminServer(config, s, s.logger)
+}
+
+func newAdminServer(config AdminConfig, target adminTarget, logger logging.Logger) (*AdminServer, error) {
+	if config.TokenFile == "" {
+		return nil, errors.New("admin token file is required")
+	}
+	content, err := os.ReadFile(config.TokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read admin token file: %w", err)
+	}
+	token := strings.TrimSpace(string(content))
+	if len(token) < minAdminTokenLength {
+		return nil, fmt.Errorf("admin token must be at least %d characters long", minAdminTokenLength)
+	}
+
+	if config.BindAddress == "" {
+		config.BindAddress = DefaultAdminBindAddress
+	}
+	if (config.TLSCertFile == "") != (config.TLSKeyFile == "") {
+		return nil, errors.New("both the admin TLS certificate and key files must be set to enable TLS")
+	}
+	if config.TLSCertFile == "" && !isLoopbackAddress(config.BindAddress) {
+		return nil, fmt.Errorf("admin service must use TLS to listen on non-loopback address %s", config.BindAddress)
+	}
+
+	if config.ReplicaID == "" {
+		config.ReplicaID, err = os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get hostname for the admin replica ID: %w", err)
+		}
+	}
+
+	return &AdminServer{
+		config: config,
+		token:  []byte(token),
+		target: target,
+		logger: logger.With("component", "AdminServer"),
+	}, nil
+}
+
+// Start serves admin requests until the context is cancelled.
+func (s *AdminServer) Start(ctx context.Context) error {
+	opts := []grpc.ServerOption{grpc.UnaryInterceptor(s.authenticate)}
+	if s.config.TLSCertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(s.config.TLSCertFile, s.config.TLSKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load admin TLS credentials: %w", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
+	addr := net.JoinHostPort(s.config.BindAddress, s.config.GrpcPort)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return errors.New("could not start tcp listener")
+	}
+
+	gs := grpc.NewServer(opts...)
+	pb.RegisterAdminServer(gs, s)
+
+	go func() {
+		<-ctx.Done()
+		gs.GracefulStop()
+	}()
+
+	s.logger.Info("Admin GRPC Listening", "port", s.config.GrpcPort, "address", listener.Addr().String(),
+		"tls", s.config.TLSCertFile != "", "replicaID", s.config.ReplicaID)
+
+	if err := gs.Serve(listener); err != nil {
+		return errors.New("could not start admin GRPC server")
+	}
+	return nil
+}
+
+// authenticate rejects requests that don't carry the admin token in an "authorization: Bearer <token>" header, and
+// requests that don't name this replica in an "x-admin-replica" header.
+func (s *AdminServer) authenticate(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	var token, replicaID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) == 1 && strings.HasPrefix(values[0], "Bearer ") {
+			token = strings.TrimPrefix(values[0], "Bearer ")
+		}
+		if values := md.Get(adminReplicaHeader); len(values) == 1 {
+			replicaID = values[0]
+		}
+	}
+	if subtle.ConstantTimeCompare([]byte(token), s.token) != 1 {
+		s.logger.Warn("rejected unauthenticated admin request", "method", info.FullMethod, "peer", peerAddress(ctx))
+		return nil, api.NewErrorUnauthenticated("a valid admin token is required")
+	}
+	if replicaID != s.config.ReplicaID {
+		s.logger.Warn("rejected admin request for another replica", "method", info.FullMethod, "peer", peerAddress(ctx),
+			"replicaID", replicaID)
+		return nil, api.NewErrorFailedPrecondition(fmt.Sprintf("request is for replica %q, but this is replica %q",
+			replicaID, s.config.ReplicaID))
+	}
+	return handler(ctx, req)
+}
+
+func (s *AdminServer) GetAccountRates(ctx context.Context, req *pb.GetAccountRatesRequest) (*pb.GetAccountRatesReply, error) {
+	account := strings.ToLower(req.GetAccount())
+	controls := s.target.getControls()
+	al := controls.effectiveAllowlist()
+
+	rates := make([]*pb.AccountRate, 0)
+	for acct, rateInfoByQuorum := range al {
+		if account != "" && acct != account {
+			continue
+		}
+		for quorumID := range rateInfoByQuorum {
+			rates = append(rates, s.accountRate(controls, al, acct, quorumID))
+		}
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Account != rates[j].Account {
+			return rates[i].Account < rates[j].Account
+		}
+		return rates[i].QuorumId < rates[j].QuorumId
+	})
+
+	return &pb.GetAccountRatesReply{Rates: rates}, nil
+}
+
+func (s *AdminServer) SetAccountRateOverride(ctx context.Context, req *pb.SetAccountRateOverrideRequest) (*pb.SetAccountRateOverrideReply, error) {
+	if !s.target.appliesAccountRates() {
+		return nil, api.NewErrorFailedPrecondition("this server does not rate limit dispersals by account")
+	}
+	quorumID, err := toQuorumID(req.GetQuorumId())
+	if err != nil {
+		return nil, err
+	}
+	account, rate, err := allowlist.ValidateEntry(allowlist.Entry{
+		Name:     req.GetName(),
+		Account:  req.GetAccount(),
+		QuorumID: quorumID,
+		BlobRate: req.GetBlobRate(),
+		ByteRate: float64(req.GetByteRate()),
+	})
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(err.Error())
+	}
+	if req.GetDurationSeconds() > uint64(math.MaxInt64/int64(time.Second)) {
+		return nil, api.NewErrorInvalidArg("duration_seconds is too large")
+	}
+	duration := time.Duration(req.GetDurationSeconds()) * time.Second
+
+	controls := s.target.getControls()
+	controls.setOverride(account, quorumID, rate, duration)
+	s.logger.Info("account rate overridden", "peer", peerAddress(ctx), "account", account, "quorumID", quorumID,
+		"name", rate.Name, "throughput", rate.Throughput, "blobRate", rate.BlobRate, "duration", duration.String())
+
+	return &pb.SetAccountRateOverrideReply{
+		Rate: s.accountRate(controls, controls.effectiveAllowlist(), account, quorumID),
+	}, nil
+}
+
+func (s *AdminServer) ClearAccountRateOverride(ctx context.Context, req *pb.ClearAccountRateOverrideRequest) (*pb.ClearAccountRateOverrideReply, error) {
+	quorumID, err := toQuorumID(req.GetQuorumId())
+	if err != nil {
+		return nil, err
+	}
+	account := strings.ToLower(req.GetAccount())
+
+	controls := s.target.getControls()
+	if !controls.clearOverride(account, quorumID) {
+		return nil, api.NewErrorNotFound(fmt.Sprintf("no override for account %s and quorum %d", account, quorumID))
+	}
+	s.logger.Info("account rate override cleared", "peer", peerAddress(ctx), "account", account, "quorumID", quorumID)
+
+	reply := &pb.ClearAccountRateOverrideReply{}
+	al := controls.effectiveAllowlist()
+	if _, ok := al[account][quorumID]; ok {
+		reply.Rate = s.accountRate(controls, al, account, quorumID)
+	}
+	return reply, nil
+}
+
+func (s *AdminServer) GetRateLimitBuckets(ctx context.Context, req *pb.GetRateLimitBucketsRequest) (*pb.GetRateLimitBucketsReply, error) {
+	quorumID, err := toQuorumID(req.GetQuorumId())
+	if err != nil {
+		return nil, err
+	}
+	ratelimiter := s.target.getRateLimiter()
+	if ratelimiter == nil {
+		return nil, api.NewErrorFailedPrecondition("rate limiting is disabled")
+	}
+	inspector, ok := ratelimiter.(common.BucketInspector)
+	if !ok {
+		return nil, api.NewErrorFailedPrecondition("the rate limiter does not support inspecting buckets")
+	}
+
+	keys := s.target.rateLimitKeys(strings.ToLower(req.GetAccount()), quorumID)
+	buckets := make([]*pb.RateLimitBucket, 0, len(keys))
+	for _, key := range keys {
+		levels, err := inspector.GetBucketLevels(ctx, key)
+		if err != nil {
+			return nil, api.NewErrorInternal(fmt.Sprintf("failed to get rate limit bucket %s: %v", key, err))
+		}
+		bucket := &pb.RateLimitBucket{
+			RequesterId: key,
+			Found:       levels.Found,
+			Levels:      make([]int64, len(levels.Levels)),
+			Sizes:       make([]int64, len(levels.Sizes)),
+		}
+		if levels.Found {
+			bucket.LastRequestTime = uint64(levels.LastRequestTime.UnixNano())
+		}
+		for i, level := range levels.Levels {
+			bucket.Levels[i] = int64(level)
+		}
+		for i, size := range levels.Sizes {
+			bucket.Sizes[i] = int64(size)
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	return &pb.GetRateLimitBucketsReply{Buckets: buckets}, nil
+}
+
+func (s *AdminServer) PauseDispersal(ctx context.Context, req *pb.PauseDispersalRequest) (*pb.PauseDispersalReply, error) {
+	quorumIDs, err := toQuorumIDs(req.GetQuorumIds())
+	if err != nil {
+		return nil, err
+	}
+	reason := strings.TrimSpace(req.GetReason())
+	if reason == "" {
+		reason = defaultPauseReason
+	}
+
+	controls := s.target.getControls()
+	controls.pause(quorumIDs, reason)
+	s.logger.Warn("dispersal paused", "peer", peerAddress(ctx), "quorums", quorumIDs, "reason", reason)
+
+	return &pb.PauseDispersalReply{PausedQuorums: toPausedQuorumsProto(controls.pausedQuorums())}, nil
+}
+
+func (s *AdminServer) ResumeDispersal(ctx context.Context, req *pb.ResumeDispersalRequest) (*pb.ResumeDispersalReply, error) {
+	quorumIDs, err := toQuorumIDs(req.GetQuorumIds())
+	if err != nil {
+		return nil, err
+	}
+
+	controls := s.target.getControls()
+	controls.resume(quorumIDs)
+	s.logger.Warn("dispersal resumed", "peer", peerAddress(ctx), "quorums", quorumIDs)
+
+	return &pb.ResumeDispersalReply{PausedQuorums: toPausedQuorumsProto(controls.pausedQuorums())}, nil
+}
+
+func (s *AdminServer) GetDispersalStatus(ctx context.Context, req *pb.GetDispersalStatusRequest) (*pb.GetDispersalStatusReply, error) {
+	return &pb.GetDispersalStatusReply{
+		PausedQuorums: toPausedQuorumsProto(s.target.getControls().pausedQuorums()),
+	}, nil
+}
+
+func (s *AdminServer) RefreshOnchainState(ctx context.Context, req *pb.RefreshOnchainStateRequest) (*pb.RefreshOnchainStateReply, error) {
+	quorumCount, requiredQuorums, err := s.target.refreshQuorums(ctx)
+	if err != nil {
+		s.logger.Error("failed to refresh onchain state", "peer", peerAddress(ctx), "err", err)
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to refresh onchain state: %v", err))
+	}
+	s.logger.Info("onchain state refreshed", "peer", peerAddress(ctx), "quorumCount", quorumCount, "requiredQuorums", requiredQuorums)
+
+	reply := &pb.RefreshOnchainStateReply{
+		QuorumCount:     uint32(quorumCount),
+		RequiredQuorums: make([]uint32, len(requiredQuorums)),
+	}
+	for i, quorumID := range requiredQuorums {
+		reply.RequiredQuorums[i] = uint32(quorumID)
+	}
+	return reply, nil
+}
+
+// accountRate converts the rate of an account for a quorum in the given effective allowlist to its protobuf form.
+func (s *AdminServer) accountRate(controls *dispersalControls, al Allowlist, account string, quorumID core.QuorumID) *pb.AccountRate {
+	rateInfo := al[account][quorumID]
+	rate := &pb.AccountRate{
+		Account:  account,
+		QuorumId: uint32(quorumID),
+		Name:     rateInfo.Name,
+		ByteRate: rateInfo.Throughput,
+		BlobRate: float64(rateInfo.BlobRate) / allowlist.BlobRateMultiplier,
+	}
+	if override, ok := controls.getOverride(account, quorumID); ok {
+		rate.Override = true
+		if !override.expiresAt.IsZero() {
+			rate.OverrideExpiresAt = uint64(override.expiresAt.Unix())
+		}
+	}
+	return rate
+}
+
+func toQuorumID(quorumID uint32) (core.QuorumID, error) {
+	if quorumID > math.MaxUint8 {
+		return 0, api.NewErrorInvalidArg(fmt.Sprintf("quorum_id must be at most %d, got %d", math.MaxUint8, quorumID))
+	}
+	return core.QuorumID(quorumID), nil
+}
+
+func toQuorumIDs(quorumIDs []uint32) ([]core.QuorumID, error) {
+	if len(quorumIDs) == 0 {
+		return nil, api.NewErrorInvalidArg("quorum_ids must not be empty")
+	}
+	result := make([]core.QuorumID, len(quorumIDs))
+	for i, quorumID := range quorumIDs {
+		id, err := toQuorumID(quorumID)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = id
+	}
+	return result, nil
+}
+
+func toPausedQuorumsProto(paused []pausedQuorum) []*pb.PausedQuorum {
+	result := make([]*pb.PausedQuorum, len(paused))
+	for i, p := range paused {
+		result[i] = &pb.PausedQuorum{
+			QuorumId: uint32(p.quorumID),
+			Reason:   p.reason,
+			PausedAt: uint64(p.pausedAt.Unix()),
+		}
+	}
+	return result
+}
+
+// isLoopbackAddress returns true if the host only accepts connections from the local machine.
+func isLoopbackAddress(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// peerAddress returns the address of the client that sent a request, for audit logs.
+func peerAddress(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// Implementation of adminTarget for the v1 dispersal server.
+
+func (s *DispersalServer) getControls() *dispersalControls {
+	return s.controls
+}
+
+func (s *DispersalServer) getRateLimiter() common.RateLimiter {
+	return s.ratelimiter
+}
+
+func (s *DispersalServer) appliesAccountRates() bool {
+	return true
+}
+
+func (s *DispersalServer) rateLimitKeys(account string, quorumID core.QuorumID) []string {
+	keys := []string{
+		fmt.Sprintf("%s:%d-%s", systemAccountKey, quorumID, SystemThroughputType.Plug()),
+		fmt.Sprintf("%s:%d-%s", systemAccountKey, quorumID, SystemBlobRateType.Plug()),
+	}
+	if account == "" {
+		return keys
+	}
+
+	// see getAccountRate for how account keys are derived
+	accountKey := "ip:" + account
+	if strings.HasPrefix(account, "0x") {
+		accountKey = "address:" + account
+	}
+	return append(keys,
+		fmt.Sprintf("%s:%d-%s", accountKey, quorumID, AccountThroughputType.Plug()),
+		fmt.Sprintf("%s:%d-%s", accountKey, quorumID, AccountBlobRateType.Plug()),
+	)
+}
+
+func (s *DispersalServer) refreshQuorums(ctx context.Context) (uint8, []core.QuorumID, error) {
+	quorumConfig, err := s.loadQuorumConfig(ctx, false)
+	if err != nil {
+		return 0, nil, err
+	}
+	return quorumConfig.QuorumCount, quorumConfig.RequiredQuorums, nil
+}
+
+// Implementation of adminTarget for the v2 dispersal server.
+
+func (s *DispersalServerV2) getControls() *dispersalControls {
+	return s.controls
+}
+
+func (s *DispersalServerV2) getRateLimiter() common.RateLimiter {
+	return s.ratelimiter
+}
+
+func (s *DispersalServerV2) appliesAccountRates() bool {
+	return false
+}
+
+func (s *DispersalServerV2) rateLimitKeys(account string, quorumID core.QuorumID) []string {
+	// the v2 server only rate limits GetBlobCommitment requests, by client IP and across all quorums
+	if account == "" || strings.HasPrefix(account, "0x") {
+		return []string{}
+	}
+	return []string{
+		fmt.Sprintf("%s:%s", account, CommitmentBlobRateType.Plug()),
+		fmt.Sprintf("%s:%s", account, CommitmentThroughputType.Plug()),
+	}
+}
+
+func (s *DispersalServerV2) refreshQuorums(ctx context.Context) (uint8, []core.QuorumID, error) {
+	if err := s.RefreshOnchainState(ctx); err != nil {
+		return 0, nil, err
+	}
+	onchainState := s.onchainState.Load()
+	return onchainState.QuorumCount, onchainState.RequiredQuorums, nil
+}
@@ -1,18 +1,15 @@
 package apiserver
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
-	"log"
-	"os"
 	"strconv"
-	"strings"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/common/allowlist"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/urfave/cli"
 )
 
@@ -29,6 +26,9 @@ const (
 	RetrievalBlobRateFlagName   = "auth.retrieval-blob-rate"
 	RetrievalThroughputFlagName = "auth.retrieval-throughput"
 
+	CommitmentBlobRateFlagName   = "auth.commitment-blob-rate"
+	CommitmentThroughputFlagName = "auth.commitment-throughput"
+
 	// We allow the user to specify the blob rate in blobs/sec, but internally we use blobs/sec * 1e6 (i.e. blobs/microsec).
 	// This is because the rate limiter takes an integer rate.
 	blobRateMultiplier = 1e6
@@ -41,21 +41,11 @@ type QuorumRateInfo struct {
 	TotalUnauthBlobRate     common.RateParam
 }
 
-type PerUserRateInfo struct {
-	Name       string
-	Throughput common.RateParam
-	BlobRate   common.RateParam
-}
+type PerUserRateInfo = allowlist.RateInfo
 
-type Allowlist = map[string]map[core.QuorumID]PerUserRateInfo
+type Allowlist = allowlist.Allowlist
 
-type AllowlistEntry struct {
-	Name     string  `json:"name"`
-	Account  string  `json:"account"`
-	QuorumID uint8   `json:"quorumID"`
-	BlobRate float64 `json:"blobRate"`
-	ByteRate float64 `json:"byteRate"`
-}
+type AllowlistEntry = allowlist.Entry
 
 type RateConfig struct {
 	QuorumRateInfos map[core.QuorumID]QuorumRateInfo
@@ -65,8 +55,17 @@ type RateConfig struct {
 	RetrievalBlobRate   common.RateParam
 	RetrievalThroughput common.RateParam
 
+	CommitmentBlobRate   common.RateParam
+	CommitmentThroughput common.RateParam
+
 	AllowlistFile            string
 	AllowlistRefreshInterval time.Duration
+	// AllowlistWarnings are the problems with the allowlist file that are tolerated at startup, since files written
+	// before the allowlist was validated may have them. Reloading the file fails until they are fixed.
+	AllowlistWarnings []*allowlist.LineError
+
+	// MetricsRegistry is used to report the state of the allowlist. If nil, no metrics are reported.
+	MetricsRegistry *prometheus.Registry
 }
 
 func AllowlistFileFlag(envPrefix string) cli.Flag {
@@ -120,7 +119,7 @@ func CLIFlags(envPrefix string) []cli.Flag {
 		AllowlistFileFlag(envPrefix),
 		cli.DurationFlag{
 			Name:     AllowlistRefreshIntervalFlagName,
-			Usage:    "The interval at which to refresh the allowlist from the file",
+			Usage:    "The interval at which to refresh the allowlist from the file. The file is also watched for changes, so this is a fallback in case a change is missed",
 			Required: false,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "ALLOWLIST_REFRESH_INTERVAL"),
 			Value:    5 * time.Minute,
@@ -137,55 +136,27 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			EnvVar:   common.PrefixEnvVar(envPrefix, "RETRIEVAL_BYTE_RATE"),
 			Required: true,
 		},
+		cli.IntFlag{
+			Name:     CommitmentBlobRateFlagName,
+			Usage:    "The blob rate limit for blob commitment requests (Blobs/sec)",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "COMMITMENT_BLOB_RATE"),
+			Value:    4,
+		},
+		cli.IntFlag{
+			Name:     CommitmentThroughputFlagName,
+			Usage:    "The throughput rate limit for blob commitment requests (Bytes/sec)",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "COMMITMENT_BYTE_RATE"),
+			Value:    10 * 1024 * 1024,
+		},
 	}
 }
 
+// ReadAllowlistFromFile reads and validates the allowlist file. The returned error describes every problem found in
+// the file, along with the line on which it was found.
 func ReadAllowlistFromFile(f string) (Allowlist, error) {
-	allowlist := make(Allowlist)
-	if f == "" {
-		return allowlist, nil
-	}
-
-	allowlistFile, err := os.Open(f)
-	if err != nil {
-		log.Printf("failed to read allowlist file: %s", err)
-		return allowlist, err
-	}
-	defer allowlistFile.Close()
-	var allowlistEntries []AllowlistEntry
-	content, err := io.ReadAll(allowlistFile)
-	if err != nil {
-		log.Printf("failed to load allowlist file content: %s", err)
-		return allowlist, err
-	}
-	err = json.Unmarshal(content, &allowlistEntries)
-	if err != nil {
-		log.Printf("failed to parse allowlist file content: %s", err)
-		return allowlist, err
-	}
-
-	for _, entry := range allowlistEntries {
-		// normalize to lowercase (non-checksummed) address or IP address
-		account := strings.ToLower(entry.Account)
-		rateInfoByQuorum, ok := allowlist[account]
-		if !ok {
-			allowlist[account] = map[core.QuorumID]PerUserRateInfo{
-				core.QuorumID(entry.QuorumID): {
-					Name:       entry.Name,
-					Throughput: common.RateParam(entry.ByteRate),
-					BlobRate:   common.RateParam(entry.BlobRate * blobRateMultiplier),
-				},
-			}
-		} else {
-			rateInfoByQuorum[core.QuorumID(entry.QuorumID)] = PerUserRateInfo{
-				Name:       entry.Name,
-				Throughput: common.RateParam(entry.ByteRate),
-				BlobRate:   common.RateParam(entry.BlobRate * blobRateMultiplier),
-			}
-		}
-	}
-
-	return allowlist, nil
+	return allowlist.ReadFile(f)
 }
 
 func ReadCLIConfig(c *cli.Context) (RateConfig, error) {
@@ -224,23 +195,22 @@ func ReadCLIConfig(c *cli.Context) (RateConfig, error) {
 		}
 	}
 
-	allowlist := make(Allowlist)
 	allowlistFileName := c.String(AllowlistFileFlagName)
-	if allowlistFileName != "" {
-		var err error
-		allowlist, err = ReadAllowlistFromFile(allowlistFileName)
-		if err != nil {
-			return RateConfig{}, fmt.Errorf("failed to read allowlist file %s: %w", allowlistFileName, err)
-		}
+	al, warnings, err := allowlist.ReadFileLenient(allowlistFileName)
+	if err != nil {
+		return RateConfig{}, fmt.Errorf("failed to read allowlist file %s: %w", allowlistFileName, err)
 	}
 
 	return RateConfig{
 		QuorumRateInfos:          quorumRateInfos,
 		ClientIPHeader:           c.String(ClientIPHeaderFlagName),
-		Allowlist:                allowlist,
+		Allowlist:                al,
 		RetrievalBlobRate:        common.RateParam(c.Int(RetrievalBlobRateFlagName) * blobRateMultiplier),
 		RetrievalThroughput:      common.RateParam(c.Int(RetrievalThroughputFlagName)),
+		CommitmentBlobRate:       common.RateParam(c.Int(CommitmentBlobRateFlagName) * blobRateMultiplier),
+		CommitmentThroughput:     common.RateParam(c.Int(CommitmentThroughputFlagName)),
 		AllowlistFile:            c.String(AllowlistFileFlagName),
 		AllowlistRefreshInterval: c.Duration(AllowlistRefreshIntervalFlagName),
+		AllowlistWarnings:        warnings,
 	}, nil
 }
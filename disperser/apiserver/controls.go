@@ -0,0 +1,238 @@
+package apiserver
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// rateOverride is a rate set through the admin API, which takes precedence over the allowlist file.
+type rateOverride struct {
+	rate PerUserRateInfo
+	// expiresAt is the time at which the override stops applying, or the zero time if it does not expire.
+	expiresAt time.Time
+}
+
+// pausedQuorum records why dispersal to a quorum is paused.
+type pausedQuorum struct {
+	quorumID core.QuorumID
+	reason   string
+	pausedAt time.Time
+}
+
+// dispersalControls holds the state of a dispersal server that can change while it is running, either because the
+// allowlist file is reloaded or because an operator changed it through the admin API. It is safe for concurrent use.
+type dispersalControls struct {
+	mu sync.RWMutex
+
+	// allowlist is the allowlist as read from the allowlist file.
+	allowlist Allowlist
+	// overrides are the rates set through the admin API, keyed by account and quorum.
+	overrides map[string]map[core.QuorumID]rateOverride
+	// effective is the allowlist with the overrides applied. It is rebuilt whenever either of them changes, and when
+	// the next override expires.
+	effective Allowlist
+	// nextExpiry is the earliest time at which an override expires, or the zero time if none expire.
+	nextExpiry time.Time
+
+	paused map[core.QuorumID]pausedQuorum
+
+	now func() time.Time
+}
+
+func newDispersalControls(allowlist Allowlist) *dispersalControls {
+	if allowlist == nil {
+		allowlist = make(Allowlist)
+	}
+	c := &dispersalControls{
+		allowlist: allowlist,
+		overrides: make(map[string]map[core.QuorumID]rateOverride),
+		paused:    make(map[core.QuorumID]pausedQuorum),
+		now:       time.Now,
+	}
+	c.rebuild()
+	return c
+}
+
+// getAllowlist returns the allowlist as read from the allowlist file, without overrides.
+func (c *dispersalControls) getAllowlist() Allowlist {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.allowlist
+}
+
+// setAllowlist replaces the allowlist read from the allowlist file. Overrides remain in place.
+func (c *dispersalControls) setAllowlist(allowlist Allowlist) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if allowlist == nil {
+		allowlist = make(Allowlist)
+	}
+	c.allowlist = allowlist
+	c.rebuild()
+}
+
+// effectiveAllowlist returns the allowlist with the overrides that haven't expired applied. The returned allowlist
+// must not be modified.
+func (c *dispersalControls) effectiveAllowlist() Allowlist {
+	c.mu.RLock()
+	expired := !c.nextExpiry.IsZero() && !c.now().Before(c.nextExpiry)
+	effective := c.effective
+	c.mu.RUnlock()
+	if !expired {
+		return effective
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rebuild()
+	return c.effective
+}
+
+// getOverride returns the override of an account's rate for a quorum, if there is one that hasn't expired.
+func (c *dispersalControls) getOverride(account string, quorumID core.QuorumID) (rateOverride, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	override, ok := c.overrides[account][quorumID]
+	if !ok || c.isExpired(override) {
+		return rateOverride{}, false
+	}
+	return override, true
+}
+
+// setOverride overrides the rate of an account for a quorum. If duration is zero, the override doesn't expire.
+func (c *dispersalControls) setOverride(account string, quorumID core.QuorumID, rate PerUserRateInfo, duration time.Duration) rateOverride {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	override := rateOverride{rate: rate}
+	if duration > 0 {
+		override.expiresAt = c.now().Add(duration)
+	}
+	if _, ok := c.overrides[account]; !ok {
+		c.overrides[account] = make(map[core.QuorumID]rateOverride)
+	}
+	c.overrides[account][quorumID] = override
+	c.rebuild()
+	return override
+}
+
+// clearOverride removes the override of an account's rate for a quorum. It returns false if there was none.
+func (c *dispersalControls) clearOverride(account string, quorumID core.QuorumID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	override, ok := c.overrides[account][quorumID]
+	if !ok || c.isExpired(override) {
+		return false
+	}
+	delete(c.overrides[account], quorumID)
+	if len(c.overrides[account]) == 0 {
+		delete(c.overrides, account)
+	}
+	c.rebuild()
+	return true
+}
+
+// pause rejects dispersal to the given quorums. Quorums that are already paused keep the time they were first paused
+// at, but take the new reason.
+func (c *dispersalControls) pause(quorumIDs []core.QuorumID, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	for _, quorumID := range quorumIDs {
+		paused, ok := c.paused[quorumID]
+		if !ok {
+			paused = pausedQuorum{quorumID: quorumID, pausedAt: now}
+		}
+		paused.reason = reason
+		c.paused[quorumID] = paused
+	}
+}
+
+// resume accepts dispersal to the given quorums again.
+func (c *dispersalControls) resume(quorumIDs []core.QuorumID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, quorumID := range quorumIDs {
+		delete(c.paused, quorumID)
+	}
+}
+
+// pausedQuorums returns the quorums for which dispersal is paused, sorted by quorum.
+func (c *dispersalControls) pausedQuorums() []pausedQuorum {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	paused := make([]pausedQuorum, 0, len(c.paused))
+	for _, p := range c.paused {
+		paused = append(paused, p)
+	}
+	sort.Slice(paused, func(i, j int) bool {
+		return paused[i].quorumID < paused[j].quorumID
+	})
+	return paused
+}
+
+// checkPaused returns an error describing the first of the given quorums for which dispersal is paused, or nil if
+// dispersal to all of them is allowed.
+func (c *dispersalControls) checkPaused(quorumIDs []core.QuorumID) error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if len(c.paused) == 0 {
+		return nil
+	}
+	for _, quorumID := range quorumIDs {
+		if paused, ok := c.paused[quorumID]; ok {
+			return fmt.Errorf("dispersal to quorum %d is paused: %s", quorumID, paused.reason)
+		}
+	}
+	return nil
+}
+
+func (c *dispersalControls) isExpired(override rateOverride) bool {
+	return !override.expiresAt.IsZero() && !c.now().Before(override.expiresAt)
+}
+
+// rebuild drops expired overrides and recomputes the effective allowlist. The caller must hold the write lock.
+func (c *dispersalControls) rebuild() {
+	c.nextExpiry = time.Time{}
+	if len(c.overrides) == 0 {
+		c.effective = c.allowlist
+		return
+	}
+
+	effective := make(Allowlist, len(c.allowlist)+len(c.overrides))
+	for account, rateInfoByQuorum := range c.allowlist {
+		effective[account] = make(map[core.QuorumID]PerUserRateInfo, len(rateInfoByQuorum))
+		for quorumID, rateInfo := range rateInfoByQuorum {
+			effective[account][quorumID] = rateInfo
+		}
+	}
+
+	for account, overrideByQuorum := range c.overrides {
+		for quorumID, override := range overrideByQuorum {
+			if c.isExpired(override) {
+				delete(overrideByQuorum, quorumID)
+				continue
+			}
+			if !override.expiresAt.IsZero() && (c.nextExpiry.IsZero() || override.expiresAt.Before(c.nextExpiry)) {
+				c.nextExpiry = override.expiresAt
+			}
+			if _, ok := effective[account]; !ok {
+				effective[account] = make(map[core.QuorumID]PerUserRateInfo)
+			}
+			effective[account][quorumID] = override.rate
+		}
+		if len(overrideByQuorum) == 0 {
+			delete(c.overrides, account)
+		}
+	}
+	c.effective = effective
+}
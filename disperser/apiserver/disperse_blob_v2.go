@@ -2,6 +2,7 @@ package apiserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,11 +10,21 @@ import (
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	"github.com/Layr-Labs/eigenda/common"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
 	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 )
 
+// errBlobContentMismatch is returned when a request has the same header as a blob that has already been stored, but
+// different data.
+var errBlobContentMismatch = errors.New("blob has already been dispersed with different data")
+
+// maxStoreBlobAttempts is the number of times storing a blob is attempted when it races with concurrent updates of the
+// record of its content.
+const maxStoreBlobAttempts = 10
+
 func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBlobRequest) (*pb.DisperseBlobReply, error) {
 	if err := s.validateDispersalRequest(req); err != nil {
 		return nil, err
@@ -31,9 +42,16 @@ func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBl
 	}
 	s.logger.Debug("received a new blob dispersal request", "origin", origin, "blobSizeBytes", len(data), "quorums", req.GetBlobHeader().GetQuorumNumbers())
 
+	if err := s.controls.checkPaused(blobHeader.QuorumNumbers); err != nil {
+		return nil, api.NewErrorUnavailable(err.Error())
+	}
+
 	// TODO(ian-shim): handle payments and check rate limits
 
 	blobKey, err := s.StoreBlob(ctx, data, blobHeader, time.Now())
+	if errors.Is(err, errBlobContentMismatch) {
+		return nil, api.NewErrorInvalidArg(err.Error())
+	}
 	if err != nil {
 		return nil, api.NewErrorInternal(err.Error())
 	}
@@ -44,27 +62,130 @@ func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBl
 	}, nil
 }
 
+// StoreBlob stores the data and metadata of a blob, and returns its key. A request for a blob that has already been
+// stored, e.g. by a retrying client, returns the key of the stored blob without storing it again. A blob whose
+// content matches that of a blob already stored reuses its data and encoded chunks.
+//
+// The reference to the shared content is taken in the same transaction that stores the metadata, so a failure at any
+// point never leaves a reference behind. Data uploaded for new content whose record could not be created is deleted
+// again; only a crash between the upload and the transaction leaves unreferenced data in the blob store.
 func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHeader *corev2.BlobHeader, requestedAt time.Time) (corev2.BlobKey, error) {
 	blobKey, err := blobHeader.BlobKey()
 	if err != nil {
 		return corev2.BlobKey{}, err
 	}
+	contentKey, err := dispv2.ComputeContentKey(blobHeader, data)
+	if err != nil {
+		return corev2.BlobKey{}, err
+	}
 
-	if err := s.blobStore.StoreBlob(ctx, blobKey, data); err != nil {
+	existing, err := s.blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	if err == nil {
+		return blobKey, checkSameContent(blobKey, existing, contentKey)
+	}
+	if !errors.Is(err, dispcommon.ErrMetadataNotFound) {
 		return corev2.BlobKey{}, err
 	}
 
 	blobMetadata := &dispv2.BlobMetadata{
 		BlobHeader:  blobHeader,
 		BlobStatus:  dispv2.Queued,
-		Expiry:      uint64(requestedAt.Add(s.onchainState.TTL).Unix()),
+		Expiry:      uint64(requestedAt.Add(s.onchainState.Load().TTL).Unix()),
 		NumRetries:  0,
 		BlobSize:    uint64(len(data)),
 		RequestedAt: uint64(requestedAt.UnixNano()),
 		UpdatedAt:   uint64(requestedAt.UnixNano()),
+		ContentKey:  &contentKey,
+	}
+
+	for i := 0; i < maxStoreBlobAttempts; i++ {
+		content, uploaded, err := s.getOrUploadBlobContent(ctx, contentKey, data)
+		if err != nil {
+			return corev2.BlobKey{}, err
+		}
+		if content == nil {
+			continue
+		}
+
+		blobMetadata.StorageKey = &content.StorageKey
+		err = s.blobMetadataStore.PutBlobMetadataWithContent(ctx, blobMetadata, content)
+		if err == nil {
+			if !uploaded {
+				s.logger.Debug("reusing stored blob content", "blobKey", blobKey.Hex(), "storageKey", content.StorageKey.Hex())
+			}
+			return blobKey, nil
+		}
+		if uploaded {
+			if err := s.blobStore.DeleteBlob(ctx, content.StorageKey); err != nil {
+				s.logger.Error("failed to delete unreferenced blob", "storageKey", content.StorageKey.Hex(), "err", err)
+			}
+		}
+		if errors.Is(err, dispcommon.ErrAlreadyExists) {
+			// The same blob was stored concurrently
+			existing, err = s.blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+			if err != nil {
+				return corev2.BlobKey{}, err
+			}
+			return blobKey, checkSameContent(blobKey, existing, contentKey)
+		}
+		if !errors.Is(err, blobstore.ErrBlobContentChanged) {
+			return corev2.BlobKey{}, err
+		}
+	}
+
+	return corev2.BlobKey{}, fmt.Errorf("failed to store blob %s after %d attempts", blobKey.Hex(), maxStoreBlobAttempts)
+}
+
+// getOrUploadBlobContent returns the content record to reference, and whether the data was uploaded for it. If there
+// is no record yet, the data is uploaded under a new storage key and a record with no references is returned, to be
+// created along with the metadata. If the last reference to the existing record has been released, its deletion is
+// completed and nil is returned, so that the caller tries again.
+func (s *DispersalServerV2) getOrUploadBlobContent(ctx context.Context, contentKey dispv2.ContentKey, data []byte) (*dispv2.BlobContent, bool, error) {
+	content, err := s.blobMetadataStore.GetBlobContent(ctx, contentKey)
+	if err == nil {
+		if content.RefCount == 0 {
+			return nil, false, s.deleteBlobContent(ctx, contentKey, content)
+		}
+		return content, false, nil
+	}
+	if !errors.Is(err, dispcommon.ErrMetadataNotFound) {
+		return nil, false, err
+	}
+
+	storageKey, err := dispv2.NewContentStorageKey()
+	if err != nil {
+		return nil, false, err
+	}
+	if err := s.blobStore.StoreBlob(ctx, storageKey, data); err != nil {
+		return nil, false, err
 	}
-	err = s.blobMetadataStore.PutBlobMetadata(ctx, blobMetadata)
-	return blobKey, err
+	return &dispv2.BlobContent{StorageKey: storageKey}, true, nil
+}
+
+// deleteBlobContent deletes the chunks, proofs and data and then the record of content that is no longer referenced. It
+// may be called concurrently for the same record, and again after a failure.
+func (s *DispersalServerV2) deleteBlobContent(ctx context.Context, contentKey dispv2.ContentKey, content *dispv2.BlobContent) error {
+	if err := s.blobStore.DeleteChunks(ctx, content.StorageKey); err != nil {
+		return fmt.Errorf("failed to delete chunks of blob content %s: %w", contentKey.Hex(), err)
+	}
+	if err := s.blobStore.DeleteBlob(ctx, content.StorageKey); err != nil {
+		return fmt.Errorf("failed to delete blob content %s: %w", contentKey.Hex(), err)
+	}
+	err := s.blobMetadataStore.DeleteBlobContent(ctx, contentKey, content)
+	if err != nil && !errors.Is(err, blobstore.ErrBlobContentChanged) {
+		return fmt.Errorf("failed to delete blob content record %s: %w", contentKey.Hex(), err)
+	}
+	return nil
+}
+
+// checkSameContent returns an error if a blob that has already been stored does not have the given content. Blob
+// keys commit to the commitments of the blob but not to its data, so a request may reuse the header of a stored blob
+// with different data.
+func checkSameContent(blobKey corev2.BlobKey, existing *dispv2.BlobMetadata, contentKey dispv2.ContentKey) error {
+	if existing.ContentKey != nil && *existing.ContentKey != contentKey {
+		return fmt.Errorf("%w: blob %s", errBlobContentMismatch, blobKey.Hex())
+	}
+	return nil
 }
 
 func (s *DispersalServerV2) validateDispersalRequest(req *pb.DisperseBlobRequest) error {
@@ -77,13 +198,14 @@ func (s *DispersalServerV2) validateDispersalRequest(req *pb.DisperseBlobRequest
 		return api.NewErrorInvalidArg("blob size must be greater than 0")
 	}
 
+	onchainState := s.onchainState.Load()
 	blobHeaderProto := req.GetBlobHeader()
 	if blobHeaderProto.GetCommitment() == nil {
 		return api.NewErrorInvalidArg("blob header must contain commitments")
 	}
 
-	if len(blobHeaderProto.GetQuorumNumbers()) > int(s.onchainState.QuorumCount) {
-		return api.NewErrorInvalidArg(fmt.Sprintf("too many quorum numbers specified: maximum is %d", s.onchainState.QuorumCount))
+	if len(blobHeaderProto.GetQuorumNumbers()) > int(onchainState.QuorumCount) {
+		return api.NewErrorInvalidArg(fmt.Sprintf("too many quorum numbers specified: maximum is %d", onchainState.QuorumCount))
 	}
 
 	// validate every 32 bytes is a valid field element
@@ -93,13 +215,13 @@ func (s *DispersalServerV2) validateDispersalRequest(req *pb.DisperseBlobRequest
 		return api.NewErrorInvalidArg("encountered an error to convert a 32-bytes into a valid field element, please use the correct format where every 32bytes(big-endian) is less than 21888242871839275222246405745257275088548364400416034343698204186575808495617")
 	}
 
-	if !containsRequiredQuorum(s.onchainState.RequiredQuorums, blobHeaderProto.GetQuorumNumbers()) {
-		return api.NewErrorInvalidArg(fmt.Sprintf("request must contain at least one required quorum: %v does not specify any of %v", blobHeaderProto.GetQuorumNumbers(), s.onchainState.RequiredQuorums))
+	if !containsRequiredQuorum(onchainState.RequiredQuorums, blobHeaderProto.GetQuorumNumbers()) {
+		return api.NewErrorInvalidArg(fmt.Sprintf("request must contain at least one required quorum: %v does not specify any of %v", blobHeaderProto.GetQuorumNumbers(), onchainState.RequiredQuorums))
 	}
 
-	if _, ok := s.onchainState.BlobVersionParameters[corev2.BlobVersion(blobHeaderProto.GetVersion())]; !ok {
-		validVersions := make([]int32, 0, len(s.onchainState.BlobVersionParameters))
-		for version := range s.onchainState.BlobVersionParameters {
+	if _, ok := onchainState.BlobVersionParameters[corev2.BlobVersion(blobHeaderProto.GetVersion())]; !ok {
+		validVersions := make([]int32, 0, len(onchainState.BlobVersionParameters))
+		for version := range onchainState.BlobVersionParameters {
 			validVersions = append(validVersions, int32(version))
 		}
 		return api.NewErrorInvalidArg(fmt.Sprintf("invalid blob version %d; valid blob versions are: %v", blobHeaderProto.GetVersion(), validVersions))
@@ -0,0 +1,94 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+)
+
+// maxReapedBlobsPerStatus is the maximum number of expired blobs of each status deleted per reaping round.
+const maxReapedBlobsPerStatus = 1000
+
+// reapedStatuses are the terminal statuses of the blobs that are deleted once they expire. Blobs that are still being
+// processed are left to the controller.
+var reapedStatuses = []dispv2.BlobStatus{dispv2.Certified, dispv2.Failed, dispv2.InsufficientSignatures}
+
+// startBlobReaper periodically deletes the metadata and data of the blobs that have expired. Every replica may run it:
+// the metadata store serializes the release of content references, and deleting data is idempotent.
+func (s *DispersalServerV2) startBlobReaper(ctx context.Context) {
+	if s.serverConfig.BlobReapInterval <= 0 {
+		s.logger.Info("expired blob reaping is disabled")
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.serverConfig.BlobReapInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.ReapExpiredBlobs(ctx, time.Now()); err != nil {
+					s.logger.Error("failed to reap expired blobs", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// ReapExpiredBlobs deletes the blobs in a terminal status which expired at or before now. The reference a blob holds to
+// shared content is released with its metadata, and the data of the content is deleted with the last reference, so
+// stored data lives exactly as long as some blob that references it.
+func (s *DispersalServerV2) ReapExpiredBlobs(ctx context.Context, now time.Time) error {
+	for _, status := range reapedStatuses {
+		blobs, err := s.blobMetadataStore.GetExpiredBlobMetadataByStatus(ctx, status, uint64(now.Unix()), maxReapedBlobsPerStatus)
+		if err != nil {
+			return err
+		}
+		for _, blob := range blobs {
+			if err := s.reapBlob(ctx, blob); err != nil {
+				s.logger.Error("failed to reap expired blob", "status", status.String(), "err", err)
+			}
+		}
+		if len(blobs) > 0 {
+			s.logger.Debug("reaped expired blobs", "status", status.String(), "count", len(blobs))
+		}
+	}
+	return nil
+}
+
+func (s *DispersalServerV2) reapBlob(ctx context.Context, blob *dispv2.BlobMetadata) error {
+	if blob.ContentKey == nil {
+		// The blob stores its data, chunks and proofs on its own, which are deleted first so that a failure is retried
+		// in the next round
+		storageKey, err := blob.GetStorageKey()
+		if err != nil {
+			return err
+		}
+		if err := s.blobStore.DeleteChunks(ctx, storageKey); err != nil {
+			return err
+		}
+		if err := s.blobStore.DeleteBlob(ctx, storageKey); err != nil {
+			return err
+		}
+	}
+
+	content, err := s.blobMetadataStore.DeleteBlobMetadata(ctx, blob)
+	if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+		// Reaped concurrently
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if content == nil || content.RefCount > 0 {
+		return nil
+	}
+	// A failure leaves the released record behind, and its deletion is completed by the next StoreBlob of the content
+	return s.deleteBlobContent(ctx, *blob.ContentKey, content)
+}
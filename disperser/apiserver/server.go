@@ -16,12 +16,15 @@ import (
 	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/fswatch"
 	healthcheck "github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/auth"
 	"github.com/Layr-Labs/eigenda/core/meterer"
 	"github.com/Layr-Labs/eigenda/disperser"
 	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigenda/disperser/common/allowlist"
+	"github.com/Layr-Labs/eigenda/disperser/common/notifier"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -42,10 +45,18 @@ type DispersalServer struct {
 	serverConfig disperser.ServerConfig
 	rateConfig   RateConfig
 
+	// controls holds the allowlist, which is replaced whenever the allowlist file changes, along with the rate
+	// overrides and paused quorums set through the admin API. rateConfig.Allowlist is not used.
+	controls        *dispersalControls
+	allowlistLoader *allowlist.Loader
+
 	blobStore    disperser.BlobStore
 	tx           core.Reader
 	quorumConfig QuorumConfig
 
+	// statusNotifier pushes the status transitions of blobs to the SubscribeBlobStatus streams.
+	statusNotifier *notifier.Notifier[disperser.BlobKey, *disperser.BlobMetadata]
+
 	meterer       *meterer.Meterer
 	ratelimiter   common.RateLimiter
 	authenticator core.BlobRequestAuthenticator
@@ -88,18 +99,21 @@ func NewDispersalServer(
 	authenticator := auth.NewAuthenticator()
 
 	return &DispersalServer{
-		serverConfig:  serverConfig,
-		rateConfig:    rateConfig,
-		blobStore:     store,
-		tx:            tx,
-		metrics:       metrics,
-		logger:        logger,
-		meterer:       meterer,
-		ratelimiter:   ratelimiter,
-		authenticator: authenticator,
-		mu:            &sync.RWMutex{},
-		quorumConfig:  QuorumConfig{},
-		maxBlobSize:   maxBlobSize,
+		serverConfig:    serverConfig,
+		rateConfig:      rateConfig,
+		controls:        newDispersalControls(rateConfig.Allowlist),
+		allowlistLoader: newAllowlistLoader(logger, rateConfig),
+		blobStore:       store,
+		statusNotifier:  newStatusNotifier(serverConfig, store, logger),
+		tx:              tx,
+		metrics:         metrics,
+		logger:          logger,
+		meterer:         meterer,
+		ratelimiter:     ratelimiter,
+		authenticator:   authenticator,
+		mu:              &sync.RWMutex{},
+		quorumConfig:    QuorumConfig{},
+		maxBlobSize:     maxBlobSize,
 	}
 }
 
@@ -283,6 +297,14 @@ func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, aut
 
 	s.logger.Debug("received a new blob dispersal request", "authenticatedAddress", authenticatedAddress, "origin", origin, "blobSizeBytes", blobSize, "securityParams", strings.Join(securityParamsStrings, ", "))
 
+	if err := s.controls.checkPaused(blob.GetQuorumNumbers()); err != nil {
+		for _, param := range securityParams {
+			s.metrics.HandleFailedRequest(codes.Unavailable.String(), fmt.Sprintf("%d", param.QuorumID), blobSize, apiMethodName)
+		}
+		s.metrics.HandleDispersalPausedRpcRequest(apiMethodName)
+		return nil, api.NewErrorUnavailable(err.Error())
+	}
+
 	// If paymentHeader is not empty, we use the meterer, otherwise we use the ratelimiter if the ratelimiter is available
 	if paymentHeader != nil {
 		blobLength := encoding.GetBlobLength(uint(blobSize))
@@ -330,12 +352,14 @@ func (s *DispersalServer) getAccountRate(origin, authenticatedAddress string, qu
 		BlobRate:   unauthRates.PerUserUnauthBlobRate,
 	}
 
+	al := s.controls.effectiveAllowlist()
+
 	// Check if the address is in the allowlist
 	if len(authenticatedAddress) > 0 {
 		// normalize to lowercase (non-checksummed) address or IP address
 		authenticatedAddress = strings.ToLower(authenticatedAddress)
 
-		quorumRates, ok := s.rateConfig.Allowlist[authenticatedAddress]
+		quorumRates, ok := al[authenticatedAddress]
 		if ok {
 			rateInfo, ok := quorumRates[quorumID]
 			if ok {
@@ -360,7 +384,7 @@ func (s *DispersalServer) getAccountRate(origin, authenticatedAddress string, qu
 	// it is a more limited resource than an ETH public key
 	key := "ip:" + origin
 
-	for account, rateInfoByQuorum := range s.rateConfig.Allowlist {
+	for account, rateInfoByQuorum := range al {
 		if !strings.Contains(origin, account) {
 			continue
 		}
@@ -398,6 +422,8 @@ const (
 	AccountBlobRateType
 	RetrievalThroughputType
 	RetrievalBlobRateType
+	CommitmentThroughputType
+	CommitmentBlobRateType
 )
 
 func (r RateType) String() string {
@@ -414,6 +440,10 @@ func (r RateType) String() string {
 		return "Retrieval throughput rate limit"
 	case RetrievalBlobRateType:
 		return "Retrieval blob rate limit"
+	case CommitmentThroughputType:
+		return "Commitment throughput rate limit"
+	case CommitmentBlobRateType:
+		return "Commitment blob rate limit"
 	default:
 		return "Unknown rate type"
 	}
@@ -433,6 +463,10 @@ func (r RateType) Plug() string {
 		return "retrieval_throughput"
 	case RetrievalBlobRateType:
 		return "retrieval_blob_rate"
+	case CommitmentThroughputType:
+		return "commitment_throughput"
+	case CommitmentBlobRateType:
+		return "commitment_blob_rate"
 	default:
 		return "unknown_rate_type"
 	}
@@ -606,15 +640,25 @@ func (s *DispersalServer) GetBlobStatus(ctx context.Context, req *pb.BlobStatusR
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob metadata, blobkey: %s", metadataKey.String()))
 	}
 
-	isConfirmed, err := metadata.IsConfirmed()
+	reply, err := s.blobStatusReply(metadata)
 	if err != nil {
 		s.metrics.HandleInternalFailureRpcRequest("GetBlobStatus")
-		return nil, api.NewErrorInternal(fmt.Sprintf("missing confirmation information: %s", err.Error()))
+		return nil, err
 	}
 
 	s.metrics.HandleSuccessfulRpcRequest("GetBlobStatus")
+	return reply, nil
+}
+
+// blobStatusReply converts the blob metadata to the reply of a blob status request. For confirmed blobs, the reply
+// includes the information needed to verify the blob on chain.
+func (s *DispersalServer) blobStatusReply(metadata *disperser.BlobMetadata) (*pb.BlobStatusReply, error) {
+	isConfirmed, err := metadata.IsConfirmed()
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("missing confirmation information: %s", err.Error()))
+	}
 
-	s.logger.Debug("isConfirmed", "metadataKey", metadataKey, "isConfirmed", isConfirmed)
+	s.logger.Debug("isConfirmed", "metadataKey", metadata.GetBlobKey(), "isConfirmed", isConfirmed)
 	if isConfirmed {
 		confirmationInfo := metadata.ConfirmationInfo
 		dataLength := uint32(confirmationInfo.BlobCommitment.Length)
@@ -807,23 +851,16 @@ func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlob
 	}, nil
 }
 
+// GetRateConfig returns a copy of the rate config, including the current allowlist.
 func (s *DispersalServer) GetRateConfig() *RateConfig {
-	return &s.rateConfig
+	rateConfig := s.rateConfig
+	rateConfig.Allowlist = s.controls.getAllowlist()
+	return &rateConfig
 }
 
 func (s *DispersalServer) Start(ctx context.Context) error {
-	go func() {
-		t := time.NewTicker(s.rateConfig.AllowlistRefreshInterval)
-		defer t.Stop()
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-t.C:
-				s.LoadAllowlist()
-			}
-		}
-	}()
+	go fswatch.WatchFile(ctx, s.logger, s.rateConfig.AllowlistFile, s.rateConfig.AllowlistRefreshInterval, s.LoadAllowlist)
+	s.statusNotifier.Start(ctx)
 	// Serve grpc requests
 	addr := fmt.Sprintf("%s:%s", disperser.Localhost, s.serverConfig.GrpcPort)
 	listener, err := net.Listen("tcp", addr)
@@ -850,18 +887,26 @@ func (s *DispersalServer) Start(ctx context.Context) error {
 	return nil
 }
 
+// newAllowlistLoader creates the loader that reloads the allowlist file, and warns about the problems with the file
+// that were tolerated when it was read at startup, since reloading the file fails until they are fixed.
+func newAllowlistLoader(logger logging.Logger, rateConfig RateConfig) *allowlist.Loader {
+	for _, warning := range rateConfig.AllowlistWarnings {
+		logger.Warn("allowlist file has a problem that is tolerated at startup but rejected on reload",
+			"file", rateConfig.AllowlistFile, "line", warning.Line, "problem", warning.Message)
+	}
+	return allowlist.NewLoader(logger, rateConfig.AllowlistFile, rateConfig.Allowlist, rateConfig.MetricsRegistry)
+}
+
+// LoadAllowlist reloads the allowlist from the allowlist file. If the file is invalid, the error is logged and the
+// previous allowlist remains in use.
 func (s *DispersalServer) LoadAllowlist() {
-	al, err := ReadAllowlistFromFile(s.rateConfig.AllowlistFile)
+	al, _, err := s.allowlistLoader.Load()
 	if err != nil {
-		s.logger.Error("failed to load allowlist", "err", err)
+		s.logger.Error("failed to load allowlist, keeping the previous allowlist", "err", err)
 		return
 	}
-	s.rateConfig.Allowlist = al
-	for account, rateInfoByQuorum := range al {
-		for quorumID, rateInfo := range rateInfoByQuorum {
-			s.logger.Info("[Allowlist]", "account", account, "name", rateInfo.Name, "quorumID", quorumID, "throughput", rateInfo.Throughput, "blobRate", rateInfo.BlobRate)
-		}
-	}
+
+	s.controls.setAllowlist(al)
 }
 
 // updateQuorumConfig updates the quorum config and returns the updated quorum config. If the update fails,
@@ -870,13 +915,19 @@ func (s *DispersalServer) LoadAllowlist() {
 // not result in a safety failure since all parameters are separately validated on the smart contract.
 
 func (s *DispersalServer) updateQuorumConfig(ctx context.Context) (QuorumConfig, error) {
+	return s.loadQuorumConfig(ctx, true)
+}
+
+// loadQuorumConfig reads the quorum config from the chain. If allowFallback is false, any RPC failure is returned
+// and the cached quorum config is left unchanged.
+func (s *DispersalServer) loadQuorumConfig(ctx context.Context, allowFallback bool) (QuorumConfig, error) {
 
 	s.mu.RLock()
 	newConfig := s.quorumConfig
 	s.mu.RUnlock()
 
 	// If the quorum count is set, we will fallback to the old quorumConfig if the RPC fails
-	fallbackToCache := newConfig.QuorumCount != 0
+	fallbackToCache := allowFallback && newConfig.QuorumCount != 0
 
 	currentBlock, err := s.tx.GetCurrentBlockNumber(ctx)
 	if err != nil {
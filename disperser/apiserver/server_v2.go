@@ -4,17 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"net"
+	"sort"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/fswatch"
 	healthcheck "github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigenda/disperser/common/allowlist"
+	"github.com/Layr-Labs/eigenda/disperser/common/notifier"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
@@ -34,14 +45,24 @@ type DispersalServerV2 struct {
 	rateConfig        RateConfig
 	blobStore         *blobstore.BlobStore
 	blobMetadataStore *blobstore.BlobMetadataStore
+	// statusNotifier pushes the status transitions of blobs to the SubscribeBlobStatus streams.
+	statusNotifier *notifier.Notifier[corev2.BlobKey, *dispv2.BlobMetadata]
+
+	// controls holds the allowlist, which is replaced whenever the allowlist file changes, along with the paused
+	// quorums set through the admin API. rateConfig.Allowlist is not used.
+	controls        *dispersalControls
+	allowlistLoader *allowlist.Loader
 
 	chainReader   core.Reader
+	meterer       *meterer.Meterer
 	ratelimiter   common.RateLimiter
 	authenticator corev2.BlobRequestAuthenticator
+	prover        encoding.Prover
 	logger        logging.Logger
 
 	// state
-	onchainState                OnchainState
+	// onchainState is replaced by every refresh, which may come from the periodic refresh or the admin API.
+	onchainState                atomic.Pointer[OnchainState]
 	maxNumSymbolsPerBlob        uint64
 	onchainStateRefreshInterval time.Duration
 }
@@ -53,29 +74,37 @@ func NewDispersalServerV2(
 	blobStore *blobstore.BlobStore,
 	blobMetadataStore *blobstore.BlobMetadataStore,
 	chainReader core.Reader,
+	meterer *meterer.Meterer,
 	ratelimiter common.RateLimiter,
 	authenticator corev2.BlobRequestAuthenticator,
+	prover encoding.Prover,
 	maxNumSymbolsPerBlob uint64,
 	onchainStateRefreshInterval time.Duration,
 	_logger logging.Logger,
 ) *DispersalServerV2 {
 	logger := _logger.With("component", "DispersalServerV2")
 
-	return &DispersalServerV2{
+	s := &DispersalServerV2{
 		serverConfig:      serverConfig,
 		rateConfig:        rateConfig,
+		controls:          newDispersalControls(rateConfig.Allowlist),
+		allowlistLoader:   newAllowlistLoader(logger, rateConfig),
 		blobStore:         blobStore,
 		blobMetadataStore: blobMetadataStore,
+		statusNotifier:    newStatusNotifierV2(serverConfig, blobMetadataStore, logger),
 
 		chainReader:   chainReader,
+		meterer:       meterer,
 		ratelimiter:   ratelimiter,
 		authenticator: authenticator,
+		prover:        prover,
 		logger:        logger,
 
-		onchainState:                OnchainState{},
 		maxNumSymbolsPerBlob:        maxNumSymbolsPerBlob,
 		onchainStateRefreshInterval: onchainStateRefreshInterval,
 	}
+	s.onchainState.Store(&OnchainState{})
+	return s
 }
 
 func (s *DispersalServerV2) Start(ctx context.Context) error {
@@ -120,20 +149,14 @@ func (s *DispersalServerV2) Start(ctx context.Context) error {
 		}
 	}()
 
-	go func() {
-		t := time.NewTicker(s.rateConfig.AllowlistRefreshInterval)
-		defer t.Stop()
-		for {
-			select {
-			case <-t.C:
-				if err := s.RefreshAllowlist(); err != nil {
-					s.logger.Error("failed to refresh allowlist", "err", err)
-				}
-			case <-ctx.Done():
-				return
-			}
+	s.statusNotifier.Start(ctx)
+	s.startBlobReaper(ctx)
+
+	go fswatch.WatchFile(ctx, s.logger, s.rateConfig.AllowlistFile, s.rateConfig.AllowlistRefreshInterval, func() {
+		if err := s.RefreshAllowlist(); err != nil {
+			s.logger.Error("failed to refresh allowlist, keeping the previous allowlist", "err", err)
 		}
-	}()
+	})
 
 	s.logger.Info("GRPC Listening", "port", s.serverConfig.GrpcPort, "address", listener.Addr().String())
 
@@ -145,32 +168,392 @@ func (s *DispersalServerV2) Start(ctx context.Context) error {
 }
 
 func (s *DispersalServerV2) GetBlobStatus(ctx context.Context, req *pb.BlobStatusRequest) (*pb.BlobStatusReply, error) {
-	return &pb.BlobStatusReply{}, api.NewErrorUnimplemented()
+	if len(req.GetBlobKey()) != 32 {
+		return nil, api.NewErrorInvalidArg("invalid blob key")
+	}
+
+	blobKey, err := corev2.BytesToBlobKey(req.GetBlobKey())
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid blob key: %s", err.Error()))
+	}
+
+	metadata, err := s.blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	if err != nil {
+		if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+			return nil, api.NewErrorNotFound("no such blob found")
+		}
+		s.logger.Error("failed to get blob metadata", "err", err, "blobKey", blobKey.Hex())
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob metadata: %s", err.Error()))
+	}
+
+	return s.blobStatusReply(ctx, blobKey, metadata)
+}
+
+// blobStatusReply converts the blob metadata to the reply of a blob status request. For certified blobs, the signed
+// batch and the verification info of the blob are read from the metadata store and included in the reply.
+func (s *DispersalServerV2) blobStatusReply(ctx context.Context, blobKey corev2.BlobKey, metadata *dispv2.BlobMetadata) (*pb.BlobStatusReply, error) {
+	if metadata.BlobStatus != dispv2.Certified {
+		return &pb.BlobStatusReply{
+			Status: metadata.BlobStatus.ToProfobuf(),
+		}, nil
+	}
+
+	// For certified blobs, include the signed batch and the information needed to verify the inclusion of the blob
+	// in the batch, so that the client can build a certificate.
+	cert, _, err := s.blobMetadataStore.GetBlobCertificate(ctx, blobKey)
+	if err != nil {
+		s.logger.Error("failed to get blob certificate", "err", err, "blobKey", blobKey.Hex())
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob certificate: %s", err.Error()))
+	}
+
+	verificationInfos, err := s.blobMetadataStore.GetBlobVerificationInfos(ctx, blobKey)
+	if err != nil {
+		s.logger.Error("failed to get blob verification info", "err", err, "blobKey", blobKey.Hex())
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob verification info: %s", err.Error()))
+	}
+
+	// A blob may be included in more than one batch, e.g. if a dispersal was retried, and the most recent batch may
+	// not be attested yet. Return the batch with the most recent reference block number among those that have an
+	// attestation. If none of them has, fall back to the most recent batch, whose attestation is reported as missing.
+	batches := make([]*corev2.BlobVerificationInfo, 0, len(verificationInfos))
+	for _, info := range verificationInfos {
+		if info.BatchHeader != nil {
+			batches = append(batches, info)
+		}
+	}
+	if len(batches) == 0 {
+		s.logger.Error("no batch header found for certified blob", "blobKey", blobKey.Hex())
+		return nil, api.NewErrorInternal("no batch header found for certified blob")
+	}
+	sort.SliceStable(batches, func(i, j int) bool {
+		return batches[i].ReferenceBlockNumber > batches[j].ReferenceBlockNumber
+	})
+
+	var verificationInfo *corev2.BlobVerificationInfo
+	var attestation *corev2.Attestation
+	for _, info := range batches {
+		batchHeaderHash, err := info.BatchHeader.Hash()
+		if err != nil {
+			s.logger.Error("failed to get batch header hash", "err", err, "blobKey", blobKey.Hex())
+			return nil, api.NewErrorInternal(fmt.Sprintf("failed to get batch header hash: %s", err.Error()))
+		}
+
+		attestation, err = s.blobMetadataStore.GetAttestation(ctx, batchHeaderHash)
+		if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+			continue
+		}
+		if err != nil {
+			s.logger.Error("failed to get attestation", "err", err, "blobKey", blobKey.Hex())
+			return nil, api.NewErrorInternal(fmt.Sprintf("failed to get attestation: %s", err.Error()))
+		}
+		verificationInfo = info
+		break
+	}
+	if verificationInfo == nil {
+		latest := batches[0]
+		s.logger.Error("no attestation found for certified blob", "blobKey", blobKey.Hex(), "referenceBlockNumber", latest.ReferenceBlockNumber)
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get attestation: no attestation found for batch with reference block number %d", latest.ReferenceBlockNumber))
+	}
+
+	attestationProto, err := attestationToProtobuf(attestation)
+	if err != nil {
+		s.logger.Error("failed to convert attestation to protobuf", "err", err, "blobKey", blobKey.Hex())
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to convert attestation to protobuf: %s", err.Error()))
+	}
+
+	verificationInfoProto, err := blobVerificationInfoToProtobuf(verificationInfo, cert)
+	if err != nil {
+		s.logger.Error("failed to convert blob verification info to protobuf", "err", err, "blobKey", blobKey.Hex())
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to convert blob verification info to protobuf: %s", err.Error()))
+	}
+
+	return &pb.BlobStatusReply{
+		Status: metadata.BlobStatus.ToProfobuf(),
+		SignedBatch: &pb.SignedBatch{
+			Header:                      verificationInfo.BatchHeader.ToProtobuf(),
+			NonSignerStakesAndSignature: attestationProto,
+		},
+		BlobVerificationInfo: verificationInfoProto,
+	}, nil
+}
+
+// attestationToProtobuf converts the attestation to its protobuf representation. The aggregate public keys and the
+// signed percentages of the quorums are returned in the same order as the quorum numbers. Attestations stored before
+// the signed percentages were recorded have none.
+func attestationToProtobuf(attestation *corev2.Attestation) (*pb.Attestation, error) {
+	if attestation.APKG2 == nil {
+		return nil, errors.New("attestation is missing the aggregate public key")
+	}
+	if attestation.Sigma == nil || attestation.Sigma.G1Point == nil {
+		return nil, errors.New("attestation is missing the aggregate signature")
+	}
+
+	nonSignerPubKeys := make([][]byte, len(attestation.NonSignerPubKeys))
+	for i, p := range attestation.NonSignerPubKeys {
+		nonSignerPubKeys[i] = p.Serialize()
+	}
+
+	quorumAPKs := make([][]byte, len(attestation.QuorumNumbers))
+	quorumNumbers := make([]uint32, len(attestation.QuorumNumbers))
+	var quorumSignedPercentages []byte
+	if len(attestation.QuorumResults) > 0 {
+		quorumSignedPercentages = make([]byte, len(attestation.QuorumNumbers))
+	}
+	for i, q := range attestation.QuorumNumbers {
+		apk, ok := attestation.QuorumAPKs[q]
+		if !ok {
+			return nil, fmt.Errorf("attestation is missing the aggregate public key of quorum %d", q)
+		}
+		quorumAPKs[i] = apk.Serialize()
+		quorumNumbers[i] = uint32(q)
+
+		if quorumSignedPercentages != nil {
+			percentSigned, ok := attestation.QuorumResults[q]
+			if !ok {
+				return nil, fmt.Errorf("attestation is missing the signed percentage of quorum %d", q)
+			}
+			quorumSignedPercentages[i] = percentSigned
+		}
+	}
+
+	return &pb.Attestation{
+		NonSignerPubkeys:        nonSignerPubKeys,
+		ApkG2:                   attestation.APKG2.Serialize(),
+		QuorumApks:              quorumAPKs,
+		Sigma:                   attestation.Sigma.Serialize(),
+		QuorumNumbers:           quorumNumbers,
+		QuorumSignedPercentages: quorumSignedPercentages,
+	}, nil
+}
+
+// blobVerificationInfoToProtobuf converts the verification info to its protobuf representation. The blob certificate
+// is not part of BlobVerificationInfo, so it must be provided by the caller.
+func blobVerificationInfoToProtobuf(verificationInfo *corev2.BlobVerificationInfo, blobCert *corev2.BlobCertificate) (*pb.BlobVerificationInfo, error) {
+	if blobCert == nil {
+		return nil, errors.New("blob certificate is nil")
+	}
+
+	blobCertProto, err := blobCert.ToProtobuf()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert blob certificate to protobuf: %v", err)
+	}
+
+	return &pb.BlobVerificationInfo{
+		BlobCertificate: blobCertProto,
+		BlobIndex:       verificationInfo.BlobIndex,
+		InclusionProof:  verificationInfo.InclusionProof,
+	}, nil
 }
 
 func (s *DispersalServerV2) GetBlobCommitment(ctx context.Context, req *pb.BlobCommitmentRequest) (*pb.BlobCommitmentReply, error) {
-	return &pb.BlobCommitmentReply{}, api.NewErrorUnimplemented()
+	if s.prover == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+
+	data := req.GetData()
+	blobSize := len(data)
+	if blobSize == 0 {
+		return nil, api.NewErrorInvalidArg("data is empty")
+	}
+	if uint64(blobSize) > s.maxNumSymbolsPerBlob*encoding.BYTES_PER_SYMBOL {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("blob size cannot exceed %v bytes", s.maxNumSymbolsPerBlob*encoding.BYTES_PER_SYMBOL))
+	}
+
+	origin, err := common.GetClientAddress(ctx, s.rateConfig.ClientIPHeader, 2, true)
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(err.Error())
+	}
+
+	// Computing commitments is expensive, so check the rate limits before doing any work.
+	if err := s.checkCommitmentRateLimits(ctx, origin, blobSize); err != nil {
+		return nil, err
+	}
+
+	// validate every 32 bytes is a valid field element
+	if _, err = rs.ToFrArray(data); err != nil {
+		return nil, api.NewErrorInvalidArg("encountered an error to convert a 32-bytes into a valid field element, please use the correct format where every 32bytes(big-endian) is less than 21888242871839275222246405745257275088548364400416034343698204186575808495617")
+	}
+
+	commitments, err := s.prover.GetCommitments(data)
+	if err != nil {
+		s.logger.Error("failed to compute blob commitments", "err", err, "origin", origin)
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to compute blob commitments: %s", err.Error()))
+	}
+
+	commitmentsProto, err := commitments.ToProtobuf()
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to convert blob commitments to protobuf: %s", err.Error()))
+	}
+
+	return &pb.BlobCommitmentReply{
+		BlobCommitment: commitmentsProto,
+	}, nil
 }
 
-func (s *DispersalServerV2) RefreshAllowlist() error {
-	s.logger.Debug("Refreshing onchain quorum state")
-	al, err := ReadAllowlistFromFile(s.rateConfig.AllowlistFile)
+// maxPaymentStateRequestAge is how far the timestamp of a payment state request may be from the current time. Signed
+// requests outside of this window are rejected, so that a captured request can't be replayed to read the state of an
+// account later on.
+const maxPaymentStateRequestAge = time.Minute
+
+// GetPaymentState returns the payment state of an account: the global parameters of the payment vault, the on-chain
+// reservation and deposit of the account, and the usage of the reservation and of the deposit tracked by the disperser.
+// The request must be signed by the account, with a recent timestamp.
+func (s *DispersalServerV2) GetPaymentState(ctx context.Context, req *pb.GetPaymentStateRequest) (*pb.GetPaymentStateReply, error) {
+	if s.meterer == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+
+	accountID := req.GetAccountId()
+	if accountID == "" {
+		return nil, api.NewErrorInvalidArg("account id is empty")
+	}
+	requestAge := time.Since(time.Unix(0, int64(req.GetTimestamp())))
+	if requestAge > maxPaymentStateRequestAge || requestAge < -maxPaymentStateRequestAge {
+		return nil, api.NewErrorUnauthenticated(fmt.Sprintf("request timestamp is more than %v away from the current time", maxPaymentStateRequestAge))
+	}
+	if err := s.authenticator.AuthenticatePaymentStateRequest(req.GetSignature(), accountID, req.GetTimestamp()); err != nil {
+		return nil, api.NewErrorUnauthenticated(fmt.Sprintf("authentication failed: %s", err.Error()))
+	}
+
+	chainState := s.meterer.ChainPaymentState
+	reservationWindow := chainState.GetReservationWindow()
+	if reservationWindow == 0 {
+		return nil, api.NewErrorInternal("reservation window is not set")
+	}
+	onDemandQuorumNumbers, err := chainState.GetOnDemandQuorumNumbers(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to load allowlist: %w", err)
+		s.logger.Error("failed to get on-demand quorum numbers", "err", err)
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get on-demand quorum numbers: %s", err.Error()))
+	}
+	paymentGlobalParams := &pb.PaymentGlobalParams{
+		GlobalSymbolsPerSecond: chainState.GetGlobalSymbolsPerSecond(),
+		MinNumSymbols:          chainState.GetMinNumSymbols(),
+		PricePerSymbol:         chainState.GetPricePerSymbol(),
+		ReservationWindow:      reservationWindow,
+		OnDemandQuorumNumbers:  make([]uint32, len(onDemandQuorumNumbers)),
+	}
+	for i, quorum := range onDemandQuorumNumbers {
+		paymentGlobalParams.OnDemandQuorumNumbers[i] = uint32(quorum)
+	}
+
+	// Requests are accepted for the current and the previous bin, and the overflow of a bin is charged two bins
+	// ahead, so these are the bins relevant for the client to account its reservation usage.
+	currentBinIndex := meterer.GetBinIndex(uint64(time.Now().Unix()), reservationWindow)
+	startBinIndex := currentBinIndex
+	if startBinIndex > 0 {
+		startBinIndex--
 	}
-	s.rateConfig.Allowlist = al
-	for account, rateInfoByQuorum := range al {
-		for quorumID, rateInfo := range rateInfoByQuorum {
-			s.logger.Info("[Allowlist]", "account", account, "name", rateInfo.Name, "quorumID", quorumID, "throughput", rateInfo.Throughput, "blobRate", rateInfo.BlobRate)
+	bins, err := s.meterer.OffchainStore.GetReservationBins(ctx, accountID, startBinIndex, currentBinIndex+2)
+	if err != nil {
+		s.logger.Error("failed to get reservation bins", "err", err, "accountID", accountID)
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get reservation bins: %s", err.Error()))
+	}
+	binRecords := make([]*pb.BinRecord, len(bins))
+	for i, bin := range bins {
+		binRecords[i] = &pb.BinRecord{
+			Index: bin.BinIndex,
+			Usage: uint64(bin.BinUsage),
 		}
 	}
 
+	cumulativePayment, err := s.meterer.OffchainStore.GetLargestCumulativePayment(ctx, accountID)
+	if err != nil {
+		s.logger.Error("failed to get largest cumulative payment", "err", err, "accountID", accountID)
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get largest cumulative payment: %s", err.Error()))
+	}
+
+	// An account may have a reservation, an on-demand deposit, both or neither. A missing entry is reported as an
+	// empty field rather than as an error.
+	var reservation *pb.Reservation
+	activeReservation, err := chainState.GetActiveReservationByAccount(ctx, accountID)
+	if err != nil {
+		s.logger.Debug("no active reservation found for account", "accountID", accountID, "err", err)
+	} else {
+		reservation = &pb.Reservation{
+			SymbolsPerSecond: activeReservation.SymbolsPerSec,
+			StartTimestamp:   activeReservation.StartTimestamp,
+			EndTimestamp:     activeReservation.EndTimestamp,
+			QuorumNumbers:    make([]uint32, len(activeReservation.QuorumNumbers)),
+			QuorumSplits:     make([]uint32, len(activeReservation.QuorumSplit)),
+		}
+		for i, quorum := range activeReservation.QuorumNumbers {
+			reservation.QuorumNumbers[i] = uint32(quorum)
+		}
+		for i, split := range activeReservation.QuorumSplit {
+			reservation.QuorumSplits[i] = uint32(split)
+		}
+	}
+
+	onchainCumulativePayment := big.NewInt(0)
+	onDemandPayment, err := chainState.GetOnDemandPaymentByAccount(ctx, accountID)
+	if err != nil {
+		s.logger.Debug("no on-demand payment found for account", "accountID", accountID, "err", err)
+	} else if onDemandPayment.CumulativePayment != nil {
+		onchainCumulativePayment = onDemandPayment.CumulativePayment
+	}
+
+	return &pb.GetPaymentStateReply{
+		PaymentGlobalParams:      paymentGlobalParams,
+		BinRecord:                binRecords,
+		Reservation:              reservation,
+		CumulativePayment:        cumulativePayment.Bytes(),
+		OnchainCumulativePayment: onchainCumulativePayment.Bytes(),
+	}, nil
+}
+
+// checkCommitmentRateLimits checks the blob rate and the throughput of the commitment requests made from the origin.
+// If either rate limit is exceeded, a ResourceExhaustedError is returned.
+func (s *DispersalServerV2) checkCommitmentRateLimits(ctx context.Context, origin string, blobSize int) error {
+	if s.ratelimiter == nil {
+		return nil
+	}
+
+	allowed, param, err := s.ratelimiter.AllowRequest(ctx, []common.RequestParams{
+		{
+			RequesterID: fmt.Sprintf("%s:%s", origin, CommitmentBlobRateType.Plug()),
+			BlobSize:    blobRateMultiplier,
+			Rate:        s.rateConfig.CommitmentBlobRate,
+			Info:        CommitmentBlobRateType.String(),
+		},
+		{
+			RequesterID: fmt.Sprintf("%s:%s", origin, CommitmentThroughputType.Plug()),
+			BlobSize:    uint(blobSize),
+			Rate:        s.rateConfig.CommitmentThroughput,
+			Info:        CommitmentThroughputType.String(),
+		},
+	})
+	if err != nil {
+		return api.NewErrorInternal(fmt.Sprintf("ratelimiter error: %v", err))
+	}
+	if !allowed {
+		errorString := "request ratelimited"
+		info, ok := param.Info.(string)
+		if ok {
+			errorString += ": " + info
+		}
+		return api.NewErrorResourceExhausted(errorString)
+	}
+
+	return nil
+}
+
+// RefreshAllowlist reloads the allowlist from the allowlist file. If the file is invalid, an error is returned and
+// the previous allowlist remains in use.
+func (s *DispersalServerV2) RefreshAllowlist() error {
+	s.logger.Debug("Refreshing allowlist")
+	al, _, err := s.allowlistLoader.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load allowlist: %w", err)
+	}
+
+	s.controls.setAllowlist(al)
+
 	return nil
 }
 
-// refreshOnchainState refreshes the onchain quorum state.
-// It should be called periodically to keep the state up to date.
-// **Note** that there is no lock. If the state is being updated concurrently, it may lead to inconsistent state.
+// RefreshOnchainState refreshes the onchain quorum state.
+// It should be called periodically to keep the state up to date. The state is only replaced if every value was read
+// successfully.
 func (s *DispersalServerV2) RefreshOnchainState(ctx context.Context) error {
 	s.logger.Debug("Refreshing onchain quorum state")
 
@@ -195,12 +578,12 @@ func (s *DispersalServerV2) RefreshOnchainState(ctx context.Context) error {
 	if err != nil || storeDurationBlocks == 0 {
 		return fmt.Errorf("failed to get STORE_DURATION_BLOCKS: %w", err)
 	}
-	s.onchainState = OnchainState{
+	s.onchainState.Store(&OnchainState{
 		QuorumCount:     quorumCount,
 		RequiredQuorums: requiredQuorums,
 		// TODO(ian-shim): this should be fetched from chain
 		BlobVersionParameters: corev2.ParametersMap,
 		TTL:                   time.Duration((storeDurationBlocks+blockStaleMeasure)*12) * time.Second,
-	}
+	})
 	return nil
 }
@@ -4,23 +4,34 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"math"
 	"math/big"
 	"net"
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/store"
+	"github.com/Layr-Labs/eigenda/core"
 	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
+	"github.com/Layr-Labs/eigenda/core/meterer"
 	"github.com/Layr-Labs/eigenda/core/mock"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
 	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	pbcommon "github.com/Layr-Labs/eigenda/api/grpc/common"
 	pbcommonv2 "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
@@ -34,6 +45,7 @@ type testComponents struct {
 	DispersalServerV2 *apiserver.DispersalServerV2
 	BlobStore         *blobstore.BlobStore
 	BlobMetadataStore *blobstore.BlobMetadataStore
+	S3Client          s3.Client
 	ChainReader       *mock.MockWriter
 	Signer            *auth.LocalBlobRequestSigner
 	Peer              *peer.Peer
@@ -83,11 +95,6 @@ func TestV2DisperseBlob(t *testing.T) {
 	assert.Equal(t, pbv2.BlobStatus_QUEUED, reply.Result)
 	assert.Equal(t, blobKey[:], reply.BlobKey)
 
-	// Check if the blob is stored
-	storedData, err := c.BlobStore.GetBlob(ctx, blobKey)
-	assert.NoError(t, err)
-	assert.Equal(t, data, storedData)
-
 	// Check if the blob metadata is stored
 	blobMetadata, err := c.BlobMetadataStore.GetBlobMetadata(ctx, blobKey)
 	assert.NoError(t, err)
@@ -98,6 +105,13 @@ func TestV2DisperseBlob(t *testing.T) {
 	assert.Greater(t, blobMetadata.Expiry, uint64(now.Unix()))
 	assert.Greater(t, blobMetadata.RequestedAt, uint64(now.UnixNano()))
 	assert.Equal(t, blobMetadata.RequestedAt, blobMetadata.UpdatedAt)
+
+	// Check if the blob is stored
+	storageKey, err := blobMetadata.GetStorageKey()
+	assert.NoError(t, err)
+	storedData, err := c.BlobStore.GetBlob(ctx, storageKey)
+	assert.NoError(t, err)
+	assert.Equal(t, data, storedData)
 }
 
 func TestV2DisperseBlobRequestValidation(t *testing.T) {
@@ -201,21 +215,618 @@ func TestV2DisperseBlobRequestValidation(t *testing.T) {
 
 func TestV2GetBlobStatus(t *testing.T) {
 	c := newTestServerV2(t)
-	_, err := c.DispersalServerV2.GetBlobStatus(context.Background(), &pbv2.BlobStatusRequest{
+	ctx := peer.NewContext(context.Background(), c.Peer)
+
+	// invalid blob key
+	_, err := c.DispersalServerV2.GetBlobStatus(ctx, &pbv2.BlobStatusRequest{
 		BlobKey: []byte{1},
 	})
-	assert.ErrorContains(t, err, "not implemented")
+	assert.ErrorContains(t, err, "invalid blob key")
+
+	// unknown blob key
+	_, err = c.DispersalServerV2.GetBlobStatus(ctx, &pbv2.BlobStatusRequest{
+		BlobKey: make([]byte, 32),
+	})
+	assert.ErrorContains(t, err, "no such blob found")
+
+	data := make([]byte, 50)
+	_, err = rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+	commitments, err := prover.GetCommitments(data)
+	assert.NoError(t, err)
+	accountID, err := c.Signer.GetAccountID()
+	assert.NoError(t, err)
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: commitments,
+		QuorumNumbers:   []core.QuorumID{0, 1},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         accountID,
+			BinIndex:          5,
+			CumulativePayment: big.NewInt(100),
+		},
+	}
+	blobKey, err := c.DispersalServerV2.StoreBlob(ctx, data, blobHeader, time.Now())
+	assert.NoError(t, err)
+
+	// queued blobs only report their status
+	reply, err := c.DispersalServerV2.GetBlobStatus(ctx, &pbv2.BlobStatusRequest{
+		BlobKey: blobKey[:],
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pbv2.BlobStatus_QUEUED, reply.GetStatus())
+	assert.Nil(t, reply.GetSignedBatch())
+	assert.Nil(t, reply.GetBlobVerificationInfo())
+
+	// certify the blob
+	blobCert := &corev2.BlobCertificate{
+		BlobHeader: blobHeader,
+		RelayKeys:  []corev2.RelayKey{0, 1},
+	}
+	err = c.BlobMetadataStore.PutBlobCertificate(ctx, blobCert, &encoding.FragmentInfo{})
+	assert.NoError(t, err)
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{1, 2, 3},
+		ReferenceBlockNumber: 100,
+	}
+	err = c.BlobMetadataStore.PutBatchHeader(ctx, batchHeader)
+	assert.NoError(t, err)
+	err = c.BlobMetadataStore.PutBlobVerificationInfo(ctx, &corev2.BlobVerificationInfo{
+		BatchHeader:    batchHeader,
+		BlobKey:        blobKey,
+		BlobIndex:      7,
+		InclusionProof: []byte("proof"),
+	})
+	assert.NoError(t, err)
+	keyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	attestation := &corev2.Attestation{
+		BatchHeader: batchHeader,
+		AttestedAt:  uint64(time.Now().UnixNano()),
+		NonSignerPubKeys: []*core.G1Point{
+			core.NewG1Point(big.NewInt(1), big.NewInt(2)),
+		},
+		APKG2: keyPair.GetPubKeyG2(),
+		QuorumAPKs: map[core.QuorumID]*core.G1Point{
+			0: core.NewG1Point(big.NewInt(5), big.NewInt(6)),
+			1: core.NewG1Point(big.NewInt(7), big.NewInt(8)),
+		},
+		Sigma: &core.Signature{
+			G1Point: core.NewG1Point(big.NewInt(9), big.NewInt(10)),
+		},
+		QuorumNumbers: []core.QuorumID{0, 1},
+		QuorumResults: map[core.QuorumID]uint8{
+			0: 80,
+			1: 100,
+		},
+	}
+	err = c.BlobMetadataStore.PutAttestation(ctx, attestation)
+	assert.NoError(t, err)
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Encoded)
+	assert.NoError(t, err)
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Certified)
+	assert.NoError(t, err)
+
+	// certified blobs include the signed batch and the verification info
+	reply, err = c.DispersalServerV2.GetBlobStatus(ctx, &pbv2.BlobStatusRequest{
+		BlobKey: blobKey[:],
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pbv2.BlobStatus_CERTIFIED, reply.GetStatus())
+	assert.Equal(t, batchHeader.ToProtobuf(), reply.GetSignedBatch().GetHeader())
+	attestationProto := reply.GetSignedBatch().GetNonSignerStakesAndSignature()
+	assert.Equal(t, [][]byte{attestation.NonSignerPubKeys[0].Serialize()}, attestationProto.GetNonSignerPubkeys())
+	assert.Equal(t, attestation.APKG2.Serialize(), attestationProto.GetApkG2())
+	assert.Equal(t, [][]byte{attestation.QuorumAPKs[0].Serialize(), attestation.QuorumAPKs[1].Serialize()}, attestationProto.GetQuorumApks())
+	assert.Equal(t, attestation.Sigma.Serialize(), attestationProto.GetSigma())
+	assert.Equal(t, []uint32{0, 1}, attestationProto.GetQuorumNumbers())
+	assert.Equal(t, []byte{80, 100}, attestationProto.GetQuorumSignedPercentages())
+	expectedCert, err := blobCert.ToProtobuf()
+	assert.NoError(t, err)
+	assert.Equal(t, expectedCert, reply.GetBlobVerificationInfo().GetBlobCertificate())
+	assert.Equal(t, uint32(7), reply.GetBlobVerificationInfo().GetBlobIndex())
+	assert.Equal(t, []byte("proof"), reply.GetBlobVerificationInfo().GetInclusionProof())
+}
+
+func TestV2GetBlobStatusLatestAttestedBatch(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+
+	data := make([]byte, 50)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+	commitments, err := prover.GetCommitments(data)
+	assert.NoError(t, err)
+	accountID, err := c.Signer.GetAccountID()
+	assert.NoError(t, err)
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: commitments,
+		QuorumNumbers:   []core.QuorumID{0},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         accountID,
+			BinIndex:          5,
+			CumulativePayment: big.NewInt(100),
+		},
+	}
+	blobKey, err := c.DispersalServerV2.StoreBlob(ctx, data, blobHeader, time.Now())
+	assert.NoError(t, err)
+	err = c.BlobMetadataStore.PutBlobCertificate(ctx, &corev2.BlobCertificate{
+		BlobHeader: blobHeader,
+		RelayKeys:  []corev2.RelayKey{0},
+	}, &encoding.FragmentInfo{})
+	assert.NoError(t, err)
+
+	// The blob is included in two batches, but only the older one is attested, e.g. because the dispersal of the
+	// newer one is still in progress.
+	olderBatch := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{1},
+		ReferenceBlockNumber: 100,
+	}
+	newerBatch := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{2},
+		ReferenceBlockNumber: 200,
+	}
+	for i, batchHeader := range []*corev2.BatchHeader{olderBatch, newerBatch} {
+		err = c.BlobMetadataStore.PutBatchHeader(ctx, batchHeader)
+		assert.NoError(t, err)
+		err = c.BlobMetadataStore.PutBlobVerificationInfo(ctx, &corev2.BlobVerificationInfo{
+			BatchHeader:    batchHeader,
+			BlobKey:        blobKey,
+			BlobIndex:      uint32(i),
+			InclusionProof: []byte("proof"),
+		})
+		assert.NoError(t, err)
+	}
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Encoded)
+	assert.NoError(t, err)
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Certified)
+	assert.NoError(t, err)
+
+	// Without any attestation, the status of the blob can't be reported
+	_, err = c.DispersalServerV2.GetBlobStatus(ctx, &pbv2.BlobStatusRequest{
+		BlobKey: blobKey[:],
+	})
+	assert.ErrorContains(t, err, "no attestation found for batch with reference block number 200")
+
+	keyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	err = c.BlobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
+		BatchHeader:      olderBatch,
+		AttestedAt:       uint64(time.Now().UnixNano()),
+		NonSignerPubKeys: []*core.G1Point{},
+		APKG2:            keyPair.GetPubKeyG2(),
+		QuorumAPKs: map[core.QuorumID]*core.G1Point{
+			0: core.NewG1Point(big.NewInt(5), big.NewInt(6)),
+		},
+		Sigma: &core.Signature{
+			G1Point: core.NewG1Point(big.NewInt(9), big.NewInt(10)),
+		},
+		QuorumNumbers: []core.QuorumID{0},
+		QuorumResults: map[core.QuorumID]uint8{
+			0: 100,
+		},
+	})
+	assert.NoError(t, err)
+
+	// The older batch is returned, since it is the only one with an attestation
+	reply, err := c.DispersalServerV2.GetBlobStatus(ctx, &pbv2.BlobStatusRequest{
+		BlobKey: blobKey[:],
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pbv2.BlobStatus_CERTIFIED, reply.GetStatus())
+	assert.Equal(t, olderBatch.ToProtobuf(), reply.GetSignedBatch().GetHeader())
+	assert.Equal(t, keyPair.GetPubKeyG2().Serialize(), reply.GetSignedBatch().GetNonSignerStakesAndSignature().GetApkG2())
+	assert.Equal(t, uint32(0), reply.GetBlobVerificationInfo().GetBlobIndex())
+}
+
+func TestV2StoreBlobDeduplication(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+
+	data := make([]byte, 50)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+	commitments, err := prover.GetCommitments(data)
+	assert.NoError(t, err)
+	accountID, err := c.Signer.GetAccountID()
+	assert.NoError(t, err)
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: commitments,
+		QuorumNumbers:   []core.QuorumID{0, 1},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         accountID,
+			BinIndex:          5,
+			CumulativePayment: big.NewInt(100),
+		},
+	}
+	blobKey, err := c.DispersalServerV2.StoreBlob(ctx, data, blobHeader, time.Now())
+	assert.NoError(t, err)
+	contentKey, err := dispv2.ComputeContentKey(blobHeader, data)
+	assert.NoError(t, err)
+
+	// the data is stored under the key of the content record rather than the key of the blob
+	content, err := c.BlobMetadataStore.GetBlobContent(ctx, contentKey)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), content.RefCount)
+	assert.NotEqual(t, blobKey, content.StorageKey)
+	storedData, err := c.BlobStore.GetBlob(ctx, content.StorageKey)
+	assert.NoError(t, err)
+	assert.Equal(t, data, storedData)
+	_, err = c.BlobStore.GetBlob(ctx, blobKey)
+	assert.ErrorIs(t, err, dispcommon.ErrBlobNotFound)
+
+	// a retried request returns the key of the stored blob
+	retriedKey, err := c.DispersalServerV2.StoreBlob(ctx, data, blobHeader, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, blobKey, retriedKey)
+	retriedContent, err := c.BlobMetadataStore.GetBlobContent(ctx, contentKey)
+	assert.NoError(t, err)
+	assert.Equal(t, content, retriedContent)
+
+	// a request with the same header but different data is rejected
+	otherData := make([]byte, 50)
+	_, err = rand.Read(otherData)
+	assert.NoError(t, err)
+	otherData = codec.ConvertByPaddingEmptyByte(otherData)
+	_, err = c.DispersalServerV2.StoreBlob(ctx, otherData, blobHeader, time.Now())
+	assert.ErrorContains(t, err, "different data")
+
+	// a blob with the same content but a different header reuses the stored data
+	otherHeader := &corev2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: commitments,
+		QuorumNumbers:   []core.QuorumID{0},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         accountID,
+			BinIndex:          6,
+			CumulativePayment: big.NewInt(200),
+		},
+	}
+	otherKey, err := c.DispersalServerV2.StoreBlob(ctx, data, otherHeader, time.Now())
+	assert.NoError(t, err)
+	assert.NotEqual(t, blobKey, otherKey)
+
+	blobMetadata, err := c.BlobMetadataStore.GetBlobMetadata(ctx, otherKey)
+	assert.NoError(t, err)
+	assert.Equal(t, &contentKey, blobMetadata.ContentKey)
+	assert.Equal(t, &content.StorageKey, blobMetadata.StorageKey)
+
+	content, err = c.BlobMetadataStore.GetBlobContent(ctx, contentKey)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), content.RefCount)
+}
+
+func TestV2ReapExpiredBlobs(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+
+	data := make([]byte, 50)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+	commitments, err := prover.GetCommitments(data)
+	assert.NoError(t, err)
+	accountID, err := c.Signer.GetAccountID()
+	assert.NoError(t, err)
+	newHeader := func(binIndex uint32) *corev2.BlobHeader {
+		return &corev2.BlobHeader{
+			BlobVersion:     0,
+			BlobCommitments: commitments,
+			QuorumNumbers:   []core.QuorumID{0, 1},
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID:         accountID,
+				BinIndex:          binIndex,
+				CumulativePayment: big.NewInt(100),
+			},
+		}
+	}
+	contentKey, err := dispv2.ComputeContentKey(newHeader(0), data)
+	assert.NoError(t, err)
+
+	// two blobs with the same content, the first of which is certified and expires first
+	now := time.Now()
+	blobKey1, err := c.DispersalServerV2.StoreBlob(ctx, data, newHeader(0), now)
+	assert.NoError(t, err)
+	blobKey2, err := c.DispersalServerV2.StoreBlob(ctx, data, newHeader(1), now.Add(time.Minute))
+	assert.NoError(t, err)
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey1, dispv2.Encoded)
+	assert.NoError(t, err)
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey1, dispv2.Certified)
+	assert.NoError(t, err)
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey2, dispv2.Failed)
+	assert.NoError(t, err)
+	content, err := c.BlobMetadataStore.GetBlobContent(ctx, contentKey)
+	assert.NoError(t, err)
+	metadata1, err := c.BlobMetadataStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+	expiry1 := time.Unix(int64(metadata1.Expiry), 0)
+
+	// the encoder stores the chunks and proofs of the content under its storage key
+	chunks := make([]byte, 100)
+	_, err = rand.Read(chunks)
+	assert.NoError(t, err)
+	err = c.S3Client.FragmentedUploadObject(ctx, s3BucketName, s3.ScopedChunkKey(content.StorageKey), chunks, 30)
+	assert.NoError(t, err)
+	err = c.S3Client.UploadObject(ctx, s3BucketName, s3.ScopedProofKey(content.StorageKey), chunks[:64])
+	assert.NoError(t, err)
+
+	// nothing has expired yet
+	err = c.DispersalServerV2.ReapExpiredBlobs(ctx, now)
+	assert.NoError(t, err)
+	_, err = c.BlobMetadataStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+
+	// the first blob releases its reference, but the data is still used by the second blob
+	err = c.DispersalServerV2.ReapExpiredBlobs(ctx, expiry1)
+	assert.NoError(t, err)
+	_, err = c.BlobMetadataStore.GetBlobMetadata(ctx, blobKey1)
+	assert.ErrorIs(t, err, dispcommon.ErrMetadataNotFound)
+	_, err = c.BlobMetadataStore.GetBlobMetadata(ctx, blobKey2)
+	assert.NoError(t, err)
+	fetchedContent, err := c.BlobMetadataStore.GetBlobContent(ctx, contentKey)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), fetchedContent.RefCount)
+	_, err = c.BlobStore.GetBlob(ctx, content.StorageKey)
+	assert.NoError(t, err)
+	_, err = c.S3Client.FragmentedDownloadObject(ctx, s3BucketName, s3.ScopedChunkKey(content.StorageKey), len(chunks), 30)
+	assert.NoError(t, err)
+	_, err = c.S3Client.DownloadObject(ctx, s3BucketName, s3.ScopedProofKey(content.StorageKey))
+	assert.NoError(t, err)
+
+	// the data, chunks and proofs are deleted with the last reference
+	err = c.DispersalServerV2.ReapExpiredBlobs(ctx, expiry1.Add(time.Minute))
+	assert.NoError(t, err)
+	_, err = c.BlobMetadataStore.GetBlobMetadata(ctx, blobKey2)
+	assert.ErrorIs(t, err, dispcommon.ErrMetadataNotFound)
+	_, err = c.BlobMetadataStore.GetBlobContent(ctx, contentKey)
+	assert.ErrorIs(t, err, dispcommon.ErrMetadataNotFound)
+	_, err = c.BlobStore.GetBlob(ctx, content.StorageKey)
+	assert.ErrorIs(t, err, dispcommon.ErrBlobNotFound)
+	fragments, err := c.S3Client.ListObjects(ctx, s3BucketName, s3.ScopedChunkKey(content.StorageKey))
+	assert.NoError(t, err)
+	assert.Empty(t, fragments)
+	_, err = c.S3Client.DownloadObject(ctx, s3BucketName, s3.ScopedProofKey(content.StorageKey))
+	assert.ErrorIs(t, err, s3.ErrObjectNotFound)
+
+	// the content can be stored again
+	_, err = c.DispersalServerV2.StoreBlob(ctx, data, newHeader(2), now)
+	assert.NoError(t, err)
+	fetchedContent, err = c.BlobMetadataStore.GetBlobContent(ctx, contentKey)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), fetchedContent.RefCount)
+	assert.NotEqual(t, content.StorageKey, fetchedContent.StorageKey)
 }
 
 func TestV2GetBlobCommitment(t *testing.T) {
 	c := newTestServerV2(t)
-	_, err := c.DispersalServerV2.GetBlobCommitment(context.Background(), &pbv2.BlobCommitmentRequest{
-		Data: []byte{1},
+	ctx := peer.NewContext(context.Background(), c.Peer)
+	data := make([]byte, 50)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	reply, err := c.DispersalServerV2.GetBlobCommitment(ctx, &pbv2.BlobCommitmentRequest{
+		Data: data,
+	})
+	assert.NoError(t, err)
+	commitments, err := prover.GetCommitments(data)
+	assert.NoError(t, err)
+	commitmentsProto, err := commitments.ToProtobuf()
+	assert.NoError(t, err)
+	assert.Equal(t, commitmentsProto.GetCommitment(), reply.GetBlobCommitment().GetCommitment())
+	assert.Equal(t, commitmentsProto.GetLengthCommitment(), reply.GetBlobCommitment().GetLengthCommitment())
+	assert.Equal(t, commitmentsProto.GetLengthProof(), reply.GetBlobCommitment().GetLengthProof())
+	assert.Equal(t, commitmentsProto.GetLength(), reply.GetBlobCommitment().GetLength())
+}
+
+func TestV2GetBlobCommitmentRequestValidation(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+
+	// empty data
+	_, err := c.DispersalServerV2.GetBlobCommitment(ctx, &pbv2.BlobCommitmentRequest{})
+	assert.ErrorContains(t, err, "data is empty")
+
+	// data exceeding the maximum blob size
+	_, err = c.DispersalServerV2.GetBlobCommitment(ctx, &pbv2.BlobCommitmentRequest{
+		Data: make([]byte, 100*encoding.BYTES_PER_SYMBOL+1),
+	})
+	assert.ErrorContains(t, err, "blob size cannot exceed")
+
+	// data which is not a valid sequence of field elements
+	data := make([]byte, 32)
+	for i := range data {
+		data[i] = 0xff
+	}
+	_, err = c.DispersalServerV2.GetBlobCommitment(ctx, &pbv2.BlobCommitmentRequest{
+		Data: data,
+	})
+	assert.ErrorContains(t, err, "valid field element")
+}
+
+func TestV2GetBlobCommitmentRateLimit(t *testing.T) {
+	globalParams := common.GlobalRateParams{
+		BucketSizes: []time.Duration{time.Second},
+		Multipliers: []float32{1},
+		CountFailed: true,
+	}
+	bucketStore, err := store.NewLocalParamStore[common.RateBucketParams](1000)
+	assert.NoError(t, err)
+	ratelimiter := ratelimit.NewRateLimiter(prometheus.NewRegistry(), globalParams, bucketStore, logging.NewNoopLogger())
+	rateConfig := apiserver.RateConfig{
+		CommitmentBlobRate:   2 * 1e6,
+		CommitmentThroughput: 1024 * 1024,
+	}
+	c := newTestServerV2WithRateLimiter(t, ratelimiter, rateConfig)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+
+	data := make([]byte, 50)
+	_, err = rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	// The bucket holds one second worth of requests, the third request exceeds the blob rate.
+	for i := 0; i < 2; i++ {
+		_, err = c.DispersalServerV2.GetBlobCommitment(ctx, &pbv2.BlobCommitmentRequest{
+			Data: data,
+		})
+		assert.NoError(t, err)
+	}
+	_, err = c.DispersalServerV2.GetBlobCommitment(ctx, &pbv2.BlobCommitmentRequest{
+		Data: data,
+	})
+	assert.ErrorContains(t, err, "request ratelimited: Commitment blob rate limit")
+}
+
+func TestV2GetPaymentState(t *testing.T) {
+	mockState := &mock.MockOnchainPaymentState{}
+	mockState.On("GetPricePerSymbol").Return(uint32(encoding.BYTES_PER_SYMBOL))
+	mockState.On("GetMinNumSymbols").Return(uint32(128))
+	mockState.On("GetGlobalSymbolsPerSecond").Return(uint64(4096))
+	mockState.On("GetOnDemandQuorumNumbers").Return([]uint8{0, 1}, nil)
+	mockState.On("GetReservationWindow").Return(uint32(60))
+	mockState.On("GetOnDemandPaymentByAccount", tmock.Anything, tmock.Anything).Return(core.OnDemandPayment{
+		CumulativePayment: big.NewInt(3000),
+	}, nil)
+	mockState.On("GetActiveReservationByAccount", tmock.Anything, tmock.Anything).Return(core.ActiveReservation{
+		SymbolsPerSec:  2048,
+		StartTimestamp: 10,
+		EndTimestamp:   math.MaxUint32,
+		QuorumNumbers:  []uint8{0, 1},
+		QuorumSplit:    []byte{50, 50},
+	}, nil)
+
+	awsConfig := aws.ClientConfig{
+		Region:          "us-east-1",
+		AccessKey:       "localstack",
+		SecretAccessKey: "localstack",
+		EndpointURL:     fmt.Sprintf("http://0.0.0.0:%s", localStackPort),
+	}
+	tableNames := []string{"reservations_server_v2_payment_state", "ondemand_server_v2_payment_state", "global_server_v2_payment_state"}
+	err := meterer.CreateReservationTable(awsConfig, tableNames[0])
+	assert.NoError(t, err)
+	err = meterer.CreateOnDemandTable(awsConfig, tableNames[1])
+	assert.NoError(t, err)
+	err = meterer.CreateGlobalReservationTable(awsConfig, tableNames[2])
+	assert.NoError(t, err)
+	offchainStore, err := meterer.NewOffchainStore(awsConfig, tableNames[0], tableNames[1], tableNames[2], logging.NewNoopLogger())
+	assert.NoError(t, err)
+	mt := meterer.NewMeterer(meterer.Config{}, mockState, offchainStore, logging.NewNoopLogger())
+
+	c := newTestServerV2WithMeterer(t, mt, nil, apiserver.RateConfig{})
+	ctx := context.Background()
+	accountID, err := c.Signer.GetAccountID()
+	assert.NoError(t, err)
+
+	// Record some usage for the account
+	currentBinIndex := meterer.GetBinIndex(uint64(time.Now().Unix()), 60)
+	_, err = offchainStore.UpdateReservationBin(ctx, accountID, uint64(currentBinIndex-5), 100)
+	assert.NoError(t, err)
+	_, err = offchainStore.UpdateReservationBin(ctx, accountID, uint64(currentBinIndex), 200)
+	assert.NoError(t, err)
+	err = offchainStore.AddOnDemandPayment(ctx, core.PaymentMetadata{
+		AccountID:         accountID,
+		CumulativePayment: big.NewInt(1500),
+	}, 128)
+	assert.NoError(t, err)
+
+	timestamp := uint64(time.Now().UnixNano())
+	signature, err := c.Signer.SignPaymentStateRequest(timestamp)
+	assert.NoError(t, err)
+	reply, err := c.DispersalServerV2.GetPaymentState(ctx, &pbv2.GetPaymentStateRequest{
+		AccountId: accountID,
+		Signature: signature,
+		Timestamp: timestamp,
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, &pbv2.PaymentGlobalParams{
+		GlobalSymbolsPerSecond: 4096,
+		MinNumSymbols:          128,
+		PricePerSymbol:         encoding.BYTES_PER_SYMBOL,
+		ReservationWindow:      60,
+		OnDemandQuorumNumbers:  []uint32{0, 1},
+	}, reply.GetPaymentGlobalParams())
+	// Only the bins relevant to the current period are returned
+	assert.Len(t, reply.GetBinRecord(), 1)
+	assert.Equal(t, currentBinIndex, reply.GetBinRecord()[0].GetIndex())
+	assert.Equal(t, uint64(200), reply.GetBinRecord()[0].GetUsage())
+	assert.Equal(t, uint64(2048), reply.GetReservation().GetSymbolsPerSecond())
+	assert.Equal(t, uint64(10), reply.GetReservation().GetStartTimestamp())
+	assert.Equal(t, uint64(math.MaxUint32), reply.GetReservation().GetEndTimestamp())
+	assert.Equal(t, []uint32{0, 1}, reply.GetReservation().GetQuorumNumbers())
+	assert.Equal(t, []uint32{50, 50}, reply.GetReservation().GetQuorumSplits())
+	assert.Equal(t, big.NewInt(1500), new(big.Int).SetBytes(reply.GetCumulativePayment()))
+	assert.Equal(t, big.NewInt(3000), new(big.Int).SetBytes(reply.GetOnchainCumulativePayment()))
+
+	// The request must be signed by the account
+	otherSigner := auth.NewLocalBlobRequestSigner("0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcded")
+	otherSignature, err := otherSigner.SignPaymentStateRequest(timestamp)
+	assert.NoError(t, err)
+	_, err = c.DispersalServerV2.GetPaymentState(ctx, &pbv2.GetPaymentStateRequest{
+		AccountId: accountID,
+		Signature: otherSignature,
+		Timestamp: timestamp,
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.ErrorContains(t, err, "authentication failed")
+
+	// The signature must cover the timestamp of the request
+	_, err = c.DispersalServerV2.GetPaymentState(ctx, &pbv2.GetPaymentStateRequest{
+		AccountId: accountID,
+		Signature: signature,
+		Timestamp: timestamp + 1,
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// A stale request can't be replayed
+	staleTimestamp := uint64(time.Now().Add(-2 * time.Minute).UnixNano())
+	staleSignature, err := c.Signer.SignPaymentStateRequest(staleTimestamp)
+	assert.NoError(t, err)
+	_, err = c.DispersalServerV2.GetPaymentState(ctx, &pbv2.GetPaymentStateRequest{
+		AccountId: accountID,
+		Signature: staleSignature,
+		Timestamp: staleTimestamp,
+	})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.ErrorContains(t, err, "request timestamp")
+
+	_, err = c.DispersalServerV2.GetPaymentState(ctx, &pbv2.GetPaymentStateRequest{
+		Signature: signature,
+	})
+	assert.ErrorContains(t, err, "account id is empty")
+}
+
+func TestV2GetPaymentStateWithoutMeterer(t *testing.T) {
+	c := newTestServerV2(t)
+	accountID, err := c.Signer.GetAccountID()
+	assert.NoError(t, err)
+	timestamp := uint64(time.Now().UnixNano())
+	signature, err := c.Signer.SignPaymentStateRequest(timestamp)
+	assert.NoError(t, err)
+
+	_, err = c.DispersalServerV2.GetPaymentState(context.Background(), &pbv2.GetPaymentStateRequest{
+		AccountId: accountID,
+		Signature: signature,
+		Timestamp: timestamp,
 	})
-	assert.ErrorContains(t, err, "not implemented")
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
 }
 
 func newTestServerV2(t *testing.T) *testComponents {
+	return newTestServerV2WithRateLimiter(t, nil, apiserver.RateConfig{})
+}
+
+func newTestServerV2WithRateLimiter(t *testing.T, ratelimiter common.RateLimiter, rateConfig apiserver.RateConfig) *testComponents {
+	return newTestServerV2WithMeterer(t, nil, ratelimiter, rateConfig)
+}
+
+func newTestServerV2WithMeterer(t *testing.T, mt *meterer.Meterer, ratelimiter common.RateLimiter, rateConfig apiserver.RateConfig) *testComponents {
 	logger := logging.NewNoopLogger()
 	// logger, err := common.NewLogger(common.DefaultLoggerConfig())
 	// if err != nil {
@@ -235,7 +846,6 @@ func newTestServerV2(t *testing.T) *testComponents {
 	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, v2MetadataTableName)
 	blobStore := blobstore.NewBlobStore(s3BucketName, s3Client, logger)
 	chainReader := &mock.MockWriter{}
-	rateConfig := apiserver.RateConfig{}
 
 	chainReader.On("GetCurrentBlockNumber").Return(uint32(100), nil)
 	chainReader.On("GetQuorumCount").Return(uint8(2), nil)
@@ -246,7 +856,7 @@ func newTestServerV2(t *testing.T) *testComponents {
 	s := apiserver.NewDispersalServerV2(disperser.ServerConfig{
 		GrpcPort:    "51002",
 		GrpcTimeout: 1 * time.Second,
-	}, rateConfig, blobStore, blobMetadataStore, chainReader, nil, auth.NewAuthenticator(), 100, time.Hour, logger)
+	}, rateConfig, blobStore, blobMetadataStore, chainReader, mt, ratelimiter, auth.NewAuthenticator(), prover, 100, time.Hour, logger)
 
 	err = s.RefreshOnchainState(context.Background())
 	assert.NoError(t, err)
@@ -262,6 +872,7 @@ func newTestServerV2(t *testing.T) *testComponents {
 		DispersalServerV2: s,
 		BlobStore:         blobStore,
 		BlobMetadataStore: blobMetadataStore,
+		S3Client:          s3Client,
 		ChainReader:       chainReader,
 		Signer:            signer,
 		Peer:              p,
@@ -0,0 +1,230 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	pbv2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigenda/disperser/common/notifier"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// newStatusNotifier creates the notifier of the v1 blob status subscriptions. The status of all the subscribed blobs
+// is read from the blob store with a single bulk read per poll interval.
+func newStatusNotifier(
+	config disperser.ServerConfig,
+	store disperser.BlobStore,
+	logger logging.Logger,
+) *notifier.Notifier[disperser.BlobKey, *disperser.BlobMetadata] {
+	fetch := func(ctx context.Context, keys []disperser.BlobKey) (map[disperser.BlobKey]*disperser.BlobMetadata, error) {
+		metadata, err := store.GetBulkBlobMetadata(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[disperser.BlobKey]*disperser.BlobMetadata, len(metadata))
+		for _, m := range metadata {
+			if m != nil {
+				result[m.GetBlobKey()] = snapshotBlobMetadata(m)
+			}
+		}
+		return result, nil
+	}
+	return notifier.NewNotifier(statusNotifierConfig(config), fetch, blobStatusUnchanged, logger)
+}
+
+// snapshotBlobMetadata copies the parts of the blob metadata which are compared by blobStatusUnchanged. Blob stores
+// may return metadata that is later updated in place, which would hide the transition from the previous value.
+func snapshotBlobMetadata(metadata *disperser.BlobMetadata) *disperser.BlobMetadata {
+	snapshot := *metadata
+	if metadata.ConfirmationInfo != nil {
+		confirmationInfo := *metadata.ConfirmationInfo
+		snapshot.ConfirmationInfo = &confirmationInfo
+	}
+	return &snapshot
+}
+
+// newStatusNotifierV2 creates the notifier of the v2 blob status subscriptions. The status of all the subscribed
+// blobs is read from the metadata store with a single bulk read per poll interval.
+func newStatusNotifierV2(
+	config disperser.ServerConfig,
+	store *blobstore.BlobMetadataStore,
+	logger logging.Logger,
+) *notifier.Notifier[corev2.BlobKey, *dispv2.BlobMetadata] {
+	fetch := func(ctx context.Context, keys []corev2.BlobKey) (map[corev2.BlobKey]*dispv2.BlobMetadata, error) {
+		metadata, err := store.GetBulkBlobMetadata(ctx, keys)
+		if err != nil {
+			return nil, err
+		}
+		result := make(map[corev2.BlobKey]*dispv2.BlobMetadata, len(metadata))
+		for _, m := range metadata {
+			blobKey, err := m.BlobHeader.BlobKey()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get blob key: %w", err)
+			}
+			result[blobKey] = m
+		}
+		return result, nil
+	}
+	unchanged := func(a, b *dispv2.BlobMetadata) bool {
+		return a.BlobStatus == b.BlobStatus
+	}
+	return notifier.NewNotifier(statusNotifierConfig(config), fetch, unchanged, logger)
+}
+
+func statusNotifierConfig(config disperser.ServerConfig) notifier.Config {
+	return notifier.Config{
+		PollInterval:     config.StatusPollInterval,
+		MaxSubscriptions: config.MaxStatusSubscriptions,
+	}
+}
+
+// blobStatusUnchanged reports whether the two metadata of a blob result in the same status reply. Besides the
+// status, the confirmation block number of a confirmed blob changes if its confirmation transaction is reorged.
+func blobStatusUnchanged(a, b *disperser.BlobMetadata) bool {
+	if getResponseStatus(a.BlobStatus) != getResponseStatus(b.BlobStatus) {
+		return false
+	}
+	if a.ConfirmationInfo == nil || b.ConfirmationInfo == nil {
+		return a.ConfirmationInfo == b.ConfirmationInfo
+	}
+	return a.ConfirmationInfo.ConfirmationBlockNumber == b.ConfirmationInfo.ConfirmationBlockNumber
+}
+
+// isFinalBlobStatus reports whether a v1 blob status never changes anymore.
+func isFinalBlobStatus(status disperser.BlobStatus) bool {
+	return status == disperser.Finalized || status == disperser.Failed || status == disperser.InsufficientSignatures
+}
+
+// isFinalBlobStatusV2 reports whether a v2 blob status never changes anymore.
+func isFinalBlobStatusV2(status dispv2.BlobStatus) bool {
+	return status == dispv2.Certified || status == dispv2.Failed || status == dispv2.InsufficientSignatures
+}
+
+// SubscribeBlobStatus streams the status of a blob: the current status is sent first, followed by every status
+// transition until the blob reaches a final status.
+func (s *DispersalServer) SubscribeBlobStatus(req *pb.BlobStatusRequest, stream pb.Disperser_SubscribeBlobStatusServer) error {
+	ctx := stream.Context()
+
+	requestID := req.GetRequestId()
+	if len(requestID) == 0 {
+		s.metrics.HandleInvalidArgRpcRequest("SubscribeBlobStatus")
+		s.metrics.HandleInvalidArgRequest("SubscribeBlobStatus")
+		return api.NewErrorInvalidArg("request_id must not be empty")
+	}
+
+	blobKey, err := disperser.ParseBlobKey(string(requestID))
+	if err != nil {
+		s.metrics.HandleInvalidArgRpcRequest("SubscribeBlobStatus")
+		s.metrics.HandleInvalidArgRequest("SubscribeBlobStatus")
+		return api.NewErrorInvalidArg(fmt.Sprintf("failed to parse the requestID: %s", err.Error()))
+	}
+
+	// Subscribe before reading the current status, so that no transition is missed in between.
+	sub, err := s.statusNotifier.Subscribe(blobKey)
+	if err != nil {
+		s.metrics.HandleRateLimitedRpcRequest("SubscribeBlobStatus")
+		return api.NewErrorResourceExhausted("too many status subscriptions, use GetBlobStatus instead")
+	}
+	defer sub.Close()
+
+	s.logger.Debug("received a new blob status subscription", "blobKey", blobKey.String())
+	metadata, err := s.blobStore.GetBlobMetadata(ctx, blobKey)
+	if err != nil {
+		if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+			s.metrics.HandleNotFoundRpcRequest("SubscribeBlobStatus")
+			s.metrics.HandleNotFoundRequest("SubscribeBlobStatus")
+			return api.NewErrorNotFound("no metadata found for the requestID")
+		}
+		s.metrics.HandleInternalFailureRpcRequest("SubscribeBlobStatus")
+		return api.NewErrorInternal(fmt.Sprintf("failed to get blob metadata, blobkey: %s", blobKey.String()))
+	}
+	metadata = snapshotBlobMetadata(metadata)
+
+	var sent *disperser.BlobMetadata
+	for {
+		if sent == nil || !blobStatusUnchanged(sent, metadata) {
+			reply, err := s.blobStatusReply(metadata)
+			if err != nil {
+				s.metrics.HandleInternalFailureRpcRequest("SubscribeBlobStatus")
+				return err
+			}
+			if err := stream.Send(reply); err != nil {
+				return err
+			}
+			sent = metadata
+		}
+
+		if isFinalBlobStatus(metadata.BlobStatus) {
+			s.metrics.HandleSuccessfulRpcRequest("SubscribeBlobStatus")
+			return nil
+		}
+
+		select {
+		case metadata = <-sub.Updates():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// SubscribeBlobStatus streams the status of a blob: the current status is sent first, followed by every status
+// transition until the blob reaches a final status.
+func (s *DispersalServerV2) SubscribeBlobStatus(req *pbv2.BlobStatusRequest, stream pbv2.Disperser_SubscribeBlobStatusServer) error {
+	ctx := stream.Context()
+
+	if len(req.GetBlobKey()) != 32 {
+		return api.NewErrorInvalidArg("invalid blob key")
+	}
+	blobKey, err := corev2.BytesToBlobKey(req.GetBlobKey())
+	if err != nil {
+		return api.NewErrorInvalidArg(fmt.Sprintf("invalid blob key: %s", err.Error()))
+	}
+
+	// Subscribe before reading the current status, so that no transition is missed in between.
+	sub, err := s.statusNotifier.Subscribe(blobKey)
+	if err != nil {
+		return api.NewErrorResourceExhausted("too many status subscriptions, use GetBlobStatus instead")
+	}
+	defer sub.Close()
+
+	metadata, err := s.blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	if err != nil {
+		if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+			return api.NewErrorNotFound("no such blob found")
+		}
+		s.logger.Error("failed to get blob metadata", "err", err, "blobKey", blobKey.Hex())
+		return api.NewErrorInternal(fmt.Sprintf("failed to get blob metadata: %s", err.Error()))
+	}
+
+	var sent *dispv2.BlobMetadata
+	for {
+		if sent == nil || sent.BlobStatus != metadata.BlobStatus {
+			reply, err := s.blobStatusReply(ctx, blobKey, metadata)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(reply); err != nil {
+				return err
+			}
+			sent = metadata
+		}
+
+		if isFinalBlobStatusV2(metadata.BlobStatus) {
+			return nil
+		}
+
+		select {
+		case metadata = <-sub.Updates():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
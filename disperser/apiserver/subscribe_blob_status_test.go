@@ -0,0 +1,269 @@
+package apiserver
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	pbv2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// fakeStatusStream is the server side of a SubscribeBlobStatus stream, for either version of the API.
+type fakeStatusStream[T any] struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies chan T
+}
+
+func newFakeStatusStream[T any](ctx context.Context) *fakeStatusStream[T] {
+	return &fakeStatusStream[T]{ctx: ctx, replies: make(chan T, 10)}
+}
+
+func (s *fakeStatusStream[T]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeStatusStream[T]) Send(reply T) error {
+	s.replies <- reply
+	return nil
+}
+
+func (s *fakeStatusStream[T]) requireReply(t *testing.T) T {
+	t.Helper()
+	select {
+	case reply := <-s.replies:
+		return reply
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for status reply")
+	}
+	var zero T
+	return zero
+}
+
+func (s *fakeStatusStream[T]) requireNoReply(t *testing.T) {
+	t.Helper()
+	select {
+	case reply := <-s.replies:
+		t.Fatalf("unexpected status reply %v", reply)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func requireStreamResult(t *testing.T, result chan error, code codes.Code) {
+	t.Helper()
+	select {
+	case err := <-result:
+		if code == codes.OK {
+			require.NoError(t, err)
+		} else {
+			requireCode(t, err, code)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the stream to end")
+	}
+}
+
+func newStatusTestServer(t *testing.T, maxSubscriptions int) (*DispersalServer, *inmem.BlobStore) {
+	logger := logging.NewNoopLogger()
+	store := inmem.NewBlobStore().(*inmem.BlobStore)
+	s := NewDispersalServer(
+		disperser.ServerConfig{MaxStatusSubscriptions: maxSubscriptions},
+		store,
+		nil,
+		logger,
+		disperser.NewMetrics(prometheus.NewRegistry(), "9100", logger),
+		nil,
+		nil,
+		RateConfig{},
+		1024,
+	)
+	return s, store
+}
+
+func TestSubscribeBlobStatus(t *testing.T) {
+	ctx := context.Background()
+	s, store := newStatusTestServer(t, 0)
+
+	blobKey, err := store.StoreBlob(ctx, &core.Blob{
+		RequestHeader: core.BlobRequestHeader{SecurityParams: []*core.SecurityParam{{QuorumID: 0}}},
+		Data:          []byte{1, 2, 3},
+	}, uint64(time.Now().UnixNano()))
+	require.NoError(t, err)
+
+	stream := newFakeStatusStream[*pb.BlobStatusReply](ctx)
+	result := make(chan error, 1)
+	go func() {
+		result <- s.SubscribeBlobStatus(&pb.BlobStatusRequest{RequestId: []byte(blobKey.String())}, stream)
+	}()
+
+	// The current status is sent first
+	assert.Equal(t, pb.BlobStatus_PROCESSING, stream.requireReply(t).GetStatus())
+	assert.Equal(t, 1, s.statusNotifier.NumSubscriptions())
+
+	// Transitions which don't change the reported status aren't sent
+	require.NoError(t, store.MarkBlobDispersing(ctx, blobKey))
+	require.NoError(t, s.statusNotifier.Poll(ctx))
+	stream.requireNoReply(t)
+
+	_, _, g1Gen, _ := bn254.Generators()
+	metadata, err := store.GetBlobMetadata(ctx, blobKey)
+	require.NoError(t, err)
+	confirmationInfo := &disperser.ConfirmationInfo{
+		BatchHeaderHash:         [32]byte{1},
+		BlobIndex:               3,
+		ReferenceBlockNumber:    100,
+		BatchRoot:               []byte("root"),
+		BlobInclusionProof:      []byte("proof"),
+		BlobCommitment:          &encoding.BlobCommitments{Commitment: (*encoding.G1Commitment)(&g1Gen), Length: 16},
+		BatchID:                 7,
+		ConfirmationBlockNumber: 150,
+		Fee:                     []byte{0},
+		QuorumResults:           map[core.QuorumID]*core.QuorumResult{0: {QuorumID: 0, PercentSigned: 100}},
+		BlobQuorumInfos:         []*core.BlobQuorumInfo{{SecurityParam: core.SecurityParam{QuorumID: 0}, ChunkLength: 8}},
+	}
+	_, err = store.MarkBlobConfirmed(ctx, metadata, confirmationInfo)
+	require.NoError(t, err)
+	require.NoError(t, s.statusNotifier.Poll(ctx))
+	reply := stream.requireReply(t)
+	assert.Equal(t, pb.BlobStatus_CONFIRMED, reply.GetStatus())
+	assert.Equal(t, uint32(7), reply.GetInfo().GetBlobVerificationProof().GetBatchId())
+	assert.Equal(t, uint32(150), reply.GetInfo().GetBlobVerificationProof().GetBatchMetadata().GetConfirmationBlockNumber())
+
+	// Polling again without changes doesn't send anything
+	require.NoError(t, s.statusNotifier.Poll(ctx))
+	stream.requireNoReply(t)
+
+	// A reorged confirmation is sent again with the new confirmation block number
+	metadata, err = store.GetBlobMetadata(ctx, blobKey)
+	require.NoError(t, err)
+	require.NoError(t, store.UpdateConfirmationBlockNumber(ctx, metadata, 155))
+	require.NoError(t, s.statusNotifier.Poll(ctx))
+	reply = stream.requireReply(t)
+	assert.Equal(t, pb.BlobStatus_CONFIRMED, reply.GetStatus())
+	assert.Equal(t, uint32(155), reply.GetInfo().GetBlobVerificationProof().GetBatchMetadata().GetConfirmationBlockNumber())
+
+	// The stream ends once the blob is finalized
+	require.NoError(t, store.MarkBlobFinalized(ctx, blobKey))
+	require.NoError(t, s.statusNotifier.Poll(ctx))
+	assert.Equal(t, pb.BlobStatus_FINALIZED, stream.requireReply(t).GetStatus())
+	requireStreamResult(t, result, codes.OK)
+	assert.Equal(t, 0, s.statusNotifier.NumSubscriptions())
+}
+
+func TestSubscribeBlobStatusErrors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s, store := newStatusTestServer(t, 1)
+
+	stream := newFakeStatusStream[*pb.BlobStatusReply](ctx)
+	err := s.SubscribeBlobStatus(&pb.BlobStatusRequest{}, stream)
+	requireCode(t, err, codes.InvalidArgument)
+	err = s.SubscribeBlobStatus(&pb.BlobStatusRequest{RequestId: []byte("invalid")}, stream)
+	requireCode(t, err, codes.InvalidArgument)
+
+	blobKey, err := store.StoreBlob(ctx, &core.Blob{Data: []byte{1}}, uint64(time.Now().UnixNano()))
+	require.NoError(t, err)
+	request := &pb.BlobStatusRequest{RequestId: []byte(blobKey.String())}
+
+	result := make(chan error, 1)
+	go func() {
+		result <- s.SubscribeBlobStatus(request, stream)
+	}()
+	assert.Equal(t, pb.BlobStatus_PROCESSING, stream.requireReply(t).GetStatus())
+
+	// The subscription limit is reached
+	err = s.SubscribeBlobStatus(request, newFakeStatusStream[*pb.BlobStatusReply](ctx))
+	requireCode(t, err, codes.ResourceExhausted)
+
+	// Cancelling the stream closes the subscription
+	cancel()
+	select {
+	case err := <-result:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the stream to end")
+	}
+	assert.Equal(t, 0, s.statusNotifier.NumSubscriptions())
+}
+
+func TestSubscribeBlobStatusV2(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewNoopLogger()
+
+	dynamoClient, err := commondynamodb.NewLocalClient(t.TempDir(), logger)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = dynamoClient.Shutdown()
+	})
+	require.NoError(t, dynamoClient.CreateTable(ctx, blobstore.GenerateTableSchema("blob-metadata", 10, 10)))
+	metadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, "blob-metadata")
+	s := NewDispersalServerV2(disperser.ServerConfig{}, RateConfig{}, nil, metadataStore, nil, nil, nil, nil, nil, 0, time.Minute, logger)
+
+	// Unknown and invalid blob keys
+	stream := newFakeStatusStream[*pbv2.BlobStatusReply](ctx)
+	err = s.SubscribeBlobStatus(&pbv2.BlobStatusRequest{BlobKey: []byte{1}}, stream)
+	requireCode(t, err, codes.InvalidArgument)
+	err = s.SubscribeBlobStatus(&pbv2.BlobStatusRequest{BlobKey: make([]byte, 32)}, stream)
+	requireCode(t, err, codes.NotFound)
+	assert.Equal(t, 0, s.statusNotifier.NumSubscriptions())
+
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion: 0,
+		BlobCommitments: encoding.BlobCommitments{
+			Commitment:       (*encoding.G1Commitment)(&g1Gen),
+			LengthCommitment: (*encoding.G2Commitment)(&g2Gen),
+			LengthProof:      (*encoding.G2Commitment)(&g2Gen),
+			Length:           16,
+		},
+		QuorumNumbers: []core.QuorumID{0},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         "0x1234",
+			CumulativePayment: big.NewInt(100),
+		},
+	}
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	require.NoError(t, metadataStore.PutBlobMetadata(ctx, &dispv2.BlobMetadata{
+		BlobHeader: blobHeader,
+		BlobStatus: dispv2.Queued,
+		Expiry:     uint64(time.Now().Add(time.Hour).Unix()),
+		UpdatedAt:  uint64(time.Now().UnixNano()),
+	}))
+
+	result := make(chan error, 1)
+	go func() {
+		result <- s.SubscribeBlobStatus(&pbv2.BlobStatusRequest{BlobKey: blobKey[:]}, stream)
+	}()
+	assert.Equal(t, pbv2.BlobStatus_QUEUED, stream.requireReply(t).GetStatus())
+
+	require.NoError(t, s.statusNotifier.Poll(ctx))
+	stream.requireNoReply(t)
+
+	require.NoError(t, metadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Encoded))
+	require.NoError(t, s.statusNotifier.Poll(ctx))
+	assert.Equal(t, pbv2.BlobStatus_ENCODED, stream.requireReply(t).GetStatus())
+
+	require.NoError(t, metadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Failed))
+	require.NoError(t, s.statusNotifier.Poll(ctx))
+	assert.Equal(t, pbv2.BlobStatus_FAILED, stream.requireReply(t).GetStatus())
+	requireStreamResult(t, result, codes.OK)
+	assert.Equal(t, 0, s.statusNotifier.NumSubscriptions())
+}
@@ -3,6 +3,7 @@ package batcher
 import (
 	"bytes"
 	"context"
+	"encoding/gob"
 	"errors"
 	"fmt"
 	"math"
@@ -12,6 +13,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -64,6 +66,12 @@ type Config struct {
 
 	TargetNumChunks          uint
 	MaxBlobsToFetchFromStore int
+
+	// TxnStorePath is the directory in which pending transactions are persisted. They are kept in memory only if empty.
+	TxnStorePath string
+	// CancelTxnAfterSpeedUps is the number of speed ups after which a stuck transaction is cancelled. Cancellation
+	// is disabled if 0.
+	CancelTxnAfterSpeedUps int
 }
 
 type Batcher struct {
@@ -85,6 +93,7 @@ type Batcher struct {
 
 	ethClient common.EthClient
 	finalizer Finalizer
+	eventSink events.Sink
 	logger    logging.Logger
 }
 
@@ -104,6 +113,7 @@ func NewBatcher(
 	logger logging.Logger,
 	metrics *Metrics,
 	heartbeatChan chan time.Time,
+	eventSink events.Sink,
 ) (*Batcher, error) {
 	batchTrigger := NewEncodedSizeNotifier(
 		make(chan struct{}, 1),
@@ -142,6 +152,7 @@ func NewBatcher(
 
 		ethClient:     ethClient,
 		finalizer:     finalizer,
+		eventSink:     eventSink,
 		logger:        logger.With("component", "Batcher"),
 		HeartbeatChan: heartbeatChan,
 	}, nil
@@ -150,6 +161,19 @@ func NewBatcher(
 func (b *Batcher) RecoverState(ctx context.Context) error {
 	b.logger.Info("Recovering state...")
 	start := time.Now()
+	// The blobs of the batches whose confirmation transaction was pending are left dispersing, since the outcome of
+	// the transaction is received once the transaction manager starts
+	confirming := make(map[disperser.BlobKey]struct{})
+	for _, metadata := range b.TransactionManager.RecoverTransactions(ctx) {
+		pending, err := decodePendingConfirmation(metadata)
+		if err != nil {
+			b.logger.Error("failed to decode recovered confirmation metadata", "err", err)
+			continue
+		}
+		for _, key := range pending.BlobKeys {
+			confirming[key] = struct{}{}
+		}
+	}
 	metas, err := b.Queue.GetBlobMetadataByStatus(ctx, disperser.Dispersing)
 	if err != nil {
 		return fmt.Errorf("failed to get blobs in dispersing state: %w", err)
@@ -157,6 +181,9 @@ func (b *Batcher) RecoverState(ctx context.Context) error {
 	expired := 0
 	processing := 0
 	for _, meta := range metas {
+		if _, ok := confirming[meta.GetBlobKey()]; ok {
+			continue
+		}
 		if meta.Expiry == 0 || meta.Expiry < uint64(time.Now().Unix()) {
 			err = b.Queue.MarkBlobFailed(ctx, meta.GetBlobKey())
 			if err != nil {
@@ -171,7 +198,7 @@ func (b *Batcher) RecoverState(ctx context.Context) error {
 			processing += 1
 		}
 	}
-	b.logger.Info("Recovering state took", "duration", time.Since(start), "numBlobs", len(metas), "expired", expired, "processing", processing)
+	b.logger.Info("Recovering state took", "duration", time.Since(start), "numBlobs", len(metas), "expired", expired, "processing", processing, "confirming", len(confirming))
 	return nil
 }
 
@@ -322,8 +349,12 @@ func (b *Batcher) updateConfirmationInfo(
 		}
 
 		if status == disperser.Confirmed {
-			if _, updateConfirmationInfoErr = b.Queue.MarkBlobConfirmed(ctx, metadata, confirmationInfo); updateConfirmationInfoErr == nil {
+			var confirmedMetadata *disperser.BlobMetadata
+			if confirmedMetadata, updateConfirmationInfoErr = b.Queue.MarkBlobConfirmed(ctx, metadata, confirmationInfo); updateConfirmationInfoErr == nil {
 				b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.Confirmed)
+				event := newBlobEvent(events.BlobConfirmed, confirmedMetadata)
+				event.ConfirmationBlockNumber = confirmedMetadata.ConfirmationInfo.ConfirmationBlockNumber
+				b.eventSink.Emit(event)
 			}
 		} else if status == disperser.InsufficientSignatures {
 			if _, updateConfirmationInfoErr = b.Queue.MarkBlobInsufficientSignatures(ctx, metadata, confirmationInfo); updateConfirmationInfoErr == nil {
@@ -351,7 +382,10 @@ func (b *Batcher) ProcessConfirmedBatch(ctx context.Context, receiptOrErr *Recei
 	if receiptOrErr.Metadata == nil {
 		return errors.New("failed to process confirmed batch: no metadata from transaction manager response")
 	}
-	confirmationMetadata := receiptOrErr.Metadata.(confirmationMetadata)
+	confirmationMetadata, err := b.txnConfirmationMetadata(ctx, receiptOrErr.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to process confirmed batch: %w", err)
+	}
 	blobs := confirmationMetadata.blobs
 	if len(blobs) == 0 {
 		return errors.New("failed to process confirmed batch: no blobs from transaction manager metadata")
@@ -426,6 +460,103 @@ type confirmationMetadata struct {
 	aggSig      *core.SignatureAggregation
 }
 
+var _ PersistentTxnMetadata = confirmationMetadata{}
+
+// pendingConfirmation is the part of the confirmation metadata which is persisted with the confirmBatch transaction.
+// The blobs are read back from the queue, and the merkle tree is rebuilt from the blob headers.
+type pendingConfirmation struct {
+	BatchHeader   *core.BatchHeader
+	BlobKeys      []disperser.BlobKey
+	BlobHeaders   []*core.BlobHeader
+	NonSigners    []*core.G1Point
+	QuorumResults map[core.QuorumID]*core.QuorumResult
+}
+
+func (m confirmationMetadata) MarshalTxnMetadata() ([]byte, error) {
+	if m.aggSig == nil {
+		return nil, errors.New("aggSig is nil")
+	}
+	pending := pendingConfirmation{
+		BatchHeader:   m.batchHeader,
+		BlobKeys:      make([]disperser.BlobKey, len(m.blobs)),
+		BlobHeaders:   m.blobHeaders,
+		NonSigners:    m.aggSig.NonSigners,
+		QuorumResults: m.aggSig.QuorumResults,
+	}
+	for i, blob := range m.blobs {
+		pending.BlobKeys[i] = blob.GetBlobKey()
+	}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(pending); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodePendingConfirmation(data RecoveredTxnMetadata) (*pendingConfirmation, error) {
+	var pending pendingConfirmation
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&pending); err != nil {
+		return nil, err
+	}
+	return &pending, nil
+}
+
+// txnConfirmationMetadata returns the confirmation metadata of a transaction manager response. The metadata of a
+// transaction recovered after a restart is rebuilt from its persisted form.
+func (b *Batcher) txnConfirmationMetadata(ctx context.Context, metadata interface{}) (confirmationMetadata, error) {
+	switch m := metadata.(type) {
+	case confirmationMetadata:
+		return m, nil
+	case RecoveredTxnMetadata:
+		pending, err := decodePendingConfirmation(m)
+		if err != nil {
+			return confirmationMetadata{}, fmt.Errorf("failed to decode recovered metadata: %w", err)
+		}
+		if pending.BatchHeader == nil || len(pending.BlobKeys) != len(pending.BlobHeaders) {
+			return confirmationMetadata{}, errors.New("invalid recovered metadata")
+		}
+		expectedRoot := pending.BatchHeader.BatchRoot
+		merkleTree, err := pending.BatchHeader.SetBatchRoot(pending.BlobHeaders)
+		if err != nil {
+			return confirmationMetadata{}, fmt.Errorf("failed to rebuild merkle tree: %w", err)
+		}
+		if pending.BatchHeader.BatchRoot != expectedRoot {
+			return confirmationMetadata{}, errors.New("batch root of recovered metadata doesn't match its blob headers")
+		}
+
+		metas, err := b.Queue.GetBulkBlobMetadata(ctx, pending.BlobKeys)
+		if err != nil {
+			return confirmationMetadata{}, fmt.Errorf("failed to get blobs of recovered batch: %w", err)
+		}
+		metasByKey := make(map[disperser.BlobKey]*disperser.BlobMetadata, len(metas))
+		for _, meta := range metas {
+			metasByKey[meta.GetBlobKey()] = meta
+		}
+		blobs := make([]*disperser.BlobMetadata, len(pending.BlobKeys))
+		for i, key := range pending.BlobKeys {
+			meta, ok := metasByKey[key]
+			if !ok {
+				return confirmationMetadata{}, fmt.Errorf("blob %s of recovered batch not found", key.String())
+			}
+			blobs[i] = meta
+		}
+
+		return confirmationMetadata{
+			batchID:     uuid.Nil,
+			batchHeader: pending.BatchHeader,
+			blobs:       blobs,
+			blobHeaders: pending.BlobHeaders,
+			merkleTree:  merkleTree,
+			aggSig: &core.SignatureAggregation{
+				NonSigners:    pending.NonSigners,
+				QuorumResults: pending.QuorumResults,
+			},
+		}, nil
+	default:
+		return confirmationMetadata{}, fmt.Errorf("unexpected metadata type %T", metadata)
+	}
+}
+
 func (b *Batcher) observeBlobAge(stage string, batch *batch) {
 	for _, m := range batch.BlobMetadata {
 		requestTime := time.Unix(0, int64(m.RequestMetadata.RequestedAt))
@@ -20,6 +20,8 @@ import (
 	bat "github.com/Layr-Labs/eigenda/disperser/batcher"
 	batchermock "github.com/Layr-Labs/eigenda/disperser/batcher/mock"
 	batmock "github.com/Layr-Labs/eigenda/disperser/batcher/mock"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
+	eventsmock "github.com/Layr-Labs/eigenda/disperser/common/events/mock"
 	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
 	dmock "github.com/Layr-Labs/eigenda/disperser/mock"
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -30,6 +32,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -46,6 +49,7 @@ type batcherComponents struct {
 	ethClient        *cmock.MockEthClient
 	dispatcher       *dmock.Dispatcher
 	chainData        *coremock.ChainDataMock
+	eventSink        *eventsmock.MockSink
 }
 
 // makeTestEncoder makes an encoder currently using the only supported backend.
@@ -130,8 +134,9 @@ func makeBatcher(t *testing.T) (*batcherComponents, *bat.Batcher, func() []time.
 	finalizer := batchermock.NewFinalizer()
 	ethClient := &cmock.MockEthClient{}
 	txnManager := batmock.NewTxnManager()
+	eventSink := eventsmock.NewSink()
 
-	b, err := bat.NewBatcher(config, timeoutConfig, blobStore, dispatcher, cst, asgn, encoderClient, agg, ethClient, finalizer, transactor, txnManager, logger, metrics, handleBatchLivenessChan)
+	b, err := bat.NewBatcher(config, timeoutConfig, blobStore, dispatcher, cst, asgn, encoderClient, agg, ethClient, finalizer, transactor, txnManager, logger, metrics, handleBatchLivenessChan, eventSink)
 	assert.NoError(t, err)
 
 	var mu sync.Mutex
@@ -161,6 +166,7 @@ func makeBatcher(t *testing.T) (*batcherComponents, *bat.Batcher, func() []time.
 			ethClient:        ethClient,
 			dispatcher:       dispatcher,
 			chainData:        cst,
+			eventSink:        eventSink,
 		}, b, func() []time.Time {
 			close(doneListening) // Stop the goroutine listening to heartbeats
 
@@ -281,6 +287,19 @@ func TestBatcherIterations(t *testing.T) {
 	assert.Equal(t, blobKey2, meta2.GetBlobKey())
 	assert.Equal(t, disperser.Confirmed, meta2.BlobStatus)
 
+	// A confirmation event is emitted for each confirmed blob
+	emitted := components.eventSink.Events()
+	require.Len(t, emitted, 2)
+	blobKeys := make([]string, 0, len(emitted))
+	for _, event := range emitted {
+		assert.Equal(t, events.BlobConfirmed, event.Type)
+		assert.Equal(t, uint8(1), event.Version)
+		assert.Equal(t, hex.EncodeToString(meta1.ConfirmationInfo.BatchHeaderHash[:]), event.BatchHeaderHash)
+		assert.Equal(t, uint32(blockNumber.Int64()), event.ConfirmationBlockNumber)
+		blobKeys = append(blobKeys, event.BlobKey)
+	}
+	assert.ElementsMatch(t, []string{blobKey1.String(), blobKey2.String()}, blobKeys)
+
 	res, err := components.encodingStreamer.EncodedBlobstore.GetEncodingResult(meta1.GetBlobKey(), 0)
 	assert.ErrorContains(t, err, "no such key")
 	assert.Nil(t, res)
@@ -836,3 +855,79 @@ func TestBatcherRecoverState(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, b2.BlobStatus, disperser.Failed)
 }
+
+func TestBatcherRecoverPendingConfirmation(t *testing.T) {
+	blob1 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+	blob2 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              1,
+		AdversaryThreshold:    70,
+		ConfirmationThreshold: 100,
+	}})
+	components, batcher, _ := makeBatcher(t)
+	components.dispatcher.On("DisperseBatch").Return(map[core.OperatorID]struct{}{})
+
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, blobKey1 := queueBlob(t, ctx, &blob1, blobStore)
+	_, blobKey2 := queueBlob(t, ctx, &blob2, blobStore)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	err := components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+
+	txn := types.NewTransaction(0, gethcommon.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
+	components.transactor.On("BuildConfirmBatchTxn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(txn, nil)
+	components.txnManager.On("ProcessTransaction").Return(nil)
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	require.Len(t, components.txnManager.Requests, 1)
+	metadata, ok := components.txnManager.Requests[0].Metadata.(bat.PersistentTxnMetadata)
+	require.True(t, ok)
+	encoded, err := metadata.MarshalTxnMetadata()
+	require.NoError(t, err)
+
+	// the batcher restarts while the confirmation transaction is pending, so its blobs are left dispersing
+	components.txnManager.Recovered = []bat.RecoveredTxnMetadata{encoded}
+	err = batcher.RecoverState(ctx)
+	assert.NoError(t, err)
+	for _, key := range []disperser.BlobKey{blobKey1, blobKey2} {
+		meta, err := blobStore.GetBlobMetadata(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, disperser.Dispersing, meta.BlobStatus)
+	}
+
+	// the blobs are confirmed once the receipt of the recovered transaction is received
+	logData, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	txHash := gethcommon.HexToHash("0x1234")
+	err = batcher.ProcessConfirmedBatch(ctx, &bat.ReceiptOrErr{
+		Receipt: &types.Receipt{
+			Logs: []*types.Log{
+				{
+					Topics: []gethcommon.Hash{common.BatchConfirmedEventSigHash, gethcommon.HexToHash("1234")},
+					Data:   logData,
+				},
+			},
+			BlockNumber: big.NewInt(123),
+			TxHash:      txHash,
+		},
+		Metadata: bat.RecoveredTxnMetadata(encoded),
+	})
+	assert.NoError(t, err)
+	for _, key := range []disperser.BlobKey{blobKey1, blobKey2} {
+		meta, err := blobStore.GetBlobMetadata(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, disperser.Confirmed, meta.BlobStatus)
+		assert.Equal(t, uint32(3), meta.ConfirmationInfo.BatchID)
+		assert.Equal(t, txHash, meta.ConfirmationInfo.ConfirmationTxnHash)
+		assert.NotEmpty(t, meta.ConfirmationInfo.BlobInclusionProof)
+	}
+}
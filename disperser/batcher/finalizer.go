@@ -2,6 +2,7 @@ package batcher
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -37,6 +39,7 @@ type finalizer struct {
 	numWorkers           int
 	logger               logging.Logger
 	metrics              *FinalizerMetrics
+	eventSink            events.Sink
 }
 
 func NewFinalizer(
@@ -50,6 +53,7 @@ func NewFinalizer(
 	numWorkers int,
 	logger logging.Logger,
 	metrics *FinalizerMetrics,
+	eventSink events.Sink,
 ) Finalizer {
 	return &finalizer{
 		timeout:              timeout,
@@ -62,6 +66,7 @@ func NewFinalizer(
 		numWorkers:           numWorkers,
 		logger:               logger.With("component", "Finalizer"),
 		metrics:              metrics,
+		eventSink:            eventSink,
 	}
 }
 
@@ -183,6 +188,8 @@ func (f *finalizer) updateBlobs(ctx context.Context, metadatas []*disperser.Blob
 			err := f.blobStore.MarkBlobFailed(ctx, m.GetBlobKey())
 			if err != nil {
 				f.logger.Error("error marking blob as failed", "blobKey", blobKey.String(), "err", err)
+			} else {
+				f.eventSink.Emit(newBlobEvent(events.BlobFailed, confirmationMetadata))
 			}
 			f.metrics.IncrementNumBlobs("failed")
 			continue
@@ -214,11 +221,25 @@ func (f *finalizer) updateBlobs(ctx context.Context, metadatas []*disperser.Blob
 			f.metrics.IncrementNumBlobs("failed")
 			continue
 		}
+		event := newBlobEvent(events.BlobFinalized, confirmationMetadata)
+		event.ConfirmationBlockNumber = uint32(confirmationBlockNumber)
+		f.eventSink.Emit(event)
 		f.metrics.IncrementNumBlobs("finalized")
 		f.metrics.ObserveLatency("round", float64(time.Since(stageTimer).Milliseconds()))
 	}
 }
 
+// newBlobEvent creates a lifecycle event of a v1 blob which has been confirmed in a batch.
+func newBlobEvent(eventType events.EventType, metadata *disperser.BlobMetadata) *events.Event {
+	accountID := ""
+	if metadata.RequestMetadata != nil {
+		accountID = metadata.RequestMetadata.AccountID
+	}
+	event := events.NewEvent(eventType, 1, accountID, metadata.GetBlobKey().String())
+	event.BatchHeaderHash = hex.EncodeToString(metadata.ConfirmationInfo.BatchHeaderHash[:])
+	return event
+}
+
 func (f *finalizer) getTransactionBlockNumber(ctx context.Context, hash gcommon.Hash) (uint64, error) {
 	var ctxWithTimeout context.Context
 	var cancel context.CancelFunc
@@ -10,6 +10,8 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
+	eventsmock "github.com/Layr-Labs/eigenda/disperser/common/events/mock"
 	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -40,7 +42,8 @@ func TestFinalizedBlob(t *testing.T) {
 	}, nil)
 
 	metrics := batcher.NewMetrics("9100", logger)
-	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 1, 1, logger, metrics.FinalizerMetrics)
+	eventSink := eventsmock.NewSink()
+	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 1, 1, logger, metrics.FinalizerMetrics, eventSink)
 
 	requestedAt := uint64(time.Now().UnixNano())
 	blob := makeTestBlob([]*core.SecurityParam{{
@@ -78,6 +81,7 @@ func TestFinalizedBlob(t *testing.T) {
 		NumRetries:   0,
 		RequestMetadata: &disperser.RequestMetadata{
 			BlobRequestHeader: core.BlobRequestHeader{
+				BlobAuthHeader: core.BlobAuthHeader{AccountID: "0x1234"},
 				SecurityParams: blob.RequestHeader.SecurityParams,
 			},
 			RequestedAt: requestedAt,
@@ -120,6 +124,19 @@ func TestFinalizedBlob(t *testing.T) {
 	assert.ElementsMatch(t, []uint64{metadatas[0].RequestMetadata.RequestedAt, metadatas[1].RequestMetadata.RequestedAt}, []uint64{requestedAt, requestedAt + 1})
 	assert.Equal(t, metadatas[0].RequestMetadata.SecurityParams, blob.RequestHeader.SecurityParams)
 	assert.Equal(t, metadatas[1].RequestMetadata.SecurityParams, blob.RequestHeader.SecurityParams)
+
+	emitted := eventSink.Events()
+	assert.Len(t, emitted, 2)
+	assert.ElementsMatch(t, []string{emitted[0].BlobKey, emitted[1].BlobKey}, []string{metadataKey1.String(), metadataKey2.String()})
+	for _, event := range emitted {
+		assert.Equal(t, events.BlobFinalized, event.Type)
+		assert.Equal(t, uint8(1), event.Version)
+		assert.Equal(t, uint32(1_000_000), event.ConfirmationBlockNumber)
+		assert.Equal(t, "0102030000000000000000000000000000000000000000000000000000000000", event.BatchHeaderHash)
+		if event.BlobKey == metadataKey1.String() {
+			assert.Equal(t, "0x1234", event.AccountID)
+		}
+	}
 }
 
 func TestUnfinalizedBlob(t *testing.T) {
@@ -139,7 +156,8 @@ func TestUnfinalizedBlob(t *testing.T) {
 	}, nil)
 
 	metrics := batcher.NewMetrics("9100", logger)
-	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 1, 1, logger, metrics.FinalizerMetrics)
+	eventSink := eventsmock.NewSink()
+	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 1, 1, logger, metrics.FinalizerMetrics, eventSink)
 
 	requestedAt := uint64(time.Now().UnixNano())
 	blob := makeTestBlob([]*core.SecurityParam{{
@@ -193,6 +211,7 @@ func TestUnfinalizedBlob(t *testing.T) {
 	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Finalized)
 	assert.NoError(t, err)
 	assert.Len(t, metadatas, 0)
+	assert.Empty(t, eventSink.Events())
 }
 
 func TestNoReceipt(t *testing.T) {
@@ -210,7 +229,8 @@ func TestNoReceipt(t *testing.T) {
 	ethClient.On("TransactionReceipt", m.Anything, m.Anything).Return(nil, ethereum.NotFound)
 
 	metrics := batcher.NewMetrics("9100", logger)
-	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 1, 1, logger, metrics.FinalizerMetrics)
+	eventSink := eventsmock.NewSink()
+	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 1, 1, logger, metrics.FinalizerMetrics, eventSink)
 
 	requestedAt := uint64(time.Now().UnixNano())
 	blob := makeTestBlob([]*core.SecurityParam{{
@@ -270,4 +290,9 @@ func TestNoReceipt(t *testing.T) {
 	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Processing)
 	assert.NoError(t, err)
 	assert.Len(t, metadatas, 0)
+
+	emitted := eventSink.Events()
+	assert.Len(t, emitted, 1)
+	assert.Equal(t, events.BlobFailed, emitted[0].Type)
+	assert.Equal(t, metadataKey.String(), emitted[0].BlobKey)
 }
@@ -11,6 +11,8 @@ type MockTxnManager struct {
 	mock.Mock
 
 	Requests []*batcher.TxnRequest
+	// Recovered is the metadata returned by RecoverTransactions
+	Recovered []batcher.RecoveredTxnMetadata
 }
 
 var _ batcher.TxnManager = (*MockTxnManager)(nil)
@@ -31,3 +33,7 @@ func (b *MockTxnManager) ReceiptChan() chan *batcher.ReceiptOrErr {
 	args := b.Called()
 	return args.Get(0).(chan *batcher.ReceiptOrErr)
 }
+
+func (b *MockTxnManager) RecoverTransactions(ctx context.Context) []batcher.RecoveredTxnMetadata {
+	return b.Recovered
+}
@@ -13,6 +13,7 @@ import (
 	walletsdk "github.com/Layr-Labs/eigensdk-go/chainio/clients/wallet"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -24,22 +25,42 @@ var (
 	maxSendTransactionRetry      = 3
 	queryTickerDuration          = 3 * time.Second
 	ErrTransactionNotBroadcasted = errors.New("transaction not broadcasted")
+	ErrTransactionCancelled      = errors.New("transaction cancelled")
 )
 
 // TxnManager receives transactions from the caller, sends them to the chain, and monitors their status.
 // It also handles the case where a transaction is not mined within a certain time. In this case, it will
 // resend the transaction with a higher gas price. It is assumed that all transactions originate from the
 // same account.
+// If a TxnStore is configured, every transaction is persisted before it is sent, and the transactions left pending by
+// a previous run are monitored again after a restart. Transactions which are stuck can be cancelled by replacing them
+// with a self-transfer of the same nonce.
 type TxnManager interface {
 	Start(ctx context.Context)
 	ProcessTransaction(ctx context.Context, req *TxnRequest) error
 	ReceiptChan() chan *ReceiptOrErr
+	// RecoverTransactions reads the transactions left pending by a previous run, and returns the metadata persisted
+	// with their requests. The outcome of each recovered transaction is sent to the receipt channel with its
+	// metadata once the TxnManager is started, like that of the transactions sent with ProcessTransaction.
+	// It must be called before Start.
+	RecoverTransactions(ctx context.Context) []RecoveredTxnMetadata
 }
 
+// PersistentTxnMetadata is implemented by the request metadata which is persisted with the transaction, so that the
+// outcome of a transaction recovered after a restart can still be handled.
+type PersistentTxnMetadata interface {
+	MarshalTxnMetadata() ([]byte, error)
+}
+
+// RecoveredTxnMetadata is the metadata of a request recovered after a restart, as encoded by MarshalTxnMetadata.
+type RecoveredTxnMetadata []byte
+
 type transaction struct {
 	*types.Transaction
 	TxID        walletsdk.TxID
 	requestedAt time.Time
+	// cancellation is true if the transaction is a self-transfer replacing the requested transaction
+	cancellation bool
 }
 
 type TxnRequest struct {
@@ -53,6 +74,8 @@ type TxnRequest struct {
 	// If a transaction hasn't been confirmed within the timeout and a replacement transaction is sent,
 	// the original transaction hash will be kept in this slice
 	txAttempts []*transaction
+	// cancelled is true once the requested transaction has been replaced by a self-transfer
+	cancelled bool
 }
 
 // ReceiptOrErr is a wrapper for a transaction receipt or an error.
@@ -78,11 +101,19 @@ type txnManager struct {
 	txnBroadcastTimeout time.Duration
 	txnRefreshInterval  time.Duration
 	metrics             *TxnManagerMetrics
+
+	// store persists the transactions being monitored. It is nil if they are kept in memory only.
+	store *TxnStore
+	// cancelAfterSpeedUps is the number of speed ups after which a transaction is cancelled. Cancellation is
+	// disabled if it is 0.
+	cancelAfterSpeedUps int
+	// recovered are the requests left pending by a previous run, which are monitored once the TxnManager starts
+	recovered []*TxnRequest
 }
 
 var _ TxnManager = (*txnManager)(nil)
 
-func NewTxnManager(ethClient common.EthClient, wallet walletsdk.Wallet, numConfirmations, queueSize int, txnBroadcastTimeout time.Duration, txnRefreshInterval time.Duration, logger logging.Logger, metrics *TxnManagerMetrics) TxnManager {
+func NewTxnManager(ethClient common.EthClient, wallet walletsdk.Wallet, numConfirmations, queueSize int, txnBroadcastTimeout time.Duration, txnRefreshInterval time.Duration, store *TxnStore, cancelAfterSpeedUps int, logger logging.Logger, metrics *TxnManagerMetrics) TxnManager {
 	return &txnManager{
 		ethClient:           ethClient,
 		wallet:              wallet,
@@ -94,6 +125,8 @@ func NewTxnManager(ethClient common.EthClient, wallet walletsdk.Wallet, numConfi
 		txnBroadcastTimeout: txnBroadcastTimeout,
 		txnRefreshInterval:  txnRefreshInterval,
 		metrics:             metrics,
+		store:               store,
+		cancelAfterSpeedUps: cancelAfterSpeedUps,
 	}
 }
 
@@ -110,6 +143,36 @@ func NewTxnRequest(tx *types.Transaction, tag string, value *big.Int, metadata i
 }
 
 func (t *txnManager) Start(ctx context.Context) {
+	recovered := t.recovered
+	t.recovered = nil
+	if len(recovered) > 0 {
+		go func() {
+			for _, req := range recovered {
+				receipt, err := t.monitorTransaction(ctx, req)
+				if ctx.Err() != nil {
+					return
+				}
+				t.forgetTransaction(req)
+				if errors.Is(err, ErrTransactionCancelled) {
+					t.logger.Info("recovered transaction cancelled", "tag", req.Tag, "nonce", req.Tx.Nonce())
+				} else if err != nil {
+					t.logger.Warn("recovered transaction failed", "tag", req.Tag, "nonce", req.Tx.Nonce(), "err", err)
+				} else {
+					t.logger.Info("recovered transaction confirmed", "tag", req.Tag, "nonce", req.Tx.Nonce(), "txHash", receipt.TxHash.Hex())
+				}
+				if req.Metadata == nil {
+					// There is nothing the caller can do with the outcome of a request whose metadata wasn't persisted
+					continue
+				}
+				t.receiptChan <- &ReceiptOrErr{
+					Receipt:  receipt,
+					Metadata: req.Metadata,
+					Err:      err,
+				}
+			}
+		}()
+	}
+
 	go func() {
 		for {
 			select {
@@ -117,6 +180,9 @@ func (t *txnManager) Start(ctx context.Context) {
 				return
 			case req := <-t.requestChan:
 				receipt, err := t.monitorTransaction(ctx, req)
+				if ctx.Err() == nil {
+					t.forgetTransaction(req)
+				}
 				if err != nil {
 					t.receiptChan <- &ReceiptOrErr{
 						Receipt:  nil,
@@ -160,19 +226,16 @@ func (t *txnManager) ProcessTransaction(ctx context.Context, req *TxnRequest) er
 
 		txn, err = t.ethClient.UpdateGas(ctx, req.Tx, req.Value, gasTipCap, gasFeeCap)
 		if err != nil {
+			t.forgetTransaction(req)
 			return fmt.Errorf("failed to update gas price: %w", err)
 		}
-		txID, err = t.wallet.SendTransaction(ctx, txn)
-		var urlErr *url.Error
-		didTimeout := false
-		if errors.As(err, &urlErr) {
-			didTimeout = urlErr.Timeout()
-		}
-		if didTimeout || errors.Is(err, context.DeadlineExceeded) {
+		txID, err = t.sendAttempt(ctx, req, txn, false)
+		if isTimeout(err) {
 			t.logger.Warn("failed to send txn due to timeout", "tag", req.Tag, "hash", txn.Hash().Hex(), "numRetries", retryFromFailure, "maxRetry", maxSendTransactionRetry, "err", err)
 			retryFromFailure++
 			continue
 		} else if err != nil {
+			t.forgetTransaction(req)
 			return fmt.Errorf("failed to send txn (%s) %s: %w", req.Tag, txn.Hash().Hex(), err)
 		} else {
 			t.logger.Debug("successfully sent txn", "tag", req.Tag, "txID", txID, "txHash", txn.Hash().Hex())
@@ -181,16 +244,10 @@ func (t *txnManager) ProcessTransaction(ctx context.Context, req *TxnRequest) er
 	}
 
 	if txn == nil || txID == "" {
+		t.forgetTransaction(req)
 		return fmt.Errorf("failed to send txn (%s) %s: %w", req.Tag, req.Tx.Hash().Hex(), err)
 	}
 
-	req.Tx = txn
-	req.txAttempts = append(req.txAttempts, &transaction{
-		TxID:        txID,
-		Transaction: txn,
-		requestedAt: time.Now(),
-	})
-
 	t.requestChan <- req
 	t.metrics.UpdateTxQueue(len(t.requestChan))
 	return nil
@@ -200,6 +257,164 @@ func (t *txnManager) ReceiptChan() chan *ReceiptOrErr {
 	return t.receiptChan
 }
 
+func (t *txnManager) RecoverTransactions(ctx context.Context) []RecoveredTxnMetadata {
+	t.recovered = t.recoverPendingTransactions(ctx)
+	metadata := make([]RecoveredTxnMetadata, 0, len(t.recovered))
+	for _, req := range t.recovered {
+		if m, ok := req.Metadata.(RecoveredTxnMetadata); ok {
+			metadata = append(metadata, m)
+		}
+	}
+	return metadata
+}
+
+// recoverPendingTransactions reads the transactions persisted by a previous run, and returns their requests so that
+// they are monitored until their outcome is known. Transactions whose nonce hasn't been used on chain yet are
+// cancelled if cancellation is enabled. The others are monitored for a receipt, which isn't found if the nonce was
+// used by a transaction of another request.
+func (t *txnManager) recoverPendingTransactions(ctx context.Context) []*TxnRequest {
+	if t.store == nil {
+		return nil
+	}
+	requests, err := t.store.list()
+	if err != nil {
+		t.logger.Error("failed to read pending transactions", "err", err)
+		return nil
+	}
+	if len(requests) == 0 {
+		return nil
+	}
+
+	sender, err := t.wallet.SenderAddress(ctx)
+	if err != nil {
+		t.logger.Error("failed to get sender address, pending transactions are not recovered", "err", err)
+		return nil
+	}
+	nonce, err := t.ethClient.NonceAt(ctx, sender, nil)
+	if err != nil {
+		t.logger.Error("failed to get account nonce, pending transactions are not recovered", "err", err)
+		return nil
+	}
+
+	recovered := make([]*TxnRequest, 0, len(requests))
+	for _, req := range requests {
+		if req.Tx.Nonce() < nonce {
+			t.logger.Info("recovering mined transaction of previous run", "tag", req.Tag, "nonce", req.Tx.Nonce(), "accountNonce", nonce)
+			recovered = append(recovered, req)
+			continue
+		}
+
+		t.logger.Warn("recovering pending transaction of previous run", "tag", req.Tag, "nonce", req.Tx.Nonce(), "txHash", req.Tx.Hash().Hex(), "numAttempts", len(req.txAttempts))
+		if t.cancelAfterSpeedUps > 0 && !req.cancelled {
+			if err := t.cancelTxn(ctx, req); err != nil {
+				// The transaction is still monitored, and will be sped up or cancelled again if it's stuck
+				t.logger.Error("failed to cancel pending transaction of previous run", "tag", req.Tag, "nonce", req.Tx.Nonce(), "err", err)
+			}
+		}
+		recovered = append(recovered, req)
+	}
+	return recovered
+}
+
+// cancelTxn replaces the transaction of the request with a self-transfer of the same nonce, and sends it.
+func (t *txnManager) cancelTxn(ctx context.Context, req *TxnRequest) error {
+	cancellation, err := t.cancellationTxn(ctx, req.Tx, req.Tag)
+	if err != nil {
+		return fmt.Errorf("failed to create cancellation transaction: %w", err)
+	}
+	txID, err := t.sendAttempt(ctx, req, cancellation, true)
+	if err != nil {
+		return fmt.Errorf("failed to send cancellation transaction: %w", err)
+	}
+	t.logger.Debug("successfully sent cancellation txn", "tag", req.Tag, "txID", txID, "txHash", cancellation.Hash().Hex())
+	return nil
+}
+
+// cancellationTxn creates a self-transfer of no value with the nonce of the given transaction, and a higher gas price.
+func (t *txnManager) cancellationTxn(ctx context.Context, tx *types.Transaction, tag string) (*types.Transaction, error) {
+	sender, err := t.wallet.SenderAddress(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender address: %w", err)
+	}
+	selfTransfer := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   tx.ChainId(),
+		Nonce:     tx.Nonce(),
+		GasTipCap: tx.GasTipCap(),
+		GasFeeCap: tx.GasFeeCap(),
+		To:        &sender,
+		Value:     big.NewInt(0),
+	})
+	t.logger.Warn("cancelling transaction", "tag", tag, "txHash", tx.Hash().Hex(), "nonce", tx.Nonce())
+	return t.speedUpTxn(ctx, selfTransfer, tag)
+}
+
+// sendAttempt sends the signed transaction as a new attempt of the request. The attempt is persisted before it is
+// sent, so that a transaction sent right before a crash is recovered, and it is looked up by hash until the wallet
+// returns its ID. A failed attempt is dropped, unless sending it timed out, in which case it may have been sent anyway.
+func (t *txnManager) sendAttempt(ctx context.Context, req *TxnRequest, tx *types.Transaction, cancellation bool) (walletsdk.TxID, error) {
+	attempt := &transaction{
+		Transaction:  tx,
+		requestedAt:  time.Now(),
+		cancellation: cancellation || req.cancelled,
+	}
+	req.txAttempts = append(req.txAttempts, attempt)
+	t.persistTransaction(req)
+
+	txID, err := t.wallet.SendTransaction(ctx, tx)
+	if err != nil {
+		if !isTimeout(err) {
+			req.txAttempts = req.txAttempts[:len(req.txAttempts)-1]
+			if len(req.txAttempts) > 0 {
+				t.persistTransaction(req)
+			}
+		}
+		return "", err
+	}
+
+	attempt.TxID = txID
+	req.Tx = tx
+	req.cancelled = attempt.cancellation
+	t.persistTransaction(req)
+	return txID, nil
+}
+
+// isTimeout returns true if the error is caused by a timeout, after which the outcome of the call is unknown.
+func isTimeout(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) && urlErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// transactionReceipt returns the receipt of the transaction. Transactions whose ID isn't known are looked up by hash.
+func (t *txnManager) transactionReceipt(ctx context.Context, tx *transaction) (*types.Receipt, error) {
+	if tx.TxID == "" {
+		return t.ethClient.TransactionReceipt(ctx, tx.Hash())
+	}
+	return t.wallet.GetTransactionReceipt(ctx, tx.TxID)
+}
+
+// persistTransaction stores the attempts of the request, so that they can be recovered after a restart.
+func (t *txnManager) persistTransaction(req *TxnRequest) {
+	if t.store == nil {
+		return
+	}
+	if err := t.store.put(req); err != nil {
+		t.logger.Error("failed to persist pending transaction", "tag", req.Tag, "nonce", req.Tx.Nonce(), "err", err)
+	}
+}
+
+// forgetTransaction removes the request from the store once its outcome is known.
+func (t *txnManager) forgetTransaction(req *TxnRequest) {
+	if t.store == nil {
+		return
+	}
+	if err := t.store.delete(req); err != nil {
+		t.logger.Error("failed to delete pending transaction", "tag", req.Tag, "nonce", req.Tx.Nonce(), "err", err)
+	}
+}
+
 // ensureAnyTransactionBroadcasted waits until all given transactions are broadcasted to the network.
 func (t *txnManager) ensureAnyTransactionBroadcasted(ctx context.Context, txs []*transaction) error {
 	queryTicker := time.NewTicker(queryTickerDuration)
@@ -207,7 +422,7 @@ func (t *txnManager) ensureAnyTransactionBroadcasted(ctx context.Context, txs []
 
 	for {
 		for _, tx := range txs {
-			_, err := t.wallet.GetTransactionReceipt(ctx, tx.TxID)
+			_, err := t.transactionReceipt(ctx, tx)
 			if err == nil || errors.Is(err, ethereum.NotFound) || errors.Is(err, walletsdk.ErrReceiptNotYetAvailable) {
 				t.metrics.ObserveLatency("broadcasted", float64(time.Since(tx.requestedAt).Milliseconds()))
 				return nil
@@ -223,20 +438,23 @@ func (t *txnManager) ensureAnyTransactionBroadcasted(ctx context.Context, txs []
 	}
 }
 
-func (t *txnManager) ensureAnyTransactionEvaled(ctx context.Context, txs []*transaction) (*types.Receipt, error) {
+// ensureAnyTransactionEvaled waits until any of the given transactions is mined with enough confirmations, and
+// returns its receipt and the transaction.
+func (t *txnManager) ensureAnyTransactionEvaled(ctx context.Context, txs []*transaction) (*types.Receipt, *transaction, error) {
 	queryTicker := time.NewTicker(queryTickerDuration)
 	defer queryTicker.Stop()
 	var receipt *types.Receipt
 	var err error
 	// transactions that need to be queried. Some transactions will be removed from this map depending on their status.
-	txnsToQuery := make(map[walletsdk.TxID]*types.Transaction, len(txs))
+	txnsToQuery := make(map[gethcommon.Hash]*transaction, len(txs))
 	for _, tx := range txs {
-		txnsToQuery[tx.TxID] = tx.Transaction
+		txnsToQuery[tx.Hash()] = tx
 	}
 
 	for {
-		for txID, tx := range txnsToQuery {
-			receipt, err = t.wallet.GetTransactionReceipt(ctx, txID)
+		for txHash, tx := range txnsToQuery {
+			txID := tx.TxID
+			receipt, err = t.transactionReceipt(ctx, tx)
 			if err == nil {
 				chainTip, err := t.ethClient.BlockNumber(ctx)
 				if err == nil {
@@ -244,7 +462,7 @@ func (t *txnManager) ensureAnyTransactionEvaled(ctx context.Context, txs []*tran
 						t.logger.Debug("transaction has been mined but don't have enough confirmations at current chain tip", "txnBlockNumber", receipt.BlockNumber.Uint64(), "numConfirmations", t.numConfirmations, "chainTip", chainTip)
 						break
 					} else {
-						return receipt, nil
+						return receipt, tx, nil
 					}
 				} else {
 					t.logger.Debug("failed to get chain tip while waiting for transaction to mine", "err", err)
@@ -255,7 +473,7 @@ func (t *txnManager) ensureAnyTransactionEvaled(ctx context.Context, txs []*tran
 				t.logger.Debug("Transaction not yet mined", "txID", txID, "txHash", tx.Hash().Hex(), "err", err)
 			} else if errors.Is(err, walletsdk.ErrTransactionFailed) {
 				t.logger.Debug("Transaction failed", "txID", txID, "txHash", tx.Hash().Hex(), "err", err)
-				delete(txnsToQuery, txID)
+				delete(txnsToQuery, txHash)
 			} else if errors.Is(err, walletsdk.ErrNotYetBroadcasted) {
 				t.logger.Error("Transaction has not been broadcasted to network but attempted to retrieve receipt", "err", err)
 			} else {
@@ -264,13 +482,13 @@ func (t *txnManager) ensureAnyTransactionEvaled(ctx context.Context, txs []*tran
 		}
 
 		if len(txnsToQuery) == 0 {
-			return nil, fmt.Errorf("all transactions failed")
+			return nil, nil, fmt.Errorf("all transactions failed")
 		}
 
 		// Wait for the next round.
 		select {
 		case <-ctx.Done():
-			return receipt, ctx.Err()
+			return receipt, nil, ctx.Err()
 		case <-queryTicker.C:
 		}
 	}
@@ -284,6 +502,7 @@ func (t *txnManager) monitorTransaction(ctx context.Context, req *TxnRequest) (*
 	retryFromFailure := 0
 
 	var receipt *types.Receipt
+	var minedTxn *transaction
 	var err error
 
 	rpcCallAttempt := func() error {
@@ -305,6 +524,9 @@ func (t *txnManager) monitorTransaction(ctx context.Context, req *TxnRequest) (*
 				// Consider these transactions failed as they haven't been broadcasted within timeout.
 				// Cancel these transactions to avoid blocking the next transactions.
 				for _, tx := range req.txAttempts {
+					if tx.TxID == "" {
+						continue
+					}
 					cancelled, err := fireblocksWallet.CancelTransactionBroadcast(ctx, tx.TxID)
 					if err != nil {
 						t.logger.Warn("failed to cancel Fireblocks transaction broadcast", "txID", tx.TxID, "err", err)
@@ -321,7 +543,7 @@ func (t *txnManager) monitorTransaction(ctx context.Context, req *TxnRequest) (*
 
 		ctxWithTimeout, cancelEvaluationTimeout := context.WithTimeout(ctx, t.txnRefreshInterval)
 		defer cancelEvaluationTimeout()
-		receipt, err = t.ensureAnyTransactionEvaled(
+		receipt, minedTxn, err = t.ensureAnyTransactionEvaled(
 			ctxWithTimeout,
 			req.txAttempts,
 		)
@@ -332,6 +554,11 @@ func (t *txnManager) monitorTransaction(ctx context.Context, req *TxnRequest) (*
 		err = rpcCallAttempt()
 		if err == nil {
 			t.metrics.UpdateSpeedUps(numSpeedUps)
+			if minedTxn.cancellation {
+				t.logger.Warn("transaction has been cancelled", "tag", req.Tag, "txHash", receipt.TxHash.Hex(), "nonce", req.Tx.Nonce())
+				t.metrics.IncrementTxnCount("cancelled")
+				return nil, ErrTransactionCancelled
+			}
 			t.metrics.IncrementTxnCount("success")
 			return receipt, nil
 		}
@@ -341,14 +568,21 @@ func (t *txnManager) monitorTransaction(ctx context.Context, req *TxnRequest) (*
 				t.logger.Warn("transaction has been mined, but hasn't accumulated the required number of confirmations", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "nonce", req.Tx.Nonce())
 				continue
 			}
-			t.logger.Warn("transaction not mined within timeout, resending with higher gas price", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "nonce", req.Tx.Nonce())
-			newTx, err := t.speedUpTxn(ctx, req.Tx, req.Tag)
+			// Replace the transaction with a self-transfer if it's stuck, after which the self-transfer is sped up instead
+			cancelStuck := t.cancelAfterSpeedUps > 0 && numSpeedUps >= t.cancelAfterSpeedUps && !req.cancelled
+			var newTx *types.Transaction
+			if cancelStuck {
+				newTx, err = t.cancellationTxn(ctx, req.Tx, req.Tag)
+			} else {
+				t.logger.Warn("transaction not mined within timeout, resending with higher gas price", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "nonce", req.Tx.Nonce())
+				newTx, err = t.speedUpTxn(ctx, req.Tx, req.Tag)
+			}
 			if err != nil {
 				t.logger.Error("failed to speed up transaction", "err", err)
 				t.metrics.IncrementTxnCount("failure")
 				return nil, err
 			}
-			txID, err := t.wallet.SendTransaction(ctx, newTx)
+			txID, err := t.sendAttempt(ctx, req, newTx, cancelStuck)
 			if err != nil {
 				if retryFromFailure >= maxSendTransactionRetry {
 					t.logger.Warn("failed to send txn - retries exhausted", "tag", req.Tag, "txn", req.Tx.Hash().Hex(), "attempt", retryFromFailure, "maxRetry", maxSendTransactionRetry, "err", err)
@@ -361,12 +595,11 @@ func (t *txnManager) monitorTransaction(ctx context.Context, req *TxnRequest) (*
 				continue
 			}
 
+			if cancelStuck {
+				t.logger.Debug("successfully sent cancellation txn", "tag", req.Tag, "txID", txID, "txHash", newTx.Hash().Hex())
+				continue
+			}
 			t.logger.Debug("successfully sent txn", "tag", req.Tag, "txID", txID, "txHash", newTx.Hash().Hex())
-			req.Tx = newTx
-			req.txAttempts = append(req.txAttempts, &transaction{
-				TxID:        txID,
-				Transaction: newTx,
-			})
 			numSpeedUps++
 		} else {
 			t.logger.Error("transaction failed", "tag", req.Tag, "txHash", req.Tx.Hash().Hex(), "err", err)
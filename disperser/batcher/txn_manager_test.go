@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
 	"github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
 	sdkmock "github.com/Layr-Labs/eigensdk-go/chainio/clients/mocks"
@@ -15,6 +17,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -24,7 +27,7 @@ func TestProcessTransaction(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, nil, 0, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
@@ -74,7 +77,7 @@ func TestReplaceGasFee(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, nil, 0, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
@@ -110,7 +113,7 @@ func TestTransactionReplacementFailure(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, nil, 0, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
@@ -143,7 +146,7 @@ func TestSendTransactionReceiptRetry(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, nil, 0, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
@@ -181,7 +184,7 @@ func TestSendTransactionRetrySuccess(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, nil, 0, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
@@ -223,7 +226,7 @@ func TestSendTransactionRetryFailure(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, time.Second, 48*time.Second, nil, 0, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
@@ -261,7 +264,7 @@ func TestTransactionNotBroadcasted(t *testing.T) {
 	w := sdkmock.NewMockWallet(ctrl)
 	logger := logging.NewNoopLogger()
 	metrics := batcher.NewMetrics("9100", logger)
-	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 48*time.Second, logger, metrics.TxnManagerMetrics)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 48*time.Second, nil, 0, logger, metrics.TxnManagerMetrics)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
 	defer cancel()
 	txnManager.Start(ctx)
@@ -286,3 +289,252 @@ func TestTransactionNotBroadcasted(t *testing.T) {
 	assert.ErrorAs(t, res.Err, &batcher.ErrTransactionNotBroadcasted)
 	assert.Nil(t, res.Receipt)
 }
+
+func numPendingTxns(t *testing.T, db kvstore.Store[[]byte]) int {
+	it, err := db.NewIterator([]byte{})
+	require.NoError(t, err)
+	defer it.Release()
+	n := 0
+	for it.Next() {
+		n++
+	}
+	return n
+}
+
+type persistentMetadata string
+
+func (m persistentMetadata) MarshalTxnMetadata() ([]byte, error) {
+	return []byte(m), nil
+}
+
+func TestRecoverPendingTransaction(t *testing.T) {
+	ethClient := &mock.MockEthClient{}
+	ctrl := gomock.NewController(t)
+	w := sdkmock.NewMockWallet(ctrl)
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	db := mapstore.NewStore()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	txn := types.NewTransaction(7, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
+	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("UpdateGas").Return(txn, nil)
+	ethClient.On("BlockNumber").Return(uint64(123), nil)
+	ethClient.On("NonceAt").Return(uint64(7), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(common.HexToAddress("0x2"), nil).AnyTimes()
+
+	// the batcher stops before the transaction is mined
+	txID := "1234"
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(txID, nil)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.NewTxnStore(db), 0, logger, metrics.TxnManagerMetrics)
+	err := txnManager.ProcessTransaction(ctx, &batcher.TxnRequest{
+		Tx:       txn,
+		Tag:      "test transaction",
+		Value:    nil,
+		Metadata: persistentMetadata("batch"),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numPendingTxns(t, db))
+
+	// the transaction is monitored again after a restart, and its outcome is sent with the persisted metadata
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), txID).Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(1),
+	}, nil).AnyTimes()
+	txnManager = batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.NewTxnStore(db), 0, logger, metrics.TxnManagerMetrics)
+	recovered := txnManager.RecoverTransactions(ctx)
+	assert.Equal(t, []batcher.RecoveredTxnMetadata{batcher.RecoveredTxnMetadata("batch")}, recovered)
+	txnManager.Start(ctx)
+	receiptOrErr := <-txnManager.ReceiptChan()
+	assert.NoError(t, receiptOrErr.Err)
+	assert.Equal(t, uint64(1), receiptOrErr.Receipt.BlockNumber.Uint64())
+	assert.Equal(t, batcher.RecoveredTxnMetadata("batch"), receiptOrErr.Metadata)
+	assert.Equal(t, 0, numPendingTxns(t, db))
+}
+
+func TestRecoverUnsentTransaction(t *testing.T) {
+	ethClient := &mock.MockEthClient{}
+	ctrl := gomock.NewController(t)
+	w := sdkmock.NewMockWallet(ctrl)
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	db := mapstore.NewStore()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	txn := types.NewTransaction(7, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
+	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("UpdateGas").Return(txn, nil)
+	ethClient.On("BlockNumber").Return(uint64(123), nil)
+	ethClient.On("NonceAt").Return(uint64(8), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(common.HexToAddress("0x2"), nil).AnyTimes()
+
+	// the transaction is persisted before it's sent, so the state left by a crash while sending it is captured
+	crashed := mapstore.NewStore()
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, tx *types.Transaction) (walletsdk.TxID, error) {
+		it, err := db.NewIterator(nil)
+		require.NoError(t, err)
+		defer it.Release()
+		for it.Next() {
+			require.NoError(t, crashed.Put(it.Key(), it.Value()))
+		}
+		return "", errors.New("connection refused")
+	})
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.NewTxnStore(db), 0, logger, metrics.TxnManagerMetrics)
+	err := txnManager.ProcessTransaction(ctx, &batcher.TxnRequest{
+		Tx:       txn,
+		Tag:      "test transaction",
+		Value:    nil,
+		Metadata: persistentMetadata("batch"),
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 0, numPendingTxns(t, db))
+	assert.Equal(t, 1, numPendingTxns(t, crashed))
+
+	// the transaction was broadcast before the crash, and it's looked up by hash since its ID is unknown
+	ethClient.On("TransactionReceipt").Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(1),
+		TxHash:      txn.Hash(),
+	}, nil)
+	txnManager = batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.NewTxnStore(crashed), 0, logger, metrics.TxnManagerMetrics)
+	recovered := txnManager.RecoverTransactions(ctx)
+	assert.Len(t, recovered, 1)
+	txnManager.Start(ctx)
+	receiptOrErr := <-txnManager.ReceiptChan()
+	assert.NoError(t, receiptOrErr.Err)
+	assert.Equal(t, txn.Hash(), receiptOrErr.Receipt.TxHash)
+	assert.Equal(t, batcher.RecoveredTxnMetadata("batch"), receiptOrErr.Metadata)
+	assert.Equal(t, 0, numPendingTxns(t, crashed))
+}
+
+func TestRecoverMinedTransaction(t *testing.T) {
+	ethClient := &mock.MockEthClient{}
+	ctrl := gomock.NewController(t)
+	w := sdkmock.NewMockWallet(ctrl)
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	db := mapstore.NewStore()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*1)
+	defer cancel()
+	txn := types.NewTransaction(7, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
+	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("UpdateGas").Return(txn, nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(common.HexToAddress("0x2"), nil).AnyTimes()
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return("1234", nil)
+
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.NewTxnStore(db), 1, logger, metrics.TxnManagerMetrics)
+	err := txnManager.ProcessTransaction(ctx, &batcher.TxnRequest{
+		Tx:    txn,
+		Tag:   "test transaction",
+		Value: nil,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, numPendingTxns(t, db))
+
+	// the nonce of the transaction has been used while the batcher was stopped, so it's monitored for its receipt
+	// instead of being cancelled
+	ethClient.On("NonceAt").Return(uint64(8), nil)
+	ethClient.On("BlockNumber").Return(uint64(123), nil)
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), walletsdk.TxID("1234")).Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(1),
+	}, nil).AnyTimes()
+	txnManager = batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.NewTxnStore(db), 1, logger, metrics.TxnManagerMetrics)
+	txnManager.RecoverTransactions(ctx)
+	txnManager.Start(ctx)
+	assert.Eventually(t, func() bool {
+		return numPendingTxns(t, db) == 0
+	}, time.Second, 10*time.Millisecond)
+	ethClient.AssertNumberOfCalls(t, "UpdateGas", 1)
+}
+
+func TestRecoverAndCancelPendingTransaction(t *testing.T) {
+	ethClient := &mock.MockEthClient{}
+	ctrl := gomock.NewController(t)
+	w := sdkmock.NewMockWallet(ctrl)
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	db := mapstore.NewStore()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	sender := common.HexToAddress("0x2")
+	txn := types.NewTransaction(7, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
+	selfTransfer := types.NewTransaction(7, sender, big.NewInt(0), 21000, big.NewInt(2e9), []byte{})
+	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("UpdateGas").Return(txn, nil).Once()
+	ethClient.On("UpdateGas").Return(selfTransfer, nil)
+	ethClient.On("BlockNumber").Return(uint64(123), nil)
+	ethClient.On("NonceAt").Return(uint64(7), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(sender, nil).AnyTimes()
+
+	txID := "1234"
+	w.EXPECT().SendTransaction(gomock.Any(), gomock.Any()).Return(txID, nil)
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.NewTxnStore(db), 3, logger, metrics.TxnManagerMetrics)
+	err := txnManager.ProcessTransaction(ctx, &batcher.TxnRequest{
+		Tx:    txn,
+		Tag:   "test transaction",
+		Value: nil,
+	})
+	assert.NoError(t, err)
+
+	// the pending transaction is replaced by a self-transfer on restart, which gets mined
+	cancelTxID := "5678"
+	w.EXPECT().SendTransaction(gomock.Any(), selfTransfer).Return(cancelTxID, nil)
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), txID).Return(nil, walletsdk.ErrReceiptNotYetAvailable).AnyTimes()
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), cancelTxID).Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(1),
+	}, nil).AnyTimes()
+	txnManager = batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.NewTxnStore(db), 3, logger, metrics.TxnManagerMetrics)
+	txnManager.RecoverTransactions(ctx)
+	txnManager.Start(ctx)
+	assert.Eventually(t, func() bool {
+		return numPendingTxns(t, db) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	ethClient.AssertNumberOfCalls(t, "UpdateGas", 2)
+}
+
+func TestCancelStuckTransaction(t *testing.T) {
+	ethClient := &mock.MockEthClient{}
+	ctrl := gomock.NewController(t)
+	w := sdkmock.NewMockWallet(ctrl)
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger)
+	db := mapstore.NewStore()
+	txnManager := batcher.NewTxnManager(ethClient, w, 0, 5, 100*time.Millisecond, 100*time.Millisecond, batcher.NewTxnStore(db), 1, logger, metrics.TxnManagerMetrics)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+	txnManager.Start(ctx)
+	sender := common.HexToAddress("0x2")
+	txn := types.NewTransaction(7, common.HexToAddress("0x1"), big.NewInt(1e18), 100000, big.NewInt(1e9), []byte{})
+	selfTransfer := types.NewTransaction(7, sender, big.NewInt(0), 21000, big.NewInt(2e9), []byte{})
+	ethClient.On("GetLatestGasCaps").Return(big.NewInt(1e9), big.NewInt(1e9), nil)
+	ethClient.On("UpdateGas").Return(txn, nil).Twice()
+	ethClient.On("UpdateGas").Return(selfTransfer, nil)
+	ethClient.On("BlockNumber").Return(uint64(123), nil)
+	w.EXPECT().SenderAddress(gomock.Any()).Return(sender, nil).AnyTimes()
+
+	// the transaction and its replacement are not mined, so the replacement is cancelled once sped up once
+	txID, speedUpTxID, cancelTxID := "1234", "2345", "3456"
+	gomock.InOrder(
+		w.EXPECT().SendTransaction(gomock.Any(), txn).Return(txID, nil),
+		w.EXPECT().SendTransaction(gomock.Any(), txn).Return(speedUpTxID, nil),
+		w.EXPECT().SendTransaction(gomock.Any(), selfTransfer).Return(cancelTxID, nil),
+	)
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), txID).Return(nil, walletsdk.ErrReceiptNotYetAvailable).AnyTimes()
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), speedUpTxID).Return(nil, walletsdk.ErrReceiptNotYetAvailable).AnyTimes()
+	w.EXPECT().GetTransactionReceipt(gomock.Any(), cancelTxID).Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(1),
+	}, nil).AnyTimes()
+
+	err := txnManager.ProcessTransaction(ctx, &batcher.TxnRequest{
+		Tx:    txn,
+		Tag:   "test transaction",
+		Value: nil,
+	})
+	assert.NoError(t, err)
+	select {
+	case res := <-txnManager.ReceiptChan():
+		assert.ErrorIs(t, res.Err, batcher.ErrTransactionCancelled)
+		assert.Nil(t, res.Receipt)
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for the transaction to be cancelled")
+	}
+	assert.Equal(t, 0, numPendingTxns(t, db))
+}
@@ -0,0 +1,159 @@
+package batcher
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	walletsdk "github.com/Layr-Labs/eigensdk-go/chainio/clients/wallet"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var pendingTxnKeyPrefix = []byte("pending-txn-")
+
+// TxnStore persists the transactions which have been sent by the TxnManager but whose outcome isn't known yet, so
+// that they can be reconciled against the chain when the batcher restarts. Transactions are keyed by nonce, since
+// only the latest request sent with a nonce can still be pending.
+type TxnStore struct {
+	db kvstore.Store[[]byte]
+}
+
+// pendingTxn is the persisted state of a transaction request.
+type pendingTxn struct {
+	Tag         string               `json:"tag"`
+	Nonce       uint64               `json:"nonce"`
+	RequestedAt time.Time            `json:"requestedAt"`
+	Attempts    []*pendingTxnAttempt `json:"attempts"`
+	// Metadata is the encoding of the request metadata, if it implements PersistentTxnMetadata
+	Metadata []byte `json:"metadata,omitempty"`
+}
+
+type pendingTxnAttempt struct {
+	// TxID is empty if the transaction was persisted before it was sent, and the wallet didn't return its ID
+	TxID walletsdk.TxID `json:"txID"`
+	// Tx is the binary encoding of the transaction
+	Tx           []byte `json:"tx"`
+	Cancellation bool   `json:"cancellation"`
+}
+
+func NewTxnStore(db kvstore.Store[[]byte]) *TxnStore {
+	return &TxnStore{db: db}
+}
+
+func pendingTxnKey(nonce uint64) []byte {
+	key := make([]byte, len(pendingTxnKeyPrefix)+8)
+	copy(key, pendingTxnKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(pendingTxnKeyPrefix):], nonce)
+	return key
+}
+
+// put persists the current state of the request, replacing any request previously sent with the same nonce.
+func (s *TxnStore) put(req *TxnRequest) error {
+	txn := &pendingTxn{
+		Tag:         req.Tag,
+		Nonce:       req.Tx.Nonce(),
+		RequestedAt: req.requestedAt,
+		Attempts:    make([]*pendingTxnAttempt, len(req.txAttempts)),
+	}
+	switch metadata := req.Metadata.(type) {
+	case RecoveredTxnMetadata:
+		txn.Metadata = metadata
+	case PersistentTxnMetadata:
+		encoded, err := metadata.MarshalTxnMetadata()
+		if err != nil {
+			return fmt.Errorf("failed to encode transaction metadata: %w", err)
+		}
+		txn.Metadata = encoded
+	}
+	for i, attempt := range req.txAttempts {
+		tx, err := attempt.Transaction.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("failed to encode transaction: %w", err)
+		}
+		txn.Attempts[i] = &pendingTxnAttempt{
+			TxID:         attempt.TxID,
+			Tx:           tx,
+			Cancellation: attempt.cancellation,
+		}
+	}
+	value, err := json.Marshal(txn)
+	if err != nil {
+		return fmt.Errorf("failed to encode pending transaction: %w", err)
+	}
+	return s.db.Put(pendingTxnKey(txn.Nonce), value)
+}
+
+// delete removes the request from the store, unless another request has since been sent with the same nonce.
+func (s *TxnStore) delete(req *TxnRequest) error {
+	key := pendingTxnKey(req.Tx.Nonce())
+	value, err := s.db.Get(key)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var txn pendingTxn
+	if err := json.Unmarshal(value, &txn); err != nil {
+		return fmt.Errorf("failed to decode pending transaction: %w", err)
+	}
+	if len(txn.Attempts) > 0 && len(req.txAttempts) > 0 {
+		first := new(types.Transaction)
+		if err := first.UnmarshalBinary(txn.Attempts[0].Tx); err != nil {
+			return fmt.Errorf("failed to decode transaction: %w", err)
+		}
+		if first.Hash() != req.txAttempts[0].Hash() {
+			return nil
+		}
+	}
+	return s.db.Delete(key)
+}
+
+// list returns the persisted requests in increasing order of nonce.
+func (s *TxnStore) list() ([]*TxnRequest, error) {
+	it, err := s.db.NewIterator(pendingTxnKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	requests := make([]*TxnRequest, 0)
+	for it.Next() {
+		var txn pendingTxn
+		if err := json.Unmarshal(it.Value(), &txn); err != nil {
+			return nil, fmt.Errorf("failed to decode pending transaction: %w", err)
+		}
+		if len(txn.Attempts) == 0 {
+			return nil, fmt.Errorf("pending transaction with nonce %d has no attempts", txn.Nonce)
+		}
+
+		req := &TxnRequest{
+			Tag:         txn.Tag,
+			requestedAt: txn.RequestedAt,
+			txAttempts:  make([]*transaction, len(txn.Attempts)),
+		}
+		if len(txn.Metadata) > 0 {
+			req.Metadata = RecoveredTxnMetadata(txn.Metadata)
+		}
+		for i, attempt := range txn.Attempts {
+			tx := new(types.Transaction)
+			if err := tx.UnmarshalBinary(attempt.Tx); err != nil {
+				return nil, fmt.Errorf("failed to decode transaction: %w", err)
+			}
+			req.txAttempts[i] = &transaction{
+				Transaction:  tx,
+				TxID:         attempt.TxID,
+				requestedAt:  txn.RequestedAt,
+				cancellation: attempt.Cancellation,
+			}
+			req.cancelled = req.cancelled || attempt.Cancellation
+		}
+		req.Tx = req.txAttempts[len(req.txAttempts)-1].Transaction
+		req.Value = req.Tx.Value()
+		requests = append(requests, req)
+	}
+	return requests, it.Error()
+}
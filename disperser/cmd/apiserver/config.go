@@ -8,10 +8,12 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/redis"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
 )
 
@@ -25,8 +27,10 @@ const (
 type Config struct {
 	DisperserVersion            DisperserVersion
 	AwsClientConfig             aws.ClientConfig
+	RedisClientConfig           redis.ClientConfig
 	BlobstoreConfig             blobstore.Config
 	ServerConfig                disperser.ServerConfig
+	AdminConfig                 apiserver.AdminConfig
 	LoggerConfig                common.LoggerConfig
 	MetricsConfig               disperser.MetricsConfig
 	RatelimiterConfig           ratelimit.Config
@@ -44,6 +48,7 @@ type Config struct {
 	MaxBlobSize                 int
 	MaxNumSymbolsPerBlob        uint
 	OnchainStateRefreshInterval time.Duration
+	EncodingConfig              kzg.KzgConfig
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
@@ -70,12 +75,29 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		return Config{}, err
 	}
 
+	if ctx.GlobalString(flags.AdminGrpcPortFlag.Name) != "" && ctx.GlobalString(flags.AdminTokenFileFlag.Name) == "" {
+		return Config{}, fmt.Errorf("--%s is required when --%s is set", flags.AdminTokenFileFlag.Name, flags.AdminGrpcPortFlag.Name)
+	}
+
 	config := Config{
-		DisperserVersion: DisperserVersion(version),
-		AwsClientConfig:  aws.ReadClientConfig(ctx, flags.FlagPrefix),
+		DisperserVersion:  DisperserVersion(version),
+		AwsClientConfig:   aws.ReadClientConfig(ctx, flags.FlagPrefix),
+		RedisClientConfig: redis.ReadClientConfig(ctx, flags.FlagPrefix),
 		ServerConfig: disperser.ServerConfig{
 			GrpcPort:    ctx.GlobalString(flags.GrpcPortFlag.Name),
 			GrpcTimeout: ctx.GlobalDuration(flags.GrpcTimeoutFlag.Name),
+
+			StatusPollInterval:     ctx.GlobalDuration(flags.StatusPollIntervalFlag.Name),
+			MaxStatusSubscriptions: ctx.GlobalInt(flags.MaxStatusSubscriptionsFlag.Name),
+			BlobReapInterval:       ctx.GlobalDuration(flags.BlobReapIntervalFlag.Name),
+		},
+		AdminConfig: apiserver.AdminConfig{
+			GrpcPort:    ctx.GlobalString(flags.AdminGrpcPortFlag.Name),
+			BindAddress: ctx.GlobalString(flags.AdminBindAddressFlag.Name),
+			TokenFile:   ctx.GlobalString(flags.AdminTokenFileFlag.Name),
+			TLSCertFile: ctx.GlobalString(flags.AdminTLSCertFileFlag.Name),
+			TLSKeyFile:  ctx.GlobalString(flags.AdminTLSKeyFileFlag.Name),
+			ReplicaID:   ctx.GlobalString(flags.AdminReplicaIDFlag.Name),
 		},
 		BlobstoreConfig: blobstore.Config{
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
@@ -101,9 +123,15 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		MaxBlobSize:                 ctx.GlobalInt(flags.MaxBlobSize.Name),
 		MaxNumSymbolsPerBlob:        ctx.GlobalUint(flags.MaxNumSymbolsPerBlob.Name),
 		OnchainStateRefreshInterval: ctx.GlobalDuration(flags.OnchainStateRefreshInterval.Name),
+		EncodingConfig:              kzg.ReadCLIConfig(ctx),
 
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 	}
+	if config.DisperserVersion == V2 {
+		if err := kzg.ValidateSRSConfig(config.EncodingConfig); err != nil {
+			return Config{}, fmt.Errorf("the v2 disperser computes blob commitments: %w", err)
+		}
+	}
 	return config, nil
 }
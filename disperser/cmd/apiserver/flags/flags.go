@@ -7,7 +7,9 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/redis"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
 )
 
@@ -120,7 +122,7 @@ var (
 	}
 	BucketTableName = cli.StringFlag{
 		Name:   common.PrefixFlag(FlagPrefix, "rate-bucket-table-name"),
-		Usage:  "name of the dynamodb table to store rate limiter buckets. If not provided, a local store will be used",
+		Usage:  "name of the dynamodb table to store rate limiter buckets. If neither this nor a redis address is provided, a local store will be used",
 		Value:  "",
 		EnvVar: common.PrefixEnvVar(envVarPrefix, "RATE_BUCKET_TABLE_NAME"),
 	}
@@ -152,6 +154,64 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_NUM_SYMBOLS_PER_BLOB"),
 		Required: false,
 	}
+	AdminGrpcPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-grpc-port"),
+		Usage:    "Port at which the authenticated admin service listens for grpc calls. The admin service is disabled if not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMIN_GRPC_PORT"),
+	}
+	AdminBindAddressFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-bind-address"),
+		Usage:    "Address at which the admin service listens. Listening on a non-loopback address requires TLS",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMIN_BIND_ADDRESS"),
+		Value:    apiserver.DefaultAdminBindAddress,
+	}
+	AdminTokenFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-token-file"),
+		Usage:    "Path to a file containing the bearer token that admin service requests must present. Required if the admin service is enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMIN_TOKEN_FILE"),
+	}
+	AdminTLSCertFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-tls-cert-file"),
+		Usage:    "Path to the TLS certificate of the admin service. The admin service serves TLS if this and the key file are set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMIN_TLS_CERT_FILE"),
+	}
+	AdminTLSKeyFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-tls-key-file"),
+		Usage:    "Path to the TLS private key of the admin service",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMIN_TLS_KEY_FILE"),
+	}
+	AdminReplicaIDFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-replica-id"),
+		Usage:    "ID of this replica, which admin requests must name in the x-admin-replica header since admin changes are local to a replica. Defaults to the hostname",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ADMIN_REPLICA_ID"),
+	}
+	StatusPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "status-poll-interval"),
+		Usage:    "Interval at which the status of the blobs with open status subscriptions is read from the metadata store",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "STATUS_POLL_INTERVAL"),
+		Value:    1 * time.Second,
+	}
+	MaxStatusSubscriptionsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-status-subscriptions"),
+		Usage:    "Maximum number of concurrent blob status subscriptions. 0 means no limit",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_STATUS_SUBSCRIPTIONS"),
+		Value:    10000,
+	}
+	BlobReapIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-reap-interval"),
+		Usage:    "Interval at which expired v2 blobs are deleted along with their data. 0 disables the deletion of expired blobs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_REAP_INTERVAL"),
+		Value:    10 * time.Minute,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -177,6 +237,15 @@ var optionalFlags = []cli.Flag{
 	GlobalRateTableName,
 	OnchainStateRefreshInterval,
 	MaxNumSymbolsPerBlob,
+	AdminGrpcPortFlag,
+	AdminBindAddressFlag,
+	AdminTokenFileFlag,
+	AdminTLSCertFileFlag,
+	AdminTLSKeyFileFlag,
+	AdminReplicaIDFlag,
+	StatusPollIntervalFlag,
+	MaxStatusSubscriptionsFlag,
+	BlobReapIntervalFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -188,5 +257,8 @@ func init() {
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, ratelimit.RatelimiterCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, redis.ClientFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, apiserver.CLIFlags(envVarPrefix)...)
+	// The SRS is only loaded by the v2 server, to compute blob commitments
+	Flags = append(Flags, kzg.OptionalCLIFlags(envVarPrefix)...)
 }
@@ -13,17 +13,20 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding/fft"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/redis"
 	"github.com/Layr-Labs/eigenda/common/store"
 	authv2 "github.com/Layr-Labs/eigenda/core/auth/v2"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
+	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 )
@@ -93,6 +96,7 @@ func RunDisperserServer(ctx *cli.Context) error {
 	}
 
 	reg := prometheus.NewRegistry()
+	config.RateConfig.MetricsRegistry = reg
 
 	var meterer *mt.Meterer
 	if config.EnablePaymentMeterer {
@@ -134,11 +138,27 @@ func RunDisperserServer(ctx *cli.Context) error {
 		globalParams := config.RatelimiterConfig.GlobalRateParams
 
 		var bucketStore common.KVStore[common.RateBucketParams]
-		if config.BucketTableName != "" {
+		if config.RedisClientConfig.Address != "" {
+			redisClient, err := redis.NewClient(config.RedisClientConfig)
+			if err != nil {
+				return fmt.Errorf("failed to create redis client: %w", err)
+			}
+			// A requester whose buckets have been idle for as long as the largest bucket has full buckets, so
+			// there's no need to keep them for longer.
+			ttl := time.Duration(0)
+			for _, size := range globalParams.BucketSizes {
+				ttl = max(ttl, size)
+			}
+			bucketStore = store.NewRedisBucketStore(redisClient, "ratelimit:", ttl)
+		} else if config.BucketTableName != "" {
 			dynamoClient, err := dynamodb.NewClient(config.AwsClientConfig, logger)
 			if err != nil {
 				return err
 			}
+			err = dynamodb.EnsureTable(context.Background(), dynamoClient, store.GenerateTableSchema(10, 10, config.BucketTableName))
+			if err != nil {
+				return fmt.Errorf("failed to create bucket table: %w", err)
+			}
 			bucketStore = store.NewDynamoParamStore[common.RateBucketParams](dynamoClient, config.BucketTableName)
 		} else {
 			bucketStore, err = store.NewLocalParamStore[common.RateBucketParams](config.BucketStoreSize)
@@ -160,24 +180,43 @@ func RunDisperserServer(ctx *cli.Context) error {
 	bucketName := config.BlobstoreConfig.BucketName
 	logger.Info("Blob store", "bucket", bucketName)
 	if config.DisperserVersion == V2 {
+		err = dynamodb.EnsureTable(context.Background(), dynamoClient, blobstorev2.GenerateTableSchema(config.BlobstoreConfig.TableName, 10, 10))
+		if err != nil {
+			return fmt.Errorf("failed to create blob metadata table: %w", err)
+		}
 		blobMetadataStore := blobstorev2.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName)
 		blobStore := blobstorev2.NewBlobStore(bucketName, s3Client, logger)
 
+		// The prover is only used to compute blob commitments, which requires the G2 points for the length proof.
+		prover, err := prover.NewProver(&config.EncodingConfig, true)
+		if err != nil {
+			return fmt.Errorf("failed to create prover: %w", err)
+		}
+
 		server := apiserver.NewDispersalServerV2(
 			config.ServerConfig,
 			config.RateConfig,
 			blobStore,
 			blobMetadataStore,
 			transactor,
+			meterer,
 			ratelimiter,
 			authv2.NewAuthenticator(),
+			prover,
 			uint64(config.MaxNumSymbolsPerBlob),
 			config.OnchainStateRefreshInterval,
 			logger,
 		)
+		if err := startAdminServer(config.AdminConfig, server.NewAdminServer, logger); err != nil {
+			return err
+		}
 		return server.Start(context.Background())
 	}
 
+	err = dynamodb.EnsureTable(context.Background(), dynamoClient, blobstore.GenerateTableSchema(config.BlobstoreConfig.TableName, 10, 10))
+	if err != nil {
+		return fmt.Errorf("failed to create blob metadata table: %w", err)
+	}
 	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second)
 	blobStore := blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
 
@@ -201,5 +240,29 @@ func RunDisperserServer(ctx *cli.Context) error {
 		logger.Info("Enabled metrics for Disperser", "socket", httpSocket)
 	}
 
+	if err := startAdminServer(config.AdminConfig, server.NewAdminServer, logger); err != nil {
+		return err
+	}
 	return server.Start(context.Background())
 }
+
+// startAdminServer starts the admin service in the background if an admin port is configured.
+func startAdminServer(
+	config apiserver.AdminConfig,
+	newAdminServer func(apiserver.AdminConfig) (*apiserver.AdminServer, error),
+	logger logging.Logger,
+) error {
+	if config.GrpcPort == "" {
+		return nil
+	}
+	adminServer, err := newAdminServer(config)
+	if err != nil {
+		return fmt.Errorf("failed to create admin server: %w", err)
+	}
+	go func() {
+		if err := adminServer.Start(context.Background()); err != nil {
+			logger.Error("admin server stopped", "err", err)
+		}
+	}()
+	return nil
+}
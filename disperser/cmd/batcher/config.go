@@ -8,6 +8,7 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/batcher/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
@@ -25,6 +26,7 @@ type Config struct {
 	IndexerConfig    indexer.Config
 	KMSKeyConfig     common.KMSKeyConfig
 	ChainStateConfig thegraph.Config
+	WebhookConfig    events.WebhookConfig
 	UseGraph         bool
 
 	IndexerDataDir string
@@ -67,6 +69,8 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			TargetNumChunks:          ctx.GlobalUint(flags.TargetNumChunksFlag.Name),
 			MaxBlobsToFetchFromStore: ctx.GlobalInt(flags.MaxBlobsToFetchFromStoreFlag.Name),
 			FinalizationBlockDelay:   ctx.GlobalUint(flags.FinalizationBlockDelayFlag.Name),
+			TxnStorePath:             ctx.GlobalString(flags.TxnStorePathFlag.Name),
+			CancelTxnAfterSpeedUps:   ctx.GlobalInt(flags.CancelTxnAfterSpeedUpsFlag.Name),
 		},
 		TimeoutConfig: batcher.TimeoutConfig{
 			EncodingTimeout:     ctx.GlobalDuration(flags.EncodingTimeoutFlag.Name),
@@ -81,6 +85,7 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			EnableMetrics: ctx.GlobalBool(flags.EnableMetrics.Name),
 		},
 		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
+		WebhookConfig:                 events.ReadWebhookConfig(ctx, flags.FlagPrefix),
 		UseGraph:                      ctx.Bool(flags.UseGraphFlag.Name),
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
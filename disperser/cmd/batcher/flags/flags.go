@@ -7,6 +7,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
 )
@@ -213,6 +214,20 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_NUM_RETRIES_PER_DISPERSAL"),
 		Value:    3,
 	}
+	TxnStorePathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "txn-store-path"),
+		Usage:    "Directory in which pending transactions are persisted, to be reconciled against the chain after a restart. Pending transactions are kept in memory only if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "TXN_STORE_PATH"),
+		Value:    "",
+	}
+	CancelTxnAfterSpeedUpsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "cancel-txn-after-speedups"),
+		Usage:    "Cancel a transaction with a self-transfer once it has been sped up this many times without being mined. Pending transactions recovered after a restart are cancelled as well. Disabled if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CANCEL_TXN_AFTER_SPEEDUPS"),
+		Value:    0,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -248,6 +263,8 @@ var optionalFlags = []cli.Flag{
 	MaxNodeConnectionsFlag,
 	MaxNumRetriesPerDispersalFlag,
 	EnableGnarkBundleEncodingFlag,
+	TxnStorePathFlag,
+	CancelTxnAfterSpeedUpsFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -261,4 +278,5 @@ func init() {
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envVarPrefix)...)
 	Flags = append(Flags, common.KMSWalletCLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, events.WebhookFlags(envVarPrefix, FlagPrefix)...)
 }
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -15,12 +17,14 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
 	"github.com/Layr-Labs/eigenda/core"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
 	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/batcher/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
 	"github.com/Layr-Labs/eigensdk-go/aws/kms"
 	walletsdk "github.com/Layr-Labs/eigensdk-go/chainio/clients/wallet"
@@ -56,15 +60,6 @@ func main() {
 	if err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	if _, err := os.Create(healthProbePath); err != nil {
-		log.Printf("Failed to create healthProbe file: %v", err)
-	}
-
-	// Start HeartBeat Monitor
-	go heartbeatMonitor(healthProbePath, maxStallDuration)
-
-	select {}
 }
 
 func RunBatcher(ctx *cli.Context) error {
@@ -192,6 +187,10 @@ func RunBatcher(ctx *cli.Context) error {
 	if err != nil || storeDurationBlocks == 0 {
 		return fmt.Errorf("failed to get STORE_DURATION_BLOCKS: %w", err)
 	}
+	err = dynamodb.EnsureTable(context.Background(), dynamoClient, blobstore.GenerateTableSchema(config.BlobstoreConfig.TableName, 10, 10))
+	if err != nil {
+		return fmt.Errorf("failed to create blob metadata table: %w", err)
+	}
 	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second)
 	queue := blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
 
@@ -229,8 +228,23 @@ func RunBatcher(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	finalizer := batcher.NewFinalizer(config.TimeoutConfig.ChainReadTimeout, config.BatcherConfig.FinalizerInterval, queue, client, rpcClient, config.BatcherConfig.MaxNumRetriesPerBlob, 1000, config.BatcherConfig.FinalizerPoolSize, logger, metrics.FinalizerMetrics)
-	txnManager := batcher.NewTxnManager(client, wallet, config.EthClientConfig.NumConfirmations, 20, config.TimeoutConfig.TxnBroadcastTimeout, config.TimeoutConfig.ChainWriteTimeout, logger, metrics.TxnManagerMetrics)
+	// The event sink shuts down when the process is asked to terminate
+	shutdownCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	eventSink, err := events.NewSink(shutdownCtx, config.WebhookConfig, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create event sink: %w", err)
+	}
+	finalizer := batcher.NewFinalizer(config.TimeoutConfig.ChainReadTimeout, config.BatcherConfig.FinalizerInterval, queue, client, rpcClient, config.BatcherConfig.MaxNumRetriesPerBlob, 1000, config.BatcherConfig.FinalizerPoolSize, logger, metrics.FinalizerMetrics, eventSink)
+	var txnStore *batcher.TxnStore
+	if config.BatcherConfig.TxnStorePath != "" {
+		db, err := leveldb.NewStore(logger, config.BatcherConfig.TxnStorePath)
+		if err != nil {
+			return fmt.Errorf("failed to open transaction store: %w", err)
+		}
+		txnStore = batcher.NewTxnStore(db)
+	}
+	txnManager := batcher.NewTxnManager(client, wallet, config.EthClientConfig.NumConfirmations, 20, config.TimeoutConfig.TxnBroadcastTimeout, config.TimeoutConfig.ChainWriteTimeout, txnStore, config.BatcherConfig.CancelTxnAfterSpeedUps, logger, metrics.TxnManagerMetrics)
 
 	// Enable Metrics Block
 	if config.MetricsConfig.EnableMetrics {
@@ -239,7 +253,7 @@ func RunBatcher(ctx *cli.Context) error {
 		logger.Info("Enabled metrics for Batcher", "socket", httpSocket)
 	}
 
-	batcher, err := batcher.NewBatcher(config.BatcherConfig, config.TimeoutConfig, queue, dispatcher, ics, asgn, encoderClient, agg, client, finalizer, tx, txnManager, logger, metrics, handleBatchLivenessChan)
+	batcher, err := batcher.NewBatcher(config.BatcherConfig, config.TimeoutConfig, queue, dispatcher, ics, asgn, encoderClient, agg, client, finalizer, tx, txnManager, logger, metrics, handleBatchLivenessChan, eventSink)
 	if err != nil {
 		return err
 	}
@@ -252,6 +266,19 @@ func RunBatcher(ctx *cli.Context) error {
 	if _, err := os.Create(readinessProbePath); err != nil {
 		log.Printf("Failed to create readiness file: %v at path %v \n", err, readinessProbePath)
 	}
+
+	if _, err := os.Create(healthProbePath); err != nil {
+		log.Printf("Failed to create healthProbe file: %v", err)
+	}
+
+	// Start HeartBeat Monitor
+	go heartbeatMonitor(healthProbePath, maxStallDuration)
+
+	// Run until the process is asked to terminate, and give the event sink the chance to dead-letter the deliveries
+	// which are still pending before exiting
+	<-shutdownCtx.Done()
+	logger.Info("Shutting down batcher")
+	events.Wait(eventSink)
 	return nil
 }
 
@@ -9,6 +9,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/controller/flags"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
@@ -30,6 +31,7 @@ type Config struct {
 	LoggerConfig     common.LoggerConfig
 	IndexerConfig    indexer.Config
 	ChainStateConfig thegraph.Config
+	WebhookConfig    events.WebhookConfig
 	UseGraph         bool
 	IndexerDataDir   string
 
@@ -82,6 +84,7 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		NodeClientCacheSize:            ctx.GlobalInt(flags.NodeClientCacheNumEntriesFlag.Name),
 		IndexerConfig:                  indexer.ReadIndexerConfig(ctx),
 		ChainStateConfig:               thegraph.ReadCLIConfig(ctx),
+		WebhookConfig:                  events.ReadWebhookConfig(ctx, flags.FlagPrefix),
 		UseGraph:                       ctx.GlobalBool(flags.UseGraphFlag.Name),
 		IndexerDataDir:                 ctx.GlobalString(flags.IndexerDataDirFlag.Name),
 
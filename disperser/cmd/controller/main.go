@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
@@ -14,6 +16,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core/indexer"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/controller/flags"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -41,7 +44,6 @@ func main() {
 	if err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-	select {}
 }
 
 func RunController(ctx *cli.Context) error {
@@ -69,6 +71,10 @@ func RunController(ctx *cli.Context) error {
 		return err
 	}
 
+	err = dynamodb.EnsureTable(context.Background(), dynamoClient, blobstore.GenerateTableSchema(config.DynamoDBTableName, 10, 10))
+	if err != nil {
+		return fmt.Errorf("failed to create blob metadata table: %w", err)
+	}
 	blobMetadataStore := blobstore.NewBlobMetadataStore(
 		dynamoClient,
 		logger,
@@ -125,6 +131,13 @@ func RunController(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to create node client manager: %v", err)
 	}
+	// The event sink shuts down when the process is asked to terminate
+	shutdownCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	eventSink, err := events.NewSink(shutdownCtx, config.WebhookConfig, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create event sink: %v", err)
+	}
 	dispatcher, err := controller.NewDispatcher(
 		config.DispatcherConfig,
 		blobMetadataStore,
@@ -132,6 +145,7 @@ func RunController(ctx *cli.Context) error {
 		ics,
 		sigAgg,
 		nodeClientManager,
+		eventSink,
 		logger,
 	)
 	if err != nil {
@@ -149,5 +163,10 @@ func RunController(ctx *cli.Context) error {
 		return fmt.Errorf("failed to start dispatcher: %v", err)
 	}
 
+	// Run until the process is asked to terminate, and give the event sink the chance to dead-letter the deliveries
+	// which are still pending before exiting
+	<-shutdownCtx.Done()
+	logger.Info("Shutting down controller")
+	events.Wait(eventSink)
 	return nil
 }
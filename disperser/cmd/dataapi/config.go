@@ -1,6 +1,8 @@
 package main
 
 import (
+	"errors"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
@@ -9,6 +11,7 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/prometheus"
+	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
 )
 
@@ -20,6 +23,7 @@ type Config struct {
 	PrometheusConfig prometheus.Config
 	MetricsConfig    dataapi.MetricsConfig
 	ChainStateConfig thegraph.Config
+	IndexerConfig    indexer.Config
 
 	SocketAddr                   string
 	PrometheusApiAddr            string
@@ -27,10 +31,17 @@ type Config struct {
 	SubgraphApiOperatorStateAddr string
 	ServerMode                   string
 	AllowOrigins                 []string
+	V2TableName                  string
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 
+	UseChainIndexer    bool
+	ChainIndexerDBPath string
+	// ChainIndexerHeaderStorePath is where the chain indexer keeps block headers and its progress.
+	ChainIndexerHeaderStorePath string
+	ChainIndexerStartBlock      uint64
+
 	DisperserHostname  string
 	ChurnerHostname    string
 	BatcherHealthEndpt string
@@ -47,6 +58,7 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
 			TableName:  ctx.GlobalString(flags.DynamoTableNameFlag.Name),
 		},
+		V2TableName:                   ctx.GlobalString(flags.DynamoV2TableNameFlag.Name),
 		AwsClientConfig:               aws.ReadClientConfig(ctx, flags.FlagPrefix),
 		EthClientConfig:               ethClientConfig,
 		LoggerConfig:                  *loggerConfig,
@@ -72,6 +84,22 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		ChurnerHostname:    ctx.GlobalString(flags.ChurnerHostnameFlag.Name),
 		BatcherHealthEndpt: ctx.GlobalString(flags.BatcherHealthEndptFlag.Name),
 		ChainStateConfig:   thegraph.ReadCLIConfig(ctx),
+		IndexerConfig:      indexer.ReadIndexerConfig(ctx),
+
+		UseChainIndexer:             ctx.GlobalBool(flags.UseChainIndexerFlag.Name),
+		ChainIndexerDBPath:          ctx.GlobalString(flags.ChainIndexerDBPathFlag.Name),
+		ChainIndexerHeaderStorePath: ctx.GlobalString(flags.ChainIndexerHeaderStorePathFlag.Name),
+		ChainIndexerStartBlock:      ctx.GlobalUint64(flags.ChainIndexerStartBlockFlag.Name),
+	}
+	if config.UseChainIndexer {
+		if config.ChainIndexerDBPath == "" {
+			return Config{}, errors.New("chain indexer db path must be specified when the chain indexer is used")
+		}
+		if config.ChainIndexerHeaderStorePath == "" {
+			config.ChainIndexerHeaderStorePath = config.ChainIndexerDBPath + "-headers"
+		}
+	} else if config.SubgraphApiBatchMetadataAddr == "" || config.SubgraphApiOperatorStateAddr == "" {
+		return Config{}, errors.New("subgraph socket addresses must be specified unless the chain indexer is used")
 	}
 	return config, nil
 }
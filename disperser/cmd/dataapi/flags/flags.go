@@ -5,6 +5,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
 )
 
@@ -60,16 +61,16 @@ var (
 	SubgraphApiBatchMetadataAddrFlag = cli.StringFlag{
 		Name: common.PrefixFlag(FlagPrefix, "sub-batch-metadata-socket-addr"),
 		//We need the socket address of the subgraph batch metadata api to pull the subgraph data from.
-		Usage:    "the socket address of the subgraph batch metadata api",
+		Usage:    "the socket address of the subgraph batch metadata api. Required unless the chain indexer is used",
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SUBGRAPH_BATCH_METADATA_API_SOCKET_ADDR"),
-		Required: true,
+		Required: false,
 	}
 	SubgraphApiOperatorStateAddrFlag = cli.StringFlag{
 		Name: common.PrefixFlag(FlagPrefix, "sub-op-state-socket-addr"),
 		//We need the socket address of the subgraph operator state api to pull the subgraph data from.
-		Usage:    "the socket address of the subgraph operator state api",
+		Usage:    "the socket address of the subgraph operator state api. Required unless the chain indexer is used",
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SUBGRAPH_OPERATOR_STATE_API_SOCKET_ADDR"),
-		Required: true,
+		Required: false,
 	}
 	BlsOperatorStateRetrieverFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever"),
@@ -132,14 +133,42 @@ var (
 		Value:    "9100",
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METRICS_HTTP_PORT"),
 	}
+	DynamoV2TableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dynamo-v2-table-name"),
+		Usage:    "Name of the dynamo table to store v2 blob metadata. The v2 endpoints are disabled if not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DYNAMO_V2_TABLE_NAME"),
+	}
+	UseChainIndexerFlag = cli.BoolFlag{
+		Name:   common.PrefixFlag(FlagPrefix, "use-chain-indexer"),
+		Usage:  "Build the batch, non-signer, operator registration and ejection views from on-chain events with the built-in indexer instead of querying the subgraphs",
+		EnvVar: common.PrefixEnvVar(envVarPrefix, "USE_CHAIN_INDEXER"),
+	}
+	ChainIndexerDBPathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chain-indexer-db-path"),
+		Usage:    "Path of the database in which the chain indexer stores the indexed events. Required if the chain indexer is used",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHAIN_INDEXER_DB_PATH"),
+	}
+	ChainIndexerHeaderStorePathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chain-indexer-header-store-path"),
+		Usage:    "Path of the database in which the chain indexer stores block headers and its progress, so that it resumes from the last indexed block after a restart. Defaults to the chain indexer db path with a \"-headers\" suffix",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHAIN_INDEXER_HEADER_STORE_PATH"),
+	}
+	ChainIndexerStartBlockFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "chain-indexer-start-block"),
+		Usage:    "Block from which the chain indexer indexes events, e.g. the block at which the EigenDA contracts were deployed",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHAIN_INDEXER_START_BLOCK"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
 	DynamoTableNameFlag,
 	SocketAddrFlag,
 	S3BucketNameFlag,
-	SubgraphApiBatchMetadataAddrFlag,
-	SubgraphApiOperatorStateAddrFlag,
 	BlsOperatorStateRetrieverFlag,
 	EigenDAServiceManagerFlag,
 	PrometheusServerURLFlag,
@@ -156,6 +185,13 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	ServerModeFlag,
 	MetricsHTTPPort,
+	DynamoV2TableNameFlag,
+	SubgraphApiBatchMetadataAddrFlag,
+	SubgraphApiOperatorStateAddrFlag,
+	UseChainIndexerFlag,
+	ChainIndexerDBPathFlag,
+	ChainIndexerHeaderStorePathFlag,
+	ChainIndexerStartBlockFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -167,4 +203,5 @@ func init() {
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, indexer.CLIFlags(envVarPrefix)...)
 }
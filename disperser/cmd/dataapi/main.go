@@ -12,15 +12,22 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
+	"github.com/Layr-Labs/eigenda/core"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
+	coreindexer "github.com/Layr-Labs/eigenda/core/indexer"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/dataapi/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/chainindexer"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/prometheus"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	indexerleveldb "github.com/Layr-Labs/eigenda/indexer/leveldb"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli"
 )
 
@@ -73,6 +80,20 @@ func RunDataApi(ctx *cli.Context) error {
 		return err
 	}
 
+	err = dynamodb.EnsureTable(context.Background(), dynamoClient, blobstore.GenerateTableSchema(config.BlobstoreConfig.TableName, 10, 10))
+	if err != nil {
+		return fmt.Errorf("failed to create blob metadata table: %w", err)
+	}
+
+	var blobMetadataStoreV2 *blobstorev2.BlobMetadataStore
+	if config.V2TableName != "" {
+		err = dynamodb.EnsureTable(context.Background(), dynamoClient, blobstorev2.GenerateTableSchema(config.V2TableName, 10, 10))
+		if err != nil {
+			return fmt.Errorf("failed to create v2 blob metadata table: %w", err)
+		}
+		blobMetadataStoreV2 = blobstorev2.NewBlobMetadataStore(dynamoClient, logger, config.V2TableName)
+	}
+
 	promApi, err := prometheus.NewApi(config.PrometheusConfig)
 	if err != nil {
 		return err
@@ -88,14 +109,74 @@ func RunDataApi(ctx *cli.Context) error {
 		return err
 	}
 
+	chainState := coreeth.NewChainState(tx, client)
+
+	var (
+		subgraphApi        subgraph.Api
+		indexedChainState  core.IndexedChainState
+		chainIndexerHealth *chainindexer.Health
+	)
+	if config.UseChainIndexer {
+		logger.Info("Using built-in chain indexer", "path", config.ChainIndexerDBPath)
+
+		rpcClient, err := rpc.Dial(config.EthClientConfig.RPCURLs[0])
+		if err != nil {
+			return err
+		}
+		db, err := leveldb.NewStore(logger, config.ChainIndexerDBPath)
+		if err != nil {
+			return fmt.Errorf("failed to open chain indexer db: %w", err)
+		}
+		defer func() {
+			if err := db.Shutdown(); err != nil {
+				logger.Error("Failed to shutdown chain indexer db", "err", err)
+			}
+		}()
+
+		headerStore, err := indexerleveldb.NewHeaderStore(config.ChainIndexerHeaderStorePath)
+		if err != nil {
+			return fmt.Errorf("failed to open chain indexer header store: %w", err)
+		}
+		defer headerStore.Close()
+
+		store := chainindexer.NewStore(db)
+		chainIndexerHealth = chainindexer.NewHealth()
+		chainIndexer, err := chainindexer.CreateNewIndexer(
+			&config.IndexerConfig,
+			store,
+			headerStore,
+			chainIndexerHealth,
+			client,
+			rpcClient,
+			config.EigenDAServiceManagerAddr,
+			config.ChainIndexerStartBlock,
+			logger,
+		)
+		if err != nil {
+			return err
+		}
+		ics, err := coreindexer.NewIndexedChainState(chainState, chainIndexer)
+		if err != nil {
+			return err
+		}
+		if err := ics.Start(context.Background()); err != nil {
+			return fmt.Errorf("failed to start chain indexer: %w", err)
+		}
+
+		subgraphApi = chainindexer.NewApi(store)
+		indexedChainState = ics
+	} else {
+		logger.Info("Connecting to subgraph", "url", config.ChainStateConfig.Endpoint)
+
+		subgraphApi = subgraph.NewApi(config.SubgraphApiBatchMetadataAddr, config.SubgraphApiOperatorStateAddr)
+		indexedChainState = thegraph.MakeIndexedChainState(config.ChainStateConfig, chainState, logger)
+	}
+
 	var (
 		promClient        = dataapi.NewPrometheusClient(promApi, config.PrometheusConfig.Cluster)
 		blobMetadataStore = blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, 0)
 		sharedStorage     = blobstore.NewSharedStorage(config.BlobstoreConfig.BucketName, s3Client, blobMetadataStore, logger)
-		subgraphApi       = subgraph.NewApi(config.SubgraphApiBatchMetadataAddr, config.SubgraphApiOperatorStateAddr)
 		subgraphClient    = dataapi.NewSubgraphClient(subgraphApi, logger)
-		chainState        = coreeth.NewChainState(tx, client)
-		indexedChainState = thegraph.MakeIndexedChainState(config.ChainStateConfig, chainState, logger)
 		metrics           = dataapi.NewMetrics(blobMetadataStore, config.MetricsConfig.HTTPPort, logger)
 		server            = dataapi.NewServer(
 			dataapi.Config{
@@ -105,8 +186,10 @@ func RunDataApi(ctx *cli.Context) error {
 				DisperserHostname:  config.DisperserHostname,
 				ChurnerHostname:    config.ChurnerHostname,
 				BatcherHealthEndpt: config.BatcherHealthEndpt,
+				ChainIndexerHealth: chainIndexerHealth,
 			},
 			sharedStorage,
+			blobMetadataStoreV2,
 			promClient,
 			subgraphClient,
 			tx,
@@ -0,0 +1,452 @@
+package allowlist
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// BlobRateMultiplier converts a blob rate in blobs/sec into the integer rate used by the rate limiter. The allowlist
+// file specifies blob rates in blobs/sec, but internally we use blobs/sec * 1e6 (i.e. blobs/microsec).
+const BlobRateMultiplier = 1e6
+
+// Entry is a single entry of an allowlist file. The file is a JSON array of entries.
+type Entry struct {
+	Name     string  `json:"name"`
+	Account  string  `json:"account"`
+	QuorumID uint8   `json:"quorumID"`
+	BlobRate float64 `json:"blobRate"`
+	ByteRate float64 `json:"byteRate"`
+}
+
+// RateInfo is the rate granted to an allowlisted account for a single quorum.
+type RateInfo struct {
+	Name       string
+	Throughput common.RateParam
+	BlobRate   common.RateParam
+}
+
+// Allowlist maps a (lowercase) account, which is either an ethereum address or an IP address, to the rates granted
+// to that account for each quorum.
+type Allowlist = map[string]map[core.QuorumID]RateInfo
+
+// LineError is a problem with an allowlist file at a particular line.
+type LineError struct {
+	// Line is the 1-indexed line of the file on which the problem was found.
+	Line int
+	// Message describes the problem.
+	Message string
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+}
+
+// ValidationError is returned when an allowlist file is malformed. It contains every problem that was found, so that
+// they can all be fixed in one go.
+type ValidationError struct {
+	Errors []*LineError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("invalid allowlist: %s", strings.Join(messages, "; "))
+}
+
+// ReadFile reads, validates and parses the allowlist file at the given path. An empty path yields an empty allowlist.
+func ReadFile(path string) (Allowlist, error) {
+	allowlist, _, err := readFile(path, true)
+	return allowlist, err
+}
+
+// ReadFileLenient reads and parses the allowlist file at the given path like ReadFile, but tolerates the problems
+// that files written before the allowlist was validated may have (see ParseLenient). The tolerated problems are
+// returned as warnings.
+func ReadFileLenient(path string) (Allowlist, []*LineError, error) {
+	return readFile(path, false)
+}
+
+func readFile(path string, strict bool) (Allowlist, []*LineError, error) {
+	if path == "" {
+		return make(Allowlist), nil, nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read allowlist file: %w", err)
+	}
+
+	allowlist, warnings, err := parse(content, strict)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse allowlist file: %w", err)
+	}
+	return allowlist, warnings, nil
+}
+
+// Parse validates and parses the content of an allowlist file. If the content is malformed a *ValidationError is
+// returned. The content is rejected if
+//   - it is not a JSON array of entries, or an entry has a field that is not part of Entry,
+//   - an entry has no name,
+//   - an account is neither a 0x-prefixed ethereum address nor an IP address,
+//   - a rate is negative, too large to be represented, or both rates of an entry are zero,
+//   - an account appears more than once for the same quorum.
+func Parse(content []byte) (Allowlist, error) {
+	allowlist, _, err := parse(content, true)
+	return allowlist, err
+}
+
+// ParseLenient parses the content of an allowlist file like Parse, but tolerates the problems that files written
+// before the allowlist was validated may have, and returns them as warnings instead:
+//   - unknown fields are ignored,
+//   - entries may have no name, or have both rates zero,
+//   - a later entry for the same account and quorum replaces an earlier one.
+//
+// Other problems are still rejected with a *ValidationError. It is meant for loading the allowlist at startup, so
+// that an existing file doesn't prevent the disperser from starting; reloads should use Parse.
+func ParseLenient(content []byte) (Allowlist, []*LineError, error) {
+	return parse(content, false)
+}
+
+func parse(content []byte, strict bool) (Allowlist, []*LineError, error) {
+	entries, locations, decodeErrors, err := decode(content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allowlist := make(Allowlist)
+	// the line on which each account/quorum pair was first defined
+	definedAt := make(map[string]map[core.QuorumID]int)
+	validationErr := &ValidationError{Errors: decodeErrors}
+	warnings := make([]*LineError, 0)
+	// report adds a problem to the errors, or to the warnings if it is tolerated
+	report := func(lineErr *LineError, tolerated bool) {
+		if tolerated && !strict {
+			warnings = append(warnings, lineErr)
+		} else {
+			validationErr.Errors = append(validationErr.Errors, lineErr)
+		}
+	}
+
+	for i, entry := range entries {
+		location := locations[i]
+		for _, field := range location.unknown {
+			report(&LineError{
+				Line:    location.line(field),
+				Message: fmt.Sprintf("entry %d: unknown field %q", location.index, field),
+			}, true)
+		}
+		if strict && len(location.unknown) > 0 {
+			// the other fields may have been misspelled, so the entry isn't validated any further
+			continue
+		}
+
+		rejected := false
+		for _, problem := range validateEntry(entry) {
+			report(&LineError{
+				Line:    location.line(problem.field),
+				Message: fmt.Sprintf("entry %d: %s", location.index, problem.message),
+			}, problem.tolerated)
+			rejected = rejected || strict || !problem.tolerated
+		}
+		if rejected {
+			continue
+		}
+
+		// normalize to lowercase (non-checksummed) address or IP address
+		account := strings.ToLower(entry.Account)
+		quorumID := core.QuorumID(entry.QuorumID)
+
+		if _, ok := definedAt[account]; !ok {
+			definedAt[account] = make(map[core.QuorumID]int)
+			allowlist[account] = make(map[core.QuorumID]RateInfo)
+		}
+		if firstLine, ok := definedAt[account][quorumID]; ok {
+			report(&LineError{
+				Line: location.start,
+				Message: fmt.Sprintf("entry %d: duplicate entry for account %s and quorum %d (first defined on line %d)",
+					location.index, account, quorumID, firstLine),
+			}, true)
+			if strict {
+				continue
+			}
+		} else {
+			definedAt[account][quorumID] = location.start
+		}
+
+		allowlist[account][quorumID] = rateInfo(entry)
+	}
+
+	if len(validationErr.Errors) > 0 {
+		return nil, nil, validationErr
+	}
+	return allowlist, warnings, nil
+}
+
+// ValidateEntry checks a single entry with the same rules that Parse applies to each entry of a file, and returns the
+// account, normalized to lowercase, and the rate the entry grants.
+func ValidateEntry(entry Entry) (string, RateInfo, error) {
+	problems := validateEntry(entry)
+	if len(problems) > 0 {
+		messages := make([]string, len(problems))
+		for i, problem := range problems {
+			messages[i] = problem.message
+		}
+		return "", RateInfo{}, errors.New(strings.Join(messages, "; "))
+	}
+	return strings.ToLower(entry.Account), rateInfo(entry), nil
+}
+
+// rateInfo converts a valid entry into the rate it grants.
+func rateInfo(entry Entry) RateInfo {
+	return RateInfo{
+		Name:       entry.Name,
+		Throughput: common.RateParam(entry.ByteRate),
+		BlobRate:   common.RateParam(entry.BlobRate * BlobRateMultiplier),
+	}
+}
+
+// entryLines records where an entry is located in the file, so that problems can be reported against the line of
+// the offending field.
+type entryLines struct {
+	// index is the position of the entry in the file.
+	index int
+	// start is the line on which the entry starts.
+	start int
+	// fields maps a JSON field name to the line on which it appears.
+	fields map[string]int
+	// unknown lists the fields of the entry that are not part of Entry.
+	unknown []string
+}
+
+// line returns the line of the given field, or the first line of the entry if the field is not present.
+func (l *entryLines) line(field string) int {
+	if line, ok := l.fields[field]; ok {
+		return line
+	}
+	return l.start
+}
+
+// decode decodes the entries of an allowlist file, along with their locations in the file. Unknown fields are
+// recorded in the locations, so that a misspelled field doesn't silently fall back to its zero value. Entries that
+// can't be decoded are skipped and their problems are returned, so that the remaining entries can still be validated.
+// If the file isn't a syntactically valid JSON array, an error is returned.
+func decode(content []byte) ([]Entry, []*entryLines, []*LineError, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+
+	token, err := decoder.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, nil, &ValidationError{Errors: []*LineError{{Line: 1, Message: "file is empty"}}}
+		}
+		return nil, nil, nil, &ValidationError{Errors: []*LineError{syntaxError(content, err)}}
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, nil, nil, &ValidationError{Errors: []*LineError{{
+			Line:    lineOf(content, skipSeparators(content, 0)),
+			Message: "expected a JSON array of allowlist entries",
+		}}}
+	}
+
+	entries := make([]Entry, 0)
+	locations := make([]*entryLines, 0)
+	entryErrors := make([]*LineError, 0)
+	for index := 0; decoder.More(); index++ {
+		start := skipSeparators(content, int(decoder.InputOffset()))
+
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, nil, nil, &ValidationError{Errors: append(entryErrors, syntaxError(content, err))}
+		}
+
+		location := &entryLines{
+			index:  index,
+			start:  lineOf(content, start),
+			fields: make(map[string]int),
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			entryErrors = append(entryErrors, &LineError{
+				Line:    location.start,
+				Message: fmt.Sprintf("entry %d: expected a JSON object", index),
+			})
+			continue
+		}
+		for field := range fields {
+			if fieldIndex := bytes.Index(raw, []byte(strconv.Quote(field))); fieldIndex >= 0 {
+				location.fields[field] = location.start + bytes.Count(raw[:fieldIndex], []byte("\n"))
+			}
+			if !isEntryField(field) {
+				location.unknown = append(location.unknown, field)
+			}
+		}
+		sort.Slice(location.unknown, func(i, j int) bool {
+			return location.line(location.unknown[i]) < location.line(location.unknown[j])
+		})
+
+		var entry Entry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			entryErrors = append(entryErrors, entryError(location, err))
+			continue
+		}
+
+		entries = append(entries, entry)
+		locations = append(locations, location)
+	}
+
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, nil, &ValidationError{Errors: append(entryErrors, syntaxError(content, err))}
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, nil, nil, &ValidationError{Errors: append(entryErrors, &LineError{
+			Line:    lineOf(content, skipSeparators(content, int(decoder.InputOffset()))),
+			Message: "unexpected content after the allowlist",
+		})}
+	}
+
+	return entries, locations, entryErrors, nil
+}
+
+// entryError describes an error encountered while decoding a single, syntactically valid, entry.
+func entryError(location *entryLines, err error) *LineError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return &LineError{
+			Line: location.line(typeErr.Field),
+			Message: fmt.Sprintf("entry %d: %s must be a %s, got %s",
+				location.index, typeErr.Field, typeErr.Type, typeErr.Value),
+		}
+	}
+
+	return &LineError{
+		Line:    location.start,
+		Message: fmt.Sprintf("entry %d: %s", location.index, err),
+	}
+}
+
+// entryFields are the JSON names of the fields of Entry.
+var entryFields = []string{"name", "account", "quorumID", "blobRate", "byteRate"}
+
+// isEntryField reports whether a JSON field is decoded into Entry. Like encoding/json, it ignores case.
+func isEntryField(field string) bool {
+	for _, entryField := range entryFields {
+		if strings.EqualFold(field, entryField) {
+			return true
+		}
+	}
+	return false
+}
+
+// syntaxError describes an error encountered while decoding the file as a whole.
+func syntaxError(content []byte, err error) *LineError {
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return &LineError{
+			Line:    lineOf(content, int(syntaxErr.Offset)),
+			Message: syntaxErr.Error(),
+		}
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return &LineError{
+			Line:    lineOf(content, len(content)),
+			Message: "unexpected end of file",
+		}
+	}
+	return &LineError{
+		Line:    lineOf(content, 0),
+		Message: err.Error(),
+	}
+}
+
+// problem is a problem with a single field of an entry.
+type problem struct {
+	field   string
+	message string
+	// tolerated is set for problems that files written before the allowlist was validated may have.
+	tolerated bool
+}
+
+// validateEntry checks the fields of a single entry.
+func validateEntry(entry Entry) []problem {
+	problems := make([]problem, 0)
+
+	if strings.TrimSpace(entry.Name) == "" {
+		problems = append(problems, problem{field: "name", message: "name is required", tolerated: true})
+	}
+
+	account := strings.ToLower(entry.Account)
+	if strings.HasPrefix(account, "0x") {
+		if len(account) != 2+2*gethcommon.AddressLength || !gethcommon.IsHexAddress(account) {
+			problems = append(problems, problem{
+				field:   "account",
+				message: fmt.Sprintf("account %q is not a valid ethereum address", entry.Account),
+			})
+		}
+	} else if net.ParseIP(account) == nil {
+		problems = append(problems, problem{
+			field:   "account",
+			message: fmt.Sprintf("account %q must be an ethereum address starting with 0x or an IP address", entry.Account),
+		})
+	}
+
+	if entry.BlobRate < 0 {
+		problems = append(problems, problem{field: "blobRate", message: "blobRate must not be negative"})
+	} else if entry.BlobRate*BlobRateMultiplier > math.MaxUint32 {
+		problems = append(problems, problem{
+			field:   "blobRate",
+			message: fmt.Sprintf("blobRate must be at most %v", math.MaxUint32/BlobRateMultiplier),
+		})
+	}
+
+	if entry.ByteRate < 0 {
+		problems = append(problems, problem{field: "byteRate", message: "byteRate must not be negative"})
+	} else if entry.ByteRate > math.MaxUint32 {
+		problems = append(problems, problem{
+			field:   "byteRate",
+			message: fmt.Sprintf("byteRate must be at most %d", uint32(math.MaxUint32)),
+		})
+	}
+
+	if entry.BlobRate == 0 && entry.ByteRate == 0 {
+		problems = append(problems, problem{
+			field:     "account",
+			message:   "at least one of blobRate and byteRate must be positive",
+			tolerated: true,
+		})
+	}
+
+	return problems
+}
+
+// skipSeparators returns the position of the first character at or after offset that is not whitespace or a comma.
+func skipSeparators(content []byte, offset int) int {
+	for offset < len(content) {
+		switch content[offset] {
+		case ' ', '\t', '\r', '\n', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// lineOf returns the 1-indexed line containing the given offset.
+func lineOf(content []byte, offset int) int {
+	offset = min(max(offset, 0), len(content))
+	return 1 + bytes.Count(content[:offset], []byte("\n"))
+}
@@ -0,0 +1,270 @@
+package allowlist_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/allowlist"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validAllowlist = `[
+  {
+    "name": "eigenlabs",
+    "account": "0.1.2.3",
+    "quorumID": 0,
+    "blobRate": 0.01,
+    "byteRate": 1024
+  },
+  {
+    "name": "eigenlabs",
+    "account": "0.1.2.3",
+    "quorumID": 1,
+    "blobRate": 1,
+    "byteRate": 1048576
+  },
+  {
+    "name": "bar",
+    "account": "0xcb14cFAaC122E52024232583e7354589AedE74Ff",
+    "quorumID": 1,
+    "blobRate": 0.1,
+    "byteRate": 4092
+  }
+]`
+
+// requireLineErrors asserts that err is a *ValidationError with problems reported on exactly the given lines.
+func requireLineErrors(t *testing.T, err error, lines ...int) *allowlist.ValidationError {
+	var validationErr *allowlist.ValidationError
+	require.True(t, errors.As(err, &validationErr), "expected a validation error, got %v", err)
+
+	actual := make([]int, len(validationErr.Errors))
+	for i, lineErr := range validationErr.Errors {
+		actual[i] = lineErr.Line
+	}
+	assert.ElementsMatch(t, lines, actual, "unexpected lines in %v", err)
+	return validationErr
+}
+
+func TestParse(t *testing.T) {
+	al, err := allowlist.Parse([]byte(validAllowlist))
+	require.NoError(t, err)
+
+	assert.Len(t, al, 2)
+	assert.Equal(t, allowlist.RateInfo{Name: "eigenlabs", Throughput: 1024, BlobRate: uint32(0.01 * 1e6)}, al["0.1.2.3"][0])
+	assert.Equal(t, allowlist.RateInfo{Name: "eigenlabs", Throughput: 1048576, BlobRate: 1e6}, al["0.1.2.3"][1])
+
+	// checksummed addresses are normalized to lowercase
+	assert.Equal(t,
+		allowlist.RateInfo{Name: "bar", Throughput: 4092, BlobRate: uint32(0.1 * 1e6)},
+		al["0xcb14cfaac122e52024232583e7354589aede74ff"][1])
+}
+
+func TestParseEmptyArray(t *testing.T) {
+	al, err := allowlist.Parse([]byte("[]"))
+	require.NoError(t, err)
+	assert.Empty(t, al)
+}
+
+func TestParseInvalidEntries(t *testing.T) {
+	content := `[
+  {
+    "name": "",
+    "account": "0x1234",
+    "quorumID": 0,
+    "blobRate": -1,
+    "byteRate": 1024
+  },
+  {
+    "name": "foo",
+    "account": "not-an-ip",
+    "quorumID": 0,
+    "blobRate": 5000,
+    "byteRate": 1e10
+  },
+  {
+    "name": "bar",
+    "account": "5.5.5.5",
+    "quorumID": 1,
+    "blobRate": 0,
+    "byteRate": 0
+  }
+]`
+
+	_, err := allowlist.Parse([]byte(content))
+	validationErr := requireLineErrors(t, err,
+		3,  // name is required
+		4,  // invalid address
+		6,  // negative blob rate
+		11, // account is neither an address nor an IP
+		13, // blob rate too large
+		14, // byte rate too large
+		18, // both rates are zero
+	)
+	assert.Contains(t, validationErr.Error(), "line 6: entry 0: blobRate must not be negative")
+}
+
+func TestParseDuplicateEntries(t *testing.T) {
+	content := `[
+  {"name": "foo", "account": "0xcb14cfaac122e52024232583e7354589aede74ff", "quorumID": 0, "byteRate": 1024},
+  {"name": "foo", "account": "0xcb14cfaac122e52024232583e7354589aede74ff", "quorumID": 1, "byteRate": 1024},
+  {"name": "foo", "account": "0xcb14cFAaC122E52024232583e7354589AedE74Ff", "quorumID": 0, "byteRate": 2048}
+]`
+
+	_, err := allowlist.Parse([]byte(content))
+	validationErr := requireLineErrors(t, err, 4)
+	assert.Contains(t, validationErr.Error(), "first defined on line 2")
+}
+
+func TestParseUnknownField(t *testing.T) {
+	content := `[
+  {
+    "name": "foo",
+    "account": "5.5.5.5",
+    "quorumID": 0,
+    "blobrate": 0.1,
+    "bytesRate": 1024
+  }
+]`
+
+	_, err := allowlist.Parse([]byte(content))
+	validationErr := requireLineErrors(t, err, 7)
+	assert.Contains(t, validationErr.Error(), `unknown field "bytesRate"`)
+}
+
+func TestParseLenient(t *testing.T) {
+	content := `[
+  {"account": "5.5.5.5", "quorumID": 0, "byteRate": 1024, "comment": "no name"},
+  {"name": "foo", "account": "6.6.6.6", "quorumID": 0},
+  {"name": "bar", "account": "7.7.7.7", "quorumID": 0, "byteRate": 1024},
+  {"name": "baz", "account": "7.7.7.7", "quorumID": 0, "byteRate": 2048}
+]`
+
+	// a file written before the allowlist was validated is rejected by Parse...
+	_, err := allowlist.Parse([]byte(content))
+	requireLineErrors(t, err, 2, 3, 5)
+
+	// ...but accepted with warnings by ParseLenient, which keeps the last entry for an account and quorum
+	al, warnings, err := allowlist.ParseLenient([]byte(content))
+	require.NoError(t, err)
+	lines := make([]int, len(warnings))
+	for i, warning := range warnings {
+		lines[i] = warning.Line
+	}
+	assert.ElementsMatch(t, []int{2, 2, 3, 5}, lines)
+	assert.Equal(t, allowlist.RateInfo{Name: "", Throughput: 1024}, al["5.5.5.5"][0])
+	assert.Equal(t, allowlist.RateInfo{Name: "foo"}, al["6.6.6.6"][0])
+	assert.Equal(t, allowlist.RateInfo{Name: "baz", Throughput: 2048}, al["7.7.7.7"][0])
+
+	// other problems are still rejected
+	_, _, err = allowlist.ParseLenient([]byte(`[
+  {"name": "foo", "account": "not-an-ip", "quorumID": 0, "byteRate": 1024, "comment": "bad account"}
+]`))
+	requireLineErrors(t, err, 2)
+}
+
+func TestParseWrongType(t *testing.T) {
+	content := `[
+  {
+    "name": "foo",
+    "account": "5.5.5.5",
+    "quorumID": 256,
+    "byteRate": 1024
+  }
+]`
+
+	_, err := allowlist.Parse([]byte(content))
+	validationErr := requireLineErrors(t, err, 5)
+	assert.Contains(t, validationErr.Error(), "quorumID must be a uint8")
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	content := `[
+  {
+    "name": "foo",
+    "account": "5.5.5.5",
+    "quorumID": 0,
+    "byteRate": 1024,
+  }
+]`
+
+	_, err := allowlist.Parse([]byte(content))
+	requireLineErrors(t, err, 7)
+}
+
+func TestParseMalformedFiles(t *testing.T) {
+	_, err := allowlist.Parse([]byte(""))
+	requireLineErrors(t, err, 1)
+
+	_, err = allowlist.Parse([]byte("\n\n{}"))
+	requireLineErrors(t, err, 3)
+
+	_, err = allowlist.Parse([]byte("[\n  {\"name\": \"foo\", \"account\": \"5.5.5.5\", \"byteRate\": 1}\n"))
+	requireLineErrors(t, err, 3)
+
+	_, err = allowlist.Parse([]byte("[]\n[]"))
+	requireLineErrors(t, err, 2)
+
+	_, err = allowlist.Parse([]byte("[\n  1\n]"))
+	requireLineErrors(t, err, 2)
+}
+
+func TestReadFile(t *testing.T) {
+	al, err := allowlist.ReadFile("")
+	require.NoError(t, err)
+	assert.Empty(t, al)
+
+	path := filepath.Join(t.TempDir(), "allowlist.json")
+	_, err = allowlist.ReadFile(path)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(path, []byte(validAllowlist), 0644))
+	al, err = allowlist.ReadFile(path)
+	require.NoError(t, err)
+	assert.Len(t, al, 2)
+
+	require.NoError(t, os.WriteFile(path, []byte("[\n  {\"name\": \"foo\"}\n]"), 0644))
+	_, err = allowlist.ReadFile(path)
+	requireLineErrors(t, err, 2, 2)
+	require.NoError(t, os.WriteFile(path, []byte("[\n  {\"account\": \"5.5.5.5\", \"byteRate\": 1}\n]"), 0644))
+	_, err = allowlist.ReadFile(path)
+	requireLineErrors(t, err, 2)
+	al, warnings, err := allowlist.ReadFileLenient(path)
+	require.NoError(t, err)
+	assert.Len(t, al, 1)
+	assert.Len(t, warnings, 1)
+}
+
+func TestParseReportsEveryProblem(t *testing.T) {
+	content := `[
+  {"name": "foo", "acount": "5.5.5.5", "quorumID": 0, "byteRate": 1024},
+  {"name": "bar", "account": "1.2.3", "quorumID": 0, "byteRate": 1024},
+  "baz",
+  {"name": "qux", "account": "7.7.7.7", "quorumID": "1", "byteRate": 1024}
+]`
+
+	_, err := allowlist.Parse([]byte(content))
+	validationErr := requireLineErrors(t, err, 2, 3, 4, 5)
+	assert.Contains(t, validationErr.Error(), "line 4: entry 2: expected a JSON object")
+	assert.Contains(t, validationErr.Error(), "line 5: entry 3: quorumID must be a uint8")
+}
+
+func TestValidateEntry(t *testing.T) {
+	account, rate, err := allowlist.ValidateEntry(allowlist.Entry{
+		Name:     "bar",
+		Account:  "0xcb14cFAaC122E52024232583e7354589AedE74Ff",
+		QuorumID: 1,
+		BlobRate: 0.1,
+		ByteRate: 4092,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0xcb14cfaac122e52024232583e7354589aede74ff", account)
+	assert.Equal(t, allowlist.RateInfo{Name: "bar", Throughput: 4092, BlobRate: uint32(0.1 * 1e6)}, rate)
+
+	_, _, err = allowlist.ValidateEntry(allowlist.Entry{Account: "not-an-ip", ByteRate: -1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "name is required")
+	assert.Contains(t, err.Error(), "byteRate must not be negative")
+}
@@ -0,0 +1,99 @@
+package allowlist
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// ChangeKind is the kind of change made to the rates of an account for a quorum.
+type ChangeKind string
+
+const (
+	// Added means that the account was granted rates for the quorum.
+	Added ChangeKind = "added"
+	// Removed means that the account's rates for the quorum were removed, i.e. the account is now subject to the
+	// default rates for unauthenticated requests.
+	Removed ChangeKind = "removed"
+	// Modified means that the account's name or rates for the quorum were changed.
+	Modified ChangeKind = "modified"
+)
+
+// Change describes how the rates of an account for a quorum differ between two allowlists.
+type Change struct {
+	Account  string
+	QuorumID core.QuorumID
+	Kind     ChangeKind
+	// Old is the rate before the change, or nil if the change is Added.
+	Old *RateInfo
+	// New is the rate after the change, or nil if the change is Removed.
+	New *RateInfo
+}
+
+func (c Change) String() string {
+	switch c.Kind {
+	case Added:
+		return fmt.Sprintf("+ %s quorum %d: %s", c.Account, c.QuorumID, formatRate(c.New))
+	case Removed:
+		return fmt.Sprintf("- %s quorum %d: %s", c.Account, c.QuorumID, formatRate(c.Old))
+	default:
+		return fmt.Sprintf("~ %s quorum %d: %s -> %s", c.Account, c.QuorumID, formatRate(c.Old), formatRate(c.New))
+	}
+}
+
+// formatRate formats a rate using the same units as the allowlist file.
+func formatRate(rate *RateInfo) string {
+	return fmt.Sprintf("name=%q blobRate=%v byteRate=%d",
+		rate.Name, float64(rate.BlobRate)/BlobRateMultiplier, rate.Throughput)
+}
+
+// Diff returns the changes needed to turn the old allowlist into the new one, sorted by account and quorum.
+func Diff(old Allowlist, new Allowlist) []Change {
+	changes := make([]Change, 0)
+
+	for account, oldRates := range old {
+		for quorumID, oldRate := range oldRates {
+			oldRate := oldRate
+			newRate, ok := new[account][quorumID]
+			if !ok {
+				changes = append(changes, Change{
+					Account:  account,
+					QuorumID: quorumID,
+					Kind:     Removed,
+					Old:      &oldRate,
+				})
+			} else if newRate != oldRate {
+				changes = append(changes, Change{
+					Account:  account,
+					QuorumID: quorumID,
+					Kind:     Modified,
+					Old:      &oldRate,
+					New:      &newRate,
+				})
+			}
+		}
+	}
+
+	for account, newRates := range new {
+		for quorumID, newRate := range newRates {
+			newRate := newRate
+			if _, ok := old[account][quorumID]; !ok {
+				changes = append(changes, Change{
+					Account:  account,
+					QuorumID: quorumID,
+					Kind:     Added,
+					New:      &newRate,
+				})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Account != changes[j].Account {
+			return changes[i].Account < changes[j].Account
+		}
+		return changes[i].QuorumID < changes[j].QuorumID
+	})
+	return changes
+}
@@ -0,0 +1,61 @@
+package allowlist_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/allowlist"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	old := allowlist.Allowlist{
+		"0.1.2.3": {
+			0: {Name: "eigenlabs", Throughput: 1024, BlobRate: 1e4},
+			1: {Name: "eigenlabs", Throughput: 2048, BlobRate: 1e6},
+		},
+		"5.5.5.5": {
+			1: {Name: "foo", Throughput: 4092, BlobRate: 1e5},
+		},
+	}
+	new := allowlist.Allowlist{
+		"0.1.2.3": {
+			0: {Name: "eigenlabs", Throughput: 1024, BlobRate: 1e4},
+			1: {Name: "eigenlabs", Throughput: 4096, BlobRate: 1e6},
+			2: {Name: "eigenlabs", Throughput: 1024, BlobRate: 1e4},
+		},
+		"7.7.7.7": {
+			0: {Name: "bar", Throughput: 100, BlobRate: 1e5},
+		},
+	}
+
+	changes := allowlist.Diff(old, new)
+	assert.Len(t, changes, 4)
+
+	assert.Equal(t, "0.1.2.3", changes[0].Account)
+	assert.Equal(t, uint8(1), changes[0].QuorumID)
+	assert.Equal(t, allowlist.Modified, changes[0].Kind)
+	assert.Equal(t, uint32(2048), changes[0].Old.Throughput)
+	assert.Equal(t, uint32(4096), changes[0].New.Throughput)
+
+	assert.Equal(t, "0.1.2.3", changes[1].Account)
+	assert.Equal(t, uint8(2), changes[1].QuorumID)
+	assert.Equal(t, allowlist.Added, changes[1].Kind)
+	assert.Nil(t, changes[1].Old)
+
+	assert.Equal(t, "5.5.5.5", changes[2].Account)
+	assert.Equal(t, allowlist.Removed, changes[2].Kind)
+	assert.Equal(t, "foo", changes[2].Old.Name)
+	assert.Nil(t, changes[2].New)
+
+	assert.Equal(t, "7.7.7.7", changes[3].Account)
+	assert.Equal(t, allowlist.Added, changes[3].Kind)
+
+	assert.Equal(t,
+		`~ 0.1.2.3 quorum 1: name="eigenlabs" blobRate=1 byteRate=2048 -> name="eigenlabs" blobRate=1 byteRate=4096`,
+		changes[0].String())
+	assert.Equal(t, `- 5.5.5.5 quorum 1: name="foo" blobRate=0.1 byteRate=4092`, changes[2].String())
+
+	assert.Empty(t, allowlist.Diff(old, old))
+	assert.Len(t, allowlist.Diff(nil, old), 3)
+	assert.Len(t, allowlist.Diff(old, nil), 3)
+}
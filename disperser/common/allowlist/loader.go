@@ -0,0 +1,185 @@
+package allowlist
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Loader loads the allowlist from a file. It keeps track of the most recently loaded allowlist so that each load
+// can report which accounts' rates changed, and so that a malformed file never replaces a valid allowlist.
+type Loader struct {
+	logger  logging.Logger
+	path    string
+	metrics *metrics
+
+	lock sync.Mutex
+	// current is the most recently loaded allowlist.
+	current Allowlist
+	// content is the file content from which current was loaded, or nil if current wasn't loaded by this Loader.
+	content []byte
+}
+
+// NewLoader creates a new Loader for the allowlist file at the given path. The initial allowlist is the one that is
+// currently in use, and is the baseline the first load is compared against. If registry is nil, no metrics are
+// reported.
+func NewLoader(logger logging.Logger, path string, initial Allowlist, registry *prometheus.Registry) *Loader {
+	if initial == nil {
+		initial = make(Allowlist)
+	}
+
+	loader := &Loader{
+		logger:  logger.With("component", "AllowlistLoader"),
+		path:    path,
+		metrics: newMetrics(registry),
+		current: initial,
+	}
+	loader.metrics.reportInitial(initial)
+	return loader
+}
+
+// Path returns the path of the allowlist file.
+func (l *Loader) Path() string {
+	return l.path
+}
+
+// Current returns the most recently loaded allowlist.
+func (l *Loader) Current() Allowlist {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.current
+}
+
+// Load reads the allowlist file and, if it is valid, makes it the current allowlist. It returns the current
+// allowlist and the changes relative to the previous one. If the file can't be read or is malformed, an error is
+// returned and the previous allowlist remains current.
+func (l *Loader) Load() (Allowlist, []Change, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.path == "" {
+		return l.current, nil, nil
+	}
+
+	content, err := os.ReadFile(l.path)
+	if err != nil {
+		l.metrics.reportFailure()
+		return nil, nil, fmt.Errorf("failed to read allowlist file %s: %w", l.path, err)
+	}
+	if l.content != nil && bytes.Equal(content, l.content) {
+		l.metrics.reportSuccess(l.current, nil)
+		return l.current, nil, nil
+	}
+
+	allowlist, err := Parse(content)
+	if err != nil {
+		l.metrics.reportFailure()
+		return nil, nil, fmt.Errorf("failed to parse allowlist file %s: %w", l.path, err)
+	}
+
+	changes := Diff(l.current, allowlist)
+	for _, change := range changes {
+		if change.Kind == Removed {
+			l.logger.Warn("allowlist entry removed", "account", change.Account, "quorumID", change.QuorumID,
+				"name", change.Old.Name, "change", change.String())
+		} else {
+			l.logger.Info("allowlist entry changed", "account", change.Account, "quorumID", change.QuorumID,
+				"name", change.New.Name, "kind", change.Kind, "change", change.String())
+		}
+	}
+	if len(changes) > 0 {
+		l.logger.Info("loaded allowlist", "path", l.path, "accounts", len(allowlist), "changes", len(changes))
+	}
+
+	l.current = allowlist
+	l.content = content
+	l.metrics.reportSuccess(allowlist, changes)
+
+	return allowlist, changes, nil
+}
+
+// metrics reports the state of the allowlist. A nil *metrics is valid and reports nothing.
+type metrics struct {
+	reloads *prometheus.CounterVec
+	changes *prometheus.CounterVec
+	entries prometheus.Gauge
+	valid   prometheus.Gauge
+}
+
+func newMetrics(registry *prometheus.Registry) *metrics {
+	if registry == nil {
+		return nil
+	}
+
+	namespace := "eigenda_disperser"
+	return &metrics{
+		reloads: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "allowlist_reloads_total",
+				Help:      "number of attempts to reload the allowlist file, by result",
+			},
+			[]string{"result"},
+		),
+		changes: promauto.With(registry).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "allowlist_changes_total",
+				Help:      "number of changes to the rates of allowlisted accounts",
+			},
+			[]string{"account", "quorum", "kind"},
+		),
+		entries: promauto.With(registry).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "allowlist_entries",
+				Help:      "number of account/quorum pairs in the current allowlist",
+			},
+		),
+		valid: promauto.With(registry).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "allowlist_file_valid",
+				Help:      "1 if the last attempt to load the allowlist file succeeded, 0 if the previous allowlist is still in use because the file is invalid",
+			},
+		),
+	}
+}
+
+func (m *metrics) reportInitial(allowlist Allowlist) {
+	if m == nil {
+		return
+	}
+
+	entries := 0
+	for _, rates := range allowlist {
+		entries += len(rates)
+	}
+	m.entries.Set(float64(entries))
+	m.valid.Set(1)
+}
+
+func (m *metrics) reportSuccess(allowlist Allowlist, changes []Change) {
+	if m == nil {
+		return
+	}
+
+	m.reportInitial(allowlist)
+	m.reloads.WithLabelValues("success").Inc()
+	for _, change := range changes {
+		m.changes.WithLabelValues(change.Account, fmt.Sprintf("%d", change.QuorumID), string(change.Kind)).Inc()
+	}
+}
+
+func (m *metrics) reportFailure() {
+	if m == nil {
+		return
+	}
+	m.reloads.WithLabelValues("failure").Inc()
+	m.valid.Set(0)
+}
@@ -0,0 +1,95 @@
+package allowlist_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/allowlist"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "allowlist.json")
+	require.NoError(t, os.WriteFile(path, []byte(validAllowlist), 0644))
+
+	initial, err := allowlist.ReadFile(path)
+	require.NoError(t, err)
+
+	registry := prometheus.NewRegistry()
+	loader := allowlist.NewLoader(logging.NewNoopLogger(), path, initial, registry)
+
+	// nothing changed since the initial allowlist was read
+	al, changes, err := loader.Load()
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, initial, al)
+
+	updated := `[
+  {"name": "eigenlabs", "account": "0.1.2.3", "quorumID": 0, "blobRate": 0.01, "byteRate": 2048},
+  {"name": "foo", "account": "5.5.5.5", "quorumID": 1, "blobRate": 0.1, "byteRate": 4092}
+]`
+	require.NoError(t, os.WriteFile(path, []byte(updated), 0644))
+	al, changes, err = loader.Load()
+	require.NoError(t, err)
+	assert.Len(t, changes, 4)
+	assert.Len(t, al, 2)
+	assert.Equal(t, uint32(2048), al["0.1.2.3"][0].Throughput)
+	assert.Equal(t, al, loader.Current())
+
+	// a malformed file keeps the previous allowlist
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name": "foo", "account": "5.5.5.5", "quorumID": 1, "byteRate": -1}]`), 0644))
+	_, _, err = loader.Load()
+	requireLineErrors(t, err, 1)
+	assert.Equal(t, al, loader.Current())
+	assert.Equal(t, float64(0), gaugeValue(t, registry, "eigenda_disperser_allowlist_file_valid"))
+
+	// so does a missing file
+	require.NoError(t, os.Remove(path))
+	_, _, err = loader.Load()
+	assert.Error(t, err)
+	assert.Equal(t, al, loader.Current())
+
+	// restoring the file restores the previous state
+	require.NoError(t, os.WriteFile(path, []byte(updated), 0644))
+	_, changes, err = loader.Load()
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	assert.Equal(t, float64(1), gaugeValue(t, registry, "eigenda_disperser_allowlist_file_valid"))
+	assert.Equal(t, float64(2), gaugeValue(t, registry, "eigenda_disperser_allowlist_entries"))
+
+	count, err := testutil.GatherAndCount(registry, "eigenda_disperser_allowlist_changes_total")
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	count, err = testutil.GatherAndCount(registry, "eigenda_disperser_allowlist_reloads_total")
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestLoaderWithoutFile(t *testing.T) {
+	initial := allowlist.Allowlist{"0.1.2.3": {0: {Name: "foo", Throughput: 1024}}}
+	loader := allowlist.NewLoader(logging.NewNoopLogger(), "", initial, nil)
+
+	al, changes, err := loader.Load()
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Equal(t, initial, al)
+}
+
+// gaugeValue returns the value of the gauge with the given name.
+func gaugeValue(t *testing.T, registry *prometheus.Registry, name string) float64 {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	t.Fatalf("metric %s not found", name)
+	return 0
+}
@@ -0,0 +1,93 @@
+package events
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+var (
+	SubscriptionsFileFlagName    = "webhook.subscriptions-file"
+	SubscriptionsRefreshFlagName = "webhook.subscriptions-refresh-interval"
+	DeadLetterFileFlagName       = "webhook.dead-letter-file"
+	MaxAttemptsFlagName          = "webhook.max-attempts"
+	RetryBackoffFlagName         = "webhook.retry-backoff"
+	RequestTimeoutFlagName       = "webhook.request-timeout"
+	QueueSizeFlagName            = "webhook.queue-size"
+	NumWorkersFlagName           = "webhook.num-workers"
+)
+
+func WebhookFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, SubscriptionsFileFlagName),
+			Usage:    "Path of the JSON file of the webhooks to which blob lifecycle events are delivered, as an array of {account, url, secret} objects. The file is managed by the operator, and is reloaded when it changes. Events are not delivered if empty",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "WEBHOOK_SUBSCRIPTIONS_FILE"),
+		},
+		cli.DurationFlag{
+			Name:     common.PrefixFlag(flagPrefix, SubscriptionsRefreshFlagName),
+			Usage:    "The interval at which to reload the subscriptions file. The file is also watched for changes, so this is a fallback in case a change is missed",
+			Required: false,
+			Value:    5 * time.Minute,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "WEBHOOK_SUBSCRIPTIONS_REFRESH_INTERVAL"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, DeadLetterFileFlagName),
+			Usage:    "Path of the file to which the events which couldn't be delivered are appended. They are only logged if empty",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "WEBHOOK_DEAD_LETTER_FILE"),
+		},
+		cli.IntFlag{
+			Name:     common.PrefixFlag(flagPrefix, MaxAttemptsFlagName),
+			Usage:    "The number of delivery attempts of an event before it is dead-lettered",
+			Required: false,
+			Value:    5,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "WEBHOOK_MAX_ATTEMPTS"),
+		},
+		cli.DurationFlag{
+			Name:     common.PrefixFlag(flagPrefix, RetryBackoffFlagName),
+			Usage:    "The delay before the first retry of a delivery, doubled with every retry",
+			Required: false,
+			Value:    time.Second,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "WEBHOOK_RETRY_BACKOFF"),
+		},
+		cli.DurationFlag{
+			Name:     common.PrefixFlag(flagPrefix, RequestTimeoutFlagName),
+			Usage:    "The maximum time to wait for a single delivery attempt",
+			Required: false,
+			Value:    10 * time.Second,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "WEBHOOK_REQUEST_TIMEOUT"),
+		},
+		cli.IntFlag{
+			Name:     common.PrefixFlag(flagPrefix, QueueSizeFlagName),
+			Usage:    "The maximum number of pending deliveries. Events are dead-lettered when the queue is full",
+			Required: false,
+			Value:    10_000,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "WEBHOOK_QUEUE_SIZE"),
+		},
+		cli.IntFlag{
+			Name:     common.PrefixFlag(flagPrefix, NumWorkersFlagName),
+			Usage:    "The number of deliveries which are attempted concurrently",
+			Required: false,
+			Value:    8,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "WEBHOOK_NUM_WORKERS"),
+		},
+	}
+}
+
+func ReadWebhookConfig(ctx *cli.Context, flagPrefix string) WebhookConfig {
+	return WebhookConfig{
+		SubscriptionsFile:            ctx.GlobalString(common.PrefixFlag(flagPrefix, SubscriptionsFileFlagName)),
+		SubscriptionsRefreshInterval: ctx.GlobalDuration(common.PrefixFlag(flagPrefix, SubscriptionsRefreshFlagName)),
+		DeadLetterFile:               ctx.GlobalString(common.PrefixFlag(flagPrefix, DeadLetterFileFlagName)),
+		MaxAttempts:                  ctx.GlobalInt(common.PrefixFlag(flagPrefix, MaxAttemptsFlagName)),
+		RetryBackoff:                 ctx.GlobalDuration(common.PrefixFlag(flagPrefix, RetryBackoffFlagName)),
+		RequestTimeout:               ctx.GlobalDuration(common.PrefixFlag(flagPrefix, RequestTimeoutFlagName)),
+		QueueSize:                    ctx.GlobalInt(common.PrefixFlag(flagPrefix, QueueSizeFlagName)),
+		NumWorkers:                   ctx.GlobalInt(common.PrefixFlag(flagPrefix, NumWorkersFlagName)),
+	}
+}
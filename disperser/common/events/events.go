@@ -0,0 +1,72 @@
+package events
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventType is the kind of a blob lifecycle event.
+type EventType string
+
+const (
+	// BlobConfirmed is emitted when the batch of a v1 blob is confirmed on chain. The confirmation may still be
+	// reorged until it is finalized.
+	BlobConfirmed EventType = "blob.confirmed"
+	// BlobFinalized is emitted when the confirmation of a v1 blob is finalized on chain.
+	BlobFinalized EventType = "blob.finalized"
+	// BlobCertified is emitted when a v2 blob has been attested by the operators.
+	BlobCertified EventType = "blob.certified"
+	// BlobFailed is emitted when a blob reaches a failed status.
+	BlobFailed EventType = "blob.failed"
+)
+
+// Event is a blob lifecycle event. It is serialized as the JSON body of a webhook request.
+type Event struct {
+	// ID identifies the event. Each type of event is emitted at most once per blob in the absence of
+	// reorgs and restarts, so receivers can use the ID to discard duplicate deliveries.
+	ID   string    `json:"id"`
+	Type EventType `json:"type"`
+	// Version is the version of the disperser API with which the blob was dispersed.
+	Version   uint8  `json:"version"`
+	AccountID string `json:"account_id"`
+	// BlobKey is the request ID of a v1 blob, or the hex encoded blob key of a v2 blob.
+	BlobKey                 string `json:"blob_key"`
+	BatchHeaderHash         string `json:"batch_header_hash,omitempty"`
+	ConfirmationBlockNumber uint32 `json:"confirmation_block_number,omitempty"`
+	// Timestamp is the time at which the event was emitted, in unix seconds.
+	Timestamp int64 `json:"timestamp"`
+}
+
+// NewEvent creates an event of the given type for a blob, timestamped now.
+func NewEvent(eventType EventType, version uint8, accountID string, blobKey string) *Event {
+	return &Event{
+		ID:        fmt.Sprintf("%s:%s", eventType, blobKey),
+		Type:      eventType,
+		Version:   version,
+		AccountID: accountID,
+		BlobKey:   blobKey,
+		Timestamp: time.Now().Unix(),
+	}
+}
+
+// Sink receives blob lifecycle events. Emit must not block on the delivery of the event, since it is called from
+// the components which update the blob status.
+type Sink interface {
+	Emit(event *Event)
+}
+
+// NoopSink discards all events. It is used when no event delivery is configured.
+type NoopSink struct{}
+
+var _ Sink = NoopSink{}
+
+func (NoopSink) Emit(*Event) {}
+
+// Wait blocks until the sink has shut down, for sinks which deliver events in the background. Callers should wait
+// for the sink after cancelling the context it was started with, so that pending deliveries are dead-lettered
+// rather than lost when the process exits. It returns immediately for other sinks.
+func Wait(sink Sink) {
+	if s, ok := sink.(interface{ Done() <-chan struct{} }); ok {
+		<-s.Done()
+	}
+}
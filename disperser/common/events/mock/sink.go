@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
+)
+
+// MockSink records the emitted events.
+type MockSink struct {
+	mu     sync.Mutex
+	events []*events.Event
+}
+
+var _ events.Sink = (*MockSink)(nil)
+
+func NewSink() *MockSink {
+	return &MockSink{}
+}
+
+func (s *MockSink) Emit(event *events.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, event)
+}
+
+// Events returns the events emitted so far, in order.
+func (s *MockSink) Events() []*events.Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*events.Event(nil), s.events...)
+}
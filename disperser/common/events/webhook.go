@@ -0,0 +1,372 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/fswatch"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// SignatureHeader carries the hex encoded HMAC-SHA256 of "<timestamp>.<body>", keyed with the webhook secret.
+	SignatureHeader = "X-EigenDA-Signature"
+	// TimestampHeader carries the unix time in seconds at which the request was signed.
+	TimestampHeader = "X-EigenDA-Timestamp"
+	// EventIDHeader carries the ID of the delivered event.
+	EventIDHeader = "X-EigenDA-Event-Id"
+)
+
+var (
+	errQueueFull = errors.New("delivery queue is full")
+	errShutdown  = errors.New("sink was shut down before the event was delivered")
+)
+
+// Subscription registers a webhook endpoint for the events of an account. The subscriptions file is a JSON array
+// of subscriptions, and an account may register several endpoints. Accounts can't register endpoints themselves:
+// subscriptions are managed by the operator of the disperser through the subscriptions file, which is reloaded when
+// it changes.
+type Subscription struct {
+	Account string `json:"account"`
+	URL     string `json:"url"`
+	Secret  string `json:"secret"`
+}
+
+// WebhookConfig configures the delivery of events to webhooks.
+type WebhookConfig struct {
+	// SubscriptionsFile is the path of the subscriptions file. Events are not delivered if it is empty.
+	SubscriptionsFile string
+	// SubscriptionsRefreshInterval is the interval at which the subscriptions file is reloaded, in addition to when
+	// it changes. It is only reloaded when it changes if zero.
+	SubscriptionsRefreshInterval time.Duration
+	// DeadLetterFile is the path of the file to which the events that couldn't be delivered are appended, one JSON
+	// object per line. They are only logged if it is empty.
+	DeadLetterFile string
+	// MaxAttempts is the number of delivery attempts of an event before it is dead-lettered.
+	MaxAttempts int
+	// RetryBackoff is the delay before the first retry. The delay doubles with every retry.
+	RetryBackoff time.Duration
+	// RequestTimeout bounds the duration of a single delivery attempt.
+	RequestTimeout time.Duration
+	// QueueSize is the number of deliveries which can be pending. Events are dead-lettered when the queue is full.
+	QueueSize int
+	// NumWorkers is the number of deliveries which are attempted concurrently.
+	NumWorkers int
+}
+
+// DeadLetter is an event delivery which failed permanently.
+type DeadLetter struct {
+	Event    *Event    `json:"event"`
+	URL      string    `json:"url"`
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error"`
+	FailedAt time.Time `json:"failed_at"`
+}
+
+type delivery struct {
+	event        *Event
+	body         []byte
+	subscription Subscription
+}
+
+// WebhookSink delivers events to the webhooks registered by the account of the blob. Requests are signed with the
+// secret of the webhook, failed deliveries are retried with exponential backoff, and deliveries which fail
+// permanently or are still pending when the sink shuts down are written to the dead-letter log.
+type WebhookSink struct {
+	config WebhookConfig
+	client *http.Client
+	queue  chan *delivery
+	logger logging.Logger
+
+	// mu guards the subscriptions, and the queue against deliveries being queued once the sink is stopped.
+	mu            sync.RWMutex
+	subscriptions map[string][]Subscription
+	stopped       bool
+
+	deadLetterMu sync.Mutex
+
+	// done is closed once the sink has shut down and its pending deliveries have been dead-lettered.
+	done chan struct{}
+}
+
+var _ Sink = (*WebhookSink)(nil)
+
+// NewSink creates and starts the sink described by the config, or returns a NoopSink if no subscriptions file is
+// configured.
+func NewSink(ctx context.Context, config WebhookConfig, logger logging.Logger) (Sink, error) {
+	if config.SubscriptionsFile == "" {
+		return NoopSink{}, nil
+	}
+	subscriptions, err := ReadSubscriptionsFile(config.SubscriptionsFile)
+	if err != nil {
+		return nil, err
+	}
+	sink, err := NewWebhookSink(config, subscriptions, logger)
+	if err != nil {
+		return nil, err
+	}
+	sink.Start(ctx)
+	go fswatch.WatchFile(ctx, logger, config.SubscriptionsFile, config.SubscriptionsRefreshInterval, sink.ReloadSubscriptions)
+	return sink, nil
+}
+
+// ReadSubscriptionsFile reads and validates the webhook subscriptions file at the given path.
+func ReadSubscriptionsFile(path string) ([]Subscription, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subscriptions file: %w", err)
+	}
+	var subscriptions []Subscription
+	if err := json.Unmarshal(content, &subscriptions); err != nil {
+		return nil, fmt.Errorf("failed to parse subscriptions file: %w", err)
+	}
+	for i, s := range subscriptions {
+		if s.Account == "" {
+			return nil, fmt.Errorf("subscription %d: account must not be empty", i)
+		}
+		u, err := url.Parse(s.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("subscription %d: invalid url %q", i, s.URL)
+		}
+		if s.Secret == "" {
+			return nil, fmt.Errorf("subscription %d: secret must not be empty", i)
+		}
+	}
+	return subscriptions, nil
+}
+
+// NewWebhookSink creates a webhook sink. Events are queued but not delivered until Start is called.
+func NewWebhookSink(config WebhookConfig, subscriptions []Subscription, logger logging.Logger) (*WebhookSink, error) {
+	if config.MaxAttempts < 1 {
+		return nil, fmt.Errorf("max attempts must be at least 1, got %d", config.MaxAttempts)
+	}
+	if config.NumWorkers < 1 {
+		return nil, fmt.Errorf("number of workers must be at least 1, got %d", config.NumWorkers)
+	}
+	if config.QueueSize < 0 {
+		return nil, fmt.Errorf("queue size must not be negative, got %d", config.QueueSize)
+	}
+
+	sink := &WebhookSink{
+		config: config,
+		client: &http.Client{Timeout: config.RequestTimeout},
+		queue:  make(chan *delivery, config.QueueSize),
+		logger: logger.With("component", "WebhookSink"),
+		done:   make(chan struct{}),
+	}
+	sink.SetSubscriptions(subscriptions)
+	return sink, nil
+}
+
+// SetSubscriptions replaces the subscriptions of the sink. Events which are already queued are delivered to the
+// webhooks they were queued for.
+func (s *WebhookSink) SetSubscriptions(subscriptions []Subscription) {
+	byAccount := make(map[string][]Subscription)
+	for _, subscription := range subscriptions {
+		account := strings.ToLower(subscription.Account)
+		byAccount[account] = append(byAccount[account], subscription)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscriptions = byAccount
+}
+
+// ReloadSubscriptions reads the subscriptions file again. The current subscriptions are kept if the file is invalid.
+func (s *WebhookSink) ReloadSubscriptions() {
+	subscriptions, err := ReadSubscriptionsFile(s.config.SubscriptionsFile)
+	if err != nil {
+		s.logger.Error("failed to reload subscriptions, keeping the current subscriptions", "path", s.config.SubscriptionsFile, "err", err)
+		return
+	}
+	s.SetSubscriptions(subscriptions)
+}
+
+// Start starts the delivery workers, which run until the context is done. Deliveries which are still pending at
+// that point, and events emitted afterwards, are written to the dead-letter log.
+func (s *WebhookSink) Start(ctx context.Context) {
+	var workers sync.WaitGroup
+	for i := 0; i < s.config.NumWorkers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case d := <-s.queue:
+					s.deliver(ctx, d)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		<-ctx.Done()
+		workers.Wait()
+		s.stop()
+		close(s.done)
+	}()
+}
+
+// Done returns a channel which is closed once the context passed to Start is done, the delivery workers have
+// returned and the pending deliveries have been dead-lettered.
+func (s *WebhookSink) Done() <-chan struct{} {
+	return s.done
+}
+
+// stop stops queueing deliveries and dead-letters the deliveries left in the queue.
+func (s *WebhookSink) stop() {
+	s.mu.Lock()
+	s.stopped = true
+	s.mu.Unlock()
+
+	for {
+		select {
+		case d := <-s.queue:
+			s.deadLetter(d, 0, errShutdown)
+		default:
+			return
+		}
+	}
+}
+
+// Emit queues the event for delivery to every webhook of its account.
+func (s *WebhookSink) Emit(event *Event) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	subscriptions := s.subscriptions[strings.ToLower(event.AccountID)]
+	if len(subscriptions) == 0 {
+		return
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		s.logger.Error("failed to serialize event", "eventID", event.ID, "err", err)
+		return
+	}
+	for _, subscription := range subscriptions {
+		d := &delivery{event: event, body: body, subscription: subscription}
+		if s.stopped {
+			s.deadLetter(d, 0, errShutdown)
+			continue
+		}
+		select {
+		case s.queue <- d:
+		default:
+			s.deadLetter(d, 0, errQueueFull)
+		}
+	}
+}
+
+func (s *WebhookSink) deliver(ctx context.Context, d *delivery) {
+	backoff := s.config.RetryBackoff
+	var err error
+	for attempt := 1; attempt <= s.config.MaxAttempts; attempt++ {
+		var retryable bool
+		retryable, err = s.post(ctx, d)
+		if err == nil {
+			s.logger.Debug("delivered event", "eventID", d.event.ID, "url", d.subscription.URL, "attempts", attempt)
+			return
+		}
+		if !retryable || attempt == s.config.MaxAttempts {
+			s.deadLetter(d, attempt, err)
+			return
+		}
+
+		s.logger.Warn("failed to deliver event, retrying", "eventID", d.event.ID, "url", d.subscription.URL, "attempt", attempt, "err", err)
+		select {
+		case <-ctx.Done():
+			s.deadLetter(d, attempt, ctx.Err())
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post makes a single delivery attempt, and reports whether a failed attempt may succeed if retried.
+func (s *WebhookSink) post(ctx context.Context, d *delivery) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.subscription.URL, bytes.NewReader(d.body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign([]byte(d.subscription.Secret), timestamp, d.body))
+	req.Header.Set(EventIDHeader, d.event.ID)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	// Other client errors mean that the request was rejected, and would be rejected again
+	retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests
+	return retryable, err
+}
+
+func (s *WebhookSink) deadLetter(d *delivery, attempts int, deliveryErr error) {
+	s.logger.Error("failed to deliver event", "eventID", d.event.ID, "url", d.subscription.URL, "attempts", attempts, "err", deliveryErr)
+	if s.config.DeadLetterFile == "" {
+		return
+	}
+
+	line, err := json.Marshal(&DeadLetter{
+		Event:    d.event,
+		URL:      d.subscription.URL,
+		Attempts: attempts,
+		Error:    deliveryErr.Error(),
+		FailedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		s.logger.Error("failed to serialize dead letter", "eventID", d.event.ID, "err", err)
+		return
+	}
+
+	s.deadLetterMu.Lock()
+	defer s.deadLetterMu.Unlock()
+	f, err := os.OpenFile(s.config.DeadLetterFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		s.logger.Error("failed to open dead-letter file", "path", s.config.DeadLetterFile, "err", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		s.logger.Error("failed to write dead letter", "path", s.config.DeadLetterFile, "err", err)
+	}
+}
+
+// Sign returns the signature of a webhook request body sent at the given timestamp.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether the signature of a webhook request is valid. Receivers should also reject requests
+// whose timestamp is too old, to prevent replays.
+func VerifySignature(secret []byte, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,333 @@
+package events_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "secret"
+
+// receiver is a webhook endpoint which verifies the signature of the requests and records the delivered events.
+// It responds to each request with the next status of its responses, and with 200 once they are exhausted.
+type receiver struct {
+	t         *testing.T
+	mu        sync.Mutex
+	responses []int
+	attempts  int
+	events    []*events.Event
+}
+
+func newReceiver(t *testing.T, responses ...int) (*receiver, *httptest.Server) {
+	r := &receiver{t: t, responses: responses}
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return r, server
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	require.NoError(r.t, err)
+	timestamp := req.Header.Get(events.TimestampHeader)
+	assert.True(r.t, events.VerifySignature([]byte(secret), timestamp, body, req.Header.Get(events.SignatureHeader)))
+	assert.False(r.t, events.VerifySignature([]byte("other"), timestamp, body, req.Header.Get(events.SignatureHeader)))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts++
+	if len(r.responses) > 0 {
+		status := r.responses[0]
+		r.responses = r.responses[1:]
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+	}
+	var event events.Event
+	require.NoError(r.t, json.Unmarshal(body, &event))
+	assert.Equal(r.t, event.ID, req.Header.Get(events.EventIDHeader))
+	r.events = append(r.events, &event)
+}
+
+func (r *receiver) delivered() ([]*events.Event, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*events.Event(nil), r.events...), r.attempts
+}
+
+func newSink(t *testing.T, config events.WebhookConfig, subscriptions ...events.Subscription) *events.WebhookSink {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	sink, err := events.NewWebhookSink(config, subscriptions, logging.NewNoopLogger())
+	require.NoError(t, err)
+	sink.Start(ctx)
+	return sink
+}
+
+func testConfig(t *testing.T) events.WebhookConfig {
+	return events.WebhookConfig{
+		DeadLetterFile: filepath.Join(t.TempDir(), "dead-letters.jsonl"),
+		MaxAttempts:    3,
+		RetryBackoff:   time.Millisecond,
+		RequestTimeout: time.Second,
+		QueueSize:      10,
+		NumWorkers:     2,
+	}
+}
+
+func readDeadLetters(t *testing.T, path string) []*events.DeadLetter {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+	defer f.Close()
+
+	var deadLetters []*events.DeadLetter
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var deadLetter events.DeadLetter
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &deadLetter))
+		deadLetters = append(deadLetters, &deadLetter)
+	}
+	require.NoError(t, scanner.Err())
+	return deadLetters
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	receiver1, server1 := newReceiver(t)
+	receiver2, server2 := newReceiver(t)
+	config := testConfig(t)
+	sink := newSink(t, config,
+		events.Subscription{Account: "0xAbCd", URL: server1.URL, Secret: secret},
+		events.Subscription{Account: "0xabcd", URL: server2.URL, Secret: secret},
+		events.Subscription{Account: "0x5678", URL: server2.URL, Secret: secret},
+	)
+
+	event := events.NewEvent(events.BlobCertified, 2, "0xABCD", "0102")
+	event.BatchHeaderHash = "0304"
+	sink.Emit(event)
+	// Events of accounts without webhooks are dropped
+	sink.Emit(events.NewEvent(events.BlobFailed, 2, "0x9999", "0506"))
+
+	// The event is delivered to every webhook of the account
+	for _, r := range []*receiver{receiver1, receiver2} {
+		require.Eventually(t, func() bool {
+			delivered, _ := r.delivered()
+			return len(delivered) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		delivered, attempts := r.delivered()
+		assert.Equal(t, 1, attempts)
+		assert.Equal(t, event, delivered[0])
+		assert.Equal(t, "blob.certified:0102", delivered[0].ID)
+	}
+	assert.Empty(t, readDeadLetters(t, config.DeadLetterFile))
+}
+
+func TestWebhookRetries(t *testing.T) {
+	r, server := newReceiver(t, http.StatusInternalServerError, http.StatusTooManyRequests)
+	config := testConfig(t)
+	sink := newSink(t, config, events.Subscription{Account: "0x1234", URL: server.URL, Secret: secret})
+
+	sink.Emit(events.NewEvent(events.BlobFinalized, 1, "0x1234", "blob"))
+	require.Eventually(t, func() bool {
+		delivered, _ := r.delivered()
+		return len(delivered) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	_, attempts := r.delivered()
+	assert.Equal(t, 3, attempts)
+	assert.Empty(t, readDeadLetters(t, config.DeadLetterFile))
+}
+
+func TestWebhookDeadLetter(t *testing.T) {
+	failing, failingServer := newReceiver(t, http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway)
+	rejecting, rejectingServer := newReceiver(t, http.StatusBadRequest)
+	config := testConfig(t)
+	sink := newSink(t, config,
+		events.Subscription{Account: "0x1234", URL: failingServer.URL, Secret: secret},
+		events.Subscription{Account: "0x5678", URL: rejectingServer.URL, Secret: secret},
+	)
+
+	failed := events.NewEvent(events.BlobFailed, 1, "0x1234", "failed")
+	sink.Emit(failed)
+	rejected := events.NewEvent(events.BlobFailed, 1, "0x5678", "rejected")
+	sink.Emit(rejected)
+
+	require.Eventually(t, func() bool {
+		return len(readDeadLetters(t, config.DeadLetterFile)) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	deadLetters := make(map[string]*events.DeadLetter)
+	for _, deadLetter := range readDeadLetters(t, config.DeadLetterFile) {
+		deadLetters[deadLetter.URL] = deadLetter
+	}
+
+	// Server errors are retried until the attempts are exhausted
+	deadLetter := deadLetters[failingServer.URL]
+	require.NotNil(t, deadLetter)
+	assert.Equal(t, failed, deadLetter.Event)
+	assert.Equal(t, 3, deadLetter.Attempts)
+	assert.Contains(t, deadLetter.Error, "502")
+	_, attempts := failing.delivered()
+	assert.Equal(t, 3, attempts)
+
+	// Rejected requests aren't retried
+	deadLetter = deadLetters[rejectingServer.URL]
+	require.NotNil(t, deadLetter)
+	assert.Equal(t, rejected, deadLetter.Event)
+	assert.Equal(t, 1, deadLetter.Attempts)
+	_, attempts = rejecting.delivered()
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWebhookQueueFull(t *testing.T) {
+	config := testConfig(t)
+	config.QueueSize = 1
+	// The sink isn't started, so the queue isn't drained
+	sink, err := events.NewWebhookSink(config, []events.Subscription{{Account: "0x1234", URL: "http://localhost", Secret: secret}}, logging.NewNoopLogger())
+	require.NoError(t, err)
+
+	sink.Emit(events.NewEvent(events.BlobFailed, 1, "0x1234", "queued"))
+	sink.Emit(events.NewEvent(events.BlobFailed, 1, "0x1234", "dropped"))
+	deadLetters := readDeadLetters(t, config.DeadLetterFile)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, "dropped", deadLetters[0].Event.BlobKey)
+	assert.Equal(t, 0, deadLetters[0].Attempts)
+}
+
+func TestReadSubscriptionsFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "subscriptions.json")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	subscriptions, err := events.ReadSubscriptionsFile(write(`[
+		{"account": "0x1234", "url": "https://example.com/hook", "secret": "s1"},
+		{"account": "0x1234", "url": "http://localhost:8080", "secret": "s2"}
+	]`))
+	require.NoError(t, err)
+	assert.Equal(t, []events.Subscription{
+		{Account: "0x1234", URL: "https://example.com/hook", Secret: "s1"},
+		{Account: "0x1234", URL: "http://localhost:8080", Secret: "s2"},
+	}, subscriptions)
+
+	for _, content := range []string{
+		`{"account": "0x1234"}`,
+		`[{"url": "https://example.com", "secret": "s"}]`,
+		`[{"account": "0x1234", "url": "ftp://example.com", "secret": "s"}]`,
+		`[{"account": "0x1234", "url": "example.com", "secret": "s"}]`,
+		`[{"account": "0x1234", "url": "https://example.com"}]`,
+	} {
+		_, err = events.ReadSubscriptionsFile(write(content))
+		assert.Error(t, err, content)
+	}
+
+	_, err = events.ReadSubscriptionsFile(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestNewSink(t *testing.T) {
+	sink, err := events.NewSink(context.Background(), events.WebhookConfig{}, logging.NewNoopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, events.NoopSink{}, sink)
+
+	// Waiting for a sink which doesn't deliver events in the background returns immediately
+	events.Wait(sink)
+}
+
+func TestWebhookShutdown(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-release
+	}))
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	config := testConfig(t)
+	config.NumWorkers = 1
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink, err := events.NewWebhookSink(config, []events.Subscription{{Account: "0x1234", URL: server.URL, Secret: secret}}, logging.NewNoopLogger())
+	require.NoError(t, err)
+	sink.Start(ctx)
+
+	// The first event is being delivered, and the others are queued
+	sink.Emit(events.NewEvent(events.BlobFailed, 1, "0x1234", "inflight"))
+	sink.Emit(events.NewEvent(events.BlobFailed, 1, "0x1234", "queued1"))
+	sink.Emit(events.NewEvent(events.BlobFailed, 1, "0x1234", "queued2"))
+	cancel()
+
+	// Pending deliveries are dead-lettered rather than dropped by the time the sink is done, and so are events
+	// emitted after the shutdown
+	done := make(chan struct{})
+	go func() {
+		events.Wait(sink)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("sink did not shut down after the context was cancelled")
+	}
+	require.Len(t, readDeadLetters(t, config.DeadLetterFile), 3)
+	sink.Emit(events.NewEvent(events.BlobFailed, 1, "0x1234", "late"))
+	blobKeys := make([]string, 0)
+	for _, deadLetter := range readDeadLetters(t, config.DeadLetterFile) {
+		blobKeys = append(blobKeys, deadLetter.Event.BlobKey)
+	}
+	assert.ElementsMatch(t, []string{"inflight", "queued1", "queued2", "late"}, blobKeys)
+}
+
+func TestWebhookReloadSubscriptions(t *testing.T) {
+	receiver1, server1 := newReceiver(t)
+	receiver2, server2 := newReceiver(t)
+	config := testConfig(t)
+	config.SubscriptionsFile = filepath.Join(t.TempDir(), "subscriptions.json")
+	writeSubscriptions := func(content string) {
+		require.NoError(t, os.WriteFile(config.SubscriptionsFile, []byte(content), 0644))
+	}
+	writeSubscriptions(`[{"account": "0x1234", "url": "` + server1.URL + `", "secret": "secret"}]`)
+
+	subscriptions, err := events.ReadSubscriptionsFile(config.SubscriptionsFile)
+	require.NoError(t, err)
+	sink := newSink(t, config, subscriptions...)
+	sink.Emit(events.NewEvent(events.BlobFailed, 1, "0x1234", "first"))
+	require.Eventually(t, func() bool {
+		delivered, _ := receiver1.delivered()
+		return len(delivered) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The subscriptions are replaced when the file is reloaded
+	writeSubscriptions(`[{"account": "0x1234", "url": "` + server2.URL + `", "secret": "secret"}]`)
+	sink.ReloadSubscriptions()
+	sink.Emit(events.NewEvent(events.BlobFailed, 1, "0x1234", "second"))
+	require.Eventually(t, func() bool {
+		delivered, _ := receiver2.delivered()
+		return len(delivered) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// An invalid file doesn't remove the current subscriptions
+	writeSubscriptions(`not json`)
+	sink.ReloadSubscriptions()
+	sink.Emit(events.NewEvent(events.BlobFailed, 1, "0x1234", "third"))
+	require.Eventually(t, func() bool {
+		delivered, _ := receiver2.delivered()
+		return len(delivered) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	delivered, _ := receiver1.delivered()
+	assert.Len(t, delivered, 1)
+}
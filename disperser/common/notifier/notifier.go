@@ -0,0 +1,220 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// DefaultPollInterval is the poll interval used when the configured interval isn't positive.
+const DefaultPollInterval = time.Second
+
+// ErrTooManySubscriptions is returned by Subscribe when the notifier is at its subscription limit.
+var ErrTooManySubscriptions = errors.New("too many subscriptions")
+
+// Fetcher returns the current values of the given keys. Keys that have no value, e.g. because the record has expired,
+// are left out of the result.
+type Fetcher[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+type Config struct {
+	// PollInterval is how often the values of the subscribed keys are fetched.
+	PollInterval time.Duration
+	// MaxSubscriptions is the maximum number of concurrent subscriptions. Zero means there is no limit.
+	MaxSubscriptions int
+}
+
+// Notifier fans out changes to the values of keys to the subscribers of each key.
+//
+// The notifier is fed by periodically fetching the values of the keys that currently have subscribers, with a single
+// fetch for all of them. The values are read from the shared store rather than reported by the process that writes
+// them, so every replica of a service observes the updates made by any writer, and the number of store reads depends
+// on the number of distinct subscribed keys rather than on the number of subscribers. Values that are known locally
+// can also be pushed to the subscribers directly with Publish.
+//
+// A subscription only holds the latest value of its key: a subscriber that falls behind skips intermediate values.
+type Notifier[K comparable, V any] struct {
+	config Config
+	fetch  Fetcher[K, V]
+	equal  func(a, b V) bool
+	logger logging.Logger
+
+	mu               sync.Mutex
+	subscriptions    map[K]map[*Subscription[K, V]]struct{}
+	numSubscriptions int
+	// latest holds the last value published for each subscribed key, so that only changes are published.
+	latest map[K]V
+}
+
+// Subscription receives the changes to the value of a key. It must be closed once it is no longer needed.
+type Subscription[K comparable, V any] struct {
+	key      K
+	updates  chan V
+	notifier *Notifier[K, V]
+	once     sync.Once
+}
+
+// NewNotifier creates a new Notifier which fetches the values of the subscribed keys with fetch. A value is published
+// to the subscribers of its key unless equal reports it as unchanged from the previously published value.
+func NewNotifier[K comparable, V any](config Config, fetch Fetcher[K, V], equal func(a, b V) bool, logger logging.Logger) *Notifier[K, V] {
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+	return &Notifier[K, V]{
+		config:        config,
+		fetch:         fetch,
+		equal:         equal,
+		logger:        logger.With("component", "Notifier"),
+		subscriptions: make(map[K]map[*Subscription[K, V]]struct{}),
+		latest:        make(map[K]V),
+	}
+}
+
+// Start polls the subscribed keys in the background until the context is cancelled.
+func (n *Notifier[K, V]) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(n.config.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := n.Poll(ctx); err != nil {
+					n.logger.Warn("failed to poll subscribed keys", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Subscribe subscribes to the changes of the value of the key. The first value published after subscribing is always
+// delivered, even if the key has other subscribers which already received it.
+func (n *Notifier[K, V]) Subscribe(key K) (*Subscription[K, V], error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.config.MaxSubscriptions > 0 && n.numSubscriptions >= n.config.MaxSubscriptions {
+		return nil, ErrTooManySubscriptions
+	}
+
+	sub := &Subscription[K, V]{
+		key:      key,
+		updates:  make(chan V, 1),
+		notifier: n,
+	}
+	subs, ok := n.subscriptions[key]
+	if !ok {
+		subs = make(map[*Subscription[K, V]]struct{})
+		n.subscriptions[key] = subs
+	}
+	subs[sub] = struct{}{}
+	n.numSubscriptions++
+	// Forget the last published value so that the new subscriber receives the next fetched value.
+	delete(n.latest, key)
+
+	return sub, nil
+}
+
+// Publish sends the value to the subscribers of the key, if it changed since the last value published for the key.
+// Values of keys without subscribers are dropped.
+func (n *Notifier[K, V]) Publish(key K, value V) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	subs, ok := n.subscriptions[key]
+	if !ok {
+		return
+	}
+	if latest, ok := n.latest[key]; ok && n.equal(latest, value) {
+		return
+	}
+	n.latest[key] = value
+
+	for sub := range subs {
+		sub.deliver(value)
+	}
+}
+
+// Poll fetches the values of all the subscribed keys once and publishes the ones that changed.
+func (n *Notifier[K, V]) Poll(ctx context.Context) error {
+	n.mu.Lock()
+	keys := make([]K, 0, len(n.subscriptions))
+	for key := range n.subscriptions {
+		keys = append(keys, key)
+	}
+	n.mu.Unlock()
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values, err := n.fetch(ctx, keys)
+	if err != nil {
+		return err
+	}
+	for key, value := range values {
+		n.Publish(key, value)
+	}
+	return nil
+}
+
+// NumSubscriptions returns the number of open subscriptions.
+func (n *Notifier[K, V]) NumSubscriptions() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.numSubscriptions
+}
+
+func (n *Notifier[K, V]) unsubscribe(sub *Subscription[K, V]) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	subs, ok := n.subscriptions[sub.key]
+	if !ok {
+		return
+	}
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	n.numSubscriptions--
+	if len(subs) == 0 {
+		delete(n.subscriptions, sub.key)
+		delete(n.latest, sub.key)
+	}
+}
+
+// Updates returns the channel on which the changes to the value of the key are delivered. The channel is never closed.
+func (s *Subscription[K, V]) Updates() <-chan V {
+	return s.updates
+}
+
+// Close cancels the subscription. It is safe to call Close more than once.
+func (s *Subscription[K, V]) Close() {
+	s.once.Do(func() {
+		s.notifier.unsubscribe(s)
+	})
+}
+
+// deliver replaces any value the subscriber hasn't received yet with the given value. It must be called with the
+// notifier's lock held, which makes the notifier the only sender on the channel.
+func (s *Subscription[K, V]) deliver(value V) {
+	select {
+	case s.updates <- value:
+		return
+	default:
+	}
+
+	select {
+	case <-s.updates:
+	default:
+	}
+	select {
+	case s.updates <- value:
+	default:
+	}
+}
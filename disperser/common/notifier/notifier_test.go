@@ -0,0 +1,228 @@
+package notifier_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/notifier"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// store is a key-value store which counts how many times and with which keys it is fetched.
+type store struct {
+	mu      sync.Mutex
+	values  map[string]int
+	fetches [][]string
+	err     error
+}
+
+func newStore() *store {
+	return &store{values: make(map[string]int)}
+}
+
+func (s *store) set(key string, value int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+func (s *store) fetch(ctx context.Context, keys []string) (map[string]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.fetches = append(s.fetches, keys)
+	if s.err != nil {
+		return nil, s.err
+	}
+	result := make(map[string]int)
+	for _, key := range keys {
+		if value, ok := s.values[key]; ok {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+func equal(a, b int) bool {
+	return a == b
+}
+
+func newNotifier(s *store, maxSubscriptions int) *notifier.Notifier[string, int] {
+	return notifier.NewNotifier[string, int](notifier.Config{MaxSubscriptions: maxSubscriptions}, s.fetch, equal, logging.NewNoopLogger())
+}
+
+func requireUpdate(t *testing.T, sub *notifier.Subscription[string, int], expected int) {
+	t.Helper()
+	select {
+	case value := <-sub.Updates():
+		require.Equal(t, expected, value)
+	default:
+		t.Fatalf("expected update %d", expected)
+	}
+}
+
+func requireNoUpdate(t *testing.T, sub *notifier.Subscription[string, int]) {
+	t.Helper()
+	select {
+	case value := <-sub.Updates():
+		t.Fatalf("unexpected update %d", value)
+	default:
+	}
+}
+
+func TestPollPublishesChanges(t *testing.T) {
+	ctx := context.Background()
+	s := newStore()
+	n := newNotifier(s, 0)
+
+	// Nothing is fetched without subscribers
+	require.NoError(t, n.Poll(ctx))
+	assert.Empty(t, s.fetches)
+
+	sub, err := n.Subscribe("a")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	// A key without a value isn't published
+	require.NoError(t, n.Poll(ctx))
+	requireNoUpdate(t, sub)
+
+	s.set("a", 1)
+	s.set("b", 1)
+	require.NoError(t, n.Poll(ctx))
+	requireUpdate(t, sub, 1)
+
+	// Unchanged values aren't published again
+	require.NoError(t, n.Poll(ctx))
+	requireNoUpdate(t, sub)
+
+	s.set("a", 2)
+	require.NoError(t, n.Poll(ctx))
+	requireUpdate(t, sub, 2)
+
+	// Only the subscribed key is fetched
+	for _, keys := range s.fetches {
+		assert.Equal(t, []string{"a"}, keys)
+	}
+}
+
+func TestFanOut(t *testing.T) {
+	ctx := context.Background()
+	s := newStore()
+	n := newNotifier(s, 0)
+
+	sub1, err := n.Subscribe("a")
+	require.NoError(t, err)
+	defer sub1.Close()
+	sub2, err := n.Subscribe("a")
+	require.NoError(t, err)
+	defer sub2.Close()
+	sub3, err := n.Subscribe("b")
+	require.NoError(t, err)
+	defer sub3.Close()
+	assert.Equal(t, 3, n.NumSubscriptions())
+
+	s.set("a", 1)
+	s.set("b", 5)
+	require.NoError(t, n.Poll(ctx))
+	requireUpdate(t, sub1, 1)
+	requireUpdate(t, sub2, 1)
+	requireUpdate(t, sub3, 5)
+
+	// Both keys are read with a single fetch
+	require.Len(t, s.fetches, 1)
+	assert.ElementsMatch(t, []string{"a", "b"}, s.fetches[0])
+
+	// A new subscriber of a key receives the next fetched value, even if it didn't change
+	sub4, err := n.Subscribe("a")
+	require.NoError(t, err)
+	defer sub4.Close()
+	require.NoError(t, n.Poll(ctx))
+	requireUpdate(t, sub4, 1)
+}
+
+func TestSubscriberOnlyKeepsLatestValue(t *testing.T) {
+	n := newNotifier(newStore(), 0)
+
+	sub, err := n.Subscribe("a")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	n.Publish("a", 1)
+	n.Publish("a", 2)
+	n.Publish("a", 3)
+	requireUpdate(t, sub, 3)
+	requireNoUpdate(t, sub)
+
+	// Values of keys without subscribers are dropped
+	n.Publish("b", 1)
+	sub2, err := n.Subscribe("b")
+	require.NoError(t, err)
+	defer sub2.Close()
+	requireNoUpdate(t, sub2)
+}
+
+func TestMaxSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	s := newStore()
+	n := newNotifier(s, 2)
+
+	sub1, err := n.Subscribe("a")
+	require.NoError(t, err)
+	sub2, err := n.Subscribe("b")
+	require.NoError(t, err)
+	defer sub2.Close()
+
+	_, err = n.Subscribe("c")
+	require.ErrorIs(t, err, notifier.ErrTooManySubscriptions)
+
+	// Closing a subscription frees its slot, and closing it twice has no effect
+	sub1.Close()
+	sub1.Close()
+	assert.Equal(t, 1, n.NumSubscriptions())
+	sub3, err := n.Subscribe("c")
+	require.NoError(t, err)
+	defer sub3.Close()
+
+	// Keys without subscribers are no longer fetched
+	require.NoError(t, n.Poll(ctx))
+	require.Len(t, s.fetches, 1)
+	assert.ElementsMatch(t, []string{"b", "c"}, s.fetches[0])
+}
+
+func TestPollError(t *testing.T) {
+	s := newStore()
+	s.err = errors.New("unavailable")
+	n := newNotifier(s, 0)
+
+	sub, err := n.Subscribe("a")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	require.ErrorIs(t, n.Poll(context.Background()), s.err)
+	requireNoUpdate(t, sub)
+}
+
+func TestStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newStore()
+	n := notifier.NewNotifier[string, int](notifier.Config{PollInterval: 10 * time.Millisecond}, s.fetch, equal, logging.NewNoopLogger())
+	n.Start(ctx)
+
+	sub, err := n.Subscribe("a")
+	require.NoError(t, err)
+	defer sub.Close()
+
+	s.set("a", 7)
+	select {
+	case value := <-sub.Updates():
+		assert.Equal(t, 7, value)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for update")
+	}
+}
@@ -1,6 +1,8 @@
 package v2
 
 import (
+	"fmt"
+
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	core "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -50,6 +52,24 @@ func (s BlobStatus) ToProfobuf() pb.BlobStatus {
 	}
 }
 
+// BlobStatusFromProtobuf converts a protobuf BlobStatus to a BlobStatus
+func BlobStatusFromProtobuf(s pb.BlobStatus) (BlobStatus, error) {
+	switch s {
+	case pb.BlobStatus_QUEUED:
+		return Queued, nil
+	case pb.BlobStatus_ENCODED:
+		return Encoded, nil
+	case pb.BlobStatus_CERTIFIED:
+		return Certified, nil
+	case pb.BlobStatus_FAILED:
+		return Failed, nil
+	case pb.BlobStatus_INSUFFICIENT_SIGNATURES:
+		return InsufficientSignatures, nil
+	default:
+		return 0, fmt.Errorf("unknown blob status: %v", s)
+	}
+}
+
 // BlobMetadata is an internal representation of a blob's metadata.
 type BlobMetadata struct {
 	BlobHeader *core.BlobHeader
@@ -66,6 +86,21 @@ type BlobMetadata struct {
 	RequestedAt uint64
 	// UpdatedAt is the Unix timestamp of when the blob was last updated in _nanoseconds_
 	UpdatedAt uint64
+	// ContentKey identifies the content of the blob, and holds a reference to the shared content record of the
+	// metadata store. It is nil if the blob does not take part in content deduplication.
+	ContentKey *ContentKey `dynamodbav:",omitempty"`
+	// StorageKey is the key under which the data and encoded chunks of the blob are stored. It is the storage key
+	// of the shared content record for blobs with a ContentKey. If nil, the data and chunks are stored under the key
+	// of the blob itself.
+	StorageKey *core.BlobKey `dynamodbav:",omitempty"`
 
 	*encoding.FragmentInfo
 }
+
+// GetStorageKey returns the key under which the data and encoded chunks of the blob are stored.
+func (m *BlobMetadata) GetStorageKey() (core.BlobKey, error) {
+	if m.StorageKey != nil {
+		return *m.StorageKey, nil
+	}
+	return m.BlobHeader.BlobKey()
+}
@@ -28,26 +28,40 @@ const (
 	StatusIndexName            = "StatusIndex"
 	OperatorDispersalIndexName = "OperatorDispersalIndex"
 	OperatorResponseIndexName  = "OperatorResponseIndex"
+	// AccountBlobIndexName is the index of blob metadata by account and request time. It was added after the table
+	// was first deployed: tables created before it must have it added with UpdateTable, since EnsureTable only
+	// creates tables of the local client, and blob metadata written before it has no AccountID attribute, so it is
+	// missing from the index until it is rewritten.
+	AccountBlobIndexName = "AccountBlobIndex"
 
 	blobKeyPrefix             = "BlobKey#"
 	dispersalKeyPrefix        = "Dispersal#"
 	batchHeaderKeyPrefix      = "BatchHeader#"
+	blobContentKeyPrefix      = "BlobContent#"
 	blobMetadataSK            = "BlobMetadata"
 	blobCertSK                = "BlobCertificate"
 	dispersalRequestSKPrefix  = "DispersalRequest#"
 	dispersalResponseSKPrefix = "DispersalResponse#"
 	batchHeaderSK             = "BatchHeader"
 	attestationSK             = "Attestation"
+	blobContentSK             = "BlobContent"
+
+	// maxContentUpdateAttempts is the number of times the release of a content reference is attempted when it races
+	// with concurrent updates of the content record.
+	maxContentUpdateAttempts = 10
 )
 
 var (
 	statusUpdatePrecondition = map[v2.BlobStatus][]v2.BlobStatus{
-		v2.Queued:    {},
-		v2.Encoded:   {v2.Queued},
-		v2.Certified: {v2.Encoded},
-		v2.Failed:    {v2.Queued, v2.Encoded},
+		v2.Queued:                 {},
+		v2.Encoded:                {v2.Queued},
+		v2.Certified:              {v2.Encoded},
+		v2.Failed:                 {v2.Queued, v2.Encoded},
+		v2.InsufficientSignatures: {v2.Encoded},
 	}
 	ErrInvalidStateTransition = errors.New("invalid state transition")
+	// ErrBlobContentChanged is returned when a content record is updated based on a stale read of it.
+	ErrBlobContentChanged = errors.New("blob content record has changed")
 )
 
 // BlobMetadataStore is a blob metadata storage backed by DynamoDB
@@ -149,6 +163,36 @@ func (s *BlobMetadataStore) GetBlobMetadata(ctx context.Context, blobKey corev2.
 	return metadata, nil
 }
 
+// GetBulkBlobMetadata returns the metadata for the given blob keys. Keys with no metadata are skipped.
+// Note: ordering of items is not guaranteed
+func (s *BlobMetadataStore) GetBulkBlobMetadata(ctx context.Context, blobKeys []corev2.BlobKey) ([]*v2.BlobMetadata, error) {
+	keys := make([]map[string]types.AttributeValue, len(blobKeys))
+	for i, blobKey := range blobKeys {
+		keys[i] = map[string]types.AttributeValue{
+			"PK": &types.AttributeValueMemberS{
+				Value: blobKeyPrefix + blobKey.Hex(),
+			},
+			"SK": &types.AttributeValueMemberS{
+				Value: blobMetadataSK,
+			},
+		}
+	}
+	items, err := s.dynamoDBClient.GetItems(ctx, s.tableName, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make([]*v2.BlobMetadata, len(items))
+	for i, item := range items {
+		metadata[i], err = UnmarshalBlobMetadata(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return metadata, nil
+}
+
 // GetBlobMetadataByStatus returns all the metadata with the given status that were updated after lastUpdatedAt
 // Because this function scans the entire index, it should only be used for status with a limited number of items.
 func (s *BlobMetadataStore) GetBlobMetadataByStatus(ctx context.Context, status v2.BlobStatus, lastUpdatedAt uint64) ([]*v2.BlobMetadata, error) {
@@ -189,13 +233,96 @@ func (s *BlobMetadataStore) GetBlobMetadataCountByStatus(ctx context.Context, st
 	return count, nil
 }
 
+// GetBlobMetadataByAccountID returns the metadata of the blobs dispersed by the given account that were requested
+// within [start, end] (in nanoseconds), newest first. At most limit items are returned if limit is positive. Blobs
+// written before the account index was added are not returned, see AccountBlobIndexName.
+func (s *BlobMetadataStore) GetBlobMetadataByAccountID(ctx context.Context, accountID core.AccountID, start uint64, end uint64, limit int32) ([]*v2.BlobMetadata, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(s.tableName),
+		IndexName:              aws.String(AccountBlobIndexName),
+		KeyConditionExpression: aws.String("AccountID = :accountID AND RequestedAt BETWEEN :start AND :end"),
+		ExpressionAttributeValues: commondynamodb.ExpressionValues{
+			":accountID": &types.AttributeValueMemberS{Value: accountID},
+			":start":     &types.AttributeValueMemberN{Value: strconv.FormatUint(start, 10)},
+			":end":       &types.AttributeValueMemberN{Value: strconv.FormatUint(end, 10)},
+		},
+		ScanIndexForward: aws.Bool(false),
+	}
+	if limit > 0 {
+		input.Limit = aws.Int32(limit)
+	}
+	items, err := s.dynamoDBClient.QueryWithInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make([]*v2.BlobMetadata, len(items))
+	for i, item := range items {
+		metadata[i], err = UnmarshalBlobMetadata(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return metadata, nil
+}
+
+// GetExpiredBlobMetadataByStatus returns the metadata with the given status whose expiry (in seconds) is at or
+// before now, oldest update first. At most limit items are returned if limit is positive. Like
+// GetBlobMetadataByStatus, the whole index partition of the status is read.
+func (s *BlobMetadataStore) GetExpiredBlobMetadataByStatus(ctx context.Context, status v2.BlobStatus, now uint64, limit int32) ([]*v2.BlobMetadata, error) {
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(s.tableName),
+		IndexName:              aws.String(StatusIndexName),
+		KeyConditionExpression: aws.String("BlobStatus = :status"),
+		FilterExpression:       aws.String("Expiry <= :now"),
+		ExpressionAttributeValues: commondynamodb.ExpressionValues{
+			":status": &types.AttributeValueMemberN{Value: strconv.Itoa(int(status))},
+			":now":    &types.AttributeValueMemberN{Value: strconv.FormatUint(now, 10)},
+		},
+	}
+	if limit > 0 {
+		input.Limit = aws.Int32(limit)
+	}
+	items, err := s.dynamoDBClient.QueryWithInput(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make([]*v2.BlobMetadata, len(items))
+	for i, item := range items {
+		metadata[i], err = UnmarshalBlobMetadata(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return metadata, nil
+}
+
 func (s *BlobMetadataStore) PutBlobCertificate(ctx context.Context, blobCert *corev2.BlobCertificate, fragmentInfo *encoding.FragmentInfo) error {
 	item, err := MarshalBlobCertificate(blobCert, fragmentInfo)
 	if err != nil {
 		return err
 	}
 
-	err = s.dynamoDBClient.PutItemWithCondition(ctx, s.tableName, item, "attribute_not_exists(PK) AND attribute_not_exists(SK)", nil, nil)
+	return s.putBlobCertificateItem(ctx, item)
+}
+
+// PutBlobCertificateWithStorageKey stores the certificate of a blob whose data and encoded chunks are stored under
+// storageKey rather than under its own key, because it shares its content with another blob.
+func (s *BlobMetadataStore) PutBlobCertificateWithStorageKey(ctx context.Context, blobCert *corev2.BlobCertificate, fragmentInfo *encoding.FragmentInfo, storageKey corev2.BlobKey) error {
+	item, err := MarshalBlobCertificate(blobCert, fragmentInfo)
+	if err != nil {
+		return err
+	}
+	item["StorageKey"] = &types.AttributeValueMemberB{Value: storageKey[:]}
+
+	return s.putBlobCertificateItem(ctx, item)
+}
+
+func (s *BlobMetadataStore) putBlobCertificateItem(ctx context.Context, item commondynamodb.Item) error {
+	err := s.dynamoDBClient.PutItemWithCondition(ctx, s.tableName, item, "attribute_not_exists(PK) AND attribute_not_exists(SK)", nil, nil)
 	if errors.Is(err, commondynamodb.ErrConditionFailed) {
 		return common.ErrAlreadyExists
 	}
@@ -204,6 +331,13 @@ func (s *BlobMetadataStore) PutBlobCertificate(ctx context.Context, blobCert *co
 }
 
 func (s *BlobMetadataStore) GetBlobCertificate(ctx context.Context, blobKey corev2.BlobKey) (*corev2.BlobCertificate, *encoding.FragmentInfo, error) {
+	cert, fragmentInfo, _, err := s.GetBlobCertificateWithStorageKey(ctx, blobKey)
+	return cert, fragmentInfo, err
+}
+
+// GetBlobCertificateWithStorageKey returns the certificate of a blob along with the key under which its data and
+// encoded chunks are stored.
+func (s *BlobMetadataStore) GetBlobCertificateWithStorageKey(ctx context.Context, blobKey corev2.BlobKey) (*corev2.BlobCertificate, *encoding.FragmentInfo, corev2.BlobKey, error) {
 	item, err := s.dynamoDBClient.GetItem(ctx, s.tableName, map[string]types.AttributeValue{
 		"PK": &types.AttributeValueMemberS{
 			Value: blobKeyPrefix + blobKey.Hex(),
@@ -214,19 +348,24 @@ func (s *BlobMetadataStore) GetBlobCertificate(ctx context.Context, blobKey core
 	})
 
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, corev2.BlobKey{}, err
 	}
 
 	if item == nil {
-		return nil, nil, fmt.Errorf("%w: certificate not found for key %s", common.ErrMetadataNotFound, blobKey.Hex())
+		return nil, nil, corev2.BlobKey{}, fmt.Errorf("%w: certificate not found for key %s", common.ErrMetadataNotFound, blobKey.Hex())
 	}
 
 	cert, fragmentInfo, err := UnmarshalBlobCertificate(item)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, corev2.BlobKey{}, err
+	}
+
+	storageKey, err := UnmarshalStorageKey(item, blobKey)
+	if err != nil {
+		return nil, nil, corev2.BlobKey{}, err
 	}
 
-	return cert, fragmentInfo, nil
+	return cert, fragmentInfo, storageKey, nil
 }
 
 // GetBlobCertificates returns the certificates for the given blob keys
@@ -263,6 +402,176 @@ func (s *BlobMetadataStore) GetBlobCertificates(ctx context.Context, blobKeys []
 	return certs, fragmentInfos, nil
 }
 
+// PutBlobMetadataWithContent stores the metadata of a blob along with a reference to the content record given by its
+// ContentKey, in a single transaction, so that a reference is held exactly while the metadata exists. content is the
+// record as last read by the caller, or a new record with no references if there was none, in which case the record
+// is created and the caller must have uploaded the data under its storage key beforehand. A record with no
+// references must be deleted with DeleteBlobContent before it can be referenced again.
+// ErrBlobContentChanged is returned if the record was updated concurrently, in which case the caller should read it
+// again, and common.ErrAlreadyExists is returned if the metadata already exists.
+func (s *BlobMetadataStore) PutBlobMetadataWithContent(ctx context.Context, blobMetadata *v2.BlobMetadata, content *v2.BlobContent) error {
+	if blobMetadata.ContentKey == nil {
+		return errors.New("blob metadata has no content key")
+	}
+	contentKey := *blobMetadata.ContentKey
+	blobKey, err := blobMetadata.BlobHeader.BlobKey()
+	if err != nil {
+		return err
+	}
+	metadataItem, err := MarshalBlobMetadata(blobMetadata)
+	if err != nil {
+		return err
+	}
+	updated := *content
+	updated.RefCount++
+	contentItem, err := MarshalBlobContent(contentKey, &updated)
+	if err != nil {
+		return err
+	}
+
+	contentCondition := expression.AttributeNotExists(expression.Name("PK"))
+	if content.RefCount > 0 {
+		contentCondition = blobContentUnchanged(content)
+	}
+	metadataCondition := expression.AttributeNotExists(expression.Name("PK"))
+	err = s.dynamoDBClient.TransactWriteItems(ctx, s.tableName, []commondynamodb.TransactWrite{
+		{Item: contentItem, Condition: &contentCondition},
+		{Item: metadataItem, Condition: &metadataCondition},
+	})
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		// The transaction doesn't tell which condition failed
+		_, err = s.GetBlobMetadata(ctx, blobKey)
+		if err == nil {
+			return common.ErrAlreadyExists
+		}
+		if !errors.Is(err, common.ErrMetadataNotFound) {
+			return err
+		}
+		return fmt.Errorf("%w: content key %s", ErrBlobContentChanged, contentKey.Hex())
+	}
+
+	return err
+}
+
+// DeleteBlobMetadata deletes the metadata and the certificate of a blob. The reference the blob holds to its content
+// record, if any, is released in the same transaction, and the record is returned as it is after the release. The
+// caller is responsible for deleting the stored data of the blob: its own data if it has no content record, or the
+// data of the record if no references remain, followed by the record itself with DeleteBlobContent.
+// common.ErrMetadataNotFound is returned if the metadata has already been deleted.
+func (s *BlobMetadataStore) DeleteBlobMetadata(ctx context.Context, blobMetadata *v2.BlobMetadata) (*v2.BlobContent, error) {
+	blobKey, err := blobMetadata.BlobHeader.BlobKey()
+	if err != nil {
+		return nil, err
+	}
+	metadataCondition := expression.AttributeExists(expression.Name("PK"))
+	writes := []commondynamodb.TransactWrite{
+		{DeleteKey: blobItemKey(blobKey, blobMetadataSK), Condition: &metadataCondition},
+		{DeleteKey: blobItemKey(blobKey, blobCertSK)},
+	}
+	if blobMetadata.ContentKey == nil {
+		err = s.dynamoDBClient.TransactWriteItems(ctx, s.tableName, writes)
+		if errors.Is(err, commondynamodb.ErrConditionFailed) {
+			return nil, fmt.Errorf("%w: blob metadata not found for key %s", common.ErrMetadataNotFound, blobKey.Hex())
+		}
+		return nil, err
+	}
+
+	contentKey := *blobMetadata.ContentKey
+	for i := 0; i < maxContentUpdateAttempts; i++ {
+		content, err := s.GetBlobContent(ctx, contentKey)
+		if err != nil {
+			return nil, err
+		}
+		if content.RefCount == 0 {
+			return nil, fmt.Errorf("content key %s of blob %s has no references", contentKey.Hex(), blobKey.Hex())
+		}
+		updated := *content
+		updated.RefCount--
+		contentItem, err := MarshalBlobContent(contentKey, &updated)
+		if err != nil {
+			return nil, err
+		}
+		contentCondition := blobContentUnchanged(content)
+
+		err = s.dynamoDBClient.TransactWriteItems(ctx, s.tableName, append(writes, commondynamodb.TransactWrite{
+			Item:      contentItem,
+			Condition: &contentCondition,
+		}))
+		if err == nil {
+			return &updated, nil
+		}
+		if !errors.Is(err, commondynamodb.ErrConditionFailed) {
+			return nil, err
+		}
+		_, err = s.GetBlobMetadata(ctx, blobKey)
+		if err != nil {
+			return nil, err
+		}
+		// The content record was updated concurrently
+	}
+
+	return nil, fmt.Errorf("failed to release content key %s after %d attempts", contentKey.Hex(), maxContentUpdateAttempts)
+}
+
+// GetBlobContent returns the record of the content with the given key.
+func (s *BlobMetadataStore) GetBlobContent(ctx context.Context, contentKey v2.ContentKey) (*v2.BlobContent, error) {
+	item, err := s.dynamoDBClient.GetItem(ctx, s.tableName, blobContentItemKey(contentKey))
+	if err != nil {
+		return nil, err
+	}
+
+	if item == nil {
+		return nil, fmt.Errorf("%w: content not found for key %s", common.ErrMetadataNotFound, contentKey.Hex())
+	}
+
+	return UnmarshalBlobContent(item)
+}
+
+// PutBlobContentFragmentInfo records the fragment info of the encoded chunks of the content, so that blobs sharing the
+// content reuse them instead of encoding them again. ErrBlobContentChanged is returned if the record no longer exists.
+func (s *BlobMetadataStore) PutBlobContentFragmentInfo(ctx context.Context, contentKey v2.ContentKey, fragmentInfo *encoding.FragmentInfo) error {
+	av, err := attributevalue.Marshal(fragmentInfo)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fragment info: %w", err)
+	}
+	_, err = s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, blobContentItemKey(contentKey), map[string]types.AttributeValue{
+		"FragmentInfo": av,
+	}, expression.AttributeExists(expression.Name("PK")))
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return fmt.Errorf("%w: content key %s", ErrBlobContentChanged, contentKey.Hex())
+	}
+
+	return err
+}
+
+// DeleteBlobContent deletes a content record that no longer has any references, once its stored data has been
+// deleted. ErrBlobContentChanged is returned if the record is no longer the given one.
+func (s *BlobMetadataStore) DeleteBlobContent(ctx context.Context, contentKey v2.ContentKey, content *v2.BlobContent) error {
+	if content.RefCount != 0 {
+		return fmt.Errorf("content key %s still has %d references", contentKey.Hex(), content.RefCount)
+	}
+	condition := blobContentUnchanged(content)
+	err := s.dynamoDBClient.TransactWriteItems(ctx, s.tableName, []commondynamodb.TransactWrite{
+		{DeleteKey: blobContentItemKey(contentKey), Condition: &condition},
+	})
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return fmt.Errorf("%w: content key %s", ErrBlobContentChanged, contentKey.Hex())
+	}
+
+	return err
+}
+
+// blobContentUnchanged is the condition that the content record is still the given one. The storage key is compared
+// because a record may be deleted and created again.
+func blobContentUnchanged(content *v2.BlobContent) expression.ConditionBuilder {
+	condition := expression.Name("RefCount").Equal(expression.Value(content.RefCount)).
+		And(expression.Name("StorageKey").Equal(expression.Value(content.StorageKey[:])))
+	if content.FragmentInfo == nil {
+		return condition.And(expression.AttributeNotExists(expression.Name("FragmentInfo")))
+	}
+	return condition.And(expression.AttributeExists(expression.Name("FragmentInfo")))
+}
+
 func (s *BlobMetadataStore) PutDispersalRequest(ctx context.Context, req *corev2.DispersalRequest) error {
 	item, err := MarshalDispersalRequest(req)
 	if err != nil {
@@ -343,6 +652,57 @@ func (s *BlobMetadataStore) GetDispersalResponse(ctx context.Context, batchHeade
 	return res, nil
 }
 
+// GetDispersalRequestsByOperator returns the dispersal requests sent to the given operator within [start, end]
+// (in nanoseconds).
+func (s *BlobMetadataStore) GetDispersalRequestsByOperator(ctx context.Context, operatorID core.OperatorID, start uint64, end uint64) ([]*corev2.DispersalRequest, error) {
+	items, err := s.queryOperatorIndex(ctx, OperatorDispersalIndexName, "DispersedAt", operatorID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	reqs := make([]*corev2.DispersalRequest, 0, len(items))
+	for _, item := range items {
+		// Responses embed their request, so they are part of the dispersal index as well
+		if !hasSKPrefix(item, dispersalRequestSKPrefix) {
+			continue
+		}
+		req, err := UnmarshalDispersalRequest(item)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+
+	return reqs, nil
+}
+
+// GetDispersalResponsesByOperator returns the dispersal responses received from the given operator within
+// [start, end] (in nanoseconds).
+func (s *BlobMetadataStore) GetDispersalResponsesByOperator(ctx context.Context, operatorID core.OperatorID, start uint64, end uint64) ([]*corev2.DispersalResponse, error) {
+	items, err := s.queryOperatorIndex(ctx, OperatorResponseIndexName, "RespondedAt", operatorID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*corev2.DispersalResponse, len(items))
+	for i, item := range items {
+		responses[i], err = UnmarshalDispersalResponse(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return responses, nil
+}
+
+func (s *BlobMetadataStore) queryOperatorIndex(ctx context.Context, indexName string, rangeKey string, operatorID core.OperatorID, start uint64, end uint64) ([]commondynamodb.Item, error) {
+	return s.dynamoDBClient.QueryIndex(ctx, s.tableName, indexName, fmt.Sprintf("OperatorID = :operatorID AND %s BETWEEN :start AND :end", rangeKey), commondynamodb.ExpressionValues{
+		":operatorID": &types.AttributeValueMemberS{Value: operatorID.Hex()},
+		":start":      &types.AttributeValueMemberN{Value: strconv.FormatUint(start, 10)},
+		":end":        &types.AttributeValueMemberN{Value: strconv.FormatUint(end, 10)},
+	})
+}
+
 func (s *BlobMetadataStore) PutBatchHeader(ctx context.Context, batchHeader *corev2.BatchHeader) error {
 	item, err := MarshalBatchHeader(batchHeader)
 	if err != nil {
@@ -557,6 +917,14 @@ func GenerateTableSchema(tableName string, readCapacityUnits int64, writeCapacit
 				AttributeName: aws.String("RespondedAt"),
 				AttributeType: types.ScalarAttributeTypeN,
 			},
+			{
+				AttributeName: aws.String("AccountID"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("RequestedAt"),
+				AttributeType: types.ScalarAttributeTypeN,
+			},
 		},
 		KeySchema: []types.KeySchemaElement{
 			{
@@ -630,6 +998,26 @@ func GenerateTableSchema(tableName string, readCapacityUnits int64, writeCapacit
 					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
 				},
 			},
+			{
+				IndexName: aws.String(AccountBlobIndexName),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String("AccountID"),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("RequestedAt"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeAll,
+				},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+				},
+			},
 		},
 		ProvisionedThroughput: &types.ProvisionedThroughput{
 			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
@@ -651,6 +1039,10 @@ func MarshalBlobMetadata(metadata *v2.BlobMetadata) (commondynamodb.Item, error)
 	}
 	fields["PK"] = &types.AttributeValueMemberS{Value: blobKeyPrefix + blobKey.Hex()}
 	fields["SK"] = &types.AttributeValueMemberS{Value: blobMetadataSK}
+	// AccountID is the partition key of the account index, so it is lifted out of the nested payment metadata
+	if metadata.BlobHeader.PaymentMetadata.AccountID != "" {
+		fields["AccountID"] = &types.AttributeValueMemberS{Value: metadata.BlobHeader.PaymentMetadata.AccountID}
+	}
 
 	return fields, nil
 }
@@ -719,6 +1111,65 @@ func UnmarshalBlobCertificate(item commondynamodb.Item) (*corev2.BlobCertificate
 	return &cert, &fragmentInfo, nil
 }
 
+// UnmarshalStorageKey returns the key under which the data and encoded chunks of a blob are stored, given the
+// certificate item of the blob and the key of the blob itself.
+func UnmarshalStorageKey(item commondynamodb.Item, blobKey corev2.BlobKey) (corev2.BlobKey, error) {
+	av, ok := item["StorageKey"]
+	if !ok {
+		return blobKey, nil
+	}
+	b, ok := av.(*types.AttributeValueMemberB)
+	if !ok {
+		return corev2.BlobKey{}, fmt.Errorf("expected *types.AttributeValueMemberB for StorageKey, got %T", av)
+	}
+	return corev2.BytesToBlobKey(b.Value)
+}
+
+func blobItemKey(blobKey corev2.BlobKey, sk string) commondynamodb.Key {
+	return map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{
+			Value: blobKeyPrefix + blobKey.Hex(),
+		},
+		"SK": &types.AttributeValueMemberS{
+			Value: sk,
+		},
+	}
+}
+
+func blobContentItemKey(contentKey v2.ContentKey) commondynamodb.Key {
+	return map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{
+			Value: blobContentKeyPrefix + contentKey.Hex(),
+		},
+		"SK": &types.AttributeValueMemberS{
+			Value: blobContentSK,
+		},
+	}
+}
+
+func MarshalBlobContent(contentKey v2.ContentKey, content *v2.BlobContent) (commondynamodb.Item, error) {
+	fields, err := attributevalue.MarshalMap(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal blob content: %w", err)
+	}
+
+	// Add PK and SK fields
+	for k, v := range blobContentItemKey(contentKey) {
+		fields[k] = v
+	}
+
+	return fields, nil
+}
+
+func UnmarshalBlobContent(item commondynamodb.Item) (*v2.BlobContent, error) {
+	content := v2.BlobContent{}
+	err := attributevalue.UnmarshalMap(item, &content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal blob content: %w", err)
+	}
+	return &content, nil
+}
+
 func UnmarshalBatchHeaderHash(item commondynamodb.Item) ([32]byte, error) {
 	type Object struct {
 		PK string
@@ -907,6 +1358,11 @@ func UnmarshalAttestation(item commondynamodb.Item) (*corev2.Attestation, error)
 	return &attestation, nil
 }
 
+func hasSKPrefix(item commondynamodb.Item, prefix string) bool {
+	sk, ok := item["SK"].(*types.AttributeValueMemberS)
+	return ok && strings.HasPrefix(sk.Value, prefix)
+}
+
 func hexToHash(h string) ([32]byte, error) {
 	s := strings.TrimPrefix(h, "0x")
 	s = strings.TrimPrefix(s, "0X")
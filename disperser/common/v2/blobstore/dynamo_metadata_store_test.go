@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"math/big"
+	"sync"
 	"testing"
 	"time"
 
@@ -76,6 +77,11 @@ func TestBlobMetadataStoreOperations(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, metadata2, fetchedMetadata)
 
+	// keys without metadata are skipped
+	bulkMetadata, err := blobMetadataStore.GetBulkBlobMetadata(ctx, []corev2.BlobKey{blobKey1, blobKey2, {1, 2, 3}})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []*v2.BlobMetadata{metadata1, metadata2}, bulkMetadata)
+
 	queued, err := blobMetadataStore.GetBlobMetadataByStatus(ctx, v2.Queued, 0)
 	assert.NoError(t, err)
 	assert.Len(t, queued, 1)
@@ -110,6 +116,61 @@ func TestBlobMetadataStoreOperations(t *testing.T) {
 	})
 }
 
+func TestBlobMetadataStoreGetBlobMetadataByAccountID(t *testing.T) {
+	ctx := context.Background()
+	accountID := "0xaccount"
+	now := uint64(time.Now().UnixNano())
+	keys := make([]commondynamodb.Key, 0)
+	for i := 0; i < 3; i++ {
+		blobHeader := &corev2.BlobHeader{
+			BlobVersion:     0,
+			QuorumNumbers:   []core.QuorumID{0},
+			BlobCommitments: mockCommitment,
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID:         accountID,
+				BinIndex:          uint32(i),
+				CumulativePayment: big.NewInt(int64(i)),
+			},
+		}
+		blobKey, err := blobHeader.BlobKey()
+		assert.NoError(t, err)
+		err = blobMetadataStore.PutBlobMetadata(ctx, &v2.BlobMetadata{
+			BlobHeader:  blobHeader,
+			BlobStatus:  v2.Queued,
+			RequestedAt: now + uint64(i),
+			UpdatedAt:   now + uint64(i),
+		})
+		assert.NoError(t, err)
+		keys = append(keys, commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + blobKey.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobMetadata"},
+		})
+	}
+
+	// newest first
+	metadata, err := blobMetadataStore.GetBlobMetadataByAccountID(ctx, accountID, now, now+10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, metadata, 3)
+	assert.Equal(t, now+2, metadata[0].RequestedAt)
+	assert.Equal(t, now, metadata[2].RequestedAt)
+
+	metadata, err = blobMetadataStore.GetBlobMetadataByAccountID(ctx, accountID, now, now+10, 2)
+	assert.NoError(t, err)
+	assert.Len(t, metadata, 2)
+	assert.Equal(t, now+2, metadata[0].RequestedAt)
+
+	metadata, err = blobMetadataStore.GetBlobMetadataByAccountID(ctx, accountID, now+1, now+1, 0)
+	assert.NoError(t, err)
+	assert.Len(t, metadata, 1)
+	assert.Equal(t, uint32(1), metadata[0].BlobHeader.PaymentMetadata.BinIndex)
+
+	metadata, err = blobMetadataStore.GetBlobMetadataByAccountID(ctx, "0xother", now, now+10, 0)
+	assert.NoError(t, err)
+	assert.Len(t, metadata, 0)
+
+	deleteItems(t, keys)
+}
+
 func TestBlobMetadataStoreCerts(t *testing.T) {
 	ctx := context.Background()
 	blobCert := &corev2.BlobCertificate{
@@ -205,6 +266,220 @@ func TestBlobMetadataStoreCerts(t *testing.T) {
 	})
 }
 
+func TestBlobMetadataStoreCertStorageKey(t *testing.T) {
+	ctx := context.Background()
+	blobCert := &corev2.BlobCertificate{
+		BlobHeader: &corev2.BlobHeader{
+			BlobVersion:     0,
+			QuorumNumbers:   []core.QuorumID{0, 1},
+			BlobCommitments: mockCommitment,
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID:         "0x123",
+				BinIndex:          0,
+				CumulativePayment: big.NewInt(987),
+			},
+			Signature: []byte("signature"),
+		},
+		RelayKeys: []corev2.RelayKey{0},
+	}
+	fragmentInfo := &encoding.FragmentInfo{
+		TotalChunkSizeBytes: 100,
+		FragmentSizeBytes:   1024 * 1024 * 4,
+	}
+	storageKey := corev2.BlobKey{1, 2, 3}
+	err := blobMetadataStore.PutBlobCertificateWithStorageKey(ctx, blobCert, fragmentInfo, storageKey)
+	assert.NoError(t, err)
+
+	blobKey, err := blobCert.BlobHeader.BlobKey()
+	assert.NoError(t, err)
+	fetchedCert, fetchedFragmentInfo, fetchedStorageKey, err := blobMetadataStore.GetBlobCertificateWithStorageKey(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, blobCert, fetchedCert)
+	assert.Equal(t, fragmentInfo, fetchedFragmentInfo)
+	assert.Equal(t, storageKey, fetchedStorageKey)
+
+	// certificates stored without a storage key are stored under their own key
+	blobCert.BlobHeader.PaymentMetadata.BinIndex = 1
+	err = blobMetadataStore.PutBlobCertificate(ctx, blobCert, fragmentInfo)
+	assert.NoError(t, err)
+	blobKey1, err := blobCert.BlobHeader.BlobKey()
+	assert.NoError(t, err)
+	_, _, fetchedStorageKey, err = blobMetadataStore.GetBlobCertificateWithStorageKey(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Equal(t, blobKey1, fetchedStorageKey)
+
+	deleteItems(t, []commondynamodb.Key{
+		{
+			"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + blobKey.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobCertificate"},
+		},
+		{
+			"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + blobKey1.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobCertificate"},
+		},
+	})
+}
+
+func TestBlobMetadataStoreContent(t *testing.T) {
+	ctx := context.Background()
+	contentKey := v2.ContentKey{4, 5, 6}
+	storageKey := corev2.BlobKey{7, 8, 9}
+	newMetadata := func(binIndex uint32) *v2.BlobMetadata {
+		return &v2.BlobMetadata{
+			BlobHeader: &corev2.BlobHeader{
+				BlobVersion:     0,
+				QuorumNumbers:   []core.QuorumID{0},
+				BlobCommitments: mockCommitment,
+				PaymentMetadata: core.PaymentMetadata{
+					AccountID:         "0x123",
+					BinIndex:          binIndex,
+					CumulativePayment: big.NewInt(532),
+				},
+			},
+			BlobStatus: v2.Queued,
+			Expiry:     uint64(time.Now().Add(time.Hour).Unix()),
+			UpdatedAt:  uint64(time.Now().UnixNano()),
+			ContentKey: &contentKey,
+			StorageKey: &storageKey,
+		}
+	}
+	metadata1 := newMetadata(0)
+	metadata2 := newMetadata(1)
+	blobKey2, err := metadata2.BlobHeader.BlobKey()
+	assert.NoError(t, err)
+
+	_, err = blobMetadataStore.GetBlobContent(ctx, contentKey)
+	assert.ErrorIs(t, err, common.ErrMetadataNotFound)
+
+	// the first blob creates the record along with its metadata
+	newContent := &v2.BlobContent{StorageKey: storageKey}
+	err = blobMetadataStore.PutBlobMetadataWithContent(ctx, metadata1, newContent)
+	assert.NoError(t, err)
+	content, err := blobMetadataStore.GetBlobContent(ctx, contentKey)
+	assert.NoError(t, err)
+	assert.Equal(t, &v2.BlobContent{StorageKey: storageKey, RefCount: 1}, content)
+
+	// the record can't be created twice, and the metadata can't be stored twice
+	err = blobMetadataStore.PutBlobMetadataWithContent(ctx, metadata2, newContent)
+	assert.ErrorIs(t, err, blobstore.ErrBlobContentChanged)
+	err = blobMetadataStore.PutBlobMetadataWithContent(ctx, metadata1, content)
+	assert.ErrorIs(t, err, common.ErrAlreadyExists)
+
+	// the second blob references the record
+	err = blobMetadataStore.PutBlobMetadataWithContent(ctx, metadata2, content)
+	assert.NoError(t, err)
+	fragmentInfo := &encoding.FragmentInfo{
+		TotalChunkSizeBytes: 100,
+		FragmentSizeBytes:   1024 * 1024 * 4,
+	}
+	err = blobMetadataStore.PutBlobContentFragmentInfo(ctx, contentKey, fragmentInfo)
+	assert.NoError(t, err)
+	content, err = blobMetadataStore.GetBlobContent(ctx, contentKey)
+	assert.NoError(t, err)
+	assert.Equal(t, &v2.BlobContent{StorageKey: storageKey, RefCount: 2, FragmentInfo: fragmentInfo}, content)
+
+	// deleting the metadata releases the references
+	content, err = blobMetadataStore.DeleteBlobMetadata(ctx, metadata1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), content.RefCount)
+	_, err = blobMetadataStore.DeleteBlobMetadata(ctx, metadata1)
+	assert.ErrorIs(t, err, common.ErrMetadataNotFound)
+	content, err = blobMetadataStore.DeleteBlobMetadata(ctx, metadata2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), content.RefCount)
+	_, err = blobMetadataStore.GetBlobMetadata(ctx, blobKey2)
+	assert.ErrorIs(t, err, common.ErrMetadataNotFound)
+
+	// a released record can't be referenced until it is deleted
+	err = blobMetadataStore.PutBlobMetadataWithContent(ctx, metadata1, content)
+	assert.ErrorIs(t, err, blobstore.ErrBlobContentChanged)
+	err = blobMetadataStore.PutBlobMetadataWithContent(ctx, metadata1, newContent)
+	assert.ErrorIs(t, err, blobstore.ErrBlobContentChanged)
+	err = blobMetadataStore.DeleteBlobContent(ctx, contentKey, content)
+	assert.NoError(t, err)
+	_, err = blobMetadataStore.GetBlobContent(ctx, contentKey)
+	assert.ErrorIs(t, err, common.ErrMetadataNotFound)
+	err = blobMetadataStore.DeleteBlobContent(ctx, contentKey, content)
+	assert.ErrorIs(t, err, blobstore.ErrBlobContentChanged)
+
+	err = blobMetadataStore.PutBlobMetadataWithContent(ctx, metadata1, newContent)
+	assert.NoError(t, err)
+	_, err = blobMetadataStore.DeleteBlobMetadata(ctx, metadata1)
+	assert.NoError(t, err)
+	deleteItems(t, []commondynamodb.Key{
+		{
+			"PK": &types.AttributeValueMemberS{Value: "BlobContent#" + contentKey.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobContent"},
+		},
+	})
+}
+
+func TestBlobMetadataStoreContentConcurrentPut(t *testing.T) {
+	ctx := context.Background()
+	contentKey := v2.ContentKey{7, 8, 9}
+	storageKey := corev2.BlobKey{1}
+	err := blobMetadataStore.PutBlobMetadataWithContent(ctx, &v2.BlobMetadata{
+		BlobHeader: &corev2.BlobHeader{
+			BlobVersion:     0,
+			QuorumNumbers:   []core.QuorumID{0},
+			BlobCommitments: mockCommitment,
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID:         "0x456",
+				BinIndex:          0,
+				CumulativePayment: big.NewInt(532),
+			},
+		},
+		ContentKey: &contentKey,
+		StorageKey: &storageKey,
+	}, &v2.BlobContent{StorageKey: storageKey})
+	assert.NoError(t, err)
+
+	// every blob retries with a fresh read of the record until its reference is taken
+	numBlobs := 8
+	var wg sync.WaitGroup
+	for i := 1; i <= numBlobs; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			metadata := &v2.BlobMetadata{
+				BlobHeader: &corev2.BlobHeader{
+					BlobVersion:     0,
+					QuorumNumbers:   []core.QuorumID{0},
+					BlobCommitments: mockCommitment,
+					PaymentMetadata: core.PaymentMetadata{
+						AccountID:         "0x456",
+						BinIndex:          uint32(i),
+						CumulativePayment: big.NewInt(532),
+					},
+				},
+				ContentKey: &contentKey,
+				StorageKey: &storageKey,
+			}
+			for {
+				content, err := blobMetadataStore.GetBlobContent(ctx, contentKey)
+				assert.NoError(t, err)
+				err = blobMetadataStore.PutBlobMetadataWithContent(ctx, metadata, content)
+				if !errors.Is(err, blobstore.ErrBlobContentChanged) {
+					assert.NoError(t, err)
+					return
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	content, err := blobMetadataStore.GetBlobContent(ctx, contentKey)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(numBlobs+1), content.RefCount)
+
+	deleteItems(t, []commondynamodb.Key{
+		{
+			"PK": &types.AttributeValueMemberS{Value: "BlobContent#" + contentKey.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobContent"},
+		},
+	})
+}
+
 func TestBlobMetadataStoreUpdateBlobStatus(t *testing.T) {
 	ctx := context.Background()
 	blobHeader := &corev2.BlobHeader{
@@ -234,6 +509,8 @@ func TestBlobMetadataStoreUpdateBlobStatus(t *testing.T) {
 	// Update the blob status to invalid status
 	err = blobMetadataStore.UpdateBlobStatus(ctx, blobKey, v2.Certified)
 	assert.ErrorIs(t, err, blobstore.ErrInvalidStateTransition)
+	err = blobMetadataStore.UpdateBlobStatus(ctx, blobKey, v2.InsufficientSignatures)
+	assert.ErrorIs(t, err, blobstore.ErrInvalidStateTransition)
 
 	// Update the blob status to a valid status
 	err = blobMetadataStore.UpdateBlobStatus(ctx, blobKey, v2.Encoded)
@@ -264,6 +541,49 @@ func TestBlobMetadataStoreUpdateBlobStatus(t *testing.T) {
 	})
 }
 
+func TestBlobMetadataStoreUpdateBlobStatusInsufficientSignatures(t *testing.T) {
+	ctx := context.Background()
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     0,
+		QuorumNumbers:   []core.QuorumID{0},
+		BlobCommitments: mockCommitment,
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         "0x123",
+			BinIndex:          0,
+			CumulativePayment: big.NewInt(533),
+		},
+	}
+	blobKey, err := blobHeader.BlobKey()
+	assert.NoError(t, err)
+
+	now := time.Now()
+	metadata := &v2.BlobMetadata{
+		BlobHeader: blobHeader,
+		BlobStatus: v2.Encoded,
+		Expiry:     uint64(now.Add(time.Hour).Unix()),
+		NumRetries: 0,
+		UpdatedAt:  uint64(now.UnixNano()),
+	}
+	err = blobMetadataStore.PutBlobMetadata(ctx, metadata)
+	assert.NoError(t, err)
+
+	// Encoded blobs which no stake signed are final
+	err = blobMetadataStore.UpdateBlobStatus(ctx, blobKey, v2.InsufficientSignatures)
+	assert.NoError(t, err)
+	fetchedMetadata, err := blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, v2.InsufficientSignatures, fetchedMetadata.BlobStatus)
+	err = blobMetadataStore.UpdateBlobStatus(ctx, blobKey, v2.Certified)
+	assert.ErrorIs(t, err, blobstore.ErrInvalidStateTransition)
+
+	deleteItems(t, []commondynamodb.Key{
+		{
+			"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + blobKey.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobMetadata"},
+		},
+	})
+}
+
 func TestBlobMetadataStoreDispersals(t *testing.T) {
 	ctx := context.Background()
 	opID := core.OperatorID{0, 1}
@@ -323,6 +643,72 @@ func TestBlobMetadataStoreDispersals(t *testing.T) {
 	})
 }
 
+func TestBlobMetadataStoreDispersalsByOperator(t *testing.T) {
+	ctx := context.Background()
+	opID := core.OperatorID{0, 2}
+	now := uint64(time.Now().UnixNano())
+	keys := make([]commondynamodb.Key, 0)
+	for i := 0; i < 3; i++ {
+		req := &corev2.DispersalRequest{
+			OperatorID:      opID,
+			OperatorAddress: gethcommon.HexToAddress("0x1234567"),
+			Socket:          "socket",
+			DispersedAt:     now + uint64(i),
+			BatchHeader: corev2.BatchHeader{
+				BatchRoot:            [32]byte{2, byte(i)},
+				ReferenceBlockNumber: 100,
+			},
+		}
+		err := blobMetadataStore.PutDispersalRequest(ctx, req)
+		assert.NoError(t, err)
+		bhh, err := req.BatchHeader.Hash()
+		assert.NoError(t, err)
+		keys = append(keys, commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: "Dispersal#" + hex.EncodeToString(bhh[:])},
+			"SK": &types.AttributeValueMemberS{Value: "DispersalRequest#" + opID.Hex()},
+		})
+
+		// only the first two batches are signed
+		if i == 2 {
+			continue
+		}
+		err = blobMetadataStore.PutDispersalResponse(ctx, &corev2.DispersalResponse{
+			DispersalRequest: req,
+			RespondedAt:      now + uint64(i) + 1,
+			Signature:        [32]byte{1, 1, 1},
+		})
+		assert.NoError(t, err)
+		keys = append(keys, commondynamodb.Key{
+			"PK": &types.AttributeValueMemberS{Value: "Dispersal#" + hex.EncodeToString(bhh[:])},
+			"SK": &types.AttributeValueMemberS{Value: "DispersalResponse#" + opID.Hex()},
+		})
+	}
+
+	reqs, err := blobMetadataStore.GetDispersalRequestsByOperator(ctx, opID, now, now+10)
+	assert.NoError(t, err)
+	assert.Len(t, reqs, 3)
+	for _, req := range reqs {
+		assert.Equal(t, opID, req.OperatorID)
+	}
+	reqs, err = blobMetadataStore.GetDispersalRequestsByOperator(ctx, opID, now+1, now+10)
+	assert.NoError(t, err)
+	assert.Len(t, reqs, 2)
+
+	responses, err := blobMetadataStore.GetDispersalResponsesByOperator(ctx, opID, now, now+10)
+	assert.NoError(t, err)
+	assert.Len(t, responses, 2)
+	for _, res := range responses {
+		assert.Equal(t, opID, res.OperatorID)
+		assert.Equal(t, [32]byte{1, 1, 1}, res.Signature)
+	}
+
+	reqs, err = blobMetadataStore.GetDispersalRequestsByOperator(ctx, core.OperatorID{9}, now, now+10)
+	assert.NoError(t, err)
+	assert.Len(t, reqs, 0)
+
+	deleteItems(t, keys)
+}
+
 func TestBlobMetadataStoreVerificationInfo(t *testing.T) {
 	ctx := context.Background()
 	blobKey := corev2.BlobKey{1, 1, 1}
@@ -10,6 +10,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// BlobStore stores the data of v2 blobs in S3. The encoder stores the chunks and proofs of the blobs in the same bucket.
+// Data, chunks and proofs are deleted by the API server once no unexpired blob references them, and shared data
+// outlives the blob that uploaded it, so the bucket must not expire objects by age.
 type BlobStore struct {
 	bucketName string
 	s3Client   s3.Client
@@ -48,3 +51,33 @@ func (b *BlobStore) GetBlob(ctx context.Context, key corev2.BlobKey) ([]byte, er
 	}
 	return data, nil
 }
+
+// DeleteBlob removes a blob from the blob store. The data of shared content must only be deleted once the last
+// reference to the content is released, see BlobMetadataStore.DeleteBlobMetadata. Deleting a blob that does not
+// exist is not an error.
+func (b *BlobStore) DeleteBlob(ctx context.Context, key corev2.BlobKey) error {
+	err := b.s3Client.DeleteObject(ctx, b.bucketName, s3.ScopedBlobKey(key))
+	if err != nil {
+		b.logger.Errorf("failed to delete blob from bucket %s: %v", b.bucketName, err)
+		return err
+	}
+	return nil
+}
+
+// DeleteChunks removes the encoded chunks and proofs of a blob from the blob store. Like DeleteBlob, it must only be
+// called for shared content once the last reference to the content is released. Deleting chunks and proofs that do not
+// exist is not an error.
+func (b *BlobStore) DeleteChunks(ctx context.Context, key corev2.BlobKey) error {
+	err := b.s3Client.FragmentedDeleteObject(ctx, b.bucketName, s3.ScopedChunkKey(key))
+	if err != nil {
+		b.logger.Errorf("failed to delete chunks from bucket %s: %v", b.bucketName, err)
+		return err
+	}
+
+	err = b.s3Client.DeleteObject(ctx, b.bucketName, s3.ScopedProofKey(key))
+	if err != nil {
+		b.logger.Errorf("failed to delete proofs from bucket %s: %v", b.bucketName, err)
+		return err
+	}
+	return nil
+}
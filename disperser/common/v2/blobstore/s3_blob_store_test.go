@@ -4,8 +4,10 @@ import (
 	"context"
 	"testing"
 
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -25,3 +27,48 @@ func TestGetBlobNotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, data)
 }
+
+func TestDeleteBlob(t *testing.T) {
+	testBlobKey := corev2.BlobKey(tu.RandomBytes(32))
+	err := blobStore.StoreBlob(context.Background(), testBlobKey, []byte("testBlobData"))
+	assert.NoError(t, err)
+	err = blobStore.DeleteBlob(context.Background(), testBlobKey)
+	assert.NoError(t, err)
+	data, err := blobStore.GetBlob(context.Background(), testBlobKey)
+	assert.ErrorIs(t, err, common.ErrBlobNotFound)
+	assert.Nil(t, data)
+}
+
+func TestDeleteChunks(t *testing.T) {
+	ctx := context.Background()
+	testBlobKey := corev2.BlobKey(tu.RandomBytes(32))
+	otherBlobKey := corev2.BlobKey(tu.RandomBytes(32))
+	for _, key := range []corev2.BlobKey{testBlobKey, otherBlobKey} {
+		err := blobStore.StoreBlob(ctx, key, []byte("testBlobData"))
+		assert.NoError(t, err)
+		err = s3Client.FragmentedUploadObject(ctx, s3BucketName, s3.ScopedChunkKey(key), tu.RandomBytes(10), 4)
+		assert.NoError(t, err)
+		err = s3Client.UploadObject(ctx, s3BucketName, s3.ScopedProofKey(key), tu.RandomBytes(10))
+		assert.NoError(t, err)
+	}
+
+	err := blobStore.DeleteChunks(ctx, testBlobKey)
+	assert.NoError(t, err)
+	fragments, err := s3Client.ListObjects(ctx, s3BucketName, s3.ScopedChunkKey(testBlobKey))
+	assert.NoError(t, err)
+	assert.Empty(t, fragments)
+	_, err = s3Client.DownloadObject(ctx, s3BucketName, s3.ScopedProofKey(testBlobKey))
+	assert.ErrorIs(t, err, s3.ErrObjectNotFound)
+
+	// the data of the blob and the chunks and proofs of other blobs are kept
+	_, err = blobStore.GetBlob(ctx, testBlobKey)
+	assert.NoError(t, err)
+	_, err = s3Client.FragmentedDownloadObject(ctx, s3BucketName, s3.ScopedChunkKey(otherBlobKey), 10, 4)
+	assert.NoError(t, err)
+	_, err = s3Client.DownloadObject(ctx, s3BucketName, s3.ScopedProofKey(otherBlobKey))
+	assert.NoError(t, err)
+
+	// deleting chunks again is not an error
+	err = blobStore.DeleteChunks(ctx, testBlobKey)
+	assert.NoError(t, err)
+}
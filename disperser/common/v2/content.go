@@ -0,0 +1,71 @@
+package v2
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"golang.org/x/crypto/sha3"
+)
+
+// ContentKey identifies the content of a blob: its data, its commitments and the blob version it is encoded with.
+// Blobs with the same content key have identical data and identical encoded chunks, even if their BlobKeys differ
+// because of their quorums or payment metadata, so they can share the same stored bytes and chunks.
+type ContentKey [32]byte
+
+func (k ContentKey) Hex() string {
+	return hex.EncodeToString(k[:])
+}
+
+// ComputeContentKey computes the content key of a blob from its header and data.
+func ComputeContentKey(blobHeader *core.BlobHeader, data []byte) (ContentKey, error) {
+	if blobHeader == nil {
+		return ContentKey{}, errors.New("blob header is nil")
+	}
+	commitments, err := blobHeader.BlobCommitments.ToProtobuf()
+	if err != nil {
+		return ContentKey{}, err
+	}
+
+	dataHash := sha3.NewLegacyKeccak256()
+	dataHash.Write(data)
+
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte{byte(blobHeader.BlobVersion)})
+	hasher.Write(commitments.GetCommitment())
+	hasher.Write(commitments.GetLengthCommitment())
+	hasher.Write(commitments.GetLengthProof())
+	hasher.Write(binary.BigEndian.AppendUint32(nil, commitments.GetLength()))
+	hasher.Write(binary.BigEndian.AppendUint64(nil, uint64(len(data))))
+	hasher.Write(dataHash.Sum(nil))
+
+	var key ContentKey
+	copy(key[:], hasher.Sum(nil))
+	return key, nil
+}
+
+// BlobContent is the record of content shared by the blobs with the same content key.
+type BlobContent struct {
+	// StorageKey is the key under which the data and encoded chunks of the content are stored. It is generated when
+	// the record is created and is not the key of any blob, so the stored data lives exactly as long as the record:
+	// it is deleted once the last blob referencing the content is deleted, regardless of which blob stored it.
+	StorageKey core.BlobKey
+	// RefCount is the number of blobs that reference the content. The stored data may only be deleted once it drops
+	// to zero, after which the record can no longer be referenced.
+	RefCount uint64
+	// FragmentInfo describes the encoded chunks of the content. It is nil until the content has been encoded.
+	FragmentInfo *encoding.FragmentInfo `dynamodbav:",omitempty"`
+}
+
+// NewContentStorageKey returns a random key to store the data of new content under.
+func NewContentStorageKey() (core.BlobKey, error) {
+	var key core.BlobKey
+	if _, err := rand.Read(key[:]); err != nil {
+		return core.BlobKey{}, fmt.Errorf("failed to generate storage key: %w", err)
+	}
+	return key, nil
+}
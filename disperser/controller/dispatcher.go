@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
@@ -11,12 +12,11 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
 	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
-	"github.com/wealdtech/go-merkletree/v2"
-	"github.com/wealdtech/go-merkletree/v2/keccak256"
 )
 
 var errNoBlobsToDispatch = errors.New("no blobs to dispatch")
@@ -37,6 +37,7 @@ type Dispatcher struct {
 	chainState        core.IndexedChainState
 	aggregator        core.SignatureAggregator
 	nodeClientManager NodeClientManager
+	eventSink         events.Sink
 	logger            logging.Logger
 
 	lastUpdatedAt uint64
@@ -56,6 +57,7 @@ func NewDispatcher(
 	chainState core.IndexedChainState,
 	aggregator core.SignatureAggregator,
 	nodeClientManager NodeClientManager,
+	eventSink events.Sink,
 	logger logging.Logger,
 ) (*Dispatcher, error) {
 	return &Dispatcher{
@@ -66,6 +68,7 @@ func NewDispatcher(
 		chainState:        chainState,
 		aggregator:        aggregator,
 		nodeClientManager: nodeClientManager,
+		eventSink:         eventSink,
 		logger:            logger.With("component", "Dispatcher"),
 
 		lastUpdatedAt: 0,
@@ -138,6 +141,12 @@ func (d *Dispatcher) HandleBatch(ctx context.Context) (chan core.SigningMessage,
 		client, err := d.nodeClientManager.GetClient(host, dispersalPort)
 		if err != nil {
 			d.logger.Error("failed to get node client", "operator", opID, "err", err)
+			// Every operator must reply, for the signatures of the batch to be handled
+			sigChan <- core.SigningMessage{
+				Operator:        opID,
+				BatchHeaderHash: batchData.BatchHeaderHash,
+				Err:             fmt.Errorf("failed to get node client: %w", err),
+			}
 			continue
 		}
 
@@ -154,6 +163,11 @@ func (d *Dispatcher) HandleBatch(ctx context.Context) (chan core.SigningMessage,
 			err := d.blobMetadataStore.PutDispersalRequest(ctx, req)
 			if err != nil {
 				d.logger.Error("failed to put dispersal request", "err", err)
+				sigChan <- core.SigningMessage{
+					Operator:        opID,
+					BatchHeaderHash: batchData.BatchHeaderHash,
+					Err:             fmt.Errorf("failed to put dispersal request: %w", err),
+				}
 				return
 			}
 
@@ -182,6 +196,14 @@ func (d *Dispatcher) HandleBatch(ctx context.Context) (chan core.SigningMessage,
 				}
 
 				d.logger.Warn("failed to send chunks", "operator", opID, "NumAttempts", i, "err", err)
+				if i == d.NumRequestRetries {
+					sigChan <- core.SigningMessage{
+						Operator:        opID,
+						BatchHeaderHash: batchData.BatchHeaderHash,
+						Err:             err,
+					}
+					break
+				}
 				time.Sleep(time.Duration(math.Pow(2, float64(i))) * time.Second) // Wait before retrying
 			}
 		})
@@ -190,20 +212,31 @@ func (d *Dispatcher) HandleBatch(ctx context.Context) (chan core.SigningMessage,
 	return sigChan, batchData, nil
 }
 
-// HandleSignatures receives signatures from operators, validates, and aggregates them
+// HandleSignatures receives signatures from operators, validates, and aggregates them. The blobs of the batch are
+// marked as certified if the attestation is stored, as having insufficient signatures if no stake signed the batch,
+// and as failed otherwise.
 func (d *Dispatcher) HandleSignatures(ctx context.Context, batchData *batchData, sigChan chan core.SigningMessage) error {
 	quorumAttestation, err := d.aggregator.ReceiveSignatures(ctx, batchData.OperatorState, batchData.BatchHeaderHash, sigChan)
 	if err != nil {
+		d.updateBatchStatus(ctx, batchData, v2.Failed)
 		return fmt.Errorf("failed to receive and validate signatures: %w", err)
 	}
-	quorums := make([]core.QuorumID, len(quorumAttestation.QuorumResults))
-	i := 0
-	for quorumID := range quorumAttestation.QuorumResults {
-		quorums[i] = quorumID
-		i++
+	// Only the quorums in which some stake signed the batch have an aggregate signature
+	quorums := make([]core.QuorumID, 0, len(quorumAttestation.QuorumResults))
+	quorumResults := make(map[core.QuorumID]uint8, len(quorumAttestation.QuorumResults))
+	for quorumID, result := range quorumAttestation.QuorumResults {
+		if result.PercentSigned > 0 {
+			quorums = append(quorums, quorumID)
+			quorumResults[quorumID] = result.PercentSigned
+		}
+	}
+	if len(quorums) == 0 {
+		d.updateBatchStatus(ctx, batchData, v2.InsufficientSignatures)
+		return errors.New("no stake signed the batch in any quorum")
 	}
 	aggSig, err := d.aggregator.AggregateSignatures(ctx, d.chainState, uint(batchData.Batch.BatchHeader.ReferenceBlockNumber), quorumAttestation, quorums)
 	if err != nil {
+		d.updateBatchStatus(ctx, batchData, v2.Failed)
 		return fmt.Errorf("failed to aggregate signatures: %w", err)
 	}
 	err = d.blobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
@@ -214,16 +247,14 @@ func (d *Dispatcher) HandleSignatures(ctx context.Context, batchData *batchData,
 		QuorumAPKs:       aggSig.QuorumAggPubKeys,
 		Sigma:            aggSig.AggSignature,
 		QuorumNumbers:    quorums,
+		QuorumResults:    quorumResults,
 	})
 	if err != nil {
+		d.updateBatchStatus(ctx, batchData, v2.Failed)
 		return fmt.Errorf("failed to put attestation: %w", err)
 	}
 
-	err = d.updateBatchStatus(ctx, batchData.BlobKeys, v2.Certified)
-	if err != nil {
-		return fmt.Errorf("failed to mark blobs as certified: %w", err)
-	}
-
+	d.updateBatchStatus(ctx, batchData, v2.Certified)
 	return nil
 }
 
@@ -292,7 +323,7 @@ func (d *Dispatcher) NewBatch(ctx context.Context, referenceBlockNumber uint64)
 		ReferenceBlockNumber: referenceBlockNumber,
 	}
 
-	tree, err := BuildMerkleTree(certs)
+	tree, err := corev2.BuildMerkleTree(certs)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build merkle tree: %w", err)
 	}
@@ -388,30 +419,29 @@ func (d *Dispatcher) sendChunks(ctx context.Context, client clients.NodeClientV2
 	return sig, nil
 }
 
-func (d *Dispatcher) updateBatchStatus(ctx context.Context, keys []corev2.BlobKey, status v2.BlobStatus) error {
-	for _, key := range keys {
+// updateBatchStatus updates the status of the blobs of a batch, and emits the lifecycle events of the blobs whose
+// status was updated. Blobs whose status can't be updated are logged and skipped.
+func (d *Dispatcher) updateBatchStatus(ctx context.Context, batchData *batchData, status v2.BlobStatus) {
+	var eventType events.EventType
+	switch status {
+	case v2.Certified:
+		eventType = events.BlobCertified
+	case v2.Failed, v2.InsufficientSignatures:
+		eventType = events.BlobFailed
+	}
+
+	batchHeaderHash := hex.EncodeToString(batchData.BatchHeaderHash[:])
+	for i, key := range batchData.BlobKeys {
 		err := d.blobMetadataStore.UpdateBlobStatus(ctx, key, status)
 		if err != nil {
 			d.logger.Error("failed to update blob status", "blobKey", key.Hex(), "status", status.String(), "err", err)
+			continue
 		}
-	}
-	return nil
-}
-
-func BuildMerkleTree(certs []*corev2.BlobCertificate) (*merkletree.MerkleTree, error) {
-	leafs := make([][]byte, len(certs))
-	for i, cert := range certs {
-		leaf, err := cert.Hash()
-		if err != nil {
-			return nil, fmt.Errorf("failed to compute blob header hash: %w", err)
+		if eventType == "" {
+			continue
 		}
-		leafs[i] = leaf[:]
-	}
-
-	tree, err := merkletree.NewTree(merkletree.WithData(leafs), merkletree.WithHashType(keccak256.New()))
-	if err != nil {
-		return nil, err
+		event := events.NewEvent(eventType, 2, batchData.Batch.BlobCertificates[i].BlobHeader.PaymentMetadata.AccountID, key.Hex())
+		event.BatchHeaderHash = batchHeaderHash
+		d.eventSink.Emit(event)
 	}
-
-	return tree, nil
 }
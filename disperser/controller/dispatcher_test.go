@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"math/big"
 	"testing"
 	"time"
@@ -13,6 +14,8 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/events"
+	eventsmock "github.com/Layr-Labs/eigenda/disperser/common/events/mock"
 	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
@@ -49,6 +52,7 @@ type dispatcherComponents struct {
 	ChainState        *coremock.ChainDataMock
 	SigAggregator     *core.StdSignatureAggregator
 	NodeClientManager *controller.MockClientManager
+	EventSink         *eventsmock.MockSink
 }
 
 func TestDispatcherHandleBatch(t *testing.T) {
@@ -57,7 +61,7 @@ func TestDispatcherHandleBatch(t *testing.T) {
 	ctx := context.Background()
 
 	// Get batch header hash to mock signatures
-	merkleTree, err := controller.BuildMerkleTree(objs.blobCerts)
+	merkleTree, err := corev2.BuildMerkleTree(objs.blobCerts)
 	require.NoError(t, err)
 	require.NotNil(t, merkleTree)
 	require.NotNil(t, merkleTree.Root())
@@ -93,6 +97,20 @@ func TestDispatcherHandleBatch(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, v2.Certified, bm1.BlobStatus)
 
+	// Test that the certified events are emitted
+	emitted := make(map[string]*events.Event)
+	for _, event := range components.EventSink.Events() {
+		emitted[event.BlobKey] = event
+	}
+	for i, key := range objs.blobKeys {
+		event, ok := emitted[key.Hex()]
+		require.True(t, ok)
+		require.Equal(t, events.BlobCertified, event.Type)
+		require.Equal(t, uint8(2), event.Version)
+		require.Equal(t, objs.blobHedaers[i].PaymentMetadata.AccountID, event.AccountID)
+		require.Equal(t, hex.EncodeToString(batchData.BatchHeaderHash[:]), event.BatchHeaderHash)
+	}
+
 	// Get batch header
 	vis, err := components.BlobMetadataStore.GetBlobVerificationInfos(ctx, objs.blobKeys[0])
 	require.NoError(t, err)
@@ -111,6 +129,87 @@ func TestDispatcherHandleBatch(t *testing.T) {
 	require.Len(t, att.QuorumAPKs, 2)
 	require.NotNil(t, att.Sigma)
 	require.ElementsMatch(t, att.QuorumNumbers, []core.QuorumID{0, 1})
+	require.Equal(t, map[core.QuorumID]uint8{0: 100, 1: 100}, att.QuorumResults)
+}
+
+func TestDispatcherHandleBatchUnreachableOperator(t *testing.T) {
+	components := newDispatcherComponents(t)
+	objs := setupBlobCerts(t, components.BlobMetadataStore, 2)
+	ctx := context.Background()
+
+	merkleTree, err := corev2.BuildMerkleTree(objs.blobCerts)
+	require.NoError(t, err)
+	batchHeader := &corev2.BatchHeader{
+		ReferenceBlockNumber: blockNumber - finalizationBlockDelay,
+	}
+	copy(batchHeader.BatchRoot[:], merkleTree.Root())
+	bhh, err := batchHeader.Hash()
+	require.NoError(t, err)
+
+	// The first operator signs, the second one can't be reached
+	mockClient0 := clientsmock.NewNodeClientV2()
+	mockClient0.On("StoreChunks", mock.Anything, mock.Anything).Return(mockChainState.KeyPairs[opId0].SignMessage(bhh), nil)
+	op0Port := mockChainState.GetTotalOperatorState(ctx, uint(blockNumber)).PrivateOperators[opId0].DispersalPort
+	op1Port := mockChainState.GetTotalOperatorState(ctx, uint(blockNumber)).PrivateOperators[opId1].DispersalPort
+	components.NodeClientManager.On("GetClient", mock.Anything, op0Port).Return(mockClient0, nil)
+	components.NodeClientManager.On("GetClient", mock.Anything, op1Port).Return(nil, errors.New("connection refused"))
+
+	sigChan, batchData, err := components.Dispatcher.HandleBatch(ctx)
+	require.NoError(t, err)
+
+	// The unreachable operator is reported as a failed signer, so that the signatures of the batch can be handled
+	// without waiting for it
+	done := make(chan error, 1)
+	go func() {
+		done <- components.Dispatcher.HandleSignatures(ctx, batchData, sigChan)
+	}()
+	select {
+	case err = <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the signatures to be handled")
+	}
+
+	for _, key := range objs.blobKeys {
+		bm, err := components.BlobMetadataStore.GetBlobMetadata(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, v2.Certified, bm.BlobStatus)
+	}
+	att, err := components.BlobMetadataStore.GetAttestation(ctx, batchData.BatchHeaderHash)
+	require.NoError(t, err)
+	require.Len(t, att.NonSignerPubKeys, 1)
+}
+
+func TestDispatcherHandleBatchNoSignatures(t *testing.T) {
+	components := newDispatcherComponents(t)
+	objs := setupBlobCerts(t, components.BlobMetadataStore, 2)
+	ctx := context.Background()
+
+	// No operator can be reached
+	components.NodeClientManager.On("GetClient", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	sigChan, batchData, err := components.Dispatcher.HandleBatch(ctx)
+	require.NoError(t, err)
+	err = components.Dispatcher.HandleSignatures(ctx, batchData, sigChan)
+	require.ErrorContains(t, err, "no stake signed the batch")
+
+	emitted := make(map[string]*events.Event)
+	for _, event := range components.EventSink.Events() {
+		emitted[event.BlobKey] = event
+	}
+	for _, key := range objs.blobKeys {
+		bm, err := components.BlobMetadataStore.GetBlobMetadata(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, v2.InsufficientSignatures, bm.BlobStatus)
+
+		event, ok := emitted[key.Hex()]
+		require.True(t, ok)
+		require.Equal(t, events.BlobFailed, event.Type)
+	}
+
+	// No attestation is written
+	_, err = components.BlobMetadataStore.GetAttestation(ctx, batchData.BatchHeaderHash)
+	require.Error(t, err)
 }
 
 func TestDispatcherNewBatch(t *testing.T) {
@@ -200,7 +299,7 @@ func TestDispatcherBuildMerkleTree(t *testing.T) {
 			RelayKeys: []corev2.RelayKey{0, 1, 2},
 		},
 	}
-	merkleTree, err := controller.BuildMerkleTree(certs)
+	merkleTree, err := corev2.BuildMerkleTree(certs)
 	require.NoError(t, err)
 	require.NotNil(t, merkleTree)
 	require.NotNil(t, merkleTree.Root())
@@ -295,13 +394,14 @@ func newDispatcherComponents(t *testing.T) *dispatcherComponents {
 	agg, err := core.NewStdSignatureAggregator(logger, chainReader)
 	require.NoError(t, err)
 	nodeClientManager := &controller.MockClientManager{}
+	eventSink := eventsmock.NewSink()
 	mockChainState.On("GetCurrentBlockNumber").Return(uint(blockNumber), nil)
 	d, err := controller.NewDispatcher(controller.DispatcherConfig{
 		PullInterval:           1 * time.Second,
 		FinalizationBlockDelay: finalizationBlockDelay,
 		NodeRequestTimeout:     1 * time.Second,
 		NumRequestRetries:      3,
-	}, blobMetadataStore, pool, mockChainState, agg, nodeClientManager, logger)
+	}, blobMetadataStore, pool, mockChainState, agg, nodeClientManager, eventSink, logger)
 	require.NoError(t, err)
 	return &dispatcherComponents{
 		Dispatcher:        d,
@@ -311,5 +411,6 @@ func newDispatcherComponents(t *testing.T) *dispatcherComponents {
 		ChainState:        mockChainState,
 		SigAggregator:     agg,
 		NodeClientManager: nodeClientManager,
+		EventSink:         eventSink,
 	}
 }
@@ -110,6 +110,7 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 	}
 
 	for _, blob := range blobMetadatas {
+		blob := blob
 		blobKey, err := blob.BlobHeader.BlobKey()
 		if err != nil {
 			e.logger.Error("failed to get blob key", "err", err, "requestedAt", blob.RequestedAt, "paymentMetadata", blob.BlobHeader.PaymentMetadata)
@@ -121,7 +122,7 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 		e.pool.Submit(func() {
 			for i := 0; i < e.NumEncodingRetries+1; i++ {
 				encodingCtx, cancel := context.WithTimeout(ctx, e.EncodingRequestTimeout)
-				fragmentInfo, err := e.encodeBlob(encodingCtx, blobKey, blob)
+				fragmentInfo, storageKey, err := e.encodeBlob(encodingCtx, blobKey, blob)
 				cancel()
 				if err != nil {
 					e.logger.Error("failed to encode blob", "blobKey", blobKey.Hex(), "err", err)
@@ -139,7 +140,11 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 				}
 
 				storeCtx, cancel := context.WithTimeout(ctx, e.StoreTimeout)
-				err = e.blobMetadataStore.PutBlobCertificate(storeCtx, cert, fragmentInfo)
+				if storageKey == blobKey {
+					err = e.blobMetadataStore.PutBlobCertificate(storeCtx, cert, fragmentInfo)
+				} else {
+					err = e.blobMetadataStore.PutBlobCertificateWithStorageKey(storeCtx, cert, fragmentInfo, storageKey)
+				}
 				cancel()
 				if err != nil && !errors.Is(err, dispcommon.ErrAlreadyExists) {
 					e.logger.Error("failed to put blob certificate", "err", err)
@@ -171,12 +176,38 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 	return nil
 }
 
-func (e *EncodingManager) encodeBlob(ctx context.Context, blobKey corev2.BlobKey, blob *v2.BlobMetadata) (*encoding.FragmentInfo, error) {
+// encodeBlob encodes a blob and returns the fragment info of its chunks along with the key they are stored under.
+// A blob that shares its content with other blobs reuses the chunks of the content if they have already been encoded.
+func (e *EncodingManager) encodeBlob(ctx context.Context, blobKey corev2.BlobKey, blob *v2.BlobMetadata) (*encoding.FragmentInfo, corev2.BlobKey, error) {
+	storageKey, err := blob.GetStorageKey()
+	if err != nil {
+		return nil, corev2.BlobKey{}, fmt.Errorf("failed to get storage key: %w", err)
+	}
+	if blob.ContentKey != nil {
+		content, err := e.blobMetadataStore.GetBlobContent(ctx, *blob.ContentKey)
+		if err != nil {
+			return nil, corev2.BlobKey{}, fmt.Errorf("failed to get blob content: %w", err)
+		}
+		if content.FragmentInfo != nil {
+			return content.FragmentInfo, storageKey, nil
+		}
+	}
+
 	encodingParams, err := blob.BlobHeader.GetEncodingParams()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get encoding params: %w", err)
+		return nil, corev2.BlobKey{}, fmt.Errorf("failed to get encoding params: %w", err)
+	}
+	fragmentInfo, err := e.encodingClient.EncodeBlob(ctx, storageKey, encodingParams)
+	if err != nil {
+		return nil, corev2.BlobKey{}, err
+	}
+	if blob.ContentKey != nil {
+		// Failing to record the chunks only means that other blobs with the same content encode them again
+		if err := e.blobMetadataStore.PutBlobContentFragmentInfo(ctx, *blob.ContentKey, fragmentInfo); err != nil {
+			e.logger.Warn("failed to record the fragment info of blob content", "blobKey", blobKey.Hex(), "err", err)
+		}
 	}
-	return e.encodingClient.EncodeBlob(ctx, blobKey, encodingParams)
+	return fragmentInfo, storageKey, nil
 }
 
 func GetRelayKeys(numAssignment uint16, availableRelays []corev2.RelayKey) ([]corev2.RelayKey, error) {
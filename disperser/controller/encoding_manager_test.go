@@ -245,6 +245,97 @@ func TestEncodingManagerHandleBatchRetryFailure(t *testing.T) {
 	c.EncodingClient.AssertNumberOfCalls(t, "EncodeBlob", 2)
 }
 
+func TestEncodingManagerHandleBatchSharedContent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	newMetadata := func(binIndex uint32, contentKey commonv2.ContentKey, storageKey corev2.BlobKey) *commonv2.BlobMetadata {
+		return &commonv2.BlobMetadata{
+			BlobHeader: &corev2.BlobHeader{
+				BlobVersion:     0,
+				QuorumNumbers:   []core.QuorumID{0},
+				BlobCommitments: mockCommitment,
+				PaymentMetadata: core.PaymentMetadata{
+					AccountID:         "0x1234567",
+					BinIndex:          binIndex,
+					CumulativePayment: big.NewInt(532),
+				},
+			},
+			BlobStatus: commonv2.Queued,
+			Expiry:     uint64(now.Add(time.Hour).Unix()),
+			UpdatedAt:  uint64(now.UnixNano()),
+			ContentKey: &contentKey,
+			StorageKey: &storageKey,
+		}
+	}
+
+	// the content of blob 1 has already been encoded for another blob
+	encodedContentKey := commonv2.ContentKey{1}
+	encodedStorageKey := corev2.BlobKey{1, 1}
+	storedFragmentInfo := &encoding.FragmentInfo{
+		TotalChunkSizeBytes: 200,
+		FragmentSizeBytes:   1024 * 1024 * 4,
+	}
+	err := blobMetadataStore.PutBlobMetadataWithContent(ctx, newMetadata(0, encodedContentKey, encodedStorageKey), &commonv2.BlobContent{StorageKey: encodedStorageKey})
+	assert.NoError(t, err)
+	err = blobMetadataStore.UpdateBlobStatus(ctx, mustBlobKey(t, newMetadata(0, encodedContentKey, encodedStorageKey)), commonv2.Failed)
+	assert.NoError(t, err)
+	err = blobMetadataStore.PutBlobContentFragmentInfo(ctx, encodedContentKey, storedFragmentInfo)
+	assert.NoError(t, err)
+	content, err := blobMetadataStore.GetBlobContent(ctx, encodedContentKey)
+	assert.NoError(t, err)
+	blob1 := newMetadata(1, encodedContentKey, encodedStorageKey)
+	err = blobMetadataStore.PutBlobMetadataWithContent(ctx, blob1, content)
+	assert.NoError(t, err)
+
+	// the content of blob 2 has not been encoded yet
+	unencodedContentKey := commonv2.ContentKey{2}
+	unencodedStorageKey := corev2.BlobKey{2, 2}
+	blob2 := newMetadata(2, unencodedContentKey, unencodedStorageKey)
+	err = blobMetadataStore.PutBlobMetadataWithContent(ctx, blob2, &commonv2.BlobContent{StorageKey: unencodedStorageKey})
+	assert.NoError(t, err)
+
+	c := newTestComponents(t)
+	c.EncodingClient.On("EncodeBlob", mock.Anything, mock.Anything, mock.Anything).Return(&encoding.FragmentInfo{
+		TotalChunkSizeBytes: 100,
+		FragmentSizeBytes:   1024 * 1024 * 4,
+	}, nil)
+
+	err = c.EncodingManager.HandleBatch(ctx)
+	assert.NoError(t, err)
+	c.Pool.StopWait()
+
+	// blob 1 reuses the chunks that have already been encoded
+	blobKey1 := mustBlobKey(t, blob1)
+	fetchedMetadata, err := blobMetadataStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Equal(t, commonv2.Encoded, fetchedMetadata.BlobStatus)
+	_, fetchedFragmentInfo, fetchedStorageKey, err := blobMetadataStore.GetBlobCertificateWithStorageKey(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Equal(t, storedFragmentInfo, fetchedFragmentInfo)
+	assert.Equal(t, encodedStorageKey, fetchedStorageKey)
+
+	// blob 2 is encoded under the key its content is stored under, and its chunks are recorded for reuse
+	blobKey2 := mustBlobKey(t, blob2)
+	fetchedMetadata, err = blobMetadataStore.GetBlobMetadata(ctx, blobKey2)
+	assert.NoError(t, err)
+	assert.Equal(t, commonv2.Encoded, fetchedMetadata.BlobStatus)
+	_, fetchedFragmentInfo, fetchedStorageKey, err = blobMetadataStore.GetBlobCertificateWithStorageKey(ctx, blobKey2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(100), fetchedFragmentInfo.TotalChunkSizeBytes)
+	assert.Equal(t, unencodedStorageKey, fetchedStorageKey)
+	content, err = blobMetadataStore.GetBlobContent(ctx, unencodedContentKey)
+	assert.NoError(t, err)
+	assert.Equal(t, fetchedFragmentInfo, content.FragmentInfo)
+
+	c.EncodingClient.AssertNumberOfCalls(t, "EncodeBlob", 1)
+}
+
+func mustBlobKey(t *testing.T, metadata *commonv2.BlobMetadata) corev2.BlobKey {
+	blobKey, err := metadata.BlobHeader.BlobKey()
+	assert.NoError(t, err)
+	return blobKey
+}
+
 func newTestComponents(t *testing.T) *testComponents {
 	logger := logging.NewNoopLogger()
 	// logger, err := common.NewLogger(common.DefaultLoggerConfig())
@@ -0,0 +1,152 @@
+package chainindexer
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// IndexedBlock is the accumulator object. The records themselves live in the Store; the object only tracks the
+// block whose records were written last.
+type IndexedBlock struct {
+	Number uint64
+	Hash   [32]byte
+}
+
+// Accumulator writes the records emitted by the Filterer to the Store.
+type Accumulator struct {
+	Store  *Store
+	Logger logging.Logger
+}
+
+var _ indexer.Accumulator = (*Accumulator)(nil)
+
+func NewAccumulator(store *Store, logger logging.Logger) *Accumulator {
+	return &Accumulator{
+		Store:  store,
+		Logger: logger,
+	}
+}
+
+func (a *Accumulator) InitializeObject(header indexer.Header) (indexer.AccumulatorObject, error) {
+	return IndexedBlock{}, nil
+}
+
+func (a *Accumulator) UpdateObject(object indexer.AccumulatorObject, header *indexer.Header, event indexer.Event) (indexer.AccumulatorObject, error) {
+	block, ok := object.(IndexedBlock)
+	if !ok {
+		return object, ErrIncorrectObject
+	}
+
+	// The records of a block are replaced as a whole when its first event is seen, so that indexing a block
+	// again after a restart or a reorg doesn't leave stale records behind.
+	if block.Number != header.Number || block.Hash != header.BlockHash {
+		if err := a.Store.DeleteBlock(header.Number); err != nil {
+			return object, err
+		}
+		block = IndexedBlock{Number: header.Number, Hash: header.BlockHash}
+	}
+
+	var err error
+	switch event.Type {
+	case BatchConfirmed:
+		record, ok := event.Payload.(*BatchRecord)
+		if !ok {
+			return object, ErrIncorrectEvent
+		}
+		err = a.Store.PutBatch(record)
+	case OperatorRegistered:
+		record, ok := event.Payload.(*OperatorRegistrationRecord)
+		if !ok {
+			return object, ErrIncorrectEvent
+		}
+		err = a.Store.PutOperatorRegistered(record)
+	case OperatorDeregistered:
+		record, ok := event.Payload.(*OperatorRegistrationRecord)
+		if !ok {
+			return object, ErrIncorrectEvent
+		}
+		err = a.Store.PutOperatorDeregistered(record)
+	case OperatorAddedToQuorums:
+		record, ok := event.Payload.(*OperatorQuorumRecord)
+		if !ok {
+			return object, ErrIncorrectEvent
+		}
+		err = a.Store.PutOperatorAddedToQuorums(record)
+	case OperatorRemovedFromQuorums:
+		record, ok := event.Payload.(*OperatorQuorumRecord)
+		if !ok {
+			return object, ErrIncorrectEvent
+		}
+		err = a.Store.PutOperatorRemovedFromQuorums(record)
+	case OperatorEjected:
+		record, ok := event.Payload.(*OperatorEjectionRecord)
+		if !ok {
+			return object, ErrIncorrectEvent
+		}
+		err = a.Store.PutOperatorEjected(record)
+	case NewPubkeyRegistration:
+		record, ok := event.Payload.(*PubkeyRegistrationRecord)
+		if !ok {
+			return object, ErrIncorrectEvent
+		}
+		err = a.Store.PutPubkeyRegistration(record)
+	case OperatorSocketUpdate:
+		record, ok := event.Payload.(*SocketUpdateRecord)
+		if !ok {
+			return object, ErrIncorrectEvent
+		}
+		err = a.Store.PutSocketUpdate(record)
+	default:
+		return object, ErrIncorrectEvent
+	}
+	if err != nil {
+		return object, err
+	}
+
+	return block, nil
+}
+
+func (a *Accumulator) SerializeObject(object indexer.AccumulatorObject, fork indexer.UpgradeFork) ([]byte, error) {
+	switch fork {
+	case "genesis":
+		obj, ok := object.(IndexedBlock)
+		if !ok {
+			return nil, ErrIncorrectObject
+		}
+
+		var (
+			buff bytes.Buffer
+			enc  = gob.NewEncoder(&buff)
+		)
+
+		if err := enc.Encode(obj); err != nil {
+			return nil, err
+		}
+
+		return buff.Bytes(), nil
+	default:
+		return nil, ErrUnrecognizedFork
+	}
+}
+
+func (a *Accumulator) DeserializeObject(data []byte, fork indexer.UpgradeFork) (indexer.AccumulatorObject, error) {
+	switch fork {
+	case "genesis":
+		var (
+			obj IndexedBlock
+			buf = bytes.NewBuffer(data)
+			dec = gob.NewDecoder(buf)
+		)
+
+		if err := dec.Decode(&obj); err != nil {
+			return nil, err
+		}
+
+		return obj, nil
+	default:
+		return nil, ErrUnrecognizedFork
+	}
+}
@@ -0,0 +1,326 @@
+package chainindexer
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/shurcooL/graphql"
+)
+
+var (
+	// batchIdPrefix and gasFeesIdPrefix are the entity id prefixes used by the batch metadata subgraph.
+	batchIdPrefix   = []byte{0x00, 0x07}
+	gasFeesIdPrefix = []byte{0x00, 0x06}
+)
+
+// Api serves the subgraph queries of the dataapi from the records in a Store, formatting the results the same
+// way the EigenDA subgraphs do. It can be passed to dataapi.NewSubgraphClient in place of subgraph.NewApi.
+type Api struct {
+	store *Store
+}
+
+var _ subgraph.Api = (*Api)(nil)
+
+func NewApi(store *Store) *Api {
+	return &Api{store: store}
+}
+
+func (a *Api) QueryBatches(ctx context.Context, descending bool, orderByField string, first, skip int) ([]*subgraph.Batches, error) {
+	if orderByField != "blockTimestamp" && orderByField != "blockNumber" {
+		return nil, fmt.Errorf("unsupported orderBy field: %s", orderByField)
+	}
+	limit := 0
+	if first > 0 {
+		limit = first + skip
+	}
+	records, err := a.store.GetBatches(0, math.MaxUint64, descending, limit)
+	if err != nil {
+		return nil, err
+	}
+	return convertBatches(page(records, first, skip)), nil
+}
+
+func (a *Api) QueryBatchesByBlockTimestampRange(ctx context.Context, start, end uint64) ([]*subgraph.Batches, error) {
+	if start > end {
+		return []*subgraph.Batches{}, nil
+	}
+	records, err := a.store.GetBatches(start, end, false, 0)
+	if err != nil {
+		return nil, err
+	}
+	return convertBatches(records), nil
+}
+
+func (a *Api) QueryOperators(ctx context.Context, first int) ([]*subgraph.Operator, error) {
+	records, err := a.store.GetOperatorsRegistered(0, math.MaxUint64, max(first, 0))
+	if err != nil {
+		return nil, err
+	}
+	return convertOperators(records), nil
+}
+
+func (a *Api) QueryBatchNonSigningOperatorIdsInInterval(ctx context.Context, intervalSeconds int64) ([]*subgraph.BatchNonSigningOperatorIds, error) {
+	nonSigningAfter := time.Now().Add(-time.Duration(intervalSeconds) * time.Second).Unix()
+	records, err := a.getBatchesInOpenInterval(nonSigningAfter, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*subgraph.BatchNonSigningOperatorIds, len(records))
+	for i, record := range records {
+		result[i] = &subgraph.BatchNonSigningOperatorIds{}
+		for _, operatorId := range record.NonSigners {
+			result[i].NonSigning.NonSigners = append(result[i].NonSigning.NonSigners, struct {
+				OperatorId graphql.String `graphql:"operatorId"`
+			}{OperatorId: graphql.String(hexutil.Encode(operatorId[:]))})
+		}
+	}
+	return result, nil
+}
+
+func (a *Api) QueryBatchNonSigningInfo(ctx context.Context, startTime, endTime int64) ([]*subgraph.BatchNonSigningInfo, error) {
+	records, err := a.getBatchesInOpenInterval(startTime, endTime)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*subgraph.BatchNonSigningInfo, len(records))
+	for i, record := range records {
+		info := &subgraph.BatchNonSigningInfo{
+			BatchId:         graphql.String(strconv.FormatUint(uint64(record.BatchId), 10)),
+			BatchHeaderHash: graphql.String(hexutil.Encode(record.BatchHeaderHash[:])),
+			BlockNumber:     graphql.String(strconv.FormatUint(record.BlockNumber, 10)),
+		}
+		info.BatchHeader.QuorumNumbers = make([]graphql.String, len(record.QuorumNumbers))
+		for j, quorum := range record.QuorumNumbers {
+			info.BatchHeader.QuorumNumbers[j] = graphql.String(strconv.FormatUint(uint64(quorum), 10))
+		}
+		info.BatchHeader.ReferenceBlockNumber = graphql.String(strconv.FormatUint(uint64(record.ReferenceBlockNumber), 10))
+		for _, operatorId := range record.NonSigners {
+			info.NonSigning.NonSigners = append(info.NonSigning.NonSigners, struct {
+				OperatorId graphql.String `graphql:"operatorId"`
+			}{OperatorId: graphql.String(hexutil.Encode(operatorId[:]))})
+		}
+		result[i] = info
+	}
+	return result, nil
+}
+
+func (a *Api) QueryDeregisteredOperatorsGreaterThanBlockTimestamp(ctx context.Context, blockTimestamp uint64) ([]*subgraph.Operator, error) {
+	if blockTimestamp == math.MaxUint64 {
+		return []*subgraph.Operator{}, nil
+	}
+	records, err := a.store.GetOperatorsDeregistered(blockTimestamp+1, math.MaxUint64, 0)
+	if err != nil {
+		return nil, err
+	}
+	return convertOperators(records), nil
+}
+
+func (a *Api) QueryRegisteredOperatorsGreaterThanBlockTimestamp(ctx context.Context, blockTimestamp uint64) ([]*subgraph.Operator, error) {
+	if blockTimestamp == math.MaxUint64 {
+		return []*subgraph.Operator{}, nil
+	}
+	records, err := a.store.GetOperatorsRegistered(blockTimestamp+1, math.MaxUint64, 0)
+	if err != nil {
+		return nil, err
+	}
+	return convertOperators(records), nil
+}
+
+// QueryOperatorInfoByOperatorIdAtBlockNumber returns the pubkeys and latest socket of the operator. Like the
+// subgraph query, the block number is not taken into account.
+func (a *Api) QueryOperatorInfoByOperatorIdAtBlockNumber(ctx context.Context, operatorId string, blockNumber uint32) (*subgraph.IndexedOperatorInfo, error) {
+	id, err := core.OperatorIDFromHex(operatorId)
+	if err != nil {
+		return nil, err
+	}
+	pubkeys, err := a.store.GetPubkeyRegistration(id)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return nil, fmt.Errorf("operator %s not found", id.Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	info := &subgraph.IndexedOperatorInfo{
+		Id:         graphql.String(hexutil.Encode(id[:])),
+		PubkeyG1_X: graphql.String(pubkeys.PubkeyG1_X.String()),
+		PubkeyG1_Y: graphql.String(pubkeys.PubkeyG1_Y.String()),
+		PubkeyG2_X: []graphql.String{graphql.String(pubkeys.PubkeyG2_X[0].String()), graphql.String(pubkeys.PubkeyG2_X[1].String())},
+		PubkeyG2_Y: []graphql.String{graphql.String(pubkeys.PubkeyG2_Y[0].String()), graphql.String(pubkeys.PubkeyG2_Y[1].String())},
+	}
+
+	socket, err := a.store.GetLatestSocketUpdate(id)
+	if err != nil && !errors.Is(err, kvstore.ErrNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		info.SocketUpdates = []subgraph.SocketUpdates{{Socket: graphql.String(socket.Socket)}}
+	}
+	return info, nil
+}
+
+// QueryOperatorAddedToQuorum finds operators' quorum opt-in history in range [startBlock, endBlock].
+func (a *Api) QueryOperatorAddedToQuorum(ctx context.Context, startBlock, endBlock uint32) ([]*subgraph.OperatorQuorum, error) {
+	if startBlock > endBlock {
+		return nil, fmt.Errorf("endBlock must be no less than startBlock, startBlock: %d, endBlock: %d", startBlock, endBlock)
+	}
+	records, err := a.store.GetOperatorsAddedToQuorums(uint64(startBlock), uint64(endBlock))
+	if err != nil {
+		return nil, err
+	}
+	return convertOperatorQuorums(records), nil
+}
+
+// QueryOperatorRemovedFromQuorum finds operators' quorum opt-out history in range [startBlock, endBlock].
+func (a *Api) QueryOperatorRemovedFromQuorum(ctx context.Context, startBlock, endBlock uint32) ([]*subgraph.OperatorQuorum, error) {
+	if startBlock > endBlock {
+		return nil, fmt.Errorf("endBlock must be no less than startBlock, startBlock: %d, endBlock: %d", startBlock, endBlock)
+	}
+	records, err := a.store.GetOperatorsRemovedFromQuorums(uint64(startBlock), uint64(endBlock))
+	if err != nil {
+		return nil, err
+	}
+	return convertOperatorQuorums(records), nil
+}
+
+func (a *Api) QueryOperatorEjectionsGteBlockTimestampByOperatorId(ctx context.Context, blockTimestamp uint64, operatorId string, first uint, skip uint) ([]*subgraph.OperatorEjection, error) {
+	id, err := core.OperatorIDFromHex(operatorId)
+	if err != nil {
+		return nil, err
+	}
+	records, err := a.store.GetOperatorEjections(blockTimestamp, math.MaxUint64)
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]*OperatorEjectionRecord, 0)
+	for _, record := range records {
+		if record.OperatorId == id {
+			filtered = append(filtered, record)
+		}
+	}
+	return convertOperatorEjections(page(filtered, int(first), int(skip))), nil
+}
+
+func (a *Api) QueryOperatorEjectionsGteBlockTimestamp(ctx context.Context, blockTimestamp uint64, first uint, skip uint) ([]*subgraph.OperatorEjection, error) {
+	records, err := a.store.GetOperatorEjections(blockTimestamp, math.MaxUint64)
+	if err != nil {
+		return nil, err
+	}
+	return convertOperatorEjections(page(records, int(first), int(skip))), nil
+}
+
+// getBatchesInOpenInterval returns the batches with block timestamps in (start, end).
+func (a *Api) getBatchesInOpenInterval(start, end int64) ([]*BatchRecord, error) {
+	if start < -1 {
+		start = -1
+	}
+	if end <= start+1 {
+		return []*BatchRecord{}, nil
+	}
+	return a.store.GetBatches(uint64(start+1), uint64(end-1), false, 0)
+}
+
+// page applies the skip and first arguments of a subgraph query. A non-positive first means no limit.
+func page[T any](records []*T, first, skip int) []*T {
+	if skip >= len(records) {
+		return []*T{}
+	}
+	if skip > 0 {
+		records = records[skip:]
+	}
+	if first > 0 && len(records) > first {
+		records = records[:first]
+	}
+	return records
+}
+
+// entityId returns the id the subgraphs give to event entities: the transaction hash followed by the log index
+// as a little-endian int32.
+func entityId(meta *EventMeta) string {
+	id := make([]byte, 0, len(meta.TxHash)+4)
+	id = append(id, meta.TxHash[:]...)
+	id = binary.LittleEndian.AppendUint32(id, uint32(meta.LogIndex))
+	return hexutil.Encode(id)
+}
+
+func convertBatches(records []*BatchRecord) []*subgraph.Batches {
+	batches := make([]*subgraph.Batches, len(records))
+	for i, record := range records {
+		gasPrice := record.GasPrice
+		if gasPrice == nil {
+			gasPrice = new(big.Int)
+		}
+		txFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(record.GasUsed))
+		batches[i] = &subgraph.Batches{
+			Id:              graphql.String(hexutil.Encode(append(bytes.Clone(batchIdPrefix), record.TxHash[:]...))),
+			BatchId:         graphql.String(strconv.FormatUint(uint64(record.BatchId), 10)),
+			BatchHeaderHash: graphql.String(hexutil.Encode(record.BatchHeaderHash[:])),
+			BlockTimestamp:  graphql.String(strconv.FormatUint(record.BlockTimestamp, 10)),
+			BlockNumber:     graphql.String(strconv.FormatUint(record.BlockNumber, 10)),
+			TxHash:          graphql.String(hexutil.Encode(record.TxHash[:])),
+			GasFees: subgraph.GasFees{
+				Id:       graphql.String(hexutil.Encode(append(bytes.Clone(gasFeesIdPrefix), record.TxHash[:]...))),
+				GasUsed:  graphql.String(strconv.FormatUint(record.GasUsed, 10)),
+				GasPrice: graphql.String(gasPrice.String()),
+				TxFee:    graphql.String(txFee.String()),
+			},
+		}
+	}
+	return batches
+}
+
+func convertOperators(records []*OperatorRegistrationRecord) []*subgraph.Operator {
+	operators := make([]*subgraph.Operator, len(records))
+	for i, record := range records {
+		operators[i] = &subgraph.Operator{
+			Id:              graphql.String(entityId(&record.EventMeta)),
+			OperatorId:      graphql.String(hexutil.Encode(record.OperatorId[:])),
+			Operator:        graphql.String(hexutil.Encode(record.Operator[:])),
+			BlockTimestamp:  graphql.String(strconv.FormatUint(record.BlockTimestamp, 10)),
+			BlockNumber:     graphql.String(strconv.FormatUint(record.BlockNumber, 10)),
+			TransactionHash: graphql.String(hexutil.Encode(record.TxHash[:])),
+		}
+	}
+	return operators
+}
+
+func convertOperatorQuorums(records []*OperatorQuorumRecord) []*subgraph.OperatorQuorum {
+	quorums := make([]*subgraph.OperatorQuorum, len(records))
+	for i, record := range records {
+		quorums[i] = &subgraph.OperatorQuorum{
+			Id:             graphql.String(entityId(&record.EventMeta)),
+			Operator:       graphql.String(hexutil.Encode(record.Operator[:])),
+			QuorumNumbers:  graphql.String(hexutil.Encode(record.QuorumNumbers)),
+			BlockNumber:    graphql.String(strconv.FormatUint(record.BlockNumber, 10)),
+			BlockTimestamp: graphql.String(strconv.FormatUint(record.BlockTimestamp, 10)),
+		}
+	}
+	return quorums
+}
+
+func convertOperatorEjections(records []*OperatorEjectionRecord) []*subgraph.OperatorEjection {
+	ejections := make([]*subgraph.OperatorEjection, len(records))
+	for i, record := range records {
+		ejections[i] = &subgraph.OperatorEjection{
+			OperatorId:      graphql.String(hexutil.Encode(record.OperatorId[:])),
+			QuorumNumber:    graphql.Int(record.QuorumNumber),
+			BlockNumber:     graphql.String(strconv.FormatUint(record.BlockNumber, 10)),
+			BlockTimestamp:  graphql.String(strconv.FormatUint(record.BlockTimestamp, 10)),
+			TransactionHash: graphql.String(hexutil.Encode(record.TxHash[:])),
+		}
+	}
+	return ejections
+}
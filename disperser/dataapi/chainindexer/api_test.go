@@ -0,0 +1,559 @@
+package chainindexer_test
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/chainindexer"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	subgraphmock "github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph/mock"
+	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/shurcooL/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	logger = logging.NewNoopLogger()
+
+	now = uint64(time.Now().Unix())
+
+	txHash1 = gethcommon.HexToHash(strings.Repeat("11", 32))
+	txHash2 = gethcommon.HexToHash(strings.Repeat("22", 32))
+	txHash3 = gethcommon.HexToHash(strings.Repeat("33", 32))
+
+	batchHeaderHash1 = [32]byte{0xa1}
+	batchHeaderHash2 = [32]byte{0xa2}
+
+	operator1 = gethcommon.HexToAddress(strings.Repeat("aa", 20))
+	operator2 = gethcommon.HexToAddress(strings.Repeat("bb", 20))
+
+	operatorId2 = core.OperatorID{0x02}
+)
+
+// pubkeys returns the G1 and G2 generators as an operator's pubkeys, with the G2 coordinates in the order used by
+// the contracts, and the operator id of the pubkey.
+func pubkeys() (*chainindexer.PubkeyRegistrationRecord, core.OperatorID) {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	record := &chainindexer.PubkeyRegistrationRecord{
+		PubkeyG1_X: g1Gen.X.BigInt(new(big.Int)),
+		PubkeyG1_Y: g1Gen.Y.BigInt(new(big.Int)),
+		PubkeyG2_X: [2]*big.Int{g2Gen.X.A1.BigInt(new(big.Int)), g2Gen.X.A0.BigInt(new(big.Int))},
+		PubkeyG2_Y: [2]*big.Int{g2Gen.Y.A1.BigInt(new(big.Int)), g2Gen.Y.A0.BigInt(new(big.Int))},
+	}
+	return record, (&core.G1Point{G1Affine: &g1Gen}).GetOperatorID()
+}
+
+type indexedEvent struct {
+	blockHash [32]byte
+	meta      chainindexer.EventMeta
+	event     indexer.Event
+}
+
+// newIndexedApi feeds the events through the accumulator, as the indexer does, and returns an Api over the
+// resulting store.
+func newIndexedApi(t *testing.T, events []indexedEvent) (*chainindexer.Api, *chainindexer.Store) {
+	store := chainindexer.NewStore(mapstore.NewStore())
+	acc := chainindexer.NewAccumulator(store, logger)
+
+	object, err := acc.InitializeObject(indexer.Header{})
+	require.NoError(t, err)
+	for _, e := range events {
+		header := &indexer.Header{BlockHash: e.blockHash, Number: e.meta.BlockNumber}
+		object, err = acc.UpdateObject(object, header, e.event)
+		require.NoError(t, err)
+	}
+	return chainindexer.NewApi(store), store
+}
+
+func meta(blockNumber uint64, timestamp uint64, txHash gethcommon.Hash, logIndex uint) chainindexer.EventMeta {
+	return chainindexer.EventMeta{
+		BlockNumber:    blockNumber,
+		BlockTimestamp: timestamp,
+		TxHash:         txHash,
+		LogIndex:       logIndex,
+	}
+}
+
+func fixtureEvents() []indexedEvent {
+	pubkeyRecord, operatorId1 := pubkeys()
+	pubkeyRecord.EventMeta = meta(90, now-200, txHash1, 0)
+	pubkeyRecord.Operator = operator1
+	pubkeyRecord.OperatorId = operatorId1
+
+	return []indexedEvent{
+		{
+			blockHash: [32]byte{90},
+			meta:      pubkeyRecord.EventMeta,
+			event:     indexer.Event{Type: chainindexer.NewPubkeyRegistration, Payload: pubkeyRecord},
+		},
+		{
+			blockHash: [32]byte{90},
+			meta:      meta(90, now-200, txHash1, 1),
+			event: indexer.Event{Type: chainindexer.OperatorSocketUpdate, Payload: &chainindexer.SocketUpdateRecord{
+				EventMeta:  meta(90, now-200, txHash1, 1),
+				OperatorId: operatorId1,
+				Socket:     "localhost:32005;32006",
+			}},
+		},
+		{
+			blockHash: [32]byte{90},
+			meta:      meta(90, now-200, txHash1, 2),
+			event: indexer.Event{Type: chainindexer.OperatorRegistered, Payload: &chainindexer.OperatorRegistrationRecord{
+				EventMeta:  meta(90, now-200, txHash1, 2),
+				Operator:   operator1,
+				OperatorId: operatorId1,
+			}},
+		},
+		{
+			blockHash: [32]byte{100},
+			meta:      meta(100, now-100, txHash2, 3),
+			event: indexer.Event{Type: chainindexer.BatchConfirmed, Payload: &chainindexer.BatchRecord{
+				EventMeta:            meta(100, now-100, txHash2, 3),
+				BatchId:              1,
+				BatchHeaderHash:      batchHeaderHash1,
+				QuorumNumbers:        []byte{0, 1},
+				ReferenceBlockNumber: 95,
+				NonSigners:           []core.OperatorID{operatorId1, operatorId2},
+				GasUsed:              21000,
+				GasPrice:             big.NewInt(1_000_000_000),
+			}},
+		},
+		{
+			blockHash: [32]byte{100},
+			meta:      meta(100, now-100, txHash2, 4),
+			event: indexer.Event{Type: chainindexer.OperatorAddedToQuorums, Payload: &chainindexer.OperatorQuorumRecord{
+				EventMeta:     meta(100, now-100, txHash2, 4),
+				Operator:      operator1,
+				OperatorId:    operatorId1,
+				QuorumNumbers: []byte{0, 1},
+			}},
+		},
+		{
+			blockHash: [32]byte{105},
+			meta:      meta(105, now-60, txHash3, 0),
+			event: indexer.Event{Type: chainindexer.OperatorRemovedFromQuorums, Payload: &chainindexer.OperatorQuorumRecord{
+				EventMeta:     meta(105, now-60, txHash3, 0),
+				Operator:      operator2,
+				OperatorId:    operatorId2,
+				QuorumNumbers: []byte{1},
+			}},
+		},
+		{
+			blockHash: [32]byte{105},
+			meta:      meta(105, now-60, txHash3, 1),
+			event: indexer.Event{Type: chainindexer.OperatorEjected, Payload: &chainindexer.OperatorEjectionRecord{
+				EventMeta:    meta(105, now-60, txHash3, 1),
+				OperatorId:   operatorId1,
+				QuorumNumber: 0,
+			}},
+		},
+		{
+			blockHash: [32]byte{105},
+			meta:      meta(105, now-60, txHash3, 2),
+			event: indexer.Event{Type: chainindexer.OperatorEjected, Payload: &chainindexer.OperatorEjectionRecord{
+				EventMeta:    meta(105, now-60, txHash3, 2),
+				OperatorId:   operatorId2,
+				QuorumNumber: 1,
+			}},
+		},
+		{
+			blockHash: [32]byte{105},
+			meta:      meta(105, now-60, txHash3, 3),
+			event: indexer.Event{Type: chainindexer.OperatorDeregistered, Payload: &chainindexer.OperatorRegistrationRecord{
+				EventMeta:  meta(105, now-60, txHash3, 3),
+				Operator:   operator1,
+				OperatorId: operatorId1,
+			}},
+		},
+		{
+			blockHash: [32]byte{110},
+			meta:      meta(110, now-50, txHash3, 5),
+			event: indexer.Event{Type: chainindexer.OperatorSocketUpdate, Payload: &chainindexer.SocketUpdateRecord{
+				EventMeta:  meta(110, now-50, txHash3, 5),
+				OperatorId: operatorId1,
+				Socket:     "localhost:32007;32008",
+			}},
+		},
+		{
+			blockHash: [32]byte{110},
+			meta:      meta(110, now-50, txHash3, 6),
+			event: indexer.Event{Type: chainindexer.BatchConfirmed, Payload: &chainindexer.BatchRecord{
+				EventMeta:            meta(110, now-50, txHash3, 6),
+				BatchId:              2,
+				BatchHeaderHash:      batchHeaderHash2,
+				QuorumNumbers:        []byte{0},
+				ReferenceBlockNumber: 105,
+				GasUsed:              30000,
+				GasPrice:             big.NewInt(2_000_000_000),
+			}},
+		},
+	}
+}
+
+// The subgraph fixtures below are what the EigenDA subgraphs return for the events in fixtureEvents.
+
+var (
+	nonSigner1 = struct {
+		OperatorId graphql.String `graphql:"operatorId"`
+	}{OperatorId: "0xe90b7bceb6e7df5418fb78d8ee546e97c83a08bbccc01a0644d599ccd2a7c2e0"}
+	nonSigner2 = struct {
+		OperatorId graphql.String `graphql:"operatorId"`
+	}{OperatorId: "0x0200000000000000000000000000000000000000000000000000000000000000"}
+)
+
+func subgraphBatches() []*subgraph.Batches {
+	return []*subgraph.Batches{
+		{
+			Id:              "0x00072222222222222222222222222222222222222222222222222222222222222222",
+			BatchId:         "1",
+			BatchHeaderHash: "0xa100000000000000000000000000000000000000000000000000000000000000",
+			BlockTimestamp:  graphql.String(fmt.Sprint(now - 100)),
+			BlockNumber:     "100",
+			TxHash:          "0x2222222222222222222222222222222222222222222222222222222222222222",
+			GasFees: subgraph.GasFees{
+				Id:       "0x00062222222222222222222222222222222222222222222222222222222222222222",
+				GasUsed:  "21000",
+				GasPrice: "1000000000",
+				TxFee:    "21000000000000",
+			},
+		},
+		{
+			Id:              "0x00073333333333333333333333333333333333333333333333333333333333333333",
+			BatchId:         "2",
+			BatchHeaderHash: "0xa200000000000000000000000000000000000000000000000000000000000000",
+			BlockTimestamp:  graphql.String(fmt.Sprint(now - 50)),
+			BlockNumber:     "110",
+			TxHash:          "0x3333333333333333333333333333333333333333333333333333333333333333",
+			GasFees: subgraph.GasFees{
+				Id:       "0x00063333333333333333333333333333333333333333333333333333333333333333",
+				GasUsed:  "30000",
+				GasPrice: "2000000000",
+				TxFee:    "60000000000000",
+			},
+		},
+	}
+}
+
+func subgraphNonSigningInfo() []*subgraph.BatchNonSigningInfo {
+	batch1 := &subgraph.BatchNonSigningInfo{
+		BatchId:         "1",
+		BatchHeaderHash: "0xa100000000000000000000000000000000000000000000000000000000000000",
+		BlockNumber:     "100",
+	}
+	batch1.BatchHeader.QuorumNumbers = []graphql.String{"0", "1"}
+	batch1.BatchHeader.ReferenceBlockNumber = "95"
+	batch1.NonSigning.NonSigners = append(batch1.NonSigning.NonSigners, nonSigner1, nonSigner2)
+
+	batch2 := &subgraph.BatchNonSigningInfo{
+		BatchId:         "2",
+		BatchHeaderHash: "0xa200000000000000000000000000000000000000000000000000000000000000",
+		BlockNumber:     "110",
+	}
+	batch2.BatchHeader.QuorumNumbers = []graphql.String{"0"}
+	batch2.BatchHeader.ReferenceBlockNumber = "105"
+
+	return []*subgraph.BatchNonSigningInfo{batch1, batch2}
+}
+
+func subgraphOperatorRegistered() []*subgraph.Operator {
+	return []*subgraph.Operator{
+		{
+			Id:              "0x111111111111111111111111111111111111111111111111111111111111111102000000",
+			OperatorId:      "0xe90b7bceb6e7df5418fb78d8ee546e97c83a08bbccc01a0644d599ccd2a7c2e0",
+			Operator:        "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			BlockTimestamp:  graphql.String(fmt.Sprint(now - 200)),
+			BlockNumber:     "90",
+			TransactionHash: "0x1111111111111111111111111111111111111111111111111111111111111111",
+		},
+	}
+}
+
+func subgraphOperatorDeregistered() []*subgraph.Operator {
+	return []*subgraph.Operator{
+		{
+			Id:              "0x333333333333333333333333333333333333333333333333333333333333333303000000",
+			OperatorId:      "0xe90b7bceb6e7df5418fb78d8ee546e97c83a08bbccc01a0644d599ccd2a7c2e0",
+			Operator:        "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			BlockTimestamp:  graphql.String(fmt.Sprint(now - 60)),
+			BlockNumber:     "105",
+			TransactionHash: "0x3333333333333333333333333333333333333333333333333333333333333333",
+		},
+	}
+}
+
+func subgraphOperatorInfo() *subgraph.IndexedOperatorInfo {
+	return &subgraph.IndexedOperatorInfo{
+		Id:         "0xe90b7bceb6e7df5418fb78d8ee546e97c83a08bbccc01a0644d599ccd2a7c2e0",
+		PubkeyG1_X: "1",
+		PubkeyG1_Y: "2",
+		PubkeyG2_X: []graphql.String{
+			"11559732032986387107991004021392285783925812861821192530917403151452391805634",
+			"10857046999023057135944570762232829481370756359578518086990519993285655852781",
+		},
+		PubkeyG2_Y: []graphql.String{
+			"4082367875863433681332203403145435568316851327593401208105741076214120093531",
+			"8495653923123431417604973247489272438418190587263600148770280649306958101930",
+		},
+		SocketUpdates: []subgraph.SocketUpdates{{Socket: "localhost:32007;32008"}},
+	}
+}
+
+func subgraphOperatorAddedToQuorum() []*subgraph.OperatorQuorum {
+	return []*subgraph.OperatorQuorum{
+		{
+			Id:             "0x222222222222222222222222222222222222222222222222222222222222222204000000",
+			Operator:       "0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+			QuorumNumbers:  "0x0001",
+			BlockNumber:    "100",
+			BlockTimestamp: graphql.String(fmt.Sprint(now - 100)),
+		},
+	}
+}
+
+func subgraphOperatorRemovedFromQuorum() []*subgraph.OperatorQuorum {
+	return []*subgraph.OperatorQuorum{
+		{
+			Id:             "0x333333333333333333333333333333333333333333333333333333333333333300000000",
+			Operator:       "0xbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+			QuorumNumbers:  "0x01",
+			BlockNumber:    "105",
+			BlockTimestamp: graphql.String(fmt.Sprint(now - 60)),
+		},
+	}
+}
+
+func subgraphOperatorEjections() []*subgraph.OperatorEjection {
+	return []*subgraph.OperatorEjection{
+		{
+			OperatorId:      "0xe90b7bceb6e7df5418fb78d8ee546e97c83a08bbccc01a0644d599ccd2a7c2e0",
+			QuorumNumber:    0,
+			BlockNumber:     "105",
+			BlockTimestamp:  graphql.String(fmt.Sprint(now - 60)),
+			TransactionHash: "0x3333333333333333333333333333333333333333333333333333333333333333",
+		},
+		{
+			OperatorId:      "0x0200000000000000000000000000000000000000000000000000000000000000",
+			QuorumNumber:    1,
+			BlockNumber:     "105",
+			BlockTimestamp:  graphql.String(fmt.Sprint(now - 60)),
+			TransactionHash: "0x3333333333333333333333333333333333333333333333333333333333333333",
+		},
+	}
+}
+
+// newClients returns a subgraph client backed by the chain indexer and one backed by the subgraph fixtures.
+func newClients(t *testing.T) (dataapi.SubgraphClient, dataapi.SubgraphClient, *subgraphmock.MockSubgraphApi) {
+	api, _ := newIndexedApi(t, fixtureEvents())
+	mockApi := &subgraphmock.MockSubgraphApi{}
+	return dataapi.NewSubgraphClient(api, logger), dataapi.NewSubgraphClient(mockApi, logger), mockApi
+}
+
+func TestParityQueryBatchesWithLimit(t *testing.T) {
+	indexed, graph, mockApi := newClients(t)
+	mockApi.On("QueryBatches").Return(subgraphBatches(), nil)
+
+	for _, tc := range []struct{ limit, skip int }{{1, 0}, {2, 0}, {10, 1}} {
+		expected, err := graph.QueryBatchesWithLimit(context.Background(), tc.limit, tc.skip)
+		require.NoError(t, err)
+		actual, err := indexed.QueryBatchesWithLimit(context.Background(), tc.limit, tc.skip)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual, "limit %d skip %d", tc.limit, tc.skip)
+	}
+}
+
+func TestParityQueryBatchNonSigningInfoInInterval(t *testing.T) {
+	indexed, graph, mockApi := newClients(t)
+	start, end := int64(now-150), int64(now)
+	mockApi.On("QueryBatchNonSigningInfo", start, end).Return(subgraphNonSigningInfo(), nil)
+
+	expected, err := graph.QueryBatchNonSigningInfoInInterval(context.Background(), start, end)
+	require.NoError(t, err)
+	actual, err := indexed.QueryBatchNonSigningInfoInInterval(context.Background(), start, end)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// The interval is open on both ends.
+	actual, err = indexed.QueryBatchNonSigningInfoInInterval(context.Background(), int64(now-100), int64(now-50))
+	require.NoError(t, err)
+	assert.Empty(t, actual)
+}
+
+func TestParityQueryBatchNonSigningOperatorIdsInInterval(t *testing.T) {
+	indexed, graph, mockApi := newClients(t)
+	nonSigningOperatorIds := make([]*subgraph.BatchNonSigningOperatorIds, 0)
+	for _, info := range subgraphNonSigningInfo() {
+		nonSigningOperatorIds = append(nonSigningOperatorIds, &subgraph.BatchNonSigningOperatorIds{NonSigning: info.NonSigning})
+	}
+	mockApi.On("QueryBatchNonSigningOperatorIdsInInterval").Return(nonSigningOperatorIds, nil)
+
+	expected, err := graph.QueryBatchNonSigningOperatorIdsInInterval(context.Background(), 3600)
+	require.NoError(t, err)
+	actual, err := indexed.QueryBatchNonSigningOperatorIdsInInterval(context.Background(), 3600)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.Len(t, actual, 2)
+}
+
+func TestParityQueryOperatorQuorumEvent(t *testing.T) {
+	indexed, graph, mockApi := newClients(t)
+	mockApi.On("QueryOperatorAddedToQuorum").Return(subgraphOperatorAddedToQuorum(), nil)
+	mockApi.On("QueryOperatorRemovedFromQuorum").Return(subgraphOperatorRemovedFromQuorum(), nil)
+
+	expected, err := graph.QueryOperatorQuorumEvent(context.Background(), 90, 110)
+	require.NoError(t, err)
+	actual, err := indexed.QueryOperatorQuorumEvent(context.Background(), 90, 110)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	// The block range is inclusive.
+	actual, err = indexed.QueryOperatorQuorumEvent(context.Background(), 101, 105)
+	require.NoError(t, err)
+	assert.Empty(t, actual.AddedToQuorum)
+	assert.Len(t, actual.RemovedFromQuorum, 1)
+
+	_, err = indexed.QueryOperatorQuorumEvent(context.Background(), 110, 90)
+	assert.Error(t, err)
+}
+
+func TestParityQueryIndexedOperatorsWithStateForTimeWindow(t *testing.T) {
+	indexed, graph, mockApi := newClients(t)
+	mockApi.On("QueryRegisteredOperatorsGreaterThanBlockTimestamp").Return(subgraphOperatorRegistered(), nil)
+	mockApi.On("QueryDeregisteredOperatorsGreaterThanBlockTimestamp").Return(subgraphOperatorDeregistered(), nil)
+	mockApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphOperatorInfo(), nil)
+
+	for _, state := range []dataapi.OperatorState{dataapi.Registered, dataapi.Deregistered} {
+		expected, err := graph.QueryIndexedOperatorsWithStateForTimeWindow(context.Background(), 1, state)
+		require.NoError(t, err)
+		actual, err := indexed.QueryIndexedOperatorsWithStateForTimeWindow(context.Background(), 1, state)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+		require.Len(t, actual.Operators, 1)
+		for _, operator := range actual.Operators {
+			assert.Empty(t, operator.OperatorProcessError)
+		}
+	}
+}
+
+func TestParityQueryOperatorInfoByOperatorId(t *testing.T) {
+	indexed, graph, mockApi := newClients(t)
+	mockApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphOperatorInfo(), nil)
+	_, operatorId := pubkeys()
+
+	expected, err := graph.QueryOperatorInfoByOperatorId(context.Background(), operatorId.Hex())
+	require.NoError(t, err)
+	actual, err := indexed.QueryOperatorInfoByOperatorId(context.Background(), operatorId.Hex())
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, "localhost:32007;32008", actual.Socket)
+
+	_, err = indexed.QueryOperatorInfoByOperatorId(context.Background(), operatorId2.Hex())
+	assert.Error(t, err)
+}
+
+func TestParityQueryOperatorEjectionsForTimeWindow(t *testing.T) {
+	indexed, graph, mockApi := newClients(t)
+	mockApi.On("QueryOperatorEjectionsGteBlockTimestamp").Return(subgraphOperatorEjections(), nil)
+	mockApi.On("QueryOperatorEjectionsGteBlockTimestampByOperatorId").Return(subgraphOperatorEjections()[:1], nil)
+	_, operatorId := pubkeys()
+
+	expected, err := graph.QueryOperatorEjectionsForTimeWindow(context.Background(), 1, "", 10, 0)
+	require.NoError(t, err)
+	actual, err := indexed.QueryOperatorEjectionsForTimeWindow(context.Background(), 1, "", 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	expected, err = graph.QueryOperatorEjectionsForTimeWindow(context.Background(), 1, operatorId.Hex(), 10, 0)
+	require.NoError(t, err)
+	actual, err = indexed.QueryOperatorEjectionsForTimeWindow(context.Background(), 1, operatorId.Hex(), 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	actual, err = indexed.QueryOperatorEjectionsForTimeWindow(context.Background(), 1, "", 10, 1)
+	require.NoError(t, err)
+	assert.Len(t, actual, 1)
+}
+
+func TestParityQueryOperatorsWithLimit(t *testing.T) {
+	indexed, graph, mockApi := newClients(t)
+	mockApi.On("QueryOperators").Return(subgraphOperatorRegistered(), nil)
+
+	expected, err := graph.QueryOperatorsWithLimit(context.Background(), 10)
+	require.NoError(t, err)
+	actual, err := indexed.QueryOperatorsWithLimit(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestReindexBlock(t *testing.T) {
+	events := fixtureEvents()
+	api, store := newIndexedApi(t, events)
+
+	// Indexing the same events again, as happens after a restart, doesn't duplicate records.
+	acc := chainindexer.NewAccumulator(store, logger)
+	object, err := acc.InitializeObject(indexer.Header{})
+	require.NoError(t, err)
+	for _, e := range events {
+		object, err = acc.UpdateObject(object, &indexer.Header{BlockHash: e.blockHash, Number: e.meta.BlockNumber}, e.event)
+		require.NoError(t, err)
+	}
+	batches, err := api.QueryBatchesByBlockTimestampRange(context.Background(), 0, now)
+	require.NoError(t, err)
+	assert.Len(t, batches, 2)
+
+	// A block replaced by a reorg drops the records of the orphaned block.
+	reorgMeta := meta(110, now-40, txHash1, 0)
+	_, err = acc.UpdateObject(object, &indexer.Header{BlockHash: [32]byte{0xff}, Number: 110}, indexer.Event{
+		Type: chainindexer.OperatorRegistered,
+		Payload: &chainindexer.OperatorRegistrationRecord{
+			EventMeta:  reorgMeta,
+			Operator:   operator2,
+			OperatorId: operatorId2,
+		},
+	})
+	require.NoError(t, err)
+
+	batches, err = api.QueryBatchesByBlockTimestampRange(context.Background(), 0, now)
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	assert.Equal(t, graphql.String("1"), batches[0].BatchId)
+
+	operators, err := api.QueryRegisteredOperatorsGreaterThanBlockTimestamp(context.Background(), now-41)
+	require.NoError(t, err)
+	require.Len(t, operators, 1)
+	assert.Equal(t, graphql.String("0x0200000000000000000000000000000000000000000000000000000000000000"), operators[0].OperatorId)
+
+	// The socket update of the orphaned block is gone as well.
+	_, operatorId := pubkeys()
+	info, err := api.QueryOperatorInfoByOperatorIdAtBlockNumber(context.Background(), operatorId.Hex(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, graphql.String("localhost:32005;32006"), info.SocketUpdates[0].Socket)
+}
+
+func TestUnexpectedEvent(t *testing.T) {
+	acc := chainindexer.NewAccumulator(chainindexer.NewStore(mapstore.NewStore()), logger)
+	object, err := acc.InitializeObject(indexer.Header{})
+	require.NoError(t, err)
+
+	_, err = acc.UpdateObject(object, &indexer.Header{Number: 1}, indexer.Event{Type: chainindexer.BatchConfirmed, Payload: &chainindexer.OperatorEjectionRecord{}})
+	assert.ErrorIs(t, err, chainindexer.ErrIncorrectEvent)
+
+	_, err = acc.UpdateObject(object, &indexer.Header{Number: 1}, indexer.Event{Type: "unknown"})
+	assert.ErrorIs(t, err, chainindexer.ErrIncorrectEvent)
+
+	data, err := acc.SerializeObject(object, "genesis")
+	require.NoError(t, err)
+	deserialized, err := acc.DeserializeObject(data, "genesis")
+	require.NoError(t, err)
+	assert.Equal(t, object, deserialized)
+}
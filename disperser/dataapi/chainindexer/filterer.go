@@ -0,0 +1,515 @@
+package chainindexer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/common"
+	blsapkreg "github.com/Layr-Labs/eigenda/contracts/bindings/BLSApkRegistry"
+	eigendasrvmg "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	ejectionmg "github.com/Layr-Labs/eigenda/contracts/bindings/EjectionManager"
+	regcoord "github.com/Layr-Labs/eigenda/contracts/bindings/RegistryCoordinator"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const confirmBatchMethod = "confirmBatch"
+
+// Filterer pulls the events backing the dataapi views from the EigenDAServiceManager, RegistryCoordinator,
+// BLSApkRegistry and EjectionManager contracts, and turns them into records.
+type Filterer struct {
+	Client     common.EthClient
+	StartBlock uint64
+	Logger     logging.Logger
+
+	serviceManager      *eigendasrvmg.ContractEigenDAServiceManagerFilterer
+	registryCoordinator *regcoord.ContractRegistryCoordinatorFilterer
+	blsApkRegistry      *blsapkreg.ContractBLSApkRegistryFilterer
+	ejectionManager     *ejectionmg.ContractEjectionManagerFilterer
+	serviceManagerAbi   abi.ABI
+
+	FastMode bool
+}
+
+var _ indexer.Filterer = (*Filterer)(nil)
+
+// NewFilterer creates a Filterer for the contracts of the EigenDA deployment at eigenDAServiceManagerAddr. Events
+// in blocks before startBlock are ignored.
+func NewFilterer(eigenDAServiceManagerAddr gethcommon.Address, client common.EthClient, startBlock uint64, logger logging.Logger) (*Filterer, error) {
+	contractEigenDAServiceManager, err := eigendasrvmg.NewContractEigenDAServiceManager(eigenDAServiceManagerAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	registryCoordinatorAddr, err := contractEigenDAServiceManager.RegistryCoordinator(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch RegistryCoordinator address: %w", err)
+	}
+	contractRegistryCoordinator, err := regcoord.NewContractRegistryCoordinator(registryCoordinatorAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	blsApkRegistryAddr, err := contractRegistryCoordinator.BlsApkRegistry(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch BLSApkRegistry address: %w", err)
+	}
+	ejectionManagerAddr, err := contractRegistryCoordinator.Ejector(&bind.CallOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch EjectionManager address: %w", err)
+	}
+
+	serviceManager, err := eigendasrvmg.NewContractEigenDAServiceManagerFilterer(eigenDAServiceManagerAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	registryCoordinator, err := regcoord.NewContractRegistryCoordinatorFilterer(registryCoordinatorAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	blsApkRegistry, err := blsapkreg.NewContractBLSApkRegistryFilterer(blsApkRegistryAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	ejectionManager, err := ejectionmg.NewContractEjectionManagerFilterer(ejectionManagerAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	serviceManagerAbi, err := abi.JSON(bytes.NewReader(common.ServiceManagerAbi))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Filterer{
+		Client:              client,
+		StartBlock:          startBlock,
+		Logger:              logger.With("component", "ChainIndexerFilterer"),
+		serviceManager:      serviceManager,
+		registryCoordinator: registryCoordinator,
+		blsApkRegistry:      blsApkRegistry,
+		ejectionManager:     ejectionManager,
+		serviceManagerAbi:   serviceManagerAbi,
+		FastMode:            false,
+	}, nil
+}
+
+// headerEvent is an event together with the position of its log, used to order the events of a block.
+type headerEvent struct {
+	header   *indexer.Header
+	logIndex uint
+	event    indexer.Event
+}
+
+// eventCollector gathers the events of a set of headers, skipping the logs of orphaned blocks.
+type eventCollector struct {
+	headers    indexer.Headers
+	timestamps map[uint64]uint64
+	events     []headerEvent
+}
+
+func (f *Filterer) FilterHeaders(headers indexer.Headers) ([]indexer.HeaderAndEvents, error) {
+	if err := headers.OK(); err != nil {
+		return nil, err
+	}
+
+	start := headers.First().Number
+	if start < f.StartBlock {
+		start = f.StartBlock
+	}
+	end := headers.Last().Number
+	if end < start {
+		return nil, nil
+	}
+
+	ctx := context.Background()
+	opts := &bind.FilterOpts{
+		Start:   start,
+		End:     &end,
+		Context: ctx,
+	}
+	collector := &eventCollector{
+		headers:    headers,
+		timestamps: make(map[uint64]uint64),
+	}
+
+	for _, filter := range []func(context.Context, *bind.FilterOpts, *eventCollector) error{
+		f.filterBatchConfirmed,
+		f.filterOperatorRegistered,
+		f.filterOperatorDeregistered,
+		f.filterOperatorSocketUpdate,
+		f.filterNewPubkeyRegistration,
+		f.filterOperatorAddedToQuorums,
+		f.filterOperatorRemovedFromQuorums,
+		f.filterOperatorEjected,
+	} {
+		if err := filter(ctx, opts, collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return collector.headerAndEvents(), nil
+}
+
+// GetSyncPoint doesn't ask the indexer to skip ahead: the operator pubkey and socket accumulators sharing the indexer
+// are built from the full event history. A restart resumes from the headers kept in the indexer's header store
+// instead, and events before StartBlock are skipped by FilterHeaders.
+func (f *Filterer) GetSyncPoint(latestHeader *indexer.Header) (uint64, error) {
+	return 0, nil
+}
+
+func (f *Filterer) SetSyncPoint(latestHeader *indexer.Header) error {
+	f.FastMode = true
+	return nil
+}
+
+func (f *Filterer) FilterFastMode(headers indexer.Headers) (*indexer.Header, indexer.Headers, error) {
+	if len(headers) == 0 {
+		return nil, nil, nil
+	}
+	if f.FastMode {
+		f.FastMode = false
+		return headers.First(), headers, nil
+	}
+	return nil, headers, nil
+}
+
+func (f *Filterer) filterBatchConfirmed(ctx context.Context, opts *bind.FilterOpts, collector *eventCollector) error {
+	it, err := f.serviceManager.FilterBatchConfirmed(opts, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		event := it.Event
+		header, meta, ok, err := f.eventMeta(ctx, collector, event.Raw)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		record := &BatchRecord{
+			EventMeta:       meta,
+			BatchId:         event.BatchId,
+			BatchHeaderHash: event.BatchHeaderHash,
+		}
+		if err := f.fillBatchTransaction(ctx, record); err != nil {
+			return err
+		}
+		collector.add(header, event.Raw.Index, BatchConfirmed, record)
+	}
+	return it.Error()
+}
+
+func (f *Filterer) filterOperatorRegistered(ctx context.Context, opts *bind.FilterOpts, collector *eventCollector) error {
+	it, err := f.registryCoordinator.FilterOperatorRegistered(opts, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		event := it.Event
+		header, meta, ok, err := f.eventMeta(ctx, collector, event.Raw)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		collector.add(header, event.Raw.Index, OperatorRegistered, &OperatorRegistrationRecord{
+			EventMeta:  meta,
+			Operator:   event.Operator,
+			OperatorId: event.OperatorId,
+		})
+	}
+	return it.Error()
+}
+
+func (f *Filterer) filterOperatorDeregistered(ctx context.Context, opts *bind.FilterOpts, collector *eventCollector) error {
+	it, err := f.registryCoordinator.FilterOperatorDeregistered(opts, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		event := it.Event
+		header, meta, ok, err := f.eventMeta(ctx, collector, event.Raw)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		collector.add(header, event.Raw.Index, OperatorDeregistered, &OperatorRegistrationRecord{
+			EventMeta:  meta,
+			Operator:   event.Operator,
+			OperatorId: event.OperatorId,
+		})
+	}
+	return it.Error()
+}
+
+func (f *Filterer) filterOperatorSocketUpdate(ctx context.Context, opts *bind.FilterOpts, collector *eventCollector) error {
+	it, err := f.registryCoordinator.FilterOperatorSocketUpdate(opts, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		event := it.Event
+		header, meta, ok, err := f.eventMeta(ctx, collector, event.Raw)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		collector.add(header, event.Raw.Index, OperatorSocketUpdate, &SocketUpdateRecord{
+			EventMeta:  meta,
+			OperatorId: event.OperatorId,
+			Socket:     event.Socket,
+		})
+	}
+	return it.Error()
+}
+
+func (f *Filterer) filterNewPubkeyRegistration(ctx context.Context, opts *bind.FilterOpts, collector *eventCollector) error {
+	it, err := f.blsApkRegistry.FilterNewPubkeyRegistration(opts, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		event := it.Event
+		header, meta, ok, err := f.eventMeta(ctx, collector, event.Raw)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		collector.add(header, event.Raw.Index, NewPubkeyRegistration, &PubkeyRegistrationRecord{
+			EventMeta:  meta,
+			Operator:   event.Operator,
+			OperatorId: pubkeyHash(event.PubkeyG1.X, event.PubkeyG1.Y),
+			PubkeyG1_X: event.PubkeyG1.X,
+			PubkeyG1_Y: event.PubkeyG1.Y,
+			PubkeyG2_X: event.PubkeyG2.X,
+			PubkeyG2_Y: event.PubkeyG2.Y,
+		})
+	}
+	return it.Error()
+}
+
+func (f *Filterer) filterOperatorAddedToQuorums(ctx context.Context, opts *bind.FilterOpts, collector *eventCollector) error {
+	it, err := f.blsApkRegistry.FilterOperatorAddedToQuorums(opts)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		event := it.Event
+		header, meta, ok, err := f.eventMeta(ctx, collector, event.Raw)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		collector.add(header, event.Raw.Index, OperatorAddedToQuorums, &OperatorQuorumRecord{
+			EventMeta:     meta,
+			Operator:      event.Operator,
+			OperatorId:    event.OperatorId,
+			QuorumNumbers: event.QuorumNumbers,
+		})
+	}
+	return it.Error()
+}
+
+func (f *Filterer) filterOperatorRemovedFromQuorums(ctx context.Context, opts *bind.FilterOpts, collector *eventCollector) error {
+	it, err := f.blsApkRegistry.FilterOperatorRemovedFromQuorums(opts)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		event := it.Event
+		header, meta, ok, err := f.eventMeta(ctx, collector, event.Raw)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		collector.add(header, event.Raw.Index, OperatorRemovedFromQuorums, &OperatorQuorumRecord{
+			EventMeta:     meta,
+			Operator:      event.Operator,
+			OperatorId:    event.OperatorId,
+			QuorumNumbers: event.QuorumNumbers,
+		})
+	}
+	return it.Error()
+}
+
+func (f *Filterer) filterOperatorEjected(ctx context.Context, opts *bind.FilterOpts, collector *eventCollector) error {
+	it, err := f.ejectionManager.FilterOperatorEjected(opts)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for it.Next() {
+		event := it.Event
+		header, meta, ok, err := f.eventMeta(ctx, collector, event.Raw)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		collector.add(header, event.Raw.Index, OperatorEjected, &OperatorEjectionRecord{
+			EventMeta:    meta,
+			OperatorId:   event.OperatorId,
+			QuorumNumber: event.QuorumNumber,
+		})
+	}
+	return it.Error()
+}
+
+// eventMeta resolves the header and block context of a log. It returns false if the log belongs to a block that
+// is no longer part of the indexed chain.
+func (f *Filterer) eventMeta(ctx context.Context, collector *eventCollector, log types.Log) (*indexer.Header, EventMeta, bool, error) {
+	header, err := collector.headers.GetHeaderByNumber(log.BlockNumber)
+	if err != nil {
+		return nil, EventMeta{}, false, err
+	}
+	if !header.BlockHashIs(log.BlockHash.Bytes()) {
+		return nil, EventMeta{}, false, nil
+	}
+
+	timestamp, ok := collector.timestamps[log.BlockNumber]
+	if !ok {
+		blockHeader, err := f.Client.HeaderByNumber(ctx, new(big.Int).SetUint64(log.BlockNumber))
+		if err != nil {
+			return nil, EventMeta{}, false, fmt.Errorf("failed to fetch header of block %d: %w", log.BlockNumber, err)
+		}
+		timestamp = blockHeader.Time
+		collector.timestamps[log.BlockNumber] = timestamp
+	}
+
+	return header, EventMeta{
+		BlockNumber:    log.BlockNumber,
+		BlockTimestamp: timestamp,
+		TxHash:         log.TxHash,
+		LogIndex:       log.Index,
+	}, true, nil
+}
+
+// fillBatchTransaction adds the gas fees, batch header and non-signers of the confirmBatch transaction to the
+// record.
+func (f *Filterer) fillBatchTransaction(ctx context.Context, record *BatchRecord) error {
+	tx, _, err := f.Client.TransactionByHash(ctx, record.TxHash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch confirmBatch transaction %s: %w", record.TxHash.Hex(), err)
+	}
+	receipt, err := f.Client.TransactionReceipt(ctx, record.TxHash)
+	if err != nil {
+		return fmt.Errorf("failed to fetch confirmBatch receipt %s: %w", record.TxHash.Hex(), err)
+	}
+	record.GasUsed = receipt.GasUsed
+	record.GasPrice = receipt.EffectiveGasPrice
+	if record.GasPrice == nil {
+		record.GasPrice = tx.GasPrice()
+	}
+
+	batchHeader, nonSignerPubkeys, err := f.decodeConfirmBatch(tx.Data())
+	if err != nil {
+		// The batch may have been confirmed through another contract, in which case the calldata of the
+		// transaction is not a confirmBatch call.
+		f.Logger.Warn("failed to decode confirmBatch calldata", "txHash", record.TxHash.Hex(), "err", err)
+		return nil
+	}
+	record.QuorumNumbers = batchHeader.QuorumNumbers
+	record.ReferenceBlockNumber = batchHeader.ReferenceBlockNumber
+	record.NonSigners = make([]core.OperatorID, len(nonSignerPubkeys))
+	for i, pubkey := range nonSignerPubkeys {
+		record.NonSigners[i] = pubkeyHash(pubkey.X, pubkey.Y)
+	}
+	return nil
+}
+
+func (f *Filterer) decodeConfirmBatch(calldata []byte) (*eigendasrvmg.IEigenDAServiceManagerBatchHeader, []eigendasrvmg.BN254G1Point, error) {
+	if len(calldata) < 4 {
+		return nil, nil, fmt.Errorf("calldata too short: %d bytes", len(calldata))
+	}
+	method, err := f.serviceManagerAbi.MethodById(calldata[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+	if method.Name != confirmBatchMethod {
+		return nil, nil, fmt.Errorf("unexpected method %s", method.Name)
+	}
+	inputs, err := method.Inputs.Unpack(calldata[4:])
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(inputs) < 2 {
+		return nil, nil, fmt.Errorf("unexpected number of confirmBatch inputs: %d", len(inputs))
+	}
+
+	batchHeader := *abi.ConvertType(inputs[0], new(eigendasrvmg.IEigenDAServiceManagerBatchHeader)).(*eigendasrvmg.IEigenDAServiceManagerBatchHeader)
+	signature := *abi.ConvertType(inputs[1], new(eigendasrvmg.IBLSSignatureCheckerNonSignerStakesAndSignature)).(*eigendasrvmg.IBLSSignatureCheckerNonSignerStakesAndSignature)
+	return &batchHeader, signature.NonSignerPubkeys, nil
+}
+
+// pubkeyHash computes the operator id of a G1 public key, keccak256(abi.encodePacked(X, Y)).
+func pubkeyHash(x, y *big.Int) core.OperatorID {
+	return core.OperatorID(crypto.Keccak256Hash(
+		math.U256Bytes(new(big.Int).Set(x)),
+		math.U256Bytes(new(big.Int).Set(y)),
+	))
+}
+
+func (c *eventCollector) add(header *indexer.Header, logIndex uint, eventType string, payload any) {
+	c.events = append(c.events, headerEvent{
+		header:   header,
+		logIndex: logIndex,
+		event:    indexer.Event{Type: eventType, Payload: payload},
+	})
+}
+
+// headerAndEvents groups the collected events by header, in chain order.
+func (c *eventCollector) headerAndEvents() []indexer.HeaderAndEvents {
+	sort.SliceStable(c.events, func(i, j int) bool {
+		if c.events[i].header.Number == c.events[j].header.Number {
+			return c.events[i].logIndex < c.events[j].logIndex
+		}
+		return c.events[i].header.Number < c.events[j].header.Number
+	})
+
+	var result []indexer.HeaderAndEvents
+	for _, e := range c.events {
+		if len(result) == 0 || result[len(result)-1].Header != e.header {
+			result = append(result, indexer.HeaderAndEvents{Header: e.header})
+		}
+		last := &result[len(result)-1]
+		last.Events = append(last.Events, e.event)
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+package chainindexer
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	eigendasrvmg "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeConfirmBatch(t *testing.T) {
+	serviceManagerAbi, err := abi.JSON(bytes.NewReader(common.ServiceManagerAbi))
+	require.NoError(t, err)
+	f := &Filterer{serviceManagerAbi: serviceManagerAbi}
+
+	_, _, g1Gen, _ := bn254.Generators()
+	var g1Double bn254.G1Affine
+	g1Double.Double(&g1Gen)
+	nonSigners := []bn254.G1Affine{g1Gen, g1Double}
+
+	pubkeys := make([]eigendasrvmg.BN254G1Point, len(nonSigners))
+	for i, p := range nonSigners {
+		pubkeys[i] = eigendasrvmg.BN254G1Point{X: p.X.BigInt(new(big.Int)), Y: p.Y.BigInt(new(big.Int))}
+	}
+	batchHeader := eigendasrvmg.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:       [32]byte{1},
+		QuorumNumbers:         []byte{0, 1},
+		SignedStakeForQuorums: []byte{100, 80},
+		ReferenceBlockNumber:  1234,
+	}
+	signature := eigendasrvmg.IBLSSignatureCheckerNonSignerStakesAndSignature{
+		NonSignerQuorumBitmapIndices: []uint32{0, 0},
+		NonSignerPubkeys:             pubkeys,
+		QuorumApks:                   []eigendasrvmg.BN254G1Point{pubkeys[0], pubkeys[1]},
+		ApkG2:                        eigendasrvmg.BN254G2Point{X: [2]*big.Int{big.NewInt(1), big.NewInt(2)}, Y: [2]*big.Int{big.NewInt(3), big.NewInt(4)}},
+		Sigma:                        pubkeys[0],
+		QuorumApkIndices:             []uint32{0, 0},
+		TotalStakeIndices:            []uint32{0, 0},
+		NonSignerStakeIndices:        [][]uint32{{0}, {0}},
+	}
+	calldata, err := serviceManagerAbi.Pack(confirmBatchMethod, batchHeader, signature)
+	require.NoError(t, err)
+
+	decodedHeader, decodedPubkeys, err := f.decodeConfirmBatch(calldata)
+	require.NoError(t, err)
+	assert.Equal(t, batchHeader, *decodedHeader)
+	require.Len(t, decodedPubkeys, len(nonSigners))
+	for i, p := range nonSigners {
+		p := p
+		assert.Equal(t, (&core.G1Point{G1Affine: &p}).GetOperatorID(), pubkeyHash(decodedPubkeys[i].X, decodedPubkeys[i].Y))
+	}
+
+	_, _, err = f.decodeConfirmBatch(calldata[:3])
+	assert.Error(t, err)
+	_, _, err = f.decodeConfirmBatch([]byte{0xde, 0xad, 0xbe, 0xef})
+	assert.Error(t, err)
+}
+
+func TestHeaderAndEvents(t *testing.T) {
+	headers := indexer.Headers{{Number: 10}, {Number: 11}, {Number: 12}}
+	collector := &eventCollector{headers: headers}
+
+	collector.add(headers[2], 0, BatchConfirmed, "c")
+	collector.add(headers[0], 5, OperatorRegistered, "b")
+	collector.add(headers[0], 1, OperatorSocketUpdate, "a")
+
+	result := collector.headerAndEvents()
+	require.Len(t, result, 2)
+	assert.Equal(t, headers[0], result[0].Header)
+	assert.Equal(t, []indexer.Event{{Type: OperatorSocketUpdate, Payload: "a"}, {Type: OperatorRegistered, Payload: "b"}}, result[0].Events)
+	assert.Equal(t, headers[2], result[1].Header)
+	assert.Equal(t, []indexer.Event{{Type: BatchConfirmed, Payload: "c"}}, result[1].Events)
+}
@@ -0,0 +1,144 @@
+package chainindexer
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	minFilterRetryBackoff = time.Second
+	maxFilterRetryBackoff = time.Minute
+)
+
+// HandlerStatus is the indexing progress of one of the indexer's accumulators.
+type HandlerStatus struct {
+	Name string `json:"name"`
+	// LastIndexedBlock is the last block whose events were handed to the accumulator.
+	LastIndexedBlock uint64 `json:"last_indexed_block"`
+	// ConsecutiveFailures is the number of times in a row that filtering events has failed. Indexing doesn't
+	// advance past the failing range until it succeeds.
+	ConsecutiveFailures int    `json:"consecutive_failures"`
+	LastError           string `json:"last_error,omitempty"`
+	// FailingSince is when the current run of failures started.
+	FailingSince *time.Time `json:"failing_since,omitempty"`
+}
+
+// Status is the indexing progress of the chain indexer.
+type Status struct {
+	Healthy  bool            `json:"healthy"`
+	Handlers []HandlerStatus `json:"handlers"`
+}
+
+// Health tracks the indexing progress of the chain indexer's accumulators. It is safe for concurrent use.
+type Health struct {
+	mu       sync.Mutex
+	handlers []*HandlerStatus
+}
+
+func NewHealth() *Health {
+	return &Health{}
+}
+
+// Status returns the current indexing progress. The indexer is healthy if filtering events last succeeded for
+// every accumulator.
+func (h *Health) Status() Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	status := Status{
+		Healthy:  true,
+		Handlers: make([]HandlerStatus, len(h.handlers)),
+	}
+	for i, handler := range h.handlers {
+		status.Handlers[i] = *handler
+		if handler.ConsecutiveFailures > 0 {
+			status.Healthy = false
+		}
+	}
+	return status
+}
+
+func (h *Health) register(name string) *HandlerStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	handler := &HandlerStatus{Name: name}
+	h.handlers = append(h.handlers, handler)
+	return handler
+}
+
+func (h *Health) reportSuccess(handler *HandlerStatus, blockNumber uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	handler.LastIndexedBlock = blockNumber
+	handler.ConsecutiveFailures = 0
+	handler.LastError = ""
+	handler.FailingSince = nil
+}
+
+func (h *Health) reportFailure(handler *HandlerStatus, err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if handler.ConsecutiveFailures == 0 {
+		now := time.Now()
+		handler.FailingSince = &now
+	}
+	handler.ConsecutiveFailures++
+	handler.LastError = err.Error()
+}
+
+// retryingFilterer retries FilterHeaders with backoff until it succeeds, and reports the outcome to Health.
+//
+// The indexer adds headers to its header store before handing them to the filterers, and never hands them out
+// again. An error returned from FilterHeaders would therefore leave a gap in the index, so transient failures (e.g.
+// of the eth client) block indexing until they resolve instead.
+type retryingFilterer struct {
+	indexer.Filterer
+
+	logger     logging.Logger
+	health     *Health
+	status     *HandlerStatus
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+func newRetryingFilterer(name string, filterer indexer.Filterer, health *Health, logger logging.Logger) *retryingFilterer {
+	return &retryingFilterer{
+		Filterer:   filterer,
+		logger:     logger.With("filterer", name),
+		health:     health,
+		status:     health.register(name),
+		minBackoff: minFilterRetryBackoff,
+		maxBackoff: maxFilterRetryBackoff,
+	}
+}
+
+func (f *retryingFilterer) FilterHeaders(headers indexer.Headers) ([]indexer.HeaderAndEvents, error) {
+	backoff := f.minBackoff
+	for {
+		result, err := f.Filterer.FilterHeaders(headers)
+		if err == nil {
+			if !headers.Empty() {
+				f.health.reportSuccess(f.status, headers.Last().Number)
+			}
+			return result, nil
+		}
+
+		f.health.reportFailure(f.status, err)
+		if errors.Is(err, indexer.ErrHeadersUnordered) {
+			// Retrying won't help if the headers themselves are invalid.
+			return nil, err
+		}
+
+		f.logger.Warn("failed to filter headers, retrying", "from", headers.First().Number,
+			"to", headers.Last().Number, "backoff", backoff, "err", err)
+		time.Sleep(backoff)
+		backoff = min(2*backoff, f.maxBackoff)
+	}
+}
@@ -0,0 +1,217 @@
+package chainindexer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/indexer"
+	indexerleveldb "github.com/Layr-Labs/eigenda/indexer/leveldb"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain serves the headers of a chain whose head can be moved forward. Headers more than 2 blocks behind the
+// head are finalized.
+type fakeChain struct {
+	mu   sync.Mutex
+	head uint64
+}
+
+var _ indexer.HeaderService = (*fakeChain)(nil)
+
+func (c *fakeChain) header(number uint64, head uint64) *indexer.Header {
+	header := &indexer.Header{
+		BlockHash:   [32]byte{byte(number >> 8), byte(number), 1},
+		Number:      number,
+		Finalized:   head-number > 2,
+		CurrentFork: "genesis",
+	}
+	if number > 0 {
+		header.PrevBlockHash = [32]byte{byte((number - 1) >> 8), byte(number - 1), 1}
+	}
+	return header
+}
+
+func (c *fakeChain) setHead(head uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.head = head
+}
+
+func (c *fakeChain) PullNewHeaders(lastHeader *indexer.Header) (indexer.Headers, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var headers indexer.Headers
+	for number := lastHeader.Number + 1; number <= c.head; number++ {
+		headers = append(headers, c.header(number, c.head))
+	}
+	return headers, len(headers) == 0, nil
+}
+
+func (c *fakeChain) PullLatestHeader(finalized bool) (*indexer.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.header(c.head, c.head), nil
+}
+
+// fakeFilterer records the headers it is asked to filter, and fails the first failures calls.
+type fakeFilterer struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+	ranges   [][2]uint64
+	fastMode bool
+	synced   bool
+}
+
+func (f *fakeFilterer) FilterHeaders(headers indexer.Headers) ([]indexer.HeaderAndEvents, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls++
+	if f.calls <= f.failures {
+		return nil, errors.New("eth client unavailable")
+	}
+	f.ranges = append(f.ranges, [2]uint64{headers.First().Number, headers.Last().Number})
+	return nil, nil
+}
+
+func (f *fakeFilterer) GetSyncPoint(latestHeader *indexer.Header) (uint64, error) {
+	return 0, nil
+}
+
+func (f *fakeFilterer) SetSyncPoint(latestHeader *indexer.Header) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.fastMode = true
+	f.synced = true
+	return nil
+}
+
+func (f *fakeFilterer) FilterFastMode(headers indexer.Headers) (*indexer.Header, indexer.Headers, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(headers) == 0 {
+		return nil, nil, nil
+	}
+	if f.fastMode {
+		f.fastMode = false
+		return headers.First(), headers, nil
+	}
+	return nil, headers, nil
+}
+
+func (f *fakeFilterer) filteredRanges() [][2]uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][2]uint64(nil), f.ranges...)
+}
+
+func newTestRetryingFilterer(filterer indexer.Filterer, health *Health) *retryingFilterer {
+	f := newRetryingFilterer("Records", filterer, health, logging.NewNoopLogger())
+	f.minBackoff = time.Millisecond
+	f.maxBackoff = 4 * time.Millisecond
+	return f
+}
+
+func TestRetryingFilterer(t *testing.T) {
+	health := NewHealth()
+	filterer := &fakeFilterer{failures: 3}
+	f := newTestRetryingFilterer(filterer, health)
+
+	chain := &fakeChain{}
+	headers := indexer.Headers{chain.header(10, 20), chain.header(11, 20)}
+	_, err := f.FilterHeaders(headers)
+	require.NoError(t, err)
+	assert.Equal(t, 4, filterer.calls)
+	assert.Equal(t, [][2]uint64{{10, 11}}, filterer.filteredRanges())
+
+	status := health.Status()
+	assert.True(t, status.Healthy)
+	require.Len(t, status.Handlers, 1)
+	assert.Equal(t, HandlerStatus{Name: "Records", LastIndexedBlock: 11}, status.Handlers[0])
+
+	// Failures are reported while they last.
+	health.reportFailure(f.status, errors.New("eth client unavailable"))
+	status = health.Status()
+	assert.False(t, status.Healthy)
+	assert.Equal(t, 1, status.Handlers[0].ConsecutiveFailures)
+	assert.Equal(t, "eth client unavailable", status.Handlers[0].LastError)
+	assert.NotNil(t, status.Handlers[0].FailingSince)
+	assert.Equal(t, uint64(11), status.Handlers[0].LastIndexedBlock)
+
+	// Invalid headers aren't retried.
+	unordered := indexer.Headers{chain.header(10, 20), chain.header(12, 20)}
+	_, err = newTestRetryingFilterer(&Filterer{}, health).FilterHeaders(unordered)
+	assert.ErrorIs(t, err, indexer.ErrHeadersUnordered)
+}
+
+func runTestIndexer(t *testing.T, chain *fakeChain, headerStore indexer.HeaderStore, filterer *fakeFilterer, health *Health, head uint64) {
+	logger := logging.NewNoopLogger()
+	handlers := []indexer.AccumulatorHandler{
+		{
+			Acc:      NewAccumulator(NewStore(mapstore.NewStore()), logger),
+			Filterer: newTestRetryingFilterer(filterer, health),
+			Status:   indexer.Good,
+		},
+	}
+	config := &indexer.Config{PullInterval: time.Millisecond}
+	idx := indexer.New(config, handlers, chain, headerStore, &upgrader{}, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, idx.Index(ctx))
+	require.Eventually(t, func() bool {
+		status := health.Status()
+		return status.Handlers[len(status.Handlers)-1].LastIndexedBlock == head
+	}, 5*time.Second, time.Millisecond)
+	cancel()
+	// Let the indexing loop observe the cancellation before the header store is closed.
+	time.Sleep(50 * time.Millisecond)
+}
+
+type upgrader struct{}
+
+func (u *upgrader) DetectUpgrade(headers indexer.Headers) indexer.Headers {
+	for _, header := range headers {
+		header.CurrentFork = "genesis"
+	}
+	return headers
+}
+
+func (u *upgrader) GetLatestUpgrade(header *indexer.Header) uint64 {
+	return header.Number
+}
+
+func TestIndexerResumesFromHeaderStore(t *testing.T) {
+	path := t.TempDir()
+	chain := &fakeChain{}
+	chain.setHead(100)
+
+	headerStore, err := indexerleveldb.NewHeaderStore(path)
+	require.NoError(t, err)
+	first := &fakeFilterer{}
+	runTestIndexer(t, chain, headerStore, first, NewHealth(), 100)
+	headerStore.Close()
+	assert.True(t, first.synced)
+	assert.Equal(t, uint64(1), first.filteredRanges()[0][0])
+
+	// After a restart, only the blocks past the previously indexed ones are filtered, even though filtering fails
+	// at first.
+	chain.setHead(150)
+	headerStore, err = indexerleveldb.NewHeaderStore(path)
+	require.NoError(t, err)
+	defer headerStore.Close()
+	second := &fakeFilterer{failures: 2}
+	runTestIndexer(t, chain, headerStore, second, NewHealth(), 150)
+	assert.False(t, second.synced)
+	ranges := second.filteredRanges()
+	require.NotEmpty(t, ranges)
+	assert.Equal(t, uint64(101), ranges[0][0])
+	assert.Equal(t, uint64(150), ranges[len(ranges)-1][1])
+}
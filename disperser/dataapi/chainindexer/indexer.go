@@ -0,0 +1,82 @@
+package chainindexer
+
+import (
+	"fmt"
+
+	dacommon "github.com/Layr-Labs/eigenda/common"
+	coreindexer "github.com/Layr-Labs/eigenda/core/indexer"
+	"github.com/Layr-Labs/eigenda/indexer"
+	indexereth "github.com/Layr-Labs/eigenda/indexer/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CreateNewIndexer creates an indexer that writes the events of the EigenDA contracts into the store. Events in
+// blocks before startBlock are not written to the store.
+//
+// The operator pubkey and socket accumulators of core/indexer are registered ahead of the store's accumulator, so the
+// returned indexer can also back a core/indexer.IndexedChainState without pulling the chain a second time.
+//
+// The headers and the accumulator objects are kept in headerStore. If it is persistent (see indexer/leveldb), the
+// indexer resumes from the last indexed block after a restart instead of indexing the chain again. The indexing
+// progress and any failures to pull events are reported to health.
+func CreateNewIndexer(
+	config *indexer.Config,
+	store *Store,
+	headerStore indexer.HeaderStore,
+	health *Health,
+	gethClient dacommon.EthClient,
+	rpcClient dacommon.RPCEthClient,
+	eigenDAServiceManagerAddr string,
+	startBlock uint64,
+	_logger logging.Logger,
+) (indexer.Indexer, error) {
+	logger := _logger.With("component", "ChainIndexer")
+	eigenDAServiceManager := common.HexToAddress(eigenDAServiceManagerAddr)
+
+	pubKeyFilterer, err := coreindexer.NewOperatorPubKeysFilterer(eigenDAServiceManager, gethClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new operator pubkeys filter: %w", err)
+	}
+
+	socketsFilterer, err := coreindexer.NewOperatorSocketsFilterer(eigenDAServiceManager, gethClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new operator sockets filter: %w", err)
+	}
+
+	filterer, err := NewFilterer(eigenDAServiceManager, gethClient, startBlock, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new chain indexer filter: %w", err)
+	}
+
+	handlers := []indexer.AccumulatorHandler{
+		{
+			Acc:      coreindexer.NewOperatorPubKeysAccumulator(logger),
+			Filterer: newRetryingFilterer("OperatorPubKeys", pubKeyFilterer, health, logger),
+			Status:   indexer.Good,
+		},
+		{
+			Acc:      coreindexer.NewOperatorSocketsAccumulator(logger),
+			Filterer: newRetryingFilterer("OperatorSockets", socketsFilterer, health, logger),
+			Status:   indexer.Good,
+		},
+		{
+			Acc:      NewAccumulator(store, logger),
+			Filterer: newRetryingFilterer("Records", filterer, health, logger),
+			Status:   indexer.Good,
+		},
+	}
+
+	var (
+		upgrader   = &coreindexer.Upgrader{}
+		headerSrvc = indexereth.NewHeaderService(logger, rpcClient)
+	)
+	return indexer.New(
+		config,
+		handlers,
+		headerSrvc,
+		headerStore,
+		upgrader,
+		logger,
+	), nil
+}
@@ -0,0 +1,89 @@
+package chainindexer
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/core"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	BatchConfirmed             = "batch_confirmed"
+	OperatorRegistered         = "operator_registered"
+	OperatorDeregistered       = "operator_deregistered"
+	OperatorAddedToQuorums     = "operator_added_to_quorums"
+	OperatorRemovedFromQuorums = "operator_removed_from_quorums"
+	OperatorEjected            = "operator_ejected"
+	NewPubkeyRegistration      = "new_pubkey_registration"
+	OperatorSocketUpdate       = "operator_socket_update"
+)
+
+var (
+	ErrIncorrectObject  = errors.New("incorrect object")
+	ErrIncorrectEvent   = errors.New("incorrect event payload")
+	ErrUnrecognizedFork = errors.New("unrecognized fork")
+)
+
+// EventMeta identifies the log that produced a record and carries the block context the subgraph exposes
+// alongside every entity.
+type EventMeta struct {
+	BlockNumber    uint64
+	BlockTimestamp uint64
+	TxHash         gethcommon.Hash
+	LogIndex       uint
+}
+
+// BatchRecord is a confirmed batch, combining the BatchConfirmed event with the confirmBatch calldata and the
+// transaction receipt.
+type BatchRecord struct {
+	EventMeta
+	BatchId              uint32
+	BatchHeaderHash      [32]byte
+	QuorumNumbers        []byte
+	ReferenceBlockNumber uint32
+	NonSigners           []core.OperatorID
+	GasUsed              uint64
+	GasPrice             *big.Int
+}
+
+// OperatorRegistrationRecord is an OperatorRegistered or OperatorDeregistered event of the RegistryCoordinator.
+type OperatorRegistrationRecord struct {
+	EventMeta
+	Operator   gethcommon.Address
+	OperatorId core.OperatorID
+}
+
+// OperatorQuorumRecord is an OperatorAddedToQuorums or OperatorRemovedFromQuorums event of the BLSApkRegistry.
+type OperatorQuorumRecord struct {
+	EventMeta
+	Operator      gethcommon.Address
+	OperatorId    core.OperatorID
+	QuorumNumbers []byte
+}
+
+// OperatorEjectionRecord is an OperatorEjected event of the EjectionManager.
+type OperatorEjectionRecord struct {
+	EventMeta
+	OperatorId   core.OperatorID
+	QuorumNumber uint8
+}
+
+// PubkeyRegistrationRecord is a NewPubkeyRegistration event of the BLSApkRegistry. OperatorId is the hash of the
+// G1 public key, which is what the registry uses as the operator id.
+type PubkeyRegistrationRecord struct {
+	EventMeta
+	Operator   gethcommon.Address
+	OperatorId core.OperatorID
+	PubkeyG1_X *big.Int
+	PubkeyG1_Y *big.Int
+	PubkeyG2_X [2]*big.Int
+	PubkeyG2_Y [2]*big.Int
+}
+
+// SocketUpdateRecord is an OperatorSocketUpdate event of the RegistryCoordinator.
+type SocketUpdateRecord struct {
+	EventMeta
+	OperatorId core.OperatorID
+	Socket     string
+}
@@ -0,0 +1,248 @@
+package chainindexer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"math"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// Key prefixes of the record types kept in the store.
+//
+// Batches, registrations and ejections are queried by block timestamp, so they are keyed by
+// prefix | timestamp | block number | log index. Block timestamps never decrease, so this is also block order.
+// Quorum changes are queried by block number and are keyed by prefix | block number | log index. Pubkeys are keyed
+// by operator id and socket updates by operator id | block number | log index.
+//
+// Every record additionally has a block index entry, prefix | block number | record key, so that the records of a
+// block can be replaced when the block is re-indexed (e.g. after a restart or a reorg).
+const (
+	batchPrefix byte = iota + 1
+	operatorRegisteredPrefix
+	operatorDeregisteredPrefix
+	operatorAddedToQuorumsPrefix
+	operatorRemovedFromQuorumsPrefix
+	operatorEjectedPrefix
+	pubkeyRegistrationPrefix
+	socketUpdatePrefix
+	blockIndexPrefix
+)
+
+// Store persists the records built from on-chain events in a key-value store.
+type Store struct {
+	db kvstore.Store[[]byte]
+}
+
+func NewStore(db kvstore.Store[[]byte]) *Store {
+	return &Store{db: db}
+}
+
+// DeleteBlock removes all records that were indexed from the given block.
+func (s *Store) DeleteBlock(blockNumber uint64) error {
+	prefix := blockIndexKey(blockNumber, nil)
+	it, err := s.db.NewIterator(prefix)
+	if err != nil {
+		return err
+	}
+	defer it.Release()
+
+	batch := s.db.NewBatch()
+	for it.Next() {
+		indexKey := bytes.Clone(it.Key())
+		batch.Delete(indexKey[len(prefix):])
+		batch.Delete(indexKey)
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Apply()
+}
+
+func (s *Store) PutBatch(record *BatchRecord) error {
+	return s.put(timeKey(batchPrefix, &record.EventMeta), &record.EventMeta, record)
+}
+
+func (s *Store) PutOperatorRegistered(record *OperatorRegistrationRecord) error {
+	return s.put(timeKey(operatorRegisteredPrefix, &record.EventMeta), &record.EventMeta, record)
+}
+
+func (s *Store) PutOperatorDeregistered(record *OperatorRegistrationRecord) error {
+	return s.put(timeKey(operatorDeregisteredPrefix, &record.EventMeta), &record.EventMeta, record)
+}
+
+func (s *Store) PutOperatorAddedToQuorums(record *OperatorQuorumRecord) error {
+	return s.put(blockKey(operatorAddedToQuorumsPrefix, &record.EventMeta), &record.EventMeta, record)
+}
+
+func (s *Store) PutOperatorRemovedFromQuorums(record *OperatorQuorumRecord) error {
+	return s.put(blockKey(operatorRemovedFromQuorumsPrefix, &record.EventMeta), &record.EventMeta, record)
+}
+
+func (s *Store) PutOperatorEjected(record *OperatorEjectionRecord) error {
+	return s.put(timeKey(operatorEjectedPrefix, &record.EventMeta), &record.EventMeta, record)
+}
+
+func (s *Store) PutPubkeyRegistration(record *PubkeyRegistrationRecord) error {
+	return s.put(operatorKey(pubkeyRegistrationPrefix, record.OperatorId), &record.EventMeta, record)
+}
+
+func (s *Store) PutSocketUpdate(record *SocketUpdateRecord) error {
+	key := append(operatorKey(socketUpdatePrefix, record.OperatorId), eventPosition(&record.EventMeta)...)
+	return s.put(key, &record.EventMeta, record)
+}
+
+// GetBatches returns the batches confirmed in blocks with timestamps in [start, end]. At most limit batches are
+// returned when limit is positive.
+func (s *Store) GetBatches(start, end uint64, descending bool, limit int) ([]*BatchRecord, error) {
+	from, to := keyRange(batchPrefix, start, end)
+	return readRange[BatchRecord](s.db, from, to, descending, limit)
+}
+
+// GetOperatorsRegistered returns the registrations in blocks with timestamps in [start, end].
+func (s *Store) GetOperatorsRegistered(start, end uint64, limit int) ([]*OperatorRegistrationRecord, error) {
+	from, to := keyRange(operatorRegisteredPrefix, start, end)
+	return readRange[OperatorRegistrationRecord](s.db, from, to, false, limit)
+}
+
+// GetOperatorsDeregistered returns the deregistrations in blocks with timestamps in [start, end].
+func (s *Store) GetOperatorsDeregistered(start, end uint64, limit int) ([]*OperatorRegistrationRecord, error) {
+	from, to := keyRange(operatorDeregisteredPrefix, start, end)
+	return readRange[OperatorRegistrationRecord](s.db, from, to, false, limit)
+}
+
+// GetOperatorsAddedToQuorums returns the quorum opt-ins in blocks [startBlock, endBlock].
+func (s *Store) GetOperatorsAddedToQuorums(startBlock, endBlock uint64) ([]*OperatorQuorumRecord, error) {
+	from, to := keyRange(operatorAddedToQuorumsPrefix, startBlock, endBlock)
+	return readRange[OperatorQuorumRecord](s.db, from, to, false, 0)
+}
+
+// GetOperatorsRemovedFromQuorums returns the quorum opt-outs in blocks [startBlock, endBlock].
+func (s *Store) GetOperatorsRemovedFromQuorums(startBlock, endBlock uint64) ([]*OperatorQuorumRecord, error) {
+	from, to := keyRange(operatorRemovedFromQuorumsPrefix, startBlock, endBlock)
+	return readRange[OperatorQuorumRecord](s.db, from, to, false, 0)
+}
+
+// GetOperatorEjections returns the ejections in blocks with timestamps in [start, end].
+func (s *Store) GetOperatorEjections(start, end uint64) ([]*OperatorEjectionRecord, error) {
+	from, to := keyRange(operatorEjectedPrefix, start, end)
+	return readRange[OperatorEjectionRecord](s.db, from, to, false, 0)
+}
+
+// GetPubkeyRegistration returns the pubkey registered for the operator, or kvstore.ErrNotFound.
+func (s *Store) GetPubkeyRegistration(operatorId core.OperatorID) (*PubkeyRegistrationRecord, error) {
+	data, err := s.db.Get(operatorKey(pubkeyRegistrationPrefix, operatorId))
+	if err != nil {
+		return nil, err
+	}
+	record := new(PubkeyRegistrationRecord)
+	if err := decode(data, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// GetLatestSocketUpdate returns the most recent socket update of the operator, or kvstore.ErrNotFound.
+func (s *Store) GetLatestSocketUpdate(operatorId core.OperatorID) (*SocketUpdateRecord, error) {
+	prefix := operatorKey(socketUpdatePrefix, operatorId)
+	records, err := readRange[SocketUpdateRecord](s.db, prefix, kvstore.PrefixEnd(prefix), true, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, kvstore.ErrNotFound
+	}
+	return records[0], nil
+}
+
+func (s *Store) put(key []byte, meta *EventMeta, record any) error {
+	data, err := encode(record)
+	if err != nil {
+		return err
+	}
+	batch := s.db.NewBatch()
+	batch.Put(key, data)
+	batch.Put(blockIndexKey(meta.BlockNumber, key), []byte{})
+	return batch.Apply()
+}
+
+func readRange[T any](db kvstore.Store[[]byte], start, end []byte, reverse bool, limit int) ([]*T, error) {
+	options := &kvstore.IteratorOptions{Reverse: reverse}
+	if limit > 0 {
+		options.Limit = uint32(limit)
+	}
+	it, err := db.NewRangeIterator(start, end, options)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Release()
+
+	records := make([]*T, 0)
+	for it.Next() {
+		record := new(T)
+		if err := decode(it.Value(), record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, it.Error()
+}
+
+func encode(record any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(record); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decode(data []byte, record any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(record)
+}
+
+// eventPosition encodes the block number and log index of an event so that keys sort in chain order.
+func eventPosition(meta *EventMeta) []byte {
+	position := make([]byte, 12)
+	binary.BigEndian.PutUint64(position[:8], meta.BlockNumber)
+	binary.BigEndian.PutUint32(position[8:], uint32(meta.LogIndex))
+	return position
+}
+
+func timeKey(prefix byte, meta *EventMeta) []byte {
+	key := make([]byte, 9, 21)
+	key[0] = prefix
+	binary.BigEndian.PutUint64(key[1:], meta.BlockTimestamp)
+	return append(key, eventPosition(meta)...)
+}
+
+func blockKey(prefix byte, meta *EventMeta) []byte {
+	return append([]byte{prefix}, eventPosition(meta)...)
+}
+
+func operatorKey(prefix byte, operatorId core.OperatorID) []byte {
+	return append([]byte{prefix}, operatorId[:]...)
+}
+
+func blockIndexKey(blockNumber uint64, key []byte) []byte {
+	indexKey := make([]byte, 9, 9+len(key))
+	indexKey[0] = blockIndexPrefix
+	binary.BigEndian.PutUint64(indexKey[1:], blockNumber)
+	return append(indexKey, key...)
+}
+
+// keyRange returns the iterator bounds of the records of a prefix whose leading uint64 (timestamp or block
+// number) is in [start, end].
+func keyRange(prefix byte, start, end uint64) ([]byte, []byte) {
+	from := make([]byte, 9)
+	from[0] = prefix
+	binary.BigEndian.PutUint64(from[1:], start)
+	if end == math.MaxUint64 {
+		return from, kvstore.PrefixEnd([]byte{prefix})
+	}
+	to := make([]byte, 9)
+	to[0] = prefix
+	binary.BigEndian.PutUint64(to[1:], end+1)
+	return from, to
+}
@@ -1,5 +1,7 @@
 package dataapi
 
+import "github.com/Layr-Labs/eigenda/disperser/dataapi/chainindexer"
+
 type Config struct {
 	SocketAddr         string
 	ServerMode         string
@@ -7,4 +9,8 @@ type Config struct {
 	DisperserHostname  string
 	ChurnerHostname    string
 	BatcherHealthEndpt string
+
+	// ChainIndexerHealth reports the progress of the built-in chain indexer in the health endpoint. It is nil if the
+	// subgraphs are used instead.
+	ChainIndexerHealth *chainindexer.Health
 }
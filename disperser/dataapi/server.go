@@ -17,6 +17,7 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/operators"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -24,6 +25,8 @@ import (
 
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/common/semver"
+	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/chainindexer"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/docs"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-contrib/logger"
@@ -54,6 +57,10 @@ const (
 	maxChurnerAvailabilityAge           = 3
 	maxBatcherAvailabilityAge           = 3
 	maxOperatorsStakeAge                = 300 // not expect the stake change to happen frequently
+	maxBlobV2Age                        = 10
+	maxBatchV2Age                       = 300 // batches are static once attested
+	maxAccountBlobsV2Age                = 10
+	maxOperatorSigningRateV2Age         = 10
 )
 
 var errNotFound = errors.New("not found")
@@ -190,6 +197,43 @@ type (
 		Error string `json:"error"`
 	}
 
+	BlobVerificationInfoV2 struct {
+		BatchHeaderHash string              `json:"batch_header_hash"`
+		BatchHeader     *corev2.BatchHeader `json:"batch_header"`
+		BlobIndex       uint32              `json:"blob_index"`
+		InclusionProof  string              `json:"inclusion_proof"`
+	}
+
+	BlobResponseV2 struct {
+		BlobKey           string                    `json:"blob_key"`
+		BlobHeader        *corev2.BlobHeader        `json:"blob_header"`
+		BlobStatus        string                    `json:"blob_status"`
+		BlobSize          uint64                    `json:"blob_size"`
+		RequestedAt       uint64                    `json:"requested_at"`
+		UpdatedAt         uint64                    `json:"updated_at"`
+		Expiry            uint64                    `json:"expiry"`
+		RelayKeys         []corev2.RelayKey         `json:"relay_keys,omitempty"`
+		VerificationInfos []*BlobVerificationInfoV2 `json:"verification_infos,omitempty"`
+	}
+
+	AccountBlobsResponseV2 struct {
+		Meta Meta              `json:"meta"`
+		Data []*BlobResponseV2 `json:"data"`
+	}
+
+	BatchResponseV2 struct {
+		BatchHeaderHash string              `json:"batch_header_hash"`
+		BatchHeader     *corev2.BatchHeader `json:"batch_header"`
+		Attestation     *corev2.Attestation `json:"attestation,omitempty"`
+	}
+
+	OperatorSigningRateV2 struct {
+		OperatorId    string  `json:"operator_id"`
+		TotalBatches  int     `json:"total_batches"`
+		SignedBatches int     `json:"signed_batches"`
+		SigningRate   float64 `json:"signing_rate"`
+	}
+
 	server struct {
 		serverMode        string
 		socketAddr        string
@@ -202,10 +246,14 @@ type (
 		chainState        core.ChainState
 		indexedChainState core.IndexedChainState
 
+		// blobMetadataStoreV2 backs the v2 routes. The v2 routes are not served if it is nil.
+		blobMetadataStoreV2 *blobstorev2.BlobMetadataStore
+
 		metrics                   *Metrics
 		disperserHostName         string
 		churnerHostName           string
 		batcherHealthEndpt        string
+		chainIndexerHealth        *chainindexer.Health
 		eigenDAGRPCServiceChecker EigenDAGRPCServiceChecker
 		eigenDAHttpServiceChecker EigenDAHttpServiceChecker
 	}
@@ -214,6 +262,7 @@ type (
 func NewServer(
 	config Config,
 	blobstore disperser.BlobStore,
+	blobMetadataStoreV2 *blobstorev2.BlobMetadataStore,
 	promClient PrometheusClient,
 	subgraphClient SubgraphClient,
 	transactor core.Reader,
@@ -245,6 +294,7 @@ func NewServer(
 		socketAddr:                config.SocketAddr,
 		allowOrigins:              config.AllowOrigins,
 		blobstore:                 blobstore,
+		blobMetadataStoreV2:       blobMetadataStoreV2,
 		promClient:                promClient,
 		subgraphClient:            subgraphClient,
 		transactor:                transactor,
@@ -254,6 +304,7 @@ func NewServer(
 		disperserHostName:         config.DisperserHostname,
 		churnerHostName:           config.ChurnerHostname,
 		batcherHealthEndpt:        config.BatcherHealthEndpt,
+		chainIndexerHealth:        config.ChainIndexerHealth,
 		eigenDAGRPCServiceChecker: eigenDAGRPCServiceChecker,
 		eigenDAHttpServiceChecker: eigenDAHttpServiceChecker,
 	}
@@ -302,9 +353,17 @@ func (s *server) Start() error {
 		}
 	}
 
-	router.GET("/", func(g *gin.Context) {
-		g.JSON(http.StatusAccepted, gin.H{"status": "OK"})
-	})
+	if s.blobMetadataStoreV2 != nil {
+		v2 := router.Group("/api/v2")
+		{
+			v2.GET("/blobs/:blob_key", s.FetchBlobV2Handler)
+			v2.GET("/batches/:batch_header_hash", s.FetchBatchV2Handler)
+			v2.GET("/accounts/:account_id/blobs", s.FetchAccountBlobsV2Handler)
+			v2.GET("/operators/:operator_id/signing-rate", s.FetchOperatorSigningRateV2Handler)
+		}
+	}
+
+	router.GET("/", s.HealthHandler)
 
 	router.Use(logger.SetLogger(
 		logger.WithSkipPath([]string{"/"}),
@@ -1160,6 +1219,23 @@ func (s *server) FetchBatcherAvailability(c *gin.Context) {
 	})
 }
 
+// HealthHandler reports whether the Data Access API is serving. If the built-in chain indexer is used, its indexing
+// progress is included, and the API is reported as unavailable while the indexer fails to pull events, since the
+// views built from them are no longer updated.
+func (s *server) HealthHandler(c *gin.Context) {
+	if s.chainIndexerHealth == nil {
+		c.JSON(http.StatusAccepted, gin.H{"status": "OK"})
+		return
+	}
+
+	indexerStatus := s.chainIndexerHealth.Status()
+	if !indexerStatus.Healthy {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "DEGRADED", "chain_indexer": indexerStatus})
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{"status": "OK", "chain_indexer": indexerStatus})
+}
+
 func errorResponse(c *gin.Context, err error) {
 	_ = c.Error(err)
 	var code int
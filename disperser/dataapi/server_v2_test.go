@@ -0,0 +1,318 @@
+package dataapi_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const v2TableName = "test-BlobMetadata-v2"
+
+type serverV2 interface {
+	FetchBlobV2Handler(c *gin.Context)
+	FetchBatchV2Handler(c *gin.Context)
+	FetchAccountBlobsV2Handler(c *gin.Context)
+	FetchOperatorSigningRateV2Handler(c *gin.Context)
+}
+
+func newTestBlobMetadataStoreV2(t *testing.T) *blobstorev2.BlobMetadataStore {
+	dynamoClient, err := dynamodb.NewLocalClient(t.TempDir(), mockLogger)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = dynamoClient.Shutdown()
+	})
+	err = dynamoClient.CreateTable(context.Background(), blobstorev2.GenerateTableSchema(v2TableName, 10, 10))
+	require.NoError(t, err)
+	return blobstorev2.NewBlobMetadataStore(dynamoClient, mockLogger, v2TableName)
+}
+
+func newTestServerV2(blobMetadataStore *blobstorev2.BlobMetadataStore) serverV2 {
+	return dataapi.NewServer(config, blobstore, blobMetadataStore, prometheusClient, subgraphClient, mockTx, mockChainState, mockIndexedChainState, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
+}
+
+func makeTestBlobHeaderV2(accountID string, binIndex uint32) *corev2.BlobHeader {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	return &corev2.BlobHeader{
+		BlobVersion:   0,
+		QuorumNumbers: []core.QuorumID{0, 1},
+		BlobCommitments: encoding.BlobCommitments{
+			Commitment:       (*encoding.G1Commitment)(&g1Gen),
+			LengthCommitment: (*encoding.G2Commitment)(&g2Gen),
+			LengthProof:      (*encoding.LengthProof)(&g2Gen),
+			Length:           uint(expectedDataLength),
+		},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         accountID,
+			BinIndex:          binIndex,
+			CumulativePayment: big.NewInt(100),
+		},
+	}
+}
+
+func getV2(t *testing.T, handler func(c *gin.Context), route string, target string, response any) *http.Response {
+	r := setUpRouter()
+	r.GET(route, handler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	r.ServeHTTP(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	if res.StatusCode == http.StatusOK {
+		require.NoError(t, json.Unmarshal(data, response))
+	}
+	return res
+}
+
+func TestFetchBlobV2Handler(t *testing.T) {
+	ctx := context.Background()
+	store := newTestBlobMetadataStoreV2(t)
+	server := newTestServerV2(store)
+
+	blobHeader := makeTestBlobHeaderV2("0xaccount", 0)
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	now := uint64(time.Now().UnixNano())
+	err = store.PutBlobMetadata(ctx, &v2.BlobMetadata{
+		BlobHeader:  blobHeader,
+		BlobStatus:  v2.Certified,
+		BlobSize:    123,
+		RequestedAt: now,
+		UpdatedAt:   now,
+	})
+	require.NoError(t, err)
+	err = store.PutBlobCertificate(ctx, &corev2.BlobCertificate{
+		BlobHeader: blobHeader,
+		RelayKeys:  []corev2.RelayKey{0, 2},
+	}, nil)
+	require.NoError(t, err)
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{1, 2, 3},
+		ReferenceBlockNumber: 100,
+	}
+	batchHeaderHash, err := batchHeader.Hash()
+	require.NoError(t, err)
+	err = store.PutBlobVerificationInfo(ctx, &corev2.BlobVerificationInfo{
+		BatchHeader:    batchHeader,
+		BlobKey:        blobKey,
+		BlobIndex:      5,
+		InclusionProof: []byte{1, 2, 3},
+	})
+	require.NoError(t, err)
+
+	var response dataapi.BlobResponseV2
+	res := getV2(t, server.FetchBlobV2Handler, "/v2/blobs/:blob_key", "/v2/blobs/"+blobKey.Hex(), &response)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, blobKey.Hex(), response.BlobKey)
+	assert.Equal(t, "Certified", response.BlobStatus)
+	assert.Equal(t, uint64(123), response.BlobSize)
+	assert.Equal(t, now, response.RequestedAt)
+	assert.Equal(t, "0xaccount", response.BlobHeader.PaymentMetadata.AccountID)
+	assert.Equal(t, []corev2.RelayKey{0, 2}, response.RelayKeys)
+	require.Len(t, response.VerificationInfos, 1)
+	assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), response.VerificationInfos[0].BatchHeaderHash)
+	assert.Equal(t, uint32(5), response.VerificationInfos[0].BlobIndex)
+	assert.Equal(t, "010203", response.VerificationInfos[0].InclusionProof)
+
+	// a queued blob has neither a certificate nor verification info
+	queuedHeader := makeTestBlobHeaderV2("0xaccount", 1)
+	queuedKey, err := queuedHeader.BlobKey()
+	require.NoError(t, err)
+	err = store.PutBlobMetadata(ctx, &v2.BlobMetadata{
+		BlobHeader:  queuedHeader,
+		BlobStatus:  v2.Queued,
+		RequestedAt: now,
+		UpdatedAt:   now,
+	})
+	require.NoError(t, err)
+	response = dataapi.BlobResponseV2{}
+	res = getV2(t, server.FetchBlobV2Handler, "/v2/blobs/:blob_key", "/v2/blobs/"+queuedKey.Hex(), &response)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "Queued", response.BlobStatus)
+	assert.Empty(t, response.RelayKeys)
+	assert.Empty(t, response.VerificationInfos)
+
+	res = getV2(t, server.FetchBlobV2Handler, "/v2/blobs/:blob_key", "/v2/blobs/"+corev2.BlobKey{9}.Hex(), &response)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	res = getV2(t, server.FetchBlobV2Handler, "/v2/blobs/:blob_key", "/v2/blobs/invalid", &response)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
+
+func TestFetchBatchV2Handler(t *testing.T) {
+	ctx := context.Background()
+	store := newTestBlobMetadataStoreV2(t)
+	server := newTestServerV2(store)
+
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{1, 2, 3},
+		ReferenceBlockNumber: 100,
+	}
+	batchHeaderHash, err := batchHeader.Hash()
+	require.NoError(t, err)
+	err = store.PutBatchHeader(ctx, batchHeader)
+	require.NoError(t, err)
+
+	// the batch is not attested yet
+	var response dataapi.BatchResponseV2
+	res := getV2(t, server.FetchBatchV2Handler, "/v2/batches/:batch_header_hash", "/v2/batches/"+hex.EncodeToString(batchHeaderHash[:]), &response)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), response.BatchHeaderHash)
+	assert.Equal(t, batchHeader, response.BatchHeader)
+	assert.Nil(t, response.Attestation)
+	assert.Equal(t, "max-age=10", res.Header.Get("Cache-Control"))
+
+	err = store.PutAttestation(ctx, &corev2.Attestation{
+		BatchHeader:   batchHeader,
+		AttestedAt:    uint64(time.Now().UnixNano()),
+		QuorumNumbers: []core.QuorumID{0, 1},
+	})
+	require.NoError(t, err)
+	response = dataapi.BatchResponseV2{}
+	res = getV2(t, server.FetchBatchV2Handler, "/v2/batches/:batch_header_hash", "/v2/batches/"+hex.EncodeToString(batchHeaderHash[:]), &response)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	require.NotNil(t, response.Attestation)
+	assert.Equal(t, []core.QuorumID{0, 1}, response.Attestation.QuorumNumbers)
+	assert.Equal(t, "max-age=300", res.Header.Get("Cache-Control"))
+
+	res = getV2(t, server.FetchBatchV2Handler, "/v2/batches/:batch_header_hash", "/v2/batches/"+hex.EncodeToString(make([]byte, 32)), &response)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestFetchAccountBlobsV2Handler(t *testing.T) {
+	ctx := context.Background()
+	store := newTestBlobMetadataStoreV2(t)
+	server := newTestServerV2(store)
+
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		requestedAt := uint64(now.Add(-time.Duration(i) * time.Minute).UnixNano())
+		err := store.PutBlobMetadata(ctx, &v2.BlobMetadata{
+			BlobHeader:  makeTestBlobHeaderV2("0xaccount", uint32(i)),
+			BlobStatus:  v2.Queued,
+			RequestedAt: requestedAt,
+			UpdatedAt:   requestedAt,
+		})
+		require.NoError(t, err)
+	}
+	// a blob of the account requested before the queried interval
+	requestedAt := uint64(now.Add(-2 * time.Hour).UnixNano())
+	err := store.PutBlobMetadata(ctx, &v2.BlobMetadata{
+		BlobHeader:  makeTestBlobHeaderV2("0xaccount", 10),
+		BlobStatus:  v2.Queued,
+		RequestedAt: requestedAt,
+		UpdatedAt:   requestedAt,
+	})
+	require.NoError(t, err)
+
+	var response dataapi.AccountBlobsResponseV2
+	res := getV2(t, server.FetchAccountBlobsV2Handler, "/v2/accounts/:account_id/blobs", "/v2/accounts/0xaccount/blobs", &response)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 3, response.Meta.Size)
+	require.Len(t, response.Data, 3)
+	// newest first
+	assert.Equal(t, uint32(0), response.Data[0].BlobHeader.PaymentMetadata.BinIndex)
+	assert.Equal(t, uint32(2), response.Data[2].BlobHeader.PaymentMetadata.BinIndex)
+
+	response = dataapi.AccountBlobsResponseV2{}
+	res = getV2(t, server.FetchAccountBlobsV2Handler, "/v2/accounts/:account_id/blobs", "/v2/accounts/0xaccount/blobs?limit=2&interval=86400", &response)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 2, response.Meta.Size)
+
+	response = dataapi.AccountBlobsResponseV2{}
+	res = getV2(t, server.FetchAccountBlobsV2Handler, "/v2/accounts/:account_id/blobs", "/v2/accounts/0xaccount/blobs?limit=100&interval=86400", &response)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 4, response.Meta.Size)
+
+	response = dataapi.AccountBlobsResponseV2{}
+	res = getV2(t, server.FetchAccountBlobsV2Handler, "/v2/accounts/:account_id/blobs", "/v2/accounts/0xother/blobs", &response)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 0, response.Meta.Size)
+
+	res = getV2(t, server.FetchAccountBlobsV2Handler, "/v2/accounts/:account_id/blobs", "/v2/accounts/0xaccount/blobs?limit=0", &response)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+	res = getV2(t, server.FetchAccountBlobsV2Handler, "/v2/accounts/:account_id/blobs", "/v2/accounts/0xaccount/blobs?limit=1001", &response)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
+
+func TestFetchOperatorSigningRateV2Handler(t *testing.T) {
+	ctx := context.Background()
+	store := newTestBlobMetadataStoreV2(t)
+	server := newTestServerV2(store)
+
+	now := time.Now()
+	for i := 0; i < 4; i++ {
+		req := &corev2.DispersalRequest{
+			OperatorID:      opId0,
+			OperatorAddress: gethcommon.HexToAddress("0x1234567"),
+			Socket:          "socket",
+			DispersedAt:     uint64(now.Add(-time.Duration(i) * time.Minute).UnixNano()),
+			BatchHeader: corev2.BatchHeader{
+				BatchRoot:            [32]byte{byte(i)},
+				ReferenceBlockNumber: 100,
+			},
+		}
+		err := store.PutDispersalRequest(ctx, req)
+		require.NoError(t, err)
+
+		// the operator signs all batches but the last one, and fails to sign the third one
+		if i == 3 {
+			continue
+		}
+		res := &corev2.DispersalResponse{
+			DispersalRequest: req,
+			RespondedAt:      req.DispersedAt + uint64(time.Second),
+			Signature:        [32]byte{1},
+		}
+		if i == 2 {
+			res.Error = "failed to sign"
+		}
+		err = store.PutDispersalResponse(ctx, res)
+		require.NoError(t, err)
+	}
+
+	var response dataapi.OperatorSigningRateV2
+	res := getV2(t, server.FetchOperatorSigningRateV2Handler, "/v2/operators/:operator_id/signing-rate", "/v2/operators/"+opId0.Hex()+"/signing-rate", &response)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, opId0.Hex(), response.OperatorId)
+	assert.Equal(t, 4, response.TotalBatches)
+	assert.Equal(t, 2, response.SignedBatches)
+	assert.Equal(t, 0.5, response.SigningRate)
+
+	response = dataapi.OperatorSigningRateV2{}
+	res = getV2(t, server.FetchOperatorSigningRateV2Handler, "/v2/operators/:operator_id/signing-rate", "/v2/operators/"+opId1.Hex()+"/signing-rate", &response)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 0, response.TotalBatches)
+	assert.Equal(t, float64(0), response.SigningRate)
+
+	res = getV2(t, server.FetchOperatorSigningRateV2Handler, "/v2/operators/:operator_id/signing-rate", "/v2/operators/invalid/signing-rate", &response)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+
+	// the queried interval is bounded
+	res = getV2(t, server.FetchOperatorSigningRateV2Handler, "/v2/operators/:operator_id/signing-rate", "/v2/operators/"+opId0.Hex()+"/signing-rate?interval=2592001", &response)
+	assert.Equal(t, http.StatusInternalServerError, res.StatusCode)
+}
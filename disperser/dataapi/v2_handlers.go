@@ -0,0 +1,370 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// maxV2QueryInterval is the longest time range, in seconds, that the v2 routes can be queried for.
+	maxV2QueryInterval = 30 * 24 * 3600
+	// maxDispersalResponseDelay bounds how long after a batch is dispersed to an operator its response is counted
+	// towards the signing rate of the operator.
+	maxDispersalResponseDelay = time.Hour
+)
+
+// FetchBlobV2Handler godoc
+//
+//	@Summary	Fetch the status, certificate and verification info of a v2 blob by blob key
+//	@Tags		V2
+//	@Produce	json
+//	@Param		blob_key	path		string	true	"Blob Key"
+//	@Success	200			{object}	BlobResponseV2
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/v2/blobs/{blob_key} [get]
+func (s *server) FetchBlobV2Handler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchBlobV2", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	blobKey, err := corev2.HexToBlobKey(c.Param("blob_key"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBlobV2")
+		errorResponse(c, fmt.Errorf("invalid blob key"))
+		return
+	}
+
+	blob, err := s.getBlobV2(c.Request.Context(), blobKey)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBlobV2")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchBlobV2")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxBlobV2Age))
+	c.JSON(http.StatusOK, blob)
+}
+
+// FetchBatchV2Handler godoc
+//
+//	@Summary	Fetch a v2 batch header and its attestation by batch header hash
+//	@Tags		V2
+//	@Produce	json
+//	@Param		batch_header_hash	path		string	true	"Batch Header Hash"
+//	@Success	200					{object}	BatchResponseV2
+//	@Failure	400					{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404					{object}	ErrorResponse	"error: Not found"
+//	@Failure	500					{object}	ErrorResponse	"error: Server error"
+//	@Router		/v2/batches/{batch_header_hash} [get]
+func (s *server) FetchBatchV2Handler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchBatchV2", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	batchHeaderHash := c.Param("batch_header_hash")
+	batchHeaderHashBytes, err := ConvertHexadecimalToBytes([]byte(batchHeaderHash))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBatchV2")
+		errorResponse(c, fmt.Errorf("invalid batch header hash"))
+		return
+	}
+
+	batch, err := s.getBatchV2(c.Request.Context(), batchHeaderHashBytes)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBatchV2")
+		errorResponse(c, err)
+		return
+	}
+
+	// A batch may be fetched before it is attested, in which case the response must not be cached for long
+	maxAge := maxBatchV2Age
+	if batch.Attestation == nil {
+		maxAge = maxBlobV2Age
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchBatchV2")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxAge))
+	c.JSON(http.StatusOK, batch)
+}
+
+// FetchAccountBlobsV2Handler godoc
+//
+//	@Summary	Fetch the v2 blobs dispersed by an account, newest first
+//	@Tags		V2
+//	@Produce	json
+//	@Param		account_id	path		string	true	"Account ID"
+//	@Param		end			query		string	false	"End time (2006-01-02T15:04:05Z) to query for blobs [default: now]"
+//	@Param		interval	query		int		false	"Interval to query for blobs in seconds [default: 3600, max: 2592000]"
+//	@Param		limit		query		int		false	"Max number of blobs to return [default: 10, max: 1000]"
+//	@Success	200			{object}	AccountBlobsResponseV2
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/v2/accounts/{account_id}/blobs [get]
+func (s *server) FetchAccountBlobsV2Handler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchAccountBlobsV2", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	accountID := c.Param("account_id")
+
+	startTime, endTime, err := parseTimeRange(c)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchAccountBlobsV2")
+		errorResponse(c, err)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchAccountBlobsV2")
+		errorResponse(c, fmt.Errorf("invalid limit parameter"))
+		return
+	}
+	if limit <= 0 || limit > 1000 {
+		s.metrics.IncrementFailedRequestNum("FetchAccountBlobsV2")
+		errorResponse(c, fmt.Errorf("limit must be between 1 and 1000"))
+		return
+	}
+
+	metadata, err := s.blobMetadataStoreV2.GetBlobMetadataByAccountID(c.Request.Context(), accountID, uint64(startTime.UnixNano()), uint64(endTime.UnixNano()), int32(limit))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchAccountBlobsV2")
+		errorResponse(c, err)
+		return
+	}
+
+	blobs := make([]*BlobResponseV2, len(metadata))
+	for i, m := range metadata {
+		blobs[i], err = convertBlobMetadataToBlobResponseV2(m)
+		if err != nil {
+			s.metrics.IncrementFailedRequestNum("FetchAccountBlobsV2")
+			errorResponse(c, err)
+			return
+		}
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchAccountBlobsV2")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxAccountBlobsV2Age))
+	c.JSON(http.StatusOK, AccountBlobsResponseV2{
+		Meta: Meta{
+			Size: len(blobs),
+		},
+		Data: blobs,
+	})
+}
+
+// FetchOperatorSigningRateV2Handler godoc
+//
+//	@Summary	Fetch the rate at which an operator signed the v2 batches dispersed to it
+//	@Tags		V2
+//	@Produce	json
+//	@Param		operator_id	path		string	true	"Operator ID"
+//	@Param		end			query		string	false	"End time (2006-01-02T15:04:05Z) to query for batches [default: now]"
+//	@Param		interval	query		int		false	"Interval to query for batches in seconds [default: 3600, max: 2592000]"
+//	@Success	200			{object}	OperatorSigningRateV2
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/v2/operators/{operator_id}/signing-rate [get]
+func (s *server) FetchOperatorSigningRateV2Handler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchOperatorSigningRateV2", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	operatorID, err := core.OperatorIDFromHex(c.Param("operator_id"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorSigningRateV2")
+		errorResponse(c, fmt.Errorf("invalid operator id"))
+		return
+	}
+
+	startTime, endTime, err := parseTimeRange(c)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorSigningRateV2")
+		errorResponse(c, err)
+		return
+	}
+
+	rate, err := s.getOperatorSigningRateV2(c.Request.Context(), operatorID, startTime, endTime)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorSigningRateV2")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchOperatorSigningRateV2")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxOperatorSigningRateV2Age))
+	c.JSON(http.StatusOK, rate)
+}
+
+func (s *server) getBlobV2(ctx context.Context, blobKey corev2.BlobKey) (*BlobResponseV2, error) {
+	metadata, err := s.blobMetadataStoreV2.GetBlobMetadata(ctx, blobKey)
+	if err != nil {
+		return nil, convertNotFoundErrorV2(err)
+	}
+
+	blob, err := convertBlobMetadataToBlobResponseV2(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// The certificate and the verification infos only exist once the blob is encoded and dispatched respectively
+	cert, _, err := s.blobMetadataStoreV2.GetBlobCertificate(ctx, blobKey)
+	if err != nil && !errors.Is(err, dispcommon.ErrMetadataNotFound) {
+		return nil, err
+	}
+	if cert != nil {
+		blob.RelayKeys = cert.RelayKeys
+	}
+
+	verificationInfos, err := s.blobMetadataStoreV2.GetBlobVerificationInfos(ctx, blobKey)
+	if err != nil && !errors.Is(err, dispcommon.ErrMetadataNotFound) {
+		return nil, err
+	}
+	for _, info := range verificationInfos {
+		batchHeaderHash, err := info.BatchHeader.Hash()
+		if err != nil {
+			return nil, err
+		}
+		blob.VerificationInfos = append(blob.VerificationInfos, &BlobVerificationInfoV2{
+			BatchHeaderHash: hex.EncodeToString(batchHeaderHash[:]),
+			BatchHeader:     info.BatchHeader,
+			BlobIndex:       info.BlobIndex,
+			InclusionProof:  hex.EncodeToString(info.InclusionProof),
+		})
+	}
+
+	return blob, nil
+}
+
+func (s *server) getBatchV2(ctx context.Context, batchHeaderHash [32]byte) (*BatchResponseV2, error) {
+	batchHeader, err := s.blobMetadataStoreV2.GetBatchHeader(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, convertNotFoundErrorV2(err)
+	}
+
+	attestation, err := s.blobMetadataStoreV2.GetAttestation(ctx, batchHeaderHash)
+	if err != nil && !errors.Is(err, dispcommon.ErrMetadataNotFound) {
+		return nil, err
+	}
+
+	return &BatchResponseV2{
+		BatchHeaderHash: hex.EncodeToString(batchHeaderHash[:]),
+		BatchHeader:     batchHeader,
+		Attestation:     attestation,
+	}, nil
+}
+
+// getOperatorSigningRateV2 returns the fraction of the batches dispersed to the operator within [startTime, endTime]
+// that the operator signed.
+func (s *server) getOperatorSigningRateV2(ctx context.Context, operatorID core.OperatorID, startTime time.Time, endTime time.Time) (*OperatorSigningRateV2, error) {
+	requests, err := s.blobMetadataStoreV2.GetDispersalRequestsByOperator(ctx, operatorID, uint64(startTime.UnixNano()), uint64(endTime.UnixNano()))
+	if err != nil {
+		return nil, err
+	}
+
+	// Responses to the requests near the end of the range may arrive after it
+	responsesEnd := endTime.Add(maxDispersalResponseDelay)
+	responses, err := s.blobMetadataStoreV2.GetDispersalResponsesByOperator(ctx, operatorID, uint64(startTime.UnixNano()), uint64(responsesEnd.UnixNano()))
+	if err != nil {
+		return nil, err
+	}
+	signed := make(map[[32]byte]struct{}, len(responses))
+	for _, res := range responses {
+		if res.Error != "" {
+			continue
+		}
+		batchHeaderHash, err := res.BatchHeader.Hash()
+		if err != nil {
+			return nil, err
+		}
+		signed[batchHeaderHash] = struct{}{}
+	}
+
+	rate := &OperatorSigningRateV2{
+		OperatorId:   operatorID.Hex(),
+		TotalBatches: len(requests),
+	}
+	for _, req := range requests {
+		batchHeaderHash, err := req.BatchHeader.Hash()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := signed[batchHeaderHash]; ok {
+			rate.SignedBatches++
+		}
+	}
+	if rate.TotalBatches > 0 {
+		rate.SigningRate = float64(rate.SignedBatches) / float64(rate.TotalBatches)
+	}
+
+	return rate, nil
+}
+
+func convertBlobMetadataToBlobResponseV2(metadata *dispv2.BlobMetadata) (*BlobResponseV2, error) {
+	blobKey, err := metadata.BlobHeader.BlobKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlobResponseV2{
+		BlobKey:     blobKey.Hex(),
+		BlobHeader:  metadata.BlobHeader,
+		BlobStatus:  metadata.BlobStatus.String(),
+		BlobSize:    metadata.BlobSize,
+		RequestedAt: metadata.RequestedAt,
+		UpdatedAt:   metadata.UpdatedAt,
+		Expiry:      metadata.Expiry,
+	}, nil
+}
+
+// convertNotFoundErrorV2 maps the not found errors of the v2 metadata store to errNotFound, so that they are
+// responded to with a 404.
+func convertNotFoundErrorV2(err error) error {
+	if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+		return fmt.Errorf("%w: %s", errNotFound, err.Error())
+	}
+	return err
+}
+
+// parseTimeRange parses the end and interval query parameters into the time range [end - interval, end].
+func parseTimeRange(c *gin.Context) (time.Time, time.Time, error) {
+	endTime := time.Now()
+	if c.Query("end") != "" {
+		var err error
+		endTime, err = time.Parse("2006-01-02T15:04:05Z", c.Query("end"))
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+
+	interval, err := strconv.ParseInt(c.DefaultQuery("interval", "3600"), 10, 64)
+	if err != nil || interval <= 0 {
+		interval = 3600
+	}
+	if interval > maxV2QueryInterval {
+		return time.Time{}, time.Time{}, fmt.Errorf("interval must be at most %d seconds", maxV2QueryInterval)
+	}
+
+	return endTime.Add(-time.Duration(interval) * time.Second), endTime, nil
+}
@@ -35,6 +35,7 @@ const (
 	StoreBlobFailure          string = "store-blob-failed"   // Fail to store the blob (S3 or DynamoDB)
 	SystemRateLimitedFailure  string = "ratelimited-system"  // The request rate limited at system level
 	AccountRateLimitedFailure string = "ratelimited-account" // The request rate limited at account level
+	DispersalPausedFailure    string = "dispersal-paused"    // Dispersal to a requested quorum is paused by the operator
 )
 
 func NewMetrics(reg *prometheus.Registry, httpPort string, logger logging.Logger) *Metrics {
@@ -138,6 +139,14 @@ func (g *Metrics) HandleRateLimitedRpcRequest(method string) {
 	}).Inc()
 }
 
+func (g *Metrics) HandleDispersalPausedRpcRequest(method string) {
+	g.NumRpcRequests.With(prometheus.Labels{
+		"status_code":   codes.Unavailable.String(),
+		"status_detail": DispersalPausedFailure,
+		"method":        method,
+	}).Inc()
+}
+
 func (g *Metrics) HandleInternalFailureRpcRequest(method string) {
 	g.NumRpcRequests.With(prometheus.Labels{
 		"status_code":   codes.Internal.String(),
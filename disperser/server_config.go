@@ -9,4 +9,13 @@ const (
 type ServerConfig struct {
 	GrpcPort    string
 	GrpcTimeout time.Duration
+
+	// StatusPollInterval is how often the status of the blobs with open status subscriptions is read from the
+	// metadata store.
+	StatusPollInterval time.Duration
+	// MaxStatusSubscriptions is the maximum number of concurrent status subscriptions. Zero means there is no limit.
+	MaxStatusSubscriptions int
+	// BlobReapInterval is how often the v2 blobs which have expired are deleted along with their data. Zero disables
+	// the deletion of expired blobs.
+	BlobReapInterval time.Duration
 }
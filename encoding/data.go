@@ -56,6 +56,31 @@ func (c *BlobCommitments) ToProtobuf() (*pbcommon.BlobCommitment, error) {
 	}, nil
 }
 
+// BlobCommitmentsFromProtobuf converts a protobuf BlobCommitment to BlobCommitments
+func BlobCommitmentsFromProtobuf(c *pbcommon.BlobCommitment) (*BlobCommitments, error) {
+	commitment, err := new(G1Commitment).Deserialize(c.GetCommitment())
+	if err != nil {
+		return nil, err
+	}
+
+	lengthCommitment, err := new(G2Commitment).Deserialize(c.GetLengthCommitment())
+	if err != nil {
+		return nil, err
+	}
+
+	lengthProof, err := new(LengthProof).Deserialize(c.GetLengthProof())
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlobCommitments{
+		Commitment:       commitment,
+		LengthCommitment: lengthCommitment,
+		LengthProof:      lengthProof,
+		Length:           uint(c.GetLength()),
+	}, nil
+}
+
 // Frame is a chunk of data with the associated multi-reveal proof
 type Frame struct {
 	// Proof is the multireveal proof corresponding to the chunk
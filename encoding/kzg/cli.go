@@ -1,6 +1,7 @@
 package kzg
 
 import (
+	"fmt"
 	"runtime"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -86,6 +87,43 @@ func CLIFlags(envPrefix string) []cli.Flag {
 	}
 }
 
+// OptionalCLIFlags returns the flags of CLIFlags, none of which is required. It is used by binaries which only need
+// the SRS in some configurations, and which check the config with ValidateSRSConfig in those configurations.
+func OptionalCLIFlags(envPrefix string) []cli.Flag {
+	flags := CLIFlags(envPrefix)
+	for i, flag := range flags {
+		switch f := flag.(type) {
+		case cli.StringFlag:
+			f.Required = false
+			flags[i] = f
+		case cli.Uint64Flag:
+			f.Required = false
+			flags[i] = f
+		}
+	}
+	return flags
+}
+
+// ValidateSRSConfig checks that the flags which are required to load the SRS are set.
+func ValidateSRSConfig(cfg KzgConfig) error {
+	if cfg.G1Path == "" {
+		return fmt.Errorf("--%s is required", G1PathFlagName)
+	}
+	if cfg.G2Path == "" && cfg.G2PowerOf2Path == "" {
+		return fmt.Errorf("either --%s or --%s is required", G2PathFlagName, G2PowerOf2PathFlagName)
+	}
+	if cfg.CacheDir == "" {
+		return fmt.Errorf("--%s is required", CachePathFlagName)
+	}
+	if cfg.SRSOrder == 0 {
+		return fmt.Errorf("--%s is required", SRSOrderFlagName)
+	}
+	if cfg.SRSNumberToLoad == 0 {
+		return fmt.Errorf("--%s is required", SRSLoadingNumberFlagName)
+	}
+	return nil
+}
+
 func ReadCLIConfig(ctx *cli.Context) KzgConfig {
 	cfg := KzgConfig{}
 	cfg.G1Path = ctx.GlobalString(G1PathFlagName)
@@ -180,6 +180,9 @@ func (env *Config) generateDisperserVars(ind int, key, address, logPath, dbPath,
 		DISPERSER_SERVER_RETRIEVAL_BLOB_RATE: "4",
 		DISPERSER_SERVER_RETRIEVAL_BYTE_RATE: "10000000",
 
+		DISPERSER_SERVER_COMMITMENT_BLOB_RATE: "4",
+		DISPERSER_SERVER_COMMITMENT_BYTE_RATE: "10000000",
+
 		DISPERSER_SERVER_BUCKET_SIZES:       "5s",
 		DISPERSER_SERVER_BUCKET_MULTIPLIERS: "1",
 		DISPERSER_SERVER_COUNT_FAILED:       "true",
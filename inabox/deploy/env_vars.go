@@ -102,6 +102,10 @@ type DisperserVars struct {
 	DISPERSER_SERVER_RETRIEVAL_BLOB_RATE string
 
 	DISPERSER_SERVER_RETRIEVAL_BYTE_RATE string
+
+	DISPERSER_SERVER_COMMITMENT_BLOB_RATE string
+
+	DISPERSER_SERVER_COMMITMENT_BYTE_RATE string
 }
 
 func (vars DisperserVars) getEnvMap() map[string]string {
@@ -107,7 +107,9 @@ func (i *indexer) Index(ctx context.Context) error {
 	}
 
 	myLatestHeader, err := i.HeaderStore.GetLatestHeader(true)
-	if err != nil || !initialized || syncFromBlock-myLatestHeader.Number > maxSyncBlocks {
+	// syncFromBlock may be behind the stored headers (e.g. when a persistent header store is resumed), so compare
+	// without subtracting to avoid wrapping around and needlessly fast forwarding.
+	if err != nil || !initialized || syncFromBlock > myLatestHeader.Number+maxSyncBlocks {
 		i.Logger.Info("Fast forwarding to sync block", "block", syncFromBlock)
 		// This probably just wipes the HeaderStore clean
 		ffErr := i.HeaderStore.FastForward()
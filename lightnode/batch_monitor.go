@@ -0,0 +1,107 @@
+package lightnode
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/retriever/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	gcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// ConfirmedBatch is a batch whose confirmation has been observed on chain.
+type ConfirmedBatch struct {
+	BatchHeaderHash      [32]byte
+	BatchRoot            [32]byte
+	ReferenceBlockNumber uint
+	QuorumNumbers        []core.QuorumID
+	// ConfirmationBlockNumber is the number of the block which includes the confirmBatch transaction
+	ConfirmationBlockNumber uint64
+}
+
+// BatchMonitor watches the EigenDAServiceManager for BatchConfirmed events.
+type BatchMonitor struct {
+	logger                logging.Logger
+	ethClient             common.EthClient
+	chainClient           eth.ChainClient
+	serviceManagerAddress gcommon.Address
+
+	// lastBlock is the last block whose events have been processed. Zero until the first poll.
+	lastBlock uint64
+}
+
+func NewBatchMonitor(
+	logger logging.Logger,
+	ethClient common.EthClient,
+	chainClient eth.ChainClient,
+	serviceManagerAddress gcommon.Address,
+) *BatchMonitor {
+	return &BatchMonitor{
+		logger:                logger.With("component", "BatchMonitor"),
+		ethClient:             ethClient,
+		chainClient:           chainClient,
+		serviceManagerAddress: serviceManagerAddress,
+	}
+}
+
+// Poll returns the batches confirmed since the previous call. The first call only records the current block, so that
+// the light node samples the batches confirmed after it started rather than the whole history of the chain.
+func (m *BatchMonitor) Poll(ctx context.Context) ([]*ConfirmedBatch, error) {
+	latestBlock, err := m.ethClient.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block number: %w", err)
+	}
+	if m.lastBlock == 0 {
+		m.lastBlock = latestBlock
+		return nil, nil
+	}
+	if latestBlock <= m.lastBlock {
+		return nil, nil
+	}
+
+	logs, err := m.ethClient.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(m.lastBlock + 1),
+		ToBlock:   new(big.Int).SetUint64(latestBlock),
+		Addresses: []gcommon.Address{m.serviceManagerAddress},
+		Topics: [][]gcommon.Hash{
+			{common.BatchConfirmedEventSigHash},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter BatchConfirmed events: %w", err)
+	}
+
+	batches := make([]*ConfirmedBatch, 0, len(logs))
+	for _, log := range logs {
+		if len(log.Topics) < 2 {
+			m.logger.Warn("BatchConfirmed event without batch header hash", "txHash", log.TxHash.Hex())
+			continue
+		}
+		batchHeaderHash := log.Topics[1]
+		blockNumber := new(big.Int).SetUint64(log.BlockNumber)
+		batchHeader, err := m.chainClient.FetchBatchHeader(ctx, m.serviceManagerAddress, batchHeaderHash.Bytes(), blockNumber, blockNumber)
+		if err != nil {
+			// The events of the current range are retried on the next poll
+			return nil, fmt.Errorf("failed to fetch batch header %s: %w", batchHeaderHash.Hex(), err)
+		}
+
+		quorumNumbers := make([]core.QuorumID, len(batchHeader.QuorumNumbers))
+		for i, q := range batchHeader.QuorumNumbers {
+			quorumNumbers[i] = core.QuorumID(q)
+		}
+		batches = append(batches, &ConfirmedBatch{
+			BatchHeaderHash:         batchHeaderHash,
+			BatchRoot:               batchHeader.BlobHeadersRoot,
+			ReferenceBlockNumber:    uint(batchHeader.ReferenceBlockNumber),
+			QuorumNumbers:           quorumNumbers,
+			ConfirmationBlockNumber: log.BlockNumber,
+		})
+	}
+
+	m.lastBlock = latestBlock
+	return batches, nil
+}
@@ -0,0 +1,86 @@
+package lightnode_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	commonmock "github.com/Layr-Labs/eigenda/common/mock"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/lightnode"
+	retrievermock "github.com/Layr-Labs/eigenda/retriever/mock"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchMonitorPoll(t *testing.T) {
+	ethClient := &commonmock.MockEthClient{}
+	chainClient := retrievermock.NewMockChainClient()
+	serviceManager := gcommon.HexToAddress("0x1234")
+	monitor := lightnode.NewBatchMonitor(logging.NewNoopLogger(), ethClient, chainClient, serviceManager)
+	ctx := context.Background()
+
+	// The first poll only records the current block
+	ethClient.On("BlockNumber").Return(uint64(100)).Once()
+	batches, err := monitor.Poll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, batches)
+
+	batchHeaderHash := gcommon.Hash{1, 2, 3}
+	ethClient.On("BlockNumber").Return(uint64(110)).Once()
+	ethClient.On("FilterLogs", ethereum.FilterQuery{
+		FromBlock: big.NewInt(101),
+		ToBlock:   big.NewInt(110),
+		Addresses: []gcommon.Address{serviceManager},
+		Topics:    [][]gcommon.Hash{{common.BatchConfirmedEventSigHash}},
+	}).Return([]types.Log{
+		{
+			Topics:      []gcommon.Hash{common.BatchConfirmedEventSigHash, batchHeaderHash},
+			BlockNumber: 105,
+		},
+	}, nil).Once()
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:      [32]byte{4, 5, 6},
+		QuorumNumbers:        []byte{0, 1},
+		ReferenceBlockNumber: 90,
+	}, nil).Once()
+
+	batches, err = monitor.Poll(ctx)
+	require.NoError(t, err)
+	require.Len(t, batches, 1)
+	assert.Equal(t, [32]byte(batchHeaderHash), batches[0].BatchHeaderHash)
+	assert.Equal(t, [32]byte{4, 5, 6}, batches[0].BatchRoot)
+	assert.Equal(t, uint(90), batches[0].ReferenceBlockNumber)
+	assert.Equal(t, []core.QuorumID{0, 1}, batches[0].QuorumNumbers)
+	assert.Equal(t, uint64(105), batches[0].ConfirmationBlockNumber)
+
+	// A failed poll is retried from the same block
+	ethClient.On("BlockNumber").Return(uint64(120)).Once()
+	ethClient.On("FilterLogs", ethereum.FilterQuery{
+		FromBlock: big.NewInt(111),
+		ToBlock:   big.NewInt(120),
+		Addresses: []gcommon.Address{serviceManager},
+		Topics:    [][]gcommon.Hash{{common.BatchConfirmedEventSigHash}},
+	}).Return([]types.Log{}, errors.New("rpc error")).Once()
+	_, err = monitor.Poll(ctx)
+	assert.Error(t, err)
+
+	ethClient.On("BlockNumber").Return(uint64(121)).Once()
+	ethClient.On("FilterLogs", ethereum.FilterQuery{
+		FromBlock: big.NewInt(111),
+		ToBlock:   big.NewInt(121),
+		Addresses: []gcommon.Address{serviceManager},
+		Topics:    [][]gcommon.Hash{{common.BatchConfirmedEventSigHash}},
+	}).Return([]types.Log{}, nil).Once()
+	batches, err = monitor.Poll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, batches)
+	ethClient.AssertExpectations(t)
+}
@@ -0,0 +1,67 @@
+package lightnode
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/lightnode/flags"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	EncoderConfig    kzg.KzgConfig
+	EthClientConfig  geth.EthClientConfig
+	LoggerConfig     common.LoggerConfig
+	MetricsConfig    MetricsConfig
+	ChainStateConfig thegraph.Config
+
+	GrpcPort                      string
+	Timeout                       time.Duration
+	NumConnections                int
+	SamplesPerQuorum              int
+	PullInterval                  time.Duration
+	AuthenticationToken           string
+	BLSOperatorStateRetrieverAddr string
+	EigenDAServiceManagerAddr     string
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{
+		EncoderConfig:   kzg.ReadCLIConfig(ctx),
+		EthClientConfig: geth.ReadEthClientConfig(ctx),
+		LoggerConfig:    *loggerConfig,
+		MetricsConfig: MetricsConfig{
+			HTTPPort: ctx.GlobalString(flags.MetricsHTTPPortFlag.Name),
+		},
+		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
+		GrpcPort:                      ctx.GlobalString(flags.GrpcPortFlag.Name),
+		Timeout:                       ctx.GlobalDuration(flags.TimeoutFlag.Name),
+		NumConnections:                ctx.GlobalInt(flags.NumConnectionsFlag.Name),
+		SamplesPerQuorum:              ctx.GlobalInt(flags.SamplesPerQuorumFlag.Name),
+		PullInterval:                  ctx.GlobalDuration(flags.PullIntervalFlag.Name),
+		AuthenticationToken:           ctx.GlobalString(flags.AuthenticationTokenFlag.Name),
+		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
+		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
+	}
+
+	if config.SamplesPerQuorum <= 0 {
+		return nil, errors.New("samples per quorum must be positive")
+	}
+	if config.NumConnections <= 0 {
+		return nil, errors.New("num connections must be positive")
+	}
+	if config.PullInterval <= 0 {
+		return nil, errors.New("pull interval must be positive")
+	}
+
+	return config, nil
+}
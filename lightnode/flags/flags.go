@@ -0,0 +1,107 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = "lightnode"
+	envPrefix  = "LIGHTNODE"
+)
+
+var (
+	/* Required Flags */
+	GrpcPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "grpc-port"),
+		Usage:    "Port at which the light node listens for grpc calls",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "GRPC_PORT"),
+	}
+	BlsOperatorStateRetrieverFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever"),
+		Usage:    "Address of the BLS Operator State Retriever",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLS_OPERATOR_STATE_RETRIVER"),
+	}
+	EigenDAServiceManagerFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "eigenda-service-manager"),
+		Usage:    "Address of the EigenDA Service Manager",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "EIGENDA_SERVICE_MANAGER"),
+	}
+
+	/* Optional Flags*/
+	TimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "timeout"),
+		Usage:    "Amount of time to wait for the GRPC calls to the DA nodes",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "TIMEOUT"),
+		Value:    10 * time.Second,
+	}
+	NumConnectionsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "num-connections"),
+		Usage:    "maximum number of concurrent connections to DA nodes",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "NUM_CONNECTIONS"),
+		Value:    20,
+	}
+	SamplesPerQuorumFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "samples-per-quorum"),
+		Usage:    "number of chunks randomly sampled from each quorum of a blob",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SAMPLES_PER_QUORUM"),
+		Value:    8,
+	}
+	PullIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "pull-interval"),
+		Usage:    "interval at which the chain is polled for newly confirmed batches",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "PULL_INTERVAL"),
+		Value:    12 * time.Second,
+	}
+	AuthenticationTokenFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "authentication-token"),
+		Usage:    "token that subscribers of the blob availability stream must present. If empty, the stream is open to everyone",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "AUTHENTICATION_TOKEN"),
+	}
+	MetricsHTTPPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metrics-http-port"),
+		Usage:    "the http port which the metrics prometheus server is listening",
+		Required: false,
+		Value:    "9100",
+		EnvVar:   common.PrefixEnvVar(envPrefix, "METRICS_HTTP_PORT"),
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	GrpcPortFlag,
+	BlsOperatorStateRetrieverFlag,
+	EigenDAServiceManagerFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	TimeoutFlag,
+	NumConnectionsFlag,
+	SamplesPerQuorumFlag,
+	PullIntervalFlag,
+	AuthenticationTokenFlag,
+	MetricsHTTPPortFlag,
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(requiredFlags, optionalFlags...)
+	Flags = append(Flags, kzg.CLIFlags(envPrefix)...)
+	Flags = append(Flags, geth.EthClientFlags(envPrefix)...)
+	Flags = append(Flags, common.LoggerCLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, thegraph.CLIFlags(envPrefix)...)
+}
@@ -0,0 +1,78 @@
+package lightnode
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// LightNode samples the blobs of every batch confirmed on chain, and publishes its availability verdicts to the
+// subscribers of its gRPC server.
+type LightNode struct {
+	logger       logging.Logger
+	pullInterval time.Duration
+	monitor      *BatchMonitor
+	sampler      *Sampler
+	server       *Server
+	metrics      *Metrics
+}
+
+func NewLightNode(
+	logger logging.Logger,
+	pullInterval time.Duration,
+	monitor *BatchMonitor,
+	sampler *Sampler,
+	server *Server,
+	metrics *Metrics,
+) *LightNode {
+	return &LightNode{
+		logger:       logger.With("component", "LightNode"),
+		pullInterval: pullInterval,
+		monitor:      monitor,
+		sampler:      sampler,
+		server:       server,
+		metrics:      metrics,
+	}
+}
+
+// Start polls the chain for confirmed batches in the background until the context is cancelled.
+func (n *LightNode) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(n.pullInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				n.processNewBatches(ctx)
+			}
+		}
+	}()
+}
+
+func (n *LightNode) processNewBatches(ctx context.Context) {
+	batches, err := n.monitor.Poll(ctx)
+	if err != nil {
+		n.logger.Error("failed to poll confirmed batches", "err", err)
+		return
+	}
+
+	for _, batch := range batches {
+		verdicts, err := n.sampler.SampleBatch(ctx, batch)
+		if err != nil {
+			n.logger.Error("failed to sample batch", "batchHeaderHash", batch.BatchHeaderHash, "err", err)
+			continue
+		}
+		n.logger.Info("sampled batch", "batchHeaderHash", batch.BatchHeaderHash, "numBlobs", len(verdicts))
+
+		for _, verdict := range verdicts {
+			n.server.Publish(verdict)
+		}
+		if n.metrics != nil {
+			n.metrics.RecordBatchSampled(verdicts)
+		}
+	}
+}
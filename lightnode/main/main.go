@@ -1,8 +1,119 @@
 package main
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/lightnode"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/lightnode"
+	"github.com/Layr-Labs/eigenda/lightnode/flags"
+	retrievereth "github.com/Layr-Labs/eigenda/retriever/eth"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+var (
+	Version   = ""
+	GitCommit = ""
+	GitDate   = ""
+)
 
 // main is the entrypoint for the light node.
 func main() {
-	fmt.Println("Hello world")
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s-%s-%s", Version, GitCommit, GitDate)
+	app.Name = "lightnode"
+	app.Usage = "EigenDA Light Node"
+	app.Description = "Service for sampling the chunks of confirmed blobs and streaming their availability"
+	app.Flags = flags.Flags
+	app.Action = LightNodeMain
+	if err := app.Run(os.Args); err != nil {
+		log.Fatalf("application failed: %v", err)
+	}
+}
+
+func LightNodeMain(ctx *cli.Context) error {
+	config, err := lightnode.NewConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to parse the command line flags: %w", err)
+	}
+	logger, err := common.NewLogger(config.LoggerConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	v, err := verifier.NewVerifier(&config.EncoderConfig, true)
+	if err != nil {
+		return fmt.Errorf("failed to create verifier: %w", err)
+	}
+	gethClient, err := geth.NewMultiHomingClient(config.EthClientConfig, gethcommon.Address{}, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create eth client: %w", err)
+	}
+	tx, err := eth.NewReader(logger, gethClient, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
+	if err != nil {
+		return fmt.Errorf("failed to create eth reader: %w", err)
+	}
+	cs := eth.NewChainState(tx, gethClient)
+	ics := thegraph.MakeIndexedChainState(config.ChainStateConfig, cs, logger)
+
+	metrics := lightnode.NewMetrics(config.MetricsConfig.HTTPPort, logger)
+	server := lightnode.NewServer(config, logger, metrics)
+	monitor := lightnode.NewBatchMonitor(
+		logger,
+		gethClient,
+		retrievereth.NewChainClient(gethClient, logger),
+		gethcommon.HexToAddress(config.EigenDAServiceManagerAddr),
+	)
+	sampler := lightnode.NewSampler(
+		logger,
+		ics,
+		&core.StdAssignmentCoordinator{},
+		clients.NewNodeClient(config.Timeout),
+		v,
+		config.SamplesPerQuorum,
+		config.NumConnections,
+	)
+	node := lightnode.NewLightNode(logger, config.PullInterval, monitor, sampler, server, metrics)
+
+	c := context.Background()
+	err = ics.Start(c)
+	if err != nil {
+		return fmt.Errorf("failed to start indexed chain state: %w", err)
+	}
+	metrics.Start(c)
+	node.Start(c)
+
+	addr := fmt.Sprintf("0.0.0.0:%s", config.GrpcPort)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("could not start tcp listener: %w", err)
+	}
+	gs := grpc.NewServer()
+
+	// Register reflection service on gRPC server
+	// This makes "grpcurl -plaintext localhost:9000 list" command work
+	reflection.Register(gs)
+
+	pb.RegisterLightNodeServer(gs, server)
+
+	// Register Server for Health Checks
+	name := pb.LightNode_ServiceDesc.ServiceName
+	healthcheck.RegisterHealthServer(name, gs)
+
+	logger.Info("light node listening", "address", addr)
+	return gs.Serve(listener)
 }
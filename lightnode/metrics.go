@@ -0,0 +1,91 @@
+package lightnode
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	Namespace = "eigenda_lightnode"
+)
+
+type MetricsConfig struct {
+	HTTPPort string
+}
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	BatchesSampled prometheus.Counter
+	BlobsSampled   *prometheus.CounterVec
+	Subscribers    prometheus.Gauge
+
+	httpPort string
+	logger   logging.Logger
+}
+
+func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	reg.MustRegister(collectors.NewGoCollector())
+
+	metrics := &Metrics{
+		registry: reg,
+		BatchesSampled: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "batches_sampled_total",
+				Help:      "the number of confirmed batches sampled by the light node",
+			},
+		),
+		BlobsSampled: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "blobs_sampled_total",
+				Help:      "the number of blobs sampled by the light node, by availability verdict",
+			},
+			[]string{"available"},
+		),
+		Subscribers: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "subscribers",
+				Help:      "the number of open blob availability streams",
+			},
+		),
+		httpPort: httpPort,
+		logger:   logger.With("component", "LightNodeMetrics"),
+	}
+	return metrics
+}
+
+// RecordBatchSampled records the verdicts of the blobs of a sampled batch
+func (g *Metrics) RecordBatchSampled(verdicts []*BlobAvailability) {
+	g.BatchesSampled.Inc()
+	for _, verdict := range verdicts {
+		g.BlobsSampled.WithLabelValues(strconv.FormatBool(verdict.Available)).Inc()
+	}
+}
+
+func (g *Metrics) Start(ctx context.Context) {
+	g.logger.Info("Starting metrics server at ", "port", g.httpPort)
+	addr := fmt.Sprintf(":%s", g.httpPort)
+	go func() {
+		log := g.logger
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(
+			g.registry,
+			promhttp.HandlerOpts{},
+		))
+		err := http.ListenAndServe(addr, mux)
+		log.Error("Prometheus server failed", "err", err)
+	}()
+}
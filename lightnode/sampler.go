@@ -0,0 +1,278 @@
+package lightnode
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gammazero/workerpool"
+	"github.com/wealdtech/go-merkletree/v2"
+	"github.com/wealdtech/go-merkletree/v2/keccak256"
+)
+
+const (
+	// maxBlobHeaderAttempts is the number of operators asked for a blob header before the light node concludes that
+	// the header can't be fetched.
+	maxBlobHeaderAttempts = 3
+	// maxBlobsWithoutHeader is the number of blob indices probed while no blob header of a batch could be fetched,
+	// and the number of blobs of the batch is therefore unknown.
+	maxBlobsWithoutHeader = 8
+	// maxBlobHeaderProofDepth bounds the depth of the merkle proof of a blob header, so that the number of blobs it
+	// implies fits in a uint32.
+	maxBlobHeaderProofDepth = 31
+)
+
+// BlobAvailability is the verdict of the light node on the availability of a blob.
+type BlobAvailability struct {
+	// BlobHeaderHash is zero if the header of the blob was withheld.
+	BlobHeaderHash  [32]byte
+	BatchHeaderHash [32]byte
+	BlobIndex       uint32
+	// Available is true if the header of the blob and every chunk sampled by the light node were served and verified
+	// against the batch root and the commitments of the blob.
+	Available bool
+}
+
+// Sampler checks the availability of the blobs of confirmed batches by downloading randomly chosen chunks from the
+// operators and verifying them against the commitments of the blobs.
+//
+// Confirmed batches are only dispersed to operators, so the chunks are sampled from the operators rather than from
+// the relays.
+type Sampler struct {
+	logger                logging.Logger
+	indexedChainState     core.IndexedChainState
+	assignmentCoordinator core.AssignmentCoordinator
+	nodeClient            clients.NodeClient
+	verifier              encoding.Verifier
+	samplesPerQuorum      int
+	numConnections        int
+}
+
+func NewSampler(
+	logger logging.Logger,
+	indexedChainState core.IndexedChainState,
+	assignmentCoordinator core.AssignmentCoordinator,
+	nodeClient clients.NodeClient,
+	verifier encoding.Verifier,
+	samplesPerQuorum int,
+	numConnections int,
+) *Sampler {
+	return &Sampler{
+		logger:                logger.With("component", "Sampler"),
+		indexedChainState:     indexedChainState,
+		assignmentCoordinator: assignmentCoordinator,
+		nodeClient:            nodeClient,
+		verifier:              verifier,
+		samplesPerQuorum:      samplesPerQuorum,
+		numConnections:        numConnections,
+	}
+}
+
+// SampleBatch samples every blob of the batch and returns the availability verdict of each of them.
+//
+// The number of blobs of a batch isn't recorded on chain, but it is bounded by the merkle proofs of the blob headers:
+// the blob headers are the leaves of a tree padded to a power of two, so a proof of depth d shows that the batch has
+// more than 2^(d-1) and at most 2^d blobs. Blob headers are requested by increasing index up to that bound. A blob
+// whose header can't be fetched is reported unavailable if it is known to exist, i.e. it is below the lower bound or a
+// blob with a higher index exists. The first blob of a batch always exists.
+func (s *Sampler) SampleBatch(ctx context.Context, batch *ConfirmedBatch) ([]*BlobAvailability, error) {
+	state, err := s.indexedChainState.GetIndexedOperatorState(ctx, batch.ReferenceBlockNumber, batch.QuorumNumbers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", batch.ReferenceBlockNumber, err)
+	}
+
+	// blobHeaders[i] is nil if the header of blob i couldn't be fetched
+	blobHeaders := make([]*core.BlobHeader, 0)
+	blobHeaderHashes := make([][32]byte, 0)
+	// numBlobs is the number of blobs known to exist, and maxBlobs the bound on the number of blobs of the batch
+	numBlobs := uint32(1)
+	maxBlobs := uint32(maxBlobsWithoutHeader)
+	boundKnown := false
+	for blobIndex := uint32(0); blobIndex < maxBlobs; blobIndex++ {
+		blobHeader, blobHeaderHash, proofDepth, err := s.getBlobHeader(ctx, state, batch, blobIndex)
+		blobHeaders = append(blobHeaders, blobHeader)
+		blobHeaderHashes = append(blobHeaderHashes, blobHeaderHash)
+		if err != nil {
+			s.logger.Debug("failed to get blob header", "batchHeaderHash", fmt.Sprintf("%x", batch.BatchHeaderHash),
+				"blobIndex", blobIndex, "err", err)
+			continue
+		}
+
+		numBlobs = max(numBlobs, blobIndex+1)
+		if !boundKnown {
+			boundKnown = true
+			maxBlobs = uint32(1) << proofDepth
+			if proofDepth > 0 {
+				numBlobs = max(numBlobs, uint32(1)<<(proofDepth-1)+1)
+			}
+		}
+	}
+
+	verdicts := make([]*BlobAvailability, 0, numBlobs)
+	for blobIndex := uint32(0); blobIndex < numBlobs; blobIndex++ {
+		verdict := &BlobAvailability{
+			BlobHeaderHash:  blobHeaderHashes[blobIndex],
+			BatchHeaderHash: batch.BatchHeaderHash,
+			BlobIndex:       blobIndex,
+		}
+		if blobHeaders[blobIndex] == nil {
+			s.logger.Warn("blob is not available, its header was withheld",
+				"batchHeaderHash", fmt.Sprintf("%x", batch.BatchHeaderHash), "blobIndex", blobIndex)
+		} else {
+			verdict.Available = s.sampleBlob(ctx, state, batch, blobIndex, blobHeaders[blobIndex])
+		}
+		verdicts = append(verdicts, verdict)
+	}
+	return verdicts, nil
+}
+
+// getBlobHeader fetches the header of a blob from random operators of the batch, and verifies its inclusion in the
+// batch. It also returns the depth of the merkle proof of the header.
+func (s *Sampler) getBlobHeader(
+	ctx context.Context,
+	state *core.IndexedOperatorState,
+	batch *ConfirmedBatch,
+	blobIndex uint32,
+) (*core.BlobHeader, [32]byte, int, error) {
+	operatorIDs := make([]core.OperatorID, 0, len(state.IndexedOperators))
+	for opID := range state.IndexedOperators {
+		operatorIDs = append(operatorIDs, opID)
+	}
+	rand.Shuffle(len(operatorIDs), func(i, j int) {
+		operatorIDs[i], operatorIDs[j] = operatorIDs[j], operatorIDs[i]
+	})
+
+	err := fmt.Errorf("no operator to get blob header from")
+	for i := 0; i < len(operatorIDs) && i < maxBlobHeaderAttempts; i++ {
+		opInfo := state.IndexedOperators[operatorIDs[i]]
+		var blobHeader *core.BlobHeader
+		var proof *merkletree.Proof
+		blobHeader, proof, err = s.nodeClient.GetBlobHeader(ctx, opInfo.Socket, batch.BatchHeaderHash, blobIndex)
+		if err != nil {
+			continue
+		}
+
+		var blobHeaderHash [32]byte
+		blobHeaderHash, err = blobHeader.GetBlobHeaderHash()
+		if err != nil {
+			s.logger.Warn("got invalid blob header", "operator", opInfo.Socket, "err", err)
+			continue
+		}
+		var verified bool
+		verified, err = merkletree.VerifyProofUsing(blobHeaderHash[:], false, proof, [][]byte{batch.BatchRoot[:]}, keccak256.New())
+		if err == nil && !verified {
+			err = fmt.Errorf("blob header is not included in batch")
+		}
+		if err == nil && (proof.Index != uint64(blobIndex) || len(proof.Hashes) > maxBlobHeaderProofDepth) {
+			err = fmt.Errorf("proof of blob header is for index %d and has depth %d", proof.Index, len(proof.Hashes))
+		}
+		if err != nil {
+			s.logger.Warn("failed to verify blob header against batch root", "operator", opInfo.Socket, "err", err)
+			continue
+		}
+		return blobHeader, blobHeaderHash, len(proof.Hashes), nil
+	}
+	return nil, [32]byte{}, 0, err
+}
+
+// sampleBlob samples random chunks of each quorum of the blob, and returns whether they were all served and valid.
+func (s *Sampler) sampleBlob(
+	ctx context.Context,
+	state *core.IndexedOperatorState,
+	batch *ConfirmedBatch,
+	blobIndex uint32,
+	blobHeader *core.BlobHeader,
+) bool {
+	logger := s.logger.With("batchHeaderHash", fmt.Sprintf("%x", batch.BatchHeaderHash), "blobIndex", blobIndex)
+
+	err := s.verifier.VerifyBlobLength(blobHeader.BlobCommitments)
+	if err != nil {
+		logger.Warn("invalid blob length proof", "err", err)
+		return false
+	}
+	err = s.verifier.VerifyCommitEquivalenceBatch([]encoding.BlobCommitments{blobHeader.BlobCommitments})
+	if err != nil {
+		logger.Warn("invalid blob commitments", "err", err)
+		return false
+	}
+
+	for _, quorumInfo := range blobHeader.QuorumInfos {
+		err = s.sampleQuorum(ctx, state, batch, blobIndex, blobHeader, quorumInfo)
+		if err != nil {
+			logger.Warn("blob is not available", "quorum", quorumInfo.QuorumID, "err", err)
+			return false
+		}
+	}
+	return true
+}
+
+// sampleQuorum picks random chunks of the blob in the quorum, and downloads and verifies the bundles of the operators
+// they are assigned to.
+func (s *Sampler) sampleQuorum(
+	ctx context.Context,
+	state *core.IndexedOperatorState,
+	batch *ConfirmedBatch,
+	blobIndex uint32,
+	blobHeader *core.BlobHeader,
+	quorumInfo *core.BlobQuorumInfo,
+) error {
+	assignments, info, err := s.assignmentCoordinator.GetAssignments(state.OperatorState, blobHeader.Length, quorumInfo)
+	if err != nil {
+		return fmt.Errorf("failed to get assignments: %w", err)
+	}
+	if info.TotalChunks == 0 {
+		return fmt.Errorf("no chunks assigned")
+	}
+	encodingParams := encoding.ParamsFromMins(quorumInfo.ChunkLength, info.TotalChunks)
+
+	sampledOperators := make(map[core.OperatorID]core.Assignment)
+	for i := 0; i < s.samplesPerQuorum; i++ {
+		index := core.ChunkNumber(rand.Intn(int(info.TotalChunks)))
+		for opID, assignment := range assignments {
+			if assignment.StartIndex <= index && index < assignment.StartIndex+assignment.NumChunks {
+				sampledOperators[opID] = assignment
+				break
+			}
+		}
+	}
+
+	// The requests still in flight when an invalid reply is received are cancelled
+	ctx, cancel := context.WithCancel(ctx)
+	chunksChan := make(chan clients.RetrievedChunks, len(sampledOperators))
+	pool := workerpool.New(s.numConnections)
+	defer func() {
+		cancel()
+		pool.StopWait()
+	}()
+	for opID := range sampledOperators {
+		opID := opID
+		opInfo, ok := state.IndexedOperators[opID]
+		if !ok {
+			chunksChan <- clients.RetrievedChunks{OperatorID: opID, Err: fmt.Errorf("operator has no socket")}
+			continue
+		}
+		pool.Submit(func() {
+			s.nodeClient.GetChunks(ctx, opID, opInfo, batch.BatchHeaderHash, blobIndex, quorumInfo.QuorumID, chunksChan)
+		})
+	}
+
+	for i := 0; i < len(sampledOperators); i++ {
+		reply := <-chunksChan
+		if reply.Err != nil {
+			return fmt.Errorf("operator %s failed to serve chunks: %w", reply.OperatorID.Hex(), reply.Err)
+		}
+		assignment := sampledOperators[reply.OperatorID]
+		if len(reply.Chunks) != int(assignment.NumChunks) {
+			return fmt.Errorf("operator %s served %d chunks, %d are assigned", reply.OperatorID.Hex(), len(reply.Chunks), assignment.NumChunks)
+		}
+		err = s.verifier.VerifyFrames(reply.Chunks, assignment.GetIndices(), blobHeader.BlobCommitments, encodingParams)
+		if err != nil {
+			return fmt.Errorf("operator %s served invalid chunks: %w", reply.OperatorID.Hex(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package lightnode_test
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/mock"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/Layr-Labs/eigenda/lightnode"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/go-merkletree/v2"
+	"github.com/wealdtech/go-merkletree/v2/keccak256"
+)
+
+const numOperators = 10
+
+var gettysburgAddressBytes = []byte("Fourscore and seven years ago our fathers brought forth, on this continent, a new nation, conceived in liberty, and dedicated to the proposition that all men are created equal.")
+
+type testBatch struct {
+	batch          *lightnode.ConfirmedBatch
+	blobHeader     *core.BlobHeader
+	blobHeaderHash [32]byte
+	proof          *merkletree.Proof
+	encodedBlob    core.EncodedBlob
+	chainState     *coremock.ChainDataMock
+	verifier       encoding.Verifier
+}
+
+// makeTestBatch encodes a batch holding a single blob dispersed to quorum 0.
+func makeTestBatch(t *testing.T) *testBatch {
+	config := &kzg.KzgConfig{
+		G1Path:          "../inabox/resources/kzg/g1.point",
+		G2Path:          "../inabox/resources/kzg/g2.point",
+		CacheDir:        "../inabox/resources/kzg/SRSTables",
+		SRSOrder:        3000,
+		SRSNumberToLoad: 3000,
+		NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+	}
+	p, err := prover.NewProver(config, true)
+	require.NoError(t, err)
+	v, err := verifier.NewVerifier(config, true)
+	require.NoError(t, err)
+
+	chainState, err := coremock.MakeChainDataMock(map[uint8]int{
+		0: numOperators,
+	})
+	require.NoError(t, err)
+	operatorState, err := chainState.GetOperatorState(context.Background(), 0, []core.QuorumID{0})
+	require.NoError(t, err)
+
+	data := codec.ConvertByPaddingEmptyByte(gettysburgAddressBytes)
+	blobLength := encoding.GetBlobLength(uint(len(data)))
+	securityParam := &core.SecurityParam{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 90,
+	}
+	coordinator := &core.StdAssignmentCoordinator{}
+	chunkLength, err := coordinator.CalculateChunkLength(operatorState, blobLength, 0, securityParam)
+	require.NoError(t, err)
+	quorumInfo := &core.BlobQuorumInfo{
+		SecurityParam: *securityParam,
+		ChunkLength:   chunkLength,
+	}
+	assignments, info, err := coordinator.GetAssignments(operatorState, blobLength, quorumInfo)
+	require.NoError(t, err)
+
+	commitments, chunks, err := p.EncodeAndProve(data, encoding.ParamsFromMins(chunkLength, info.TotalChunks))
+	require.NoError(t, err)
+
+	blobHeader := &core.BlobHeader{
+		BlobCommitments: commitments,
+		QuorumInfos:     []*core.BlobQuorumInfo{quorumInfo},
+	}
+	blobHeaderHash, err := blobHeader.GetBlobHeaderHash()
+	require.NoError(t, err)
+	tree, err := merkletree.NewTree(merkletree.WithData([][]byte{blobHeaderHash[:]}), merkletree.WithHashType(keccak256.New()))
+	require.NoError(t, err)
+	proof, err := tree.GenerateProof(blobHeaderHash[:], 0)
+	require.NoError(t, err)
+
+	encodedBlob := core.EncodedBlob{
+		BlobHeader:               blobHeader,
+		EncodedBundlesByOperator: make(map[core.OperatorID]core.EncodedBundles),
+	}
+	for id, assignment := range assignments {
+		bundles := core.Bundles{
+			0: chunks[assignment.StartIndex : assignment.StartIndex+assignment.NumChunks],
+		}
+		encodedBundles, err := bundles.ToEncodedBundles()
+		require.NoError(t, err)
+		encodedBlob.EncodedBundlesByOperator[id] = encodedBundles
+	}
+
+	batch := &lightnode.ConfirmedBatch{
+		BatchHeaderHash:      [32]byte{1, 2, 3},
+		ReferenceBlockNumber: 0,
+		QuorumNumbers:        []core.QuorumID{0},
+	}
+	copy(batch.BatchRoot[:], tree.Root())
+
+	return &testBatch{
+		batch:          batch,
+		blobHeader:     blobHeader,
+		blobHeaderHash: blobHeaderHash,
+		proof:          proof,
+		encodedBlob:    encodedBlob,
+		chainState:     chainState,
+		verifier:       v,
+	}
+}
+
+func newTestSampler(tb *testBatch, nodeClient *clientsmock.MockNodeClient) *lightnode.Sampler {
+	// Enough samples for every operator to be sampled with overwhelming probability
+	return lightnode.NewSampler(logging.NewNoopLogger(), tb.chainState, &core.StdAssignmentCoordinator{}, nodeClient, tb.verifier, 200, 4)
+}
+
+func TestSampleBatch(t *testing.T) {
+	tb := makeTestBatch(t)
+
+	nodeClient := clientsmock.NewNodeClient()
+	nodeClient.On("GetBlobHeader", mock.Anything, tb.batch.BatchHeaderHash, uint32(0)).Return(tb.blobHeader, tb.proof.Hashes, tb.proof.Index, nil)
+	nodeClient.On("GetBlobHeader", mock.Anything, tb.batch.BatchHeaderHash, uint32(1)).Return((*core.BlobHeader)(nil), nil, nil, errors.New("blob not found"))
+	nodeClient.On("GetChunks", mock.Anything, mock.Anything, tb.batch.BatchHeaderHash, uint32(0)).Return(tb.encodedBlob)
+
+	verdicts, err := newTestSampler(tb, nodeClient).SampleBatch(context.Background(), tb.batch)
+	require.NoError(t, err)
+	require.Len(t, verdicts, 1)
+	assert.Equal(t, tb.blobHeaderHash, verdicts[0].BlobHeaderHash)
+	assert.Equal(t, tb.batch.BatchHeaderHash, verdicts[0].BatchHeaderHash)
+	assert.Equal(t, uint32(0), verdicts[0].BlobIndex)
+	assert.True(t, verdicts[0].Available)
+}
+
+func TestSampleBatchInvalidChunks(t *testing.T) {
+	tb := makeTestBatch(t)
+
+	// Every operator serves the chunks of another operator, so that any sample fails verification
+	operatorIDs := make([]core.OperatorID, 0, len(tb.encodedBlob.EncodedBundlesByOperator))
+	for id := range tb.encodedBlob.EncodedBundlesByOperator {
+		operatorIDs = append(operatorIDs, id)
+	}
+	tampered := make(map[core.OperatorID]core.EncodedBundles, len(operatorIDs))
+	for i, id := range operatorIDs {
+		tampered[id] = tb.encodedBlob.EncodedBundlesByOperator[operatorIDs[(i+1)%len(operatorIDs)]]
+	}
+	tb.encodedBlob.EncodedBundlesByOperator = tampered
+
+	nodeClient := clientsmock.NewNodeClient()
+	nodeClient.On("GetBlobHeader", mock.Anything, tb.batch.BatchHeaderHash, uint32(0)).Return(tb.blobHeader, tb.proof.Hashes, tb.proof.Index, nil)
+	nodeClient.On("GetBlobHeader", mock.Anything, tb.batch.BatchHeaderHash, uint32(1)).Return((*core.BlobHeader)(nil), nil, nil, errors.New("blob not found"))
+	nodeClient.On("GetChunks", mock.Anything, mock.Anything, tb.batch.BatchHeaderHash, uint32(0)).Return(tb.encodedBlob)
+
+	verdicts, err := newTestSampler(tb, nodeClient).SampleBatch(context.Background(), tb.batch)
+	require.NoError(t, err)
+	require.Len(t, verdicts, 1)
+	assert.False(t, verdicts[0].Available)
+}
+
+func TestSampleBatchInvalidBlobHeader(t *testing.T) {
+	tb := makeTestBatch(t)
+
+	// The proof doesn't include the blob header in the batch
+	nodeClient := clientsmock.NewNodeClient()
+	nodeClient.On("GetBlobHeader", mock.Anything, tb.batch.BatchHeaderHash, uint32(0)).Return(tb.blobHeader, [][]byte{{1}}, uint64(0), nil)
+	nodeClient.On("GetBlobHeader", mock.Anything, tb.batch.BatchHeaderHash, mock.Anything).Return((*core.BlobHeader)(nil), nil, nil, errors.New("blob not found"))
+
+	verdicts, err := newTestSampler(tb, nodeClient).SampleBatch(context.Background(), tb.batch)
+	require.NoError(t, err)
+	require.Len(t, verdicts, 1)
+	assert.Equal(t, [32]byte{}, verdicts[0].BlobHeaderHash)
+	assert.Equal(t, uint32(0), verdicts[0].BlobIndex)
+	assert.False(t, verdicts[0].Available)
+}
+
+func TestSampleBatchWithheldBlobHeader(t *testing.T) {
+	tb := makeTestBatch(t)
+
+	// The batch holds two blobs, and the header of the first one is withheld
+	tree, err := merkletree.NewTree(merkletree.WithData([][]byte{{1}, tb.blobHeaderHash[:]}), merkletree.WithHashType(keccak256.New()))
+	require.NoError(t, err)
+	proof, err := tree.GenerateProof(tb.blobHeaderHash[:], 0)
+	require.NoError(t, err)
+	copy(tb.batch.BatchRoot[:], tree.Root())
+
+	nodeClient := clientsmock.NewNodeClient()
+	nodeClient.On("GetBlobHeader", mock.Anything, tb.batch.BatchHeaderHash, uint32(0)).Return((*core.BlobHeader)(nil), nil, nil, errors.New("blob not found"))
+	nodeClient.On("GetBlobHeader", mock.Anything, tb.batch.BatchHeaderHash, uint32(1)).Return(tb.blobHeader, proof.Hashes, proof.Index, nil)
+	nodeClient.On("GetChunks", mock.Anything, mock.Anything, tb.batch.BatchHeaderHash, uint32(1)).Return(tb.encodedBlob)
+
+	verdicts, err := newTestSampler(tb, nodeClient).SampleBatch(context.Background(), tb.batch)
+	require.NoError(t, err)
+	require.Len(t, verdicts, 2)
+	assert.Equal(t, uint32(0), verdicts[0].BlobIndex)
+	assert.Equal(t, [32]byte{}, verdicts[0].BlobHeaderHash)
+	assert.False(t, verdicts[0].Available)
+	assert.Equal(t, uint32(1), verdicts[1].BlobIndex)
+	assert.Equal(t, tb.blobHeaderHash, verdicts[1].BlobHeaderHash)
+	assert.True(t, verdicts[1].Available)
+}
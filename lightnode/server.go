@@ -0,0 +1,108 @@
+package lightnode
+
+import (
+	"crypto/subtle"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/lightnode"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// subscriberBufferSize is the number of verdicts buffered for a subscriber. Verdicts published while the buffer of a
+// subscriber is full are dropped for that subscriber.
+const subscriberBufferSize = 1024
+
+// Server implements the LightNode gRPC service. It streams the availability verdicts of the blobs sampled by the light
+// node to its subscribers.
+type Server struct {
+	pb.UnimplementedLightNodeServer
+
+	config  *Config
+	logger  logging.Logger
+	metrics *Metrics
+
+	mu           sync.Mutex
+	subscribers  map[uint64]chan *BlobAvailability
+	nextSubscrID uint64
+}
+
+func NewServer(config *Config, logger logging.Logger, metrics *Metrics) *Server {
+	return &Server{
+		config:      config,
+		logger:      logger.With("component", "LightNodeServer"),
+		metrics:     metrics,
+		subscribers: make(map[uint64]chan *BlobAvailability),
+	}
+}
+
+// Publish sends the verdict to all the subscribers, whether the blob was found to be available or not.
+func (s *Server) Publish(verdict *BlobAvailability) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, ch := range s.subscribers {
+		select {
+		case ch <- verdict:
+		default:
+			s.logger.Warn("subscriber is too slow, dropping blob availability", "subscriber", id, "blobHeaderHash", verdict.BlobHeaderHash)
+		}
+	}
+}
+
+func (s *Server) StreamBlobAvailability(request *pb.StreamChunkAvailabilityRequest, stream pb.LightNode_StreamBlobAvailabilityServer) error {
+	if s.config.AuthenticationToken != "" &&
+		subtle.ConstantTimeCompare(request.GetAuthenticationToken(), []byte(s.config.AuthenticationToken)) != 1 {
+		return api.NewErrorUnauthenticated("invalid authentication token")
+	}
+
+	id, ch := s.subscribe()
+	defer s.unsubscribe(id)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case verdict := <-ch:
+			err := stream.Send(newStreamChunkAvailabilityReply(verdict))
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func newStreamChunkAvailabilityReply(verdict *BlobAvailability) *pb.StreamChunkAvailabilityReply {
+	reply := &pb.StreamChunkAvailabilityReply{
+		BatchHeaderHash: verdict.BatchHeaderHash[:],
+		BlobIndex:       verdict.BlobIndex,
+		Available:       verdict.Available,
+	}
+	if verdict.Available {
+		reply.HeaderHash = verdict.BlobHeaderHash[:]
+	} else if verdict.BlobHeaderHash != [32]byte{} {
+		reply.UnavailableHeaderHash = verdict.BlobHeaderHash[:]
+	}
+	return reply
+}
+
+func (s *Server) subscribe() (uint64, chan *BlobAvailability) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := s.nextSubscrID
+	s.nextSubscrID++
+	ch := make(chan *BlobAvailability, subscriberBufferSize)
+	s.subscribers[id] = ch
+	if s.metrics != nil {
+		s.metrics.Subscribers.Set(float64(len(s.subscribers)))
+	}
+	return id, ch
+}
+
+func (s *Server) unsubscribe(id uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscribers, id)
+	if s.metrics != nil {
+		s.metrics.Subscribers.Set(float64(len(s.subscribers)))
+	}
+}
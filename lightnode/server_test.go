@@ -0,0 +1,119 @@
+package lightnode_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/lightnode"
+	"github.com/Layr-Labs/eigenda/lightnode"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockAvailabilityStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	replies chan *pb.StreamChunkAvailabilityReply
+}
+
+func (s *mockAvailabilityStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockAvailabilityStream) Send(reply *pb.StreamChunkAvailabilityReply) error {
+	s.replies <- reply
+	return nil
+}
+
+// startStream opens a blob availability stream on the server, and returns the replies received by the subscriber
+// and the error the stream ended with.
+func startStream(t *testing.T, server *lightnode.Server, token []byte) (chan *pb.StreamChunkAvailabilityReply, chan error, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &mockAvailabilityStream{
+		ctx:     ctx,
+		replies: make(chan *pb.StreamChunkAvailabilityReply, 10),
+	}
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- server.StreamBlobAvailability(&pb.StreamChunkAvailabilityRequest{AuthenticationToken: token}, stream)
+	}()
+	return stream.replies, errChan, cancel
+}
+
+// nextReply returns the next reply of the stream that isn't for the skipped verdict.
+func nextReply(t *testing.T, replies chan *pb.StreamChunkAvailabilityReply, skipped *lightnode.BlobAvailability) *pb.StreamChunkAvailabilityReply {
+	for {
+		select {
+		case reply := <-replies:
+			if !bytes.Equal(reply.GetBatchHeaderHash(), skipped.BatchHeaderHash[:]) {
+				return reply
+			}
+		case <-time.After(time.Second):
+			t.Fatal("timed out waiting for blob availability")
+		}
+	}
+}
+
+func TestStreamBlobAvailability(t *testing.T) {
+	server := lightnode.NewServer(&lightnode.Config{AuthenticationToken: "secret"}, logging.NewNoopLogger(), nil)
+
+	replies1, errChan1, cancel1 := startStream(t, server, []byte("secret"))
+	replies2, errChan2, cancel2 := startStream(t, server, []byte("secret"))
+
+	// Wait for both subscribers to be registered
+	registered := &lightnode.BlobAvailability{BlobHeaderHash: [32]byte{1}, BatchHeaderHash: [32]byte{1}, Available: true}
+	require.Eventually(t, func() bool {
+		server.Publish(registered)
+		return len(replies1) > 0 && len(replies2) > 0
+	}, time.Second, 10*time.Millisecond)
+
+	available := &lightnode.BlobAvailability{BlobHeaderHash: [32]byte{1}, BatchHeaderHash: [32]byte{3}, BlobIndex: 0, Available: true}
+	unavailable := &lightnode.BlobAvailability{BlobHeaderHash: [32]byte{2}, BatchHeaderHash: [32]byte{3}, BlobIndex: 1, Available: false}
+	// The header of a withheld blob is unknown
+	withheld := &lightnode.BlobAvailability{BatchHeaderHash: [32]byte{3}, BlobIndex: 2, Available: false}
+
+	// Verdicts of unavailable blobs are streamed too, without the header hash of an available blob
+	server.Publish(unavailable)
+	server.Publish(withheld)
+	server.Publish(available)
+	for _, replies := range []chan *pb.StreamChunkAvailabilityReply{replies1, replies2} {
+		for _, expected := range []*lightnode.BlobAvailability{unavailable, withheld, available} {
+			reply := nextReply(t, replies, registered)
+			assert.Equal(t, expected.Available, reply.GetAvailable())
+			assert.Equal(t, expected.BatchHeaderHash[:], reply.GetBatchHeaderHash())
+			assert.Equal(t, expected.BlobIndex, reply.GetBlobIndex())
+			if expected.Available {
+				assert.Equal(t, expected.BlobHeaderHash[:], reply.GetHeaderHash())
+				assert.Empty(t, reply.GetUnavailableHeaderHash())
+			} else {
+				assert.Empty(t, reply.GetHeaderHash())
+			}
+			if expected == unavailable {
+				assert.Equal(t, expected.BlobHeaderHash[:], reply.GetUnavailableHeaderHash())
+			}
+			if expected == withheld {
+				assert.Empty(t, reply.GetUnavailableHeaderHash())
+			}
+		}
+	}
+
+	cancel1()
+	cancel2()
+	assert.NoError(t, <-errChan1)
+	assert.NoError(t, <-errChan2)
+}
+
+func TestStreamBlobAvailabilityUnauthenticated(t *testing.T) {
+	server := lightnode.NewServer(&lightnode.Config{AuthenticationToken: "secret"}, logging.NewNoopLogger(), nil)
+
+	_, errChan, cancel := startStream(t, server, []byte("wrong"))
+	defer cancel()
+	err := <-errChan
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
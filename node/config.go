@@ -10,7 +10,9 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/node/flags"
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
@@ -74,6 +76,9 @@ type Config struct {
 	UseSecureGrpc                  bool
 	ReachabilityPollIntervalSec    uint64
 	DisableNodeInfoResources       bool
+	EnableV2                       bool
+	RelaySockets                   map[corev2.RelayKey]string
+	V2DbBackend                    tablestore.StoreType
 
 	EthClientConfig geth.EthClientConfig
 	LoggerConfig    common.LoggerConfig
@@ -164,6 +169,20 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		internalRetrievalFlag = ctx.GlobalString(flags.RetrievalPortFlag.Name)
 	}
 
+	enableV2 := ctx.GlobalBool(flags.EnableV2Flag.Name)
+	relaySockets := make(map[corev2.RelayKey]string)
+	for i, socket := range ctx.GlobalStringSlice(flags.RelaySocketsFlag.Name) {
+		relaySockets[corev2.RelayKey(i)] = socket
+	}
+	if enableV2 && len(relaySockets) == 0 {
+		return nil, fmt.Errorf("%s is required if %s is enabled", flags.RelaySocketsFlag.Name, flags.EnableV2Flag.Name)
+	}
+
+	v2DbBackend, err := tablestore.ParseStoreType(ctx.GlobalString(flags.V2DbBackendFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", flags.V2DbBackendFlag.Name, err)
+	}
+
 	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
 	if err != nil {
 		return nil, err
@@ -205,5 +224,8 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		ClientIPHeader:                 ctx.GlobalString(flags.ClientIPHeaderFlag.Name),
 		UseSecureGrpc:                  ctx.GlobalBoolT(flags.ChurnerUseSecureGRPC.Name),
 		DisableNodeInfoResources:       ctx.GlobalBool(flags.DisableNodeInfoResourcesFlag.Name),
+		EnableV2:                       enableV2,
+		RelaySockets:                   relaySockets,
+		V2DbBackend:                    v2DbBackend,
 	}, nil
 }
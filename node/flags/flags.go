@@ -272,6 +272,25 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISABLE_NODE_INFO_RESOURCES"),
 	}
+	EnableV2Flag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-v2"),
+		Usage:    "Enable the V2 dispersal and retrieval APIs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_V2"),
+	}
+	RelaySocketsFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "relay-sockets"),
+		Usage:    "Sockets (host:port) of the relays to fetch V2 chunks from. The relay key of each relay is its index in this list",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RELAY_SOCKETS"),
+	}
+	V2DbBackendFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "v2-db-backend"),
+		Usage:    "Storage engine of the V2 chunk database, either leveldb or pebble",
+		Required: false,
+		Value:    "leveldb",
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "V2_DB_BACKEND"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -314,6 +333,9 @@ var optionalFlags = []cli.Flag{
 	DataApiUrlFlag,
 	DisableNodeInfoResourcesFlag,
 	EnableGnarkBundleEncodingFlag,
+	EnableV2Flag,
+	RelaySocketsFlag,
+	V2DbBackendFlag,
 }
 
 func init() {
@@ -2,12 +2,19 @@ package grpc
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"runtime"
 	"sync"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/node/v2"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/shirou/gopsutil/mem"
@@ -52,10 +59,158 @@ func (s *ServerV2) NodeInfo(ctx context.Context, in *pb.NodeInfoRequest) (*pb.No
 	return &pb.NodeInfoReply{Semver: node.SemVer, Os: runtime.GOOS, Arch: runtime.GOARCH, NumCpu: uint32(runtime.GOMAXPROCS(0)), MemBytes: memBytes}, nil
 }
 
-func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*pb.StoreChunksReply, error) {
-	return &pb.StoreChunksReply{}, api.NewErrorUnimplemented()
+func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (reply *pb.StoreChunksReply, err error) {
+	start := time.Now()
+	defer func() {
+		if err != nil {
+			s.node.Metrics.RecordRPCRequest("v2/StoreChunks", "failure", time.Since(start))
+			s.logger.Error("StoreChunks RPC failed", "duration", time.Since(start), "err", err)
+		} else {
+			s.node.Metrics.RecordRPCRequest("v2/StoreChunks", "success", time.Since(start))
+			s.logger.Info("StoreChunks RPC succeeded", "duration", time.Since(start))
+		}
+	}()
+
+	batch, err := s.validateStoreChunksRequest(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.handleStoreChunksRequest(ctx, batch)
+}
+
+// validateStoreChunksRequest checks that the request is well formed and converts it into a batch.
+func (s *ServerV2) validateStoreChunksRequest(in *pb.StoreChunksRequest) (*corev2.Batch, error) {
+	if in.GetBatch() == nil {
+		return nil, api.NewErrorInvalidArg("missing batch in request")
+	}
+	if in.GetBatch().GetHeader() == nil {
+		return nil, api.NewErrorInvalidArg("missing batch header in request")
+	}
+	if in.GetBatch().GetHeader().GetReferenceBlockNumber() == 0 {
+		return nil, api.NewErrorInvalidArg("missing reference_block_number in request")
+	}
+	if len(in.GetBatch().GetBlobCertificates()) == 0 {
+		return nil, api.NewErrorInvalidArg("missing blob certificates in request")
+	}
+
+	batch, err := corev2.BatchFromProtobuf(in.GetBatch())
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to deserialize batch: %v", err))
+	}
+
+	for _, cert := range batch.BlobCertificates {
+		if len(cert.BlobHeader.QuorumNumbers) == 0 {
+			return nil, api.NewErrorInvalidArg("missing quorum numbers in blob header")
+		}
+		if len(cert.RelayKeys) == 0 {
+			return nil, api.NewErrorInvalidArg("missing relay keys in blob certificate")
+		}
+	}
+
+	return batch, nil
 }
 
-func (s *ServerV2) GetChunks(context.Context, *pb.GetChunksRequest) (*pb.GetChunksReply, error) {
-	return &pb.GetChunksReply{}, api.NewErrorUnimplemented()
+// handleStoreChunksRequest downloads the operator's chunks of the batch from the relays, validates and stores them,
+// and signs the batch header hash.
+//
+// Notes:
+//   - If the batch is stored already, it's no-op to store it more than once
+//   - If the batch fails validation after it has been stored, the data written for it is rolled back. Bundles that
+//     were stored by earlier batches are left untouched.
+func (s *ServerV2) handleStoreChunksRequest(ctx context.Context, batch *corev2.Batch) (*pb.StoreChunksReply, error) {
+	if !s.config.EnableV2 || s.node.StoreV2 == nil {
+		return nil, api.NewErrorInternal("v2 API is disabled")
+	}
+
+	batchHeaderHash, err := batch.BatchHeader.Hash()
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid batch header: %v", err))
+	}
+
+	operatorState, err := s.node.ChainState.GetOperatorStateByOperator(ctx, uint(batch.BatchHeader.ReferenceBlockNumber), s.config.ID)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get the operator state: %v", err))
+	}
+
+	stageTimer := time.Now()
+	blobShards, rawBundles, err := s.node.DownloadBundles(ctx, batch, operatorState)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to download bundles: %v", err))
+	}
+	s.logger.Debug("Downloaded bundles from relays", "duration", time.Since(stageTimer))
+
+	// Store the batch concurrently with the validation, since storing is IO bound.
+	type storeResult struct {
+		keys []kvstore.Key
+		err  error
+	}
+	storeChan := make(chan storeResult)
+	go func() {
+		keys, err := s.node.StoreV2.StoreBatch(batch, rawBundles)
+		if err != nil {
+			if errors.Is(err, node.ErrBatchAlreadyExist) {
+				// The batch has been stored already, which is not an error.
+				storeChan <- storeResult{keys: nil, err: nil}
+				return
+			}
+			storeChan <- storeResult{keys: nil, err: fmt.Errorf("failed to store batch: %v", err)}
+			return
+		}
+		storeChan <- storeResult{keys: keys, err: nil}
+	}()
+
+	err = s.node.ValidateBatchV2(ctx, batch, blobShards, operatorState)
+	if err != nil {
+		res := <-storeChan
+		if len(res.keys) > 0 {
+			if deleteErr := s.node.StoreV2.DeleteKeys(res.keys); deleteErr != nil {
+				s.logger.Error("failed to delete keys of the invalid batch", "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]), "err", deleteErr)
+			}
+		}
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to validate batch: %v", err))
+	}
+
+	res := <-storeChan
+	if res.err != nil {
+		return nil, api.NewErrorInternal(res.err.Error())
+	}
+
+	sig := s.node.KeyPair.SignMessage(batchHeaderHash)
+	return &pb.StoreChunksReply{
+		Signature: sig.Serialize(),
+	}, nil
+}
+
+// GetChunks returns the chunks of a blob in a quorum that were assigned to, and are stored by, this node.
+func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.GetChunksReply, error) {
+	start := time.Now()
+
+	if !s.config.EnableV2 || s.node.StoreV2 == nil {
+		return nil, api.NewErrorInternal("v2 API is disabled")
+	}
+
+	blobKey, err := corev2.BytesToBlobKey(in.GetBlobKey())
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid blob key: %v", err))
+	}
+
+	if in.GetQuorumId() > corev2.MaxQuorumID {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid quorum ID %d: quorum ID must be in range [0, %d]", in.GetQuorumId(), corev2.MaxQuorumID))
+	}
+	quorumID := core.QuorumID(in.GetQuorumId())
+
+	chunks, err := s.node.StoreV2.GetChunks(blobKey, quorumID)
+	if err != nil {
+		s.node.Metrics.RecordRPCRequest("v2/GetChunks", "failure", time.Since(start))
+		if errors.Is(err, kvstore.ErrNotFound) {
+			return nil, api.NewErrorNotFound(fmt.Sprintf("could not find chunks for blob %s in quorum %d", blobKey.Hex(), quorumID))
+		}
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get chunks for blob %s in quorum %d: %v", blobKey.Hex(), quorumID, err))
+	}
+
+	s.node.Metrics.RecordRPCRequest("v2/GetChunks", "success", time.Since(start))
+	return &pb.GetChunksReply{
+		Chunks: chunks,
+	}, nil
 }
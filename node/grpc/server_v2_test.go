@@ -2,29 +2,45 @@ package grpc_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"testing"
+	"time"
 
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/mock"
 	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
 	pbv2 "github.com/Layr-Labs/eigenda/api/grpc/node/v2"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 	commonmock "github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/core"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	v2mock "github.com/Layr-Labs/eigenda/core/mock/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigenda/node/grpc"
 	"github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-func newTestServerV2(t *testing.T, mockValidator bool) *grpc.ServerV2 {
-	return newTestServerV2WithConfig(t, mockValidator, makeConfig(t))
+type testComponents struct {
+	server      *grpc.ServerV2
+	node        *node.Node
+	store       kvstore.TableStore
+	validator   *v2mock.MockShardValidator
+	relayClient *clientsmock.MockRelayClient
 }
 
-func newTestServerV2WithConfig(t *testing.T, mockValidator bool, config *node.Config) *grpc.ServerV2 {
+func newTestComponents(t *testing.T, config *node.Config) *testComponents {
 	var err error
 	keyPair, err = core.GenRandomBlsKeys()
 	if err != nil {
@@ -48,72 +64,304 @@ func newTestServerV2WithConfig(t *testing.T, mockValidator bool, config *node.Co
 
 	ratelimiter := &commonmock.NoopRatelimiter{}
 
-	var val core.ShardValidator
+	val := coremock.NewMockShardValidator()
+	val.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	val.On("ValidateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	if mockValidator {
-		mockVal := coremock.NewMockShardValidator()
-		mockVal.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-		mockVal.On("ValidateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-		val = mockVal
-	} else {
+	metrics := node.NewMetrics(noopMetrics, reg, logger, ":9090", opID, -1, tx, chainState)
+	store, err := node.NewLevelDBStore(config.DbPath, logger, metrics, 1e9, 1e9)
+	if err != nil {
+		panic("failed to create a new levelDB store")
+	}
 
-		_, v, err := makeTestComponents()
-		if err != nil {
-			panic("failed to create test encoder")
-		}
+	tableStoreConfig := tablestore.DefaultMapStoreConfig()
+	tableStoreConfig.Schema = []string{node.BatchHeaderTableName, node.BundleTableName}
+	db, err := tablestore.Start(logger, tableStoreConfig)
+	require.NoError(t, err)
+	storeV2, err := node.NewLevelDBStoreV2(db, logger, time.Hour)
+	require.NoError(t, err)
 
-		asn := &core.StdAssignmentCoordinator{}
+	valV2 := v2mock.NewMockShardValidator()
+	relayClient := clientsmock.NewRelayClient()
 
-		cst, err := coremock.MakeChainDataMock(map[uint8]int{
-			0: 10,
-			1: 10,
-			2: 10,
-		})
-		if err != nil {
-			panic("failed to create test encoder")
-		}
+	node := &node.Node{
+		Config:      config,
+		Logger:      logger,
+		KeyPair:     keyPair,
+		Metrics:     metrics,
+		Store:       store,
+		StoreV2:     storeV2,
+		ChainState:  chainState,
+		Validator:   val,
+		ValidatorV2: valV2,
+		RelayClient: relayClient,
+	}
+	return &testComponents{
+		server:      grpc.NewServerV2(config, node, logger, ratelimiter),
+		node:        node,
+		store:       db,
+		validator:   valV2,
+		relayClient: relayClient,
+	}
+}
 
-		val = core.NewShardValidator(v, asn, cst, opID)
+func newTestServerV2(t *testing.T) *grpc.ServerV2 {
+	return newTestComponents(t, makeConfig(t)).server
+}
+
+// makeV2TestConfig returns a config for an operator that is registered in all quorums of the mock chain state.
+func makeV2TestConfig(t *testing.T) *node.Config {
+	config := makeConfig(t)
+	config.ID = coremock.MakeOperatorId(0)
+	config.EnableV2 = true
+	return config
+}
+
+func makeV2TestBatch(t *testing.T, numBlobs int, quorums []core.QuorumID) *corev2.Batch {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	certs := make([]*corev2.BlobCertificate, numBlobs)
+	for i := range certs {
+		certs[i] = &corev2.BlobCertificate{
+			BlobHeader: &corev2.BlobHeader{
+				BlobVersion: 0,
+				BlobCommitments: encoding.BlobCommitments{
+					Commitment:       (*encoding.G1Commitment)(&g1Gen),
+					LengthCommitment: (*encoding.G2Commitment)(&g2Gen),
+					LengthProof:      (*encoding.G2Commitment)(&g2Gen),
+					Length:           uint(16 * (i + 1)),
+				},
+				QuorumNumbers: quorums,
+				PaymentMetadata: core.PaymentMetadata{
+					AccountID:         "0x123",
+					BinIndex:          uint32(i),
+					CumulativePayment: big.NewInt(100),
+				},
+				Signature: []byte{1, 2, 3},
+			},
+			RelayKeys: []corev2.RelayKey{0},
+		}
 	}
+	tree, err := corev2.BuildMerkleTree(certs)
+	require.NoError(t, err)
+	var batchRoot [32]byte
+	copy(batchRoot[:], tree.Root())
 
-	metrics := node.NewMetrics(noopMetrics, reg, logger, ":9090", opID, -1, tx, chainState)
-	store, err := node.NewLevelDBStore(config.DbPath, logger, metrics, 1e9, 1e9)
-	if err != nil {
-		panic("failed to create a new levelDB store")
+	return &corev2.Batch{
+		BatchHeader: &corev2.BatchHeader{
+			BatchRoot:            batchRoot,
+			ReferenceBlockNumber: 100,
+		},
+		BlobCertificates: certs,
 	}
+}
 
-	node := &node.Node{
-		Config:     config,
-		Logger:     logger,
-		KeyPair:    keyPair,
-		Metrics:    metrics,
-		Store:      store,
-		ChainState: chainState,
-		Validator:  val,
+func makeTestBundle(t *testing.T) []byte {
+	_, _, g1Gen, _ := bn254.Generators()
+	frame := &encoding.Frame{
+		Proof:  g1Gen,
+		Coeffs: []fr.Element{fr.NewElement(1), fr.NewElement(2)},
 	}
-	return grpc.NewServerV2(config, node, logger, ratelimiter)
+	bundle, err := core.Bundle{frame}.Serialize()
+	require.NoError(t, err)
+	return bundle
 }
 
 func TestV2NodeInfoRequest(t *testing.T) {
-	server := newTestServerV2(t, true)
+	server := newTestServerV2(t)
 	resp, err := server.NodeInfo(context.Background(), &pbv2.NodeInfoRequest{})
 	assert.True(t, resp.Semver == "0.0.0")
 	assert.True(t, err == nil)
 }
 
-func TestV2StoreChunks(t *testing.T) {
-	server := newTestServerV2(t, true)
-	_, err := server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
-		Batch: &commonpb.Batch{},
+func TestV2StoreChunksInputValidation(t *testing.T) {
+	server := newTestServerV2(t)
+	batch := makeV2TestBatch(t, 1, []core.QuorumID{0})
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+
+	_, err = server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{})
+	require.ErrorContains(t, err, "missing batch in request")
+
+	_, err = server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: &commonpb.Batch{
+			BlobCertificates: batchProto.GetBlobCertificates(),
+		},
+	})
+	require.ErrorContains(t, err, "missing batch header in request")
+
+	_, err = server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: &commonpb.Batch{
+			Header: &commonpb.BatchHeader{
+				BatchRoot: batchProto.GetHeader().GetBatchRoot(),
+			},
+			BlobCertificates: batchProto.GetBlobCertificates(),
+		},
+	})
+	require.ErrorContains(t, err, "missing reference_block_number in request")
+
+	_, err = server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: &commonpb.Batch{
+			Header: batchProto.GetHeader(),
+		},
+	})
+	require.ErrorContains(t, err, "missing blob certificates in request")
+
+	batchProto.BlobCertificates[0].Relays = nil
+	_, err = server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: batchProto,
 	})
-	assert.ErrorContains(t, err, "not implemented")
+	require.ErrorContains(t, err, "missing relay keys in blob certificate")
+}
+
+func TestV2StoreChunksSuccess(t *testing.T) {
+	c := newTestComponents(t, makeV2TestConfig(t))
+	batch := makeV2TestBatch(t, 2, []core.QuorumID{0, 1})
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+
+	// 2 blobs x 2 quorums, all fetched from relay 0
+	bundle := makeTestBundle(t)
+	c.relayClient.On("GetChunksByRange").Return([][]byte{bundle, bundle, bundle, bundle}, nil)
+	c.validator.On("ValidateBatchHeader", mock.Anything, mock.Anything).Return(nil)
+	c.validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	reply, err := c.server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: batchProto,
+	})
+	require.NoError(t, err)
+
+	batchHeaderHash, err := batch.BatchHeader.Hash()
+	require.NoError(t, err)
+	sig, err := new(core.Signature).Deserialize(reply.GetSignature())
+	require.NoError(t, err)
+	require.True(t, (&core.Signature{G1Point: sig}).Verify(keyPair.GetPubKeyG2(), batchHeaderHash))
+
+	bundleKeyBuilder, err := c.store.GetKeyBuilder(node.BundleTableName)
+	require.NoError(t, err)
+	for _, cert := range batch.BlobCertificates {
+		blobKey, err := cert.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		for _, quorum := range cert.BlobHeader.QuorumNumbers {
+			bundleKey, err := node.BundleKey(blobKey, quorum)
+			require.NoError(t, err)
+			stored, err := c.store.Get(bundleKeyBuilder.Key(bundleKey))
+			require.NoError(t, err)
+			require.Equal(t, bundle, stored)
+		}
+	}
+
+	// Storing the same batch again is a no-op
+	_, err = c.server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: batchProto,
+	})
+	require.NoError(t, err)
+}
+
+func TestV2StoreChunksValidationFailure(t *testing.T) {
+	c := newTestComponents(t, makeV2TestConfig(t))
+	batch := makeV2TestBatch(t, 1, []core.QuorumID{0})
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+
+	bundle := makeTestBundle(t)
+	c.relayClient.On("GetChunksByRange").Return([][]byte{bundle}, nil)
+	c.validator.On("ValidateBatchHeader", mock.Anything, mock.Anything).Return(nil)
+	c.validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("invalid chunks"))
+
+	_, err = c.server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: batchProto,
+	})
+	require.ErrorContains(t, err, "invalid chunks")
+
+	// The stored data must be rolled back
+	batchHeaderHash, err := batch.BatchHeader.Hash()
+	require.NoError(t, err)
+	batchHeaderKeyBuilder, err := c.store.GetKeyBuilder(node.BatchHeaderTableName)
+	require.NoError(t, err)
+	_, err = c.store.Get(batchHeaderKeyBuilder.Key(batchHeaderHash[:]))
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+}
+
+func TestV2StoreChunksRelayFailure(t *testing.T) {
+	c := newTestComponents(t, makeV2TestConfig(t))
+	batch := makeV2TestBatch(t, 1, []core.QuorumID{0})
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+
+	c.relayClient.On("GetChunksByRange").Return([][]byte{}, errors.New("relay unavailable"))
+
+	_, err = c.server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: batchProto,
+	})
+	require.ErrorContains(t, err, "relay unavailable")
 }
 
 func TestV2GetChunks(t *testing.T) {
-	server := newTestServerV2(t, true)
+	c := newTestComponents(t, makeV2TestConfig(t))
+	batch := makeV2TestBatch(t, 1, []core.QuorumID{0, 1})
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
 
-	_, err := server.GetChunks(context.Background(), &pbv2.GetChunksRequest{
+	bundle := makeTestBundle(t)
+	c.relayClient.On("GetChunksByRange").Return([][]byte{bundle, bundle}, nil)
+	c.validator.On("ValidateBatchHeader", mock.Anything, mock.Anything).Return(nil)
+	c.validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	_, err = c.server.StoreChunks(context.Background(), &pbv2.StoreChunksRequest{
+		Batch: batchProto,
+	})
+	require.NoError(t, err)
+
+	blobKey, err := batch.BlobCertificates[0].BlobHeader.BlobKey()
+	require.NoError(t, err)
+	reply, err := c.server.GetChunks(context.Background(), &pbv2.GetChunksRequest{
+		BlobKey:  blobKey[:],
+		QuorumId: 1,
+	})
+	require.NoError(t, err)
+	frames, err := new(core.Bundle).Deserialize(bundle)
+	require.NoError(t, err)
+	require.Len(t, reply.GetChunks(), len(frames))
+	for i, chunk := range reply.GetChunks() {
+		frame, err := new(encoding.Frame).DeserializeGnark(chunk)
+		require.NoError(t, err)
+		require.Equal(t, frames[i], frame)
+	}
+
+	// Quorum the blob was not dispersed to
+	_, err = c.server.GetChunks(context.Background(), &pbv2.GetChunksRequest{
+		BlobKey:  blobKey[:],
+		QuorumId: 2,
+	})
+	require.ErrorContains(t, err, "could not find chunks")
+
+	// Unknown blob
+	_, err = c.server.GetChunks(context.Background(), &pbv2.GetChunksRequest{
+		BlobKey:  make([]byte, 32),
+		QuorumId: 0,
+	})
+	require.ErrorContains(t, err, "could not find chunks")
+}
+
+func TestV2GetChunksInputValidation(t *testing.T) {
+	c := newTestComponents(t, makeV2TestConfig(t))
+
+	_, err := c.server.GetChunks(context.Background(), &pbv2.GetChunksRequest{
 		BlobKey: []byte{0},
 	})
-	assert.ErrorContains(t, err, "not implemented")
+	require.ErrorContains(t, err, "invalid blob key")
+
+	_, err = c.server.GetChunks(context.Background(), &pbv2.GetChunksRequest{
+		BlobKey:  make([]byte, 32),
+		QuorumId: 255,
+	})
+	require.ErrorContains(t, err, "invalid quorum ID")
+}
+
+func TestV2Disabled(t *testing.T) {
+	server := newTestServerV2(t)
+
+	_, err := server.GetChunks(context.Background(), &pbv2.GetChunksRequest{
+		BlobKey: make([]byte, 32),
+	})
+	require.ErrorContains(t, err, "v2 API is disabled")
 }
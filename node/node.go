@@ -22,11 +22,14 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/Layr-Labs/eigenda/api/clients"
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/indexer"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/Layr-Labs/eigensdk-go/metrics"
 	rpccalls "github.com/Layr-Labs/eigensdk-go/metrics/collectors/rpc_calls"
@@ -55,8 +58,11 @@ type Node struct {
 	Metrics                 *Metrics
 	NodeApi                 *nodeapi.NodeApi
 	Store                   *Store
+	StoreV2                 StoreV2
 	ChainState              core.ChainState
 	Validator               core.ShardValidator
+	ValidatorV2             corev2.ShardValidator
+	RelayClient             clients.RelayClient
 	Transactor              core.Writer
 	PubIPProvider           pubip.Provider
 	OperatorSocketsFilterer indexer.OperatorSocketsFilterer
@@ -149,6 +155,47 @@ func NewNode(reg *prometheus.Registry, config *Config, pubIPProvider pubip.Provi
 		return nil, fmt.Errorf("failed to create new store: %w", err)
 	}
 
+	var storeV2 StoreV2
+	var validatorV2 corev2.ShardValidator
+	var relayClient clients.RelayClient
+	if config.EnableV2 {
+		// The v2 data has the same lifecycle as the v1 data, see Store.expirationTime.
+		ttl := time.Duration(blockStaleMeasure+storeDurationBlocks) * 12 * time.Second
+		dbPathV2 := config.DbPath + "/chunk_v2"
+		dbV2, err := tablestore.Start(logger, &tablestore.Config{
+			Type:                       config.V2DbBackend,
+			Path:                       &dbPathV2,
+			GarbageCollectionEnabled:   true,
+			GarbageCollectionInterval:  time.Duration(config.ExpirationPollIntervalSec) * time.Second,
+			GarbageCollectionBatchSize: 1024,
+			Schema:                     []string{BatchHeaderTableName, BundleTableName},
+			MetricsRegistry:            reg,
+			MetricsName:                "chunk_v2",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new v2 store: %w", err)
+		}
+		storeV2, err = NewLevelDBStoreV2(dbV2, logger, ttl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new v2 store: %w", err)
+		}
+
+		validatorV2 = corev2.NewShardValidator(v, cst, config.ID)
+
+		operatorID := config.ID
+		relayClient, err = clients.NewRelayClient(&clients.RelayClientConfig{
+			Sockets:           config.RelaySockets,
+			UseSecureGrpcFlag: config.UseSecureGrpc,
+			OperatorID:        &operatorID,
+			MessageSigner: func(ctx context.Context, data [32]byte) (*core.Signature, error) {
+				return keyPair.SignMessage(data), nil
+			},
+		}, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new relay client: %w", err)
+		}
+	}
+
 	eigenDAServiceManagerAddr := gethcommon.HexToAddress(config.EigenDAServiceManagerAddr)
 	socketsFilterer, err := indexer.NewOperatorSocketsFilterer(eigenDAServiceManagerAddr, client)
 	if err != nil {
@@ -167,9 +214,12 @@ func NewNode(reg *prometheus.Registry, config *Config, pubIPProvider pubip.Provi
 		Metrics:                 metrics,
 		NodeApi:                 nodeApi,
 		Store:                   store,
+		StoreV2:                 storeV2,
 		ChainState:              cst,
 		Transactor:              tx,
 		Validator:               validator,
+		ValidatorV2:             validatorV2,
+		RelayClient:             relayClient,
 		PubIPProvider:           pubIPProvider,
 		OperatorSocketsFilterer: socketsFilterer,
 		ChainID:                 chainID,
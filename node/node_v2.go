@@ -0,0 +1,163 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/gammazero/workerpool"
+)
+
+// RawBundles contains the serialized bundles (i.e. core.Bundle in its serialized form) of a blob, keyed by quorum.
+type RawBundles struct {
+	BlobCertificate *corev2.BlobCertificate
+	Bundles         map[core.QuorumID][]byte
+}
+
+// requestMetadata identifies the blob and quorum that a chunk request to a relay was made for.
+type requestMetadata struct {
+	blobShardIndex int
+	quorum         core.QuorumID
+}
+
+// DownloadBundles fetches the chunks assigned to this operator for every blob in the batch from the relays.
+// The chunks of each blob/quorum are requested from a randomly chosen relay among the ones that the blob
+// certificate lists. The returned blob shards and raw bundles are in the same order as the blob certificates.
+func (n *Node) DownloadBundles(ctx context.Context, batch *corev2.Batch, operatorState *core.OperatorState) ([]*corev2.BlobShard, []*RawBundles, error) {
+	if n.RelayClient == nil {
+		return nil, nil, errors.New("relay client is not set")
+	}
+
+	blobShards := make([]*corev2.BlobShard, len(batch.BlobCertificates))
+	rawBundles := make([]*RawBundles, len(batch.BlobCertificates))
+	requests := make(map[corev2.RelayKey][]*clients.ChunkRequestByRange)
+	metadata := make(map[corev2.RelayKey][]*requestMetadata)
+	for i, cert := range batch.BlobCertificates {
+		blobKey, err := cert.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get blob key: %v", err)
+		}
+
+		if len(cert.RelayKeys) == 0 {
+			return nil, nil, fmt.Errorf("no relay keys in the certificate of blob %s", blobKey.Hex())
+		}
+		blobShards[i] = &corev2.BlobShard{
+			BlobCertificate: *cert,
+			Chunks:          make(map[core.QuorumID][]*encoding.Frame),
+		}
+		rawBundles[i] = &RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+		}
+		relayIndex := rand.Intn(len(cert.RelayKeys))
+		relayKey := cert.RelayKeys[relayIndex]
+		for _, quorum := range cert.BlobHeader.QuorumNumbers {
+			if _, ok := operatorState.Operators[quorum][n.Config.ID]; !ok {
+				// The operator is not a member of the quorum, so there are no chunks to download.
+				continue
+			}
+			assignment, err := corev2.GetAssignment(operatorState, cert.BlobHeader.BlobVersion, quorum, n.Config.ID)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get assignment for blob %s in quorum %d: %v", blobKey.Hex(), quorum, err)
+			}
+			if assignment.NumChunks == 0 {
+				continue
+			}
+
+			requests[relayKey] = append(requests[relayKey], &clients.ChunkRequestByRange{
+				BlobKey: blobKey,
+				Start:   assignment.StartIndex,
+				End:     assignment.StartIndex + assignment.NumChunks,
+			})
+			metadata[relayKey] = append(metadata[relayKey], &requestMetadata{
+				blobShardIndex: i,
+				quorum:         quorum,
+			})
+		}
+	}
+
+	type response struct {
+		metadata []*requestMetadata
+		bundles  [][]byte
+		err      error
+	}
+
+	pool := workerpool.New(len(requests))
+	bundleChan := make(chan response, len(requests))
+	for relayKey := range requests {
+		relayKey := relayKey
+		req := requests[relayKey]
+		metadata := metadata[relayKey]
+		pool.Submit(func() {
+			bundles, err := n.RelayClient.GetChunksByRange(ctx, relayKey, req)
+			if err != nil {
+				n.Logger.Error("failed to get chunks from relays", "relayKey", relayKey, "err", err)
+				bundleChan <- response{
+					metadata: nil,
+					bundles:  nil,
+					err:      err,
+				}
+				return
+			}
+			bundleChan <- response{
+				metadata: metadata,
+				bundles:  bundles,
+				err:      nil,
+			}
+		})
+	}
+	pool.StopWait()
+
+	for i := 0; i < len(requests); i++ {
+		resp := <-bundleChan
+		if resp.err != nil {
+			return nil, nil, fmt.Errorf("failed to get chunks from relays: %v", resp.err)
+		}
+		if len(resp.bundles) != len(resp.metadata) {
+			return nil, nil, fmt.Errorf("number of bundles (%d) does not match number of requests (%d)", len(resp.bundles), len(resp.metadata))
+		}
+		for j, bundle := range resp.bundles {
+			metadata := resp.metadata[j]
+			frames, err := new(core.Bundle).Deserialize(bundle)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to deserialize bundle: %v", err)
+			}
+			blobShards[metadata.blobShardIndex].Chunks[metadata.quorum] = frames
+			rawBundles[metadata.blobShardIndex].Bundles[metadata.quorum] = bundle
+		}
+	}
+
+	return blobShards, rawBundles, nil
+}
+
+// ValidateBatchV2 validates the batch header against the blob certificates and the downloaded chunks against
+// the blob commitments.
+func (n *Node) ValidateBatchV2(
+	ctx context.Context,
+	batch *corev2.Batch,
+	blobShards []*corev2.BlobShard,
+	operatorState *core.OperatorState,
+) error {
+	if n.ValidatorV2 == nil {
+		return errors.New("v2 validator is not set")
+	}
+
+	start := time.Now()
+	if err := n.ValidatorV2.ValidateBatchHeader(ctx, batch.BatchHeader, batch.BlobCertificates); err != nil {
+		return fmt.Errorf("failed to validate batch header: %v", err)
+	}
+
+	pool := workerpool.New(n.Config.NumBatchValidators)
+	if err := n.ValidatorV2.ValidateBlobs(ctx, blobShards, pool, batch.BatchHeader.ReferenceBlockNumber); err != nil {
+		return fmt.Errorf("failed to validate blobs: %v", err)
+	}
+	n.Logger.Debug("ValidateBatchV2 completed", "duration", time.Since(start))
+
+	return nil
+}
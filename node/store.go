@@ -113,22 +113,21 @@ func (s *Store) DeleteExpiredEntries(currentTimeUnixSec int64, timeLimitSec uint
 // All blobs & blob headers in a batch are expired by deleteNBatches method.
 func (s *Store) deleteExpiredBlobs(currentTimeUnixSec int64, numBlobs int) (int, error) {
 	// Scan for expired batches.
-	iter, err := s.db.NewIterator(EncodeBlobExpirationKeyPrefix())
+	iter, err := s.db.NewRangeIterator(
+		EncodeBlobExpirationKeyPrefix(),
+		EncodeBlobExpirationKey(currentTimeUnixSec+1, [32]byte{}),
+		nil)
 	if err != nil {
 		return -1, fmt.Errorf("failed to create an iterator for the expired blobs: %w", err)
 	}
 	batch := s.db.NewBatch()
 	expiredBlobHeaders := make([][32]byte, 0)
 	for iter.Next() {
-		ts, err := DecodeBlobExpirationKey(iter.Key())
+		_, err := DecodeBlobExpirationKey(iter.Key())
 		if err != nil {
 			s.logger.Error("Could not decode the expiration key", "key", iter.Key(), "error", err)
 			continue
 		}
-		// No more rows expired up to current time.
-		if currentTimeUnixSec < ts {
-			break
-		}
 		batch.Delete(copyBytes(iter.Key()))
 		blobHeaderBytes := copyBytes(iter.Value())
 		blobHeaders, err := DecodeHashSlice(blobHeaderBytes)
@@ -191,22 +190,21 @@ func (s *Store) deleteExpiredBlobs(currentTimeUnixSec int64, numBlobs int) (int,
 // The second return value is the number of batch header entries deleted.
 func (s *Store) deleteExpiredBatchMapping(currentTimeUnixSec int64, numBatches int) (numExpiredMappings int, numExpiredBatches int, err error) {
 	// Scan for expired batches.
-	iter, err := s.db.NewIterator(EncodeBatchMappingExpirationKeyPrefix())
+	iter, err := s.db.NewRangeIterator(
+		EncodeBatchMappingExpirationKeyPrefix(),
+		EncodeBatchMappingExpirationKey(currentTimeUnixSec+1, [32]byte{}),
+		nil)
 	if err != nil {
 		return -1, -1, fmt.Errorf("failed to create an iterator for the expired batch mapping: %w", err)
 	}
 	batch := s.db.NewBatch()
 	expiredBatches := make([][]byte, 0)
 	for iter.Next() {
-		ts, err := DecodeBatchMappingExpirationKey(iter.Key())
+		_, err := DecodeBatchMappingExpirationKey(iter.Key())
 		if err != nil {
 			s.logger.Error("Could not decode the batch mapping expiration key", "key", iter.Key(), "error", err)
 			continue
 		}
-		// No more rows expired up to current time.
-		if currentTimeUnixSec < ts {
-			break
-		}
 		batch.Delete(copyBytes(iter.Key()))
 		expiredBatches = append(expiredBatches, copyBytes(iter.Value()))
 		if int(batch.Size()) == numBatches {
@@ -257,22 +255,21 @@ func (s *Store) deleteExpiredBatchMapping(currentTimeUnixSec int64, numBatches i
 // is set to -1 (invalid value) if the deletion status is an error.
 func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int) (int, error) {
 	// Scan for expired batches.
-	iter, err := s.db.NewIterator(EncodeBatchExpirationKeyPrefix())
+	iter, err := s.db.NewRangeIterator(
+		EncodeBatchExpirationKeyPrefix(),
+		EncodeBatchExpirationKey(currentTimeUnixSec+1),
+		nil)
 	if err != nil {
 		return -1, fmt.Errorf("failed to create an iterator for the expired batches: %w", err)
 	}
 	batch := s.db.NewBatch()
 	expiredBatches := make([][]byte, 0)
 	for iter.Next() {
-		ts, err := DecodeBatchExpirationKey(iter.Key())
+		_, err := DecodeBatchExpirationKey(iter.Key())
 		if err != nil {
 			s.logger.Error("Could not decode the expiration key", "key:", iter.Key(), "error", err)
 			continue
 		}
-		// No more rows expired up to current time.
-		if currentTimeUnixSec < ts {
-			break
-		}
 		batch.Delete(copyBytes(iter.Key()))
 		expiredBatches = append(expiredBatches, copyBytes(iter.Value()))
 		if int(batch.Size()) == numBatches {
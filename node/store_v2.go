@@ -0,0 +1,187 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// BatchHeaderTableName is the name of the table that stores v2 batch headers, keyed by batch header hash.
+	BatchHeaderTableName = "batch_headers"
+	// BundleTableName is the name of the table that stores v2 bundles, keyed by blob key and quorum ID.
+	BundleTableName = "bundles"
+)
+
+// StoreV2 encapsulates the database for storing batches of chunk data for the V2 dispersal.
+type StoreV2 interface {
+	// StoreBatch stores a batch and its raw bundles in the database. Returns the keys of the data written by
+	// this call and an error if any. The data expires after the configured TTL.
+	StoreBatch(batch *corev2.Batch, rawBundles []*RawBundles) ([]kvstore.Key, error)
+
+	// DeleteKeys deletes the keys from local storage.
+	DeleteKeys(keys []kvstore.Key) error
+
+	// GetChunks returns the chunks of the given blob and quorum held by this node, each chunk in its
+	// serialized (gnark) form. Returns kvstore.ErrNotFound if the node doesn't have the bundle.
+	GetChunks(blobKey corev2.BlobKey, quorum core.QuorumID) ([][]byte, error)
+}
+
+type storeV2 struct {
+	db     kvstore.TableStore
+	logger logging.Logger
+
+	// ttl is the time-to-live of the data stored in the database.
+	ttl time.Duration
+
+	batchHeaderKeyBuilder kvstore.KeyBuilder
+	bundleKeyBuilder      kvstore.KeyBuilder
+}
+
+var _ StoreV2 = (*storeV2)(nil)
+
+// NewLevelDBStoreV2 creates a StoreV2 on top of the given TableStore. The TableStore must contain the
+// BatchHeaderTableName and BundleTableName tables.
+func NewLevelDBStoreV2(db kvstore.TableStore, logger logging.Logger, ttl time.Duration) (*storeV2, error) {
+	batchHeaderKeyBuilder, err := db.GetKeyBuilder(BatchHeaderTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for table %s: %w", BatchHeaderTableName, err)
+	}
+	bundleKeyBuilder, err := db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for table %s: %w", BundleTableName, err)
+	}
+
+	return &storeV2{
+		db:                    db,
+		logger:                logger.With("component", "NodeStoreV2"),
+		ttl:                   ttl,
+		batchHeaderKeyBuilder: batchHeaderKeyBuilder,
+		bundleKeyBuilder:      bundleKeyBuilder,
+	}, nil
+}
+
+// StoreBatch stores the batch header and all the raw bundles atomically. The following entries are written:
+//   - Batch header: keyed by <batchHeaderHash> in the batch header table
+//   - The bundle of each blob and quorum: keyed by <blobKey, quorumID> in the bundle table
+//
+// If the batch header already exists in the store, ErrBatchAlreadyExist is returned and nothing is written.
+// Bundles which are stored already, because an earlier batch contains the same blob, are neither overwritten nor
+// returned, so that deleting the returned keys never removes the data of another batch.
+func (s *storeV2) StoreBatch(batch *corev2.Batch, rawBundles []*RawBundles) ([]kvstore.Key, error) {
+	if len(rawBundles) == 0 {
+		return nil, errors.New("no raw bundles")
+	}
+	if len(rawBundles) != len(batch.BlobCertificates) {
+		return nil, errors.New("mismatch between raw bundles and blob certificates")
+	}
+
+	dbBatch := s.db.NewTTLBatch()
+	keys := make([]kvstore.Key, 0)
+
+	batchHeaderHash, err := batch.BatchHeader.Hash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash batch header: %v", err)
+	}
+
+	// If the batch header exists already in store, we know that all data items associated
+	// with this batch should be in the store already (because they are written atomically).
+	batchHeaderKey := s.batchHeaderKeyBuilder.Key(batchHeaderHash[:])
+	if _, err := s.db.Get(batchHeaderKey); err == nil {
+		return nil, ErrBatchAlreadyExist
+	} else if !errors.Is(err, kvstore.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check the existence of batch header: %v", err)
+	}
+
+	batchHeaderBytes, err := proto.Marshal(batch.BatchHeader.ToProtobuf())
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize batch header: %v", err)
+	}
+	keys = append(keys, batchHeaderKey)
+	dbBatch.PutWithTTL(batchHeaderKey, batchHeaderBytes, s.ttl)
+
+	size := 0
+	written := make(map[string]struct{})
+	for _, bundles := range rawBundles {
+		blobKey, err := bundles.BlobCertificate.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob key: %v", err)
+		}
+
+		for quorum, bundle := range bundles.Bundles {
+			bundleKey, err := BundleKey(blobKey, quorum)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get bundle key: %v", err)
+			}
+
+			k := s.bundleKeyBuilder.Key(bundleKey)
+			if _, ok := written[string(k.Raw())]; ok {
+				continue
+			}
+			if _, err := s.db.Get(k); err == nil {
+				continue
+			} else if !errors.Is(err, kvstore.ErrNotFound) {
+				return nil, fmt.Errorf("failed to check the existence of bundle: %v", err)
+			}
+			written[string(k.Raw())] = struct{}{}
+			keys = append(keys, k)
+			dbBatch.PutWithTTL(k, bundle, s.ttl)
+			size += len(bundle)
+		}
+	}
+
+	start := time.Now()
+	if err := dbBatch.Apply(); err != nil {
+		return nil, fmt.Errorf("failed to write the batch into local database: %v", err)
+	}
+	s.logger.Debug("StoreBatch succeeded", "batchHeaderHash", fmt.Sprintf("%x", batchHeaderHash), "num blobs", len(rawBundles), "num of key-value pair entries", len(keys), "total bytes", size, "write batch duration", time.Since(start))
+
+	return keys, nil
+}
+
+// DeleteKeys deletes the given keys from the store atomically.
+func (s *storeV2) DeleteKeys(keys []kvstore.Key) error {
+	dbBatch := s.db.NewBatch()
+	for _, key := range keys {
+		dbBatch.Delete(key)
+	}
+	return dbBatch.Apply()
+}
+
+// GetChunks returns the chunks of the given blob and quorum held by this node.
+func (s *storeV2) GetChunks(blobKey corev2.BlobKey, quorum core.QuorumID) ([][]byte, error) {
+	bundleKey, err := BundleKey(blobKey, quorum)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle, err := s.db.Get(s.bundleKeyBuilder.Key(bundleKey))
+	if err != nil {
+		return nil, err
+	}
+
+	chunks, err := DecodeGnarkChunks(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the bundle of blob %s in quorum %d: %v", blobKey.Hex(), quorum, err)
+	}
+	s.logger.Debug("Retrieved chunks", "blobKey", blobKey.Hex(), "quorum", quorum, "num chunks", len(chunks))
+
+	return chunks, nil
+}
+
+// BundleKey returns the key under which the bundle of the given blob and quorum is stored, i.e. <blobKey, quorumID>.
+func BundleKey(blobKey corev2.BlobKey, quorumID core.QuorumID) ([]byte, error) {
+	if quorumID > corev2.MaxQuorumID {
+		return nil, fmt.Errorf("quorum ID must be in range [0, %d], but found %d", corev2.MaxQuorumID, quorumID)
+	}
+	key := make([]byte, 0, len(blobKey)+1)
+	key = append(key, blobKey[:]...)
+	key = append(key, quorumID)
+	return key, nil
+}
@@ -0,0 +1,184 @@
+package node_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func createStoreV2(t *testing.T) (node.StoreV2, kvstore.TableStore) {
+	logger := logging.NewNoopLogger()
+	config := tablestore.DefaultMapStoreConfig()
+	config.Schema = []string{node.BatchHeaderTableName, node.BundleTableName}
+	db, err := tablestore.Start(logger, config)
+	require.NoError(t, err)
+	s, err := node.NewLevelDBStoreV2(db, logger, time.Hour)
+	require.NoError(t, err)
+	return s, db
+}
+
+func createBatchV2(t *testing.T) (*corev2.Batch, []*node.RawBundles) {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	quorums := []core.QuorumID{0, 1}
+	certs := make([]*corev2.BlobCertificate, 2)
+	rawBundles := make([]*node.RawBundles, len(certs))
+	for i := range certs {
+		certs[i] = &corev2.BlobCertificate{
+			BlobHeader: &corev2.BlobHeader{
+				BlobVersion: 0,
+				BlobCommitments: encoding.BlobCommitments{
+					Commitment:       (*encoding.G1Commitment)(&g1Gen),
+					LengthCommitment: (*encoding.G2Commitment)(&g2Gen),
+					LengthProof:      (*encoding.G2Commitment)(&g2Gen),
+					Length:           16,
+				},
+				QuorumNumbers: quorums,
+				PaymentMetadata: core.PaymentMetadata{
+					AccountID:         "0x123",
+					BinIndex:          uint32(i),
+					CumulativePayment: big.NewInt(100),
+				},
+			},
+			RelayKeys: []corev2.RelayKey{0},
+		}
+
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: certs[i],
+			Bundles:         make(map[core.QuorumID][]byte),
+		}
+		for _, quorum := range quorums {
+			frames := make(core.Bundle, int(quorum)+1)
+			for j := range frames {
+				frames[j] = &encoding.Frame{
+					Proof:  g1Gen,
+					Coeffs: []fr.Element{fr.NewElement(uint64(i)), fr.NewElement(uint64(j))},
+				}
+			}
+			bundle, err := frames.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundle
+		}
+	}
+
+	return &corev2.Batch{
+		BatchHeader: &corev2.BatchHeader{
+			BatchRoot:            [32]byte{1},
+			ReferenceBlockNumber: 100,
+		},
+		BlobCertificates: certs,
+	}, rawBundles
+}
+
+func TestStoreBatchV2(t *testing.T) {
+	s, _ := createStoreV2(t)
+	batch, rawBundles := createBatchV2(t)
+
+	keys, err := s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	// 1 batch header + 2 blobs x 2 quorums
+	require.Len(t, keys, 5)
+
+	for _, bundles := range rawBundles {
+		blobKey, err := bundles.BlobCertificate.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		for quorum, bundle := range bundles.Bundles {
+			chunks, err := s.GetChunks(blobKey, quorum)
+			require.NoError(t, err)
+
+			frames, err := new(core.Bundle).Deserialize(bundle)
+			require.NoError(t, err)
+			require.Len(t, chunks, len(frames))
+			for i, chunk := range chunks {
+				frame, err := new(encoding.Frame).DeserializeGnark(chunk)
+				require.NoError(t, err)
+				require.Equal(t, frames[i], frame)
+			}
+		}
+	}
+
+	// Storing the same batch again is rejected
+	_, err = s.StoreBatch(batch, rawBundles)
+	require.ErrorIs(t, err, node.ErrBatchAlreadyExist)
+
+	// Deleting the keys removes the chunks
+	err = s.DeleteKeys(keys)
+	require.NoError(t, err)
+	blobKey, err := batch.BlobCertificates[0].BlobHeader.BlobKey()
+	require.NoError(t, err)
+	_, err = s.GetChunks(blobKey, 0)
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+}
+
+func TestStoreBatchV2InvalidInput(t *testing.T) {
+	s, _ := createStoreV2(t)
+	batch, rawBundles := createBatchV2(t)
+
+	_, err := s.StoreBatch(batch, nil)
+	require.Error(t, err)
+
+	_, err = s.StoreBatch(batch, rawBundles[:1])
+	require.Error(t, err)
+}
+
+func TestGetChunksV2NotFound(t *testing.T) {
+	s, _ := createStoreV2(t)
+	batch, rawBundles := createBatchV2(t)
+	_, err := s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+
+	_, err = s.GetChunks(corev2.BlobKey{1, 2, 3}, 0)
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+
+	blobKey, err := batch.BlobCertificates[0].BlobHeader.BlobKey()
+	require.NoError(t, err)
+	_, err = s.GetChunks(blobKey, 2)
+	require.ErrorIs(t, err, kvstore.ErrNotFound)
+}
+
+func TestStoreBatchV2SharedBlob(t *testing.T) {
+	s, _ := createStoreV2(t)
+	batch, rawBundles := createBatchV2(t)
+	_, err := s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+
+	// Another batch which contains one of the blobs of the first batch
+	otherBatch := &corev2.Batch{
+		BatchHeader: &corev2.BatchHeader{
+			BatchRoot:            [32]byte{2},
+			ReferenceBlockNumber: 101,
+		},
+		BlobCertificates: batch.BlobCertificates[:1],
+	}
+	otherBundles := []*node.RawBundles{{
+		BlobCertificate: batch.BlobCertificates[0],
+		Bundles:         map[core.QuorumID][]byte{0: {1, 2, 3}, 1: {4, 5, 6}},
+	}}
+	keys, err := s.StoreBatch(otherBatch, otherBundles)
+	require.NoError(t, err)
+	// Only the batch header is written, the bundles of the first batch are kept
+	require.Len(t, keys, 1)
+
+	// Rolling back the second batch doesn't remove the data of the first batch
+	err = s.DeleteKeys(keys)
+	require.NoError(t, err)
+	blobKey, err := batch.BlobCertificates[0].BlobHeader.BlobKey()
+	require.NoError(t, err)
+	for quorum, bundle := range rawBundles[0].Bundles {
+		chunks, err := s.GetChunks(blobKey, quorum)
+		require.NoError(t, err)
+		frames, err := new(core.Bundle).Deserialize(bundle)
+		require.NoError(t, err)
+		require.Len(t, chunks, len(frames))
+	}
+}
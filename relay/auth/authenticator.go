@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/Layr-Labs/eigenda/core"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// RequestAuthenticator authenticates requests to the relay service. This object is thread safe.
+type RequestAuthenticator interface {
+	// AuthenticateGetChunksRequest authenticates a GetChunksRequest, returning the ID of the operator that made
+	// the request if the signature in the request was made by that operator, and an error otherwise.
+	AuthenticateGetChunksRequest(ctx context.Context, request *pb.GetChunksRequest) (core.OperatorID, error)
+}
+
+var _ RequestAuthenticator = &requestAuthenticator{}
+
+type requestAuthenticator struct {
+	ics core.IndexedChainState
+
+	// keyCache is used to cache the public keys of operators. It holds the keys of the operator set as of the
+	// last lookup, so that the keys of operators which have deregistered are evicted once the cache expires.
+	keyCache *lru.Cache[core.OperatorID, *core.G2Point]
+	// keyTimeout is the maximum age of the cached keys, after which the operator set is looked up again.
+	keyTimeout time.Duration
+	// keysRefreshedAt is the time at which the cached keys were looked up, in nanoseconds since the epoch.
+	keysRefreshedAt atomic.Int64
+
+	// refreshLock serializes the lookups of the operator set, so that a burst of requests from operators that are
+	// not in the cache results in a single onchain lookup.
+	refreshLock sync.Mutex
+	// lastRefresh is the time of the last lookup of the operator set. Protected by refreshLock.
+	lastRefresh time.Time
+	// minRefreshInterval is the minimum time between two lookups of the operator set. This prevents requests
+	// with unknown operator IDs from triggering an onchain lookup each.
+	minRefreshInterval time.Duration
+
+	// maxTimestampSkew is how far the timestamp of a request may be from the current time. Requests outside of this
+	// window are rejected, so that a captured request can't be replayed later on.
+	maxTimestampSkew time.Duration
+}
+
+// NewRequestAuthenticator creates a new RequestAuthenticator. The keyCacheSize is the maximum number of operator
+// public keys that are cached, and keyTimeout is the time after which cached keys are looked up again, which bounds
+// how long an operator that deregistered is still served. The minRefreshInterval is the minimum time between two
+// lookups of the operator set when a request comes from an operator whose key is not cached. The maxTimestampSkew is
+// how far the timestamp of a request may be from the current time, in either direction.
+func NewRequestAuthenticator(
+	ics core.IndexedChainState,
+	keyCacheSize int,
+	keyTimeout time.Duration,
+	minRefreshInterval time.Duration,
+	maxTimestampSkew time.Duration) (RequestAuthenticator, error) {
+
+	keyCache, err := lru.New[core.OperatorID, *core.G2Point](keyCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key cache: %w", err)
+	}
+
+	return &requestAuthenticator{
+		ics:                ics,
+		keyCache:           keyCache,
+		keyTimeout:         keyTimeout,
+		minRefreshInterval: minRefreshInterval,
+		maxTimestampSkew:   maxTimestampSkew,
+	}, nil
+}
+
+func (a *requestAuthenticator) AuthenticateGetChunksRequest(
+	ctx context.Context,
+	request *pb.GetChunksRequest) (core.OperatorID, error) {
+
+	if len(request.GetRequesterId()) != len(core.OperatorID{}) {
+		return core.OperatorID{}, fmt.Errorf("invalid requester ID length: expected %d bytes, got %d",
+			len(core.OperatorID{}), len(request.GetRequesterId()))
+	}
+	operatorID := core.OperatorID(request.GetRequesterId())
+
+	requestAge := time.Since(time.Unix(0, int64(request.GetTimestamp())))
+	if requestAge > a.maxTimestampSkew || requestAge < -a.maxTimestampSkew {
+		return core.OperatorID{}, fmt.Errorf("request timestamp is more than %v away from the current time",
+			a.maxTimestampSkew)
+	}
+
+	if len(request.GetRequesterSignature()) == 0 {
+		return core.OperatorID{}, errors.New("missing requester signature")
+	}
+	g1Point, err := (&core.G1Point{}).Deserialize(request.GetRequesterSignature())
+	if err != nil {
+		return core.OperatorID{}, fmt.Errorf("failed to deserialize signature: %w", err)
+	}
+	signature := core.Signature{G1Point: g1Point}
+
+	key, err := a.getOperatorKey(ctx, operatorID)
+	if err != nil {
+		return core.OperatorID{}, err
+	}
+
+	hash := HashGetChunksRequest(request)
+	if !signature.Verify(key, hash) {
+		return core.OperatorID{}, fmt.Errorf("signature verification failed for operator %s", operatorID.Hex())
+	}
+
+	return operatorID, nil
+}
+
+// getOperatorKey returns the G2 public key of the operator with the given ID.
+func (a *requestAuthenticator) getOperatorKey(ctx context.Context, operatorID core.OperatorID) (*core.G2Point, error) {
+	if a.keysFresh() {
+		key, ok := a.keyCache.Get(operatorID)
+		if ok {
+			return key, nil
+		}
+	}
+
+	a.refreshLock.Lock()
+	defer a.refreshLock.Unlock()
+
+	// Another goroutine may have refreshed the keys while we were waiting for the lock.
+	fresh := a.keysFresh()
+	if fresh {
+		key, ok := a.keyCache.Get(operatorID)
+		if ok {
+			return key, nil
+		}
+	}
+
+	if time.Since(a.lastRefresh) < a.minRefreshInterval {
+		if fresh {
+			return nil, fmt.Errorf("operator %s not found", operatorID.Hex())
+		}
+		// The last lookup of expired keys failed
+		return nil, errors.New("operator set is unavailable")
+	}
+	a.lastRefresh = time.Now()
+
+	blockNumber, err := a.ics.GetCurrentBlockNumber()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current block number: %w", err)
+	}
+	operators, err := a.ics.GetIndexedOperators(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operators: %w", err)
+	}
+
+	// Replace the cached keys with those of the current operator set, so that one lookup is enough to warm up the
+	// cache and the operators that deregistered are evicted.
+	a.keyCache.Purge()
+	for id, info := range operators {
+		if info != nil && info.PubkeyG2 != nil {
+			a.keyCache.Add(id, info.PubkeyG2)
+		}
+	}
+	a.keysRefreshedAt.Store(a.lastRefresh.UnixNano())
+
+	operator, ok := operators[operatorID]
+	if !ok || operator == nil || operator.PubkeyG2 == nil {
+		return nil, fmt.Errorf("operator %s not found", operatorID.Hex())
+	}
+
+	return operator.PubkeyG2, nil
+}
+
+// keysFresh returns true if the cached keys were looked up less than keyTimeout ago.
+func (a *requestAuthenticator) keysFresh() bool {
+	return time.Since(time.Unix(0, a.keysRefreshedAt.Load())) < a.keyTimeout
+}
@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestChainState(t *testing.T) *mock.ChainDataMock {
+	ics, err := mock.MakeChainDataMock(map[core.QuorumID]int{0: 4})
+	require.NoError(t, err)
+	ics.Mock.On("GetCurrentBlockNumber").Return(uint(100), nil)
+	return ics
+}
+
+func TestValidRequest(t *testing.T) {
+	tu.InitializeRandom()
+	ics := newTestChainState(t)
+	authenticator, err := NewRequestAuthenticator(ics, 1024, time.Hour, time.Minute, time.Minute)
+	require.NoError(t, err)
+
+	operatorID := mock.MakeOperatorId(1)
+	request := randomGetChunksRequest()
+	request.RequesterId = operatorID[:]
+	request.RequesterSignature = SignGetChunksRequest(ics.KeyPairs[operatorID], request)
+
+	id, err := authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.NoError(t, err)
+	require.Equal(t, operatorID, id)
+
+	// The keys of all operators are cached after the first lookup.
+	for i := 0; i < 4; i++ {
+		operatorID := mock.MakeOperatorId(i)
+		request := randomGetChunksRequest()
+		request.RequesterId = operatorID[:]
+		request.RequesterSignature = SignGetChunksRequest(ics.KeyPairs[operatorID], request)
+
+		id, err := authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+		require.NoError(t, err)
+		require.Equal(t, operatorID, id)
+	}
+	ics.Mock.AssertNumberOfCalls(t, "GetCurrentBlockNumber", 1)
+}
+
+func TestInvalidRequests(t *testing.T) {
+	tu.InitializeRandom()
+	ics := newTestChainState(t)
+	authenticator, err := NewRequestAuthenticator(ics, 1024, time.Hour, time.Minute, time.Minute)
+	require.NoError(t, err)
+
+	operatorID := mock.MakeOperatorId(1)
+
+	// Missing requester ID
+	request := randomGetChunksRequest()
+	request.RequesterId = nil
+	request.RequesterSignature = SignGetChunksRequest(ics.KeyPairs[operatorID], request)
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.Error(t, err)
+
+	// Missing signature
+	request = randomGetChunksRequest()
+	request.RequesterId = operatorID[:]
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.Error(t, err)
+
+	// Malformed signature
+	request.RequesterSignature = tu.RandomBytes(32)
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.Error(t, err)
+
+	// Signed by another operator
+	request.RequesterSignature = SignGetChunksRequest(ics.KeyPairs[mock.MakeOperatorId(2)], request)
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.Error(t, err)
+
+	// Tampered with after signing
+	request.RequesterSignature = SignGetChunksRequest(ics.KeyPairs[operatorID], request)
+	request.ChunkRequests = randomGetChunksRequest().ChunkRequests
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.Error(t, err)
+
+	// Unknown operator
+	keys, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	unknownID := keys.GetPubKeyG1().GetOperatorID()
+	request = randomGetChunksRequest()
+	request.RequesterId = unknownID[:]
+	request.RequesterSignature = SignGetChunksRequest(keys, request)
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestUnknownOperatorRefreshInterval(t *testing.T) {
+	tu.InitializeRandom()
+	ics := newTestChainState(t)
+	authenticator, err := NewRequestAuthenticator(ics, 1024, time.Hour, time.Hour, time.Minute)
+	require.NoError(t, err)
+
+	// Requests from unknown operators only trigger a lookup of the operator set once per refresh interval.
+	for i := 0; i < 10; i++ {
+		keys, err := core.GenRandomBlsKeys()
+		require.NoError(t, err)
+		unknownID := keys.GetPubKeyG1().GetOperatorID()
+		request := randomGetChunksRequest()
+		request.RequesterId = unknownID[:]
+		request.RequesterSignature = SignGetChunksRequest(keys, request)
+		_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+		require.Error(t, err)
+	}
+	ics.Mock.AssertNumberOfCalls(t, "GetCurrentBlockNumber", 1)
+}
+
+func TestDeregisteredOperatorKeyTimeout(t *testing.T) {
+	tu.InitializeRandom()
+	ics := newTestChainState(t)
+	keyTimeout := 50 * time.Millisecond
+	authenticator, err := NewRequestAuthenticator(ics, 1024, keyTimeout, 0, time.Minute)
+	require.NoError(t, err)
+
+	operatorID := mock.MakeOperatorId(1)
+	request := randomGetChunksRequest()
+	request.RequesterId = operatorID[:]
+	request.RequesterSignature = SignGetChunksRequest(ics.KeyPairs[operatorID], request)
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.NoError(t, err)
+
+	// The operator deregisters. Its cached key is used until it times out.
+	operators := make([]core.OperatorID, 0, len(ics.Operators))
+	for _, id := range ics.Operators {
+		if id != operatorID {
+			operators = append(operators, id)
+		}
+	}
+	ics.Operators = operators
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.NoError(t, err)
+
+	time.Sleep(keyTimeout)
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.ErrorContains(t, err, "not found")
+
+	// The keys of the operators that are still registered are looked up again.
+	otherID := mock.MakeOperatorId(2)
+	request = randomGetChunksRequest()
+	request.RequesterId = otherID[:]
+	request.RequesterSignature = SignGetChunksRequest(ics.KeyPairs[otherID], request)
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.NoError(t, err)
+	ics.Mock.AssertNumberOfCalls(t, "GetCurrentBlockNumber", 2)
+}
+
+func TestRequestTimestamp(t *testing.T) {
+	tu.InitializeRandom()
+	ics := newTestChainState(t)
+	maxSkew := 100 * time.Millisecond
+	authenticator, err := NewRequestAuthenticator(ics, 1024, time.Hour, time.Minute, maxSkew)
+	require.NoError(t, err)
+
+	operatorID := mock.MakeOperatorId(1)
+	signedRequest := func(timestamp time.Time) *pb.GetChunksRequest {
+		request := randomGetChunksRequest()
+		request.RequesterId = operatorID[:]
+		request.Timestamp = uint64(timestamp.UnixNano())
+		request.RequesterSignature = SignGetChunksRequest(ics.KeyPairs[operatorID], request)
+		return request
+	}
+
+	// Missing timestamp
+	request := randomGetChunksRequest()
+	request.RequesterId = operatorID[:]
+	request.Timestamp = 0
+	request.RequesterSignature = SignGetChunksRequest(ics.KeyPairs[operatorID], request)
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.ErrorContains(t, err, "timestamp")
+
+	// Stale request
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), signedRequest(time.Now().Add(-time.Second)))
+	require.ErrorContains(t, err, "timestamp")
+
+	// Request from the future
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), signedRequest(time.Now().Add(time.Second)))
+	require.ErrorContains(t, err, "timestamp")
+
+	// A fresh request is accepted, but it can't be replayed once it is older than the allowed skew.
+	request = signedRequest(time.Now())
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.NoError(t, err)
+	time.Sleep(2 * maxSkew)
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.ErrorContains(t, err, "timestamp")
+
+	// Refreshing the timestamp of a captured request invalidates its signature.
+	request.Timestamp = uint64(time.Now().UnixNano())
+	_, err = authenticator.AuthenticateGetChunksRequest(context.Background(), request)
+	require.ErrorContains(t, err, "signature verification failed")
+}
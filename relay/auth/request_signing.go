@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"encoding/binary"
+	"hash"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/Layr-Labs/eigenda/core"
+	"golang.org/x/crypto/sha3"
+)
+
+// Domain separators for the two kinds of chunk requests, so that a request by index can never hash to the same
+// value as a request by range.
+var (
+	byIndexDomain = []byte("i")
+	byRangeDomain = []byte("r")
+)
+
+// HashGetChunksRequest hashes the given GetChunksRequest. The requester signature is not covered by the hash.
+//
+// The hash is keccak256(requesterID || timestamp || chunkRequest_0 || ... || chunkRequest_n), where each chunk
+// request is encoded as either:
+//   - "i" || blobKey || index_0 || ... || index_m (by index)
+//   - "r" || blobKey || startIndex || endIndex (by range)
+//
+// with the timestamp encoded as an 8 byte big endian value, and all other integers as 4 byte big endian values.
+func HashGetChunksRequest(request *pb.GetChunksRequest) [32]byte {
+	hasher := sha3.NewLegacyKeccak256()
+
+	hasher.Write(request.GetRequesterId())
+	hashUint64(hasher, request.GetTimestamp())
+	for _, chunkRequest := range request.GetChunkRequests() {
+		if chunkRequest.GetByIndex() != nil {
+			getByIndex := chunkRequest.GetByIndex()
+			hasher.Write(byIndexDomain)
+			hasher.Write(getByIndex.GetBlobKey())
+			for _, index := range getByIndex.GetChunkIndices() {
+				hashUint32(hasher, index)
+			}
+		} else {
+			getByRange := chunkRequest.GetByRange()
+			hasher.Write(byRangeDomain)
+			hasher.Write(getByRange.GetBlobKey())
+			hashUint32(hasher, getByRange.GetStartIndex())
+			hashUint32(hasher, getByRange.GetEndIndex())
+		}
+	}
+
+	var digest [32]byte
+	copy(digest[:], hasher.Sum(nil))
+	return digest
+}
+
+// hashUint32 writes the big endian encoding of value into the hasher.
+func hashUint32(hasher hash.Hash, value uint32) {
+	bytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(bytes, value)
+	_, _ = hasher.Write(bytes)
+}
+
+// hashUint64 writes the big endian encoding of value into the hasher.
+func hashUint64(hasher hash.Hash, value uint64) {
+	bytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(bytes, value)
+	_, _ = hasher.Write(bytes)
+}
+
+// SignGetChunksRequest signs the given GetChunksRequest with the given BLS key pair and returns the serialized
+// signature. The requester ID and the timestamp must be set in the request before it is signed.
+func SignGetChunksRequest(keys *core.KeyPair, request *pb.GetChunksRequest) []byte {
+	hash := HashGetChunksRequest(request)
+	return keys.SignMessage(hash).Serialize()
+}
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/require"
+)
+
+func randomGetChunksRequest() *pb.GetChunksRequest {
+	requestedChunks := make([]*pb.ChunkRequest, 0)
+	requestCount := 1 + rand.Intn(9)
+	for i := 0; i < requestCount; i++ {
+		if rand.Intn(2) == 0 {
+			indices := make([]uint32, 1+rand.Intn(9))
+			for j := range indices {
+				indices[j] = uint32(rand.Intn(1000))
+			}
+			requestedChunks = append(requestedChunks, &pb.ChunkRequest{
+				Request: &pb.ChunkRequest_ByIndex{
+					ByIndex: &pb.ChunkRequestByIndex{
+						BlobKey:      tu.RandomBytes(32),
+						ChunkIndices: indices,
+					},
+				},
+			})
+		} else {
+			start := uint32(rand.Intn(1000))
+			requestedChunks = append(requestedChunks, &pb.ChunkRequest{
+				Request: &pb.ChunkRequest_ByRange{
+					ByRange: &pb.ChunkRequestByRange{
+						BlobKey:    tu.RandomBytes(32),
+						StartIndex: start,
+						EndIndex:   start + uint32(1+rand.Intn(99)),
+					},
+				},
+			})
+		}
+	}
+	return &pb.GetChunksRequest{
+		RequesterId:   tu.RandomBytes(32),
+		Timestamp:     uint64(time.Now().UnixNano()),
+		ChunkRequests: requestedChunks,
+	}
+}
+
+func TestHashGetChunksRequest(t *testing.T) {
+	tu.InitializeRandom()
+
+	requestA := randomGetChunksRequest()
+	requestB := randomGetChunksRequest()
+
+	// Hashing the same request twice yields the same hash
+	require.Equal(t, HashGetChunksRequest(requestA), HashGetChunksRequest(requestA))
+	require.NotEqual(t, HashGetChunksRequest(requestA), HashGetChunksRequest(requestB))
+
+	// The signature is not covered by the hash
+	hash := HashGetChunksRequest(requestA)
+	requestA.RequesterSignature = tu.RandomBytes(32)
+	require.Equal(t, hash, HashGetChunksRequest(requestA))
+
+	// Changing the requester changes the hash
+	requestA.RequesterId = tu.RandomBytes(32)
+	require.NotEqual(t, hash, HashGetChunksRequest(requestA))
+
+	// Changing the timestamp changes the hash
+	hash = HashGetChunksRequest(requestA)
+	requestA.Timestamp++
+	require.NotEqual(t, hash, HashGetChunksRequest(requestA))
+
+	// A request by index and a request by range over the same chunks do not collide
+	byIndex := &pb.GetChunksRequest{
+		ChunkRequests: []*pb.ChunkRequest{{
+			Request: &pb.ChunkRequest_ByIndex{
+				ByIndex: &pb.ChunkRequestByIndex{BlobKey: make([]byte, 32), ChunkIndices: []uint32{1, 2}},
+			},
+		}},
+	}
+	byRange := &pb.GetChunksRequest{
+		ChunkRequests: []*pb.ChunkRequest{{
+			Request: &pb.ChunkRequest_ByRange{
+				ByRange: &pb.ChunkRequestByRange{BlobKey: make([]byte, 32), StartIndex: 1, EndIndex: 2},
+			},
+		}},
+	}
+	require.NotEqual(t, HashGetChunksRequest(byIndex), HashGetChunksRequest(byRange))
+}
+
+func TestSignGetChunksRequest(t *testing.T) {
+	tu.InitializeRandom()
+
+	keys, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+
+	request := randomGetChunksRequest()
+	signature := SignGetChunksRequest(keys, request)
+
+	g1Point, err := (&core.G1Point{}).Deserialize(signature)
+	require.NoError(t, err)
+	sig := core.Signature{G1Point: g1Point}
+	require.True(t, sig.Verify(keys.GetPubKeyG2(), HashGetChunksRequest(request)))
+
+	otherKeys, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	require.False(t, sig.Verify(otherKeys.GetPubKeyG2(), HashGetChunksRequest(request)))
+}
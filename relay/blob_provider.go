@@ -0,0 +1,74 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// blobProvider encapsulates logic for fetching blobs. Utilized by the relay Server.
+type blobProvider struct {
+	ctx    context.Context
+	logger logging.Logger
+
+	// blobStore is used to read blobs from S3.
+	blobStore *blobstore.BlobStore
+	// blobCache is an LRU cache of blobs.
+	blobCache CachedAccessor[corev2.BlobKey, []byte]
+	// fetchTimeout is the maximum time to wait for a blob fetch from the blob store.
+	fetchTimeout time.Duration
+}
+
+// newBlobProvider creates a new blobProvider.
+func newBlobProvider(
+	ctx context.Context,
+	logger logging.Logger,
+	blobStore *blobstore.BlobStore,
+	blobCacheSize int,
+	fetchTimeout time.Duration) (*blobProvider, error) {
+
+	provider := &blobProvider{
+		ctx:          ctx,
+		logger:       logger,
+		blobStore:    blobStore,
+		fetchTimeout: fetchTimeout,
+	}
+
+	blobCache, err := NewCachedAccessor[corev2.BlobKey, []byte](blobCacheSize, provider.fetchBlob)
+	if err != nil {
+		return nil, fmt.Errorf("error creating blob cache: %w", err)
+	}
+	provider.blobCache = blobCache
+
+	return provider, nil
+}
+
+// GetBlob retrieves a blob from the blob store, or from the cache if it has been fetched recently.
+func (b *blobProvider) GetBlob(blobKey corev2.BlobKey) ([]byte, error) {
+	data, err := b.blobCache.Get(blobKey)
+	if err != nil {
+		// It should not be possible for external users to force an error here since we won't
+		// even call this method if the blob key is invalid (so it's ok to have a noisy log here).
+		b.logger.Errorf("Failed to fetch blob: %v", err)
+		return nil, err
+	}
+
+	return *data, nil
+}
+
+// fetchBlob retrieves a single blob from the blob store. Called when there is a cache miss.
+func (b *blobProvider) fetchBlob(blobKey corev2.BlobKey) (*[]byte, error) {
+	ctx, cancel := context.WithTimeout(b.ctx, b.fetchTimeout)
+	defer cancel()
+
+	data, err := b.blobStore.GetBlob(ctx, blobKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
@@ -0,0 +1,149 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/relay/chunkstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// chunkProvider encapsulates logic for fetching chunks. Utilized by the relay Server.
+type chunkProvider struct {
+	ctx    context.Context
+	logger logging.Logger
+
+	// chunkReader is used to read chunks from the chunk store.
+	chunkReader chunkstore.ChunkReader
+	// frameCache is a cache of the frames of recently requested blobs.
+	frameCache CachedAccessor[blobKeyWithMetadata, []*encoding.Frame]
+	// fetchTimeout is the maximum time to wait for the chunks of a blob to be fetched from the chunk store.
+	fetchTimeout time.Duration
+}
+
+// blobKeyWithMetadata attaches some additional metadata to a blobKey. The metadata is needed to fetch the
+// chunk coefficients. Since the metadata of a blob never changes, it does not affect cache hits. The blobKey is the
+// key the chunks are stored under, so blobs that share their content also share cached frames.
+type blobKeyWithMetadata struct {
+	blobKey  corev2.BlobKey
+	metadata blobMetadata
+}
+
+// newChunkProvider creates a new chunkProvider.
+func newChunkProvider(
+	ctx context.Context,
+	logger logging.Logger,
+	chunkReader chunkstore.ChunkReader,
+	cacheSize int,
+	fetchTimeout time.Duration) (*chunkProvider, error) {
+
+	provider := &chunkProvider{
+		ctx:          ctx,
+		logger:       logger,
+		chunkReader:  chunkReader,
+		fetchTimeout: fetchTimeout,
+	}
+
+	frameCache, err := NewCachedAccessor[blobKeyWithMetadata, []*encoding.Frame](cacheSize, provider.fetchFrames)
+	if err != nil {
+		return nil, fmt.Errorf("error creating frame cache: %w", err)
+	}
+	provider.frameCache = frameCache
+
+	return provider, nil
+}
+
+// frameMap is a map of blob keys to frames.
+type frameMap map[corev2.BlobKey][]*encoding.Frame
+
+// GetFrames retrieves the frames for the blobs in the metadata map. Fetches from the cache if available,
+// otherwise from the chunk store.
+func (p *chunkProvider) GetFrames(mMap metadataMap) (frameMap, error) {
+	type framesResult struct {
+		key  corev2.BlobKey
+		data []*encoding.Frame
+		err  error
+	}
+
+	completionChannel := make(chan *framesResult, len(mMap))
+	for key, metadata := range mMap {
+		key := key
+		metadata := metadata
+		go func() {
+			frames, err := p.frameCache.Get(blobKeyWithMetadata{blobKey: metadata.storageKey, metadata: *metadata})
+			if err != nil {
+				completionChannel <- &framesResult{
+					key: key,
+					err: err,
+				}
+				return
+			}
+			completionChannel <- &framesResult{
+				key:  key,
+				data: *frames,
+			}
+		}()
+	}
+
+	fMap := make(frameMap, len(mMap))
+	var err error
+	for range mMap {
+		result := <-completionChannel
+		if result.err != nil {
+			// Keep draining the channel so that no goroutine is left blocked.
+			if err == nil {
+				err = fmt.Errorf("error fetching frames for blob %s: %w", result.key.Hex(), result.err)
+			}
+			continue
+		}
+		fMap[result.key] = result.data
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return fMap, nil
+}
+
+// fetchFrames retrieves the proofs and coefficients of a blob from the chunk store and combines them into
+// frames. Called when there is a cache miss.
+func (p *chunkProvider) fetchFrames(key blobKeyWithMetadata) (*[]*encoding.Frame, error) {
+	ctx, cancel := context.WithTimeout(p.ctx, p.fetchTimeout)
+	defer cancel()
+
+	proofs, err := p.chunkReader.GetChunkProofs(ctx, key.blobKey)
+	if err != nil {
+		return nil, err
+	}
+
+	coefficients, err := p.chunkReader.GetChunkCoefficients(ctx, key.blobKey, &key.metadata.fragmentInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(proofs) != len(coefficients) {
+		return nil, fmt.Errorf("number of proofs (%d) does not match number of coefficients (%d) for blob %s",
+			len(proofs), len(coefficients), key.blobKey.Hex())
+	}
+
+	frames := make([]*encoding.Frame, len(proofs))
+	for i := range proofs {
+		frames[i] = &encoding.Frame{
+			Proof:  *proofs[i],
+			Coeffs: coefficients[i].Coeffs,
+		}
+	}
+
+	return &frames, nil
+}
+
+// serializeFrames serializes the given frames as a core.Bundle, which is the format expected by the clients
+// of the relay.
+func serializeFrames(frames []*encoding.Frame) ([]byte, error) {
+	bundle := core.Bundle(frames)
+	return bundle.Serialize()
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/relay"
+	"github.com/Layr-Labs/eigenda/relay/cmd/flags"
+	"github.com/urfave/cli"
+)
+
+// Config is the configuration for the relay binary.
+type Config struct {
+	// Log is the configuration for the logger.
+	Log common.LoggerConfig
+	// AWS is the configuration for the AWS client.
+	AWS aws.ClientConfig
+	// BucketName is the name of the S3 bucket that stores blobs and chunks.
+	BucketName string
+	// MetadataTableName is the name of the DynamoDB table that stores metadata.
+	MetadataTableName string
+	// RelayConfig is the configuration for the relay.
+	RelayConfig relay.Config
+	// RatelimiterConfig is the configuration of the per-operator rate limiter.
+	RatelimiterConfig ratelimit.Config
+	// AuthenticationKeyCacheSize is the maximum number of operator public keys cached for request authentication.
+	AuthenticationKeyCacheSize int
+	// AuthenticationKeyTimeout is the time after which cached operator public keys are looked up again.
+	AuthenticationKeyTimeout time.Duration
+	// AuthenticationTimestampSkew is how far the timestamp of a request may be from the current time.
+	AuthenticationTimestampSkew time.Duration
+	// OperatorRefreshInterval is the minimum time between lookups of the operator set.
+	OperatorRefreshInterval time.Duration
+
+	EthClientConfig  geth.EthClientConfig
+	ChainStateConfig thegraph.Config
+
+	BLSOperatorStateRetrieverAddr string
+	EigenDAServiceManagerAddr     string
+}
+
+func NewConfig(ctx *cli.Context) (Config, error) {
+	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read logger config: %w", err)
+	}
+	ratelimiterConfig, err := ratelimit.ReadCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read rate limiter config: %w", err)
+	}
+	relayKeys := ctx.GlobalIntSlice(flags.RelayKeysFlag.Name)
+	keys := make([]corev2.RelayKey, len(relayKeys))
+	for i, key := range relayKeys {
+		if key < 0 || key > 65_535 {
+			return Config{}, fmt.Errorf("invalid relay key: %d", key)
+		}
+		keys[i] = corev2.RelayKey(key)
+	}
+
+	config := Config{
+		Log:               *loggerConfig,
+		AWS:               aws.ReadClientConfig(ctx, flags.FlagPrefix),
+		BucketName:        ctx.GlobalString(flags.BucketNameFlag.Name),
+		MetadataTableName: ctx.GlobalString(flags.MetadataTableNameFlag.Name),
+		RelayConfig: relay.Config{
+			GRPCPort:                   ctx.GlobalInt(flags.GRPCPortFlag.Name),
+			MaxGRPCMessageSize:         ctx.GlobalInt(flags.MaxGRPCMessageSizeFlag.Name),
+			RelayKeys:                  keys,
+			MetadataCacheSize:          ctx.GlobalInt(flags.MetadataCacheSizeFlag.Name),
+			BlobCacheSize:              ctx.GlobalInt(flags.BlobCacheSizeFlag.Name),
+			ChunkCacheSize:             ctx.GlobalInt(flags.ChunkCacheSizeFlag.Name),
+			MaxKeysPerGetChunksRequest: ctx.GlobalInt(flags.MaxKeysPerGetChunksRequestFlag.Name),
+			FetchTimeout:               ctx.GlobalDuration(flags.FetchTimeoutFlag.Name),
+			MaxGetChunkOpsPerSecond:    uint32(ctx.GlobalUint(flags.MaxGetChunkOpsPerSecondFlag.Name)),
+			MaxGetChunkBytesPerSecond:  uint32(ctx.GlobalUint(flags.MaxGetChunkBytesPerSecondFlag.Name)),
+		},
+		RatelimiterConfig:             ratelimiterConfig,
+		AuthenticationKeyCacheSize:    ctx.GlobalInt(flags.AuthenticationKeyCacheSizeFlag.Name),
+		AuthenticationKeyTimeout:      ctx.GlobalDuration(flags.AuthenticationKeyTimeoutFlag.Name),
+		AuthenticationTimestampSkew:   ctx.GlobalDuration(flags.AuthenticationTimestampSkewFlag.Name),
+		OperatorRefreshInterval:       ctx.GlobalDuration(flags.OperatorRefreshIntervalFlag.Name),
+		EthClientConfig:               geth.ReadEthClientConfigRPCOnly(ctx),
+		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
+		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
+		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
+	}
+	return config, nil
+}
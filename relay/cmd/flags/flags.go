@@ -0,0 +1,175 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix   = "relay"
+	envVarPrefix = "RELAY"
+)
+
+var (
+	GRPCPortFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "grpc-port"),
+		Usage:    "Port to listen on for gRPC",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GRPC_PORT"),
+	}
+	BucketNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bucket-name"),
+		Usage:    "Name of the s3 bucket to store blobs and chunks",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BUCKET_NAME"),
+	}
+	MetadataTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metadata-table-name"),
+		Usage:    "Name of the dynamodb table to store blob metadata",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METADATA_TABLE_NAME"),
+	}
+	BlsOperatorStateRetrieverFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever"),
+		Usage:    "Address of the BLS Operator State Retriever",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLS_OPERATOR_STATE_RETRIVER"),
+	}
+	EigenDAServiceManagerFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "eigenda-service-manager"),
+		Usage:    "Address of the EigenDA Service Manager",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "EIGENDA_SERVICE_MANAGER"),
+	}
+	RelayKeysFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "relay-keys"),
+		Usage:    "Relay keys to use. If empty, the relay serves all blobs in storage",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RELAY_KEYS"),
+	}
+	MaxGRPCMessageSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-grpc-message-size"),
+		Usage:    "Max size of a gRPC message in bytes",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_GRPC_MESSAGE_SIZE"),
+		Value:    1024 * 1024 * 300,
+	}
+	MetadataCacheSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metadata-cache-size"),
+		Usage:    "Max number of items in the metadata cache",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METADATA_CACHE_SIZE"),
+		Value:    1024 * 1024,
+	}
+	BlobCacheSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-cache-size"),
+		Usage:    "Max number of items in the blob cache",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_CACHE_SIZE"),
+		Value:    32,
+	}
+	ChunkCacheSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-cache-size"),
+		Usage:    "Max number of items in the chunk cache",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_CACHE_SIZE"),
+		Value:    32,
+	}
+	MaxKeysPerGetChunksRequestFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-keys-per-get-chunks-request"),
+		Usage:    "Max number of chunk requests in a single GetChunks request",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_KEYS_PER_GET_CHUNKS_REQUEST"),
+		Value:    1024,
+	}
+	MaxGetChunkOpsPerSecondFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-get-chunk-ops-per-second"),
+		Usage:    "Max number of GetChunks requests per second that a single operator may make",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_GET_CHUNK_OPS_PER_SECOND"),
+		Value:    8,
+	}
+	MaxGetChunkBytesPerSecondFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-get-chunk-bytes-per-second"),
+		Usage:    "Max bandwidth in bytes per second of chunk data served to a single operator",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_GET_CHUNK_BYTES_PER_SECOND"),
+		Value:    20 * 1024 * 1024,
+	}
+	AuthenticationKeyCacheSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "authentication-key-cache-size"),
+		Usage:    "Max number of operator public keys to cache for request authentication",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "AUTHENTICATION_KEY_CACHE_SIZE"),
+		Value:    1024 * 1024,
+	}
+	AuthenticationKeyTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "authentication-key-timeout"),
+		Usage:    "Time after which cached operator public keys are looked up again. Operators that deregistered are served until their cached key times out",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "AUTHENTICATION_KEY_TIMEOUT"),
+		Value:    10 * time.Minute,
+	}
+	AuthenticationTimestampSkewFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "authentication-timestamp-skew"),
+		Usage:    "Max difference between the timestamp of a request and the current time. Requests outside of this window are rejected",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "AUTHENTICATION_TIMESTAMP_SKEW"),
+		Value:    time.Minute,
+	}
+	OperatorRefreshIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-refresh-interval"),
+		Usage:    "Minimum interval between lookups of the operator set when a request comes from an unknown operator",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_REFRESH_INTERVAL"),
+		Value:    time.Minute,
+	}
+	FetchTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "fetch-timeout"),
+		Usage:    "Timeout for fetching data from the metadata store, blob store and chunk store",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FETCH_TIMEOUT"),
+		Value:    10 * time.Second,
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	GRPCPortFlag,
+	BucketNameFlag,
+	MetadataTableNameFlag,
+	BlsOperatorStateRetrieverFlag,
+	EigenDAServiceManagerFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	RelayKeysFlag,
+	MaxGRPCMessageSizeFlag,
+	MetadataCacheSizeFlag,
+	BlobCacheSizeFlag,
+	ChunkCacheSizeFlag,
+	MaxKeysPerGetChunksRequestFlag,
+	FetchTimeoutFlag,
+	MaxGetChunkOpsPerSecondFlag,
+	MaxGetChunkBytesPerSecondFlag,
+	AuthenticationKeyCacheSizeFlag,
+	AuthenticationKeyTimeoutFlag,
+	AuthenticationTimestampSkewFlag,
+	OperatorRefreshIntervalFlag,
+}
+
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(requiredFlags, optionalFlags...)
+	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
+	Flags = append(Flags, thegraph.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, ratelimit.RatelimiterCLIFlags(envVarPrefix, FlagPrefix)...)
+}
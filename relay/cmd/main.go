@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/store"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/relay"
+	"github.com/Layr-Labs/eigenda/relay/auth"
+	"github.com/Layr-Labs/eigenda/relay/chunkstore"
+	"github.com/Layr-Labs/eigenda/relay/cmd/flags"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/urfave/cli"
+)
+
+var (
+	version   string
+	gitCommit string
+	gitDate   string
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Flags = flags.Flags
+	app.Version = fmt.Sprintf("%s-%s-%s", version, gitCommit, gitDate)
+	app.Name = "relay"
+	app.Usage = "EigenDA Relay"
+	app.Description = "EigenDA relay for serving blobs and chunks data"
+
+	app.Action = RunRelay
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Fatalf("application failed: %v", err)
+	}
+	select {}
+}
+
+// RunRelay is the entrypoint for the relay.
+func RunRelay(ctx *cli.Context) error {
+	config, err := NewConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create relay config: %w", err)
+	}
+
+	logger, err := common.NewLogger(config.Log)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	dynamoClient, err := dynamodb.NewClient(config.AWS, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create dynamodb client: %w", err)
+	}
+
+	s3Client, err := s3.NewClient(context.Background(), config.AWS, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 client: %w", err)
+	}
+
+	err = dynamodb.EnsureTable(context.Background(), dynamoClient, blobstore.GenerateTableSchema(config.MetadataTableName, 10, 10))
+	if err != nil {
+		return fmt.Errorf("failed to create metadata table: %w", err)
+	}
+	metadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.MetadataTableName)
+	blobStore := blobstore.NewBlobStore(config.BucketName, s3Client, logger)
+	chunkReader := chunkstore.NewChunkReader(logger, nil, s3Client, config.BucketName, []uint32{})
+
+	gethClient, err := geth.NewMultiHomingClient(config.EthClientConfig, gethcommon.Address{}, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create eth client: %w", err)
+	}
+	tx, err := eth.NewReader(logger, gethClient, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
+	if err != nil {
+		return fmt.Errorf("failed to create chain reader: %w", err)
+	}
+	cs := eth.NewChainState(tx, gethClient)
+	logger.Info("Connecting to subgraph", "url", config.ChainStateConfig.Endpoint)
+	ics := thegraph.MakeIndexedChainState(config.ChainStateConfig, cs, logger)
+	if err := ics.Start(context.Background()); err != nil {
+		return fmt.Errorf("failed to start indexed chain state: %w", err)
+	}
+
+	authenticator, err := auth.NewRequestAuthenticator(
+		ics,
+		config.AuthenticationKeyCacheSize,
+		config.AuthenticationKeyTimeout,
+		config.OperatorRefreshInterval,
+		config.AuthenticationTimestampSkew)
+	if err != nil {
+		return fmt.Errorf("failed to create request authenticator: %w", err)
+	}
+
+	bucketStore, err := store.NewLocalParamStore[common.RateBucketParams](config.RatelimiterConfig.BucketStoreSize)
+	if err != nil {
+		return fmt.Errorf("failed to create bucket store: %w", err)
+	}
+	ratelimiter := ratelimit.NewRateLimiter(prometheus.NewRegistry(), config.RatelimiterConfig.GlobalRateParams, bucketStore, logger)
+
+	server, err := relay.NewServer(
+		context.Background(),
+		logger,
+		&config.RelayConfig,
+		metadataStore,
+		blobStore,
+		chunkReader,
+		authenticator,
+		ratelimiter)
+	if err != nil {
+		return fmt.Errorf("failed to create relay server: %w", err)
+	}
+
+	err = server.Start()
+	if err != nil {
+		return fmt.Errorf("failed to start relay server: %w", err)
+	}
+
+	return nil
+}
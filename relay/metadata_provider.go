@@ -0,0 +1,164 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// ErrBlobNotAssigned is returned when a blob is requested from a relay that it is not assigned to.
+var ErrBlobNotAssigned = errors.New("blob is not assigned to this relay")
+
+// blobMetadata contains the information about a blob that the relay needs in order to serve it.
+type blobMetadata struct {
+	// storageKey is the key under which the data and chunks of the blob are stored. It differs from the key of the
+	// blob when the blob shares its content with another blob.
+	storageKey corev2.BlobKey
+	// fragmentInfo describes how the chunk coefficients of the blob are laid out in the chunk store.
+	fragmentInfo encoding.FragmentInfo
+	// chunkSizeBytes is the size of a serialized chunk of the blob, including its proof.
+	chunkSizeBytes uint32
+}
+
+// metadataProvider encapsulates logic for fetching the metadata of blobs. Only blobs that are assigned to one of
+// the relay keys of this relay are served.
+type metadataProvider struct {
+	ctx    context.Context
+	logger logging.Logger
+
+	// metadataStore is the store where blob certificates are persisted.
+	metadataStore *blobstore.BlobMetadataStore
+	// metadataCache is a cache of blob metadata.
+	metadataCache CachedAccessor[corev2.BlobKey, blobMetadata]
+	// relayKeys is the set of relay keys assigned to this relay. If empty, this relay serves all blobs.
+	relayKeys map[corev2.RelayKey]struct{}
+	// fetchTimeout is the maximum time to wait for a metadata fetch from the metadata store.
+	fetchTimeout time.Duration
+}
+
+// newMetadataProvider creates a new metadataProvider.
+func newMetadataProvider(
+	ctx context.Context,
+	logger logging.Logger,
+	metadataStore *blobstore.BlobMetadataStore,
+	metadataCacheSize int,
+	relayKeys []corev2.RelayKey,
+	fetchTimeout time.Duration) (*metadataProvider, error) {
+
+	relayKeySet := make(map[corev2.RelayKey]struct{}, len(relayKeys))
+	for _, id := range relayKeys {
+		relayKeySet[id] = struct{}{}
+	}
+
+	provider := &metadataProvider{
+		ctx:           ctx,
+		logger:        logger,
+		metadataStore: metadataStore,
+		relayKeys:     relayKeySet,
+		fetchTimeout:  fetchTimeout,
+	}
+
+	metadataCache, err := NewCachedAccessor[corev2.BlobKey, blobMetadata](metadataCacheSize, provider.fetchMetadata)
+	if err != nil {
+		return nil, fmt.Errorf("error creating metadata cache: %w", err)
+	}
+	provider.metadataCache = metadataCache
+
+	return provider, nil
+}
+
+// metadataMap is a map of blob keys to metadata.
+type metadataMap map[corev2.BlobKey]*blobMetadata
+
+// GetMetadataForBlobs retrieves metadata about each blob. Fetches from the cache if available, otherwise from the
+// metadata store. If any of the blobs do not exist or are not assigned to this relay, an error is returned.
+func (m *metadataProvider) GetMetadataForBlobs(keys []corev2.BlobKey) (metadataMap, error) {
+	// Deduplicate the keys, a request may ask for several chunk ranges of the same blob.
+	uniqueKeys := make(map[corev2.BlobKey]struct{}, len(keys))
+	for _, key := range keys {
+		uniqueKeys[key] = struct{}{}
+	}
+
+	type metadataResult struct {
+		key      corev2.BlobKey
+		metadata *blobMetadata
+		err      error
+	}
+
+	results := make(chan *metadataResult, len(uniqueKeys))
+	for key := range uniqueKeys {
+		key := key
+		go func() {
+			metadata, err := m.metadataCache.Get(key)
+			results <- &metadataResult{
+				key:      key,
+				metadata: metadata,
+				err:      err,
+			}
+		}()
+	}
+
+	mMap := make(metadataMap, len(uniqueKeys))
+	var err error
+	for range uniqueKeys {
+		result := <-results
+		if result.err != nil {
+			// Keep draining the channel so that no goroutine is left blocked.
+			if err == nil {
+				err = fmt.Errorf("error fetching metadata for blob %s: %w", result.key.Hex(), result.err)
+			}
+			continue
+		}
+		mMap[result.key] = result.metadata
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return mMap, nil
+}
+
+// fetchMetadata retrieves metadata about a blob from the metadata store. Called when there is a cache miss.
+func (m *metadataProvider) fetchMetadata(key corev2.BlobKey) (*blobMetadata, error) {
+	ctx, cancel := context.WithTimeout(m.ctx, m.fetchTimeout)
+	defer cancel()
+
+	cert, fragmentInfo, storageKey, err := m.metadataStore.GetBlobCertificateWithStorageKey(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving certificate for blob %s: %w", key.Hex(), err)
+	}
+	if fragmentInfo == nil {
+		return nil, fmt.Errorf("missing fragment info for blob %s", key.Hex())
+	}
+
+	if len(m.relayKeys) > 0 {
+		assigned := false
+		for _, relayKey := range cert.RelayKeys {
+			if _, ok := m.relayKeys[relayKey]; ok {
+				assigned = true
+				break
+			}
+		}
+		if !assigned {
+			return nil, fmt.Errorf("%w: blob %s", ErrBlobNotAssigned, key.Hex())
+		}
+	}
+
+	chunkLength, err := corev2.GetChunkLength(cert.BlobHeader.BlobVersion, uint32(cert.BlobHeader.BlobCommitments.Length))
+	if err != nil {
+		return nil, fmt.Errorf("error computing chunk length for blob %s: %w", key.Hex(), err)
+	}
+
+	return &blobMetadata{
+		storageKey:     storageKey,
+		fragmentInfo:   *fragmentInfo,
+		chunkSizeBytes: chunkLength*encoding.BYTES_PER_SYMBOL + bn254.SizeOfG1AffineCompressed,
+	}, nil
+}
@@ -0,0 +1,360 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	dacommon "github.com/Layr-Labs/eigenda/common"
+	healthcheck "github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/relay/auth"
+	"github.com/Layr-Labs/eigenda/relay/chunkstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+var _ pb.RelayServer = &Server{}
+
+// Config is the configuration for the relay Server.
+type Config struct {
+	// GRPCPort is the port that the relay server listens on.
+	GRPCPort int
+	// MaxGRPCMessageSize is the maximum size of a gRPC message that the server will accept.
+	MaxGRPCMessageSize int
+	// RelayKeys contains the keys of the relays that this server is willing to serve data for. If empty, the server
+	// will serve data for any blob it has in storage.
+	RelayKeys []corev2.RelayKey
+	// MetadataCacheSize is the maximum number of items in the metadata cache.
+	MetadataCacheSize int
+	// BlobCacheSize is the maximum number of items in the blob cache.
+	BlobCacheSize int
+	// ChunkCacheSize is the maximum number of items in the chunk cache.
+	ChunkCacheSize int
+	// MaxKeysPerGetChunksRequest is the maximum number of chunk requests allowed in a single GetChunks request.
+	MaxKeysPerGetChunksRequest int
+	// FetchTimeout is the maximum time to wait for a single fetch from the metadata store, blob store or chunk store.
+	FetchTimeout time.Duration
+	// MaxGetChunkOpsPerSecond is the maximum rate of GetChunks requests that a single operator may make.
+	MaxGetChunkOpsPerSecond dacommon.RateParam
+	// MaxGetChunkBytesPerSecond is the maximum bandwidth, in bytes per second, of chunk data that is served to a
+	// single operator.
+	MaxGetChunkBytesPerSecond dacommon.RateParam
+}
+
+// DefaultConfig returns the default configuration for the relay Server.
+func DefaultConfig() *Config {
+	return &Config{
+		GRPCPort:                   50051,
+		MaxGRPCMessageSize:         1024 * 1024 * 300, // 300 MiB
+		MetadataCacheSize:          1024 * 1024,
+		BlobCacheSize:              32,
+		ChunkCacheSize:             32,
+		MaxKeysPerGetChunksRequest: 1024,
+		FetchTimeout:               10 * time.Second,
+		MaxGetChunkOpsPerSecond:    8,
+		MaxGetChunkBytesPerSecond:  20 * 1024 * 1024, // 20 MiB/s
+	}
+}
+
+// Server implements the Relay service defined in api/proto/relay/relay.proto
+type Server struct {
+	pb.UnimplementedRelayServer
+
+	config *Config
+	logger logging.Logger
+
+	// metadataProvider encapsulates logic for fetching metadata for blobs.
+	metadataProvider *metadataProvider
+	// blobProvider encapsulates logic for fetching blobs.
+	blobProvider *blobProvider
+	// chunkProvider encapsulates logic for fetching chunks.
+	chunkProvider *chunkProvider
+
+	// authenticator verifies that GetChunks requests are signed by the operator that makes them.
+	authenticator auth.RequestAuthenticator
+	// ratelimiter enforces the per-operator request rate and bandwidth limits of GetChunks requests.
+	ratelimiter dacommon.RateLimiter
+	// mu serializes the rate limiter updates, which are not atomic per requester.
+	mu sync.Mutex
+
+	// grpcServer is the gRPC server, set when the server is started.
+	grpcServer *grpc.Server
+}
+
+// NewServer creates a new relay Server.
+func NewServer(
+	ctx context.Context,
+	logger logging.Logger,
+	config *Config,
+	metadataStore *blobstore.BlobMetadataStore,
+	blobStore *blobstore.BlobStore,
+	chunkReader chunkstore.ChunkReader,
+	authenticator auth.RequestAuthenticator,
+	ratelimiter dacommon.RateLimiter) (*Server, error) {
+
+	logger = logger.With("component", "RelayServer")
+
+	mp, err := newMetadataProvider(ctx, logger, metadataStore, config.MetadataCacheSize, config.RelayKeys, config.FetchTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("error creating metadata provider: %w", err)
+	}
+
+	bp, err := newBlobProvider(ctx, logger, blobStore, config.BlobCacheSize, config.FetchTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("error creating blob provider: %w", err)
+	}
+
+	cp, err := newChunkProvider(ctx, logger, chunkReader, config.ChunkCacheSize, config.FetchTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("error creating chunk provider: %w", err)
+	}
+
+	return &Server{
+		config:           config,
+		logger:           logger,
+		metadataProvider: mp,
+		blobProvider:     bp,
+		chunkProvider:    cp,
+		authenticator:    authenticator,
+		ratelimiter:      ratelimiter,
+	}, nil
+}
+
+// GetBlob retrieves a blob stored by the relay.
+func (s *Server) GetBlob(ctx context.Context, request *pb.GetBlobRequest) (*pb.GetBlobReply, error) {
+	key, err := corev2.BytesToBlobKey(request.GetBlobKey())
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid blob key: %v", err))
+	}
+
+	// Make sure the blob is assigned to this relay before serving it.
+	mMap, err := s.metadataProvider.GetMetadataForBlobs([]corev2.BlobKey{key})
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	data, err := s.blobProvider.GetBlob(mMap[key].storageKey)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	return &pb.GetBlobReply{
+		Blob: data,
+	}, nil
+}
+
+// GetChunks retrieves chunks from blobs stored by the relay. Each chunk request is answered with a serialized
+// bundle containing the requested chunks, in the same order as the requests. Only requests signed by a registered
+// operator are served, subject to the per-operator rate limits.
+func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*pb.GetChunksReply, error) {
+	if len(request.GetChunkRequests()) == 0 {
+		return nil, api.NewErrorInvalidArg("no chunk requests provided")
+	}
+	if len(request.GetChunkRequests()) > s.config.MaxKeysPerGetChunksRequest {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf(
+			"too many chunk requests provided, max is %d", s.config.MaxKeysPerGetChunksRequest))
+	}
+
+	operatorID, err := s.authenticator.AuthenticateGetChunksRequest(ctx, request)
+	if err != nil {
+		return nil, api.NewErrorUnauthenticated(fmt.Sprintf("failed to authenticate request: %v", err))
+	}
+
+	err = s.allowRequest(ctx, operatorID, operatorID.Hex()+":ops", 1, s.config.MaxGetChunkOpsPerSecond)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]corev2.BlobKey, 0, len(request.GetChunkRequests()))
+	for _, chunkRequest := range request.GetChunkRequests() {
+		var blobKeyBytes []byte
+		if chunkRequest.GetByIndex() != nil {
+			blobKeyBytes = chunkRequest.GetByIndex().GetBlobKey()
+		} else if chunkRequest.GetByRange() != nil {
+			blobKeyBytes = chunkRequest.GetByRange().GetBlobKey()
+		} else {
+			return nil, api.NewErrorInvalidArg("chunk request must be either by index or by range")
+		}
+
+		key, err := corev2.BytesToBlobKey(blobKeyBytes)
+		if err != nil {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid blob key: %v", err))
+		}
+		keys = append(keys, key)
+	}
+
+	mMap, err := s.metadataProvider.GetMetadataForBlobs(keys)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	// The bandwidth is charged before the chunks are fetched, so that an operator that exceeds its limit can't make
+	// the relay read from the chunk store.
+	size := requestedChunkBytes(mMap, keys, request)
+	err = s.allowRequest(ctx, operatorID, operatorID.Hex()+":bytes", size, s.config.MaxGetChunkBytesPerSecond)
+	if err != nil {
+		return nil, err
+	}
+
+	frames, err := s.chunkProvider.GetFrames(mMap)
+	if err != nil {
+		return nil, toGRPCError(err)
+	}
+
+	data, err := gatherChunkDataToSend(frames, keys, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetChunksReply{
+		Data: data,
+	}, nil
+}
+
+// allowRequest applies the rate limit with the given rate to a request of the given size. The request rate and
+// the bandwidth of an operator are limited independently, so each of them is tracked under its own requester ID.
+func (s *Server) allowRequest(
+	ctx context.Context,
+	operatorID core.OperatorID,
+	requesterID dacommon.RequesterID,
+	size uint,
+	rate dacommon.RateParam) error {
+
+	params := []dacommon.RequestParams{
+		{
+			RequesterID: requesterID,
+			BlobSize:    size,
+			Rate:        rate,
+		},
+	}
+
+	s.mu.Lock()
+	allowed, _, err := s.ratelimiter.AllowRequest(ctx, params)
+	s.mu.Unlock()
+	if err != nil {
+		return api.NewErrorInternal(fmt.Sprintf("failed to apply rate limit: %v", err))
+	}
+	if !allowed {
+		return api.NewErrorResourceExhausted(fmt.Sprintf("rate limit exceeded for operator %s", operatorID.Hex()))
+	}
+	return nil
+}
+
+// gatherChunkDataToSend takes the chunks fetched from the chunk store and arranges them into the format expected
+// by the client. The i-th entry of the returned slice is the serialized bundle for the i-th chunk request.
+func gatherChunkDataToSend(
+	frames frameMap,
+	keys []corev2.BlobKey,
+	request *pb.GetChunksRequest) ([][]byte, error) {
+
+	data := make([][]byte, 0, len(keys))
+	for i, chunkRequest := range request.GetChunkRequests() {
+		key := keys[i]
+		framesToSend, err := selectFrames(frames[key], chunkRequest)
+		if err != nil {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid chunk request for blob %s: %v", key.Hex(), err))
+		}
+
+		bundle, err := serializeFrames(framesToSend)
+		if err != nil {
+			return nil, api.NewErrorInternal(fmt.Sprintf("failed to serialize chunks of blob %s: %v", key.Hex(), err))
+		}
+		data = append(data, bundle)
+	}
+
+	return data, nil
+}
+
+// requestedChunkBytes returns the size of the chunks requested by the given request, as computed from the metadata
+// of the blobs. Invalid chunk requests are rejected once the chunks are fetched, and aren't counted.
+func requestedChunkBytes(mMap metadataMap, keys []corev2.BlobKey, request *pb.GetChunksRequest) uint {
+	size := uint(0)
+	for i, chunkRequest := range request.GetChunkRequests() {
+		numChunks := uint(0)
+		if chunkRequest.GetByIndex() != nil {
+			numChunks = uint(len(chunkRequest.GetByIndex().GetChunkIndices()))
+		} else if chunkRequest.GetByRange().GetEndIndex() > chunkRequest.GetByRange().GetStartIndex() {
+			numChunks = uint(chunkRequest.GetByRange().GetEndIndex() - chunkRequest.GetByRange().GetStartIndex())
+		}
+		size += numChunks * uint(mMap[keys[i]].chunkSizeBytes)
+	}
+	return size
+}
+
+// selectFrames returns the frames of a blob that were requested by the given chunk request.
+func selectFrames(frames []*encoding.Frame, chunkRequest *pb.ChunkRequest) ([]*encoding.Frame, error) {
+	if chunkRequest.GetByIndex() != nil {
+		indices := chunkRequest.GetByIndex().GetChunkIndices()
+		selected := make([]*encoding.Frame, 0, len(indices))
+		for _, index := range indices {
+			if index >= uint32(len(frames)) {
+				return nil, fmt.Errorf("chunk index %d out of range, blob has %d chunks", index, len(frames))
+			}
+			selected = append(selected, frames[index])
+		}
+		return selected, nil
+	}
+
+	start := chunkRequest.GetByRange().GetStartIndex()
+	end := chunkRequest.GetByRange().GetEndIndex()
+	if start >= end {
+		return nil, fmt.Errorf("chunk range [%d, %d) is empty", start, end)
+	}
+	if end > uint32(len(frames)) {
+		return nil, fmt.Errorf("chunk range [%d, %d) out of range, blob has %d chunks", start, end, len(frames))
+	}
+	return frames[start:end], nil
+}
+
+// toGRPCError converts an error returned by one of the providers into the appropriate gRPC error.
+func toGRPCError(err error) error {
+	if errors.Is(err, common.ErrMetadataNotFound) || errors.Is(err, common.ErrBlobNotFound) || errors.Is(err, ErrBlobNotAssigned) {
+		return api.NewErrorNotFound(err.Error())
+	}
+	return api.NewErrorInternal(err.Error())
+}
+
+// Start starts the server listening for requests. This method will block until the server is stopped.
+func (s *Server) Start() error {
+	addr := fmt.Sprintf("0.0.0.0:%d", s.config.GRPCPort)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("could not start tcp listener on %s: %w", addr, err)
+	}
+
+	opt := grpc.MaxRecvMsgSize(s.config.MaxGRPCMessageSize)
+
+	s.grpcServer = grpc.NewServer(opt)
+	reflection.Register(s.grpcServer)
+	pb.RegisterRelayServer(s.grpcServer, s)
+
+	// Register Server for Health Checks
+	name := pb.Relay_ServiceDesc.ServiceName
+	healthcheck.RegisterHealthServer(name, s.grpcServer)
+
+	s.logger.Info("GRPC Listening", "port", s.config.GRPCPort, "address", listener.Addr().String())
+
+	if err := s.grpcServer.Serve(listener); err != nil {
+		return fmt.Errorf("could not start GRPC server: %w", err)
+	}
+
+	return nil
+}
+
+// Stop stops the server.
+func (s *Server) Stop() {
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+}
@@ -0,0 +1,443 @@
+package relay
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	dacommon "github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/store"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigenda/relay/auth"
+	"github.com/Layr-Labs/eigenda/relay/chunkstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/prometheus/client_golang/prometheus"
+	tmock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testBucket            = "test-relay-bucket"
+	testMetadataTableName = "test-relay-metadata"
+)
+
+type testComponents struct {
+	server       *Server
+	dynamoClient *mock.MockDynamoDBClient
+	blobStore    *blobstore.BlobStore
+	chunkWriter  chunkstore.ChunkWriter
+	chainState   *coremock.ChainDataMock
+}
+
+func newTestComponents(t *testing.T, relayKeys []corev2.RelayKey) *testComponents {
+	config := DefaultConfig()
+	config.RelayKeys = relayKeys
+	return newTestComponentsWithConfig(t, config)
+}
+
+func newTestComponentsWithConfig(t *testing.T, config *Config) *testComponents {
+	logger := logging.NewNoopLogger()
+	s3Client := mock.NewS3Client()
+	dynamoClient := &mock.MockDynamoDBClient{}
+
+	metadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, testMetadataTableName)
+	blobStore := blobstore.NewBlobStore(testBucket, s3Client, logger)
+	chunkReader := chunkstore.NewChunkReader(logger, nil, s3Client, testBucket, []uint32{})
+	chunkWriter := chunkstore.NewChunkWriter(logger, s3Client, testBucket, 1024)
+
+	chainState, err := coremock.MakeChainDataMock(map[core.QuorumID]int{0: 2})
+	require.NoError(t, err)
+	chainState.Mock.On("GetCurrentBlockNumber").Return(uint(100), nil)
+	authenticator, err := auth.NewRequestAuthenticator(chainState, 1024, time.Hour, time.Minute, time.Minute)
+	require.NoError(t, err)
+
+	globalParams := dacommon.GlobalRateParams{
+		BucketSizes: []time.Duration{time.Second},
+		Multipliers: []float32{1},
+		CountFailed: true,
+	}
+	bucketStore, err := store.NewLocalParamStore[dacommon.RateBucketParams](1024)
+	require.NoError(t, err)
+	ratelimiter := ratelimit.NewRateLimiter(prometheus.NewRegistry(), globalParams, bucketStore, logger)
+
+	server, err := NewServer(
+		context.Background(),
+		logger,
+		config,
+		metadataStore,
+		blobStore,
+		chunkReader,
+		authenticator,
+		ratelimiter)
+	require.NoError(t, err)
+
+	return &testComponents{
+		server:       server,
+		dynamoClient: dynamoClient,
+		blobStore:    blobStore,
+		chunkWriter:  chunkWriter,
+		chainState:   chainState,
+	}
+}
+
+// signedRequest returns a GetChunksRequest for the given chunk requests, signed by the operator with the given index.
+func (c *testComponents) signedRequest(operatorIndex int, chunkRequests ...*pb.ChunkRequest) *pb.GetChunksRequest {
+	operatorID := coremock.MakeOperatorId(operatorIndex)
+	request := &pb.GetChunksRequest{
+		ChunkRequests: chunkRequests,
+		RequesterId:   operatorID[:],
+		Timestamp:     uint64(time.Now().UnixNano()),
+	}
+	request.RequesterSignature = auth.SignGetChunksRequest(c.chainState.KeyPairs[operatorID], request)
+	return request
+}
+
+// randomBlobCertificate creates a blob certificate for a random blob that is assigned to the given relays.
+func randomBlobCertificate(t *testing.T, relayKeys []corev2.RelayKey) *corev2.BlobCertificate {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	return &corev2.BlobCertificate{
+		BlobHeader: &corev2.BlobHeader{
+			BlobVersion: 0,
+			BlobCommitments: encoding.BlobCommitments{
+				Commitment:       (*encoding.G1Commitment)(&g1Gen),
+				LengthCommitment: (*encoding.G2Commitment)(&g2Gen),
+				LengthProof:      (*encoding.G2Commitment)(&g2Gen),
+				// The chunks of the blob have 4 symbols, like the frames made by randomFrames
+				Length: 4096,
+			},
+			QuorumNumbers: []core.QuorumID{0},
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID:         tu.RandomString(10),
+				BinIndex:          5,
+				CumulativePayment: big.NewInt(100),
+			},
+		},
+		RelayKeys: relayKeys,
+	}
+}
+
+// randomFrames creates the given number of frames with random coefficients.
+func randomFrames(count int) []*encoding.Frame {
+	_, _, g1Gen, _ := bn254.Generators()
+	frames := make([]*encoding.Frame, count)
+	for i := range frames {
+		coeffs := make([]fr.Element, 4)
+		for j := range coeffs {
+			coeffs[j].SetRandom()
+		}
+		frames[i] = &encoding.Frame{
+			Proof:  g1Gen,
+			Coeffs: coeffs,
+		}
+	}
+	return frames
+}
+
+// blobCertificateKey returns the dynamodb key under which the certificate of the given blob is stored.
+func blobCertificateKey(blobKey corev2.BlobKey) dynamodb.Key {
+	return dynamodb.Key{
+		"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + blobKey.Hex()},
+		"SK": &types.AttributeValueMemberS{Value: "BlobCertificate"},
+	}
+}
+
+// putBlob stores the blob data, its frames and its certificate, and returns the blob key.
+func (c *testComponents) putBlob(
+	t *testing.T,
+	cert *corev2.BlobCertificate,
+	data []byte,
+	frames []*encoding.Frame) corev2.BlobKey {
+
+	ctx := context.Background()
+	blobKey, err := cert.BlobHeader.BlobKey()
+	require.NoError(t, err)
+
+	err = c.blobStore.StoreBlob(ctx, blobKey, data)
+	require.NoError(t, err)
+
+	proofs := make([]*encoding.Proof, len(frames))
+	coefficients := make([]*rs.Frame, len(frames))
+	for i, frame := range frames {
+		proof := frame.Proof
+		proofs[i] = &proof
+		coefficients[i] = &rs.Frame{Coeffs: frame.Coeffs}
+	}
+	err = c.chunkWriter.PutChunkProofs(ctx, blobKey, proofs)
+	require.NoError(t, err)
+	fragmentInfo, err := c.chunkWriter.PutChunkCoefficients(ctx, blobKey, coefficients)
+	require.NoError(t, err)
+
+	item, err := blobstore.MarshalBlobCertificate(cert, fragmentInfo)
+	require.NoError(t, err)
+	c.dynamoClient.On("GetItem", tmock.Anything, testMetadataTableName, blobCertificateKey(blobKey)).Return(item, nil)
+
+	return blobKey
+}
+
+func requireStatusCode(t *testing.T, err error, code codes.Code) {
+	require.Error(t, err)
+	s, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, s.Code())
+}
+
+func TestGetBlob(t *testing.T) {
+	tu.InitializeRandom()
+	c := newTestComponents(t, []corev2.RelayKey{1})
+
+	data := tu.RandomBytes(1024)
+	blobKey := c.putBlob(t, randomBlobCertificate(t, []corev2.RelayKey{0, 1}), data, randomFrames(4))
+
+	// Fetch twice, the second fetch is served from the cache.
+	for i := 0; i < 2; i++ {
+		reply, err := c.server.GetBlob(context.Background(), &pb.GetBlobRequest{BlobKey: blobKey[:]})
+		require.NoError(t, err)
+		require.Equal(t, data, reply.GetBlob())
+	}
+	c.dynamoClient.AssertNumberOfCalls(t, "GetItem", 1)
+}
+
+func TestGetBlobErrors(t *testing.T) {
+	tu.InitializeRandom()
+	c := newTestComponents(t, []corev2.RelayKey{1})
+
+	// Invalid blob key
+	_, err := c.server.GetBlob(context.Background(), &pb.GetBlobRequest{BlobKey: []byte{1, 2, 3}})
+	requireStatusCode(t, err, codes.InvalidArgument)
+
+	// Unknown blob
+	unknownKey := corev2.BlobKey(tu.RandomBytes(32))
+	c.dynamoClient.On("GetItem", tmock.Anything, testMetadataTableName, blobCertificateKey(unknownKey)).Return(nil, nil)
+	_, err = c.server.GetBlob(context.Background(), &pb.GetBlobRequest{BlobKey: unknownKey[:]})
+	requireStatusCode(t, err, codes.NotFound)
+
+	// Blob assigned to another relay
+	blobKey := c.putBlob(t, randomBlobCertificate(t, []corev2.RelayKey{2}), tu.RandomBytes(128), randomFrames(4))
+	_, err = c.server.GetBlob(context.Background(), &pb.GetBlobRequest{BlobKey: blobKey[:]})
+	requireStatusCode(t, err, codes.NotFound)
+}
+
+func TestGetBlobWithoutRelayKeys(t *testing.T) {
+	tu.InitializeRandom()
+
+	// A server without relay keys serves every blob in storage.
+	c := newTestComponents(t, nil)
+	data := tu.RandomBytes(128)
+	blobKey := c.putBlob(t, randomBlobCertificate(t, []corev2.RelayKey{2}), data, randomFrames(4))
+
+	reply, err := c.server.GetBlob(context.Background(), &pb.GetBlobRequest{BlobKey: blobKey[:]})
+	require.NoError(t, err)
+	require.Equal(t, data, reply.GetBlob())
+}
+
+func TestGetChunks(t *testing.T) {
+	tu.InitializeRandom()
+	c := newTestComponents(t, []corev2.RelayKey{0})
+
+	frames1 := randomFrames(8)
+	blobKey1 := c.putBlob(t, randomBlobCertificate(t, []corev2.RelayKey{0}), tu.RandomBytes(64), frames1)
+	frames2 := randomFrames(16)
+	blobKey2 := c.putBlob(t, randomBlobCertificate(t, []corev2.RelayKey{0}), tu.RandomBytes(64), frames2)
+
+	reply, err := c.server.GetChunks(context.Background(), c.signedRequest(0,
+		&pb.ChunkRequest{
+			Request: &pb.ChunkRequest_ByRange{
+				ByRange: &pb.ChunkRequestByRange{BlobKey: blobKey1[:], StartIndex: 2, EndIndex: 5},
+			},
+		},
+		&pb.ChunkRequest{
+			Request: &pb.ChunkRequest_ByIndex{
+				ByIndex: &pb.ChunkRequestByIndex{BlobKey: blobKey2[:], ChunkIndices: []uint32{15, 0, 7}},
+			},
+		},
+		&pb.ChunkRequest{
+			Request: &pb.ChunkRequest_ByRange{
+				ByRange: &pb.ChunkRequestByRange{BlobKey: blobKey1[:], StartIndex: 0, EndIndex: 8},
+			},
+		},
+	))
+	require.NoError(t, err)
+	require.Len(t, reply.GetData(), 3)
+
+	expected := [][]*encoding.Frame{
+		frames1[2:5],
+		{frames2[15], frames2[0], frames2[7]},
+		frames1,
+	}
+	for i, data := range reply.GetData() {
+		bundle, err := new(core.Bundle).Deserialize(data)
+		require.NoError(t, err)
+		require.Len(t, bundle, len(expected[i]))
+		for j, frame := range bundle {
+			require.Equal(t, expected[i][j].Proof, frame.Proof)
+			require.Equal(t, expected[i][j].Coeffs, frame.Coeffs)
+		}
+	}
+
+	// Each blob's metadata is only fetched once.
+	c.dynamoClient.AssertNumberOfCalls(t, "GetItem", 2)
+}
+
+func TestGetChunksErrors(t *testing.T) {
+	tu.InitializeRandom()
+	c := newTestComponents(t, []corev2.RelayKey{0})
+
+	blobKey := c.putBlob(t, randomBlobCertificate(t, []corev2.RelayKey{0}), tu.RandomBytes(64), randomFrames(8))
+	otherKey := c.putBlob(t, randomBlobCertificate(t, []corev2.RelayKey{1}), tu.RandomBytes(64), randomFrames(8))
+
+	byRange := func(key corev2.BlobKey, start uint32, end uint32) *pb.ChunkRequest {
+		return &pb.ChunkRequest{
+			Request: &pb.ChunkRequest_ByRange{
+				ByRange: &pb.ChunkRequestByRange{BlobKey: key[:], StartIndex: start, EndIndex: end},
+			},
+		}
+	}
+
+	// No requests
+	_, err := c.server.GetChunks(context.Background(), &pb.GetChunksRequest{})
+	requireStatusCode(t, err, codes.InvalidArgument)
+
+	// Too many requests
+	requests := make([]*pb.ChunkRequest, c.server.config.MaxKeysPerGetChunksRequest+1)
+	for i := range requests {
+		requests[i] = byRange(blobKey, 0, 1)
+	}
+	_, err = c.server.GetChunks(context.Background(), &pb.GetChunksRequest{ChunkRequests: requests})
+	requireStatusCode(t, err, codes.InvalidArgument)
+
+	// Empty request
+	_, err = c.server.GetChunks(context.Background(), c.signedRequest(0, &pb.ChunkRequest{}))
+	requireStatusCode(t, err, codes.InvalidArgument)
+
+	// Out of range
+	_, err = c.server.GetChunks(context.Background(), c.signedRequest(0, byRange(blobKey, 4, 9)))
+	requireStatusCode(t, err, codes.InvalidArgument)
+	_, err = c.server.GetChunks(context.Background(), c.signedRequest(0, byRange(blobKey, 4, 4)))
+	requireStatusCode(t, err, codes.InvalidArgument)
+	_, err = c.server.GetChunks(context.Background(), c.signedRequest(0, &pb.ChunkRequest{
+		Request: &pb.ChunkRequest_ByIndex{
+			ByIndex: &pb.ChunkRequestByIndex{BlobKey: blobKey[:], ChunkIndices: []uint32{8}},
+		},
+	}))
+	requireStatusCode(t, err, codes.InvalidArgument)
+
+	// Blob assigned to another relay
+	_, err = c.server.GetChunks(context.Background(), c.signedRequest(0, byRange(blobKey, 0, 1), byRange(otherKey, 0, 1)))
+	requireStatusCode(t, err, codes.NotFound)
+}
+
+func TestGetChunksAuthentication(t *testing.T) {
+	tu.InitializeRandom()
+	c := newTestComponents(t, []corev2.RelayKey{0})
+
+	blobKey := c.putBlob(t, randomBlobCertificate(t, []corev2.RelayKey{0}), tu.RandomBytes(64), randomFrames(8))
+	chunkRequest := &pb.ChunkRequest{
+		Request: &pb.ChunkRequest_ByRange{
+			ByRange: &pb.ChunkRequestByRange{BlobKey: blobKey[:], StartIndex: 0, EndIndex: 4},
+		},
+	}
+
+	// Unsigned request
+	_, err := c.server.GetChunks(context.Background(), &pb.GetChunksRequest{
+		ChunkRequests: []*pb.ChunkRequest{chunkRequest},
+	})
+	requireStatusCode(t, err, codes.Unauthenticated)
+
+	// Signed by a different operator than the requester
+	request := c.signedRequest(0, chunkRequest)
+	operatorID := coremock.MakeOperatorId(1)
+	request.RequesterId = operatorID[:]
+	_, err = c.server.GetChunks(context.Background(), request)
+	requireStatusCode(t, err, codes.Unauthenticated)
+
+	// Signed by an unregistered operator
+	keys, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	unknownID := keys.GetPubKeyG1().GetOperatorID()
+	request = &pb.GetChunksRequest{
+		ChunkRequests: []*pb.ChunkRequest{chunkRequest},
+		RequesterId:   unknownID[:],
+		Timestamp:     uint64(time.Now().UnixNano()),
+	}
+	request.RequesterSignature = auth.SignGetChunksRequest(keys, request)
+	_, err = c.server.GetChunks(context.Background(), request)
+	requireStatusCode(t, err, codes.Unauthenticated)
+
+	// Both registered operators are served
+	for i := 0; i < 2; i++ {
+		reply, err := c.server.GetChunks(context.Background(), c.signedRequest(i, chunkRequest))
+		require.NoError(t, err)
+		require.Len(t, reply.GetData(), 1)
+	}
+}
+
+func TestGetChunksRateLimit(t *testing.T) {
+	tu.InitializeRandom()
+
+	config := DefaultConfig()
+	config.RelayKeys = []corev2.RelayKey{0}
+	config.MaxGetChunkOpsPerSecond = 2
+	c := newTestComponentsWithConfig(t, config)
+
+	blobKey := c.putBlob(t, randomBlobCertificate(t, []corev2.RelayKey{0}), tu.RandomBytes(64), randomFrames(8))
+	chunkRequest := &pb.ChunkRequest{
+		Request: &pb.ChunkRequest_ByRange{
+			ByRange: &pb.ChunkRequestByRange{BlobKey: blobKey[:], StartIndex: 0, EndIndex: 4},
+		},
+	}
+
+	// The bucket holds one second worth of requests, the third request exceeds the rate of the operator.
+	for i := 0; i < 2; i++ {
+		_, err := c.server.GetChunks(context.Background(), c.signedRequest(0, chunkRequest))
+		require.NoError(t, err)
+	}
+	_, err := c.server.GetChunks(context.Background(), c.signedRequest(0, chunkRequest))
+	requireStatusCode(t, err, codes.ResourceExhausted)
+
+	// The limit is per operator.
+	_, err = c.server.GetChunks(context.Background(), c.signedRequest(1, chunkRequest))
+	require.NoError(t, err)
+}
+
+func TestGetChunksBandwidthLimit(t *testing.T) {
+	tu.InitializeRandom()
+
+	frames := randomFrames(8)
+	bundle, err := serializeFrames(frames)
+	require.NoError(t, err)
+
+	config := DefaultConfig()
+	config.RelayKeys = []corev2.RelayKey{0}
+	config.MaxGetChunkOpsPerSecond = 1000
+	// Allow a bit more than one full bundle per second.
+	config.MaxGetChunkBytesPerSecond = uint32(len(bundle) * 3 / 2)
+	c := newTestComponentsWithConfig(t, config)
+
+	blobKey := c.putBlob(t, randomBlobCertificate(t, []corev2.RelayKey{0}), tu.RandomBytes(64), frames)
+	chunkRequest := &pb.ChunkRequest{
+		Request: &pb.ChunkRequest_ByRange{
+			ByRange: &pb.ChunkRequestByRange{BlobKey: blobKey[:], StartIndex: 0, EndIndex: 8},
+		},
+	}
+
+	_, err = c.server.GetChunks(context.Background(), c.signedRequest(0, chunkRequest))
+	require.NoError(t, err)
+	_, err = c.server.GetChunks(context.Background(), c.signedRequest(0, chunkRequest))
+	requireStatusCode(t, err, codes.ResourceExhausted)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/allowlist"
+	"github.com/Layr-Labs/eigenda/tools/allowlistdiff"
+	"github.com/Layr-Labs/eigenda/tools/allowlistdiff/flags"
+	"github.com/urfave/cli"
+)
+
+var (
+	version   = ""
+	gitCommit = ""
+	gitDate   = ""
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s,%s,%s", version, gitCommit, gitDate)
+	app.Name = "allowlistdiff"
+	app.Description = "validate a candidate disperser allowlist and show how it differs from the current one"
+	app.Usage = ""
+	app.Flags = flags.Flags
+	app.Action = RunDiff
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func RunDiff(ctx *cli.Context) error {
+	config := allowlistdiff.ReadConfig(ctx)
+
+	candidate, err := readAllowlist(config.CandidateFile)
+	if err != nil {
+		return fmt.Errorf("candidate allowlist is invalid: %w", err)
+	}
+
+	current := make(allowlist.Allowlist)
+	if config.CurrentFile != "" {
+		current, err = readAllowlist(config.CurrentFile)
+		if err != nil {
+			return fmt.Errorf("current allowlist is invalid, the disperser is using the last valid version of it: %w", err)
+		}
+	}
+
+	changes := allowlist.Diff(current, candidate)
+	counts := make(map[allowlist.ChangeKind]int)
+	for _, change := range changes {
+		fmt.Println(change.String())
+		counts[change.Kind]++
+	}
+	fmt.Printf("%d added, %d modified, %d removed\n",
+		counts[allowlist.Added], counts[allowlist.Modified], counts[allowlist.Removed])
+
+	if counts[allowlist.Removed] > 0 && !config.AllowRemovals {
+		return fmt.Errorf("candidate allowlist removes %d entries, rerun with --%s if this is intended",
+			counts[allowlist.Removed], flags.AllowRemovalsFlag.Name)
+	}
+	return nil
+}
+
+// readAllowlist reads the allowlist file at the given path. Problems with the file are printed in a
+// "path:line: message" format that editors can jump to.
+func readAllowlist(path string) (allowlist.Allowlist, error) {
+	al, err := allowlist.ReadFile(path)
+	var validationErr *allowlist.ValidationError
+	if errors.As(err, &validationErr) {
+		for _, lineErr := range validationErr.Errors {
+			fmt.Fprintf(os.Stderr, "%s:%d: %s\n", path, lineErr.Line, lineErr.Message)
+		}
+		return nil, fmt.Errorf("%d problems found in %s", len(validationErr.Errors), path)
+	}
+	return al, err
+}
@@ -0,0 +1,23 @@
+package allowlistdiff
+
+import (
+	"github.com/Layr-Labs/eigenda/tools/allowlistdiff/flags"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	// The path to the candidate allowlist file.
+	CandidateFile string
+	// The path to the allowlist file currently used by the disperser. Empty if there is none.
+	CurrentFile string
+	// If true, removing accounts from the allowlist is not treated as an error.
+	AllowRemovals bool
+}
+
+func ReadConfig(ctx *cli.Context) *Config {
+	return &Config{
+		CandidateFile: ctx.String(flags.CandidateFileFlag.Name),
+		CurrentFile:   ctx.String(flags.CurrentFileFlag.Name),
+		AllowRemovals: ctx.Bool(flags.AllowRemovalsFlag.Name),
+	}
+}
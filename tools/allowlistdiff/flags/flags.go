@@ -0,0 +1,51 @@
+package flags
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = ""
+	envPrefix  = "ALLOWLISTDIFF"
+)
+
+var (
+	/* Required Flags*/
+	CandidateFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "candidate"),
+		Usage:    "Path to the candidate allowlist file to validate",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CANDIDATE"),
+	}
+	/* Optional Flags*/
+	CurrentFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "current"),
+		Usage:    "Path to the allowlist file currently used by the disperser. If empty, every entry of the candidate is reported as added",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CURRENT"),
+		Value:    "",
+	}
+	AllowRemovalsFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "allow-removals"),
+		Usage:    "Succeed even if the candidate removes accounts from the allowlist (default: false)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "ALLOW_REMOVALS"),
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	CandidateFileFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	CurrentFileFlag,
+	AllowRemovalsFlag,
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(requiredFlags, optionalFlags...)
+}
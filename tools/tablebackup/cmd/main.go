@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigenda/tools/tablebackup"
+	"github.com/Layr-Labs/eigenda/tools/tablebackup/flags"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli"
+)
+
+var (
+	version   = ""
+	gitCommit = ""
+	gitDate   = ""
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s,%s,%s", version, gitCommit, gitDate)
+	app.Name = "tablebackup"
+	app.Description = "snapshot and restore a table store, to and from a local file or S3"
+	app.Usage = ""
+	app.Flags = flags.Flags
+	app.Commands = []cli.Command{
+		{
+			Name:   "snapshot",
+			Usage:  "write a snapshot of the store",
+			Action: RunSnapshot,
+		},
+		{
+			Name:   "restore",
+			Usage:  "restore a snapshot into an empty store",
+			Action: RunRestore,
+		},
+	}
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func setup(ctx *cli.Context) (*tablebackup.Config, logging.Logger, s3.Client, error) {
+	config, err := tablebackup.NewConfig(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	logger, err := common.NewLogger(config.LoggerConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if config.S3Bucket == "" {
+		return config, logger, nil, nil
+	}
+	client, err := s3.NewClient(context.Background(), config.AwsConfig, logger)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+	return config, logger, client, nil
+}
+
+func RunSnapshot(ctx *cli.Context) error {
+	config, logger, client, err := setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	storePath := config.StorePath
+	if config.Live {
+		storePath, err = tablebackup.CopyLiveStore(logger, config.StorePath, config.LiveCopyAttempts)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			err := os.RemoveAll(storePath)
+			if err != nil {
+				logger.Warn("failed to remove copy of store", "path", storePath, "err", err)
+			}
+		}()
+	}
+
+	var writer io.WriteCloser
+	if client != nil {
+		writer = tablestore.NewS3SnapshotWriter(
+			context.Background(), client, config.S3Bucket, config.S3Key, config.S3PartSize)
+	} else {
+		writer, err = os.Create(config.FilePath)
+		if err != nil {
+			return fmt.Errorf("failed to create snapshot file: %w", err)
+		}
+	}
+
+	err = tablestore.SnapshotStore(logger, config.StoreConfig(storePath), writer)
+	if err != nil {
+		_ = writer.Close()
+		return fmt.Errorf("failed to snapshot store: %w", err)
+	}
+	err = writer.Close()
+	if err != nil {
+		return fmt.Errorf("failed to finish writing snapshot: %w", err)
+	}
+
+	logger.Info("Snapshot complete", "store", config.StorePath)
+	return nil
+}
+
+func RunRestore(ctx *cli.Context) error {
+	config, logger, client, err := setup(ctx)
+	if err != nil {
+		return err
+	}
+
+	var reader io.Reader
+	if client != nil {
+		reader, err = tablestore.NewS3SnapshotReader(context.Background(), client, config.S3Bucket, config.S3Key)
+		if err != nil {
+			return err
+		}
+	} else {
+		file, err := os.Open(config.FilePath)
+		if err != nil {
+			return fmt.Errorf("failed to open snapshot file: %w", err)
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	// Indices are not known to this tool. They are rebuilt the next time the application starts the store.
+	storeConfig := config.StoreConfig(config.StorePath)
+	storeConfig.GarbageCollectionEnabled = false
+	store, err := tablestore.Restore(logger, storeConfig, reader)
+	if err != nil {
+		return fmt.Errorf("failed to restore store: %w", err)
+	}
+	err = store.Shutdown()
+	if err != nil {
+		return fmt.Errorf("failed to shut down restored store: %w", err)
+	}
+
+	logger.Info("Restore complete", "store", config.StorePath)
+	return nil
+}
@@ -0,0 +1,88 @@
+package tablebackup
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigenda/tools/tablebackup/flags"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	LoggerConfig common.LoggerConfig
+	AwsConfig    aws.ClientConfig
+
+	// The path to the directory of the table store.
+	StorePath string
+	// The storage engine of the table store.
+	Backend tablestore.StoreType
+	// The path of a local snapshot file. Empty if the snapshot is in S3.
+	FilePath string
+	// The S3 bucket and key of the snapshot. Empty if the snapshot is a local file.
+	S3Bucket string
+	S3Key    string
+	// The size of each object that a snapshot is split into in S3.
+	S3PartSize int
+
+	// If true, the store is open in another process and a copy of its files is snapshotted.
+	Live bool
+	// The number of times to try copying a live store.
+	LiveCopyAttempts int
+}
+
+func ReadConfig(ctx *cli.Context) *Config {
+	return &Config{
+		AwsConfig:        aws.ReadClientConfig(ctx, flags.FlagPrefix),
+		StorePath:        ctx.GlobalString(flags.StorePathFlag.Name),
+		FilePath:         ctx.GlobalString(flags.FilePathFlag.Name),
+		S3Bucket:         ctx.GlobalString(flags.S3BucketFlag.Name),
+		S3Key:            ctx.GlobalString(flags.S3KeyFlag.Name),
+		S3PartSize:       ctx.GlobalInt(flags.S3PartSizeFlag.Name),
+		Live:             ctx.GlobalBool(flags.LiveFlag.Name),
+		LiveCopyAttempts: ctx.GlobalInt(flags.LiveCopyAttemptsFlag.Name),
+	}
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	config := ReadConfig(ctx)
+	config.LoggerConfig = *loggerConfig
+
+	config.Backend, err = tablestore.ParseStoreType(ctx.GlobalString(flags.BackendFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", flags.BackendFlag.Name, err)
+	}
+
+	useFile := config.FilePath != ""
+	useS3 := config.S3Bucket != "" || config.S3Key != ""
+	if useFile == useS3 {
+		return nil, errors.New("exactly one of a snapshot file or an S3 bucket and key must be provided")
+	}
+	if useS3 && (config.S3Bucket == "" || config.S3Key == "") {
+		return nil, errors.New("both an S3 bucket and an S3 key must be provided")
+	}
+	if config.LiveCopyAttempts < 1 {
+		return nil, errors.New("live copy attempts must be at least 1")
+	}
+	if config.Live && config.Backend != tablestore.LevelDB {
+		// Copies of live stores are made from LevelDB manifests, see CopyLiveStore
+		return nil, fmt.Errorf("live snapshots are only supported for %s stores", tablestore.LevelDB)
+	}
+
+	return config, nil
+}
+
+// StoreConfig returns the config of a table store at the given path, with the storage engine of the table store.
+func (c *Config) StoreConfig(path string) *tablestore.Config {
+	if c.Backend == tablestore.Pebble {
+		return tablestore.DefaultPebbleConfig(path)
+	}
+	return tablestore.DefaultLevelDBConfig(path)
+}
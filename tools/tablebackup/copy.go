@@ -0,0 +1,306 @@
+package tablebackup
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/syndtr/goleveldb/leveldb/journal"
+)
+
+// errFileVanished is returned when a file of a live store is deleted while it is being copied.
+var errFileVanished = errors.New("file was removed during the copy")
+
+// Tags of the fields of a LevelDB manifest record. These are part of the LevelDB file format.
+const (
+	manifestComparer       = 1
+	manifestJournalNum     = 2
+	manifestNextFileNum    = 3
+	manifestSeqNum         = 4
+	manifestCompPtr        = 5
+	manifestDelTable       = 6
+	manifestAddTable       = 7
+	manifestPrevJournalNum = 9
+)
+
+// storeVersion is the set of files that a LevelDB manifest says make up the store.
+type storeVersion struct {
+	// tables are the numbers of the live table files.
+	tables map[uint64]struct{}
+	// journalNum is the number of the oldest journal that hasn't been compacted into tables. Journals with this
+	// number or higher are replayed when the store is opened.
+	journalNum uint64
+	// prevJournalNum is the number of an older journal that must also be replayed, or 0 if there is none.
+	prevJournalNum uint64
+}
+
+// CopyLiveStore copies the files of a LevelDB store that may be open in another process into a temporary directory,
+// and returns the path to the copy. A LevelDB store is described by the manifest named in its CURRENT file, which
+// lists the table files and journals that make up the store. LevelDB never modifies a table file, and only deletes
+// one after a newer manifest no longer refers to it. The copy is therefore made from a single manifest: the manifest
+// is copied and decoded, and then exactly the table files and journals it refers to are copied. Files written after
+// the manifest was copied are left out, and if any file the manifest refers to is deleted before it is copied, the
+// copy is discarded and retried. The caller is responsible for removing the returned directory.
+func CopyLiveStore(logger logging.Logger, path string, attempts int) (string, error) {
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		var copyPath string
+		copyPath, err = os.MkdirTemp("", "tablebackup-")
+		if err != nil {
+			return "", fmt.Errorf("error creating temporary directory: %w", err)
+		}
+
+		err = copyStoreFiles(path, copyPath)
+		if err == nil {
+			return copyPath, nil
+		}
+
+		removeErr := os.RemoveAll(copyPath)
+		if removeErr != nil {
+			logger.Warn("failed to remove temporary directory", "path", copyPath, "err", removeErr)
+		}
+		if !errors.Is(err, errFileVanished) {
+			return "", err
+		}
+		logger.Info("store changed during copy, retrying", "attempt", attempt, "err", err)
+	}
+	return "", fmt.Errorf("failed to copy store after %d attempts: %w", attempts, err)
+}
+
+// copyStoreFiles copies the current manifest of a LevelDB store and the files it refers to.
+func copyStoreFiles(source string, destination string) error {
+	current, err := os.ReadFile(filepath.Join(source, "CURRENT"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no CURRENT file in %s, is it a LevelDB store?", source)
+		}
+		return fmt.Errorf("error reading CURRENT file: %w", err)
+	}
+	manifest := strings.TrimSuffix(string(current), "\n")
+	if !strings.HasPrefix(manifest, "MANIFEST-") || strings.ContainsAny(manifest, "/\\") {
+		return fmt.Errorf("CURRENT file names an invalid manifest %q", manifest)
+	}
+
+	err = copyFile(filepath.Join(source, manifest), filepath.Join(destination, manifest))
+	if err != nil {
+		return err
+	}
+	version, err := readManifest(filepath.Join(destination, manifest))
+	if err != nil {
+		return fmt.Errorf("error reading manifest %s: %w", manifest, err)
+	}
+
+	// Journals are deleted as soon as their content is compacted into a table, while table files live until the next
+	// compaction of their level, so the journals are copied first.
+	journals, err := listJournals(source, version)
+	if err != nil {
+		return err
+	}
+	for _, name := range journals {
+		err = copyFile(filepath.Join(source, name), filepath.Join(destination, name))
+		if err != nil {
+			return err
+		}
+	}
+
+	tables := make([]uint64, 0, len(version.tables))
+	for num := range version.tables {
+		tables = append(tables, num)
+	}
+	sort.Slice(tables, func(i, j int) bool { return tables[i] < tables[j] })
+	for _, num := range tables {
+		err = copyTable(source, destination, num)
+		if err != nil {
+			return err
+		}
+	}
+
+	// The CURRENT file is written last, so that the copy only looks like a store once it is complete.
+	err = os.WriteFile(filepath.Join(destination, "CURRENT"), current, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing CURRENT file: %w", err)
+	}
+	return nil
+}
+
+// copyTable copies a table file, which is named either NNNNNN.ldb or, by older versions of LevelDB, NNNNNN.sst.
+func copyTable(source string, destination string, num uint64) error {
+	name := fmt.Sprintf("%06d.ldb", num)
+	err := copyFile(filepath.Join(source, name), filepath.Join(destination, name))
+	if !errors.Is(err, errFileVanished) {
+		return err
+	}
+	oldName := fmt.Sprintf("%06d.sst", num)
+	err = copyFile(filepath.Join(source, oldName), filepath.Join(destination, oldName))
+	if errors.Is(err, errFileVanished) {
+		return fmt.Errorf("%w: table %s", errFileVanished, name)
+	}
+	return err
+}
+
+// listJournals returns the names of the journals of a store that are replayed when it is opened at the given version.
+// Returns errFileVanished if the journal the version starts from no longer exists.
+func listJournals(source string, version *storeVersion) ([]string, error) {
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return nil, fmt.Errorf("error reading store directory %s: %w", source, err)
+	}
+
+	journals := make([]string, 0)
+	foundCurrent := false
+	for _, entry := range entries {
+		var num uint64
+		var extension string
+		if _, err := fmt.Sscanf(entry.Name(), "%d.%s", &num, &extension); err != nil || extension != "log" {
+			continue
+		}
+		if num < version.journalNum && num != version.prevJournalNum {
+			continue
+		}
+		if num == version.journalNum {
+			foundCurrent = true
+		}
+		journals = append(journals, entry.Name())
+	}
+	if version.journalNum != 0 && !foundCurrent {
+		return nil, fmt.Errorf("%w: journal %06d.log", errFileVanished, version.journalNum)
+	}
+	return journals, nil
+}
+
+// readManifest replays the records of a LevelDB manifest. Like LevelDB, it skips corrupted records, such as a record
+// that was being appended when the manifest was copied.
+func readManifest(path string) (*storeVersion, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	version := &storeVersion{
+		tables: make(map[uint64]struct{}),
+	}
+	reader := journal.NewReader(file, nil, false, true)
+	for {
+		record, err := reader.Next()
+		if err == io.EOF {
+			return version, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(record)
+		if err != nil {
+			continue
+		}
+		err = applyManifestRecord(version, data)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// applyManifestRecord applies the changes recorded in a manifest record to a version. Like LevelDB, the deleted
+// tables of a record are removed before its added tables are added, since a table that is moved to another level is
+// both deleted and added by the same record.
+func applyManifestRecord(version *storeVersion, data []byte) error {
+	reader := bytes.NewReader(data)
+	readNumber := func() (uint64, error) {
+		return binary.ReadUvarint(reader)
+	}
+	skipBytes := func() error {
+		n, err := readNumber()
+		if err != nil {
+			return err
+		}
+		if n > uint64(reader.Len()) {
+			return io.ErrUnexpectedEOF
+		}
+		_, err = reader.Seek(int64(n), io.SeekCurrent)
+		return err
+	}
+
+	added := make([]uint64, 0)
+	for reader.Len() > 0 {
+		tag, err := readNumber()
+		if err != nil {
+			return fmt.Errorf("corrupted manifest record: %w", err)
+		}
+		switch tag {
+		case manifestComparer:
+			err = skipBytes()
+		case manifestJournalNum:
+			version.journalNum, err = readNumber()
+		case manifestPrevJournalNum:
+			version.prevJournalNum, err = readNumber()
+		case manifestNextFileNum, manifestSeqNum:
+			_, err = readNumber()
+		case manifestCompPtr:
+			if _, err = readNumber(); err == nil {
+				err = skipBytes()
+			}
+		case manifestDelTable:
+			var num uint64
+			if _, err = readNumber(); err == nil {
+				num, err = readNumber()
+				delete(version.tables, num)
+			}
+		case manifestAddTable:
+			var num uint64
+			if _, err = readNumber(); err == nil {
+				num, err = readNumber()
+			}
+			if err == nil {
+				_, err = readNumber()
+			}
+			if err == nil {
+				err = skipBytes()
+			}
+			if err == nil {
+				err = skipBytes()
+			}
+			added = append(added, num)
+		default:
+			return fmt.Errorf("unknown manifest record field %d", tag)
+		}
+		if err != nil {
+			return fmt.Errorf("corrupted manifest record field %d: %w", tag, err)
+		}
+	}
+
+	for _, num := range added {
+		version.tables[num] = struct{}{}
+	}
+	return nil
+}
+
+// copyFile copies a single file. Returns errFileVanished if the source file does not exist.
+func copyFile(source string, destination string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", errFileVanished, source)
+		}
+		return fmt.Errorf("error opening %s: %w", source, err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(destination)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", destination, err)
+	}
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		_ = out.Close()
+		return fmt.Errorf("error copying %s: %w", source, err)
+	}
+	return out.Close()
+}
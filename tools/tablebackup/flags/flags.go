@@ -0,0 +1,101 @@
+package flags
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = ""
+	envPrefix  = "TABLEBACKUP"
+)
+
+var (
+	/* Required Flags*/
+	StorePathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "store-path"),
+		Usage:    "Path to the directory of the table store",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "STORE_PATH"),
+	}
+	/* Optional Flags*/
+	BackendFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "backend"),
+		Usage:    "Storage engine of the table store, either leveldb or pebble",
+		Required: false,
+		Value:    "leveldb",
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BACKEND"),
+	}
+	FilePathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "file"),
+		Usage:    "Path of the local snapshot file to write or read. Either this or the S3 bucket and key must be set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "FILE"),
+	}
+	S3BucketFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-bucket"),
+		Usage:    "S3 bucket of the snapshot",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "S3_BUCKET"),
+	}
+	S3KeyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-key"),
+		Usage:    "S3 key of the snapshot. The snapshot is stored as several objects under this key",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "S3_KEY"),
+	}
+	S3PartSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-part-size"),
+		Usage:    "Size in bytes of each object that a snapshot is split into when uploaded to S3",
+		Required: false,
+		Value:    tablestore.DefaultS3SnapshotPartSize,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "S3_PART_SIZE"),
+	}
+	LiveFlag = cli.BoolFlag{
+		Name: common.PrefixFlag(FlagPrefix, "live"),
+		Usage: "Snapshot a store that is open in another process, by snapshotting a copy of its files. " +
+			"Only applies to snapshots, and only supported for leveldb stores",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "LIVE"),
+	}
+	LiveCopyAttemptsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "live-copy-attempts"),
+		Usage:    "Number of times to try copying a live store, since files may be compacted away during the copy",
+		Required: false,
+		Value:    5,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "LIVE_COPY_ATTEMPTS"),
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	StorePathFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	BackendFlag,
+	FilePathFlag,
+	S3BucketFlag,
+	S3KeyFlag,
+	S3PartSizeFlag,
+	LiveFlag,
+	LiveCopyAttemptsFlag,
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(requiredFlags, optionalFlags...)
+	Flags = append(Flags, common.LoggerCLIFlags(envPrefix, FlagPrefix)...)
+
+	// S3 is optional for this tool, so none of the AWS flags are required.
+	for _, flag := range aws.ClientFlags(envPrefix, FlagPrefix) {
+		if stringFlag, ok := flag.(cli.StringFlag); ok {
+			stringFlag.Required = false
+			flag = stringFlag
+		}
+		Flags = append(Flags, flag)
+	}
+}